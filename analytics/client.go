@@ -2,8 +2,6 @@ package analytics
 
 import (
 	"fmt"
-	"io"
-	"net/http"
 	"runtime"
 	"strings"
 	"sync"
@@ -16,7 +14,8 @@ import (
 )
 
 // Client represents a Google Analytics client. Hits are submitted to
-// GA in batches of 20 to avoid overloading the network.
+// the config's endpoint (GA by default) in batches of 20 (or the config's
+// batch size) to avoid overloading the network.
 //
 // Screenview queues a screenview hit, while Event queues an event hit.
 // Params represents additional measurement protocol parameters to
@@ -44,11 +43,28 @@ func NewClient(config Config) Client {
 		log.Debugf("Analytics opt-out is set, analytics will be disabled")
 		return &noopClient{}
 	}
+	sink, err := newSink(config)
+	if err != nil {
+		log.Warnf("Analytics will be disabled: %v", err)
+		return &noopClient{}
+	}
+	if config.Offline {
+		log.Debugf("Analytics is offline, hits will not be submitted over the network")
+	}
 	client := &client{
-		userID: config.UserID,
+		userID:    config.UserID,
+		sink:      sink,
+		batchSize: config.BatchSize,
+	}
+	if client.batchSize <= 0 {
+		client.batchSize = maxHits
+	}
+	flushInterval := config.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = 1 * time.Minute
 	}
 	// Periodically flush queued analytics hits
-	ticker := time.NewTicker(1 * time.Minute)
+	ticker := time.NewTicker(flushInterval)
 	go func() {
 		for {
 			<-ticker.C
@@ -58,10 +74,13 @@ func NewClient(config Config) Client {
 	return client
 }
 
+// maxHits is the default batch size
 const maxHits = 20
 
 type client struct {
 	userID     uuid.UUID
+	sink       sink
+	batchSize  int
 	queueLock  sync.Mutex
 	queuedHits []Params
 }
@@ -95,7 +114,7 @@ func (c *client) Flush() {
 func (c *client) enqueue(hit Params) {
 	c.queueLock.Lock()
 	defer c.queueLock.Unlock()
-	if len(c.queuedHits) >= maxHits {
+	if len(c.queuedHits) >= c.batchSize {
 		c.flush()
 	}
 	c.queuedHits = append(c.queuedHits, hit)
@@ -105,9 +124,7 @@ func (c *client) flush() {
 	if len(c.queuedHits) <= 0 {
 		return
 	}
-	// According to https://developers.google.com/analytics/devguides/collection/protocol/v1/devguide#batch,
-	// each line in the batch request's body represents a single hit. Thus, we log
-	// something like
+	// We log something like
 	//     Submitting analytics... (<base_params>)
 	//     Payload:
 	//       <first_hit>
@@ -117,23 +134,15 @@ func (c *client) flush() {
 	baseParams := c.baseParams()
 	var logMsg strings.Builder
 	fmt.Fprintf(&logMsg, "Submitting analytics... (%v)\nPayload:\n", baseParams)
-	var payload []string
-	for _, hit := range c.queuedHits {
-		payload = append(payload, hit.merge(baseParams).encode())
+	hits := make([]Params, len(c.queuedHits))
+	for i, hit := range c.queuedHits {
 		fmt.Fprintf(&logMsg, "  %v\n", hit)
+		hits[i] = hit.merge(baseParams)
 	}
 	log.Debug(logMsg.String())
-	body := strings.Join(payload, "\n")
-	// The Measurement Protocol's docs indicate that the endpoint
-	// will always return a 200 OK status, even if the request
-	// contains any errors. Thus, the response is useless so it is
-	// OK for us to ignore it.
-	_, err := httpClient.post(
-		"https://www.google-analytics.com/batch",
-		"application/x-www-form-urlencoded",
-		strings.NewReader(body),
-	)
-	if err != nil {
+	if err := c.sink.submit(hits); err == errOffline {
+		log.Debugf("Skipping submission of the analytics because analytics is offline")
+	} else if err != nil {
 		log.Infof("Failed to send analytics: %v", err)
 	}
 	c.queuedHits = c.queuedHits[:0]
@@ -297,18 +306,3 @@ func (s screenview) ToHit() Params {
 func (s screenview) String() string {
 	return fmt.Sprintf("'%v' screenview (%v)", s.name, s.params)
 }
-
-// The code below makes it possible for the tests to mock the
-// HTTP client
-
-type httpClientI interface {
-	post(string, string, io.Reader) (*http.Response, error)
-}
-
-type httpClientImpl struct{}
-
-func (httpClientImpl) post(url string, contentType string, body io.Reader) (*http.Response, error) {
-	return http.Post(url, contentType, body)
-}
-
-var httpClient httpClientI = httpClientImpl{}
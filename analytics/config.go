@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"gopkg.in/yaml.v2"
@@ -15,6 +16,14 @@ import (
 type Config struct {
 	Disabled bool      `yaml:"disabled"`
 	UserID   uuid.UUID `yaml:"user-id"`
+	// Offline guarantees that hits are never submitted over the network.
+	// They're still submitted to file endpoints.
+	Offline bool `yaml:"offline,omitempty"`
+	// Endpoint is the http(s) or file URL that hits are submitted to. It
+	// defaults to DefaultEndpoint.
+	Endpoint      string        `yaml:"endpoint,omitempty"`
+	BatchSize     int           `yaml:"batch-size,omitempty"`
+	FlushInterval time.Duration `yaml:"flush-interval,omitempty"`
 }
 
 // GetConfig returns Wash's analytics config, which is located at
@@ -45,6 +54,10 @@ func GetConfig() (Config, error) {
 	if err != nil && !os.IsNotExist(err) {
 		return config, newConfigReadErr(analyticsConfigFile, err)
 	}
+	fileConfig := config
+	if err := config.applyEnv(); err != nil {
+		return config, err
+	}
 	if config.Disabled || config.UserID != uuid.Nil {
 		return config, nil
 	}
@@ -64,8 +77,10 @@ func GetConfig() (Config, error) {
 	} else {
 		config.UserID = uuid.New()
 	}
-	// Now write the config
-	bytes, err := yaml.Marshal(config)
+	// Now write the config. Only the user ID is written back so that the
+	// environment's settings aren't persisted.
+	fileConfig.UserID = config.UserID
+	bytes, err := yaml.Marshal(fileConfig)
 	if err != nil {
 		// This should never happen
 		return config, fmt.Errorf("could not marshal the analytics config: %v", err)
@@ -78,6 +93,22 @@ func GetConfig() (Config, error) {
 	return config, ioutil.WriteFile(analyticsConfigFile, bytes, 0644)
 }
 
+// applyEnv overrides the config with the WASH_ANALYTICS_OFFLINE and
+// WASH_ANALYTICS_ENDPOINT environment variables.
+func (config *Config) applyEnv() error {
+	if offlineStr, ok := os.LookupEnv("WASH_ANALYTICS_OFFLINE"); ok {
+		offline, err := strconv.ParseBool(offlineStr)
+		if err != nil {
+			return fmt.Errorf("WASH_ANALYTICS_OFFLINE is set to %v. Valid values are 'true' or 'false'", offlineStr)
+		}
+		config.Offline = offline
+	}
+	if endpoint, ok := os.LookupEnv("WASH_ANALYTICS_ENDPOINT"); ok {
+		config.Endpoint = endpoint
+	}
+	return nil
+}
+
 func readAnalyticsConfigFile(path string) (Config, error) {
 	rawConfig, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -0,0 +1,127 @@
+package analytics
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultEndpoint is Google Analytics' batch endpoint. Hits are submitted
+// to it unless the config specifies a different endpoint.
+const DefaultEndpoint = "https://www.google-analytics.com/batch"
+
+// sink submits a batch of hits. Each hit already includes the base params.
+type sink interface {
+	submit(hits []Params) error
+}
+
+// newSink returns the sink for the config's endpoint. http(s) endpoints
+// receive the hits in the Measurement Protocol's batch format, e.g. an
+// internal telemetry collector. file endpoints append each hit as a line to
+// a local file, which is useful for auditing what would be submitted.
+func newSink(config Config) (sink, error) {
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %v: %v", endpoint, err)
+	}
+	switch u.Scheme {
+	case "http", "https":
+		client := httpClient
+		if config.Offline {
+			// Enforce offline mode where the network would be used so that it
+			// can't be bypassed by a sink that forgets to check it.
+			client = offlineHTTPClient{}
+		}
+		return &httpSink{url: endpoint, client: client}, nil
+	case "file":
+		path := u.Path
+		if u.Host != "" {
+			// Treat file://relative/path as a relative path.
+			path = filepath.Join(u.Host, path)
+		}
+		return &fileSink{path: path}, nil
+	default:
+		return nil, fmt.Errorf("invalid endpoint %v: the scheme must be http, https or file", endpoint)
+	}
+}
+
+type httpSink struct {
+	url    string
+	client httpClientI
+}
+
+func (s *httpSink) submit(hits []Params) error {
+	// According to https://developers.google.com/analytics/devguides/collection/protocol/v1/devguide#batch,
+	// each line in the batch request's body represents a single hit.
+	var payload []string
+	for _, hit := range hits {
+		payload = append(payload, hit.encode())
+	}
+	// The Measurement Protocol's docs indicate that the endpoint
+	// will always return a 200 OK status, even if the request
+	// contains any errors. Thus, the response is useless so it is
+	// OK for us to ignore it.
+	resp, err := s.client.post(s.url, "application/x-www-form-urlencoded", strings.NewReader(strings.Join(payload, "\n")))
+	if err == nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	return err
+}
+
+type fileSink struct {
+	path string
+	mux  sync.Mutex
+}
+
+func (s *fileSink) submit(hits []Params) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if err := os.MkdirAll(filepath.Dir(s.path), 0750); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	for _, hit := range hits {
+		if _, err := fmt.Fprintln(f, hit.encode()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The code below makes it possible for the tests to mock the
+// HTTP client
+
+type httpClientI interface {
+	post(string, string, io.Reader) (*http.Response, error)
+}
+
+type httpClientImpl struct{}
+
+func (httpClientImpl) post(url string, contentType string, body io.Reader) (*http.Response, error) {
+	return http.Post(url, contentType, body)
+}
+
+var httpClient httpClientI = httpClientImpl{}
+
+var errOffline = errors.New("analytics is offline")
+
+// offlineHTTPClient refuses to make any requests.
+type offlineHTTPClient struct{}
+
+func (offlineHTTPClient) post(string, string, io.Reader) (*http.Response, error) {
+	return nil, errOffline
+}
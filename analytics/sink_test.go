@@ -0,0 +1,83 @@
+package analytics
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewSink(t *testing.T) {
+	s, err := newSink(Config{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, &httpSink{url: DefaultEndpoint, client: httpClient}, s)
+	}
+
+	s, err = newSink(Config{Endpoint: "http://telemetry.example.com/collect"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, &httpSink{url: "http://telemetry.example.com/collect", client: httpClient}, s)
+	}
+
+	s, err = newSink(Config{Endpoint: "file:///tmp/analytics.log"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, &fileSink{path: "/tmp/analytics.log"}, s)
+	}
+
+	_, err = newSink(Config{Endpoint: "ftp://example.com"})
+	assert.EqualError(t, err, "invalid endpoint ftp://example.com: the scheme must be http, https or file")
+}
+
+func TestOfflineClient_NeverUsesTheNetwork(t *testing.T) {
+	oldHTTPClient := httpClient
+	defer func() { httpClient = oldHTTPClient }()
+	mockClient := &mockHTTPClient{}
+	httpClient = mockClient
+
+	c := NewClient(Config{UserID: uuid.New(), Offline: true, Endpoint: "https://telemetry.example.com"})
+	assert.NoError(t, c.Screenview("foo", Params{}))
+	c.Flush()
+	mockClient.AssertNotCalled(t, "post", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err := c.(*client).sink.(*httpSink).client.post("https://telemetry.example.com", "", nil)
+	assert.Equal(t, errOffline, err)
+}
+
+func TestClient_CustomEndpointAndBatchSize(t *testing.T) {
+	oldHTTPClient := httpClient
+	defer func() { httpClient = oldHTTPClient }()
+	mockClient := &mockHTTPClient{}
+	httpClient = mockClient
+	mockClient.On("post", "https://telemetry.example.com", mock.Anything, mock.Anything).Return(&http.Response{}, nil)
+
+	c := NewClient(Config{UserID: uuid.New(), Endpoint: "https://telemetry.example.com", BatchSize: 2})
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, c.Screenview("foo", Params{}))
+	}
+	mockClient.AssertNotCalled(t, "post", mock.Anything, mock.Anything, mock.Anything)
+	// The batch is full, so it's submitted before the third hit's queued.
+	assert.NoError(t, c.Screenview("foo", Params{}))
+	mockClient.AssertNumberOfCalls(t, "post", 1)
+}
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wash-analytics")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "analytics", "hits.log")
+	s := &fileSink{path: path}
+	assert.NoError(t, s.submit([]Params{{"t": "screenview", "cd": "foo"}}))
+	assert.NoError(t, s.submit([]Params{{"t": "event", "ec": "Invocation"}}))
+
+	content, err := ioutil.ReadFile(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "cd=foo&t=screenview\nec=Invocation&t=event\n", string(content))
+	}
+}
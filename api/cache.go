@@ -1,9 +1,12 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
@@ -13,7 +16,11 @@ import (
 //
 // Remove items from the cache
 //
-// Removes the specified entry and its children from the cache.
+// Removes the specified entry and its children from the cache. If glob is
+// true, the path is a glob and every cached entry that matches it is removed.
+// The op parameter (which can be repeated) restricts clearing to the results
+// of the List, Read or Metadata ops. If rewarm is true, the cleared List and
+// Metadata results are fetched again before responding.
 //
 //     Produces:
 //     - application/json
@@ -22,6 +29,7 @@ import (
 //
 //     Responses:
 //       200:
+//       400: errorResp
 //       500: errorResp
 var cacheHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
 	path, errResp := getWashPathFromRequest(r)
@@ -29,12 +37,102 @@ var cacheHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *err
 		return errResp
 	}
 
-	deleted := plugin.ClearCacheFor(path, true)
+	query := r.URL.Query()
+	ops := query["op"]
+	var deleted []string
+	var err error
+	if query.Get("glob") == "true" {
+		deleted, err = plugin.ClearCacheForGlob(path, ops)
+	} else if len(ops) > 0 {
+		deleted, err = plugin.ClearCacheForOps(path, ops)
+	} else {
+		deleted = plugin.ClearCacheFor(path, true)
+	}
+	if err != nil {
+		return badRequestResponse(err.Error())
+	}
 	activity.Record(r.Context(), "API: Cache DELETE %v %+v", path, deleted)
 
+	if query.Get("rewarm") == "true" {
+		rewarmCache(r.Context(), deleted)
+	}
+
 	jsonEncoder := json.NewEncoder(w)
 	if err := jsonEncoder.Encode(deleted); err != nil {
 		return unknownErrorResponse(fmt.Errorf("Could not marshal deleted keys for %v: %v", path, err))
 	}
 	return nil
 }}
+
+// rewarmCache re-fetches the List and Metadata results of the deleted cache keys. Parents are
+// listed before their children so that finding the children reuses their lists.
+func rewarmCache(ctx context.Context, deleted []string) {
+	ops := make(map[string][]string)
+	var paths []string
+	for _, key := range deleted {
+		segments := strings.SplitN(key, "::", 2)
+		if len(segments) != 2 || (segments[0] != "List" && segments[0] != "Metadata") {
+			continue
+		}
+		op, path := segments[0], segments[1]
+		if _, ok := ops[path]; !ok {
+			paths = append(paths, path)
+		}
+		ops[path] = append(ops[path], op)
+	}
+	sort.Strings(paths)
+
+	mountpoint := ctx.Value(mountpointKey).(string)
+	for _, path := range paths {
+		entry, _, errResp := getEntryFromPath(ctx, mountpoint+path)
+		if errResp != nil {
+			// The entry may no longer exist
+			activity.Record(ctx, "API: Cache rewarm %v: %v", path, errResp)
+			continue
+		}
+		for _, op := range ops[path] {
+			var err error
+			switch op {
+			case "List":
+				if parent, ok := entry.(plugin.Parent); ok {
+					_, err = plugin.List(ctx, parent)
+				}
+			case "Metadata":
+				_, err = plugin.Metadata(ctx, entry)
+			}
+			if err != nil {
+				activity.Warnf(ctx, "API: Cache rewarm %v %v: %v", op, path, err)
+			}
+		}
+	}
+}
+
+// swagger:route GET /cache cache cacheState
+//
+// Cache state of an entry
+//
+// Returns the cache state of the entry's List, Read, and Metadata ops,
+// keyed by the op's name.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http
+//
+//     Responses:
+//       200:
+//       400: errorResp
+//       404: errorResp
+//       500: errorResp
+var cacheStateHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	entry, path, errResp := getEntryFromRequest(r)
+	if errResp != nil {
+		return errResp
+	}
+
+	jsonEncoder := json.NewEncoder(w)
+	if err := jsonEncoder.Encode(plugin.CacheStateOf(entry)); err != nil {
+		return unknownErrorResponse(fmt.Errorf("Could not marshal the cache state of %v: %v", path, err))
+	}
+	return nil
+}}
@@ -19,28 +19,50 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/Benchkram/errz"
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/analytics"
 	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/slowlog"
 )
 
 // Client represents a Wash API client.
 type Client interface {
 	Info(path string) (apitypes.Entry, error)
+	InfoWithActivity(path string) (apitypes.Entry, error)
 	List(path string) ([]apitypes.Entry, error)
+	ListStream(path string) (<-chan apitypes.ListPacket, error)
+	ListPage(path string, limit int, continuationToken string) (apitypes.ListPage, error)
 	Metadata(path string) (map[string]interface{}, error)
+	DecompressedMetadata(path string) (map[string]interface{}, error)
 	Stream(path string) (io.ReadCloser, error)
+	Read(path string) (io.ReadCloser, error)
+	ReadDecompressed(path string) (io.ReadCloser, error)
+	Write(path string, content io.Reader) error
+	WriteCompressed(path string, content io.Reader) error
+	Copy(src string, dst string) error
 	Exec(path string, command string, args []string, opts apitypes.ExecOptions) (<-chan apitypes.ExecPacket, error)
+	Search(path string, pattern string) ([]plugin.SearchMatch, error)
 	History(bool) (chan apitypes.Activity, error)
-	ActivityJournal(index int, follow bool) (io.ReadCloser, error)
-	Clear(path string) ([]string, error)
+	ActivityJournal(index int, opts apitypes.JournalOptions) (io.ReadCloser, error)
+	Clear(path string, opts apitypes.ClearOptions) ([]string, error)
+	CacheState(path string) (map[string]plugin.OpCacheState, error)
 	// A "nil" schema means that the schema's unknown.
 	Schema(path string) (*apitypes.EntrySchema, error)
 	Screenview(name string, params analytics.Params) error
 	Delete(path string) (bool, error)
 	Signal(path string, signal string) error
+	Rename(path string, newName string) error
+	Create(path string, name string, attr plugin.EntryAttributes) (apitypes.Entry, error)
+	SetAttr(path string, attr plugin.EntryAttributes) error
+	Metrics() ([]plugin.MethodStats, error)
+	SlowOperations(limit int) ([]slowlog.Offender, error)
+	Plugins() ([]plugin.PluginStatus, error)
+	EnablePlugin(name string) error
+	DisablePlugin(name string) error
 }
 
 // A domainSocketClient is a wash API client.
@@ -145,6 +167,18 @@ func (c *domainSocketClient) Info(path string) (apitypes.Entry, error) {
 	return e, nil
 }
 
+// InfoWithActivity retrieves the information of the resource located at "path",
+// including its recent method invocations.
+func (c *domainSocketClient) InfoWithActivity(path string) (apitypes.Entry, error) {
+	var e apitypes.Entry
+	params := url.Values{"path": []string{path}, "activity": []string{"true"}}
+	if err := c.getRequest("/fs/info", params, &e); err != nil {
+		return e, err
+	}
+
+	return e, nil
+}
+
 // List lists the resources located at "path".
 func (c *domainSocketClient) List(path string) ([]apitypes.Entry, error) {
 	var ls []apitypes.Entry
@@ -155,6 +189,56 @@ func (c *domainSocketClient) List(path string) ([]apitypes.Entry, error) {
 	return ls, nil
 }
 
+// ListStream lists the resources located at "path", sending each one as
+// soon as the server's listed it. This is useful for huge directories. The
+// resources aren't sorted. The channel's closed once they've all been sent.
+// If the list fails partway through, then the last packet contains the error.
+func (c *domainSocketClient) ListStream(path string) (<-chan apitypes.ListPacket, error) {
+	params := url.Values{"path": []string{path}, "stream": []string{"true"}}
+	respBody, err := c.doRequest(http.MethodGet, "/fs/list", params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	packets := make(chan apitypes.ListPacket, 1)
+	go func() {
+		defer func() { errz.Log(respBody.Close()) }()
+		defer close(packets)
+		decoder := json.NewDecoder(respBody)
+		for {
+			var pkt apitypes.ListPacket
+			if err := decoder.Decode(&pkt); err == io.EOF {
+				return
+			} else if err != nil {
+				packets <- apitypes.ListPacket{Err: &apitypes.ErrorObj{
+					Kind: apitypes.UnknownError,
+					Msg:  fmt.Sprintf("could not decode the list results: %v", err),
+				}}
+				return
+			}
+			packets <- pkt
+		}
+	}()
+	return packets, nil
+}
+
+// ListPage lists up to limit of the resources located at "path", starting
+// from the page identified by continuationToken. The first page's token is
+// empty. The returned page's ContinuationToken identifies the next page, and
+// it's empty once there aren't any more resources.
+func (c *domainSocketClient) ListPage(path string, limit int, continuationToken string) (apitypes.ListPage, error) {
+	params := url.Values{"path": []string{path}, "limit": []string{strconv.Itoa(limit)}}
+	if continuationToken != "" {
+		params.Set("continuation-token", continuationToken)
+	}
+	var page apitypes.ListPage
+	if err := c.getRequest("/fs/list", params, &page); err != nil {
+		return page, err
+	}
+
+	return page, nil
+}
+
 // Metadata gets the metadata of the resource located at "path".
 func (c *domainSocketClient) Metadata(path string) (map[string]interface{}, error) {
 	var metadata map[string]interface{}
@@ -165,6 +249,18 @@ func (c *domainSocketClient) Metadata(path string) (map[string]interface{}, erro
 	return metadata, nil
 }
 
+// DecompressedMetadata is Metadata that also includes the "compression" format
+// and "decompressed_size" of the resource's content if it's compressed.
+func (c *domainSocketClient) DecompressedMetadata(path string) (map[string]interface{}, error) {
+	var metadata map[string]interface{}
+	params := url.Values{"path": []string{path}, "decompress": []string{"true"}}
+	if err := c.getRequest("/fs/metadata", params, &metadata); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
 // Stream updates for the resource located at "path".
 func (c *domainSocketClient) Stream(path string) (io.ReadCloser, error) {
 	respBody, err := c.doRequest(http.MethodGet, "/fs/stream", url.Values{"path": []string{path}}, nil)
@@ -175,6 +271,57 @@ func (c *domainSocketClient) Stream(path string) (io.ReadCloser, error) {
 	return respBody, nil
 }
 
+// Read the content of the resource located at "path".
+func (c *domainSocketClient) Read(path string) (io.ReadCloser, error) {
+	return c.doRequest(http.MethodGet, "/fs/read", url.Values{"path": []string{path}}, nil)
+}
+
+// ReadDecompressed reads the content of the resource located at "path",
+// decompressing it if it's gzip or zstd compressed.
+func (c *domainSocketClient) ReadDecompressed(path string) (io.ReadCloser, error) {
+	params := url.Values{"path": []string{path}, "decompress": []string{"true"}}
+	return c.doRequest(http.MethodGet, "/fs/read", params, nil)
+}
+
+// Write replaces the content of the resource located at "path".
+func (c *domainSocketClient) Write(path string, content io.Reader) error {
+	respBody, err := c.doRequest(http.MethodPost, "/fs/write", url.Values{"path": []string{path}}, content)
+	if err != nil {
+		return err
+	}
+	return respBody.Close()
+}
+
+// WriteCompressed replaces the content of the resource located at "path",
+// compressing it with the compression format of the resource's current
+// content. Use it to write back content that was read with ReadDecompressed.
+func (c *domainSocketClient) WriteCompressed(path string, content io.Reader) error {
+	params := url.Values{"path": []string{path}, "compress": []string{"true"}}
+	respBody, err := c.doRequest(http.MethodPost, "/fs/write", params, content)
+	if err != nil {
+		return err
+	}
+	return respBody.Close()
+}
+
+// Copy replaces the content of the resource located at "dst" with the content
+// of the resource located at "src". The content is copied by the server.
+func (c *domainSocketClient) Copy(src string, dst string) error {
+	src, err := filepath.Abs(src)
+	if err != nil {
+		return fmt.Errorf("could not calculate the absolute path of %v: %v", src, err)
+	}
+	jsonBody, err := json.Marshal(apitypes.CopyBody{Source: src})
+	if err != nil {
+		return err
+	}
+	respBody, err := c.doRequest(http.MethodPost, "/fs/copy", url.Values{"path": []string{dst}}, bytes.NewReader(jsonBody))
+	if err != nil {
+		return err
+	}
+	return respBody.Close()
+}
+
 // Exec invokes the given command + args on the resource located at "path".
 //
 // The resulting channel contains events, ordered as we receive them from the
@@ -214,6 +361,16 @@ func (c *domainSocketClient) Exec(path string, command string, args []string, op
 	return events, nil
 }
 
+// Search returns the lines of the content of the entry at "path", or of its
+// descendants' content, that match pattern, a Go regular expression.
+func (c *domainSocketClient) Search(path string, pattern string) ([]plugin.SearchMatch, error) {
+	var matches []plugin.SearchMatch
+	if err := c.getRequest("/fs/search", url.Values{"path": []string{path}, "pattern": []string{pattern}}, &matches); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
 // History returns a command history channel for the current wash server session.
 // If follow is false, it closes when all current activity has been delivered.
 func (c *domainSocketClient) History(follow bool) (chan apitypes.Activity, error) {
@@ -246,18 +403,44 @@ func (c *domainSocketClient) History(follow bool) (chan apitypes.Activity, error
 }
 
 // ActivityJournal returns a reader for the journal associated with a particular command in history.
-// If follow is true, it streams new updates instead of returning the whole journal.
-func (c *domainSocketClient) ActivityJournal(index int, follow bool) (io.ReadCloser, error) {
-	var params url.Values
-	if follow {
-		params = url.Values{"follow": []string{"true"}}
+// If opts.Follow is true, it streams new updates instead of returning the whole journal. The other
+// options filter the journal's records.
+func (c *domainSocketClient) ActivityJournal(index int, opts apitypes.JournalOptions) (io.ReadCloser, error) {
+	params := url.Values{}
+	if opts.Follow {
+		params.Set("follow", "true")
+	}
+	if !opts.Since.IsZero() {
+		params.Set("since", opts.Since.Format(time.RFC3339Nano))
+	}
+	if !opts.Until.IsZero() {
+		params.Set("until", opts.Until.Format(time.RFC3339Nano))
+	}
+	for _, path := range opts.Paths {
+		params.Add("path", path)
+	}
+	if opts.Action != "" {
+		params.Set("action", opts.Action)
+	}
+	if opts.ErrorsOnly {
+		params.Set("errors", "true")
 	}
 	return c.doRequest(http.MethodGet, "/history/"+strconv.Itoa(index), params, nil)
 }
 
 // Clear the cache at "path".
-func (c *domainSocketClient) Clear(path string) ([]string, error) {
-	respBody, err := c.doRequest(http.MethodDelete, "/cache", url.Values{"path": []string{path}}, nil)
+func (c *domainSocketClient) Clear(path string, opts apitypes.ClearOptions) ([]string, error) {
+	params := url.Values{"path": []string{path}}
+	if opts.Glob {
+		params.Set("glob", "true")
+	}
+	for _, op := range opts.Ops {
+		params.Add("op", op)
+	}
+	if opts.Rewarm {
+		params.Set("rewarm", "true")
+	}
+	respBody, err := c.doRequest(http.MethodDelete, "/cache", params, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -276,6 +459,15 @@ func (c *domainSocketClient) Clear(path string) ([]string, error) {
 	return result, nil
 }
 
+// CacheState returns the cache state of the entry's ops at "path".
+func (c *domainSocketClient) CacheState(path string) (map[string]plugin.OpCacheState, error) {
+	var state map[string]plugin.OpCacheState
+	if err := c.getRequest("/cache", url.Values{"path": []string{path}}, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
 // Schema returns the entry's schema
 func (c *domainSocketClient) Schema(path string) (*apitypes.EntrySchema, error) {
 	var schema *apitypes.EntrySchema
@@ -316,3 +508,93 @@ func (c *domainSocketClient) Signal(path string, signal string) error {
 	_, err = c.doRequest(http.MethodPost, "/fs/signal", url.Values{"path": []string{path}}, bytes.NewReader(jsonBody))
 	return err
 }
+
+// Rename renames the entry at "path" to newName within its parent
+func (c *domainSocketClient) Rename(path string, newName string) error {
+	jsonBody, err := json.Marshal(apitypes.RenameBody{Name: newName})
+	if err != nil {
+		return err
+	}
+	respBody, err := c.doRequest(http.MethodPost, "/fs/rename", url.Values{"path": []string{path}}, bytes.NewReader(jsonBody))
+	if err != nil {
+		return err
+	}
+	return respBody.Close()
+}
+
+// Create creates a child named name of the entry at "path" with the given
+// attributes. The child is a parent (e.g. a directory) if attr's mode is a
+// directory. It returns the new child.
+func (c *domainSocketClient) Create(path string, name string, attr plugin.EntryAttributes) (apitypes.Entry, error) {
+	var e apitypes.Entry
+	jsonBody, err := json.Marshal(apitypes.CreateBody{Name: name, Attributes: attr})
+	if err != nil {
+		return e, err
+	}
+	err = c.doRequestAndParseJSONBody(http.MethodPost, "/fs/create", url.Values{"path": []string{path}}, bytes.NewReader(jsonBody), &e)
+	return e, err
+}
+
+// SetAttr sets the mode, atime and/or mtime of the entry at "path" to the ones in attr
+func (c *domainSocketClient) SetAttr(path string, attr plugin.EntryAttributes) error {
+	jsonBody, err := json.Marshal(attr)
+	if err != nil {
+		return err
+	}
+	respBody, err := c.doRequest(http.MethodPatch, "/fs/attributes", url.Values{"path": []string{path}}, bytes.NewReader(jsonBody))
+	if err != nil {
+		return err
+	}
+	return respBody.Close()
+}
+
+// Metrics returns the stats of each plugin's method invocations.
+func (c *domainSocketClient) Metrics() ([]plugin.MethodStats, error) {
+	var metrics []plugin.MethodStats
+	if err := c.getRequest("/metrics", url.Values{"format": []string{"json"}}, &metrics); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// SlowOperations returns up to limit of the slow log's worst offenders. All of
+// them are returned if limit <= 0.
+func (c *domainSocketClient) SlowOperations(limit int) ([]slowlog.Offender, error) {
+	params := url.Values{}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	var offenders []slowlog.Offender
+	if err := c.getRequest("/metrics/slow", params, &offenders); err != nil {
+		return nil, err
+	}
+	return offenders, nil
+}
+
+// Plugins returns the status of each registered plugin.
+func (c *domainSocketClient) Plugins() ([]plugin.PluginStatus, error) {
+	var plugins []plugin.PluginStatus
+	if err := c.getRequest("/plugins", nil, &plugins); err != nil {
+		return nil, err
+	}
+	return plugins, nil
+}
+
+// EnablePlugin adds a disabled plugin back to Wash.
+func (c *domainSocketClient) EnablePlugin(name string) error {
+	return c.postPluginRequest(name, "enable")
+}
+
+// DisablePlugin removes a plugin from Wash until it's enabled again.
+func (c *domainSocketClient) DisablePlugin(name string) error {
+	return c.postPluginRequest(name, "disable")
+}
+
+func (c *domainSocketClient) postPluginRequest(name string, action string) error {
+	respBody, err := c.doRequest(http.MethodPost, "/plugins/"+url.PathEscape(name)+"/"+action, nil, nil)
+	if err != nil {
+		return err
+	}
+	errz.Log(respBody.Close())
+	return nil
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+
+	"github.com/puppetlabs/wash/activity"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// swagger:route POST /fs/copy copy copyContent
+//
+// Copy content
+//
+// Replace the content of the specified entry with the content of the source
+// entry. The content is copied by the server, so it is not sent to the client.
+// If the source supports the copy action and the specified entry's parent is
+// in the same plugin, then the plugin copies the source instead. In that case,
+// the specified entry doesn't need to exist.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http
+//
+//     Responses:
+//       200:
+//       400: errorResp
+//       403: errorResp
+//       404: errorResp
+//       500: errorResp
+var copyHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	ctx := r.Context()
+	path, errResp := getPathFromRequest(r)
+	if errResp != nil {
+		return errResp
+	}
+
+	if r.Body == nil {
+		return badActionRequestResponse(path, plugin.WriteAction(), "Please send a JSON request body")
+	}
+
+	var body apitypes.CopyBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return badActionRequestResponse(path, plugin.WriteAction(), err.Error())
+	}
+
+	source, sourcePath, errResp := getEntryFromPath(ctx, body.Source)
+	if errResp != nil {
+		return errResp
+	}
+	if plugin.CopyAction().IsSupportedOn(source) {
+		dstParent, _, errResp := getEntryFromPath(ctx, filepath.Dir(path))
+		if errResp == nil && plugin.CanCopyTo(source, dstParent) {
+			return copyEntry(ctx, source.(plugin.Copyable), sourcePath, dstParent.(plugin.Parent), path)
+		}
+	}
+
+	entry, path, errResp := getEntryFromPath(ctx, path)
+	if errResp != nil {
+		return errResp
+	}
+	if !plugin.WriteAction().IsSupportedOn(entry) {
+		return unsupportedActionResponse(path, plugin.WriteAction())
+	}
+	if !plugin.ReadAction().IsSupportedOn(source) {
+		return unsupportedActionResponse(sourcePath, plugin.ReadAction())
+	}
+
+	content, err := readContent(ctx, source)
+	if err != nil {
+		return erroredActionResponse(sourcePath, plugin.ReadAction(), err.Error())
+	}
+	if err := plugin.WriteWithAnalytics(ctx, entry.(plugin.Writable), content); err != nil {
+		if notPermittedErr, ok := err.(plugin.ActionNotPermittedErr); ok {
+			return actionNotPermittedResponse(path, notPermittedErr)
+		}
+		return erroredActionResponse(path, plugin.WriteAction(), err.Error())
+	}
+
+	activity.Record(ctx, "API: Copy %v %v: %v bytes", sourcePath, path, len(content))
+	return nil
+}}
+
+// copyEntry has the source's plugin copy it to path.
+func copyEntry(ctx context.Context, source plugin.Copyable, sourcePath string, dstParent plugin.Parent, path string) *errorResponse {
+	if err := plugin.CopyWithAnalytics(ctx, source, dstParent, filepath.Base(path)); err != nil {
+		if plugin.IsInvalidInputErr(err) {
+			return badActionRequestResponse(sourcePath, plugin.CopyAction(), err.Error())
+		}
+		if notPermittedErr, ok := err.(plugin.ActionNotPermittedErr); ok {
+			return actionNotPermittedResponse(sourcePath, notPermittedErr)
+		}
+		return erroredActionResponse(sourcePath, plugin.CopyAction(), err.Error())
+	}
+
+	activity.Record(ctx, "API: Copy %v %v", sourcePath, path)
+	return nil
+}
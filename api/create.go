@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/puppetlabs/wash/activity"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// swagger:route POST /fs/create create createEntry
+//
+// Creates a child of the entry at the specified path.
+//
+// Returns an Entry object describing the new child.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http
+//
+//     Responses:
+//       200: Entry
+//       400: errorResp
+//       403: errorResp
+//       404: errorResp
+//       500: errorResp
+var createHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	ctx := r.Context()
+	entry, path, errResp := getEntryFromRequest(r)
+	if errResp != nil {
+		return errResp
+	}
+
+	if !plugin.CreateAction().IsSupportedOn(entry) {
+		return unsupportedActionResponse(path, plugin.CreateAction())
+	}
+
+	if r.Body == nil {
+		return badActionRequestResponse(path, plugin.CreateAction(), "Please send a JSON request body")
+	}
+
+	var body apitypes.CreateBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return badActionRequestResponse(path, plugin.CreateAction(), err.Error())
+	}
+
+	child, err := plugin.CreateWithAnalytics(ctx, entry.(plugin.Creatable), body.Name, body.Attributes)
+	if err != nil {
+		if plugin.IsInvalidInputErr(err) {
+			return badActionRequestResponse(path, plugin.CreateAction(), err.Error())
+		}
+		if notPermittedErr, ok := err.(plugin.ActionNotPermittedErr); ok {
+			return actionNotPermittedResponse(path, notPermittedErr)
+		}
+		return erroredActionResponse(path, plugin.CreateAction(), err.Error())
+	}
+	activity.Record(ctx, "API: Create %v %v", path, body.Name)
+
+	apiEntry := apitypes.NewEntry(child)
+	apiEntry.Path = path + "/" + apiEntry.CName
+	if err := json.NewEncoder(w).Encode(&apiEntry); err != nil {
+		return unknownErrorResponse(fmt.Errorf("Could not marshal %v: %v", apiEntry.Path, err))
+	}
+	return nil
+}}
@@ -21,6 +21,7 @@ import (
 //     Responses:
 //       200:
 //       400: errorResp
+//       403: errorResp
 //       404: errorResp
 //       500: errorResp
 var deleteHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
@@ -34,6 +35,9 @@ var deleteHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *er
 	}
 	deleted, err := plugin.DeleteWithAnalytics(ctx, entry.(plugin.Deletable))
 	if err != nil {
+		if notPermittedErr, ok := err.(plugin.ActionNotPermittedErr); ok {
+			return actionNotPermittedResponse(path, notPermittedErr)
+		}
 		return erroredActionResponse(path, plugin.DeleteAction(), err.Error())
 	}
 	activity.Record(ctx, "API: Delete %v %v", path, deleted)
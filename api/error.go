@@ -189,6 +189,19 @@ func erroredActionResponse(path string, a plugin.Action, reason string) *errorRe
 	return &errorResponse{statusCode, body}
 }
 
+func actionNotPermittedResponse(path string, e plugin.ActionNotPermittedErr) *errorResponse {
+	fields := apitypes.ErrorFields{
+		"path":   path,
+		"action": e.Action.Name,
+	}
+	body := newErrorObj(
+		apitypes.ActionNotPermitted,
+		fmt.Sprintf("The %v action is not permitted on %v: %v", e.Action.Name, path, e.Reason),
+		fields,
+	)
+	return &errorResponse{http.StatusForbidden, body}
+}
+
 func duplicateCNameResponse(e plugin.DuplicateCNameErr) *errorResponse {
 	fields := apitypes.ErrorFields{
 		"parent_id":                   e.ParentID,
@@ -59,6 +59,7 @@ type execResponse struct {
 //     Responses:
 //       200: execResponse
 //       400: errorResp
+//       403: errorResp
 //       404: errorResp
 //       500: errorResp
 var execHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
@@ -93,6 +94,9 @@ var execHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *erro
 	}
 	cmd, err := plugin.ExecWithAnalytics(ctx, entry.(plugin.Execable), body.Cmd, body.Args, opts)
 	if err != nil {
+		if notPermittedErr, ok := err.(plugin.ActionNotPermittedErr); ok {
+			return actionNotPermittedResponse(path, notPermittedErr)
+		}
 		return erroredActionResponse(path, plugin.ExecAction(), err.Error())
 	}
 
@@ -68,8 +68,15 @@ func getEntryFromRequest(r *http.Request) (plugin.Entry, string, *errorResponse)
 	if errResp != nil {
 		return nil, "", errResp
 	}
+	return getEntryFromPath(r.Context(), path)
+}
 
-	ctx := r.Context()
+// getEntryFromPath is getEntryFromRequest for an absolute path that was not
+// passed in as the path query param, e.g. the source of a copy.
+func getEntryFromPath(ctx context.Context, path string) (plugin.Entry, string, *errorResponse) {
+	if !filepath.IsAbs(path) {
+		return nil, "", relativePathResponse(path)
+	}
 	trimmedPath, errResp := toWashPath(ctx, path)
 	if errResp != nil {
 		if errResp.body.Kind != apitypes.NonWashPath {
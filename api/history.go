@@ -1,15 +1,20 @@
 package api
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/kr/logfmt"
 	"github.com/puppetlabs/wash/activity"
 	apitypes "github.com/puppetlabs/wash/api/types"
 	log "github.com/sirupsen/logrus"
@@ -24,6 +29,31 @@ type historyParams struct {
 	Follow bool
 }
 
+// swagger:parameters getJournal
+//nolint:deadcode,unused
+type journalParams struct {
+	// only return records at or after this RFC3339 time
+	//
+	// in: query
+	Since string
+	// only return records before this RFC3339 time
+	//
+	// in: query
+	Until string
+	// only return records that mention one of these paths
+	//
+	// in: query
+	Path []string
+	// only return records that mention this action, e.g. exec
+	//
+	// in: query
+	Action string
+	// only return warnings and errors when true
+	//
+	// in: query
+	Errors bool
+}
+
 // swagger:route GET /history history retrieveHistory
 //
 // Get command history
@@ -103,7 +133,8 @@ func writeHistory(ctx context.Context, enc *json.Encoder, history []activity.Jou
 // Get logs for a particular entry in history
 //
 // Get the logs related to a particular command run via 'wash', requested by
-// index within its activity history.
+// index within its activity history. The logs can be filtered by time range,
+// path, action, and whether they're errors.
 //
 //     Produces:
 //     - application/json
@@ -132,6 +163,10 @@ var historyEntryHandler = handler{logOnly: true, fn: func(w http.ResponseWriter,
 	if errResp != nil {
 		return errResp
 	}
+	filter, errResp := newJournalFilter(r.URL)
+	if errResp != nil {
+		return errResp
+	}
 
 	journal := history[idx]
 	streamCleanup := func(cleanup func() error) {
@@ -165,6 +200,9 @@ var historyEntryHandler = handler{logOnly: true, fn: func(w http.ResponseWriter,
 			if line.Err != nil {
 				return unknownErrorResponse(line.Err)
 			}
+			if !filter.matches(line.Text) {
+				continue
+			}
 			if _, err := fmt.Fprintln(f, line.Text); err != nil {
 				return unknownErrorResponse(err)
 			}
@@ -179,9 +217,113 @@ var historyEntryHandler = handler{logOnly: true, fn: func(w http.ResponseWriter,
 
 		go streamCleanup(rdr.Close)
 
-		if _, err := io.Copy(w, rdr); err != nil {
+		if filter.empty() {
+			if _, err := io.Copy(w, rdr); err != nil {
+				return unknownErrorResponse(fmt.Errorf("Could not read journal %v: %v", journal, err))
+			}
+			return nil
+		}
+
+		scanner := bufio.NewScanner(rdr)
+		scanner.Buffer(make([]byte, 4096), 100*1024*1024)
+		for scanner.Scan() {
+			if !filter.matches(scanner.Text()) {
+				continue
+			}
+			if _, err := fmt.Fprintln(w, scanner.Text()); err != nil {
+				return unknownErrorResponse(err)
+			}
+		}
+		if err := scanner.Err(); err != nil {
 			return unknownErrorResponse(fmt.Errorf("Could not read journal %v: %v", journal, err))
 		}
 	}
 	return nil
 }}
+
+// journalFilter selects a journal's records, which are logfmt lines like
+//     time="2020-01-02T15:04:05.000-07:00" level=info msg="API: GET /fs/list?path=/wash/docker"
+type journalFilter struct {
+	since      time.Time
+	until      time.Time
+	paths      []string
+	action     *regexp.Regexp
+	errorsOnly bool
+}
+
+func newJournalFilter(u *url.URL) (*journalFilter, *errorResponse) {
+	f := &journalFilter{}
+	query := u.Query()
+	for key, t := range map[string]*time.Time{"since": &f.since, "until": &f.until} {
+		if val := query.Get(key); val != "" {
+			var err error
+			if *t, err = time.Parse(time.RFC3339Nano, val); err != nil {
+				return nil, badRequestResponse(fmt.Sprintf("%v must be an RFC3339 time: %v", key, err))
+			}
+		}
+	}
+	f.paths = query["path"]
+	if action := query.Get("action"); action != "" {
+		// Records mention actions by their API endpoint (e.g. /fs/exec) or
+		// plugin method (e.g. Exec), so match the action as a word.
+		f.action = regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(action) + `\b`)
+	}
+	var errResp *errorResponse
+	if f.errorsOnly, errResp = getBoolParam(u, "errors"); errResp != nil {
+		return nil, errResp
+	}
+	return f, nil
+}
+
+func (f *journalFilter) empty() bool {
+	return f.since.IsZero() && f.until.IsZero() && len(f.paths) == 0 && f.action == nil && !f.errorsOnly
+}
+
+type journalRecord struct {
+	Time, Level, Msg string
+}
+
+func (f *journalFilter) matches(line string) bool {
+	if f.empty() {
+		return true
+	}
+	var record journalRecord
+	if err := logfmt.Unmarshal([]byte(line), &record); err != nil || record.Time == "" {
+		return false
+	}
+	if !f.since.IsZero() || !f.until.IsZero() {
+		t, err := time.Parse(time.RFC3339Nano, record.Time)
+		if err != nil {
+			return false
+		}
+		if !f.since.IsZero() && t.Before(f.since) {
+			return false
+		}
+		if !f.until.IsZero() && !t.Before(f.until) {
+			return false
+		}
+	}
+	if len(f.paths) > 0 {
+		mentioned := false
+		for _, path := range f.paths {
+			if strings.Contains(record.Msg, path) {
+				mentioned = true
+				break
+			}
+		}
+		if !mentioned {
+			return false
+		}
+	}
+	if f.action != nil && !f.action.MatchString(record.Msg) {
+		return false
+	}
+	if f.errorsOnly {
+		switch record.Level {
+		case "warning", "error", "fatal", "panic":
+		default:
+			return false
+		}
+	}
+	return true
+}
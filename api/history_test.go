@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournalFilter(t *testing.T) {
+	lines := []string{
+		`time="2020-01-02T15:04:05.000Z" level=info msg="API: GET /fs/list?path=/wash/docker"`,
+		`time="2020-01-02T15:05:05.000Z" level=info msg="API: POST /fs/exec?path=/wash/docker/containers/foo"`,
+		`time="2020-01-02T15:06:05.000Z" level=warning msg="Exec [ls] on /docker/containers/bar errored: not running"`,
+		`not a record`,
+	}
+	filtered := func(query string) []string {
+		u, err := url.Parse("/history/0?" + query)
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		filter, errResp := newJournalFilter(u)
+		if !assert.Nil(t, errResp) {
+			return nil
+		}
+		var matches []string
+		for _, line := range lines {
+			if filter.matches(line) {
+				matches = append(matches, line)
+			}
+		}
+		return matches
+	}
+
+	assert.Equal(t, lines, filtered(""))
+	assert.Equal(t, lines[1:3], filtered("since=2020-01-02T15:05:00Z"))
+	assert.Equal(t, lines[:1], filtered("until=2020-01-02T15:05:00Z"))
+	assert.Equal(t, lines[1:2], filtered("path=/wash/docker/containers"))
+	assert.Equal(t, lines[1:3], filtered("path=/wash/docker/containers&path=/docker/containers"))
+	assert.Equal(t, lines[1:3], filtered("action=exec"))
+	assert.Equal(t, lines[2:3], filtered("errors=true"))
+	assert.Empty(t, filtered("action=list&errors=true"))
+
+	u, _ := url.Parse("/history/0?since=yesterday")
+	_, errResp := newJournalFilter(u)
+	assert.NotNil(t, errResp)
+}
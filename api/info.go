@@ -6,13 +6,24 @@ import (
 	"net/http"
 
 	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/plugin"
 )
 
+// swagger:parameters entryInfo
+//nolint:deadcode,unused
+type infoParams struct {
+	// include the entry's recent method invocations when true
+	//
+	// in: query
+	Activity bool
+}
+
 // swagger:route GET /fs/info info entryInfo
 //
 // Info about entry at path
 //
-// Returns an Entry object describing the given path.
+// Returns an Entry object describing the given path. If activity is true, the
+// entry's recent method invocations are included.
 //
 //     Produces:
 //     - application/json
@@ -30,10 +41,18 @@ var infoHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *erro
 		return errResp
 	}
 
+	includeActivity, errResp := getBoolParam(r.URL, "activity")
+	if errResp != nil {
+		return errResp
+	}
+
 	jsonEncoder := json.NewEncoder(w)
 	// TODO: Include the entry's full metadata?
 	apiEntry := apitypes.NewEntry(entry)
 	apiEntry.Path = path
+	if includeActivity {
+		apiEntry.Activity = plugin.ActivityOf(entry)
+	}
 	if err := jsonEncoder.Encode(&apiEntry); err != nil {
 		return unknownErrorResponse(fmt.Errorf("Could not marshal %v: %v", path, err))
 	}
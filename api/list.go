@@ -18,12 +18,39 @@ type entryList struct {
 	Entries []apitypes.Entry
 }
 
+// swagger:parameters listEntries
+//nolint:deadcode,unused
+type listParams struct {
+	// stream the children as a ListPacket per line as soon as they're listed
+	//
+	// in: query
+	Stream bool
+	// the maximum number of children in the returned ListPage
+	//
+	// in: query
+	Limit int
+	// the continuation token of the ListPage to return
+	//
+	// in: query
+	ContinuationToken string `json:"continuation-token"`
+}
+
 // swagger:route GET /fs/list list listEntries
 //
 // Lists children of a path
 //
 // Returns a list of Entry objects describing children of the given path.
-// The "metadata" key is set to the partial metadata.
+// The "metadata" key is set to the partial metadata. With stream=true, each
+// child is sent as a ListPacket on its own line instead, as soon as it's been
+// listed, and the children aren't sorted. An error that occurs once the first
+// packet's been sent is sent as the last packet.
+//
+// If limit or continuation-token is set, then a ListPage with up to limit
+// children is returned instead. Its continuation token identifies the next
+// page, and it's empty for the last page. Parents that support pagination,
+// like S3 prefixes, list each page themselves. Otherwise the page's taken
+// from the sorted children, and a limit of 0 returns all of the remaining
+// children. Pagination can't be combined with stream.
 //
 //     Produces:
 //     - application/json
@@ -33,6 +60,7 @@ type entryList struct {
 //     Responses:
 //       200: entryList
 //       400: errorResp
+//       403: errorResp
 //       404: errorResp
 //       500: errorResp
 var listHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
@@ -46,14 +74,30 @@ var listHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *erro
 		return unsupportedActionResponse(path, plugin.ListAction())
 	}
 
+	stream, errResp := getBoolParam(r.URL, "stream")
+	if errResp != nil {
+		return errResp
+	}
+
+	limit, hasLimit, errResp := getIntParam(r.URL, "limit")
+	if errResp != nil {
+		return errResp
+	}
+	continuationToken := r.URL.Query().Get("continuation-token")
+
 	parent := entry.(plugin.Parent)
+	if hasLimit || continuationToken != "" {
+		if stream {
+			return badActionRequestResponse(path, plugin.ListAction(), "stream can't be combined with limit or continuation-token")
+		}
+		return listPage(w, r, path, parent, limit, continuationToken)
+	}
+	if stream {
+		return streamList(w, r, path, parent)
+	}
 	entries, err := plugin.ListWithAnalytics(ctx, parent)
 	if err != nil {
-		if cnameErr, ok := err.(plugin.DuplicateCNameErr); ok {
-			return duplicateCNameResponse(cnameErr)
-		}
-
-		return erroredActionResponse(path, plugin.ListAction(), err.Error())
+		return listErrorResponse(path, err)
 	}
 
 	result := make([]apitypes.Entry, 0, entries.Len())
@@ -73,3 +117,86 @@ var listHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *erro
 	}
 	return nil
 }}
+
+func listErrorResponse(path string, err error) *errorResponse {
+	if cnameErr, ok := err.(plugin.DuplicateCNameErr); ok {
+		return duplicateCNameResponse(cnameErr)
+	}
+	if notPermittedErr, ok := err.(plugin.ActionNotPermittedErr); ok {
+		return actionNotPermittedResponse(path, notPermittedErr)
+	}
+	if plugin.IsInvalidInputErr(err) {
+		return badActionRequestResponse(path, plugin.ListAction(), err.Error())
+	}
+	return erroredActionResponse(path, plugin.ListAction(), err.Error())
+}
+
+// listPage writes a page of the parent's children as a ListPage. Unlike the
+// other list responses, the page's children keep the order that the parent
+// listed them in.
+func listPage(w http.ResponseWriter, r *http.Request, path string, parent plugin.Parent, limit int, continuationToken string) *errorResponse {
+	ctx := r.Context()
+	entries, nextToken, err := plugin.ListPageWithAnalytics(ctx, parent, limit, continuationToken)
+	if err != nil {
+		return listErrorResponse(path, err)
+	}
+
+	page := apitypes.ListPage{
+		Entries:           make([]apitypes.Entry, 0, len(entries)),
+		ContinuationToken: nextToken,
+	}
+	for _, entry := range entries {
+		apiEntry := apitypes.NewEntry(entry)
+		apiEntry.Path = path + "/" + apiEntry.CName
+		page.Entries = append(page.Entries, apiEntry)
+	}
+	activity.Record(ctx, "API: List page of %v %v items", path, len(page.Entries))
+
+	jsonEncoder := json.NewEncoder(w)
+	if err = jsonEncoder.Encode(page); err != nil {
+		return unknownErrorResponse(fmt.Errorf("Could not marshal list page for %v: %v", path, err))
+	}
+	return nil
+}
+
+// streamList writes each of the parent's children as a ListPacket as soon as
+// it's been listed. This lets clients start consuming huge directories before
+// they've been fully listed.
+func streamList(w http.ResponseWriter, r *http.Request, path string, parent plugin.Parent) *errorResponse {
+	ctx := r.Context()
+	f, ok := w.(flushableWriter)
+	if !ok {
+		return unknownErrorResponse(fmt.Errorf("Cannot stream the list results, response handler does not support flushing"))
+	}
+
+	var enc *json.Encoder
+	count := 0
+	err := plugin.ListEachWithAnalytics(ctx, parent, func(entry plugin.Entry) error {
+		if enc == nil {
+			w.WriteHeader(http.StatusOK)
+			enc = json.NewEncoder(&streamableResponseWriter{f})
+		}
+		apiEntry := apitypes.NewEntry(entry)
+		apiEntry.Path = path + "/" + apiEntry.CName
+		count++
+		return enc.Encode(apitypes.ListPacket{Entry: &apiEntry})
+	})
+	activity.Record(ctx, "API: Streamed list %v %v items", path, count)
+	if err == nil {
+		if enc == nil {
+			w.WriteHeader(http.StatusOK)
+		}
+		return nil
+	}
+
+	errResp := listErrorResponse(path, err)
+	if enc == nil {
+		return errResp
+	}
+	// The response's status has already been sent, so send the error as the
+	// last packet.
+	if err := enc.Encode(apitypes.ListPacket{Err: errResp.body}); err != nil {
+		activity.Record(ctx, "API: Streamed list %v errored: %v", path, err)
+	}
+	return nil
+}
@@ -15,11 +15,23 @@ type entryMetadata struct {
 	JSONObject plugin.JSONObject
 }
 
+// swagger:parameters getMetadata
+//nolint:deadcode,unused
+type metadataParams struct {
+	// include the compression format and decompressed size of the entry's
+	// content when it's gzip or zstd compressed
+	//
+	// in: query
+	Decompress bool
+}
+
 // swagger:route GET /fs/metadata metadata getMetadata
 //
 // Get metadata
 //
-// Get metadata about the specified entry.
+// Get metadata about the specified entry. If decompress is true and the
+// entry's content is compressed, then the metadata includes the content's
+// "compression" format and "decompressed_size".
 //
 //     Produces:
 //     - application/json
@@ -28,6 +40,7 @@ type entryMetadata struct {
 //
 //     Responses:
 //       200: entryMetadata
+//       400: errorResp
 //       404: errorResp
 //       500: errorResp
 var metadataHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
@@ -37,11 +50,32 @@ var metadataHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *
 		return errResp
 	}
 
+	decompress, errResp := getBoolParam(r.URL, "decompress")
+	if errResp != nil {
+		return errResp
+	}
+
 	metadata, err := plugin.Metadata(ctx, entry)
 
 	if err != nil {
 		return unknownErrorResponse(err)
 	}
+	if decompress && plugin.ReadAction().IsSupportedOn(entry) {
+		size, compression, err := plugin.DecompressedSize(ctx, entry)
+		if err != nil {
+			return unknownErrorResponse(err)
+		}
+		if compression != plugin.NoCompression {
+			// Copy the metadata so that its cached value isn't modified.
+			withCompression := make(plugin.JSONObject, len(metadata)+2)
+			for k, v := range metadata {
+				withCompression[k] = v
+			}
+			withCompression["compression"] = compression
+			withCompression["decompressed_size"] = size
+			metadata = withCompression
+		}
+	}
 	activity.Record(ctx, "API: Metadata %v %+v", path, metadata)
 
 	jsonEncoder := json.NewEncoder(w)
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/slowlog"
+)
+
+// swagger:parameters getMetrics
+//nolint:deadcode,unused
+type metricsParams struct {
+	// "json" to return the metrics as a list of MethodStats objects
+	//
+	// in: query
+	Format string
+}
+
+// swagger:route GET /metrics metrics getMetrics
+//
+// Plugin method metrics
+//
+// Returns the latency histograms and error counts of each plugin's List, Read,
+// Metadata, Exec, and other method invocations since the server started. They're
+// in Prometheus' text format unless format=json is passed.
+//
+//     Produces:
+//     - application/json
+//     - text/plain
+//
+//     Schemes: http
+//
+//     Responses:
+//       200:
+//       400: errorResp
+//       500: errorResp
+var metricsHandler = handler{logOnly: true, fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	metrics := plugin.MethodMetrics()
+	switch format := r.URL.Query().Get("format"); format {
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(metrics); err != nil {
+			return unknownErrorResponse(fmt.Errorf("Could not marshal the metrics: %v", err))
+		}
+	case "", "prometheus":
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if err := writePrometheusMetrics(w, metrics); err != nil {
+			return unknownErrorResponse(fmt.Errorf("Could not write the metrics: %v", err))
+		}
+	default:
+		return badRequestResponse(fmt.Sprintf("unknown format %v; must be json or prometheus", format))
+	}
+	return nil
+}}
+
+// writePrometheusMetrics writes the metrics in Prometheus' text exposition
+// format.
+func writePrometheusMetrics(w io.Writer, metrics []plugin.MethodStats) error {
+	var err error
+	printf := func(format string, a ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, a...)
+		}
+	}
+
+	printf("# HELP wash_plugin_method_duration_seconds Latency of plugin method invocations.\n")
+	printf("# TYPE wash_plugin_method_duration_seconds histogram\n")
+	for _, stats := range metrics {
+		labels := fmt.Sprintf("plugin=%q,method=%q", stats.Plugin, stats.Method)
+		for i, bound := range plugin.LatencyBuckets {
+			le := strconv.FormatFloat(bound, 'g', -1, 64)
+			printf("wash_plugin_method_duration_seconds_bucket{%v,le=%q} %v\n", labels, le, stats.Buckets[i])
+		}
+		printf("wash_plugin_method_duration_seconds_bucket{%v,le=\"+Inf\"} %v\n", labels, stats.Count)
+		printf("wash_plugin_method_duration_seconds_sum{%v} %v\n", labels, strconv.FormatFloat(stats.TotalSeconds, 'g', -1, 64))
+		printf("wash_plugin_method_duration_seconds_count{%v} %v\n", labels, stats.Count)
+	}
+
+	printf("# HELP wash_plugin_method_errors_total Plugin method invocations that failed.\n")
+	printf("# TYPE wash_plugin_method_errors_total counter\n")
+	for _, stats := range metrics {
+		printf("wash_plugin_method_errors_total{plugin=%q,method=%q} %v\n", stats.Plugin, stats.Method, stats.Errors)
+	}
+	return err
+}
+
+// swagger:parameters getSlowOperations
+//nolint:deadcode,unused
+type slowOperationsParams struct {
+	// the maximum number of offenders to return. All of them are returned if
+	// it's omitted.
+	//
+	// in: query
+	Limit int
+}
+
+// swagger:route GET /metrics/slow metrics getSlowOperations
+//
+// Slow operations
+//
+// Returns the paths and methods whose plugin method invocations or API
+// requests exceeded the slow log's threshold, ordered by their slowest
+// operation.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http
+//
+//     Responses:
+//       200:
+//       400: errorResp
+//       500: errorResp
+var slowOperationsHandler = handler{logOnly: true, fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	limit, _, errResp := getIntParam(r.URL, "limit")
+	if errResp != nil {
+		return errResp
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(slowlog.TopOffenders(limit)); err != nil {
+		return unknownErrorResponse(fmt.Errorf("Could not marshal the slow operations: %v", err))
+	}
+	return nil
+}}
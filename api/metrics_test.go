@@ -0,0 +1,42 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWritePrometheusMetrics(t *testing.T) {
+	metrics := []plugin.MethodStats{{
+		Plugin:       "docker",
+		Method:       "List",
+		Count:        2,
+		Errors:       1,
+		TotalSeconds: 0.5,
+		Buckets:      []uint64{0, 0, 0, 0, 1, 1, 1, 2, 2, 2, 2},
+	}}
+	var out strings.Builder
+	assert.NoError(t, writePrometheusMetrics(&out, metrics))
+	assert.Equal(t, `# HELP wash_plugin_method_duration_seconds Latency of plugin method invocations.
+# TYPE wash_plugin_method_duration_seconds histogram
+wash_plugin_method_duration_seconds_bucket{plugin="docker",method="List",le="0.005"} 0
+wash_plugin_method_duration_seconds_bucket{plugin="docker",method="List",le="0.01"} 0
+wash_plugin_method_duration_seconds_bucket{plugin="docker",method="List",le="0.025"} 0
+wash_plugin_method_duration_seconds_bucket{plugin="docker",method="List",le="0.05"} 0
+wash_plugin_method_duration_seconds_bucket{plugin="docker",method="List",le="0.1"} 1
+wash_plugin_method_duration_seconds_bucket{plugin="docker",method="List",le="0.25"} 1
+wash_plugin_method_duration_seconds_bucket{plugin="docker",method="List",le="0.5"} 1
+wash_plugin_method_duration_seconds_bucket{plugin="docker",method="List",le="1"} 2
+wash_plugin_method_duration_seconds_bucket{plugin="docker",method="List",le="2.5"} 2
+wash_plugin_method_duration_seconds_bucket{plugin="docker",method="List",le="5"} 2
+wash_plugin_method_duration_seconds_bucket{plugin="docker",method="List",le="10"} 2
+wash_plugin_method_duration_seconds_bucket{plugin="docker",method="List",le="+Inf"} 2
+wash_plugin_method_duration_seconds_sum{plugin="docker",method="List"} 0.5
+wash_plugin_method_duration_seconds_count{plugin="docker",method="List"} 2
+# HELP wash_plugin_method_errors_total Plugin method invocations that failed.
+# TYPE wash_plugin_method_errors_total counter
+wash_plugin_method_errors_total{plugin="docker",method="List"} 1
+`, out.String())
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// swagger:response
+//nolint:deadcode,unused
+type pluginsResponse struct {
+	// in: body
+	Plugins []plugin.PluginStatus
+}
+
+// swagger:route GET /plugins plugins listPlugins
+//
+// Lists the registered plugins
+//
+// Returns each registered plugin's name and whether it's enabled.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http
+//
+//     Responses:
+//       200: pluginsResponse
+//       500: errorResp
+var pluginsHandler = handler{logOnly: true, fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	registry := r.Context().Value(pluginRegistryKey).(*plugin.Registry)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(registry.PluginStatuses()); err != nil {
+		return unknownErrorResponse(fmt.Errorf("Could not marshal the plugins: %v", err))
+	}
+	return nil
+}}
+
+// swagger:parameters enablePlugin disablePlugin
+//nolint:deadcode,unused
+type pluginParams struct {
+	// the plugin's name
+	//
+	// in: path
+	Name string
+}
+
+// swagger:route POST /plugins/{name}/enable plugins enablePlugin
+//
+// Enables a plugin
+//
+// Adds a disabled plugin's subtree back to Wash.
+//
+//     Schemes: http
+//
+//     Responses:
+//       200:
+//       404: errorResp
+var enablePluginHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	return setPluginEnabled(r, true)
+}}
+
+// swagger:route POST /plugins/{name}/disable plugins disablePlugin
+//
+// Disables a plugin
+//
+// Removes the plugin's subtree from Wash without restarting the server. Its
+// entries reject every action until it's enabled again.
+//
+//     Schemes: http
+//
+//     Responses:
+//       200:
+//       404: errorResp
+var disablePluginHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	return setPluginEnabled(r, false)
+}}
+
+func setPluginEnabled(r *http.Request, enabled bool) *errorResponse {
+	ctx := r.Context()
+	registry := ctx.Value(pluginRegistryKey).(*plugin.Registry)
+	name := mux.Vars(r)["name"]
+
+	var err error
+	if enabled {
+		err = registry.EnablePlugin(name)
+	} else {
+		err = registry.DisablePlugin(name)
+	}
+	if err != nil {
+		return pluginDoesNotExistResponse(name)
+	}
+	activity.Record(ctx, "API: Set plugin %v enabled to %v", name, enabled)
+	return nil
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// swagger:parameters readContent
+//nolint:deadcode,unused
+type readParams struct {
+	// decompress gzip or zstd compressed content when true
+	//
+	// in: query
+	Decompress bool
+}
+
+// swagger:route GET /fs/read read readContent
+//
+// Read content
+//
+// Get the content of the specified entry. If decompress is true, then
+// gzip and zstd compressed content is decompressed.
+//
+//     Produces:
+//     - application/json
+//     - application/octet-stream
+//
+//     Schemes: http
+//
+//     Responses:
+//       200: octetResponse
+//       400: errorResp
+//       403: errorResp
+//       404: errorResp
+//       500: errorResp
+var readHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	ctx := r.Context()
+	entry, path, errResp := getEntryFromRequest(r)
+	if errResp != nil {
+		return errResp
+	}
+
+	if !plugin.ReadAction().IsSupportedOn(entry) {
+		return unsupportedActionResponse(path, plugin.ReadAction())
+	}
+
+	decompress, errResp := getBoolParam(r.URL, "decompress")
+	if errResp != nil {
+		return errResp
+	}
+
+	var content []byte
+	var err error
+	if decompress {
+		content, err = readDecompressedContent(ctx, entry)
+	} else {
+		content, err = readContent(ctx, entry)
+	}
+	if err != nil {
+		if notPermittedErr, ok := err.(plugin.ActionNotPermittedErr); ok {
+			return actionNotPermittedResponse(path, notPermittedErr)
+		}
+		return erroredActionResponse(path, plugin.ReadAction(), err.Error())
+	}
+	activity.Record(ctx, "API: Read %v: %v bytes", path, len(content))
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(content); err != nil {
+		activity.Record(ctx, "API: Read %v errored: %v", path, err)
+	}
+	return nil
+}}
+
+// readContent reads all of the entry's content.
+func readContent(ctx context.Context, entry plugin.Entry) ([]byte, error) {
+	size, err := plugin.Size(ctx, entry)
+	if err != nil {
+		return nil, fmt.Errorf("could not get the content size: %v", err)
+	}
+	content, err := plugin.ReadWithAnalytics(ctx, entry, int64(size), 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return content, nil
+}
+
+// readDecompressedContent reads all of the entry's decompressed content.
+func readDecompressedContent(ctx context.Context, entry plugin.Entry) ([]byte, error) {
+	size, _, err := plugin.DecompressedSize(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	content, err := plugin.ReadDecompressedWithAnalytics(ctx, entry, int64(size), 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return content, nil
+}
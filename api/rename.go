@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/puppetlabs/wash/activity"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// swagger:route POST /fs/rename rename renameEntry
+//
+// Renames the entry at the specified path within its parent.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http
+//
+//     Responses:
+//       200:
+//       400: errorResp
+//       403: errorResp
+//       404: errorResp
+//       500: errorResp
+var renameHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	ctx := r.Context()
+	entry, path, errResp := getEntryFromRequest(r)
+	if errResp != nil {
+		return errResp
+	}
+
+	if !plugin.RenameAction().IsSupportedOn(entry) {
+		return unsupportedActionResponse(path, plugin.RenameAction())
+	}
+
+	if r.Body == nil {
+		return badActionRequestResponse(path, plugin.RenameAction(), "Please send a JSON request body")
+	}
+
+	var body apitypes.RenameBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return badActionRequestResponse(path, plugin.RenameAction(), err.Error())
+	}
+
+	if err := plugin.RenameWithAnalytics(ctx, entry.(plugin.Renameable), body.Name); err != nil {
+		if plugin.IsInvalidInputErr(err) {
+			return badActionRequestResponse(path, plugin.RenameAction(), err.Error())
+		}
+		if notPermittedErr, ok := err.(plugin.ActionNotPermittedErr); ok {
+			return actionNotPermittedResponse(path, notPermittedErr)
+		}
+		return erroredActionResponse(path, plugin.RenameAction(), err.Error())
+	}
+
+	activity.Record(ctx, "API: Rename %v %v", path, body.Name)
+	return nil
+}}
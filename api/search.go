@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// swagger:response
+//nolint:deadcode,unused
+type searchMatches struct {
+	Matches []plugin.SearchMatch
+}
+
+// swagger:parameters searchEntry
+//nolint:deadcode,unused
+type searchParams struct {
+	// the Go regular expression that the lines must match
+	//
+	// in: query
+	Pattern string
+}
+
+// swagger:route GET /fs/search search searchEntry
+//
+// Searches the entry's content
+//
+// Returns the lines of the entry's content that match the pattern. If the
+// entry's a parent, then its descendants' content is searched instead.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http
+//
+//     Responses:
+//       200: searchMatches
+//       400: errorResp
+//       403: errorResp
+//       404: errorResp
+//       500: errorResp
+var searchHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	ctx := r.Context()
+	entry, path, errResp := getEntryFromRequest(r)
+	if errResp != nil {
+		return errResp
+	}
+
+	if !plugin.SearchAction().IsSupportedOn(entry) {
+		return unsupportedActionResponse(path, plugin.SearchAction())
+	}
+
+	pattern := r.URL.Query().Get("pattern")
+	matches, err := plugin.SearchWithAnalytics(ctx, entry.(plugin.Searchable), pattern)
+	if err != nil {
+		if plugin.IsInvalidInputErr(err) {
+			return badActionRequestResponse(path, plugin.SearchAction(), err.Error())
+		}
+		if notPermittedErr, ok := err.(plugin.ActionNotPermittedErr); ok {
+			return actionNotPermittedResponse(path, notPermittedErr)
+		}
+		return erroredActionResponse(path, plugin.SearchAction(), err.Error())
+	}
+	activity.Record(ctx, "API: Search %v %v: %v matches", path, pattern, len(matches))
+
+	if matches == nil {
+		matches = []plugin.SearchMatch{}
+	}
+	if err := json.NewEncoder(w).Encode(matches); err != nil {
+		return unknownErrorResponse(fmt.Errorf("Could not marshal the search's matches for %v: %v", path, err))
+	}
+	return nil
+}}
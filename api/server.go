@@ -8,12 +8,15 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/analytics"
 	apitypes "github.com/puppetlabs/wash/api/types"
 	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/slowlog"
+	"github.com/puppetlabs/wash/tracing"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -25,7 +28,7 @@ const (
 	mountpointKey
 )
 
-// swagger:parameters cacheDelete listEntries entryInfo getMetadata readContent streamUpdates deleteEntry signalEntry entrySchema
+// swagger:parameters cacheDelete cacheState listEntries entryInfo getMetadata readContent streamUpdates deleteEntry signalEntry entrySchema
 //nolint:deadcode,unused
 type params struct {
 	// uniquely identifies an entry
@@ -55,8 +58,34 @@ func (handle handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	record("API: %v %v", r.Method, r.URL)
 
-	if err := handle.fn(w, r); err != nil {
+	start := time.Now()
+	ctx, span := tracing.Start(
+		slowlog.WithInvocationCounter(r.Context()),
+		"API: "+r.Method+" "+r.URL.Path,
+		tracing.String("http.method", r.Method),
+		tracing.String("http.target", r.URL.String()),
+	)
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	err := handle.fn(w, r)
+	op := slowlog.Operation{
+		Time:     start,
+		Kind:     slowlog.Request,
+		Path:     r.URL.Query().Get("path"),
+		Method:   r.Method + " " + r.URL.Path,
+		Duration: time.Since(start),
+		Cache:    slowlog.CacheStatus(ctx),
+	}
+	if err != nil {
+		op.Error = err.Error()
+	}
+	slowlog.Record(op)
+
+	if err != nil {
 		record("API: %v %v: %v", r.Method, r.URL, err)
+		span.RecordError(err)
+		span.SetAttributes(tracing.Int("http.status_code", int64(err.statusCode)))
 		w.WriteHeader(err.statusCode)
 
 		// NOTE: Do not set these headers in the middleware because not
@@ -130,13 +159,26 @@ func StartAPI(
 	r.Handle("/fs/find", findHandler).Methods(http.MethodPost)
 	r.Handle("/fs/metadata", metadataHandler).Methods(http.MethodGet)
 	r.Handle("/fs/stream", streamHandler).Methods(http.MethodGet)
+	r.Handle("/fs/read", readHandler).Methods(http.MethodGet)
+	r.Handle("/fs/write", writeHandler).Methods(http.MethodPost)
+	r.Handle("/fs/copy", copyHandler).Methods(http.MethodPost)
+	r.Handle("/fs/rename", renameHandler).Methods(http.MethodPost)
+	r.Handle("/fs/create", createHandler).Methods(http.MethodPost)
+	r.Handle("/fs/attributes", setAttrHandler).Methods(http.MethodPatch)
 	r.Handle("/fs/exec", execHandler).Methods(http.MethodPost)
+	r.Handle("/fs/search", searchHandler).Methods(http.MethodGet)
 	r.Handle("/fs/schema", schemaHandler).Methods(http.MethodGet)
 	r.Handle("/fs/delete", deleteHandler).Methods(http.MethodDelete)
 	r.Handle("/fs/signal", signalHandler).Methods(http.MethodPost)
 	r.Handle("/cache", cacheHandler).Methods(http.MethodDelete)
+	r.Handle("/cache", cacheStateHandler).Methods(http.MethodGet)
 	r.Handle("/history", historyHandler).Methods(http.MethodGet)
 	r.Handle("/history/{index:[0-9]+}", historyEntryHandler).Methods(http.MethodGet)
+	r.Handle("/metrics", metricsHandler).Methods(http.MethodGet)
+	r.Handle("/metrics/slow", slowOperationsHandler).Methods(http.MethodGet)
+	r.Handle("/plugins", pluginsHandler).Methods(http.MethodGet)
+	r.Handle("/plugins/{name}/enable", enablePluginHandler).Methods(http.MethodPost)
+	r.Handle("/plugins/{name}/disable", disablePluginHandler).Methods(http.MethodPost)
 
 	r.Use(prepareContextMiddleWare)
 
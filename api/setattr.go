@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// swagger:route PATCH /fs/attributes attributes setEntryAttributes
+//
+// Sets the mode, atime and/or mtime of the entry at the specified path. The
+// body's a JSON object of the attributes to set, with the same format as
+// an entry's attributes.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http
+//
+//     Responses:
+//       200:
+//       400: errorResp
+//       403: errorResp
+//       404: errorResp
+//       500: errorResp
+var setAttrHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	ctx := r.Context()
+	entry, path, errResp := getEntryFromRequest(r)
+	if errResp != nil {
+		return errResp
+	}
+
+	if !plugin.SetAttrAction().IsSupportedOn(entry) {
+		return unsupportedActionResponse(path, plugin.SetAttrAction())
+	}
+
+	if r.Body == nil {
+		return badActionRequestResponse(path, plugin.SetAttrAction(), "Please send a JSON request body")
+	}
+
+	var attr plugin.EntryAttributes
+	if err := json.NewDecoder(r.Body).Decode(&attr); err != nil {
+		return badActionRequestResponse(path, plugin.SetAttrAction(), err.Error())
+	}
+
+	if err := plugin.SetAttrWithAnalytics(ctx, entry.(plugin.SetAttrable), attr); err != nil {
+		if plugin.IsInvalidInputErr(err) {
+			return badActionRequestResponse(path, plugin.SetAttrAction(), err.Error())
+		}
+		if notPermittedErr, ok := err.(plugin.ActionNotPermittedErr); ok {
+			return actionNotPermittedResponse(path, notPermittedErr)
+		}
+		return erroredActionResponse(path, plugin.SetAttrAction(), err.Error())
+	}
+
+	activity.Record(ctx, "API: SetAttr %v %+v", path, attr.ToMap())
+	return nil
+}}
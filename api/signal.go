@@ -24,6 +24,7 @@ import (
 //     Responses:
 //       200:
 //       400: errorResp
+//       403: errorResp
 //       404: errorResp
 //       500: errorResp
 var signalHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
@@ -50,6 +51,9 @@ var signalHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *er
 		if plugin.IsInvalidInputErr(err) {
 			return badActionRequestResponse(path, plugin.SignalAction(), err.Error())
 		}
+		if notPermittedErr, ok := err.(plugin.ActionNotPermittedErr); ok {
+			return actionNotPermittedResponse(path, notPermittedErr)
+		}
 		return erroredActionResponse(path, plugin.SignalAction(), err.Error())
 	}
 
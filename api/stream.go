@@ -23,6 +23,7 @@ import (
 //
 //     Responses:
 //       200: octetResponse
+//       403: errorResp
 //       404: errorResp
 //       500: errorResp
 var streamHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
@@ -44,6 +45,9 @@ var streamHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *er
 	rdr, err := plugin.StreamWithAnalytics(ctx, entry.(plugin.Streamable))
 
 	if err != nil {
+		if notPermittedErr, ok := err.(plugin.ActionNotPermittedErr); ok {
+			return actionNotPermittedResponse(path, notPermittedErr)
+		}
 		return erroredActionResponse(path, plugin.StreamAction(), err.Error())
 	}
 	activity.Record(ctx, "API: Streaming %v", path)
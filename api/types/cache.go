@@ -0,0 +1,12 @@
+package apitypes
+
+// ClearOptions are options that can be passed as part of a Clear call.
+type ClearOptions struct {
+	// Glob treats the path as a glob that matches the cached entries to clear
+	Glob bool
+	// Ops restricts clearing to the results of the listed ops (List, Read or
+	// Metadata). The results of all ops are cleared if it's empty.
+	Ops []string
+	// Rewarm re-fetches the cleared List and Metadata results
+	Rewarm bool
+}
@@ -0,0 +1,12 @@
+package apitypes
+
+import "github.com/puppetlabs/wash/plugin"
+
+// CreateBody encapsulates the payload for a call to a plugin's Create function
+type CreateBody struct {
+	// The new child's name. It must not contain a /.
+	Name string `json:"name"`
+	// The new child's attributes. The child is a parent (e.g. a directory)
+	// if their mode is a directory.
+	Attributes plugin.EntryAttributes `json:"attributes"`
+}
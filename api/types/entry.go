@@ -13,6 +13,12 @@ type Entry struct {
 	CName      string                 `json:"cname"`
 	Attributes plugin.EntryAttributes `json:"attributes"`
 	Metadata   plugin.JSONObject      `json:"metadata"`
+	// SymlinkTarget is the entry's target relative to its parent if it's a
+	// symlink.
+	SymlinkTarget string `json:"symlink_target,omitempty"`
+	// Activity is the entry's recent method invocations. It's only included
+	// when requested.
+	Activity []plugin.MethodInvocation `json:"activity,omitempty"`
 }
 
 func NewEntry(e plugin.Entry) Entry {
@@ -23,6 +29,8 @@ func NewEntry(e plugin.Entry) Entry {
 		Actions:    plugin.SupportedActionsOf(e),
 		Attributes: plugin.Attributes(e),
 		Metadata:   plugin.PartialMetadata(e),
+		// Only listed entries can be symlinks, and they always have an ID.
+		SymlinkTarget: plugin.SymlinkTarget(e),
 	}
 }
 
@@ -36,3 +44,17 @@ func (e *Entry) Supports(action plugin.Action) bool {
 	}
 	return false
 }
+
+// ListPacket is a single packet of a streamed list. It has either one of the
+// children or the error that ended the list.
+type ListPacket struct {
+	Entry *Entry    `json:"entry,omitempty"`
+	Err   *ErrorObj `json:"error,omitempty"`
+}
+
+// ListPage is a page of a paginated list. ContinuationToken identifies the
+// next page. It's empty if this is the last page.
+type ListPage struct {
+	Entries           []Entry `json:"entries"`
+	ContinuationToken string  `json:"continuation_token,omitempty"`
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/emirpasic/gods/maps/linkedhashmap"
 	"github.com/getlantern/deepcopy"
@@ -20,6 +21,7 @@ type SignalSchema = plugin.SignalSchema
 type EntrySchema struct {
 	plugin.EntrySchema
 	path     string
+	typePath string
 	typeID   string
 	children []*EntrySchema
 	// graph is an ordered map of `<TypeID>` => `<EntrySchema>`. We store it to make
@@ -137,8 +139,8 @@ func (s *EntrySchema) UnmarshalJSON(data []byte) error {
 	}
 
 	// Now fill-in the stree.
-	var fillStree func(string, string, map[string]*EntrySchema) *EntrySchema
-	fillStree = func(path string, typeID string, visited map[string]*EntrySchema) *EntrySchema {
+	var fillStree func(*EntrySchema, string, map[string]*EntrySchema) *EntrySchema
+	fillStree = func(parent *EntrySchema, typeID string, visited map[string]*EntrySchema) *EntrySchema {
 		if node, ok := visited[typeID]; ok {
 			return node
 		}
@@ -146,25 +148,34 @@ func (s *EntrySchema) UnmarshalJSON(data []byte) error {
 		node := &EntrySchema{
 			EntrySchema: schema.(plugin.EntrySchema),
 			typeID:      typeID,
-			path:        path,
 		}
-		if len(path) <= 0 {
+		namespace, typeName := splitTypeID(typeID)
+		if parent == nil {
 			// This is the root
 			node.path = node.Label()
+			node.typePath = typeName
+			if namespace != "" {
+				node.typePath = namespace + "/" + typeName
+			}
 		} else {
 			// This is some intermediate node
-			node.path = path + "/" + node.Label()
+			node.path = parent.path + "/" + node.Label()
+			node.typePath = parent.typePath + "/" + typeName
+			if parentNamespace, _ := splitTypeID(parent.typeID); namespace != parentNamespace {
+				// We're crossing into a plugin
+				node.typePath = parent.typePath + "/" + namespace + "/" + typeName
+			}
 		}
 		visited[typeID] = node
 		for _, childTypeID := range node.EntrySchema.Children {
-			node.children = append(node.children, fillStree(node.path, childTypeID, visited))
+			node.children = append(node.children, fillStree(node, childTypeID, visited))
 		}
 		delete(visited, typeID)
 		return node
 	}
 	it := graph.Iterator()
 	it.First()
-	root := fillStree("", it.Key().(string), make(map[string]*EntrySchema))
+	root := fillStree(nil, it.Key().(string), make(map[string]*EntrySchema))
 	(*s) = (*root)
 	s.graph = graph
 
@@ -185,6 +196,37 @@ func (s *EntrySchema) SetPath(path string) *EntrySchema {
 	return s
 }
 
+// TypePath returns the path of type names from the stree root to this
+// specific entry's schema. A type name is the last segment of a type ID,
+// e.g. "s3Object" for "aws::github.com/puppetlabs/wash/plugin/aws/s3Object".
+// The plugin's name precedes the first type name of each plugin, so the
+// type path looks like
+//    <plugin>/<root_type>/<parent1_type>/.../<type>
+func (s *EntrySchema) TypePath() string {
+	return s.typePath
+}
+
+// SetTypePath sets the entry's type path. This should only be called
+// by the tests.
+func (s *EntrySchema) SetTypePath(typePath string) *EntrySchema {
+	s.typePath = typePath
+	return s
+}
+
+// splitTypeID splits the type ID into its plugin namespace and its type
+// name. The namespace is empty for type IDs that aren't namespaced (e.g.
+// the plugin registry's).
+func splitTypeID(typeID string) (namespace string, typeName string) {
+	typeName = typeID
+	if ix := strings.Index(typeID, "::"); ix >= 0 {
+		namespace, typeName = typeID[:ix], typeID[ix+2:]
+	}
+	if ix := strings.LastIndex(typeName, "/"); ix >= 0 {
+		typeName = typeName[ix+1:]
+	}
+	return
+}
+
 // TypeID returns the entry's type ID.
 func (s *EntrySchema) TypeID() string {
 	return s.typeID
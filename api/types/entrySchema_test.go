@@ -51,6 +51,33 @@ func (suite *EntrySchemaTestSuite) TestUnmarshalJSON_KnownSchema_InvalidSchema()
 	suite.Regexp("number", err)
 }
 
+func (suite *EntrySchemaTestSuite) TestUnmarshalJSON_TypePath() {
+	rawSchema := `{
+		"aws::plugin/aws/Root": {"label": "aws", "children": ["aws::plugin/aws/bucket"]},
+		"aws::plugin/aws/bucket": {"label": "bucket", "children": ["kubernetes::plugin/kubernetes/pod"]},
+		"kubernetes::plugin/kubernetes/pod": {"label": "pod"}
+	}`
+	var s *EntrySchema
+	if suite.NoError(json.Unmarshal([]byte(rawSchema), &s)) {
+		suite.Equal("aws/Root", s.TypePath())
+		bucket := s.Children()[0]
+		suite.Equal("aws/Root/bucket", bucket.TypePath())
+		suite.Equal("aws/Root/bucket/kubernetes/pod", bucket.Children()[0].TypePath())
+	}
+}
+
+func (suite *EntrySchemaTestSuite) TestSplitTypeID() {
+	namespace, typeName := splitTypeID("aws::github.com/puppetlabs/wash/plugin/aws/s3Object")
+	suite.Equal("aws", namespace)
+	suite.Equal("s3Object", typeName)
+	namespace, typeName = splitTypeID("github.com/puppetlabs/wash/plugin/Registry")
+	suite.Equal("", namespace)
+	suite.Equal("Registry", typeName)
+	namespace, typeName = splitTypeID("external::volume")
+	suite.Equal("external", namespace)
+	suite.Equal("volume", typeName)
+}
+
 func TestEntrySchema(t *testing.T) {
 	suite.Run(t, new(EntrySchemaTestSuite))
 }
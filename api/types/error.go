@@ -43,4 +43,5 @@ const (
 	NonWashPath        = "puppetlabs.wash/non-wash-path"
 	InvalidBool        = "puppetlabs.wash/invalid-bool"
 	InvalidInt         = "puppetlabs.wash/invalid-int"
+	ActionNotPermitted = "puppetlabs.wash/action-not-permitted"
 )
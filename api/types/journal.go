@@ -15,6 +15,21 @@ type Activity struct {
 	Start       time.Time `json:"start"`
 }
 
+// JournalOptions are options that can be passed as part of an ActivityJournal call.
+type JournalOptions struct {
+	// Follow streams new records instead of stopping at the end of the journal
+	Follow bool
+	// Since and Until restrict the records to those recorded in [Since, Until).
+	// They're ignored if they're zero.
+	Since, Until time.Time
+	// Paths restricts the records to those that mention any of the paths
+	Paths []string
+	// Action restricts the records to those that mention the action, e.g. exec
+	Action string
+	// ErrorsOnly restricts the records to warnings and errors
+	ErrorsOnly bool
+}
+
 // HistoryResponse describes the result returned by the `/history` endpoint.
 //
 // swagger:response
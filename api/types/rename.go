@@ -0,0 +1,7 @@
+package apitypes
+
+// RenameBody encapsulates the payload for a call to a plugin's Rename function
+type RenameBody struct {
+	// The entry's new name. It must not contain a /.
+	Name string `json:"name"`
+}
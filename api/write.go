@@ -0,0 +1,90 @@
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// swagger:parameters writeContent
+//nolint:deadcode,unused
+type writeParams struct {
+	// compress the request body with the compression format of the entry's
+	// current content when true
+	//
+	// in: query
+	Compress bool
+}
+
+// swagger:route POST /fs/write write writeContent
+//
+// Write content
+//
+// Replace the content of the specified entry with the request body. If
+// compress is true and the entry's current content is gzip or zstd
+// compressed, then the body is compressed with the same format. Use it to
+// write back content that was read with decompress.
+//
+//     Consumes:
+//     - application/octet-stream
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http
+//
+//     Responses:
+//       200:
+//       400: errorResp
+//       403: errorResp
+//       404: errorResp
+//       500: errorResp
+var writeHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	ctx := r.Context()
+	entry, path, errResp := getEntryFromRequest(r)
+	if errResp != nil {
+		return errResp
+	}
+
+	if !plugin.WriteAction().IsSupportedOn(entry) {
+		return unsupportedActionResponse(path, plugin.WriteAction())
+	}
+
+	compress, errResp := getBoolParam(r.URL, "compress")
+	if errResp != nil {
+		return errResp
+	}
+
+	if r.Body == nil {
+		return badActionRequestResponse(path, plugin.WriteAction(), "Please send the content as the request body")
+	}
+	content, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return badActionRequestResponse(path, plugin.WriteAction(), err.Error())
+	}
+
+	if compress {
+		if !plugin.ReadAction().IsSupportedOn(entry) {
+			return badActionRequestResponse(path, plugin.WriteAction(), "compress requires a readable entry")
+		}
+		_, compression, err := plugin.DecompressedSize(ctx, entry)
+		if err == nil {
+			content, err = plugin.Compress(content, compression)
+		}
+		if err != nil {
+			return erroredActionResponse(path, plugin.WriteAction(), err.Error())
+		}
+	}
+
+	if err := plugin.WriteWithAnalytics(ctx, entry.(plugin.Writable), content); err != nil {
+		if notPermittedErr, ok := err.(plugin.ActionNotPermittedErr); ok {
+			return actionNotPermittedResponse(path, notPermittedErr)
+		}
+		return erroredActionResponse(path, plugin.WriteAction(), err.Error())
+	}
+
+	activity.Record(ctx, "API: Write %v: %v bytes", path, len(content))
+	return nil
+}}
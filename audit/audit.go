@@ -0,0 +1,259 @@
+// Package audit records Wash's destructive actions (Write, Delete, Signal and
+// Exec) to an append-only audit log. The log is separate from the activity
+// journals, which are meant for debugging and are pruned along with the cache.
+//
+// Each event is a JSON line that includes the hash of the previous event, and
+// its own hash covers that. Editing or removing an event therefore breaks the
+// chain, which Verify detects. The hashes are HMACs keyed with a secret that's
+// kept outside of the log, so someone who can write the log but not read the
+// key can't recompute the chain after tampering with it. Nothing anchors the
+// chain's latest event though, so removing the last events (truncating the
+// log) goes undetected.
+package audit
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	log "github.com/sirupsen/logrus"
+)
+
+// Event describes a single destructive action.
+type Event struct {
+	Time time.Time `json:"time"`
+	// User is the user that the Wash server's running as.
+	User string `json:"user"`
+	// Command is the command that invoked the action, e.g. "wash rm docker/containers/foo",
+	// and JournalID identifies its activity journal.
+	Command   string   `json:"command,omitempty"`
+	JournalID string   `json:"journal_id,omitempty"`
+	Path      string   `json:"path"`
+	Action    string   `json:"action"`
+	Args      []string `json:"args,omitempty"`
+	// Result is "success" or "failure". Error is set on failure.
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+	// Prev is the previous event's hash, and Hash is the keyed hash of this
+	// event (with Hash unset).
+	Prev string `json:"prev"`
+	Hash string `json:"hash,omitempty"`
+}
+
+// Results of an Event.
+const (
+	Success = "success"
+	Failure = "failure"
+)
+
+func (e Event) computeHash(key []byte) (string, error) {
+	e.Hash = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// keySize is the size of the keys generated by LoadKey
+const keySize = 32
+
+// LoadKey reads the key that the audit log's hashes are computed with from
+// keyPath. If the key doesn't exist, then LoadKey generates one and saves it
+// to keyPath so that only the current user can read it. The key should be
+// kept away from anyone that can write the audit log.
+func LoadKey(keyPath string) ([]byte, error) {
+	key, err := ioutil.ReadFile(keyPath)
+	if err == nil {
+		if len(key) == 0 {
+			return nil, fmt.Errorf("the audit key %v is empty", keyPath)
+		}
+		return key, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key = make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("could not generate the audit key: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(keyPath), 0750); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(keyPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0400)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Write(key); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return key, file.Close()
+}
+
+type auditLog struct {
+	mux      sync.Mutex
+	file     *os.File
+	key      []byte
+	lastHash string
+	user     string
+}
+
+var auditFile *auditLog
+
+// Init opens the audit log at path, creating it if necessary. Its hashes are
+// computed with key. Events are ignored until it's called.
+func Init(path string, key []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	events, err := Read(path)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	a := &auditLog{file: file, key: key}
+	if len(events) > 0 {
+		a.lastHash = events[len(events)-1].Hash
+	}
+	if u, err := user.Current(); err == nil {
+		a.user = u.Username
+	} else {
+		a.user = fmt.Sprint(os.Getuid())
+	}
+	auditFile = a
+	return nil
+}
+
+// Close closes the audit log.
+func Close() {
+	if auditFile != nil {
+		auditFile.mux.Lock()
+		defer auditFile.mux.Unlock()
+		if err := auditFile.file.Close(); err != nil {
+			log.Warnf("Failed to close the audit log: %v", err)
+		}
+		auditFile = nil
+	}
+}
+
+// Record records that action was invoked on the entry at path with args. err is
+// the action's result.
+func Record(ctx context.Context, path string, action string, args []string, err error) {
+	a := auditFile
+	if a == nil {
+		return
+	}
+
+	event := Event{
+		Time:   time.Now(),
+		User:   a.user,
+		Path:   path,
+		Action: action,
+		Args:   args,
+		Result: Success,
+	}
+	if journal, ok := ctx.Value(activity.JournalKey).(activity.Journal); ok {
+		event.Command = journal.Description
+		event.JournalID = journal.ID
+	}
+	if err != nil {
+		event.Result = Failure
+		event.Error = err.Error()
+	}
+
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	if writeErr := a.append(event); writeErr != nil {
+		log.Warnf("Failed to audit %v on %v: %v", action, path, writeErr)
+	}
+}
+
+func (a *auditLog) append(event Event) error {
+	event.Prev = a.lastHash
+	hash, err := event.computeHash(a.key)
+	if err != nil {
+		return err
+	}
+	event.Hash = hash
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := a.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	a.lastHash = hash
+	return nil
+}
+
+// Read reads the events in the audit log at path. It returns no events if the
+// log doesn't exist.
+func Read(path string) ([]Event, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readEvents(file)
+}
+
+func readEvents(r io.Reader) ([]Event, error) {
+	var events []Event
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 4096), 10*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("line %v: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	return events, scanner.Err()
+}
+
+// Verify checks that the events haven't been tampered with, using the key
+// that their hashes were computed with. It returns an error describing the
+// first event whose hash or link to the previous event doesn't match.
+//
+// Verify can't tell if events were removed from the end of the log, since the
+// remaining events still form a valid chain. Compare the number of events or
+// the last event's hash against a copy kept elsewhere to detect that.
+func Verify(events []Event, key []byte) error {
+	prev := ""
+	for i, event := range events {
+		if event.Prev != prev {
+			return fmt.Errorf("event %v does not follow event %v; events were removed or reordered", i+1, i)
+		}
+		hash, err := event.computeHash(key)
+		if err != nil {
+			return err
+		}
+		if !hmac.Equal([]byte(event.Hash), []byte(hash)) {
+			return fmt.Errorf("event %v was modified", i+1)
+		}
+		prev = event.Hash
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecord_NotInitialized(t *testing.T) {
+	// Events are ignored rather than panicking
+	Record(context.Background(), "/docker/containers/foo", "delete", nil, nil)
+}
+
+func TestRecordAndVerify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wash-audit")
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "Temporary directory required for further testing")
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+	key, err := LoadKey(filepath.Join(dir, "keys", "audit.key"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	ctx := context.WithValue(context.Background(), activity.JournalKey, activity.NewJournal("1", "wash rm foo"))
+	assert.NoError(t, Init(path, key))
+	Record(ctx, "/docker/containers/foo", "delete", nil, nil)
+	Record(ctx, "/docker/containers/bar", "signal", []string{"stop"}, errors.New("no such container"))
+	Close()
+
+	// Reopening the log continues the chain
+	assert.NoError(t, Init(path, key))
+	Record(context.Background(), "/docker/containers/baz", "exec", []string{"echo", "hi"}, nil)
+	Close()
+
+	events, err := Read(path)
+	assert.NoError(t, err)
+	if !assert.Len(t, events, 3) {
+		return
+	}
+	assert.Equal(t, "delete", events[0].Action)
+	assert.Equal(t, "wash rm foo", events[0].Command)
+	assert.Equal(t, "1", events[0].JournalID)
+	assert.Equal(t, Success, events[0].Result)
+	assert.NotEmpty(t, events[0].User)
+	assert.Empty(t, events[0].Prev)
+	assert.Equal(t, []string{"stop"}, events[1].Args)
+	assert.Equal(t, Failure, events[1].Result)
+	assert.Equal(t, "no such container", events[1].Error)
+	assert.Equal(t, events[0].Hash, events[1].Prev)
+	assert.Equal(t, events[1].Hash, events[2].Prev)
+	assert.NoError(t, Verify(events, key))
+}
+
+func TestLoadKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wash-audit")
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "Temporary directory required for further testing")
+	}
+	defer os.RemoveAll(dir)
+	keyPath := filepath.Join(dir, "audit.key")
+
+	key, err := LoadKey(keyPath)
+	if assert.NoError(t, err) {
+		assert.Len(t, key, keySize)
+	}
+	// The generated key is reused
+	reloaded, err := LoadKey(keyPath)
+	if assert.NoError(t, err) {
+		assert.Equal(t, key, reloaded)
+	}
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	key := []byte("secret")
+	var events []Event
+	a := &auditLog{key: key}
+	for _, path := range []string{"/a", "/b", "/c"} {
+		event := Event{Path: path, Action: "delete", Result: Success}
+		event.Prev = a.lastHash
+		hash, err := event.computeHash(a.key)
+		assert.NoError(t, err)
+		event.Hash = hash
+		a.lastHash = hash
+		events = append(events, event)
+	}
+	assert.NoError(t, Verify(events, key))
+
+	modified := append([]Event{}, events...)
+	modified[1].Result = Failure
+	err := Verify(modified, key)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "event 2 was modified")
+	}
+
+	removed := []Event{events[0], events[2]}
+	err = Verify(removed, key)
+	if assert.Error(t, err) {
+		assert.True(t, strings.Contains(err.Error(), "removed or reordered"))
+	}
+
+	// Recomputing the chain requires the key
+	err = Verify(events, []byte("guessed"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "event 1 was modified")
+	}
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/puppetlabs/wash/audit"
+	"github.com/puppetlabs/wash/cmd/internal/config"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+func auditCommand() *cobra.Command {
+	auditCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Prints the audit log of destructive actions",
+		Long: `The Wash server audits every write, delete, signal and exec that it performs, recording when it
+happened, the user and command that invoked it, the entry's path, the action's arguments and its result.
+Print those events from the audit log, which is the audit.log file in Wash's cache directory unless
+WASH_AUDIT_FILE is set.
+
+The events can be filtered by when they happened (--since and --until), by the entries they were
+performed on (--path), by --action, by --user and by whether they --failed. --since and --until accept
+a duration relative to now (e.g. 2h) or a time (e.g. 2020-01-02T15:04:05Z or "2020-01-02 15:04").
+
+Each event includes the hash of the event before it, so changing or removing an event breaks the chain.
+The hashes are keyed with the audit.key file next to Wash's config file unless WASH_AUDIT_KEY_FILE is
+set, so the chain can't be recomputed without the key. Use --verify to check the whole log for tampering.
+It can't detect events that were removed from the end of the log, so it also prints the number of events
+and the last event's hash; compare them against a copy from an earlier --verify to detect truncation.`,
+		Example: `audit --since 24h --action delete
+  print the entries that were deleted in the last day`,
+		Args: cobra.NoArgs,
+		RunE: toRunE(auditMain),
+	}
+	auditCmd.Flags().String("since", "", "Only print events at or after the given duration ago or time")
+	auditCmd.Flags().String("until", "", "Only print events before the given duration ago or time")
+	auditCmd.Flags().String("path", "", "Only print events on the given entry or its descendants")
+	auditCmd.Flags().String("action", "", "Only print events for the given action (write, delete, signal, or exec)")
+	auditCmd.Flags().String("user", "", "Only print events by the given user")
+	auditCmd.Flags().Bool("failed", false, "Only print events that failed")
+	auditCmd.Flags().Bool("verify", false, "Check that the audit log hasn't been tampered with")
+	auditCmd.Flags().Bool("json", false, "Print each event as a JSON object")
+	return auditCmd
+}
+
+// auditFilter selects the audit log's events
+type auditFilter struct {
+	since  time.Time
+	until  time.Time
+	path   string
+	action string
+	user   string
+	failed bool
+}
+
+func newAuditFilter(cmd *cobra.Command) (*auditFilter, error) {
+	f := &auditFilter{}
+	since, err := cmd.Flags().GetString("since")
+	if err != nil {
+		panic(err.Error())
+	}
+	if f.since, err = parseHistoryTime(since); err != nil {
+		return nil, fmt.Errorf("invalid --since: %v", err)
+	}
+	until, err := cmd.Flags().GetString("until")
+	if err != nil {
+		panic(err.Error())
+	}
+	if f.until, err = parseHistoryTime(until); err != nil {
+		return nil, fmt.Errorf("invalid --until: %v", err)
+	}
+	if f.action, err = cmd.Flags().GetString("action"); err != nil {
+		panic(err.Error())
+	}
+	if f.user, err = cmd.Flags().GetString("user"); err != nil {
+		panic(err.Error())
+	}
+	if f.failed, err = cmd.Flags().GetBool("failed"); err != nil {
+		panic(err.Error())
+	}
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		panic(err.Error())
+	}
+	if path != "" {
+		// Events record the entry's ID, which is its path relative to the
+		// mountpoint. Paths outside of the mountpoint are assumed to be IDs.
+		f.path = filepath.Join("/", path)
+		if cmdutil.IsWashPath(path) {
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --path: %v", err)
+			}
+			f.path = strings.TrimPrefix(abs, os.Getenv("W"))
+		}
+	}
+	return f, nil
+}
+
+func (f *auditFilter) matches(event audit.Event) bool {
+	if !f.since.IsZero() && event.Time.Before(f.since) {
+		return false
+	}
+	if !f.until.IsZero() && !event.Time.Before(f.until) {
+		return false
+	}
+	if f.action != "" && event.Action != f.action {
+		return false
+	}
+	if f.user != "" && event.User != f.user {
+		return false
+	}
+	if f.failed && event.Result != audit.Failure {
+		return false
+	}
+	if f.path != "" && f.path != "/" && event.Path != f.path && !strings.HasPrefix(event.Path, f.path+"/") {
+		return false
+	}
+	return true
+}
+
+func auditMain(cmd *cobra.Command, args []string) exitCode {
+	verify, err := cmd.Flags().GetBool("verify")
+	if err != nil {
+		panic(err.Error())
+	}
+	asJSON, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		panic(err.Error())
+	}
+	filter, err := newAuditFilter(cmd)
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+
+	events, err := audit.Read(config.AuditFile)
+	if err != nil {
+		cmdutil.ErrPrintf("could not read the audit log %v: %v\n", config.AuditFile, err)
+		return exitCode{1}
+	}
+	if verify {
+		key, err := ioutil.ReadFile(config.AuditKeyFile)
+		if err != nil {
+			cmdutil.ErrPrintf("could not read the audit key %v: %v\n", config.AuditKeyFile, err)
+			return exitCode{1}
+		}
+		if err := audit.Verify(events, key); err != nil {
+			cmdutil.ErrPrintf("%v: %v\n", config.AuditFile, err)
+			return exitCode{1}
+		}
+		cmdutil.Printf("Verified %v events in %v\n", len(events), config.AuditFile)
+		if len(events) > 0 {
+			cmdutil.Printf("The last event's hash is %v\n", events[len(events)-1].Hash)
+		}
+		return exitCode{0}
+	}
+
+	var rows [][]string
+	for _, event := range events {
+		if !filter.matches(event) {
+			continue
+		}
+		if asJSON {
+			data, err := json.Marshal(event)
+			if err != nil {
+				cmdutil.ErrPrintf("%v\n", err)
+				return exitCode{1}
+			}
+			cmdutil.Println(string(data))
+			continue
+		}
+		result := event.Result
+		if event.Error != "" {
+			result += ": " + event.Error
+		}
+		rows = append(rows, []string{
+			event.Time.Format("2006-01-02 15:04:05"),
+			event.User,
+			event.Action,
+			event.Path,
+			strings.Join(event.Args, " "),
+			result,
+		})
+	}
+	if len(rows) > 0 {
+		table := cmdutil.NewTableWithHeaders([]cmdutil.ColumnHeader{
+			{ShortName: "time", FullName: "TIME"},
+			{ShortName: "user", FullName: "USER"},
+			{ShortName: "action", FullName: "ACTION"},
+			{ShortName: "path", FullName: "PATH"},
+			{ShortName: "args", FullName: "ARGS"},
+			{ShortName: "result", FullName: "RESULT"},
+		}, rows)
+		cmdutil.Print(table.Format())
+	}
+	return exitCode{0}
+}
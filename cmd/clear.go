@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"strings"
+
+	apitypes "github.com/puppetlabs/wash/api/types"
 	cmdutil "github.com/puppetlabs/wash/cmd/util"
 	"github.com/spf13/cobra"
 )
@@ -13,10 +16,20 @@ func clearCommand() *cobra.Command {
 		Short:   "Clears the cache at the specified paths, or current directory if not specified",
 		Long: `Wash caches most operations. If the resource you're querying appears out-of-date, use this
 subcommand to reset the cache for resources at or contained within the specified paths.
-Defaults to the current directory if no path is provided.`,
+Defaults to the current directory if no path is provided.
+
+A path can also be a glob, e.g. 'docker/containers/*/log' (quote it so that your shell doesn't
+expand it). Globs are matched against the cached entries, so clearing them doesn't list anything.
+
+Use --list, --read and --metadata to only clear the results of those operations, and --rewarm to
+fetch the cleared lists and metadata again right away.`,
 		RunE: toRunE(clearMain),
 	}
 	clearCmd.Flags().BoolP("verbose", "v", false, "Print paths that were cleared from the cache")
+	clearCmd.Flags().Bool("list", false, "Only clear cached lists")
+	clearCmd.Flags().Bool("read", false, "Only clear cached content")
+	clearCmd.Flags().Bool("metadata", false, "Only clear cached metadata")
+	clearCmd.Flags().Bool("rewarm", false, "Fetch the cleared lists and metadata again")
 	return clearCmd
 }
 
@@ -29,6 +42,20 @@ func clearMain(cmd *cobra.Command, args []string) exitCode {
 	if err != nil {
 		panic(err.Error())
 	}
+	rewarm, err := cmd.Flags().GetBool("rewarm")
+	if err != nil {
+		panic(err.Error())
+	}
+	var ops []string
+	for _, op := range []string{"List", "Read", "Metadata"} {
+		only, err := cmd.Flags().GetBool(strings.ToLower(op))
+		if err != nil {
+			panic(err.Error())
+		}
+		if only {
+			ops = append(ops, op)
+		}
+	}
 
 	conn := cmdutil.NewClient()
 
@@ -37,7 +64,12 @@ func clearMain(cmd *cobra.Command, args []string) exitCode {
 	// request.
 	ec := 0
 	for _, path := range paths {
-		cleared, err := conn.Clear(path)
+		opts := apitypes.ClearOptions{
+			Glob:   strings.ContainsAny(path, "*?["),
+			Ops:    ops,
+			Rewarm: rewarm,
+		}
+		cleared, err := conn.Clear(path, opts)
 		if err != nil {
 			ec = 1
 			cmdutil.ErrPrintf("%v: %v\n", path, err)
@@ -49,7 +81,7 @@ func clearMain(cmd *cobra.Command, args []string) exitCode {
 				cmdutil.Println("Cleared", p)
 			}
 		} else {
-			cmdutil.Println("Cleared", path)
+			cmdutil.Printf("Cleared %v (%v cache entries)\n", path, len(cleared))
 		}
 	}
 
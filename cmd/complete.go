@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/puppetlabs/wash/api/client"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func completeCommand() *cobra.Command {
+	completeCmd := &cobra.Command{
+		Use:   "complete [--action <action> | --command <command>] <word>",
+		Short: "Prints the Wash paths that complete the given word",
+		Long: `Prints the Wash paths that complete the given word, one per line. Parents are printed with a
+trailing '/' so that completion can continue into them. The Wash shell uses complete for tab
+completion; it's not meant to be invoked directly.
+
+Completions are listed with the API, so they're served from the cache when possible. If --action
+is given, only entries that support the action (and parents, which may contain such entries) are
+printed. --command selects the action for a Wash command instead, e.g. '--command wexec' only
+completes entries that support exec. Nothing is printed if the word isn't a Wash path, so shells
+can fall back to their default completion.`,
+		Args:   cobra.MaximumNArgs(1),
+		Hidden: true,
+		RunE:   toRunE(completeMain),
+	}
+	completeCmd.Flags().String("action", "", "Only complete entries that support the action")
+	completeCmd.Flags().String("command", "", "Only complete entries that support the command's action")
+	return completeCmd
+}
+
+// completionActions are the actions that commands need their path arguments
+// to support. Commands that aren't listed accept any entry.
+var completionActions = map[string]string{
+	"exec":   plugin.ExecAction().Name,
+	"ps":     plugin.ExecAction().Name,
+	"tail":   plugin.StreamAction().Name,
+	"signal": plugin.SignalAction().Name,
+	"delete": plugin.DeleteAction().Name,
+}
+
+func completeMain(cmd *cobra.Command, args []string) exitCode {
+	actionName, err := cmd.Flags().GetString("action")
+	if err != nil {
+		panic(err.Error())
+	}
+	command, err := cmd.Flags().GetString("command")
+	if err != nil {
+		panic(err.Error())
+	}
+	if actionName == "" && command != "" {
+		// The Wash shell aliases commands with a 'w' prefix, e.g. wexec.
+		actionName = completionActions[strings.TrimPrefix(command, "w")]
+	}
+
+	var action *plugin.Action
+	if actionName != "" {
+		a, ok := plugin.Actions()[actionName]
+		if !ok {
+			cmdutil.ErrPrintf("unknown action %v\n", actionName)
+			return exitCode{1}
+		}
+		action = &a
+	}
+
+	var word string
+	if len(args) > 0 {
+		word = args[0]
+	}
+
+	// Errors are expected while the user is typing (e.g. for paths that don't
+	// exist), so don't print them.
+	for _, completion := range completePath(cmdutil.NewClient(), word, action) {
+		cmdutil.Println(completion)
+	}
+	return exitCode{0}
+}
+
+// completePath returns the paths that complete word. Parents are suffixed
+// with a '/'.
+func completePath(conn client.Client, word string, action *plugin.Action) []string {
+	var dir, base string
+	if i := strings.LastIndex(word, "/"); i >= 0 {
+		dir, base = word[:i+1], word[i+1:]
+	} else {
+		base = word
+	}
+
+	listPath := dir
+	if listPath == "" {
+		listPath = "."
+	}
+	if !cmdutil.IsWashPath(listPath) {
+		return nil
+	}
+	listPath, err := filepath.Abs(listPath)
+	if err != nil {
+		return nil
+	}
+
+	entries, err := conn.List(listPath)
+	if err != nil {
+		return nil
+	}
+
+	var completions []string
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.CName, base) {
+			continue
+		}
+		// Like most shells, only complete hidden entries when asked to.
+		if strings.HasPrefix(entry.CName, ".") && !strings.HasPrefix(base, ".") {
+			continue
+		}
+		parent := entry.Supports(plugin.ListAction())
+		if action != nil && !parent && !entry.Supports(*action) {
+			continue
+		}
+		completion := dir + entry.CName
+		if parent {
+			completion += "/"
+		}
+		completions = append(completions, completion)
+	}
+	sort.Strings(completions)
+	return completions
+}
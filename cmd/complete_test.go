@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func TestCompletePath(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	conn := &cmdtest.MockClient{}
+	conn.On("List", "/wash/docker/containers").Return([]apitypes.Entry{
+		{CName: "web", Actions: []string{"list", "exec"}},
+		{CName: "worker", Actions: []string{"exec"}},
+		{CName: "wiki.log", Actions: []string{"read"}},
+		{CName: ".hidden", Actions: []string{"exec"}},
+		{CName: "db", Actions: []string{"exec"}},
+	}, nil)
+
+	completions := completePath(conn, "/wash/docker/containers/w", nil)
+	assert.Equal(t, []string{"/wash/docker/containers/web/", "/wash/docker/containers/wiki.log", "/wash/docker/containers/worker"}, completions)
+
+	exec := plugin.ExecAction()
+	completions = completePath(conn, "/wash/docker/containers/w", &exec)
+	assert.Equal(t, []string{"/wash/docker/containers/web/", "/wash/docker/containers/worker"}, completions)
+
+	completions = completePath(conn, "/wash/docker/containers/.", nil)
+	assert.Equal(t, []string{"/wash/docker/containers/.hidden"}, completions)
+
+	// Local paths aren't completed
+	assert.Empty(t, completePath(conn, "/tmp/", nil))
+}
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+)
+
+func completionCommand() *cobra.Command {
+	use, aliases := generateShellAlias("completion")
+	completionCmd := &cobra.Command{
+		Use:     use + " bash|zsh|fish",
+		Aliases: aliases,
+		Short:   "Prints a completion script for wash",
+		Long: `Prints a script that completes wash's commands and flags for the given shell. Arguments are
+completed with Wash paths (via the same helper that the Wash shell uses) when they're inside the
+Wash mountpoint, and with local files otherwise.
+
+To load completions in your current shell session, run
+  bash: source <(wash completion bash)
+  zsh:  source <(wash completion zsh)
+  fish: wash completion fish | source
+
+To load them for every session, save the script to your shell's completion directory, e.g.
+/etc/bash_completion.d/wash, a directory in your zsh $fpath as _wash, or
+~/.config/fish/completions/wash.fish.`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		RunE:      toRunE(completionMain),
+	}
+	return completionCmd
+}
+
+func completionMain(cmd *cobra.Command, args []string) exitCode {
+	commands := completionCommands(cmd.Root())
+	switch args[0] {
+	case "bash":
+		cmdutil.Print(bashCompletionScript(commands))
+	case "zsh":
+		cmdutil.Print(zshCompletionScript(commands))
+	case "fish":
+		cmdutil.Print(fishCompletionScript(commands))
+	default:
+		cmdutil.ErrPrintf("unsupported shell %v; must be bash, zsh or fish\n", args[0])
+		return exitCode{1}
+	}
+	return exitCode{0}
+}
+
+// completionFlag describes a flag for completion scripts.
+type completionFlag struct {
+	name      string
+	shorthand string
+	usage     string
+}
+
+// words returns the flag's completion words, e.g. --long and -l.
+func (f completionFlag) words() []string {
+	words := []string{"--" + f.name}
+	if f.shorthand != "" {
+		words = append(words, "-"+f.shorthand)
+	}
+	return words
+}
+
+// completionSpec describes a command for completion scripts. A command's
+// names include its aliases.
+type completionSpec struct {
+	names []string
+	short string
+	flags []completionFlag
+}
+
+// completionCommands returns the root command (with no names) followed by its
+// available subcommands.
+func completionCommands(root *cobra.Command) []completionSpec {
+	commands := []completionSpec{{flags: completionFlags(root.NonInheritedFlags())}}
+	subcommands := root.Commands()
+	sort.Slice(subcommands, func(i, j int) bool {
+		return subcommands[i].Name() < subcommands[j].Name()
+	})
+	for _, subcommand := range subcommands {
+		if !subcommand.IsAvailableCommand() {
+			continue
+		}
+		commands = append(commands, completionSpec{
+			names: append([]string{subcommand.Name()}, subcommand.Aliases...),
+			short: subcommand.Short,
+			flags: completionFlags(subcommand.Flags()),
+		})
+	}
+	return commands
+}
+
+func completionFlags(flags *pflag.FlagSet) []completionFlag {
+	var completionFlags []completionFlag
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+		completionFlags = append(completionFlags, completionFlag{
+			name:      flag.Name,
+			shorthand: flag.Shorthand,
+			usage:     flag.Usage,
+		})
+	})
+	return completionFlags
+}
+
+// Quote a string in single quotes for use in a shell script.
+func singleQuote(str string) string {
+	return "'" + strings.Replace(str, "'", `'\''`, -1) + "'"
+}
+
+func flagWords(flags []completionFlag) string {
+	var words []string
+	for _, flag := range flags {
+		words = append(words, flag.words()...)
+	}
+	return strings.Join(words, " ")
+}
+
+// bashCompletionScript generates wash's bash completion. Arguments are
+// completed with `wash complete`, which prints nothing for local paths; the
+// scripts fall back to completing files in that case.
+func bashCompletionScript(commands []completionSpec) string {
+	var names []string
+	var cases string
+	for _, command := range commands[1:] {
+		names = append(names, command.names...)
+		cases += fmt.Sprintf("\t\t%v) flags=%v ;;\n", strings.Join(command.names, "|"), singleQuote(flagWords(command.flags)))
+	}
+
+	return `# bash completion for wash
+_wash_completion() {
+	local cur=${COMP_WORDS[COMP_CWORD]}
+	if [[ ${COMP_CWORD} -eq 1 ]]; then
+		if [[ ${cur} == -* ]]; then
+			COMPREPLY=($(compgen -W ` + singleQuote(flagWords(commands[0].flags)) + ` -- "${cur}"))
+		else
+			COMPREPLY=($(compgen -W ` + singleQuote(strings.Join(names, " ")) + ` -- "${cur}"))
+		fi
+		return
+	fi
+
+	local command=${COMP_WORDS[1]} flags
+	case "${command}" in
+` + cases + `	esac
+	if [[ ${cur} == -* ]]; then
+		COMPREPLY=($(compgen -W "${flags} --help" -- "${cur}"))
+		return
+	fi
+
+	local IFS=$'\n'
+	COMPREPLY=($(wash complete --command "${command}" -- "${cur}" 2>/dev/null))
+	if [[ ${#COMPREPLY[@]} -eq 1 && ${COMPREPLY[0]} == */ ]]; then
+		compopt -o nospace
+	fi
+}
+complete -o default -F _wash_completion wash
+`
+}
+
+// zshCompletionScript is the zsh equivalent of bashCompletionScript. It can be
+// sourced or autoloaded from $fpath.
+func zshCompletionScript(commands []completionSpec) string {
+	var descriptions, cases string
+	for _, command := range commands[1:] {
+		for _, name := range command.names {
+			descriptions += "\t\t" + singleQuote(name+":"+command.short) + "\n"
+		}
+		cases += fmt.Sprintf("\t\t%v) flags=(%v) ;;\n", strings.Join(command.names, "|"), flagWords(command.flags))
+	}
+
+	return `#compdef wash
+_wash() {
+	if (( CURRENT == 2 )); then
+		if [[ ${words[CURRENT]} == -* ]]; then
+			compadd -- ` + flagWords(commands[0].flags) + `
+			return
+		fi
+		local -a commands
+		commands=(
+` + descriptions + `	)
+		_describe 'command' commands
+		return
+	fi
+
+	local command=${words[2]}
+	local -a flags
+	case "${command}" in
+` + cases + `	esac
+	if [[ ${words[CURRENT]} == -* ]]; then
+		compadd -- ${flags} --help
+		return
+	fi
+
+	local -a completions
+	completions=("${(@f)$(wash complete --command "${command}" -- "${words[CURRENT]}" 2>/dev/null)}")
+	completions=(${completions:#})
+	if (( ${#completions} == 0 )); then
+		_files
+		return
+	fi
+	compadd -Q -S '' -- ${(M)completions:#*/}
+	compadd -Q -- ${completions:#*/}
+}
+
+if [[ "${funcstack[1]}" == "_wash" ]]; then
+	_wash "$@"
+else
+	compdef _wash wash
+fi
+`
+}
+
+// fishCompletionScript is the fish equivalent of bashCompletionScript. Fish
+// also shows each command's and flag's description.
+func fishCompletionScript(commands []completionSpec) string {
+	script := `# fish completion for wash
+function __wash_complete_path
+	set -l tokens (commandline -opc)
+	wash complete --command $tokens[2] -- (commandline -ct) 2>/dev/null
+end
+
+complete -c wash -n 'not __fish_use_subcommand' -a '(__wash_complete_path)'
+`
+	flagCompletion := func(condition string, flag completionFlag) string {
+		line := "complete -c wash -n " + singleQuote(condition) + " -l " + flag.name
+		if flag.shorthand != "" {
+			line += " -s " + flag.shorthand
+		}
+		return line + " -d " + singleQuote(flag.usage) + "\n"
+	}
+	for _, flag := range commands[0].flags {
+		script += flagCompletion("__fish_use_subcommand", flag)
+	}
+	for _, command := range commands[1:] {
+		for _, name := range command.names {
+			script += "complete -c wash -n '__fish_use_subcommand' -a " + name + " -d " + singleQuote(command.short) + "\n"
+		}
+		for _, flag := range command.flags {
+			script += flagCompletion("__fish_seen_subcommand_from "+strings.Join(command.names, " "), flag)
+		}
+	}
+	return script
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletionScripts(t *testing.T) {
+	root := &cobra.Command{Use: "wash"}
+	root.Flags().String("config-file", "", "Set the config file's location")
+	ls := &cobra.Command{Use: "ls", Aliases: []string{"wls"}, Short: "Lists the children", Run: func(*cobra.Command, []string) {}}
+	ls.Flags().BoolP("long", "l", false, "Show long format")
+	hidden := &cobra.Command{Use: "complete", Hidden: true, Run: func(*cobra.Command, []string) {}}
+	root.AddCommand(ls, hidden)
+
+	commands := completionCommands(root)
+	if assert.Len(t, commands, 2) {
+		assert.Equal(t, []string{"ls", "wls"}, commands[1].names)
+		assert.Equal(t, "--long -l", flagWords(commands[1].flags))
+	}
+
+	bash := bashCompletionScript(commands)
+	assert.Contains(t, bash, "compgen -W '--config-file'")
+	assert.Contains(t, bash, "compgen -W 'ls wls'")
+	assert.Contains(t, bash, "ls|wls) flags='--long -l' ;;")
+	assert.NotContains(t, bash, "complete)")
+
+	zsh := zshCompletionScript(commands)
+	assert.Contains(t, zsh, "'wls:Lists the children'")
+	assert.Contains(t, zsh, "ls|wls) flags=(--long -l) ;;")
+
+	fish := fishCompletionScript(commands)
+	assert.Contains(t, fish, "complete -c wash -n '__fish_use_subcommand' -a ls -d 'Lists the children'")
+	assert.Contains(t, fish, "complete -c wash -n '__fish_seen_subcommand_from ls wls' -l long -s l -d 'Show long format'")
+	assert.Contains(t, fish, "-l config-file -d 'Set the config file'\\''s location'")
+}
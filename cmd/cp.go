@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/puppetlabs/wash/api/client"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func cpCommand() *cobra.Command {
+	use, aliases := generateShellAlias("cp")
+	cpCmd := &cobra.Command{
+		Use:     use + " [-r] <source>... <dest>",
+		Aliases: aliases,
+		Short:   "Copies entries between wash paths and local paths",
+		Long: `Copies the content of each source to dest. Sources and dest can be any
+combination of wash paths (paths inside the Wash mountpoint, $W) and local
+paths. If there are multiple sources or dest is a directory, then each source is
+copied into dest. Use -r to copy directories (parent entries) recursively.
+
+When both the source and dest are wash paths, the content is copied by the Wash
+server. Otherwise, it is streamed between the server and the local filesystem.
+Either way, this is faster and more reliable than copying through the FUSE mount.
+
+The copied wash entries must support the read action, and the destination
+entries must support the write action. Missing destination entries and
+directories are created by their parent's create action, so their parent must
+support it. The exception is wash entries that support the copy action, like
+S3 objects, when they're copied to the same plugin. Their plugin copies them
+itself, so their content isn't read and the destination isn't created first.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: toRunE(cpMain),
+	}
+	cpCmd.Flags().BoolP("recursive", "r", false, "Copy directories recursively")
+	cpCmd.Flags().BoolP("quiet", "q", false, "Do not print the copied files or the copy's progress")
+
+	return cpCmd
+}
+
+func cpMain(cmd *cobra.Command, args []string) exitCode {
+	recursive, err := cmd.Flags().GetBool("recursive")
+	if err != nil {
+		panic(err.Error())
+	}
+	quiet, err := cmd.Flags().GetBool("quiet")
+	if err != nil {
+		panic(err.Error())
+	}
+
+	sources := args[:len(args)-1]
+	dest := args[len(args)-1]
+
+	c := &copier{
+		conn:      cmdutil.NewClient(),
+		recursive: recursive,
+		quiet:     quiet,
+	}
+
+	_, destIsDir, err := c.stat(dest)
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	if len(sources) > 1 && !destIsDir {
+		cmdutil.ErrPrintf("%v is not a directory\n", dest)
+		return exitCode{1}
+	}
+
+	ec := 0
+	for _, src := range sources {
+		dst := dest
+		if destIsDir {
+			dst = filepath.Join(dest, filepath.Base(src))
+		}
+		if err := c.copy(src, dst); err != nil {
+			cmdutil.ErrPrintf("%v\n", err)
+			ec = 1
+		}
+	}
+	return exitCode{ec}
+}
+
+// copier copies entries between wash paths and local paths.
+type copier struct {
+	conn      client.Client
+	recursive bool
+	quiet     bool
+}
+
+// stat returns whether path exists and whether it is a directory, i.e. a
+// local directory or a wash entry that supports the list action.
+func (c *copier) stat(path string) (exists bool, isDir bool, err error) {
+	if cmdutil.IsWashPath(path) {
+		e, err := c.conn.Info(path)
+		if err != nil {
+			// We can't tell if the error's a "not found" error, so treat the
+			// entry as missing. Copying to it will return a better error.
+			return false, false, nil
+		}
+		return true, e.Supports(plugin.ListAction()), nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	return true, info.IsDir(), nil
+}
+
+// children returns the names of the directory's children
+func (c *copier) children(dir string) ([]string, error) {
+	var names []string
+	if cmdutil.IsWashPath(dir) {
+		entries, err := c.conn.List(dir)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", dir, err)
+		}
+		for _, e := range entries {
+			names = append(names, e.CName)
+		}
+		return names, nil
+	}
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.Readdirnames(-1)
+}
+
+// mkdir ensures that dir exists. It creates dir and its missing parents if
+// they don't exist.
+func (c *copier) mkdir(dir string) error {
+	exists, isDir, err := c.stat(dir)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if !isDir {
+			return fmt.Errorf("%v is not a directory", dir)
+		}
+		return nil
+	}
+	return mkdir(c.conn, dir, true)
+}
+
+// createFile creates the wash file dst if it doesn't exist so that it can be
+// written. It's skipped if src is a wash entry that supports the copy action,
+// because the plugin creates dst when it copies src.
+func (c *copier) createFile(src string, dst string) error {
+	exists, _, err := c.stat(dst)
+	if err != nil || exists {
+		return err
+	}
+	if cmdutil.IsWashPath(src) {
+		if e, err := c.conn.Info(src); err == nil && e.Supports(plugin.CopyAction()) {
+			return nil
+		}
+	}
+	var attr plugin.EntryAttributes
+	attr.SetMode(0640)
+	_, err = createWashEntry(c.conn, dst, attr)
+	return err
+}
+
+func (c *copier) copy(src string, dst string) error {
+	exists, isDir, err := c.stat(src)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%v: no such file or directory", src)
+	}
+	if !isDir {
+		return c.copyFile(src, dst)
+	}
+	if !c.recursive {
+		return fmt.Errorf("%v is a directory (not copied)", src)
+	}
+	if err := c.mkdir(dst); err != nil {
+		return err
+	}
+	children, err := c.children(src)
+	if err != nil {
+		return err
+	}
+	// Keep going if a child fails to copy so that one bad entry doesn't
+	// abort the entire copy.
+	var copyErr error
+	for _, child := range children {
+		if err := c.copy(filepath.Join(src, child), filepath.Join(dst, child)); err != nil {
+			cmdutil.ErrPrintf("%v\n", err)
+			copyErr = fmt.Errorf("%v: some entries could not be copied", src)
+		}
+	}
+	return copyErr
+}
+
+func (c *copier) copyFile(src string, dst string) error {
+	srcIsWash, dstIsWash := cmdutil.IsWashPath(src), cmdutil.IsWashPath(dst)
+	var n int64
+	var err error
+	switch {
+	case srcIsWash && dstIsWash:
+		// Let the server copy the content so that it isn't sent to us. The
+		// server lets the plugin copy the entry if it supports the copy action.
+		if err = c.createFile(src, dst); err != nil {
+			break
+		}
+		err = c.conn.Copy(src, dst)
+		n = -1
+	case srcIsWash:
+		var rdr io.ReadCloser
+		if rdr, err = c.conn.Read(src); err != nil {
+			break
+		}
+		defer rdr.Close()
+		n, err = c.writeLocal(dst, c.track(src, rdr))
+	case dstIsWash:
+		var f *os.File
+		if f, err = os.Open(src); err != nil {
+			break
+		}
+		defer f.Close()
+		if err = c.createFile(src, dst); err != nil {
+			break
+		}
+		t := c.track(src, f)
+		err = c.conn.Write(dst, t)
+		n = t.n
+	default:
+		var f *os.File
+		if f, err = os.Open(src); err != nil {
+			break
+		}
+		defer f.Close()
+		n, err = c.writeLocal(dst, c.track(src, f))
+	}
+	if err != nil {
+		return fmt.Errorf("could not copy %v to %v: %v", src, dst, err)
+	}
+	if !c.quiet {
+		if n >= 0 {
+			cmdutil.Printf("%v -> %v (%v)\n", src, dst, cmdutil.FormatBytes(uint64(n)))
+		} else {
+			cmdutil.Printf("%v -> %v\n", src, dst)
+		}
+	}
+	return nil
+}
+
+func (c *copier) writeLocal(dst string, rdr io.Reader) (int64, error) {
+	f, err := os.Create(dst)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(f, rdr)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
+
+func (c *copier) track(path string, rdr io.Reader) *progressReader {
+	return &progressReader{
+		Reader: rdr,
+		path:   path,
+		show:   !c.quiet && plugin.IsInteractive(),
+	}
+}
+
+// progressReader counts the bytes that are read from the underlying reader.
+// If show is set, then it periodically prints the count to stderr.
+type progressReader struct {
+	io.Reader
+	path    string
+	show    bool
+	n       int64
+	printed time.Time
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+	if r.show && (err != nil || time.Since(r.printed) >= 100*time.Millisecond) {
+		r.printed = time.Now()
+		fmt.Fprintf(cmdutil.Stderr, "\r\033[K%v: %v", r.path, cmdutil.FormatBytes(uint64(r.n)))
+		if err != nil {
+			fmt.Fprint(cmdutil.Stderr, "\r\033[K")
+		}
+	}
+	return n, err
+}
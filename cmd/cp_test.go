@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func fileAttr() plugin.EntryAttributes {
+	var attr plugin.EntryAttributes
+	attr.SetMode(0640)
+	return attr
+}
+
+func TestCopier_WashToWash(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	conn := &cmdtest.MockClient{}
+	c := &copier{conn: conn, quiet: true}
+	conn.On("Info", "/wash/docker/volumes/v/a.txt").Return(apitypes.Entry{Actions: []string{"read"}}, nil)
+	conn.On("Info", "/wash/s3/bucket/a.txt").Return(apitypes.Entry{Actions: []string{"read", "write"}}, nil)
+	conn.On("Copy", "/wash/docker/volumes/v/a.txt", "/wash/s3/bucket/a.txt").Return(nil)
+	assert.NoError(t, c.copy("/wash/docker/volumes/v/a.txt", "/wash/s3/bucket/a.txt"))
+	conn.AssertExpectations(t)
+	conn.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCopier_WashToWash_CreatesMissingDest(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	conn := &cmdtest.MockClient{}
+	c := &copier{conn: conn, quiet: true}
+	conn.On("Info", "/wash/docker/volumes/v/a.txt").Return(apitypes.Entry{Actions: []string{"read"}}, nil)
+	conn.On("Info", "/wash/s3/bucket/a.txt").Return(apitypes.Entry{}, errors.New("not found"))
+	conn.On("Create", "/wash/s3/bucket", "a.txt", fileAttr()).Return(apitypes.Entry{CName: "a.txt"}, nil)
+	conn.On("Copy", "/wash/docker/volumes/v/a.txt", "/wash/s3/bucket/a.txt").Return(nil)
+	assert.NoError(t, c.copy("/wash/docker/volumes/v/a.txt", "/wash/s3/bucket/a.txt"))
+	conn.AssertExpectations(t)
+}
+
+func TestCopier_WashToWash_CopyActionDoesNotCreateDest(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	conn := &cmdtest.MockClient{}
+	c := &copier{conn: conn, quiet: true}
+	conn.On("Info", "/wash/s3/bucket/a.txt").Return(apitypes.Entry{Actions: []string{"read", "copy"}}, nil)
+	conn.On("Info", "/wash/s3/bucket/b.txt").Return(apitypes.Entry{}, errors.New("not found"))
+	conn.On("Copy", "/wash/s3/bucket/a.txt", "/wash/s3/bucket/b.txt").Return(nil)
+	assert.NoError(t, c.copy("/wash/s3/bucket/a.txt", "/wash/s3/bucket/b.txt"))
+	conn.AssertExpectations(t)
+	conn.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCopier_WashToLocal(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+	tmpdir, err := ioutil.TempDir("", "testCopier")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(tmpdir)
+
+	conn := &cmdtest.MockClient{}
+	c := &copier{conn: conn, quiet: true}
+	conn.On("Info", "/wash/s3/bucket/a.txt").Return(apitypes.Entry{Actions: []string{"read"}}, nil)
+	conn.On("Read", "/wash/s3/bucket/a.txt").Return(ioutil.NopCloser(strings.NewReader("hello")), nil)
+	dst := filepath.Join(tmpdir, "a.txt")
+	if assert.NoError(t, c.copy("/wash/s3/bucket/a.txt", dst)) {
+		content, err := ioutil.ReadFile(dst)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(content))
+	}
+}
+
+func TestCopier_LocalToWash(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+	tmpdir, err := ioutil.TempDir("", "testCopier")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(tmpdir)
+	src := filepath.Join(tmpdir, "a.txt")
+	if !assert.NoError(t, ioutil.WriteFile(src, []byte("hello"), 0640)) {
+		return
+	}
+
+	conn := &cmdtest.MockClient{}
+	c := &copier{conn: conn, quiet: true}
+	conn.On("Info", "/wash/s3/bucket/a.txt").Return(apitypes.Entry{}, errors.New("not found"))
+	conn.On("Create", "/wash/s3/bucket", "a.txt", fileAttr()).Return(apitypes.Entry{CName: "a.txt"}, nil)
+	conn.On("Write", "/wash/s3/bucket/a.txt", mock.Anything).Return(nil)
+	assert.NoError(t, c.copy(src, "/wash/s3/bucket/a.txt"))
+	conn.AssertExpectations(t)
+}
+
+func TestCopier_Recursive(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+	tmpdir, err := ioutil.TempDir("", "testCopier")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(tmpdir)
+	src := filepath.Join(tmpdir, "dir")
+	if !assert.NoError(t, os.Mkdir(src, 0750)) {
+		return
+	}
+	if !assert.NoError(t, ioutil.WriteFile(filepath.Join(src, "a.txt"), []byte("hello"), 0640)) {
+		return
+	}
+
+	conn := &cmdtest.MockClient{}
+	c := &copier{conn: conn, quiet: true}
+	assert.EqualError(t, c.copy(src, "/wash/s3/bucket/dir"), src+" is a directory (not copied)")
+
+	// The missing dest directory and its files are created
+	c.recursive = true
+	notFound := errors.New("not found")
+	conn.On("Info", "/wash/s3/bucket/dir").Return(apitypes.Entry{}, notFound)
+	conn.On("Info", "/wash/s3/bucket").Return(apitypes.Entry{Actions: []string{"list", "create"}}, nil)
+	conn.On("Create", "/wash/s3/bucket", "dir", dirAttr()).Return(apitypes.Entry{CName: "dir"}, nil)
+	conn.On("Info", "/wash/s3/bucket/dir/a.txt").Return(apitypes.Entry{}, notFound)
+	conn.On("Create", "/wash/s3/bucket/dir", "a.txt", fileAttr()).Return(apitypes.Entry{CName: "a.txt"}, nil)
+	conn.On("Write", "/wash/s3/bucket/dir/a.txt", mock.Anything).Return(nil)
+	assert.NoError(t, c.copy(src, "/wash/s3/bucket/dir"))
+	conn.AssertExpectations(t)
+}
+
+func TestCopier_DestIsNotADirectory(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	conn := &cmdtest.MockClient{}
+	c := &copier{conn: conn, recursive: true, quiet: true}
+	conn.On("Info", "/wash/s3/bucket/dir").Return(apitypes.Entry{Actions: []string{"list"}}, nil)
+	conn.On("Info", "/wash/s3/other/a.txt").Return(apitypes.Entry{Actions: []string{"read", "write"}}, nil)
+	err := c.copy("/wash/s3/bucket/dir", "/wash/s3/other/a.txt")
+	assert.EqualError(t, err, "/wash/s3/other/a.txt is not a directory")
+}
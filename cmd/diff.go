@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+
+	"github.com/puppetlabs/wash/api/client"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+)
+
+func diffCommand() *cobra.Command {
+	use, aliases := generateShellAlias("diff")
+	diffCmd := &cobra.Command{
+		Use:     use + " [--meta] <path1> <path2>",
+		Aliases: aliases,
+		Short:   "Compares the content or metadata of two entries",
+		Long: `Prints a unified diff of the content of the entries at path1 and path2. Either
+path can be a local path, so diff can compare a wash entry against a local file.
+
+If --meta is set, then diff compares the entries' metadata instead. Each changed
+field is printed on its own line as
+  + <key sequence>: <value>           (field was added)
+  - <key sequence>: <value>           (field was removed)
+  ~ <key sequence>: <old> -> <new>    (field was changed)
+where the key sequence has the same syntax as the meta primary's key sequence in
+'wash find'. A local path is parsed as a JSON or YAML metadata document. For
+example,
+  diff --meta kubernetes/ctx/default/configmaps/foo configmap.yaml
+compares a live ConfigMap against its checked-in copy.
+
+diff exits with 0 if there are no differences, 1 if there are differences, and 2
+if an error occurred.`,
+		Args: cobra.ExactArgs(2),
+		RunE: toRunE(diffMain),
+	}
+	diffCmd.Flags().Bool("meta", false, "Compare the entries' metadata instead of their content")
+	diffCmd.Flags().IntP("unified", "U", 3, "Print n lines of context in the content diff")
+
+	return diffCmd
+}
+
+func diffMain(cmd *cobra.Command, args []string) exitCode {
+	meta, err := cmd.Flags().GetBool("meta")
+	if err != nil {
+		panic(err.Error())
+	}
+	context, err := cmd.Flags().GetInt("unified")
+	if err != nil {
+		panic(err.Error())
+	}
+	a, b := args[0], args[1]
+
+	var diff string
+	if meta {
+		ma, err := diffMetadata(a)
+		if err != nil {
+			cmdutil.ErrPrintf("%v: %v\n", a, err)
+			return exitCode{2}
+		}
+		mb, err := diffMetadata(b)
+		if err != nil {
+			cmdutil.ErrPrintf("%v: %v\n", b, err)
+			return exitCode{2}
+		}
+		diff = strings.Join(metadataDiff("", ma, mb), "\n")
+		if diff != "" {
+			diff += "\n"
+		}
+	} else {
+		ca, err := diffContent(a)
+		if err != nil {
+			cmdutil.ErrPrintf("%v: %v\n", a, err)
+			return exitCode{2}
+		}
+		cb, err := diffContent(b)
+		if err != nil {
+			cmdutil.ErrPrintf("%v: %v\n", b, err)
+			return exitCode{2}
+		}
+		diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(ca),
+			B:        difflib.SplitLines(cb),
+			FromFile: a,
+			ToFile:   b,
+			Context:  context,
+		})
+		if err != nil {
+			cmdutil.ErrPrintf("failed to diff %v and %v: %v\n", a, b, err)
+			return exitCode{2}
+		}
+	}
+
+	if diff == "" {
+		return exitCode{0}
+	}
+	cmdutil.Print(diff)
+	return exitCode{1}
+}
+
+// diffContent returns the content of the entry at path
+func diffContent(path string) (string, error) {
+	if !cmdutil.IsWashPath(path) {
+		content, err := ioutil.ReadFile(path)
+		return string(content), err
+	}
+	content, err := readAll(cmdutil.NewClient(), path)
+	return string(content), err
+}
+
+// diffMetadata returns the metadata of the entry at path. Local paths are
+// parsed as JSON or YAML metadata documents.
+func diffMetadata(path string) (interface{}, error) {
+	var metadata interface{}
+	if !cmdutil.IsWashPath(path) {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(content, &metadata); err != nil {
+			return nil, fmt.Errorf("could not parse the metadata: %v", err)
+		}
+		return metadata, nil
+	}
+	return normalizedMetadata(cmdutil.NewClient(), path)
+}
+
+// normalizedMetadata returns the metadata of the wash entry at path. The
+// metadata is round-tripped through JSON so that its values have the same
+// types as a parsed local document.
+func normalizedMetadata(conn client.Client, path string) (interface{}, error) {
+	m, err := conn.Metadata(path)
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var metadata interface{}
+	if err := json.Unmarshal(bytes, &metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// metadataDiff returns the differences between a and b. Each difference is
+// prefixed with the key sequence of the changed field.
+func metadataDiff(keySequence string, a interface{}, b interface{}) []string {
+	ma, aIsMap := a.(map[string]interface{})
+	mb, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		keys := make(map[string]struct{})
+		for k := range ma {
+			keys[k] = struct{}{}
+		}
+		for k := range mb {
+			keys[k] = struct{}{}
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for k := range keys {
+			sortedKeys = append(sortedKeys, k)
+		}
+		sort.Strings(sortedKeys)
+
+		var diffs []string
+		for _, k := range sortedKeys {
+			va, inA := ma[k]
+			vb, inB := mb[k]
+			key := keySequence + "." + k
+			switch {
+			case !inA:
+				diffs = append(diffs, fmt.Sprintf("+ %v: %v", key, diffValue(vb)))
+			case !inB:
+				diffs = append(diffs, fmt.Sprintf("- %v: %v", key, diffValue(va)))
+			default:
+				diffs = append(diffs, metadataDiff(key, va, vb)...)
+			}
+		}
+		return diffs
+	}
+
+	sa, aIsArray := a.([]interface{})
+	sb, bIsArray := b.([]interface{})
+	if aIsArray && bIsArray {
+		var diffs []string
+		for i := 0; i < len(sa) || i < len(sb); i++ {
+			key := fmt.Sprintf("%v[%v]", keySequence, i)
+			switch {
+			case i >= len(sa):
+				diffs = append(diffs, fmt.Sprintf("+ %v: %v", key, diffValue(sb[i])))
+			case i >= len(sb):
+				diffs = append(diffs, fmt.Sprintf("- %v: %v", key, diffValue(sa[i])))
+			default:
+				diffs = append(diffs, metadataDiff(key, sa[i], sb[i])...)
+			}
+		}
+		return diffs
+	}
+
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+	if keySequence == "" {
+		keySequence = "."
+	}
+	return []string{fmt.Sprintf("~ %v: %v -> %v", keySequence, diffValue(a), diffValue(b))}
+}
+
+func diffValue(v interface{}) string {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(bytes)
+}
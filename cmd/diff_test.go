@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataDiff(t *testing.T) {
+	a := map[string]interface{}{
+		"name":   "foo",
+		"labels": map[string]interface{}{"app": "web", "tier": "frontend"},
+		"ports":  []interface{}{float64(80), float64(443)},
+		"state":  "running",
+	}
+	b := map[string]interface{}{
+		"name":   "foo",
+		"labels": map[string]interface{}{"app": "api"},
+		"ports":  []interface{}{float64(80), float64(443), float64(8080)},
+		"owner":  "bar",
+	}
+	assert.Equal(t, []string{
+		`~ .labels.app: "web" -> "api"`,
+		`- .labels.tier: "frontend"`,
+		`+ .owner: "bar"`,
+		`+ .ports[2]: 8080`,
+		`- .state: "running"`,
+	}, metadataDiff("", a, b))
+
+	assert.Empty(t, metadataDiff("", a, a))
+	assert.Equal(t, []string{`~ .: "foo" -> ["foo"]`}, metadataDiff("", "foo", []interface{}{"foo"}))
+}
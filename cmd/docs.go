@@ -3,10 +3,13 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/kballard/go-shellquote"
+	"github.com/puppetlabs/wash/api/client"
 	apitypes "github.com/puppetlabs/wash/api/types"
 	cmdutil "github.com/puppetlabs/wash/cmd/util"
 	"github.com/puppetlabs/wash/plugin"
@@ -17,7 +20,11 @@ func docsCommand() *cobra.Command {
 	docsCmd := &cobra.Command{
 		Use:   "docs <path>",
 		Short: "Displays the entry's documentation",
-		RunE:  toRunE(docsMain),
+		Long: `Displays the entry's documentation. This includes its description, its
+supported attributes and actions (along with the plugin methods that implement
+them), its signals, the kinds of children it has, and other entries of the same
+type in the live tree.`,
+		RunE: toRunE(docsMain),
 	}
 	return docsCmd
 }
@@ -45,16 +52,18 @@ func docsMain(cmd *cobra.Command, args []string) exitCode {
 
 	// Print the description
 	if schema != nil && len(schema.Description()) > 0 {
-		addSection(docs, strings.Trim(schema.Description(), "\n"))
+		addSection(docs, renderMarkdown(strings.Trim(schema.Description(), "\n")))
 	}
 
-	if mountpoint := os.Getenv("W"); len(mountpoint) > 0 {
-		if strings.HasPrefix(path, mountpoint) {
-			// Munge the path so that something like 'docs $W/gcp'
-			// will generate examples like 'ls $W/gcp' instead of
-			// 'ls <mountpoint>/gcp'.
-			path = "$W" + strings.TrimPrefix(path, mountpoint)
-		}
+	// Find other entries of the same type before munging the path
+	var examples []string
+	if len(entry.TypeID) > 0 {
+		examples = findExamples(conn, path, entry)
+	}
+
+	path = mungeDocsPath(path)
+	for i, example := range examples {
+		examples[i] = mungeDocsPath(example)
 	}
 
 	// Print the supported attributes. This part is printed as
@@ -112,10 +121,61 @@ func docsMain(cmd *cobra.Command, args []string) exitCode {
 		}
 	}
 
+	// Print the kinds of children. This part is printed as
+	//   CHILDREN
+	//     * <label>
+	//     * [<label>]
+	//
+	// where [<label>] means that there can be multiple children of that kind.
+	if schema != nil && len(schema.Children()) > 0 {
+		addSection(docs, stringifyChildren(path, schema))
+	}
+
+	// Print other entries of the same type
+	if len(examples) > 0 {
+		addSection(docs, stringifyExamples(examples))
+	}
+
 	cmdutil.Println(docs.String())
 	return exitCode{0}
 }
 
+// mungeDocsPath munges the path so that something like 'docs $W/gcp' will
+// generate examples like 'ls $W/gcp' instead of 'ls <mountpoint>/gcp'.
+func mungeDocsPath(path string) string {
+	if mountpoint := os.Getenv("W"); len(mountpoint) > 0 {
+		if strings.HasPrefix(path, mountpoint) {
+			return "$W" + strings.TrimPrefix(path, mountpoint)
+		}
+	}
+	return path
+}
+
+// The maximum number of other entries of the same type to print
+const maxExamples = 3
+
+// findExamples returns the paths of up to maxExamples siblings of the entry
+// at path that have the same type.
+func findExamples(conn client.Client, path string, entry apitypes.Entry) []string {
+	parent := filepath.Join(path, "..")
+	siblings, err := conn.List(parent)
+	if err != nil {
+		// Examples are optional, so ignore the error
+		return nil
+	}
+	var examples []string
+	for _, sibling := range siblings {
+		if sibling.TypeID != entry.TypeID || sibling.Path == entry.Path {
+			continue
+		}
+		examples = append(examples, filepath.Join(parent, sibling.CName))
+		if len(examples) >= maxExamples {
+			break
+		}
+	}
+	return examples
+}
+
 func stringifySupportedAttributes(path string, entry apitypes.Entry) string {
 	path = shellquote.Join(path)
 	var supportedAttributes strings.Builder
@@ -167,7 +227,11 @@ func stringifySupportedActions(path string, entry apitypes.Entry) string {
 	actions := entry.Actions
 	sort.Strings(actions)
 	for _, action := range actions {
-		supportedActions.WriteString(fmt.Sprintf("* %v\n", action))
+		supportedActions.WriteString(fmt.Sprintf("* %v", action))
+		if signature, ok := actionSignatures[action]; ok {
+			supportedActions.WriteString(fmt.Sprintf(" -- %v", signature))
+		}
+		supportedActions.WriteString("\n")
 		var actionDescriptionLines []string
 		switch action {
 		case plugin.ListAction().Name:
@@ -236,6 +300,76 @@ func stringifySupportedActions(path string, entry apitypes.Entry) string {
 	return supportedActions.String()
 }
 
+// actionSignatures maps an action to the plugin method that implements it
+var actionSignatures = map[string]string{
+	plugin.ListAction().Name:   "Parent#List(ctx) ([]Entry, error)",
+	plugin.ReadAction().Name:   "Readable#Read(ctx) ([]byte, error) or BlockReadable#Read(ctx, size, offset) ([]byte, error)",
+	plugin.StreamAction().Name: "Streamable#Stream(ctx) (io.ReadCloser, error)",
+	plugin.WriteAction().Name:  "Writable#Write(ctx, data) error",
+	plugin.ExecAction().Name:   "Execable#Exec(ctx, cmd, args, opts) (ExecCommand, error)",
+	plugin.DeleteAction().Name: "Deletable#Delete(ctx) (deleted bool, error)",
+	plugin.SignalAction().Name: "Signalable#Signal(ctx, signal) error",
+}
+
+func stringifyChildren(path string, schema *apitypes.EntrySchema) string {
+	var children strings.Builder
+	children.WriteString("CHILDREN\n")
+	for _, child := range schema.Children() {
+		label := child.Label()
+		if !child.Singleton() {
+			label = fmt.Sprintf("[%v]", label)
+		}
+		children.WriteString(fmt.Sprintf("* %v\n", label))
+	}
+	children.WriteString("\n[<label>] means that there can be multiple children of that kind. Use\n")
+	children.WriteString(fmt.Sprintf("    stree %s\n", shellquote.Join(path)))
+	children.WriteString("to see the rest of the hierarchy.")
+	return children.String()
+}
+
+func stringifyExamples(examples []string) string {
+	var section strings.Builder
+	section.WriteString("OTHER ENTRIES OF THIS TYPE\n")
+	for _, example := range examples {
+		section.WriteString(fmt.Sprintf("* %v\n", example))
+	}
+	return section.String()
+}
+
+// renderMarkdown renders the markdown in a description for the terminal.
+// Headings are upper-cased, code blocks are indented, and inline markup is
+// removed.
+func renderMarkdown(md string) string {
+	var lines []string
+	inCodeBlock := false
+	for _, line := range strings.Split(md, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			lines = append(lines, "    "+line)
+			continue
+		}
+		if heading := strings.TrimLeft(trimmed, "#"); heading != trimmed && strings.HasPrefix(heading, " ") {
+			lines = append(lines, strings.ToUpper(renderInlineMarkdown(strings.TrimSpace(heading))))
+			continue
+		}
+		lines = append(lines, renderInlineMarkdown(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+var markdownLinkRegex = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+var markdownEmphasisRegex = regexp.MustCompile(`(\*\*|__)(.+?)(\*\*|__)`)
+
+func renderInlineMarkdown(line string) string {
+	line = markdownLinkRegex.ReplaceAllString(line, "$1 ($2)")
+	line = markdownEmphasisRegex.ReplaceAllString(line, "$2")
+	return strings.Replace(line, "`", "'", -1)
+}
+
 func stringifySignalSet(setName string, signals []apitypes.SignalSchema) string {
 	var signalSet strings.Builder
 	signalSet.WriteString(fmt.Sprintf("%v\n", setName))
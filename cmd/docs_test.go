@@ -74,6 +74,8 @@ func (suite *DocsTestSuite) TestStringifySupportedActions() {
 	suite.Regexp(`exec.*\n.*wexec foo <command> <args\.\.\.>.*\n.*wexec foo uname`, supportedActions)
 	suite.Regexp("delete.*\n.*delete foo", supportedActions)
 	suite.Regexp("signal.*\n.*signal <signal> foo.*\n.*signal start foo", supportedActions)
+	suite.Regexp(`\* list -- Parent#List\(ctx\)`, supportedActions)
+	suite.Regexp(`\* exec -- Execable#Exec\(ctx, cmd, args, opts\)`, supportedActions)
 
 	// Test non-file-like entry
 	entry.Actions = []string{"read", "write"}
@@ -104,6 +106,19 @@ func (suite *DocsTestSuite) TestStringifySignalSet() {
 	suite.Regexp(".*bar.*\n.*bar signal", supportedSignals)
 }
 
+func (suite *DocsTestSuite) TestRenderMarkdown() {
+	md := "# Containers\n" +
+		"A **running** container. See [the docs](https://docs.docker.com) or use `docker ps`.\n" +
+		"```\n" +
+		"wexec foo uname\n" +
+		"```\n" +
+		"#nottitle"
+	suite.Equal("CONTAINERS\n"+
+		"A running container. See the docs (https://docs.docker.com) or use 'docker ps'.\n"+
+		"    wexec foo uname\n"+
+		"#nottitle", renderMarkdown(md))
+}
+
 func TestDocs(t *testing.T) {
 	suite.Run(t, new(DocsTestSuite))
 }
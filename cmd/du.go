@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/puppetlabs/wash/api/client"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func duCommand() *cobra.Command {
+	use, aliases := generateShellAlias("du")
+	duCmd := &cobra.Command{
+		Use:     use + " [<path>...]",
+		Aliases: aliases,
+		Short:   "Summarizes the size of the entries at the specified paths",
+		Long: `Prints the total size of each parent entry under the specified paths. Defaults to
+the current directory if no path is provided. Sizes are printed in bytes unless
+-h is set.
+
+An entry's size is its size attribute. If an entry doesn't have a size attribute
+but supports the read action, then its content is read to get its size, which
+can be slow. Use --no-read to count those entries as empty instead.`,
+		RunE: toRunE(duMain),
+	}
+	duCmd.Flags().BoolP("human-readable", "h", false, "Print sizes in human readable format (e.g. 1.5K, 23M)")
+	duCmd.Flags().BoolP("all", "a", false, "Print the size of every entry, not just parents")
+	duCmd.Flags().BoolP("summarize", "s", false, "Only print the total size of each path")
+	duCmd.Flags().IntP("max-depth", "d", -1, "Only print the sizes of entries at most n levels below the paths")
+	duCmd.Flags().IntP("parallel", "p", 10, "List up to n entries concurrently")
+	duCmd.Flags().Bool("no-read", false, "Do not read the content of entries without a size attribute")
+	return duCmd
+}
+
+func duMain(cmd *cobra.Command, args []string) exitCode {
+	paths := []string{"."}
+	if len(args) > 0 {
+		paths = args
+	}
+	d := &du{conn: cmdutil.NewClient()}
+	var err error
+	if d.human, err = cmd.Flags().GetBool("human-readable"); err != nil {
+		panic(err.Error())
+	}
+	if d.all, err = cmd.Flags().GetBool("all"); err != nil {
+		panic(err.Error())
+	}
+	summarize, err := cmd.Flags().GetBool("summarize")
+	if err != nil {
+		panic(err.Error())
+	}
+	if d.maxDepth, err = cmd.Flags().GetInt("max-depth"); err != nil {
+		panic(err.Error())
+	}
+	if summarize {
+		d.maxDepth = 0
+	}
+	parallel, err := cmd.Flags().GetInt("parallel")
+	if err != nil {
+		panic(err.Error())
+	}
+	if parallel < 1 {
+		parallel = 1
+	}
+	d.sem = make(chan struct{}, parallel)
+	if d.noRead, err = cmd.Flags().GetBool("no-read"); err != nil {
+		panic(err.Error())
+	}
+
+	for _, path := range paths {
+		e, err := d.conn.Info(path)
+		if err != nil {
+			cmdutil.ErrPrintf("%v: %v\n", path, err)
+			d.failed = true
+			continue
+		}
+		d.print(d.walk(e, path), 0)
+	}
+	if d.failed {
+		return exitCode{1}
+	}
+	return exitCode{0}
+}
+
+// du aggregates the sizes of entries
+type du struct {
+	conn     client.Client
+	human    bool
+	all      bool
+	maxDepth int
+	noRead   bool
+	// sem bounds the number of concurrent API calls
+	sem    chan struct{}
+	mux    sync.Mutex
+	failed bool
+}
+
+type duNode struct {
+	path     string
+	size     uint64
+	isParent bool
+	children []*duNode
+}
+
+// walk returns the size tree rooted at e. The children of a parent are walked
+// concurrently.
+func (d *du) walk(e apitypes.Entry, path string) *duNode {
+	n := &duNode{path: path}
+	if !e.Supports(plugin.ListAction()) {
+		n.size = d.sizeOf(e, path)
+		return n
+	}
+	n.isParent = true
+	d.sem <- struct{}{}
+	children, err := d.conn.List(path)
+	<-d.sem
+	if err != nil {
+		d.fail(path, err)
+		return n
+	}
+	n.children = make([]*duNode, len(children))
+	var wg sync.WaitGroup
+	for i, child := range children {
+		wg.Add(1)
+		go func(i int, child apitypes.Entry) {
+			defer wg.Done()
+			n.children[i] = d.walk(child, filepath.Join(path, child.CName))
+		}(i, child)
+	}
+	wg.Wait()
+	for _, child := range n.children {
+		n.size += child.size
+	}
+	return n
+}
+
+func (d *du) sizeOf(e apitypes.Entry, path string) uint64 {
+	if e.Attributes.HasSize() {
+		return e.Attributes.Size()
+	}
+	if d.noRead || !e.Supports(plugin.ReadAction()) {
+		return 0
+	}
+	d.sem <- struct{}{}
+	defer func() { <-d.sem }()
+	rdr, err := d.conn.Read(path)
+	if err != nil {
+		d.fail(path, err)
+		return 0
+	}
+	defer rdr.Close()
+	n, err := io.Copy(ioutil.Discard, rdr)
+	if err != nil {
+		d.fail(path, err)
+	}
+	return uint64(n)
+}
+
+func (d *du) fail(path string, err error) {
+	d.mux.Lock()
+	d.failed = true
+	d.mux.Unlock()
+	cmdutil.SafeErrPrintf("%v: %v\n", path, err)
+}
+
+// print prints the tree in post-order, like du(1)
+func (d *du) print(n *duNode, depth int) {
+	for _, child := range n.children {
+		if child.isParent || d.all {
+			d.print(child, depth+1)
+		}
+	}
+	if d.maxDepth >= 0 && depth > d.maxDepth {
+		return
+	}
+	if !n.isParent && !d.all && depth > 0 {
+		return
+	}
+	size := strconv.FormatUint(n.size, 10)
+	if d.human {
+		size = cmdutil.FormatBytes(n.size)
+	}
+	cmdutil.Printf("%v\t%v\n", size, n.path)
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// captureOutput redirects cmdutil's Stdout and Stderr to buffers until
+// restore is called.
+func captureOutput() (stdout *bytes.Buffer, stderr *bytes.Buffer, restore func()) {
+	origStdout, origStderr := cmdutil.Stdout, cmdutil.ColoredStderr
+	stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+	cmdutil.Stdout, cmdutil.ColoredStderr = stdout, stderr
+	return stdout, stderr, func() {
+		cmdutil.Stdout, cmdutil.ColoredStderr = origStdout, origStderr
+	}
+}
+
+func sizeAttr(size uint64) plugin.EntryAttributes {
+	var attr plugin.EntryAttributes
+	attr.SetSize(size)
+	return attr
+}
+
+// newDuTestClient mocks a "dir" parent with a 10 byte "a" file and a "sub"
+// parent, whose "b" file doesn't have a size attribute and reads "hello".
+func newDuTestClient() *cmdtest.MockClient {
+	conn := &cmdtest.MockClient{}
+	conn.On("List", "dir").Return([]apitypes.Entry{
+		{CName: "a", Actions: []string{"read"}, Attributes: sizeAttr(10)},
+		{CName: "sub", Actions: []string{"list"}},
+	}, nil)
+	conn.On("List", "dir/sub").Return([]apitypes.Entry{
+		{CName: "b", Actions: []string{"read"}},
+	}, nil)
+	conn.On("Read", "dir/sub/b").Return(ioutil.NopCloser(strings.NewReader("hello")), nil)
+	return conn
+}
+
+func newTestDu(conn *cmdtest.MockClient) *du {
+	return &du{conn: conn, maxDepth: -1, sem: make(chan struct{}, 2)}
+}
+
+func TestDu_PrintsParents(t *testing.T) {
+	stdout, _, restore := captureOutput()
+	defer restore()
+
+	d := newTestDu(newDuTestClient())
+	d.print(d.walk(apitypes.Entry{Actions: []string{"list"}}, "dir"), 0)
+	assert.Equal(t, "5\tdir/sub\n15\tdir\n", stdout.String())
+	assert.False(t, d.failed)
+}
+
+func TestDu_All(t *testing.T) {
+	stdout, _, restore := captureOutput()
+	defer restore()
+
+	d := newTestDu(newDuTestClient())
+	d.all = true
+	d.print(d.walk(apitypes.Entry{Actions: []string{"list"}}, "dir"), 0)
+	assert.Equal(t, "10\tdir/a\n5\tdir/sub/b\n5\tdir/sub\n15\tdir\n", stdout.String())
+}
+
+func TestDu_MaxDepthAndHumanReadable(t *testing.T) {
+	stdout, _, restore := captureOutput()
+	defer restore()
+
+	d := newTestDu(newDuTestClient())
+	d.maxDepth = 0
+	d.human = true
+	d.print(d.walk(apitypes.Entry{Actions: []string{"list"}}, "dir"), 0)
+	assert.Equal(t, "15B\tdir\n", stdout.String())
+}
+
+func TestDu_NoRead(t *testing.T) {
+	stdout, _, restore := captureOutput()
+	defer restore()
+
+	conn := newDuTestClient()
+	d := newTestDu(conn)
+	d.noRead = true
+	d.print(d.walk(apitypes.Entry{Actions: []string{"list"}}, "dir"), 0)
+	assert.Equal(t, "0\tdir/sub\n10\tdir\n", stdout.String())
+	conn.AssertNotCalled(t, "Read", mock.Anything)
+}
+
+func TestDu_FileUsesSizeAttribute(t *testing.T) {
+	stdout, _, restore := captureOutput()
+	defer restore()
+
+	d := newTestDu(&cmdtest.MockClient{})
+	d.print(d.walk(apitypes.Entry{Actions: []string{"read"}, Attributes: sizeAttr(2048)}, "file"), 0)
+	assert.Equal(t, "2048\tfile\n", stdout.String())
+}
+
+func TestDu_ReportsErrors(t *testing.T) {
+	stdout, stderr, restore := captureOutput()
+	defer restore()
+
+	conn := &cmdtest.MockClient{}
+	conn.On("List", "dir").Return([]apitypes.Entry{
+		{CName: "a", Actions: []string{"read"}, Attributes: sizeAttr(10)},
+		{CName: "sub", Actions: []string{"list"}},
+	}, nil)
+	conn.On("List", "dir/sub").Return([]apitypes.Entry{}, errors.New("list failed"))
+	d := newTestDu(conn)
+	d.print(d.walk(apitypes.Entry{Actions: []string{"list"}}, "dir"), 0)
+	assert.Equal(t, "0\tdir/sub\n10\tdir\n", stdout.String())
+	assert.Contains(t, stderr.String(), "dir/sub: list failed")
+	assert.True(t, d.failed)
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kballard/go-shellquote"
+	"github.com/spf13/cobra"
+
+	"github.com/puppetlabs/wash/api/client"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+)
+
+func editCommand() *cobra.Command {
+	use, aliases := generateShellAlias("edit")
+	editCmd := &cobra.Command{
+		Use:     use + " <path>",
+		Aliases: aliases,
+		Short:   "Edits the content of the entry at the specified path",
+		Long: `Downloads the entry's content to a temporary file and opens it in $VISUAL or
+$EDITOR (vi if neither is set). Once the editor exits, the new content is written
+back to the entry with its write action. Nothing is written if the content was
+not changed.
+
+Before writing, edit re-reads the entry's content. If it changed since it was
+downloaded, e.g. because someone else edited it, then edit does not overwrite
+it and keeps the temporary file so that the changes aren't lost. Use --force to
+overwrite the entry anyway.
+
+Use --decompress to edit the decompressed content of a gzip or zstd compressed
+entry, e.g. an archived log. The edited content is compressed with the same
+format before it's written back.`,
+		Args: cobra.ExactArgs(1),
+		RunE: toRunE(editMain),
+	}
+	editCmd.Flags().BoolP("force", "f", false, "Overwrite the entry even if its content changed while it was edited")
+	editCmd.Flags().BoolP("decompress", "z", false, "Edit the decompressed content of a compressed entry")
+	return editCmd
+}
+
+func editMain(cmd *cobra.Command, args []string) exitCode {
+	path := args[0]
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		panic(err.Error())
+	}
+	decompress, err := cmd.Flags().GetBool("decompress")
+	if err != nil {
+		panic(err.Error())
+	}
+
+	conn := cmdutil.NewClient()
+	read, write := conn.Read, conn.Write
+	if decompress {
+		read, write = conn.ReadDecompressed, conn.WriteCompressed
+	}
+	original, err := readAllWith(read, path)
+	if err != nil {
+		cmdutil.ErrPrintf("%v: %v\n", path, err)
+		return exitCode{1}
+	}
+
+	// Keep the entry's name so that the editor can detect the file type
+	tmpdir, err := ioutil.TempDir("", "wash-edit")
+	if err != nil {
+		cmdutil.ErrPrintf("could not create a temporary directory: %v\n", err)
+		return exitCode{1}
+	}
+	name := filepath.Base(path)
+	if decompress {
+		name = strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ".zst")
+	}
+	tmpfile := filepath.Join(tmpdir, name)
+	keep := false
+	defer func() {
+		if !keep {
+			os.RemoveAll(tmpdir)
+		}
+	}()
+	if err := ioutil.WriteFile(tmpfile, original, 0600); err != nil {
+		cmdutil.ErrPrintf("could not write %v: %v\n", tmpfile, err)
+		return exitCode{1}
+	}
+
+	if err := runEditor(tmpfile); err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	edited, err := ioutil.ReadFile(tmpfile)
+	if err != nil {
+		cmdutil.ErrPrintf("could not read %v: %v\n", tmpfile, err)
+		return exitCode{1}
+	}
+	if bytes.Equal(original, edited) {
+		cmdutil.Printf("%v was not changed\n", path)
+		return exitCode{0}
+	}
+
+	if !force {
+		current, err := readAllWith(read, path)
+		if err != nil {
+			keep = true
+			cmdutil.ErrPrintf("could not check %v for conflicts: %v\nYour changes are saved in %v\n", path, err, tmpfile)
+			return exitCode{1}
+		}
+		if !bytes.Equal(original, current) {
+			keep = true
+			cmdutil.ErrPrintf("%v changed while it was being edited, so it was not overwritten.\n", path)
+			cmdutil.ErrPrintf("Your changes are saved in %v. Use --force to overwrite it.\n", tmpfile)
+			return exitCode{1}
+		}
+	}
+
+	if err := write(path, bytes.NewReader(edited)); err != nil {
+		keep = true
+		cmdutil.ErrPrintf("could not write %v: %v\nYour changes are saved in %v\n", path, err, tmpfile)
+		return exitCode{1}
+	}
+	cmdutil.Printf("%v has been updated\n", path)
+	return exitCode{0}
+}
+
+func readAll(conn client.Client, path string) ([]byte, error) {
+	return readAllWith(conn.Read, path)
+}
+
+func readAllWith(read func(string) (io.ReadCloser, error), path string) ([]byte, error) {
+	rdr, err := read(path)
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+	return ioutil.ReadAll(rdr)
+}
+
+// runEditor opens file in the user's editor and waits for it to exit
+func runEditor(file string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	// The editor can include arguments, e.g. "code --wait"
+	words, err := shellquote.Split(editor)
+	if err != nil || len(words) == 0 {
+		return fmt.Errorf("invalid editor %q: %v", editor, err)
+	}
+	cmd := exec.Command(words[0], append(words[1:], file)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%v exited with an error: %v", editor, err)
+	}
+	return nil
+}
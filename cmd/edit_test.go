@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/puppetlabs/wash/api/client"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+)
+
+// setupEditTest points edit at conn and at an editor that replaces the
+// file's content with "edited". Temporary files are created in a directory
+// that's removed by the returned cleanup function.
+func setupEditTest(t *testing.T, conn *cmdtest.MockClient) func() {
+	tmpdir, err := ioutil.TempDir("", "testEdit")
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "A temporary directory is required for further testing")
+	}
+	origNewClient := cmdutil.NewClient
+	cmdutil.NewClient = func() client.Client { return conn }
+	os.Setenv("TMPDIR", tmpdir)
+	os.Setenv("VISUAL", `sh -c 'printf edited > "$0"'`)
+	return func() {
+		cmdutil.NewClient = origNewClient
+		os.Unsetenv("TMPDIR")
+		os.Unsetenv("VISUAL")
+		os.RemoveAll(tmpdir)
+	}
+}
+
+func mockContent(str string) io.ReadCloser {
+	return ioutil.NopCloser(strings.NewReader(str))
+}
+
+func hasContent(expected string) interface{} {
+	return mock.MatchedBy(func(r io.Reader) bool {
+		actual, err := ioutil.ReadAll(r)
+		return err == nil && string(actual) == expected
+	})
+}
+
+func TestEdit_WritesEditedContent(t *testing.T) {
+	conn := &cmdtest.MockClient{}
+	defer setupEditTest(t, conn)()
+	stdout, _, restore := captureOutput()
+	defer restore()
+
+	conn.On("Read", "file.txt").Return(mockContent("original"), nil).Once()
+	conn.On("Read", "file.txt").Return(mockContent("original"), nil).Once()
+	conn.On("Write", "file.txt", hasContent("edited")).Return(nil).Once()
+	assert.Equal(t, exitCode{0}, editMain(editCommand(), []string{"file.txt"}))
+	assert.Equal(t, "file.txt has been updated\n", stdout.String())
+	conn.AssertExpectations(t)
+}
+
+func TestEdit_Unchanged(t *testing.T) {
+	conn := &cmdtest.MockClient{}
+	defer setupEditTest(t, conn)()
+	os.Setenv("VISUAL", "true")
+	stdout, _, restore := captureOutput()
+	defer restore()
+
+	conn.On("Read", "file.txt").Return(mockContent("original"), nil).Once()
+	assert.Equal(t, exitCode{0}, editMain(editCommand(), []string{"file.txt"}))
+	assert.Equal(t, "file.txt was not changed\n", stdout.String())
+	conn.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+}
+
+func TestEdit_Conflict(t *testing.T) {
+	conn := &cmdtest.MockClient{}
+	defer setupEditTest(t, conn)()
+	_, stderr, restore := captureOutput()
+	defer restore()
+
+	conn.On("Read", "file.txt").Return(mockContent("original"), nil).Once()
+	conn.On("Read", "file.txt").Return(mockContent("changed"), nil).Once()
+	assert.Equal(t, exitCode{1}, editMain(editCommand(), []string{"file.txt"}))
+	assert.Contains(t, stderr.String(), "file.txt changed while it was being edited")
+	conn.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+}
+
+func TestEdit_Force(t *testing.T) {
+	conn := &cmdtest.MockClient{}
+	defer setupEditTest(t, conn)()
+	_, _, restore := captureOutput()
+	defer restore()
+
+	conn.On("Read", "file.txt").Return(mockContent("original"), nil).Once()
+	conn.On("Write", "file.txt", hasContent("edited")).Return(nil).Once()
+	cmd := editCommand()
+	assert.NoError(t, cmd.Flags().Set("force", "true"))
+	assert.Equal(t, exitCode{0}, editMain(cmd, []string{"file.txt"}))
+	conn.AssertExpectations(t)
+}
+
+func TestEdit_Decompress(t *testing.T) {
+	conn := &cmdtest.MockClient{}
+	defer setupEditTest(t, conn)()
+	_, _, restore := captureOutput()
+	defer restore()
+
+	conn.On("ReadDecompressed", "app.log.gz").Return(mockContent("original"), nil).Twice()
+	conn.On("WriteCompressed", "app.log.gz", hasContent("edited")).Return(nil).Once()
+	cmd := editCommand()
+	assert.NoError(t, cmd.Flags().Set("decompress", "true"))
+	assert.Equal(t, exitCode{0}, editMain(cmd, []string{"app.log.gz"}))
+	conn.AssertExpectations(t)
+	conn.AssertNotCalled(t, "Read", mock.Anything)
+}
+
+func TestEdit_ReadFails(t *testing.T) {
+	conn := &cmdtest.MockClient{}
+	defer setupEditTest(t, conn)()
+	_, stderr, restore := captureOutput()
+	defer restore()
+
+	conn.On("Read", "file.txt").Return(mockContent(""), errors.New("the read action is not supported"))
+	assert.Equal(t, exitCode{1}, editMain(editCommand(), []string{"file.txt"}))
+	assert.Contains(t, stderr.String(), "file.txt: the read action is not supported")
+}
+
+func TestRunEditor(t *testing.T) {
+	defer os.Unsetenv("VISUAL")
+	defer os.Unsetenv("EDITOR")
+
+	os.Setenv("EDITOR", "false")
+	assert.EqualError(t, runEditor("file"), "false exited with an error: exit status 1")
+
+	// VISUAL takes precedence over EDITOR
+	os.Setenv("VISUAL", "true")
+	assert.NoError(t, runEditor("file"))
+
+	os.Setenv("VISUAL", "'unterminated")
+	assert.Error(t, runEditor("file"))
+}
@@ -2,7 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
 
+	"github.com/fatih/color"
+	"github.com/puppetlabs/wash/api/client"
 	apitypes "github.com/puppetlabs/wash/api/types"
 	cmdutil "github.com/puppetlabs/wash/cmd/util"
 	"github.com/spf13/cobra"
@@ -11,14 +16,28 @@ import (
 func execCommand() *cobra.Command {
 	use, aliases := generateShellAlias("exec")
 	execCmd := &cobra.Command{
-		Use:     use + " <path> <command> [<arg>...]",
+		Use:     use + " <path> <command> [<arg>...] | <path>... -- <command> [<arg>...]",
 		Aliases: aliases,
-		Short:   "Executes the given command on the indicated target",
+		Short:   "Executes the given command on the indicated targets",
 		Long: `For a Wash resource (specified by <path>) that implements the ability to execute a command, run the
 specified command and arguments. The results will be forwarded from the target on stdout, stderr,
-and exit code.`,
+and exit code.
+
+To run the command on several targets, separate the paths from the command with "--". A path can
+also be a glob, e.g. 'docker/containers/*' (quote it so that your shell doesn't expand it). The
+command runs on up to --parallel targets concurrently. Each line of output is prefixed with its
+target's path, and a summary of the targets that failed is printed at the end. The exit code is
+the largest exit code of the targets, or 1 if the command could not be run on a target. Use
+--fail-fast to stop starting the command on new targets once one of them fails.
+
+Note that if the arguments contain "--", then the first "--" always separates the paths from the
+command. Use "wash exec <path> -- <command> [<arg>...]" to run a command whose arguments contain
+"--" on a single target.`,
 		Example: `exec docker/containers/example_1 printenv USER
-  print the USER environment variable from a Docker container instance`,
+  print the USER environment variable from a Docker container instance
+
+exec 'docker/containers/*' -- uptime
+  print the uptime of every Docker container instance`,
 		Args: cobra.MinimumNArgs(2),
 		RunE: toRunE(execMain),
 	}
@@ -26,6 +45,8 @@ and exit code.`,
 	// Don't interpret any flags after the first positional argument. Those should
 	// instead get interpreted by this command as normal args, not flags.
 	execCmd.Flags().SetInterspersed(false)
+	execCmd.Flags().IntP("parallel", "p", 10, "Run the command on up to n targets concurrently")
+	execCmd.Flags().Bool("fail-fast", false, "Stop starting the command on new targets once one of them fails")
 
 	return execCmd
 }
@@ -70,26 +91,242 @@ func printPackets(pkts <-chan apitypes.ExecPacket) (int, error) {
 }
 
 func execMain(cmd *cobra.Command, args []string) exitCode {
-	var path string
-	var command string
-	var commandArgs []string
+	parallel, err := cmd.Flags().GetInt("parallel")
+	if err != nil {
+		panic(err.Error())
+	}
+	if parallel < 1 {
+		parallel = 1
+	}
+	failFast, err := cmd.Flags().GetBool("fail-fast")
+	if err != nil {
+		panic(err.Error())
+	}
 
-	path = args[0]
-	command = args[1]
-	commandArgs = args[2:]
+	patterns, command, commandArgs, err := parseExecArgs(args)
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := expandExecTarget(pattern)
+		if err != nil {
+			cmdutil.ErrPrintf("%v\n", err)
+			return exitCode{1}
+		}
+		paths = append(paths, matches...)
+	}
 
 	conn := cmdutil.NewClient()
 
-	ch, err := conn.Exec(path, command, commandArgs, apitypes.ExecOptions{})
+	if len(paths) == 1 {
+		ch, err := conn.Exec(paths[0], command, commandArgs, apitypes.ExecOptions{})
+		if err != nil {
+			cmdutil.ErrPrintf("%v\n", err)
+			return exitCode{1}
+		}
+
+		code, err := printPackets(ch)
+		if err != nil {
+			return exitCode{1}
+		}
+
+		return exitCode{code}
+	}
+
+	e := &multiExec{
+		conn:        conn,
+		command:     command,
+		commandArgs: commandArgs,
+		failFast:    failFast,
+	}
+	for _, path := range paths {
+		if len(path) > e.prefixWidth {
+			e.prefixWidth = len(path)
+		}
+	}
+	return exitCode{e.run(paths, parallel)}
+}
+
+// parseExecArgs splits args into the target paths and the command. The paths
+// are separated from the command by "--" if args contains it.
+func parseExecArgs(args []string) (paths []string, command string, commandArgs []string, err error) {
+	for i, arg := range args {
+		if arg != "--" {
+			continue
+		}
+		if i == 0 {
+			return nil, "", nil, fmt.Errorf("no paths were specified before --")
+		}
+		if i == len(args)-1 {
+			return nil, "", nil, fmt.Errorf("no command was specified after --")
+		}
+		return args[:i], args[i+1], args[i+2:], nil
+	}
+	if len(args) < 2 {
+		return nil, "", nil, fmt.Errorf("no command was specified")
+	}
+	return args[:1], args[1], args[2:], nil
+}
+
+// expandExecTarget returns the paths that match pattern if it's a glob,
+// or pattern itself if it isn't.
+func expandExecTarget(pattern string) ([]string, error) {
+	if !strings.ContainsAny(pattern, "*?[") {
+		return []string{pattern}, nil
+	}
+	matches, err := filepath.Glob(pattern)
 	if err != nil {
-		cmdutil.ErrPrintf("%v\n", err)
-		return exitCode{1}
+		return nil, fmt.Errorf("invalid glob %v: %v", pattern, err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%v did not match any entries", pattern)
+	}
+	return matches, nil
+}
+
+// The colors that are used to distinguish the targets' output
+var execColors = []color.Attribute{
+	color.FgCyan,
+	color.FgGreen,
+	color.FgYellow,
+	color.FgBlue,
+	color.FgMagenta,
+}
+
+// multiExec runs a command on multiple targets
+type multiExec struct {
+	conn        client.Client
+	command     string
+	commandArgs []string
+	failFast    bool
+	prefixWidth int
+	mux         sync.Mutex
+	failed      bool
+	results     map[string]string
+}
+
+// run runs the command on the paths and returns the aggregated exit code.
+func (e *multiExec) run(paths []string, parallel int) int {
+	e.results = make(map[string]string)
+	exit := 0
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		sem <- struct{}{}
+		e.mux.Lock()
+		stop := e.failFast && e.failed
+		if stop {
+			e.results[path] = "not run"
+		}
+		e.mux.Unlock()
+		if stop {
+			<-sem
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, path string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			code, err := e.execOn(path, color.New(execColors[i%len(execColors)]))
+
+			e.mux.Lock()
+			defer e.mux.Unlock()
+			switch {
+			case err != nil:
+				e.results[path] = err.Error()
+				code = 1
+			case code != 0:
+				e.results[path] = fmt.Sprintf("exited with %v", code)
+			}
+			if code != 0 {
+				e.failed = true
+			}
+			if code > exit {
+				exit = code
+			}
+		}(i, path)
+	}
+	wg.Wait()
+
+	if len(e.results) > 0 {
+		cmdutil.ErrPrintf("%v of %v targets failed:\n", len(e.results), len(paths))
+		for _, path := range paths {
+			if result, ok := e.results[path]; ok {
+				cmdutil.ErrPrintf("  %v: %v\n", path, result)
+			}
+		}
+		if exit == 0 {
+			// Only targets that were not run
+			exit = 1
+		}
 	}
+	return exit
+}
 
-	code, err := printPackets(ch)
+// execOn runs the command on path, printing each line of its output with
+// the path as a prefix.
+func (e *multiExec) execOn(path string, c *color.Color) (int, error) {
+	ch, err := e.conn.Exec(path, e.command, e.commandArgs, apitypes.ExecOptions{})
 	if err != nil {
-		return exitCode{1}
+		return 0, err
 	}
 
-	return exitCode{code}
+	prefix := c.Sprintf("%-*v | ", e.prefixWidth, path)
+	stdout := &prefixWriter{prefix: prefix, print: cmdutil.SafePrint}
+	stderr := &prefixWriter{prefix: prefix, print: cmdutil.SafeErrPrint}
+	exit := 0
+	var pktErr error
+	for pkt := range ch {
+		if pkt.Err != nil {
+			// Keep draining the channel so that the exec can finish
+			if pktErr == nil {
+				pktErr = pkt.Err
+			}
+			continue
+		}
+		switch pkt.TypeField {
+		case apitypes.Exitcode:
+			exit = int(pkt.Data.(float64))
+		case apitypes.Stdout:
+			stdout.write(pkt.Data.(string))
+		case apitypes.Stderr:
+			stderr.write(pkt.Data.(string))
+		}
+	}
+	stdout.flush()
+	stderr.flush()
+	return exit, pktErr
+}
+
+// prefixWriter prints complete lines with a prefix. Lines are printed with a
+// single call so that the output of concurrent writers is not interleaved
+// within a line.
+type prefixWriter struct {
+	prefix  string
+	print   func(a ...interface{})
+	partial string
+}
+
+func (w *prefixWriter) write(data string) {
+	data = w.partial + data
+	w.partial = ""
+	for {
+		i := strings.IndexByte(data, '\n')
+		if i < 0 {
+			w.partial = data
+			return
+		}
+		w.print(w.prefix + data[:i+1])
+		data = data[i+1:]
+	}
+}
+
+func (w *prefixWriter) flush() {
+	if w.partial != "" {
+		w.print(w.prefix + w.partial + "\n")
+		w.partial = ""
+	}
 }
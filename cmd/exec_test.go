@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExecArgs(t *testing.T) {
+	paths, command, args, err := parseExecArgs([]string{"foo", "git", "log", "--", "file"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"foo", "git", "log"}, paths)
+		assert.Equal(t, "file", command)
+		assert.Empty(t, args)
+	}
+
+	paths, command, args, err = parseExecArgs([]string{"foo", "printenv", "USER"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"foo"}, paths)
+		assert.Equal(t, "printenv", command)
+		assert.Equal(t, []string{"USER"}, args)
+	}
+
+	paths, command, args, err = parseExecArgs([]string{"foo", "bar", "--", "uptime", "-p"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"foo", "bar"}, paths)
+		assert.Equal(t, "uptime", command)
+		assert.Equal(t, []string{"-p"}, args)
+	}
+
+	_, _, _, err = parseExecArgs([]string{"--", "uptime"})
+	assert.EqualError(t, err, "no paths were specified before --")
+	_, _, _, err = parseExecArgs([]string{"foo", "--"})
+	assert.EqualError(t, err, "no command was specified after --")
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var lines []string
+	w := &prefixWriter{prefix: "foo | ", print: func(a ...interface{}) {
+		lines = append(lines, fmt.Sprint(a...))
+	}}
+	w.write("a\nb")
+	w.write("c\n\nd")
+	assert.Equal(t, []string{"foo | a\n", "foo | bc\n", "foo | \n"}, lines)
+	w.flush()
+	assert.Equal(t, "foo | d\n", lines[len(lines)-1])
+	w.flush()
+	assert.Len(t, lines, 4)
+}
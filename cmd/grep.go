@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/spf13/cobra"
+
+	"github.com/puppetlabs/wash/api/client"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func grepCommand() *cobra.Command {
+	use, aliases := generateShellAlias("grep")
+	grepCmd := &cobra.Command{
+		Use:     use + " [flags] <pattern> <path> [<path>...]",
+		Aliases: aliases,
+		Short:   "Searches the content of the entries at the specified paths",
+		Long: `Prints the lines of the entries' content that match pattern, a Go regular
+expression. Each matching line is printed as <path>:<line number>:<line>, and each
+context line as <path>-<line number>-<line>.
+
+Entries that support the read action are searched. Entries that only support the
+stream action are searched for --stream-window, since their streams don't end;
+they are skipped if --stream-window is not set. Entries that support the search
+action are searched by their plugin instead, unless context lines are requested.
+That includes all of a parent's descendants with -r. Use -r to search the children of
+parent entries recursively, and --include to only search the entries whose name
+matches a glob. Entries are searched concurrently, but each entry's matches are
+printed together.
+
+grep exits with 0 if a line matched, 1 if no lines matched, and 2 if an error
+occurred.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: toRunE(grepMain),
+	}
+	grepCmd.Flags().BoolP("ignore-case", "i", false, "Ignore case when matching")
+	grepCmd.Flags().BoolP("recursive", "r", false, "Search the children of parent entries recursively")
+	grepCmd.Flags().String("include", "", "Only search entries whose name matches the given glob")
+	grepCmd.Flags().BoolP("files-with-matches", "l", false, "Only print the paths of the entries that match")
+	grepCmd.Flags().IntP("after-context", "A", 0, "Print n lines of context after each match")
+	grepCmd.Flags().IntP("before-context", "B", 0, "Print n lines of context before each match")
+	grepCmd.Flags().IntP("context", "C", 0, "Print n lines of context around each match")
+	grepCmd.Flags().IntP("parallel", "p", 10, "Search up to n entries concurrently")
+	grepCmd.Flags().Duration("stream-window", 0, "Search stream-only entries for the given duration")
+	return grepCmd
+}
+
+func grepMain(cmd *cobra.Command, args []string) exitCode {
+	g := &grepper{conn: cmdutil.NewClient()}
+	ignoreCase, err := cmd.Flags().GetBool("ignore-case")
+	if err != nil {
+		panic(err.Error())
+	}
+	pattern := args[0]
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	if g.re, err = regexp.Compile(pattern); err != nil {
+		cmdutil.ErrPrintf("invalid pattern %v: %v\n", args[0], err)
+		return exitCode{2}
+	}
+	if g.recursive, err = cmd.Flags().GetBool("recursive"); err != nil {
+		panic(err.Error())
+	}
+	include, err := cmd.Flags().GetString("include")
+	if err != nil {
+		panic(err.Error())
+	}
+	if include != "" {
+		if g.include, err = glob.Compile(include); err != nil {
+			cmdutil.ErrPrintf("invalid glob %v: %v\n", include, err)
+			return exitCode{2}
+		}
+	}
+	if g.filesOnly, err = cmd.Flags().GetBool("files-with-matches"); err != nil {
+		panic(err.Error())
+	}
+	context, err := cmd.Flags().GetInt("context")
+	if err != nil {
+		panic(err.Error())
+	}
+	g.before, g.after = context, context
+	if cmd.Flags().Changed("before-context") {
+		if g.before, err = cmd.Flags().GetInt("before-context"); err != nil {
+			panic(err.Error())
+		}
+	}
+	if cmd.Flags().Changed("after-context") {
+		if g.after, err = cmd.Flags().GetInt("after-context"); err != nil {
+			panic(err.Error())
+		}
+	}
+	parallel, err := cmd.Flags().GetInt("parallel")
+	if err != nil {
+		panic(err.Error())
+	}
+	if parallel < 1 {
+		parallel = 1
+	}
+	g.sem = make(chan struct{}, parallel)
+	if g.streamWindow, err = cmd.Flags().GetDuration("stream-window"); err != nil {
+		panic(err.Error())
+	}
+
+	var wg sync.WaitGroup
+	for _, path := range args[1:] {
+		e, err := g.conn.Info(path)
+		if err != nil {
+			g.fail(path, err)
+			continue
+		}
+		wg.Add(1)
+		go func(e apitypes.Entry, path string) {
+			defer wg.Done()
+			g.search(e, path, true)
+		}(e, path)
+	}
+	wg.Wait()
+
+	switch {
+	case g.failed:
+		return exitCode{2}
+	case g.matched:
+		return exitCode{0}
+	default:
+		return exitCode{1}
+	}
+}
+
+// grepper searches the content of entries
+type grepper struct {
+	conn         client.Client
+	re           *regexp.Regexp
+	recursive    bool
+	include      glob.Glob
+	filesOnly    bool
+	before       int
+	after        int
+	streamWindow time.Duration
+	// sem bounds the number of concurrent API calls
+	sem     chan struct{}
+	mux     sync.Mutex
+	matched bool
+	failed  bool
+}
+
+// search searches e. If e is a parent, then its children are searched
+// concurrently if the recursive option is set. Explicitly specified paths are
+// searched even if they don't match the include glob.
+func (g *grepper) search(e apitypes.Entry, path string, explicit bool) {
+	isParent := e.Supports(plugin.ListAction())
+	// The plugin's search doesn't return context lines, so the entry's read
+	// instead if they're needed.
+	if e.Supports(plugin.SearchAction()) && g.before == 0 && g.after == 0 && (g.recursive || !isParent) {
+		if !isParent && !explicit && g.include != nil && !g.include.Match(e.CName) {
+			return
+		}
+		g.searchOnServer(path)
+		return
+	}
+	if isParent {
+		if !g.recursive {
+			if explicit {
+				g.fail(path, fmt.Errorf("is a parent (use -r to search its children)"))
+			}
+			return
+		}
+		g.sem <- struct{}{}
+		children, err := g.conn.List(path)
+		<-g.sem
+		if err != nil {
+			g.fail(path, err)
+			return
+		}
+		var wg sync.WaitGroup
+		for _, child := range children {
+			wg.Add(1)
+			go func(child apitypes.Entry) {
+				defer wg.Done()
+				g.search(child, filepath.Join(path, child.CName), false)
+			}(child)
+		}
+		wg.Wait()
+		return
+	}
+	if !explicit && g.include != nil && !g.include.Match(e.CName) {
+		return
+	}
+
+	g.sem <- struct{}{}
+	defer func() { <-g.sem }()
+	var rdr io.ReadCloser
+	var err error
+	streaming := false
+	switch {
+	case e.Supports(plugin.ReadAction()):
+		rdr, err = g.conn.Read(path)
+	case e.Supports(plugin.StreamAction()) && g.streamWindow > 0:
+		rdr, err = g.conn.Stream(path)
+		streaming = true
+		if err == nil {
+			// Closing the stream ends the search
+			timer := time.AfterFunc(g.streamWindow, func() { rdr.Close() })
+			defer timer.Stop()
+		}
+	default:
+		if explicit {
+			g.fail(path, fmt.Errorf("does not support the read or stream actions"))
+		}
+		return
+	}
+	if err != nil {
+		g.fail(path, err)
+		return
+	}
+	defer rdr.Close()
+
+	lines, err := grepLines(path, rdr, g.re, g.before, g.after, g.filesOnly)
+	// The stream's read fails once it's closed, which is expected
+	if err != nil && !streaming {
+		g.fail(path, err)
+	}
+	g.print(lines)
+}
+
+// searchOnServer delegates the search of the entry at path, and of its
+// descendants if it's a parent, to the plugin's search action.
+func (g *grepper) searchOnServer(path string) {
+	g.sem <- struct{}{}
+	matches, err := g.conn.Search(path, g.re.String())
+	<-g.sem
+	if err != nil {
+		g.fail(path, err)
+		return
+	}
+	g.print(searchMatchLines(path, matches, g.include, g.filesOnly))
+}
+
+func (g *grepper) print(lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	g.matched = true
+	cmdutil.SafePrint(strings.Join(lines, "\n") + "\n")
+}
+
+func (g *grepper) fail(path string, err error) {
+	g.mux.Lock()
+	g.failed = true
+	g.mux.Unlock()
+	cmdutil.SafeErrPrintf("%v: %v\n", path, err)
+}
+
+// searchMatchLines formats the matches of a search of the entry at path like
+// grepLines does. Matches in the entry's descendants are skipped if their
+// name doesn't match include. If filesOnly is set, then only the paths of the
+// entries that matched are returned.
+func searchMatchLines(path string, matches []plugin.SearchMatch, include glob.Glob, filesOnly bool) []string {
+	var out []string
+	printed := make(map[string]bool)
+	for _, m := range matches {
+		matchPath := path
+		if m.Path != "" {
+			if include != nil && !include.Match(filepath.Base(m.Path)) {
+				continue
+			}
+			matchPath = filepath.Join(path, m.Path)
+		}
+		switch {
+		case filesOnly:
+			if !printed[matchPath] {
+				printed[matchPath] = true
+				out = append(out, matchPath)
+			}
+		case m.Line > 0:
+			out = append(out, fmt.Sprintf("%v:%v:%v", matchPath, m.Line, m.Text))
+		default:
+			// The line's number isn't known
+			out = append(out, fmt.Sprintf("%v:%v", matchPath, m.Text))
+		}
+	}
+	return out
+}
+
+// grepLines returns the lines in rdr that match re along with their context
+// lines, formatted like grep(1). Non-adjacent groups of lines are separated by
+// "--". If filesOnly is set, then only the path is returned on the first match.
+func grepLines(path string, rdr io.Reader, re *regexp.Regexp, before int, after int, filesOnly bool) ([]string, error) {
+	var out []string
+	// prev holds up to before lines that preceded the current line
+	type numberedLine struct {
+		n    int
+		text string
+	}
+	var prev []numberedLine
+	lastPrinted := 0
+	afterLeft := 0
+	scanner := bufio.NewScanner(rdr)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		emit := func(sep string) {
+			if lastPrinted > 0 && n-len(prev) > lastPrinted+1 && (before > 0 || after > 0) {
+				out = append(out, "--")
+			}
+			for _, p := range prev {
+				out = append(out, fmt.Sprintf("%v-%v-%v", path, p.n, p.text))
+			}
+			prev = prev[:0]
+			out = append(out, fmt.Sprintf("%v%v%v%v%v", path, sep, n, sep, line))
+			lastPrinted = n
+		}
+		switch {
+		case re.MatchString(line):
+			if filesOnly {
+				return []string{path}, nil
+			}
+			emit(":")
+			afterLeft = after
+		case afterLeft > 0:
+			emit("-")
+			afterLeft--
+		case before > 0:
+			if len(prev) == before {
+				prev = prev[1:]
+			}
+			prev = append(prev, numberedLine{n, line})
+		}
+	}
+	return out, scanner.Err()
+}
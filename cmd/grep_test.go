@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gobwas/glob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+const grepTestContent = `one
+two
+three
+four
+five
+six
+seven
+eight
+`
+
+func TestGrepLines(t *testing.T) {
+	re := regexp.MustCompile("^t")
+	lines, err := grepLines("foo", strings.NewReader(grepTestContent), re, 0, 0, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"foo:2:two", "foo:3:three"}, lines)
+	}
+
+	lines, err = grepLines("foo", strings.NewReader(grepTestContent), re, 0, 0, true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"foo"}, lines)
+	}
+
+	lines, err = grepLines("foo", strings.NewReader(grepTestContent), regexp.MustCompile("nine"), 1, 1, false)
+	if assert.NoError(t, err) {
+		assert.Empty(t, lines)
+	}
+}
+
+func TestGrepLines_Context(t *testing.T) {
+	re := regexp.MustCompile("two|seven")
+	lines, err := grepLines("foo", strings.NewReader(grepTestContent), re, 1, 1, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{
+			"foo-1-one",
+			"foo:2:two",
+			"foo-3-three",
+			"--",
+			"foo-6-six",
+			"foo:7:seven",
+			"foo-8-eight",
+		}, lines)
+	}
+
+	// Overlapping context isn't printed twice
+	re = regexp.MustCompile("two|four")
+	lines, err = grepLines("foo", strings.NewReader(grepTestContent), re, 1, 1, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{
+			"foo-1-one",
+			"foo:2:two",
+			"foo-3-three",
+			"foo:4:four",
+			"foo-5-five",
+		}, lines)
+	}
+}
+
+func TestSearchMatchLines(t *testing.T) {
+	matches := []plugin.SearchMatch{
+		{Path: "api/a.log", Line: 2, Text: "two"},
+		{Path: "api/a.log", Line: 3, Text: "three"},
+		{Path: "db/b.txt", Line: 1, Text: "ten"},
+		{Text: "an event"},
+	}
+	assert.Equal(t, []string{
+		"logs/api/a.log:2:two",
+		"logs/api/a.log:3:three",
+		"logs/db/b.txt:1:ten",
+		"logs:an event",
+	}, searchMatchLines("logs", matches, nil, false))
+
+	assert.Equal(t, []string{
+		"logs/api/a.log",
+		"logs/db/b.txt",
+		"logs",
+	}, searchMatchLines("logs", matches, nil, true))
+
+	assert.Equal(t, []string{
+		"logs/api/a.log:2:two",
+		"logs/api/a.log:3:three",
+		"logs:an event",
+	}, searchMatchLines("logs", matches, glob.MustCompile("*.log"), false))
+}
+
+func TestGrep_DelegatesToSearch(t *testing.T) {
+	conn := &cmdtest.MockClient{}
+	g := &grepper{conn: conn, re: regexp.MustCompile("t"), recursive: true, sem: make(chan struct{}, 1)}
+	conn.On("Search", "logs", "t").Return([]plugin.SearchMatch{{Path: "a.log", Line: 2, Text: "two"}}, nil).Once()
+
+	g.search(apitypes.Entry{Actions: []string{"list", "search"}}, "logs", true)
+	assert.True(t, g.matched)
+	assert.False(t, g.failed)
+	conn.AssertExpectations(t)
+	conn.AssertNotCalled(t, "List", mock.Anything)
+
+	// Context lines aren't returned by the search, so the children are
+	// listed and read instead.
+	g = &grepper{conn: conn, re: regexp.MustCompile("t"), recursive: true, after: 1, sem: make(chan struct{}, 1)}
+	conn.On("List", "logs").Return([]apitypes.Entry{}, nil).Once()
+	g.search(apitypes.Entry{Actions: []string{"list", "search"}}, "logs", true)
+	conn.AssertExpectations(t)
+}
@@ -2,13 +2,20 @@ package cmd
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Benchkram/errz"
 	"github.com/kr/logfmt"
+	"github.com/puppetlabs/wash/api/client"
+	apitypes "github.com/puppetlabs/wash/api/types"
 	cmdutil "github.com/puppetlabs/wash/cmd/util"
 	"github.com/spf13/cobra"
 )
@@ -20,19 +27,170 @@ func historyCommand() *cobra.Command {
 		Aliases: aliases,
 		Short:   "Prints the wash command history, or journal of a particular item",
 		Long: `Wash maintains a history of commands executed through it. Print that command history, or specify an
-<id> to print a log of activity related to a particular command.`,
+<id> to print a log of activity related to a particular command.
+
+The history can be filtered by when the commands started (--since and --until), by a regular expression
+that their description must match (--grep), and by the entries that they touched (--path). --since and
+--until accept a duration relative to now (e.g. 2h) or a time (e.g. 2020-01-02T15:04:05Z or
+"2020-01-02 15:04"). --path selects the commands whose journal mentions the given path, which requires
+reading each command's journal. Use --json to print each command as a JSON object, one per line, e.g.
+to export the history.
+
+When an <id> is given, the same flags filter the records in its journal instead, so that you can find
+what went wrong in a bulk operation without reading the whole journal. --action selects the records that
+mention an action (e.g. exec), and --errors selects the warnings and errors.`,
+		Example: `history --since 1h --path docker/containers/redis
+  print the commands from the last hour that touched the redis container
+
+history 12 --errors --path docker/containers
+  print the errors that command 12 hit on the containers`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: toRunE(historyMain),
 	}
 	historyCmd.Flags().BoolP("follow", "f", false, "Follow new updates")
+	historyCmd.Flags().String("since", "", "Only print commands that started at or after the given duration ago or time")
+	historyCmd.Flags().String("until", "", "Only print commands that started before the given duration ago or time")
+	historyCmd.Flags().String("grep", "", "Only print commands whose description matches the given regular expression")
+	historyCmd.Flags().String("path", "", "Only print commands whose journal mentions the given path")
+	historyCmd.Flags().String("action", "", "With <id>, only print journal records that mention the given action")
+	historyCmd.Flags().Bool("errors", false, "With <id>, only print journal warnings and errors")
+	historyCmd.Flags().Bool("json", false, "Print each command as a JSON object")
 	return historyCmd
 }
 
+// historyFilter selects the history's activities
+type historyFilter struct {
+	since time.Time
+	until time.Time
+	grep  *regexp.Regexp
+	// paths are the forms in which the journals can mention the path
+	paths []string
+}
+
+func newHistoryFilter(cmd *cobra.Command) (*historyFilter, error) {
+	f := &historyFilter{}
+	since, err := cmd.Flags().GetString("since")
+	if err != nil {
+		panic(err.Error())
+	}
+	if f.since, err = parseHistoryTime(since); err != nil {
+		return nil, fmt.Errorf("invalid --since: %v", err)
+	}
+	until, err := cmd.Flags().GetString("until")
+	if err != nil {
+		panic(err.Error())
+	}
+	if f.until, err = parseHistoryTime(until); err != nil {
+		return nil, fmt.Errorf("invalid --until: %v", err)
+	}
+	grep, err := cmd.Flags().GetString("grep")
+	if err != nil {
+		panic(err.Error())
+	}
+	if grep != "" {
+		if f.grep, err = regexp.Compile(grep); err != nil {
+			return nil, fmt.Errorf("invalid --grep: %v", err)
+		}
+	}
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		panic(err.Error())
+	}
+	if path != "" {
+		// The API's journal records use the absolute path, while FUSE's
+		// records use the path relative to the mountpoint.
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --path: %v", err)
+		}
+		f.paths = []string{abs}
+		if mountpoint := os.Getenv("W"); mountpoint != "" && cmdutil.IsWashPath(abs) {
+			if rel := strings.TrimPrefix(abs, mountpoint); rel != "" {
+				f.paths = append(f.paths, rel)
+			}
+		}
+	}
+	return f, nil
+}
+
+// parseHistoryTime parses a duration relative to now or a time. It returns the
+// zero time if str is empty.
+func parseHistoryTime(str string) (time.Time, error) {
+	if str == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(str); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, str); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04", str, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%v is not a duration or a time", str)
+}
+
+// matches returns true if the activity at the given (0-based) index should be
+// printed.
+func (f *historyFilter) matches(conn client.Client, index int, act apitypes.Activity) (bool, error) {
+	if !f.since.IsZero() && act.Start.Before(f.since) {
+		return false, nil
+	}
+	if !f.until.IsZero() && !act.Start.Before(f.until) {
+		return false, nil
+	}
+	if f.grep != nil && !f.grep.MatchString(act.Description) {
+		return false, nil
+	}
+	if len(f.paths) == 0 {
+		return true, nil
+	}
+
+	rdr, err := conn.ActivityJournal(index, apitypes.JournalOptions{Paths: f.paths})
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		errz.Log(rdr.Close())
+	}()
+	// The server only returns records that mention the paths
+	buf := make([]byte, 1)
+	n, err := rdr.Read(buf)
+	if n > 0 {
+		return true, nil
+	} else if err == io.EOF {
+		return false, nil
+	}
+	return false, err
+}
+
+// journalOptions returns the options that filter the records of a command's
+// journal.
+func (f *historyFilter) journalOptions(cmd *cobra.Command, follow bool) apitypes.JournalOptions {
+	action, err := cmd.Flags().GetString("action")
+	if err != nil {
+		panic(err.Error())
+	}
+	errorsOnly, err := cmd.Flags().GetBool("errors")
+	if err != nil {
+		panic(err.Error())
+	}
+	return apitypes.JournalOptions{
+		Follow:     follow,
+		Since:      f.since,
+		Until:      f.until,
+		Paths:      f.paths,
+		Action:     action,
+		ErrorsOnly: errorsOnly,
+	}
+}
+
 type logFmtLine struct {
 	Time, Level, Msg string
 }
 
-func printJournalEntry(index string, follow bool) error {
+func printJournalEntry(index string, opts apitypes.JournalOptions, grep *regexp.Regexp) error {
 	idx, err := strconv.Atoi(index)
 	if err != nil {
 		return err
@@ -40,7 +198,7 @@ func printJournalEntry(index string, follow bool) error {
 
 	conn := cmdutil.NewClient()
 	// Translate from 1-indexing for history entries
-	rdr, err := conn.ActivityJournal(idx-1, follow)
+	rdr, err := conn.ActivityJournal(idx-1, opts)
 	if err != nil {
 		return err
 	}
@@ -65,6 +223,9 @@ func printJournalEntry(index string, follow bool) error {
 			// Parser ignored incomplete line rather than erroring. Skip it.
 			continue
 		}
+		if grep != nil && !grep.MatchString(line.Msg) {
+			continue
+		}
 
 		// TODO: add option to print the original longer time format.
 		t, err := time.Parse(time.RFC3339Nano, line.Time)
@@ -85,7 +246,13 @@ func printJournalEntry(index string, follow bool) error {
 	return scanner.Err()
 }
 
-func printHistory(follow bool) error {
+// historyItem is the JSON representation of a history item
+type historyItem struct {
+	ID int `json:"id"`
+	apitypes.Activity
+}
+
+func printHistory(follow bool, filter *historyFilter, asJSON bool) error {
 	conn := cmdutil.NewClient()
 	history, err := conn.History(follow)
 	if err != nil {
@@ -97,8 +264,24 @@ func printHistory(follow bool) error {
 	formatStr := "%" + strconv.Itoa(indexColumnLength) + "d  %s  %s\n"
 	i := 0
 	for item := range history {
-		cmdutil.Printf(formatStr, i+1, item.Start.Format("2006-01-02 15:04"), item.Description)
 		i++
+		ok, err := filter.matches(conn, i-1, item)
+		if err != nil {
+			cmdutil.ErrPrintf("could not read the journal of %v: %v\n", i, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if asJSON {
+			data, err := json.Marshal(historyItem{ID: i, Activity: item})
+			if err != nil {
+				return err
+			}
+			cmdutil.Println(string(data))
+			continue
+		}
+		cmdutil.Printf(formatStr, i, item.Start.Format("2006-01-02 15:04"), item.Description)
 	}
 	return nil
 }
@@ -108,11 +291,20 @@ func historyMain(cmd *cobra.Command, args []string) exitCode {
 	if err != nil {
 		panic(err.Error())
 	}
+	asJSON, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		panic(err.Error())
+	}
+	filter, err := newHistoryFilter(cmd)
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
 
 	if len(args) > 0 {
-		err = printJournalEntry(args[0], follow)
+		err = printJournalEntry(args[0], filter.journalOptions(cmd, follow), filter.grep)
 	} else {
-		err = printHistory(follow)
+		err = printHistory(follow, filter, asJSON)
 	}
 
 	if err != nil {
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/puppetlabs/wash/api/client"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+)
+
+func TestParseHistoryTime(t *testing.T) {
+	tm, err := parseHistoryTime("")
+	if assert.NoError(t, err) {
+		assert.True(t, tm.IsZero())
+	}
+
+	tm, err = parseHistoryTime("2h")
+	if assert.NoError(t, err) {
+		assert.WithinDuration(t, time.Now().Add(-2*time.Hour), tm, time.Minute)
+	}
+
+	tm, err = parseHistoryTime("2020-01-02T15:04:05Z")
+	if assert.NoError(t, err) {
+		assert.Equal(t, time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC), tm)
+	}
+
+	tm, err = parseHistoryTime("2020-01-02 15:04")
+	if assert.NoError(t, err) {
+		assert.Equal(t, time.Date(2020, 1, 2, 15, 4, 0, 0, time.Local), tm)
+	}
+
+	_, err = parseHistoryTime("yesterday")
+	assert.EqualError(t, err, "yesterday is not a duration or a time")
+}
+
+func TestNewHistoryFilter(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	cmd := historyCommand()
+	assert.NoError(t, cmd.Flags().Set("grep", "^ls"))
+	assert.NoError(t, cmd.Flags().Set("path", "/wash/docker/containers"))
+	f, err := newHistoryFilter(cmd)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "^ls", f.grep.String())
+		assert.Equal(t, []string{"/wash/docker/containers", "/docker/containers"}, f.paths)
+	}
+
+	cmd = historyCommand()
+	assert.NoError(t, cmd.Flags().Set("since", "yesterday"))
+	_, err = newHistoryFilter(cmd)
+	assert.EqualError(t, err, "invalid --since: yesterday is not a duration or a time")
+
+	cmd = historyCommand()
+	assert.NoError(t, cmd.Flags().Set("until", "yesterday"))
+	_, err = newHistoryFilter(cmd)
+	assert.EqualError(t, err, "invalid --until: yesterday is not a duration or a time")
+
+	cmd = historyCommand()
+	assert.NoError(t, cmd.Flags().Set("grep", "["))
+	_, err = newHistoryFilter(cmd)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid --grep")
+	}
+}
+
+func TestHistoryFilterMatches(t *testing.T) {
+	now := time.Now()
+	act := apitypes.Activity{Description: "ls docker", Start: now}
+	conn := &cmdtest.MockClient{}
+
+	cases := []struct {
+		filter   historyFilter
+		expected bool
+	}{
+		{historyFilter{}, true},
+		{historyFilter{since: now.Add(-time.Minute)}, true},
+		{historyFilter{since: now.Add(time.Minute)}, false},
+		{historyFilter{until: now.Add(time.Minute)}, true},
+		{historyFilter{until: now}, false},
+		{historyFilter{grep: regexp.MustCompile("^ls")}, true},
+		{historyFilter{grep: regexp.MustCompile("^find")}, false},
+	}
+	for _, c := range cases {
+		ok, err := c.filter.matches(conn, 0, act)
+		if assert.NoError(t, err) {
+			assert.Equal(t, c.expected, ok)
+		}
+	}
+	conn.AssertNotCalled(t, "ActivityJournal", mock.Anything, mock.Anything)
+}
+
+func TestHistoryFilterMatches_Paths(t *testing.T) {
+	act := apitypes.Activity{Description: "ls docker", Start: time.Now()}
+	f := historyFilter{paths: []string{"/wash/docker"}}
+	opts := apitypes.JournalOptions{Paths: f.paths}
+
+	conn := &cmdtest.MockClient{}
+	conn.On("ActivityJournal", 0, opts).Return(mockContent("a record that mentions the path\n"), nil)
+	conn.On("ActivityJournal", 1, opts).Return(mockContent(""), nil)
+	conn.On("ActivityJournal", 2, opts).Return(mockContent(""), errors.New("journal not found"))
+
+	ok, err := f.matches(conn, 0, act)
+	if assert.NoError(t, err) {
+		assert.True(t, ok)
+	}
+	ok, err = f.matches(conn, 1, act)
+	if assert.NoError(t, err) {
+		assert.False(t, ok)
+	}
+	_, err = f.matches(conn, 2, act)
+	assert.EqualError(t, err, "journal not found")
+}
+
+func TestHistoryFilterJournalOptions(t *testing.T) {
+	since := time.Now().Add(-time.Hour)
+	f := historyFilter{since: since, paths: []string{"/wash/docker"}}
+	cmd := historyCommand()
+	assert.NoError(t, cmd.Flags().Set("action", "exec"))
+	assert.NoError(t, cmd.Flags().Set("errors", "true"))
+	expected := apitypes.JournalOptions{
+		Follow:     true,
+		Since:      since,
+		Paths:      []string{"/wash/docker"},
+		Action:     "exec",
+		ErrorsOnly: true,
+	}
+	assert.Equal(t, expected, f.journalOptions(cmd, true))
+}
+
+func TestPrintHistory(t *testing.T) {
+	start := time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)
+	newHistory := func() chan apitypes.Activity {
+		history := make(chan apitypes.Activity, 3)
+		history <- apitypes.Activity{Description: "ls docker", Start: start}
+		history <- apitypes.Activity{Description: "find gcp", Start: start}
+		history <- apitypes.Activity{Description: "ls aws", Start: start}
+		close(history)
+		return history
+	}
+	conn := &cmdtest.MockClient{}
+	conn.On("History", false).Return(newHistory(), nil).Once()
+	conn.On("History", false).Return(newHistory(), nil).Once()
+	origNewClient := cmdutil.NewClient
+	cmdutil.NewClient = func() client.Client { return conn }
+	defer func() { cmdutil.NewClient = origNewClient }()
+	stdout, _, restore := captureOutput()
+	defer restore()
+
+	filter := &historyFilter{grep: regexp.MustCompile("^ls")}
+	if assert.NoError(t, printHistory(false, filter, false)) {
+		assert.Equal(t, "1  2020-01-02 15:04  ls docker\n3  2020-01-02 15:04  ls aws\n", stdout.String())
+	}
+
+	stdout.Reset()
+	if assert.NoError(t, printHistory(false, filter, true)) {
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		if assert.Len(t, lines, 2) {
+			var item historyItem
+			if assert.NoError(t, json.Unmarshal([]byte(lines[1]), &item)) {
+				assert.Equal(t, 3, item.ID)
+				assert.Equal(t, "ls aws", item.Description)
+				assert.True(t, start.Equal(item.Start))
+			}
+		}
+	}
+
+	conn.On("History", true).Return(make(chan apitypes.Activity), errors.New("server unavailable"))
+	assert.EqualError(t, printHistory(true, filter, false), "server unavailable")
+}
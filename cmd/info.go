@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"sync"
+	"time"
 
 	"github.com/emirpasic/gods/maps/linkedhashmap"
+	apitypes "github.com/puppetlabs/wash/api/types"
 	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
 	"github.com/spf13/cobra"
 	goyaml "gopkg.in/yaml.v2"
 )
@@ -15,9 +18,26 @@ func infoCommand() *cobra.Command {
 		Use:     use + " <path> [<path>]...",
 		Aliases: aliases,
 		Short:   "Wash's version of 'stat'. Prints the entries' info at the specified paths",
-		RunE:    toRunE(infoMain),
+		Long: `Prints the entries' info at the specified paths.
+
+Use --format to print each entry with a Go template instead. The template
+refers to the entry's fields by their JSON keys: name, cname, path, type_id,
+actions, attributes and metadata (the partial metadata). The json function
+marshals a value to JSON, and the join function joins a list, e.g.
+'{{.name}} {{join .actions ","}}'.
+
+Use --activity to also print the entry's recent method invocations: which
+methods the Wash server invoked on it, when, how long they took, and the
+command (and its history journal) that invoked them. Cached results aren't
+invocations, so they aren't included. The template refers to them as
+.activity.`,
+		Example: `info --format '{{.name}} {{.attributes.size}}' docker/containers/*
+  print the name and size of every Docker container instance`,
+		RunE: toRunE(infoMain),
 	}
 	infoCmd.Flags().StringP("output", "o", "yaml", "Set the output format (json, yaml, or text)")
+	infoCmd.Flags().String("format", "", "Print each entry with the given Go template")
+	infoCmd.Flags().Bool("activity", false, "Include the entry's recent method invocations")
 	return infoCmd
 }
 
@@ -31,6 +51,15 @@ func infoMain(cmd *cobra.Command, args []string) exitCode {
 		panic(err.Error())
 	}
 
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		panic(err.Error())
+	}
+	includeActivity, err := cmd.Flags().GetBool("activity")
+	if err != nil {
+		panic(err.Error())
+	}
+
 	marshaller, err := cmdutil.NewMarshaller(output)
 	if err != nil {
 		cmdutil.ErrPrintf(err.Error())
@@ -38,6 +67,14 @@ func infoMain(cmd *cobra.Command, args []string) exitCode {
 	}
 
 	conn := cmdutil.NewClient()
+	info := conn.Info
+	if includeActivity {
+		info = conn.InfoWithActivity
+	}
+
+	if format != "" {
+		return infoWithTemplate(info, paths, format)
+	}
 
 	// Use a sorted map so that we can control how the information's
 	// displayed.
@@ -52,7 +89,7 @@ func infoMain(cmd *cobra.Command, args []string) exitCode {
 		go func(path string) {
 			defer wg.Done()
 
-			entry, err := conn.Info(path)
+			entry, err := info(path)
 			if err != nil {
 				ec = 1
 				cmdutil.SafeErrPrintf("%v: %v\n", path, err)
@@ -64,6 +101,9 @@ func infoMain(cmd *cobra.Command, args []string) exitCode {
 			entryMap.Put("CName", entry.CName)
 			entryMap.Put("Actions", entry.Actions)
 			entryMap.Put("Attributes", entry.Attributes.ToMap())
+			if includeActivity {
+				entryMap.Put("Activity", activityMaps(entry.Activity))
+			}
 
 			infoMapMux.Lock()
 			infoMap[path] = entryMap
@@ -89,6 +129,57 @@ func infoMain(cmd *cobra.Command, args []string) exitCode {
 	return exitCode{ec}
 }
 
+// activityMaps converts the invocations to ordered maps so that their fields
+// are printed in a readable order and format.
+func activityMaps(invocations []plugin.MethodInvocation) []orderedMap {
+	maps := make([]orderedMap, len(invocations))
+	for i, invocation := range invocations {
+		mp := orderedMap{linkedhashmap.New()}
+		mp.Put("Time", invocation.Time.Format(time.RFC3339))
+		mp.Put("Method", invocation.Method)
+		mp.Put("Duration", invocation.Duration.String())
+		if invocation.Command != "" {
+			mp.Put("Command", invocation.Command)
+		}
+		if invocation.JournalID != "" {
+			mp.Put("Journal", invocation.JournalID)
+		}
+		if invocation.Error != "" {
+			mp.Put("Error", invocation.Error)
+		}
+		maps[i] = mp
+	}
+	return maps
+}
+
+// infoWithTemplate prints each entry with the given template, in the
+// order of the paths.
+func infoWithTemplate(info func(string) (apitypes.Entry, error), paths []string, format string) exitCode {
+	tmpl, err := cmdutil.NewTemplate(format)
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+
+	ec := 0
+	for _, path := range paths {
+		entry, err := info(path)
+		if err != nil {
+			ec = 1
+			cmdutil.ErrPrintf("%v: %v\n", path, err)
+			continue
+		}
+		out, err := tmpl.Format(entry)
+		if err != nil {
+			ec = 1
+			cmdutil.ErrPrintf("%v: %v\n", path, err)
+			continue
+		}
+		cmdutil.Println(out)
+	}
+	return exitCode{ec}
+}
+
 // This wrapped type's here to implement MarshalYAML because the (default)
 // JSON unmarshalling causes the orderedMap values to be marshalled as a
 // map[string]interface. This loses their insertion order.
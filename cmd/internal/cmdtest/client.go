@@ -7,6 +7,8 @@ import (
 
 	"github.com/puppetlabs/wash/analytics"
 	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/slowlog"
 )
 
 // MockClient mocks a Wash API client
@@ -20,30 +22,90 @@ func (c *MockClient) Info(path string) (apitypes.Entry, error) {
 	return args.Get(0).(apitypes.Entry), args.Error(1)
 }
 
+// InfoWithActivity mocks Client#InfoWithActivity
+func (c *MockClient) InfoWithActivity(path string) (apitypes.Entry, error) {
+	args := c.Called(path)
+	return args.Get(0).(apitypes.Entry), args.Error(1)
+}
+
 // List mocks Client#List
 func (c *MockClient) List(path string) ([]apitypes.Entry, error) {
 	args := c.Called(path)
 	return args.Get(0).([]apitypes.Entry), args.Error(1)
 }
 
+// ListStream mocks Client#ListStream
+func (c *MockClient) ListStream(path string) (<-chan apitypes.ListPacket, error) {
+	args := c.Called(path)
+	return args.Get(0).(<-chan apitypes.ListPacket), args.Error(1)
+}
+
+// ListPage mocks Client#ListPage
+func (c *MockClient) ListPage(path string, limit int, continuationToken string) (apitypes.ListPage, error) {
+	args := c.Called(path, limit, continuationToken)
+	return args.Get(0).(apitypes.ListPage), args.Error(1)
+}
+
 // Metadata mocks Client#Metadata
 func (c *MockClient) Metadata(path string) (map[string]interface{}, error) {
 	args := c.Called(path)
 	return args.Get(0).(map[string]interface{}), args.Error(1)
 }
 
+// DecompressedMetadata mocks Client#DecompressedMetadata
+func (c *MockClient) DecompressedMetadata(path string) (map[string]interface{}, error) {
+	args := c.Called(path)
+	return args.Get(0).(map[string]interface{}), args.Error(1)
+}
+
 // Stream mocks Client#Stream
 func (c *MockClient) Stream(path string) (io.ReadCloser, error) {
 	args := c.Called(path)
 	return args.Get(0).(io.ReadCloser), args.Error(1)
 }
 
+// Read mocks Client#Read
+func (c *MockClient) Read(path string) (io.ReadCloser, error) {
+	args := c.Called(path)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+// ReadDecompressed mocks Client#ReadDecompressed
+func (c *MockClient) ReadDecompressed(path string) (io.ReadCloser, error) {
+	args := c.Called(path)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+// Write mocks Client#Write
+func (c *MockClient) Write(path string, content io.Reader) error {
+	args := c.Called(path, content)
+	return args.Error(0)
+}
+
+// WriteCompressed mocks Client#WriteCompressed
+func (c *MockClient) WriteCompressed(path string, content io.Reader) error {
+	args := c.Called(path, content)
+	return args.Error(0)
+}
+
+// Copy mocks Client#Copy
+func (c *MockClient) Copy(src string, dst string) error {
+	args := c.Called(src, dst)
+	return args.Error(0)
+}
+
 // Exec mocks Client#Exec
 func (c *MockClient) Exec(path string, command string, args []string, opts apitypes.ExecOptions) (<-chan apitypes.ExecPacket, error) {
 	margs := c.Called(path, command, args, opts)
 	return margs.Get(0).(<-chan apitypes.ExecPacket), margs.Error(1)
 }
 
+// Search mocks Client#Search
+func (c *MockClient) Search(path string, pattern string) ([]plugin.SearchMatch, error) {
+	args := c.Called(path, pattern)
+	return args.Get(0).([]plugin.SearchMatch), args.Error(1)
+}
+
 // History mocks Client#History
 func (c *MockClient) History(follow bool) (chan apitypes.Activity, error) {
 	args := c.Called(follow)
@@ -51,17 +113,23 @@ func (c *MockClient) History(follow bool) (chan apitypes.Activity, error) {
 }
 
 // ActivityJournal mocks Client#ActivityJournal
-func (c *MockClient) ActivityJournal(index int, follow bool) (io.ReadCloser, error) {
-	args := c.Called(index, follow)
+func (c *MockClient) ActivityJournal(index int, opts apitypes.JournalOptions) (io.ReadCloser, error) {
+	args := c.Called(index, opts)
 	return args.Get(0).(io.ReadCloser), args.Error(1)
 }
 
 // Clear mocks Client#Clear
-func (c *MockClient) Clear(path string) ([]string, error) {
-	args := c.Called(path)
+func (c *MockClient) Clear(path string, opts apitypes.ClearOptions) ([]string, error) {
+	args := c.Called(path, opts)
 	return args.Get(0).([]string), args.Error(1)
 }
 
+// CacheState mocks Client#CacheState
+func (c *MockClient) CacheState(path string) (map[string]plugin.OpCacheState, error) {
+	args := c.Called(path)
+	return args.Get(0).(map[string]plugin.OpCacheState), args.Error(1)
+}
+
 // Schema mocks Client#Schema
 func (c *MockClient) Schema(path string) (*apitypes.EntrySchema, error) {
 	args := c.Called(path)
@@ -85,3 +153,51 @@ func (c *MockClient) Signal(path string, signal string) error {
 	args := c.Called(path, signal)
 	return args.Error(0)
 }
+
+// Rename mocks Client#Rename
+func (c *MockClient) Rename(path string, newName string) error {
+	args := c.Called(path, newName)
+	return args.Error(0)
+}
+
+// Create mocks Client#Create
+func (c *MockClient) Create(path string, name string, attr plugin.EntryAttributes) (apitypes.Entry, error) {
+	args := c.Called(path, name, attr)
+	return args.Get(0).(apitypes.Entry), args.Error(1)
+}
+
+// SetAttr mocks Client#SetAttr
+func (c *MockClient) SetAttr(path string, attr plugin.EntryAttributes) error {
+	args := c.Called(path, attr)
+	return args.Error(0)
+}
+
+// Metrics mocks Client#Metrics
+func (c *MockClient) Metrics() ([]plugin.MethodStats, error) {
+	args := c.Called()
+	return args.Get(0).([]plugin.MethodStats), args.Error(1)
+}
+
+// SlowOperations mocks Client#SlowOperations
+func (c *MockClient) SlowOperations(limit int) ([]slowlog.Offender, error) {
+	args := c.Called(limit)
+	return args.Get(0).([]slowlog.Offender), args.Error(1)
+}
+
+// Plugins mocks Client#Plugins
+func (c *MockClient) Plugins() ([]plugin.PluginStatus, error) {
+	args := c.Called()
+	return args.Get(0).([]plugin.PluginStatus), args.Error(1)
+}
+
+// EnablePlugin mocks Client#EnablePlugin
+func (c *MockClient) EnablePlugin(name string) error {
+	args := c.Called(name)
+	return args.Error(0)
+}
+
+// DisablePlugin mocks Client#DisablePlugin
+func (c *MockClient) DisablePlugin(name string) error {
+	args := c.Called(name)
+	return args.Error(0)
+}
@@ -15,13 +15,26 @@ import (
 
 // Contains all the keys for Wash's shared config
 const (
-	SocketKey   = "socket"
-	EmbeddedKey = "embedded"
+	SocketKey       = "socket"
+	AuditFileKey    = "audit.file"
+	AuditKeyFileKey = "audit.key_file"
+	SlowLogFileKey  = "slowlog.file"
+	EmbeddedKey     = "embedded"
+	PromptFormatKey = "prompt.format"
+	ShellAliasesKey = "shell.aliases"
+	ShellStartupKey = "shell.startup"
 )
 
 // Socket is the path to the Wash server's UNIX
 // socket
 var Socket string
+
+// AuditFile is the path to the Wash server's audit log
+var AuditFile string
+
+// AuditKeyFile is the path to the key that the audit log's hashes are
+// computed with
+var AuditKeyFile string
 var Embedded bool
 
 // Init initializes the config package. It loads Wash's defaults and
@@ -33,11 +46,16 @@ func Init() error {
 		return err
 	}
 	viper.SetDefault(SocketKey, filepath.Join(cdir, "wash", "wash-api.sock"))
+	viper.SetDefault(AuditFileKey, filepath.Join(cdir, "wash", "audit.log"))
+	viper.SetDefault(SlowLogFileKey, filepath.Join(cdir, "wash", "slow.log"))
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return err
 	}
 	defaultFileAbs = filepath.Join(homeDir, defaultFileSuffix)
+	// The key is kept out of the cache directory so that it isn't next to the
+	// audit log.
+	viper.SetDefault(AuditKeyFileKey, filepath.Join(filepath.Dir(defaultFileAbs), "audit.key"))
 
 	// Tell viper that the config. can be read from WASH_<entry>
 	// environment variables
@@ -50,6 +68,8 @@ func Init() error {
 
 	// Load the shared config
 	Socket = viper.GetString(SocketKey)
+	AuditFile = viper.GetString(AuditFileKey)
+	AuditKeyFile = viper.GetString(AuditKeyFileKey)
 	Embedded = viper.GetBool(EmbeddedKey)
 
 	return nil
@@ -73,13 +93,18 @@ func DefaultFileAbsPath() string {
 	return defaultFileAbs
 }
 
-// ReadFrom reads the config from the specified file.
+// ReadFrom reads the config from the specified file, applying the named
+// profile's settings if profile isn't empty. ${VAR} references in the
+// config's values are replaced by their environment variables.
 // If file == DefaultFile(), then ReadFrom wil not return
 // an error if file does not exist.
-func ReadFrom(file string) error {
+func ReadFrom(file string, profile string) error {
 	if file == DefaultFile() {
 		file = DefaultFileAbsPath()
 		if _, err := os.Stat(file); os.IsNotExist(err) {
+			if profile != "" {
+				return newConfigReadErr(file, fmt.Errorf("unknown profile %v: the config file does not exist", profile))
+			}
 			return nil
 		}
 	}
@@ -87,6 +112,10 @@ func ReadFrom(file string) error {
 	if err != nil {
 		return newConfigReadErr(file, err)
 	}
+	content, err = processConfig(content, profile)
+	if err != nil {
+		return newConfigReadErr(file, err)
+	}
 	if err := viper.ReadConfig(bytes.NewReader(content)); err != nil {
 		return newConfigReadErr(file, err)
 	}
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ProfileKey selects the config file's profile. It's set by the --profile
+// flag or the WASH_PROFILE environment variable.
+const ProfileKey = "profile"
+
+// profilesKey is the config file's key for its named profiles.
+const profilesKey = "profiles"
+
+// processConfig applies the profile's settings over the config's top-level
+// settings, then interpolates environment variables in the result's string
+// values. The profiles themselves are dropped so that the unselected
+// profiles' variables needn't be set.
+func processConfig(content []byte, profile string) ([]byte, error) {
+	var cfg map[interface{}]interface{}
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return nil, err
+	}
+	profiles, ok := cfg[profilesKey].(map[interface{}]interface{})
+	if cfg[profilesKey] != nil && !ok {
+		return nil, fmt.Errorf("%v must be a map of profile names to their settings", profilesKey)
+	}
+	delete(cfg, profilesKey)
+
+	if profile != "" {
+		settings, ok := profiles[profile]
+		if !ok {
+			return nil, fmt.Errorf("unknown profile %v. Known profiles are: %v", profile, profileNames(profiles))
+		}
+		if settings != nil {
+			settingsMap, ok := settings.(map[interface{}]interface{})
+			if !ok {
+				return nil, fmt.Errorf("profile %v must be a map of settings", profile)
+			}
+			mergeConfig(cfg, settingsMap)
+		}
+	}
+
+	interpolated, err := interpolate(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(interpolated)
+}
+
+func profileNames(profiles map[interface{}]interface{}) string {
+	var names []string
+	for name := range profiles {
+		names = append(names, fmt.Sprint(name))
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// mergeConfig merges src into dst. Nested maps are merged, while other
+// values in src replace the ones in dst.
+func mergeConfig(dst map[interface{}]interface{}, src map[interface{}]interface{}) {
+	for key, srcValue := range src {
+		srcMap, srcIsMap := srcValue.(map[interface{}]interface{})
+		dstMap, dstIsMap := dst[key].(map[interface{}]interface{})
+		if srcIsMap && dstIsMap {
+			mergeConfig(dstMap, srcMap)
+		} else {
+			dst[key] = srcValue
+		}
+	}
+}
+
+// interpolate replaces ${VAR} in the value's strings with the VAR environment
+// variable, and ${VAR:-default} with default if VAR is unset or empty. $${
+// escapes a literal ${. Other uses of $ are left alone so that values like
+// passwords needn't be escaped.
+func interpolate(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return interpolateString(v)
+	case map[interface{}]interface{}:
+		for key, elem := range v {
+			interpolated, err := interpolate(elem)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %v", key, err)
+			}
+			v[key] = interpolated
+		}
+		return v, nil
+	case []interface{}:
+		for i, elem := range v {
+			interpolated, err := interpolate(elem)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = interpolated
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+func interpolateString(str string) (string, error) {
+	var result strings.Builder
+	for {
+		i := strings.Index(str, "${")
+		if i < 0 {
+			result.WriteString(str)
+			return result.String(), nil
+		}
+		if i > 0 && str[i-1] == '$' {
+			// $${ is an escaped ${
+			result.WriteString(str[:i-1])
+			result.WriteString("${")
+			str = str[i+2:]
+			continue
+		}
+		result.WriteString(str[:i])
+		end := strings.Index(str[i:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated ${ in %q", str[i:])
+		}
+		expr := str[i+2 : i+end]
+		str = str[i+end+1:]
+
+		name, defaultValue, hasDefault := expr, "", false
+		if j := strings.Index(expr, ":-"); j >= 0 {
+			name, defaultValue, hasDefault = expr[:j], expr[j+2:], true
+		}
+		if name == "" {
+			return "", fmt.Errorf("missing the environment variable's name in ${%v}", expr)
+		}
+		value := os.Getenv(name)
+		if value == "" {
+			if !hasDefault {
+				return "", fmt.Errorf("the %v environment variable is not set", name)
+			}
+			value = defaultValue
+		}
+		result.WriteString(value)
+	}
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+const profilesConfig = `
+loglevel: info
+aws:
+  profiles:
+    - dev
+  region: us-west-1
+profiles:
+  prod:
+    loglevel: warn
+    aws:
+      region: ${WASH_TEST_REGION}
+  staging:
+    aws:
+      region: ${WASH_TEST_UNSET:-us-east-2}
+`
+
+func processTestConfig(t *testing.T, profile string) map[string]interface{} {
+	content, err := processConfig([]byte(profilesConfig), profile)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	var cfg map[string]interface{}
+	if !assert.NoError(t, yaml.Unmarshal(content, &cfg)) {
+		return nil
+	}
+	return cfg
+}
+
+func TestProcessConfig(t *testing.T) {
+	cfg := processTestConfig(t, "")
+	assert.Equal(t, map[string]interface{}{
+		"loglevel": "info",
+		"aws": map[interface{}]interface{}{
+			"profiles": []interface{}{"dev"},
+			"region":   "us-west-1",
+		},
+	}, cfg)
+
+	// Nested settings are merged
+	cfg = processTestConfig(t, "staging")
+	assert.Equal(t, "info", cfg["loglevel"])
+	assert.Equal(t, map[interface{}]interface{}{
+		"profiles": []interface{}{"dev"},
+		"region":   "us-east-2",
+	}, cfg["aws"])
+
+	_, err := processConfig([]byte(profilesConfig), "prod")
+	assert.EqualError(t, err, "aws: region: the WASH_TEST_REGION environment variable is not set")
+	os.Setenv("WASH_TEST_REGION", "eu-west-1")
+	defer os.Unsetenv("WASH_TEST_REGION")
+	cfg = processTestConfig(t, "prod")
+	assert.Equal(t, "warn", cfg["loglevel"])
+	assert.Equal(t, "eu-west-1", cfg["aws"].(map[interface{}]interface{})["region"])
+
+	_, err = processConfig([]byte(profilesConfig), "test")
+	assert.EqualError(t, err, "unknown profile test. Known profiles are: prod, staging")
+}
+
+func TestInterpolateString(t *testing.T) {
+	os.Setenv("WASH_TEST_TOKEN", "secret")
+	defer os.Unsetenv("WASH_TEST_TOKEN")
+
+	for input, expected := range map[string]string{
+		"${WASH_TEST_TOKEN}":                   "secret",
+		"Bearer ${WASH_TEST_TOKEN}!":           "Bearer secret!",
+		"${WASH_TEST_UNSET:-default}":          "default",
+		"${WASH_TEST_TOKEN:-default}":          "secret",
+		"pa$$word $WASH_TEST_TOKEN":            "pa$$word $WASH_TEST_TOKEN",
+		"$${WASH_TEST_TOKEN}":                  "${WASH_TEST_TOKEN}",
+		"${WASH_TEST_TOKEN}${WASH_TEST_TOKEN}": "secretsecret",
+	} {
+		actual, err := interpolateString(input)
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, expected, actual, input)
+		}
+	}
+
+	_, err := interpolateString("${WASH_TEST_TOKEN")
+	assert.EqualError(t, err, `unterminated ${ in "${WASH_TEST_TOKEN"`)
+	_, err = interpolateString("${}")
+	assert.EqualError(t, err, "missing the environment variable's name in ${}")
+}
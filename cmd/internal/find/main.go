@@ -11,6 +11,7 @@ import (
 	"github.com/puppetlabs/wash/cmd/internal/find/primary"
 	"github.com/puppetlabs/wash/cmd/internal/find/types"
 	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
 )
 
 // Main is `wash find`'s main function.
@@ -42,15 +43,61 @@ func Main(args []string) int {
 		)
 	}
 
+	params.Force = opts.Force
+	params.ReadLimit = opts.ReadLimit
+	if primary.IsSet(primary.Delete) {
+		// -delete can't ask for confirmation without a terminal, so don't
+		// let it delete anything unless its confirmation was skipped.
+		if !opts.Force && !plugin.IsInteractive() {
+			cmdutil.ErrPrintf("find: -delete requires the -force option when find isn't run interactively\n")
+			return 1
+		}
+		// Delete the children before their parent
+		opts.Depth = true
+	}
+
+	var state *runState
+	if (opts.Resume || opts.Cached > 0) && opts.Watch == 0 {
+		state, err = loadState(args)
+		if err != nil {
+			cmdutil.ErrPrintf("find: could not load the state of the previous run: %v\n", err)
+			return 1
+		}
+		if opts.Cached > 0 && !primary.PerformsActions() && state.isFresh(opts.Cached) {
+			for _, match := range state.matches {
+				cmdutil.Printf("%v\n", match)
+			}
+			return state.completed.ExitCode
+		}
+		if err := state.start(opts.Resume); err != nil {
+			cmdutil.ErrPrintf("find: could not save the state of the current run: %v\n", err)
+			return 1
+		}
+	}
+
 	// Do the walk
+	prog := newProgress()
+	stopReporting := prog.report(opts.Progress)
+	defer stopReporting()
 	conn := cmdutil.NewClient()
-	walker := newWalker(result, conn)
+	walker := newWalker(result, conn, state, prog)
 	exitCode := 0
+	if opts.Watch > 0 {
+		if !walker.Watch(result.Paths, opts.Watch) {
+			exitCode = 1
+		}
+		return exitCode
+	}
 	for _, path := range result.Paths {
 		if !walker.Walk(path) {
 			exitCode = 1
 		}
 	}
+	walker.Flush()
+	if !primary.FlushExecBatches() {
+		exitCode = 1
+	}
+	state.complete(exitCode)
 	return exitCode
 }
 
@@ -16,7 +16,7 @@ import (
 
 type MainTestSuite struct {
 	*cmdtest.Suite
-	oldNewWalker func(r parser.Result, conn client.Client) walker
+	oldNewWalker func(r parser.Result, conn client.Client, state *runState, prog *progress) walker
 	walker       *mockWalker
 }
 
@@ -24,8 +24,8 @@ func (s *MainTestSuite) SetupTest() {
 	s.Suite.SetupTest()
 	s.oldNewWalker = newWalker
 	s.walker = &mockWalker{}
-	newWalker = func(r parser.Result, conn client.Client) walker {
-		s.walker.walkerImpl = s.oldNewWalker(r, conn).(*walkerImpl)
+	newWalker = func(r parser.Result, conn client.Client, state *runState, prog *progress) walker {
+		s.walker.walkerImpl = s.oldNewWalker(r, conn, state, prog).(*walkerImpl)
 		return s.walker
 	}
 }
@@ -7,3 +7,15 @@ import "time"
 // ReferenceTime is the reference time that's used for `wash find`'s
 // time predicates. Defaults to `wash find`'s start time.
 var ReferenceTime time.Time
+
+// Force is set when the user passed the -force option. It tells the delete
+// primary to skip its confirmation prompts.
+var Force bool
+
+// ReadLimit is the maximum number of bytes that the grep primary reads
+// from an entry. It is set by the -readlimit option.
+var ReadLimit int64
+
+// StartPath is the start path of the current walk. It is set by the walker
+// before it walks each of the paths passed into find.
+var StartPath string
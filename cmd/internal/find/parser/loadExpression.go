@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/kballard/go-shellquote"
+	"github.com/puppetlabs/wash/cmd/internal/config"
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	"github.com/spf13/viper"
+)
+
+// SavedSearchesKey is the wash.yaml key containing the saved searches.
+// Each saved search maps a name to an expression, e.g.
+//
+//   find:
+//     searches:
+//       crashloop: -k '*pod' -meta '.status.phase' Failed
+//
+const SavedSearchesKey = "find.searches"
+
+// loadExpression loads the expressions specified by the file and search
+// options. The loaded expressions are and'ed with the expression in tokens.
+func loadExpression(o types.Options, tokens []string) ([]string, error) {
+	var loaded [][]string
+	if o.File != "" {
+		content, err := readExpressionFile(o.File)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the expression from %v: %v", o.File, err)
+		}
+		expr, err := tokenizeExpression(content)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the expression in %v: %v", o.File, err)
+		}
+		loaded = append(loaded, expr)
+	}
+	if o.Search != "" {
+		content, ok, err := lookupSavedSearch(o.Search)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("%v: unknown saved search. Saved searches are specified under the %v key", o.Search, SavedSearchesKey)
+		}
+		expr, err := tokenizeExpression(content)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the %v saved search: %v", o.Search, err)
+		}
+		loaded = append(loaded, expr)
+	}
+	if len(loaded) == 0 {
+		return tokens, nil
+	}
+	var combined []string
+	for _, expr := range append(loaded, tokens) {
+		if len(expr) == 0 {
+			continue
+		}
+		// Parenthesize each expression so that e.g. an -o in the file
+		// doesn't change the meaning of the command-line expression.
+		combined = append(combined, "(")
+		combined = append(combined, expr...)
+		combined = append(combined, ")")
+	}
+	return combined, nil
+}
+
+// Make this a variable so that the tests can mock it
+var readExpressionFile = func(file string) (string, error) {
+	content, err := ioutil.ReadFile(file)
+	return string(content), err
+}
+
+// Make this a variable so that the tests can mock it
+var lookupSavedSearch = func(name string) (string, bool, error) {
+	if err := config.ReadFrom(config.DefaultFile()); err != nil {
+		return "", false, err
+	}
+	key := SavedSearchesKey + "." + name
+	if !viper.IsSet(key) {
+		return "", false, nil
+	}
+	return viper.GetString(key), true, nil
+}
+
+// tokenizeExpression splits content into tokens the way a shell would.
+// Content can span multiple lines, and a "#" that starts a word begins
+// a comment that runs to the end of the line.
+func tokenizeExpression(content string) ([]string, error) {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		lines = append(lines, stripComment(line))
+	}
+	return shellquote.Split(strings.Join(lines, "\n"))
+}
+
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			} else if ch == '\\' && quote == '"' {
+				i++
+			}
+		case ch == '\\':
+			i++
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoadExpressionTestSuite struct {
+	suite.Suite
+	oldReadExpressionFile func(string) (string, error)
+	oldLookupSavedSearch  func(string) (string, bool, error)
+}
+
+func (s *LoadExpressionTestSuite) SetupTest() {
+	s.oldReadExpressionFile = readExpressionFile
+	s.oldLookupSavedSearch = lookupSavedSearch
+	readExpressionFile = func(file string) (string, error) {
+		if file == "expr.wash" {
+			return "# Running containers\n-k '*container' # the kind\n  -meta '.state' 'running # not a comment'\n", nil
+		}
+		return "", fmt.Errorf("no such file")
+	}
+	lookupSavedSearch = func(name string) (string, bool, error) {
+		if name == "recent" {
+			return "-mtime -1h", true, nil
+		}
+		return "", false, nil
+	}
+}
+
+func (s *LoadExpressionTestSuite) TearDownTest() {
+	readExpressionFile = s.oldReadExpressionFile
+	lookupSavedSearch = s.oldLookupSavedSearch
+}
+
+func (s *LoadExpressionTestSuite) TestNothingToLoad() {
+	tokens, err := loadExpression(types.NewOptions(), []string{"-name", "foo"})
+	if s.NoError(err) {
+		s.Equal([]string{"-name", "foo"}, tokens)
+	}
+}
+
+func (s *LoadExpressionTestSuite) TestFile() {
+	o := types.NewOptions()
+	o.File = "expr.wash"
+	tokens, err := loadExpression(o, []string{})
+	if s.NoError(err) {
+		s.Equal([]string{"(", "-k", "*container", "-meta", ".state", "running # not a comment", ")"}, tokens)
+	}
+
+	o.File = "missing.wash"
+	_, err = loadExpression(o, []string{})
+	s.Regexp("could not read the expression from missing.wash: no such file", err)
+}
+
+func (s *LoadExpressionTestSuite) TestSavedSearch() {
+	o := types.NewOptions()
+	o.Search = "recent"
+	tokens, err := loadExpression(o, []string{"-name", "foo"})
+	if s.NoError(err) {
+		s.Equal([]string{"(", "-mtime", "-1h", ")", "(", "-name", "foo", ")"}, tokens)
+	}
+
+	o.Search = "unknown"
+	_, err = loadExpression(o, []string{})
+	s.Regexp("unknown: unknown saved search", err)
+}
+
+func (s *LoadExpressionTestSuite) TestTokenizeExpression() {
+	tokens, err := tokenizeExpression("-name foo#bar \\# # comment\n-o '-name' \"a b\"")
+	if s.NoError(err) {
+		s.Equal([]string{"-name", "foo#bar", "#", "-o", "-name", "a b"}, tokens)
+	}
+	_, err = tokenizeExpression("-name 'foo")
+	s.Error(err)
+}
+
+func TestLoadExpression(t *testing.T) {
+	suite.Run(t, new(LoadExpressionTestSuite))
+}
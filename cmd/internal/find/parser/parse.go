@@ -24,6 +24,10 @@ func Parse(args []string) (Result, error) {
 	if err != nil {
 		return r, err
 	}
+	args, err = loadExpression(r.Options, args)
+	if err != nil {
+		return r, err
+	}
 	r.Predicate, err = parseExpression(args)
 	return r, err
 }
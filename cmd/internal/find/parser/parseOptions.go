@@ -74,6 +74,12 @@ func parseOptions(args []string) (types.Options, []string, error) {
 		if f.Name == types.MaxdepthFlag && o.Maxdepth < 0 {
 			o.Maxdepth = types.DefaultMaxdepth
 		}
+		if f.Name == types.ParallelFlag && o.Parallel < 1 {
+			o.Parallel = 1
+		}
+		if f.Name == types.MaxResultsFlag && o.MaxResults < 0 {
+			o.MaxResults = 0
+		}
 	})
 
 	// Calculate the remaining args
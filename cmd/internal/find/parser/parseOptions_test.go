@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/puppetlabs/wash/cmd/internal/find/types"
 	"github.com/stretchr/testify/suite"
@@ -139,6 +140,50 @@ func (s *ParseOptionsTestSuite) TestParseOptionsNegativeMaxdepth() {
 	s.RTC("-maxdepth -1", o, "")
 }
 
+func (s *ParseOptionsTestSuite) TestParseOptionsNonPositiveParallel() {
+	o := types.NewOptions()
+	o.MarkAsSet(types.ParallelFlag)
+	s.RTC("-parallel 0", o, "")
+	s.RTC("-parallel -1", o, "")
+}
+
+func (s *ParseOptionsTestSuite) TestParseOptionsReadLimit() {
+	o := types.NewOptions()
+	o.ReadLimit = 2048
+	o.MarkAsSet(types.ReadLimitFlag)
+	s.RTC("-readlimit 2048", o, "")
+	s.RTC("-readlimit 2k", o, "")
+	s.RETC("-readlimit 2x", "2x: illegal size value")
+	s.RETC("-readlimit 0", "size must be positive")
+}
+
+func (s *ParseOptionsTestSuite) TestParseOptionsResumeAndCached() {
+	o := types.NewOptions()
+	o.Resume = true
+	o.Cached = 10 * time.Minute
+	o.MarkAsSet(types.ResumeFlag)
+	o.MarkAsSet(types.CachedFlag)
+	s.RTC("-resume -cached 10m", o, "")
+}
+
+func (s *ParseOptionsTestSuite) TestParseOptionsSort() {
+	o := types.NewOptions()
+	o.Sort = types.SortByMtime
+	o.Reverse = true
+	o.MaxResults = 20
+	o.MarkAsSet(types.SortFlag)
+	o.MarkAsSet(types.ReverseFlag)
+	o.MarkAsSet(types.MaxResultsFlag)
+	s.RTC("-sort mtime -reverse -max-results 20", o, "")
+	s.RETC("-sort foo", "foo: expected one of mtime, size or name")
+}
+
+func (s *ParseOptionsTestSuite) TestParseOptionsNegativeMaxResults() {
+	o := types.NewOptions()
+	o.MarkAsSet(types.MaxResultsFlag)
+	s.RTC("-max-results -1", o, "")
+}
+
 func TestParseOptions(t *testing.T) {
 	suite.Run(t, new(ParseOptionsTestSuite))
 }
@@ -16,32 +16,63 @@ var Action = Parser.add(&Primary{
 	Description: "Returns true if the entry supports action",
 	name:        "action",
 	args:        "action",
-	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
-		if len(tokens) == 0 {
-			return nil, nil, fmt.Errorf("requires additional arguments")
+	parseFunc:   parseActionPrimary,
+})
+
+// Supports is the supports primary. It is an alias of the action
+// primary that reads better in bulk operations, e.g.
+// "-supports exec -exec ...".
+//
+// supportsPrimary => -supports <action>
+//nolint
+var Supports = Parser.add(&Primary{
+	Description:         "Same as -action",
+	DetailedDescription: supportsDetailedDescription,
+	name:                "supports",
+	args:                "action",
+	parseFunc:           parseActionPrimary,
+})
+
+func parseActionPrimary(tokens []string) (types.EntryPredicate, []string, error) {
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("requires additional arguments")
+	}
+	validActions := plugin.Actions()
+	action, ok := validActions[tokens[0]]
+	if !ok {
+		// User's querying an invalid action, so return an error.
+		validActionsArray := make([]string, 0, len(validActions))
+		for actionName := range validActions {
+			validActionsArray = append(validActionsArray, actionName)
 		}
-		validActions := plugin.Actions()
-		action, ok := validActions[tokens[0]]
-		if !ok {
-			// User's querying an invalid action, so return an error.
-			validActionsArray := make([]string, 0, len(validActions))
-			for actionName := range validActions {
-				validActionsArray = append(validActionsArray, actionName)
+		validActionsStr := strings.Join(validActionsArray, ", ")
+		return nil, nil, fmt.Errorf("%v is an invalid action. Valid actions are %v", tokens[0], validActionsStr)
+	}
+	p := types.ToEntryP(func(e types.Entry) bool {
+		return e.Supports(action)
+	})
+	p.SetSchemaP(types.ToEntrySchemaP(func(s *types.EntrySchema) bool {
+		for _, a := range s.Actions() {
+			if action.Name == a {
+				return true
 			}
-			validActionsStr := strings.Join(validActionsArray, ", ")
-			return nil, nil, fmt.Errorf("%v is an invalid action. Valid actions are %v", tokens[0], validActionsStr)
 		}
-		p := types.ToEntryP(func(e types.Entry) bool {
-			return e.Supports(action)
-		})
-		p.SetSchemaP(types.ToEntrySchemaP(func(s *types.EntrySchema) bool {
-			for _, a := range s.Actions() {
-				if action.Name == a {
-					return true
-				}
-			}
-			return false
-		}))
-		return p, tokens[1:], nil
-	},
-})
+		return false
+	}))
+	return p, tokens[1:], nil
+}
+
+const supportsDetailedDescription = `
+-supports action
+
+Returns true if the entry supports action. Like -action, -supports uses the
+entry schemas to only visit the entries that can support action.
+
+-supports is useful for restricting bulk operations to the entries that can
+perform them. For example,
+
+  find docker -supports exec -exec wash exec {} uptime \;
+
+runs uptime on every Docker entry that supports the exec action (i.e. every
+running container), instead of failing on the entries that don't.
+`
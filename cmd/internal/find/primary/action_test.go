@@ -28,6 +28,18 @@ func (s *ActionPrimaryTestSuite) TestValidInput_SchemaP() {
 	s.RSTC("list", "", []string{"read", "stream", "list"}, []string{"read", "stream"})
 }
 
+func (s *ActionPrimaryTestSuite) TestSupports() {
+	p, tokens, err := Supports.Parse([]string{"exec", "-foo"})
+	if s.NoError(err) {
+		s.Equal([]string{"-foo"}, tokens)
+		ep := p.(types.EntryPredicate)
+		s.True(ep.P(s.ConstructEntry([]string{"exec"})))
+		s.False(ep.P(s.ConstructEntry([]string{"list"})))
+	}
+	_, _, err = Supports.Parse([]string{"foo"})
+	s.Regexp("foo is an invalid action", err)
+}
+
 func TestActionPrimary(t *testing.T) {
 	s := new(ActionPrimaryTestSuite)
 	s.Parser = Action
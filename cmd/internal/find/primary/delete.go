@@ -0,0 +1,92 @@
+package primary
+
+import (
+	"fmt"
+
+	"github.com/puppetlabs/wash/api/client"
+	"github.com/puppetlabs/wash/cmd/internal/find/params"
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Delete is the delete primary
+//
+// deletePrimary => -delete
+//nolint
+var Delete = Parser.add(&Primary{
+	Description:         "Deletes the entry. Returns true if the entry was deleted",
+	DetailedDescription: deleteDetailedDescription,
+	name:                "delete",
+	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
+		var conn client.Client
+		deleteAction := plugin.DeleteAction()
+		p := types.ToEntryP(func(e types.Entry) bool {
+			if !e.Supports(deleteAction) {
+				return false
+			}
+			if !params.Force {
+				// Never delete without confirmation. find's main refuses to
+				// run -delete non-interactively without -force, so this is
+				// just a safeguard.
+				if !plugin.IsInteractive() || !confirmDeletion(e.NormalizedPath) {
+					return false
+				}
+			}
+			if conn == nil {
+				conn = cmdutil.NewClient()
+			}
+			deleted, err := conn.Delete(e.Path)
+			if err != nil {
+				cmdutil.ErrPrintf("could not delete %v: %v\n", e.NormalizedPath, err)
+				return false
+			}
+			if !deleted {
+				cmdutil.Printf("%v has been marked for deletion and will eventually be deleted\n", e.NormalizedPath)
+			}
+			return true
+		})
+		// Only traverse the entries that support the delete action
+		p.SetSchemaP(types.ToEntrySchemaP(func(s *types.EntrySchema) bool {
+			for _, a := range s.Actions() {
+				if a == deleteAction.Name {
+					return true
+				}
+			}
+			return false
+		}))
+		return p, tokens, nil
+	},
+})
+
+// Make this a variable so that the tests can mock it
+var confirmDeletion = func(path string) bool {
+	return promptYesOrNo(fmt.Sprintf("remove %v?", path))
+}
+
+const deleteDetailedDescription = `
+-delete
+
+Deletes the entry, returning true if it was deleted (or marked for deletion).
+Entries that do not support the delete action are skipped, and -delete returns
+false for them.
+
+-delete asks for confirmation before deleting each entry. Use the -force
+option to skip the confirmation. Since the confirmation needs a terminal,
+find refuses to run -delete without -force when it isn't run interactively,
+e.g. from a script or cron job.
+
+Note that -delete turns on the -depth option so that an entry's children are
+visited before the entry itself. Like -exec, the matching entries are not
+printed. Since -delete acts on every entry that reaches it, it should usually
+be the last primary in the expression. For example,
+
+  find docker/containers -k '*container' -meta '.state' exited -delete
+
+deletes all exited Docker containers, while
+
+  find gcp -force -k '*storage*object' -mtime +90d -delete
+
+deletes all GCP storage objects that are older than 90 days without asking
+for confirmation.
+`
@@ -0,0 +1,80 @@
+package primary
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/puppetlabs/wash/api/client"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+	"github.com/puppetlabs/wash/cmd/internal/find/params"
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type DeletePrimaryTestSuite struct {
+	primaryTestSuite
+	client       *cmdtest.MockClient
+	oldNewClient func() client.Client
+}
+
+func (s *DeletePrimaryTestSuite) SetupTest() {
+	s.primaryTestSuite.SetupTest()
+	s.client = &cmdtest.MockClient{}
+	s.oldNewClient = cmdutil.NewClient
+	cmdutil.NewClient = func() client.Client {
+		return s.client
+	}
+	params.Force = true
+}
+
+func (s *DeletePrimaryTestSuite) TearDownTest() {
+	s.primaryTestSuite.TeardownTest()
+	cmdutil.NewClient = s.oldNewClient
+	params.Force = false
+}
+
+func (s *DeletePrimaryTestSuite) TestValidInput_EntryP() {
+	s.client.On("Delete", "foo").Return(true, nil).Once()
+	s.RTC("-foo", "-foo", []string{"delete"})
+	s.RNTC("", "", []string{"list"})
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *DeletePrimaryTestSuite) TestValidInput_EntryP_DeleteError() {
+	s.client.On("Delete", "foo").Return(false, fmt.Errorf("failed")).Once()
+	s.RNTC("", "", []string{"delete"})
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *DeletePrimaryTestSuite) TestValidInput_EntryP_NonInteractiveWithoutForce() {
+	plugin.InitInteractive(false)
+	params.Force = false
+	s.RNTC("", "", []string{"delete"})
+	s.client.AssertNotCalled(s.T(), "Delete", mock.Anything)
+}
+
+func (s *DeletePrimaryTestSuite) TestValidInput_SchemaP() {
+	s.RSTC("", "", []string{"delete"}, []string{"list"})
+}
+
+func TestDeletePrimary(t *testing.T) {
+	s := new(DeletePrimaryTestSuite)
+	s.Parser = Delete
+	s.ConstructEntry = func(v interface{}) types.Entry {
+		e := types.Entry{}
+		e.Path = "foo"
+		e.NormalizedPath = "foo"
+		e.Actions = v.([]string)
+		return e
+	}
+	s.SchemaPParser = types.EntryPredicateParser(Delete.parseFunc).ToSchemaPParser()
+	s.ConstructEntrySchema = func(v interface{}) *types.EntrySchema {
+		s := &types.EntrySchema{}
+		s.SetActions(v.([]string))
+		return s
+	}
+	suite.Run(t, s)
+}
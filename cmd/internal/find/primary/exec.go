@@ -0,0 +1,224 @@
+package primary
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+)
+
+// Exec is the exec primary
+//
+// execPrimary => -exec command [argument ...] ;
+//              | -exec command [argument ...] {} +
+//nolint
+var Exec = Parser.add(&Primary{
+	Description:         "Runs command on the entry. Returns true if command exits 0",
+	DetailedDescription: execDetailedDescription,
+	name:                "exec",
+	args:                "command [argument ...] ;|+",
+	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
+		return parseExec(tokens, false)
+	},
+})
+
+// Ok is the ok primary
+//
+// okPrimary => -ok command [argument ...] ;
+//nolint
+var Ok = Parser.add(&Primary{
+	Description:         "Like -exec, but asks for confirmation before running command",
+	DetailedDescription: okDetailedDescription,
+	name:                "ok",
+	args:                "command [argument ...] ;",
+	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
+		return parseExec(tokens, true)
+	},
+})
+
+// maxBatchSize is the maximum number of paths that are passed to a
+// single invocation of a batched ("+") command.
+const maxBatchSize = 1000
+
+// execBatch accumulates the paths of a batched ("+") command
+type execBatch struct {
+	cmd   []string
+	paths []string
+	// failed is true if one of the batch's commands failed
+	failed bool
+}
+
+func (b *execBatch) flush() bool {
+	if len(b.paths) == 0 {
+		return !b.failed
+	}
+	argv := append(append([]string{}, b.cmd...), b.paths...)
+	b.paths = nil
+	if !runCommand(argv) {
+		b.failed = true
+	}
+	return !b.failed
+}
+
+// execBatches contains the batches of all the parsed "-exec ... +" primaries
+var execBatches []*execBatch
+
+// FlushExecBatches runs all the pending "-exec ... +" commands. It returns
+// false if any of the batched commands failed, including the ones that ran
+// during the walk. Call this after `wash find` finishes its walk.
+func FlushExecBatches() bool {
+	successful := true
+	for _, b := range execBatches {
+		successful = b.flush() && successful
+		// Reset the batch for the next walk, e.g. with -watch
+		b.failed = false
+	}
+	return successful
+}
+
+// PerformsActions returns true if one of the set primaries acts on the
+// matching entries. In that case, `wash find` does not print the entries.
+// Call this after `wash find` finishes parsing its arguments.
+func PerformsActions() bool {
+	return IsSet(Exec) || IsSet(Ok) || IsSet(Delete) || IsSet(JSON) || IsSet(Printf)
+}
+
+func parseExec(tokens []string, confirm bool) (types.EntryPredicate, []string, error) {
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("requires additional arguments")
+	}
+	endIx := -1
+	for i, token := range tokens {
+		if token == ";" || (!confirm && token == "+" && i > 0 && tokens[i-1] == "{}") {
+			endIx = i
+			break
+		}
+	}
+	if endIx < 0 {
+		if confirm {
+			return nil, nil, fmt.Errorf("missing terminating ';'")
+		}
+		return nil, nil, fmt.Errorf("missing terminating ';' or '+'")
+	}
+	if endIx == 0 {
+		return nil, nil, fmt.Errorf("requires a command")
+	}
+	cmd, terminator := tokens[:endIx], tokens[endIx]
+	tokens = tokens[endIx+1:]
+
+	if terminator == "+" {
+		if endIx == 1 {
+			return nil, nil, fmt.Errorf("requires a command")
+		}
+		b := &execBatch{cmd: cmd[:len(cmd)-1]}
+		execBatches = append(execBatches, b)
+		p := types.ToEntryP(func(e types.Entry) bool {
+			b.paths = append(b.paths, e.NormalizedPath)
+			if len(b.paths) >= maxBatchSize {
+				// The batched form always returns true. A failure is
+				// reported by FlushExecBatches instead.
+				b.flush()
+			}
+			return true
+		})
+		return p, tokens, nil
+	}
+
+	p := types.ToEntryP(func(e types.Entry) bool {
+		argv := make([]string, len(cmd))
+		for i, arg := range cmd {
+			argv[i] = strings.Replace(arg, "{}", e.NormalizedPath, -1)
+		}
+		if confirm && !confirmCommand(argv) {
+			return false
+		}
+		return runCommand(argv)
+	})
+	return p, tokens, nil
+}
+
+// Make this a variable so that the tests can mock it
+var runCommand = func(argv []string) bool {
+	c := exec.Command(argv[0], argv[1:]...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			cmdutil.ErrPrintf("could not run %v: %v\n", argv[0], err)
+		}
+		return false
+	}
+	return true
+}
+
+// Make this a variable so that the tests can mock it
+var confirmCommand = func(argv []string) bool {
+	msg := fmt.Sprintf("< %v > ?", strings.Join(argv, " "))
+	// The command inherits stdin, so read the response from the terminal
+	// instead. That way, the response can't consume the command's input.
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return promptYesOrNo(msg)
+	}
+	defer tty.Close()
+	input, err := cmdutil.PromptFrom(tty, msg, cmdutil.YesOrNoP)
+	if err != nil {
+		return false
+	}
+	return input.(bool)
+}
+
+// promptYesOrNo prompts the user with msg, returning true if they confirmed it
+func promptYesOrNo(msg string) bool {
+	input, err := cmdutil.Prompt(msg, cmdutil.YesOrNoP)
+	if err != nil {
+		// This includes an empty response
+		return false
+	}
+	return input.(bool)
+}
+
+const execDetailedDescription = `
+-exec command [argument ...] ;
+-exec command [argument ...] {} +
+
+Runs command on the entry, returning true if command exits with status 0.
+The first form runs command once per entry, replacing every occurrence
+of "{}" in the arguments with the entry's path. The second form batches
+the entries, appending their paths to the arguments and running command
+as few times as possible. The second form always returns true; if any of
+its commands fail, then find exits with a non-zero status. Note that
+the ';' terminator typically needs to be quoted or escaped so that the
+shell doesn't interpret it.
+
+If the expression contains -exec, -ok, -delete, -json or -printf, then
+find does not print the matching entries.
+
+Wash actions are run through their wash commands. For example,
+
+  find docker/containers -k '*container' -meta '.state' running -exec wash signal stop {} \;
+
+stops all running Docker containers, while
+
+  find gcp -k '*storage*object' -mtime +30d -exec wash delete {} +
+
+deletes all GCP storage objects that are older than 30 days.
+`
+
+const okDetailedDescription = `
+-ok command [argument ...] ;
+
+Like -exec, but it asks for confirmation on stderr before running command.
+The response is read from the terminal (or from stdin if there isn't one)
+so that it doesn't consume the command's input. The command is only run if
+the response starts with 'y' or 'Y'; otherwise, -ok returns false. For
+example,
+
+  find docker/containers -k '*container' -ok wash signal stop {} \;
+
+prompts before stopping each Docker container.
+`
@@ -0,0 +1,129 @@
+package primary
+
+import (
+	"testing"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	"github.com/stretchr/testify/suite"
+)
+
+// execTestSuite mocks the commands that are run by the exec and ok primaries
+type execTestSuite struct {
+	primaryTestSuite
+	oldRunCommand     func([]string) bool
+	oldConfirmCommand func([]string) bool
+	ran               [][]string
+	confirm           bool
+}
+
+func (s *execTestSuite) SetupTest() {
+	s.primaryTestSuite.SetupTest()
+	s.oldRunCommand = runCommand
+	s.oldConfirmCommand = confirmCommand
+	s.ran = nil
+	runCommand = func(argv []string) bool {
+		s.ran = append(s.ran, argv)
+		return argv[0] != "false"
+	}
+	confirmCommand = func([]string) bool {
+		return s.confirm
+	}
+}
+
+func (s *execTestSuite) TearDownTest() {
+	s.primaryTestSuite.TeardownTest()
+	runCommand = s.oldRunCommand
+	confirmCommand = s.oldConfirmCommand
+	execBatches = nil
+}
+
+type ExecPrimaryTestSuite struct {
+	execTestSuite
+}
+
+func (s *ExecPrimaryTestSuite) TestErrors() {
+	s.RETC("", "requires additional arguments")
+	s.RETC("echo {}", "missing terminating ';' or '\\+'")
+	s.RETC("echo +", "missing terminating ';' or '\\+'")
+	s.RETC(";", "requires a command")
+	s.RETC("{} +", "requires a command")
+}
+
+func (s *ExecPrimaryTestSuite) TestValidInput() {
+	s.RTC("true ; -foo", "-foo", "foo")
+	s.RNTC("false ;", "", "foo")
+	s.RTC("echo {} x{}y ;", "", "foo")
+	s.Equal([]string{"echo", "foo", "xfooy"}, s.ran[len(s.ran)-1])
+}
+
+func (s *ExecPrimaryTestSuite) TestBatch() {
+	p, tokens, err := Exec.Parse([]string{"echo", "a", "{}", "+", "-foo"})
+	if s.NoError(err) {
+		s.Equal([]string{"-foo"}, tokens)
+		ep := p.(types.EntryPredicate)
+		s.True(ep.P(s.ConstructEntry("foo")))
+		s.True(ep.P(s.ConstructEntry("bar")))
+		s.Empty(s.ran)
+		s.True(FlushExecBatches())
+		s.Equal([][]string{{"echo", "a", "foo", "bar"}}, s.ran)
+		// A flushed batch shouldn't run again
+		s.True(FlushExecBatches())
+		s.Len(s.ran, 1)
+	}
+}
+
+func (s *ExecPrimaryTestSuite) TestBatch_FullBatchFails() {
+	p, _, err := Exec.Parse([]string{"false", "{}", "+"})
+	if s.NoError(err) {
+		ep := p.(types.EntryPredicate)
+		// The batched form returns true even when a full batch's command fails
+		for i := 0; i < maxBatchSize; i++ {
+			s.True(ep.P(s.ConstructEntry("foo")))
+		}
+		s.Len(s.ran, 1)
+		s.False(FlushExecBatches())
+		// The failure was reported, so the next walk starts afresh
+		s.True(FlushExecBatches())
+	}
+}
+
+func TestExecPrimary(t *testing.T) {
+	s := new(ExecPrimaryTestSuite)
+	s.Parser = Exec
+	s.ConstructEntry = func(v interface{}) types.Entry {
+		e := types.Entry{}
+		e.NormalizedPath = v.(string)
+		return e
+	}
+	suite.Run(t, s)
+}
+
+type OkPrimaryTestSuite struct {
+	execTestSuite
+}
+
+func (s *OkPrimaryTestSuite) TestErrors() {
+	s.RETC("", "requires additional arguments")
+	s.RETC("echo {}", "missing terminating ';'")
+	s.RETC("echo {} +", "missing terminating ';'")
+}
+
+func (s *OkPrimaryTestSuite) TestValidInput() {
+	s.confirm = true
+	s.RTC("true ;", "", "foo")
+	s.Len(s.ran, 1)
+	s.confirm = false
+	s.RNTC("true ;", "", "foo")
+	s.Len(s.ran, 1)
+}
+
+func TestOkPrimary(t *testing.T) {
+	s := new(OkPrimaryTestSuite)
+	s.Parser = Ok
+	s.ConstructEntry = func(v interface{}) types.Entry {
+		e := types.Entry{}
+		e.NormalizedPath = v.(string)
+		return e
+	}
+	suite.Run(t, s)
+}
@@ -0,0 +1,98 @@
+package primary
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/params"
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Grep is the grep primary
+//
+// grepPrimary => -grep Regex
+//nolint
+var Grep = Parser.add(&Primary{
+	Description:         "Returns true if the entry's content matches regex",
+	DetailedDescription: grepDetailedDescription,
+	name:                "grep",
+	args:                "regex",
+	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
+		if len(tokens) == 0 {
+			return nil, nil, fmt.Errorf("requires additional arguments")
+		}
+		re, err := regexp.Compile(tokens[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid regex: %v", err)
+		}
+		readAction := plugin.ReadAction()
+		p := types.ToEntryP(func(e types.Entry) bool {
+			if !e.Supports(readAction) {
+				return false
+			}
+			content, err := readContent(e.Path, params.ReadLimit)
+			if err != nil {
+				cmdutil.ErrPrintf("could not read %v: %v\n", e.NormalizedPath, err)
+				return false
+			}
+			return re.Match(content)
+		})
+		// Only traverse the entries that support the read action
+		p.SetSchemaP(types.ToEntrySchemaP(func(s *types.EntrySchema) bool {
+			for _, a := range s.Actions() {
+				if a == readAction.Name {
+					return true
+				}
+			}
+			return false
+		}))
+		return p, tokens[1:], nil
+	},
+})
+
+// readContent reads at most limit bytes of the entry's content. Make this a
+// variable so that the tests can mock it.
+var readContent = func(path string, limit int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ioutil.ReadAll(io.LimitReader(f, limit))
+}
+
+const grepDetailedDescription = `
+-grep regex
+
+Returns true if the entry's content matches regex. The regex uses Go's
+regular expression syntax (https://golang.org/pkg/regexp/syntax). Only
+entries that support the read action are considered; stream-only entries
+like logs that are continuously written to are skipped.
+
+Reading content is expensive, so -grep only reads the first 1 mebibyte of
+each entry. Use the -readlimit option to change that. Entries are read one
+at a time. To avoid reading more entries than necessary, put -grep after
+cheaper primaries like -name or -kind so that it is only evaluated on the
+entries that satisfy them.
+
+Examples:
+  -grep 'password'           Returns true if the entry's content contains
+                             "password"
+
+  -grep '(?im)^listen\s'     Returns true if one of the entry's lines
+                             begins with "listen", ignoring case. The
+                             "m" flag makes ^ match the start of each
+                             line
+
+For example,
+
+  find docker/volumes -name '*.conf' -grep 'ssl_certificate'
+
+finds all the config files in Docker volumes that configure an SSL
+certificate.
+`
@@ -0,0 +1,73 @@
+package primary
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/params"
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type GrepPrimaryTestSuite struct {
+	primaryTestSuite
+	oldReadContent func(string, int64) ([]byte, error)
+}
+
+func (s *GrepPrimaryTestSuite) SetupTest() {
+	s.primaryTestSuite.SetupTest()
+	s.oldReadContent = readContent
+	params.ReadLimit = 3
+	readContent = func(path string, limit int64) ([]byte, error) {
+		if path == "unreadable" {
+			return nil, fmt.Errorf("failed")
+		}
+		content := []byte(path)
+		if int64(len(content)) > limit {
+			content = content[:limit]
+		}
+		return content, nil
+	}
+}
+
+func (s *GrepPrimaryTestSuite) TearDownTest() {
+	s.primaryTestSuite.TeardownTest()
+	readContent = s.oldReadContent
+	params.ReadLimit = 0
+}
+
+func (s *GrepPrimaryTestSuite) TestErrors() {
+	s.RETC("", "requires additional arguments")
+	s.RETC("(", "invalid regex")
+}
+
+func (s *GrepPrimaryTestSuite) TestValidInput() {
+	s.RTC("fo -foo", "-foo", "foo", "bar")
+	s.RTC("^f.o$", "", "fxo", "bar")
+	// The content's truncated to the read limit
+	s.RNTC("foobar", "", "foobar")
+	s.RNTC("un", "", "unreadable")
+}
+
+func (s *GrepPrimaryTestSuite) TestValidInput_SchemaP() {
+	s.RSTC("foo", "", []string{"read"}, []string{"stream"})
+}
+
+func TestGrepPrimary(t *testing.T) {
+	s := new(GrepPrimaryTestSuite)
+	s.Parser = Grep
+	s.ConstructEntry = func(v interface{}) types.Entry {
+		e := types.Entry{}
+		e.Path = v.(string)
+		e.NormalizedPath = v.(string)
+		e.Actions = []string{"read"}
+		return e
+	}
+	s.SchemaPParser = types.EntryPredicateParser(Grep.parseFunc).ToSchemaPParser()
+	s.ConstructEntrySchema = func(v interface{}) *types.EntrySchema {
+		s := &types.EntrySchema{}
+		s.SetActions(v.([]string))
+		return s
+	}
+	suite.Run(t, s)
+}
@@ -11,34 +11,70 @@ import (
 
 // Kind is the kind primary
 //
-// kindPrimary => -kind ShellPattern
+// kindPrimary => -kind ShellPattern[,ShellPattern...]
 //nolint
 var Kind = Parser.add(&Primary{
-	Description:         "Returns true if the entry's kind matches pattern",
+	Description:         "Returns true if the entry's kind matches one of the comma-separated patterns",
 	DetailedDescription: kindDetailedDescription,
 	name:                "kind",
-	args:                "pattern",
+	args:                "pattern[,pattern...]",
 	shortName:           "k",
 	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
 		if len(tokens) == 0 {
 			return nil, nil, fmt.Errorf("requires additional arguments")
 		}
-		g, err := glob.Compile(tokens[0])
-		if err != nil {
-			return nil, nil, fmt.Errorf("invalid pattern: %v", err)
+		var globs []glob.Glob
+		for _, pattern := range splitAlternatives(tokens[0]) {
+			if len(pattern) == 0 {
+				return nil, nil, fmt.Errorf("invalid pattern: empty alternative in %v", tokens[0])
+			}
+			g, err := glob.Compile(pattern)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid pattern: %v", err)
+			}
+			globs = append(globs, g)
 		}
-		return kindP(g, false), tokens[1:], nil
+		return kindP(globs, false), tokens[1:], nil
 	},
 })
 
-func kindP(g glob.Glob, negated bool) types.EntryPredicate {
+// splitAlternatives splits pattern on its top-level commas. Commas that are
+// escaped or inside a "{...}" or "[...]" are part of the pattern.
+func splitAlternatives(pattern string) []string {
+	var alternatives []string
+	start := 0
+	braces := 0
+	inBrackets := false
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; {
+		case ch == '\\':
+			// Skip the escaped character
+			i++
+		case inBrackets:
+			inBrackets = ch != ']'
+		case ch == '[':
+			inBrackets = true
+		case ch == '{':
+			braces++
+		case ch == '}' && braces > 0:
+			braces--
+		case ch == ',' && braces == 0:
+			alternatives = append(alternatives, pattern[start:i])
+			start = i + 1
+		}
+	}
+	return append(alternatives, pattern[start:])
+}
+
+func kindP(globs []glob.Glob, negated bool) types.EntryPredicate {
 	p := kindPredicate{
 		EntryPredicate: types.ToEntryP(func(e types.Entry) bool {
 			// kind is a schema predicate, so the entry predicate should
 			// always return true
 			return true
 		}),
-		g: g,
+		globs:   globs,
+		negated: negated,
 	}
 	p.SetSchemaP(types.ToEntrySchemaP(func(s *types.EntrySchema) bool {
 		segments := strings.SplitN(s.Path(), "/", 2)
@@ -47,14 +83,12 @@ func kindP(g glob.Glob, negated bool) types.EntryPredicate {
 			return false
 		}
 		kind := segments[1]
-		if !g.Match(kind) {
-			return negated
+		for _, g := range globs {
+			if g.Match(kind) {
+				return !negated
+			}
 		}
-		// g matches kind
-		if negated {
-			return false
-		}
-		return true
+		return negated
 	}))
 	p.RequireSchema()
 	return p
@@ -63,21 +97,23 @@ func kindP(g glob.Glob, negated bool) types.EntryPredicate {
 // The separate type's necessary to implement proper Negation semantics.
 type kindPredicate struct {
 	types.EntryPredicate
-	g       glob.Glob
+	globs   []glob.Glob
 	negated bool
 }
 
 func (p kindPredicate) Negate() predicate.Predicate {
-	return kindP(p.g, !p.negated)
+	return kindP(p.globs, !p.negated)
 }
 
 const kindDetailedDescription = `
-(-k|-kind) pattern
+(-k|-kind) pattern[,pattern...]
 
 The kind primary constructs a predicate on the entry's kind, where the entry's
 kind is its schema path but without the <root_label>. It will return true if
-the entry's kind matches pattern, false otherwise. Note that the kind primary
-will always return false for schema-less entries.
+the entry's kind matches one of the comma-separated patterns, false otherwise.
+Commas inside "{...}" or "[...]", or escaped with a backslash, are part of the
+pattern. Note that the kind primary will always return false for schema-less
+entries, even when it is negated.
 
 An entry's schema path is constructed as <root_label>/<parent1_label>/.../<label>,
 where <root_label> is the label of the stree root(s). The stree root(s) are the
@@ -120,8 +156,19 @@ find docker -k '*container' -mtime -1h
 
 find -kind 'docker/*container' -o -k 'aws/*ec2*instance' -mtime -1h
 find -k 'docker/*container' -o -k 'aws/*ec2*instance' -mtime -1h
+find -k 'docker/*container,aws/*ec2*instance' -mtime -1h
     This prints out all Docker containers and EC2 instances that were modified within the
-    last hour.
+    last hour. The last form is shorthand for the first two.
+
+find aws/demo ! -kind '*log*group'
+find aws/demo ! -k '*log*group'
+    This prints out everything in the demo profile except CloudWatch log groups. Note
+    that negation only excludes the matching entries themselves, not their children,
+    so find still visits each log group's streams. Use -prune to exclude the log groups'
+    subtrees, e.g. "\( -k '*log*group' -prune -false \) -o -true".
+
+find docker ! -k '*container,*volume'
+    This prints out every Docker entry that is neither a container nor a volume.
 
 NOTE: You can use 'stree <path>' to determine an entry's kind. For example, if <path>
 is "docker", then
@@ -15,6 +15,7 @@ type KindPrimaryTestSuite struct {
 func (s *KindPrimaryTestSuite) TestErrors() {
 	s.RETC("", "requires additional arguments")
 	s.RETC("[a", "invalid pattern: unexpected end of input")
+	s.RETC("a,,b", "invalid pattern: empty alternative in a,,b")
 }
 
 func (s *KindPrimaryTestSuite) TestValidInput() {
@@ -22,12 +23,25 @@ func (s *KindPrimaryTestSuite) TestValidInput() {
 	s.RNTC("a", "", types.Entry{})
 	// Test the main schema predicate
 	s.RSTC("containers*container", "", "docker/containers/container", "docker/containers/container/fs")
+	// Test comma-separated alternatives
+	s.RSTC("*container,*volume", "", "docker/containers/container", "docker/containers/container/fs")
+	s.RSTC("*container,*volume", "", "docker/volumes/volume", "docker/volumes")
+	s.RSTC("{*container,*volume}", "", "docker/volumes/volume", "docker/volumes")
+	s.RSTC("*foo\\,bar", "", "docker/foo,bar", "docker/bar")
+}
+
+func (s *KindPrimaryTestSuite) TestSplitAlternatives() {
+	s.Equal([]string{"a"}, splitAlternatives("a"))
+	s.Equal([]string{"a", "b", ""}, splitAlternatives("a,b,"))
+	s.Equal([]string{"{a,b}", "c"}, splitAlternatives("{a,b},c"))
+	s.Equal([]string{"[,]", "c"}, splitAlternatives("[,],c"))
+	s.Equal([]string{"a\\,b"}, splitAlternatives("a\\,b"))
 }
 
 func (s *KindPrimaryTestSuite) TestKindP() {
 	g, err := glob.Compile("containers*container")
 	if s.NoError(err) {
-		p := kindP(g, false)
+		p := kindP([]glob.Glob{g}, false)
 
 		// Test the entry predicate
 		entry := types.Entry{}
@@ -55,7 +69,7 @@ func (s *KindPrimaryTestSuite) TestKindP() {
 func (s *KindPrimaryTestSuite) TestKindP_Negate() {
 	g, err := glob.Compile("containers*container")
 	if s.NoError(err) {
-		p := kindP(g, false).Negate().(types.EntryPredicate)
+		p := kindP([]glob.Glob{g}, false).Negate().(types.EntryPredicate)
 
 		// Test the entry predicate
 		entry := types.Entry{}
@@ -80,6 +94,18 @@ func (s *KindPrimaryTestSuite) TestKindP_Negate() {
 	}
 }
 
+func (s *KindPrimaryTestSuite) TestKindP_DoubleNegate() {
+	g, err := glob.Compile("containers*container")
+	if s.NoError(err) {
+		p := kindP([]glob.Glob{g}, false).Negate().(types.EntryPredicate).Negate().(types.EntryPredicate)
+		schema := &types.EntrySchema{}
+		schema.SetPath("docker/containers/container")
+		s.True(p.SchemaP().P(schema))
+		schema.SetPath("docker/containers/container/fs")
+		s.False(p.SchemaP().P(schema))
+	}
+}
+
 func TestKindPrimary(t *testing.T) {
 	s := new(KindPrimaryTestSuite)
 	s.Parser = Kind
@@ -0,0 +1,31 @@
+package primary
+
+import (
+	"fmt"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/primary/numeric"
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+)
+
+// Level is the level primary
+//
+// levelPrimary => -level (+|-)?\d+ | \d+..\d+
+//nolint
+var Level = Parser.add(&Primary{
+	Description: "Returns true if the entry's depth relative to its start path satisfies the given numeric predicate",
+	name:        "level",
+	args:        "[+|-]n | m..n",
+	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
+		if len(tokens) == 0 {
+			return nil, nil, fmt.Errorf("requires additional arguments")
+		}
+		numericP, _, err := numeric.ParsePredicate(tokens[0], numeric.ParsePositiveInt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%v: illegal level value", tokens[0])
+		}
+		p := types.ToEntryP(func(e types.Entry) bool {
+			return numericP(int64(e.Depth))
+		})
+		return p, tokens[1:], nil
+	},
+})
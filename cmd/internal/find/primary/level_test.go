@@ -0,0 +1,36 @@
+package primary
+
+import (
+	"testing"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type LevelPrimaryTestSuite struct {
+	primaryTestSuite
+}
+
+func (s *LevelPrimaryTestSuite) TestErrors() {
+	s.RETC("", "requires additional arguments")
+	s.RETC("foo", "foo: illegal level value")
+	s.RETC("3..1", "3..1: illegal level value")
+}
+
+func (s *LevelPrimaryTestSuite) TestValidInput() {
+	s.RTC("2", "", uint(2), uint(3))
+	s.RTC("+2", "", uint(3), uint(2))
+	s.RTC("-2", "", uint(1), uint(2))
+	s.RTC("1..3", "", uint(3), uint(4))
+}
+
+func TestLevelPrimary(t *testing.T) {
+	s := new(LevelPrimaryTestSuite)
+	s.Parser = Level
+	s.ConstructEntry = func(v interface{}) types.Entry {
+		e := types.Entry{}
+		e.Depth = v.(uint)
+		return e
+	}
+	suite.Run(t, s)
+}
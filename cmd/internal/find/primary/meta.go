@@ -46,8 +46,8 @@ NullPredicate       => -null
 ExistsPredicate     => -exists
 BooleanPredicate    => -true | -false
 
-NumericPredicate    => (+|-)? Number
-Number              => N | '{' N '}' | numeric.SizeRegex
+NumericPredicate    => (+|-)? Number | Number '..' Number
+Number              => N | '{' N '}' | numeric.SizeRegex | numeric.ScientificRegex
 
 TimePredicate       => (+|-)? Duration
 Duration            => numeric.DurationRegex | '{' numeric.DurationRegex '}'
@@ -117,6 +117,17 @@ for each chunk:
       Returns false if the current value is not an array. Otherwise, returns
       false if the array has less than n elements. Otherwise, returns p(a[n]).
 
+  .* p
+      Returns false if the current value is not an object. Otherwise, returns
+      true if p returns true for some value in the object.
+
+  ..foo p
+      Returns false if the current value is not an object. Otherwise, returns
+      true if p(o[mkey]) returns true for some object o at any depth of the
+      current value, including the current value itself, where mkey is a key
+      in o that matches 'foo'. Nested arrays are also searched. '..*' matches
+      every key at any depth.
+
 Below are some KeySequence examples. Note that 'p' represents the
 predicate parsed by the PredicateExpression part of the input, while
 'm' represents the entry's metadata. For brevity, assume that 'foo'
@@ -136,8 +147,22 @@ matches 'foo'.
       Returns true if some element in m['foo'] is an object o s.t.
       p(o['bar']) returns true.
 
-NOTE: You can use a backslash "\" to escape ".", "[", "]", or "\". For
-example, '.foo\.bar p' returns p(m['foo.bar']).
+  .*.bar p
+      Returns true if some value v in m is an object s.t. p(v['bar'])
+      returns true.
+
+  ..bar p
+      Returns true if p returns true for some 'bar' key's value, no matter
+      how deeply it is nested in m. For example, if m is
+      {"foo": [{"bar": 5}]}, then '..bar 5' returns true.
+
+NOTE: You can use a backslash "\" to escape ".", "[", "]", "*", or "\". For
+example, '.foo\.bar p' returns p(m['foo.bar']), while '.\* p' returns
+p(m['*']).
+
+NOTE: The meta primary uses the metadata schema (if available) to skip
+entries whose metadata cannot satisfy the key sequence. That optimization
+is disabled for key sequences containing '.*' or '..'.
 
 PREDICATE EXPRESSIONS:
 Predicate expression syntax is structurally identical to the top-level
@@ -256,7 +281,9 @@ returns true if m['foo'] is false.
 
 NUMERIC PREDICATE:
 [+|-]? N [ckMGTP] |
-[+|-]? '{' N '}'
+[+|-]? '{' N '}'  |
+[+|-]? N e X      |
+M..N
 
 where N >= 0. Here are the semantics for numeric predicates. Let v be the
 current meta value that's being compared. Then:
@@ -279,9 +306,16 @@ current meta value that's being compared. Then:
     Suffixing N with units is useful when v represents a size value (e.g. like
     the size of a VM's filesystem or the amount of memory that VM has).
 
+  * If N e X is specified (e.g. 1e9 or 2.5E6), then v is compared to
+    N * 10 ^ X. Scientific notation is useful for large counts like the
+    number of bytes transferred. N * 10 ^ X must be a whole number.
+
   * If {N} is specified, then the predicate compares v with -N. {N} is useful
     for comparing negative numbers.
 
+  * If M..N is specified, then the predicate returns M <= v <= N. M and N can
+    be any of the above forms, e.g. {5}..5 or 0..1M.
+
 Below are some examples of numeric predicates
 
   1
@@ -311,6 +345,12 @@ Below are some examples of numeric predicates
   -1k
       Returns true if v < (1 * 1024)
 
+  1k..1M
+      Returns true if (1 * 1024) <= v <= (1 * 1024 ^ 2)
+
+  +1e9
+      Returns true if v > 1000000000
+
 And here's an example of a numeric predicate being used in conjunction
 with an object predicate:
 
@@ -328,7 +368,9 @@ then please file a feature request so that we can add this support!
 
 TIME PREDICATE:
 [+|-]? N <smhdw>         |
-[+|-]? '{' N <smhdw> '}'
+[+|-]? '{' N <smhdw> '}' |
+M <smhdw> .. N <smhdw>   |
+'{' M <smhdw> '}' .. '{' N <smhdw> '}'
 
 where N >= 0. Note that units must be specified. Otherwise, there is no (simple)
 way for the parser to distinguish between a numeric predicate and a time
@@ -355,6 +397,9 @@ being compared. Then:
   * If {Nu} is specified, then "d" is the difference between v and the reference time.
     Brackets are useful to distinguish "future" queries from "past" queries.
 
+  * If Mu..Nu is specified, then the predicate returns Mu <= "d" <= Nu. Both bounds
+    must be bracketed for a future window, e.g. {1h}..{1d}.
+
 NOTE: If "d" < 0, then the predicate always returns false. "d" < 0 represents a time
 mismatch (i.e. you are making a past query on a future time value or a future query on a
 past time value). We impose this limitation to make it easier for people to reason about
@@ -382,6 +427,12 @@ Below are some examples of time predicates.
   -{1h}
       Returns true if v is less than one hour from now
 
+  1h..24h
+      Returns true if v was between one hour and one day ago
+
+  {1d}..{1w}
+      Returns true if v is between one day and one week from now
+
 And here are some examples of time predicates being used in conjunction with
 object predicates:
 
@@ -8,18 +8,23 @@ import (
 	"github.com/puppetlabs/wash/cmd/internal/find/primary/numeric"
 )
 
-// NumericPredicate => (+|-)? Number
-// Number           => N | '{' N '}' | numeric.SizeRegex
+// NumericPredicate => (+|-)? Number | Number '..' Number
+// Number           => N | '{' N '}' | numeric.SizeRegex | numeric.ScientificRegex
 func parseNumericPredicate(tokens []string) (predicate.Predicate, []string, error) {
 	if len(tokens) == 0 {
 		return nil, nil, errz.NewMatchError("expected a +, -, or a digit")
 	}
 	token := tokens[0]
+	// Combine the parsers so that a range's bounds can mix them,
+	// e.g. "{5}..5", "0..1M" or "0..1e9".
 	p, _, err := numeric.ParsePredicate(
 		token,
-		numeric.ParsePositiveInt,
-		numeric.Bracket(numeric.Negate(numeric.ParsePositiveInt)),
-		numeric.ParseSize,
+		numeric.Combine(
+			numeric.ParsePositiveInt,
+			numeric.Bracket(numeric.Negate(numeric.ParsePositiveInt)),
+			numeric.ParseSize,
+			numeric.ParseScientific,
+		),
 	)
 	if err != nil {
 		if errz.IsMatchError(err) {
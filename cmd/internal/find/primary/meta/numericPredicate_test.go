@@ -16,6 +16,8 @@ func (s *NumericPredicateTestSuite) TestErrors() {
 	s.RETC("", `expected a \+, -, or a digit`, true)
 	s.RETC("foo", "expected.*number.*foo", true)
 	s.RETC("--15", "expected.*positive", false)
+	s.RETC("2..1", "lower bound.*greater", false)
+	s.RETC("1.5e0", "whole number", false)
 }
 
 func (s *NumericPredicateTestSuite) TestValidInput() {
@@ -31,6 +33,14 @@ func (s *NumericPredicateTestSuite) TestValidInput() {
 	s.RTC("2G -size", "-size", float64(2*numeric.BytesOf('G')))
 	s.RTC("+2G -size", "-size", float64(3*numeric.BytesOf('G')))
 	s.RTC("-2G -size", "-size", float64(1*numeric.BytesOf('G')))
+	// Test a scientific value
+	s.RTC("1e9 -size", "-size", float64(1e9))
+	s.RTC("+2.5e6 -size", "-size", float64(2500001))
+	s.RTC("-1E3 -size", "-size", float64(999))
+	// Test a range
+	s.RTC("{5}..5 -size", "-size", float64(-5), float64(6))
+	s.RTC("0..1M -size", "-size", float64(numeric.BytesOf('M')), float64(-1))
+	s.RTC("1e3..1e6 -size", "-size", float64(5000), float64(999))
 }
 
 func (s *NumericPredicateTestSuite) TestValidInput_SchemaP() {
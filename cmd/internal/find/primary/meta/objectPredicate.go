@@ -28,10 +28,17 @@ func parseObjectP(tokens []string, baseCaseParser, keySequenceParser predicate.P
 		return nil, nil, errz.NewMatchError("expected a key sequence")
 	}
 	tk := tokens[0]
+	// "..key" searches for key at any depth
+	recursive := strings.HasPrefix(tk, "..")
+	if recursive {
+		tk = tk[1:]
+	}
 	key, rem, err := parseKey(tk)
 	if err != nil {
 		return nil, nil, err
 	}
+	// An unescaped "*" matches every key
+	wildcard := key == "*" && tk[1] == '*'
 	var p predicate.Predicate
 	if len(rem) <= 0 {
 		// tk is a single key, so it is of the form "key". This is the base case.
@@ -50,6 +57,9 @@ func parseObjectP(tokens []string, baseCaseParser, keySequenceParser predicate.P
 			err = fmt.Errorf("expected a predicate after %v", key)
 		}
 	}
+	if recursive || wildcard {
+		return objectSearchP(key, wildcard, recursive, p), tokens, err
+	}
 	return objectP(key, p), tokens, err
 }
 
@@ -62,13 +72,14 @@ func parseObjectP(tokens []string, baseCaseParser, keySequenceParser predicate.P
 //
 // NOTE: Users can still specify ".", "[", or "]" by escaping
 // them with a backslash "\". For example, '.com\.docker\.compose'
-// would be parsed as the key "com.docker.compose".
+// would be parsed as the key "com.docker.compose". Similarly, '.\*'
+// is parsed as the literal "*" key instead of the wildcard key.
 func parseKey(tk string) (string, string, error) {
 	isTerminatingChar := func(char byte) bool {
 		return char == '.' || char == '[' || char == ']'
 	}
 	isEscapableChar := func(char byte) bool {
-		return isTerminatingChar(char) || char == '\\'
+		return isTerminatingChar(char) || char == '\\' || char == '*'
 	}
 
 	if len(tk) <= 0 || tk[0] != '.' {
@@ -148,3 +159,67 @@ func (objP *objectPredicate) Negate() predicate.Predicate {
 	// Note that these semantics also hold for schemaP negation.
 	return objectP(objP.key, objP.p.Negate())
 }
+
+// objectSearchP returns a predicate that searches the current value for
+// the matching keys. If wildcard is true, then every key matches. If
+// recursive is true, then the search descends into all of the nested
+// objects and arrays. The predicate returns true if p returns true for
+// some matching key's value.
+func objectSearchP(key string, wildcard bool, recursive bool, p predicate.Predicate) Predicate {
+	if p == nil {
+		return nil
+	}
+	upcasedKey := strings.ToUpper(key)
+	var search func(v interface{}) bool
+	search = func(v interface{}) bool {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			for k, value := range t {
+				if (wildcard || strings.ToUpper(k) == upcasedKey) && p.IsSatisfiedBy(value) {
+					return true
+				}
+				if recursive && search(value) {
+					return true
+				}
+			}
+		case []interface{}:
+			if recursive {
+				for _, value := range t {
+					if search(value) {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+	searchP := &objectSearchPredicate{
+		predicateBase: newPredicateBase(func(v interface{}) bool {
+			if _, ok := v.(map[string]interface{}); !ok {
+				return false
+			}
+			return search(v)
+		}),
+		key:       key,
+		wildcard:  wildcard,
+		recursive: recursive,
+		p:         p,
+	}
+	// We can't express the searched key sequences as a single key
+	// sequence, so don't prune any schemas.
+	searchP.SchemaP = anySchemaP{}
+	return searchP
+}
+
+type objectSearchPredicate struct {
+	*predicateBase
+	key       string
+	wildcard  bool
+	recursive bool
+	p         predicate.Predicate
+}
+
+func (searchP *objectSearchPredicate) Negate() predicate.Predicate {
+	// Like objectPredicate, ! ..key p == ..key ! p
+	return objectSearchP(searchP.key, searchP.wildcard, searchP.recursive, searchP.p.Negate())
+}
@@ -45,6 +45,8 @@ func (s *ObjectPredicateTestSuite) TestParseKey() {
 		{".foo\\.bar\\[baz\\][", "foo.bar[baz]", "[", ""},
 		{".foo\\.bar\\[baz\\]]", "foo.bar[baz]", "]", ""},
 		{".k\\\\.ey", "k\\", ".ey", ""},
+		{".\\*", "*", "", ""},
+		{".*.key", "*", ".key", ""},
 	}
 
 	for _, testCase := range testCases {
@@ -77,6 +79,9 @@ func (s *ObjectPredicateTestSuite) TestParseObjectPredicateErrors() {
 	s.RETC(".key ( -true", `\(: missing closing '\)'`, false)
 	s.RETC(".key ( )", `\(\): empty inner expression`, false)
 	s.RETC(".key ( -true -false -foo", "unknown predicate -foo", false)
+	// Test recursive descent errors
+	s.RETC("..", "expected a key sequence after '.'", false)
+	s.RETC("..key", "expected a predicate after key", false)
 }
 
 func (s *ObjectPredicateTestSuite) TestParseObjectPredicateValidInput() {
@@ -107,6 +112,39 @@ func (s *ObjectPredicateTestSuite) TestParseObjectPredicateValidInput() {
 	s.RTC(".key ( ! ( -true -a -false ) ) -size", "-size", mp1)
 }
 
+func (s *ObjectPredicateTestSuite) TestParseObjectPredicateValidInput_Search() {
+	mp := map[string]interface{}{
+		"foo": map[string]interface{}{
+			"bar": []interface{}{
+				map[string]interface{}{"Key": true},
+			},
+		},
+		"baz": false,
+	}
+	// Test the wildcard key
+	s.RTC(".* -false -size", "-size", mp, map[string]interface{}{"foo": true})
+	s.RTC(".*.bar -exists -size", "-size", mp, map[string]interface{}{"baz": false})
+	// Test recursive descent
+	s.RTC("..key -true -size", "-size", mp, map[string]interface{}{"baz": false})
+	s.RTC(".foo..key -true", "", mp)
+	s.RNTC(".baz..key -true", "", mp)
+	s.RNTC("..key -false", "", mp)
+	s.RTC("..* -false", "", mp)
+	// Test a literal "*" key
+	s.RTC(".\\* -true", "", map[string]interface{}{"*": true}, map[string]interface{}{"foo": true})
+	// Test negation
+	s.RNTC("..key ! -true", "", mp)
+	s.RTC("..baz ! -true", "", mp)
+}
+
+func (s *ObjectPredicateTestSuite) TestParseObjectPredicateValidInput_Search_SchemaP() {
+	// Searches can't be expressed as a single key sequence, so their
+	// schemaPs are always satisfied
+	s.RSTC("..key -true -size", "-size", ".foo p")
+	s.RSTC(".* -true -size", "-size", "p")
+	s.RSTC(".key1..key2 -true -size", "-size", ".key1 p")
+}
+
 func (s *ObjectPredicateTestSuite) TestParseObjectPredicateValidInput_SchemaP() {
 	// Test -empty
 	s.RSTC("-empty -size", "-size", "o")
@@ -97,6 +97,22 @@ func (p1 *valueSchemaP) updateKS(updateFunc func(keySequence) keySequence) {
 	p1.ks = updateFunc(p1.ks)
 }
 
+// anySchemaP is a schema predicate that's satisfied by every schema. It's
+// used by predicates whose key sequences can't be determined when they're
+// parsed (e.g. "..key"). This preserves the invariant since anySchemaP
+// never returns false.
+type anySchemaP struct{}
+
+func (p1 anySchemaP) IsSatisfiedBy(v interface{}) bool {
+	return true
+}
+
+func (p1 anySchemaP) Negate() predicate.Predicate {
+	return p1
+}
+
+func (p1 anySchemaP) updateKS(func(keySequence) keySequence) {}
+
 // schemaPBinaryOp is a base class for schemaP binary operators
 type schemaPBinaryOp struct {
 	p1 schemaPredicate
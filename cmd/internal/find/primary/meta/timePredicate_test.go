@@ -18,6 +18,7 @@ func (s *TimePredicateTestSuite) TestErrors() {
 	s.RETC("", `expected a \+, -, or a digit`, true)
 	s.RETC("200", "expected a duration", true)
 	s.RETC("+{", ".*closing.*}", false)
+	s.RETC("1h..{2h}", "same units", false)
 }
 
 func (s *TimePredicateTestSuite) TestValidInputTrueValues() {
@@ -26,6 +27,9 @@ func (s *TimePredicateTestSuite) TestValidInputTrueValues() {
 	s.RTC("-2h -size", "-size", addTRT(-1*numeric.DurationOf('h')))
 	s.RTC("+{2h} -size", "-size", addTRT(3*numeric.DurationOf('h')))
 	s.RTC("-{2h} -size", "-size", addTRT(1*numeric.DurationOf('h')))
+	// Test time windows
+	s.RTC("1h..24h -size", "-size", addTRT(-2*numeric.DurationOf('h')))
+	s.RTC("{1d}..{1w} -size", "-size", addTRT(2*numeric.DurationOf('d')))
 	// Test a stringified time to ensure that munge.ToTime's called
 	s.RTC("+2h -size", "-size", addTRT(-3*numeric.DurationOf('h')).String())
 }
@@ -33,6 +37,8 @@ func (s *TimePredicateTestSuite) TestValidInputTrueValues() {
 func (s *TimePredicateTestSuite) TestValidInputFalseValues() {
 	s.RNTC("+2h", "", "not_a_valid_time_value")
 	s.RNTC("+2h", "", addTRT(-1*numeric.DurationOf('h')))
+	s.RNTC("1h..24h", "", addTRT(-2*numeric.DurationOf('d')))
+	s.RNTC("1h..24h", "", addTRT(2*numeric.DurationOf('h')))
 	s.RNTC("-2h", "", addTRT(-3*numeric.DurationOf('h')))
 	s.RNTC("+{2h}", "", addTRT(1*numeric.DurationOf('h')))
 	s.RNTC("-{2h}", "", addTRT(3*numeric.DurationOf('h')))
@@ -2,6 +2,7 @@ package primary
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/gobwas/glob"
 	"github.com/puppetlabs/wash/cmd/internal/find/types"
@@ -28,3 +29,25 @@ var Name = Parser.add(&Primary{
 		}), tokens[1:], nil
 	},
 })
+
+// Iname is the iname primary
+//
+// inamePrimary => -iname ShellPattern
+//nolint
+var Iname = Parser.add(&Primary{
+	Description: "Like -name, but the match is case-insensitive",
+	name:        "iname",
+	args:        "pattern",
+	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
+		if len(tokens) == 0 {
+			return nil, nil, fmt.Errorf("requires additional arguments")
+		}
+		g, err := glob.Compile(strings.ToLower(tokens[0]))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid pattern: %v", err)
+		}
+		return types.ToEntryP(func(e types.Entry) bool {
+			return g.Match(strings.ToLower(e.CName))
+		}), tokens[1:], nil
+	},
+})
@@ -30,3 +30,28 @@ func TestNamePrimary(t *testing.T) {
 	}
 	suite.Run(t, s)
 }
+
+type InamePrimaryTestSuite struct {
+	primaryTestSuite
+}
+
+func (s *InamePrimaryTestSuite) TestErrors() {
+	s.RETC("", "requires additional arguments")
+	s.RETC("[a", "invalid pattern: unexpected end of input")
+}
+
+func (s *InamePrimaryTestSuite) TestValidInput() {
+	s.RTC("a", "", "A", "b")
+	s.RTC("*Foo*", "", "afoob", "bar")
+}
+
+func TestInamePrimary(t *testing.T) {
+	s := new(InamePrimaryTestSuite)
+	s.Parser = Iname
+	s.ConstructEntry = func(v interface{}) types.Entry {
+		e := types.Entry{}
+		e.CName = v.(string)
+		return e
+	}
+	suite.Run(t, s)
+}
@@ -0,0 +1,70 @@
+package primary
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+)
+
+// Newer is the newer primary
+//
+// newerPrimary => -newer Path
+//nolint
+var Newer = Parser.add(&Primary{
+	Description:         "Returns true if the entry's mtime is more recent than path's mtime",
+	DetailedDescription: newerDetailedDescription,
+	name:                "newer",
+	args:                "path",
+	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
+		if len(tokens) == 0 {
+			return nil, nil, fmt.Errorf("requires additional arguments")
+		}
+		mtime, err := getMtime(tokens[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		return types.ToEntryP(func(e types.Entry) bool {
+			if !e.Attributes.HasMtime() {
+				return false
+			}
+			return e.Attributes.Mtime().After(mtime)
+		}), tokens[1:], nil
+	},
+})
+
+// getMtime returns the mtime of the entry or the local file at path. Make
+// this a variable so that the tests can mock it.
+var getMtime = func(path string) (time.Time, error) {
+	// Try Wash first so that we use the entry's mtime attribute
+	if e, err := cmdutil.NewClient().Info(path); err == nil {
+		if !e.Attributes.HasMtime() {
+			return time.Time{}, fmt.Errorf("%v does not have an mtime attribute", path)
+		}
+		return e.Attributes.Mtime(), nil
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not stat %v: %v", path, err)
+	}
+	return fi.ModTime(), nil
+}
+
+const newerDetailedDescription = `
+-newer path
+
+Returns true if the entry's mtime attribute is more recent than the mtime
+of path. Path can be a Wash entry or a local file. Like the time primaries,
+-newer returns false if the entry does not have an mtime attribute. It is
+an error for a Wash entry at path to not have an mtime attribute.
+
+-newer is useful for incremental workflows. For example,
+
+  find gcp -k '*storage*object' -newer ~/.last-sync
+  touch ~/.last-sync
+
+prints all the GCP storage objects that were modified since the last
+sync.
+`
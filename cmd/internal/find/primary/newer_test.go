@@ -0,0 +1,56 @@
+package primary
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type NewerPrimaryTestSuite struct {
+	primaryTestSuite
+	oldGetMtime func(string) (time.Time, error)
+	mtime       time.Time
+}
+
+func (s *NewerPrimaryTestSuite) SetupTest() {
+	s.primaryTestSuite.SetupTest()
+	s.mtime = time.Now()
+	s.oldGetMtime = getMtime
+	getMtime = func(path string) (time.Time, error) {
+		if path != "foo" {
+			return time.Time{}, fmt.Errorf("could not stat %v", path)
+		}
+		return s.mtime, nil
+	}
+}
+
+func (s *NewerPrimaryTestSuite) TearDownTest() {
+	s.primaryTestSuite.TeardownTest()
+	getMtime = s.oldGetMtime
+}
+
+func (s *NewerPrimaryTestSuite) TestErrors() {
+	s.RETC("", "requires additional arguments")
+	s.RETC("bar", "could not stat bar")
+}
+
+func (s *NewerPrimaryTestSuite) TestValidInput() {
+	s.RTC("foo", "", s.mtime.Add(1*time.Second), s.mtime)
+	s.RNTC("foo", "", time.Time{})
+}
+
+func TestNewerPrimary(t *testing.T) {
+	s := new(NewerPrimaryTestSuite)
+	s.Parser = Newer
+	s.ConstructEntry = func(v interface{}) types.Entry {
+		e := types.Entry{}
+		if t := v.(time.Time); !t.IsZero() {
+			e.Attributes.SetMtime(t)
+		}
+		return e
+	}
+	suite.Run(t, s)
+}
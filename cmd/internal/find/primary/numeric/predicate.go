@@ -2,6 +2,7 @@ package numeric
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/puppetlabs/wash/cmd/internal/find/parser/errz"
 	"github.com/puppetlabs/wash/cmd/internal/find/parser/predicate"
@@ -30,10 +31,12 @@ func (p1 Predicate) IsSatisfiedBy(v interface{}) bool {
 type Parser func(string) (int64, error)
 
 // ParsePredicate parses a numeric predicate from str. Str should
-// satisfy the regex `(\+|\-)?<number>`, where <number> is s.t.
-// that parser(<number>) does not return an error for at least one
-// parser in parsers. The returned value is the parsed predicate
-// and the id of the parser that parsed <number>.
+// satisfy the regex `(\+|\-)?<number>` or `<number>..<number>`, where
+// <number> is s.t. that parser(<number>) does not return an error for
+// at least one parser in parsers. The latter form is a closed range,
+// and both of its bounds must be parsed by the same parser. The returned
+// value is the parsed predicate and the id of the parser that parsed
+// <number>.
 func ParsePredicate(str string, parsers ...Parser) (Predicate, int, error) {
 	if len(str) == 0 {
 		return nil, -1, errz.NewMatchError("empty input")
@@ -50,26 +53,33 @@ func ParsePredicate(str string, parsers ...Parser) (Predicate, int, error) {
 		cmp = '='
 	}
 
-	var parserID int
-	var n int64
-	var err error
-	for i, parser := range parsers {
-		n, err = parser(str)
-		if err == nil {
-			parserID = i
-			break
+	if bounds := strings.SplitN(str, "..", 2); len(bounds) == 2 {
+		if cmp != '=' {
+			return nil, -1, fmt.Errorf("a range cannot be prefixed with a %c", cmp)
 		}
-		if !errz.IsMatchError(err) {
-			// Parser matched the input, but returned a parse error. Return
-			// the error.
+		lo, loParserID, err := parseNumber(bounds[0], parsers)
+		if err != nil {
+			return nil, -1, err
+		}
+		hi, hiParserID, err := parseNumber(bounds[1], parsers)
+		if err != nil {
 			return nil, -1, err
 		}
+		if loParserID != hiParserID {
+			return nil, -1, fmt.Errorf("the bounds of %v must have the same units", str)
+		}
+		if lo > hi {
+			return nil, -1, fmt.Errorf("the lower bound of %v is greater than its upper bound", str)
+		}
+		return func(v int64) bool {
+			return lo <= v && v <= hi
+		}, loParserID, nil
 	}
+
+	n, parserID, err := parseNumber(str, parsers)
 	if err != nil {
-		msg := fmt.Sprintf("%v is not a number", str)
-		return nil, -1, errz.NewMatchError(msg)
+		return nil, -1, err
 	}
-
 	return func(v int64) bool {
 		switch cmp {
 		case '+':
@@ -81,3 +91,33 @@ func ParsePredicate(str string, parsers ...Parser) (Predicate, int, error) {
 		}
 	}, parserID, nil
 }
+
+// parseNumber parses str with the first parser in parsers that
+// matches it, returning the parsed number and the parser's id.
+func parseNumber(str string, parsers []Parser) (int64, int, error) {
+	for i, parser := range parsers {
+		n, err := parser(str)
+		if err == nil {
+			return n, i, nil
+		}
+		if !errz.IsMatchError(err) {
+			// Parser matched the input, but returned a parse error. Return
+			// the error.
+			return 0, -1, err
+		}
+	}
+	msg := fmt.Sprintf("%v is not a number", str)
+	return 0, -1, errz.NewMatchError(msg)
+}
+
+// Combine returns a new parser g that parses str with the first parser
+// in parsers that matches it. Use Combine when the caller doesn't care
+// which parser parsed the number. For example, ParsePredicate requires
+// both bounds of a range to be parsed by the same parser, so combining
+// ParsePositiveInt and ParseSize lets ranges like "0..1M" through.
+func Combine(parsers ...Parser) Parser {
+	return func(str string) (int64, error) {
+		n, _, err := parseNumber(str, parsers)
+		return n, err
+	}
+}
@@ -23,6 +23,22 @@ func (suite *PredicateTestSuite) TestParsePredicateErrors() {
 	_, _, err = ParsePredicate("foo", ParsePositiveInt)
 	suite.True(errz.IsMatchError(err))
 	suite.Regexp("foo.*number", err)
+
+	_, _, err = ParsePredicate("1..foo", ParsePositiveInt)
+	suite.True(errz.IsMatchError(err))
+	suite.Regexp("foo.*number", err)
+
+	_, _, err = ParsePredicate("+1..2", ParsePositiveInt)
+	suite.False(errz.IsMatchError(err))
+	suite.Regexp("range.*prefixed.*\\+", err)
+
+	_, _, err = ParsePredicate("1..2h", ParsePositiveInt, ParseDuration)
+	suite.False(errz.IsMatchError(err))
+	suite.Regexp("1..2h.*same units", err)
+
+	_, _, err = ParsePredicate("2..1", ParsePositiveInt)
+	suite.False(errz.IsMatchError(err))
+	suite.Regexp("2..1.*lower bound.*greater", err)
 }
 
 func (suite *PredicateTestSuite) TestParsePredicateValidInput() {
@@ -40,6 +56,10 @@ func (suite *PredicateTestSuite) TestParsePredicateValidInput() {
 		// This tests that ParsePredicate loops over all its given
 		// parsers
 		testCase{"1h", 1, 1 * DurationOf('h'), 2},
+		// These test closed ranges
+		testCase{"1..3", 0, 1, 0},
+		testCase{"1..3", 0, 3, 4},
+		testCase{"1h..2h", 1, 2 * DurationOf('h'), 1},
 	}
 	for _, c := range testCases {
 		inputStr := func() string {
@@ -54,6 +74,26 @@ func (suite *PredicateTestSuite) TestParsePredicateValidInput() {
 	}
 }
 
+func (suite *PredicateTestSuite) TestCombine() {
+	p := Combine(ParsePositiveInt, ParseSize)
+	n, err := p("1")
+	if suite.NoError(err) {
+		suite.Equal(int64(1), n)
+	}
+	n, err = p("1k")
+	if suite.NoError(err) {
+		suite.Equal(int64(1024), n)
+	}
+	_, err = p("foo")
+	suite.True(errz.IsMatchError(err))
+
+	pred, _, err := ParsePredicate("0..1k", p)
+	if suite.NoError(err) {
+		suite.True(pred(1024))
+		suite.False(pred(1025))
+	}
+}
+
 func TestPredicate(t *testing.T) {
 	suite.Run(t, new(PredicateTestSuite))
 }
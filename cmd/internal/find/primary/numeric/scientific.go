@@ -0,0 +1,32 @@
+package numeric
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/parser/errz"
+)
+
+// ScientificRegex describes a valid number in scientific notation, e.g.
+// 1e9 or 2.5E6.
+var ScientificRegex = regexp.MustCompile(`^\d+(\.\d+)?[eE]\+?\d+$`)
+
+// ParseScientific parses a positive integer written in scientific notation.
+// Scientific values are described by ScientificRegex. The parsed value must
+// be a whole number, so "1.5e0" is rejected.
+func ParseScientific(str string) (int64, error) {
+	if !ScientificRegex.MatchString(str) {
+		msg := fmt.Sprintf("scientific values must conform to the regex `%v`", ScientificRegex)
+		return 0, errz.NewMatchError(msg)
+	}
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("%v is too large", str)
+	}
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("%v is not a whole number", str)
+	}
+	return int64(f), nil
+}
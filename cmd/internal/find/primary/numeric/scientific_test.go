@@ -0,0 +1,45 @@
+package numeric
+
+import (
+	"testing"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/parser/errz"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScientificTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ScientificTestSuite) TestScientificRegex() {
+	suite.Regexp(ScientificRegex, "1e9")
+	suite.Regexp(ScientificRegex, "1E9")
+	suite.Regexp(ScientificRegex, "1e+9")
+	suite.Regexp(ScientificRegex, "2.5e6")
+
+	suite.NotRegexp(ScientificRegex, "1")
+	suite.NotRegexp(ScientificRegex, "1e")
+	suite.NotRegexp(ScientificRegex, "1e-9")
+	suite.NotRegexp(ScientificRegex, ".5e6")
+	suite.NotRegexp(ScientificRegex, "  1e9")
+}
+
+func (suite *ScientificTestSuite) TestParseScientific() {
+	_, err := ParseScientific("12")
+	suite.True(errz.IsMatchError(err))
+
+	_, err = ParseScientific("1.5e0")
+	suite.Regexp("whole number", err)
+
+	_, err = ParseScientific("1e30")
+	suite.Regexp("too large", err)
+
+	n, err := ParseScientific("2.5e6")
+	if suite.NoError(err) {
+		suite.Equal(int64(2500000), n)
+	}
+}
+
+func TestScientific(t *testing.T) {
+	suite.Run(t, new(ScientificTestSuite))
+}
@@ -0,0 +1,193 @@
+package primary
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+)
+
+// JSON is the json primary
+//
+// jsonPrimary => -json
+//nolint
+var JSON = Parser.add(&Primary{
+	Description:         "Prints the entry as a line of JSON. Always returns true",
+	DetailedDescription: jsonDetailedDescription,
+	name:                "json",
+	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
+		return types.ToEntryP(func(e types.Entry) bool {
+			bytes, err := json.Marshal(e.Entry)
+			if err != nil {
+				cmdutil.ErrPrintf("could not marshal %v: %v\n", e.NormalizedPath, err)
+				return false
+			}
+			cmdutil.Printf("%s\n", bytes)
+			return true
+		}), tokens, nil
+	},
+})
+
+// Printf is the printf primary
+//
+// printfPrimary => -printf Format
+//nolint
+var Printf = Parser.add(&Primary{
+	Description:         "Prints the entry using format. Always returns true",
+	DetailedDescription: printfDetailedDescription,
+	name:                "printf",
+	args:                "format",
+	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
+		if len(tokens) == 0 {
+			return nil, nil, fmt.Errorf("requires additional arguments")
+		}
+		format, err := parseFormat(tokens[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		return types.ToEntryP(func(e types.Entry) bool {
+			var sb strings.Builder
+			for _, directive := range format {
+				sb.WriteString(directive(e))
+			}
+			cmdutil.Printf("%s", sb.String())
+			return true
+		}), tokens[1:], nil
+	},
+})
+
+// formatDirective returns the part of the output that it represents
+type formatDirective func(types.Entry) string
+
+func literal(str string) formatDirective {
+	return func(types.Entry) string {
+		return str
+	}
+}
+
+func timeAttr(name string) formatDirective {
+	return func(e types.Entry) string {
+		t, ok := getTimeAttrValue(name, e)
+		if !ok {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+}
+
+var formatDirectives = map[byte]formatDirective{
+	'p': func(e types.Entry) string {
+		return e.NormalizedPath
+	},
+	'P': func(e types.Entry) string {
+		return e.Path
+	},
+	'n': func(e types.Entry) string {
+		return e.CName
+	},
+	't': func(e types.Entry) string {
+		return e.TypeID
+	},
+	's': func(e types.Entry) string {
+		if !e.Attributes.HasSize() {
+			return ""
+		}
+		return strconv.FormatUint(e.Attributes.Size(), 10)
+	},
+	'a': timeAttr("atime"),
+	'c': timeAttr("ctime"),
+	'm': timeAttr("mtime"),
+	'r': timeAttr("crtime"),
+	'%': literal("%"),
+}
+
+var escapes = map[byte]string{
+	'n':  "\n",
+	't':  "\t",
+	'\\': "\\",
+}
+
+// parseFormat parses the printf primary's format string
+func parseFormat(str string) ([]formatDirective, error) {
+	var format []formatDirective
+	var lit strings.Builder
+	flushLiteral := func() {
+		if lit.Len() > 0 {
+			format = append(format, literal(lit.String()))
+			lit.Reset()
+		}
+	}
+	for i := 0; i < len(str); i++ {
+		switch c := str[i]; c {
+		case '%', '\\':
+			if i+1 >= len(str) {
+				return nil, fmt.Errorf("format ends with an incomplete %c sequence", c)
+			}
+			i++
+			if c == '\\' {
+				escape, ok := escapes[str[i]]
+				if !ok {
+					return nil, fmt.Errorf("unknown escape sequence \\%c", str[i])
+				}
+				lit.WriteString(escape)
+				continue
+			}
+			directive, ok := formatDirectives[str[i]]
+			if !ok {
+				return nil, fmt.Errorf("unknown directive %%%c", str[i])
+			}
+			flushLiteral()
+			format = append(format, directive)
+		default:
+			lit.WriteByte(c)
+		}
+	}
+	flushLiteral()
+	return format, nil
+}
+
+const jsonDetailedDescription = `
+-json
+
+Prints the entry as a line of JSON, and always returns true. The JSON
+object contains the entry's path, cname, type ID, supported actions,
+attributes and metadata. If the -fullmeta option is set, then the
+metadata is the entry's full metadata. Otherwise, it is the entry's
+partial metadata. Since each entry is printed as a single line, the
+output is newline-delimited JSON that can be piped into tools like jq.
+For example,
+
+  find docker -k '*container' -json | jq -r '.metadata.Image'
+
+prints the images of all the Docker containers.
+`
+
+const printfDetailedDescription = `
+-printf format
+
+Prints the entry using format, and always returns true. Unlike -json,
+-printf does not print a trailing newline. format can contain the
+following directives:
+
+%p       The entry's normalized path (i.e. the path that find prints)
+%P       The entry's absolute path
+%n       The entry's cname
+%t       The entry's type ID
+%s       The entry's size attribute
+%a       The entry's atime attribute, in RFC3339 format
+%c       The entry's ctime attribute, in RFC3339 format
+%m       The entry's mtime attribute, in RFC3339 format
+%r       The entry's crtime attribute, in RFC3339 format
+%%       A literal %
+
+Directives for missing attributes are replaced with an empty string.
+format can also contain the \n, \t and \\ escape sequences. For example,
+
+  find gcp -k '*storage*object' -printf '%s\t%p\n' | sort -n
+
+prints the GCP storage objects sorted by their size.
+`
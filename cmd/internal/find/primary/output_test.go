@@ -0,0 +1,81 @@
+package primary
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/stretchr/testify/suite"
+)
+
+// outputTestSuite captures the output of the json and printf primaries
+type outputTestSuite struct {
+	primaryTestSuite
+	stdout    *bytes.Buffer
+	oldStdout io.Writer
+}
+
+func (s *outputTestSuite) SetupTest() {
+	s.primaryTestSuite.SetupTest()
+	s.stdout = &bytes.Buffer{}
+	s.oldStdout = cmdutil.Stdout
+	cmdutil.Stdout = s.stdout
+}
+
+func (s *outputTestSuite) TearDownTest() {
+	s.primaryTestSuite.TeardownTest()
+	cmdutil.Stdout = s.oldStdout
+}
+
+func newOutputTestEntry(v interface{}) types.Entry {
+	e := types.Entry{}
+	e.Path = "/wash/foo/bar"
+	e.NormalizedPath = "foo/bar"
+	e.CName = "bar"
+	e.TypeID = "plugin::bar"
+	e.Attributes.SetSize(uint64(v.(int)))
+	e.Attributes.SetMtime(time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC))
+	return e
+}
+
+type JSONPrimaryTestSuite struct {
+	outputTestSuite
+}
+
+func (s *JSONPrimaryTestSuite) TestValidInput() {
+	s.RTC("-foo", "-foo", 10)
+	s.Regexp(`^\{.*"path":"/wash/foo/bar".*"cname":"bar".*"size":10.*\}\n$`, s.stdout.String())
+}
+
+func TestJSONPrimary(t *testing.T) {
+	s := new(JSONPrimaryTestSuite)
+	s.Parser = JSON
+	s.ConstructEntry = newOutputTestEntry
+	suite.Run(t, s)
+}
+
+type PrintfPrimaryTestSuite struct {
+	outputTestSuite
+}
+
+func (s *PrintfPrimaryTestSuite) TestErrors() {
+	s.RETC("", "requires additional arguments")
+	s.RETC("%", "incomplete % sequence")
+	s.RETC("%z", "unknown directive %z")
+	s.RETC("\\z", "unknown escape sequence")
+}
+
+func (s *PrintfPrimaryTestSuite) TestValidInput() {
+	s.RTC("%p|%P|%n|%t|%s|%m|%c|100%%\\t\\\\\\n -foo", "-foo", 10)
+	s.Equal("foo/bar|/wash/foo/bar|bar|plugin::bar|10|2020-01-02T03:04:05Z||100%\t\\\n", s.stdout.String())
+}
+
+func TestPrintfPrimary(t *testing.T) {
+	s := new(PrintfPrimaryTestSuite)
+	s.Parser = Printf
+	s.ConstructEntry = newOutputTestEntry
+	suite.Run(t, s)
+}
@@ -30,3 +30,28 @@ func TestPathPrimary(t *testing.T) {
 	}
 	suite.Run(t, s)
 }
+
+type IpathPrimaryTestSuite struct {
+	primaryTestSuite
+}
+
+func (s *IpathPrimaryTestSuite) TestErrors() {
+	s.RETC("", "requires additional arguments")
+	s.RETC("[a", "invalid pattern: unexpected end of input")
+}
+
+func (s *IpathPrimaryTestSuite) TestValidInput() {
+	s.RTC("a", "", "A", "b")
+	s.RTC("*Foo*", "", "afoob", "bar")
+}
+
+func TestIpathPrimary(t *testing.T) {
+	s := new(IpathPrimaryTestSuite)
+	s.Parser = Ipath
+	s.ConstructEntry = func(v interface{}) types.Entry {
+		e := types.Entry{}
+		e.NormalizedPath = v.(string)
+		return e
+	}
+	suite.Run(t, s)
+}
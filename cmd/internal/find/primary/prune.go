@@ -0,0 +1,56 @@
+package primary
+
+import (
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+)
+
+// Prune is the prune primary
+//
+// prunePrimary => -prune
+//nolint
+var Prune = Parser.add(&Primary{
+	Description:         "Does not descend into the entry. Always returns true",
+	DetailedDescription: pruneDetailedDescription,
+	name:                "prune",
+	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
+		return types.ToEntryP(func(e types.Entry) bool {
+			pruned = true
+			return true
+		}), tokens, nil
+	},
+})
+
+// pruned is set when the prune primary is evaluated on the entry that's
+// currently being visited.
+var pruned bool
+
+// ResetPruned resets the pruned state. Call this before evaluating the
+// expression on an entry.
+func ResetPruned() {
+	pruned = false
+}
+
+// Pruned returns true if the prune primary was evaluated on the entry
+// that was last visited. In that case, `wash find` does not descend into
+// the entry.
+func Pruned() bool {
+	return pruned
+}
+
+const pruneDetailedDescription = `
+-prune
+
+Always returns true. If the entry is a parent, then find does not descend
+into it. -prune has no effect if the -depth option is set since the entry's
+children are visited before the entry itself.
+
+-prune is typically used with -o to skip large subtrees. For example,
+
+  find aws/profile/resources/s3/bucket -path '*/logs' -prune -o -size +1G
+
+prints all the objects in the S3 bucket that are larger than a gibibyte
+without traversing the bucket's logs/ prefix. Note that the pruned entries
+themselves are printed since -prune returns true. To avoid that, use
+
+  find aws/profile/resources/s3/bucket \( -path '*/logs' -prune -false \) -o -size +1G
+`
@@ -0,0 +1,37 @@
+package primary
+
+import (
+	"testing"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type PrunePrimaryTestSuite struct {
+	primaryTestSuite
+}
+
+func (s *PrunePrimaryTestSuite) TearDownTest() {
+	s.primaryTestSuite.TeardownTest()
+	ResetPruned()
+}
+
+func (s *PrunePrimaryTestSuite) TestValidInput() {
+	s.False(Pruned())
+	s.RTC("", "", "foo")
+	s.True(Pruned())
+	ResetPruned()
+	s.RTC("-foo", "-foo", "foo")
+	s.True(Pruned())
+}
+
+func TestPrunePrimary(t *testing.T) {
+	s := new(PrunePrimaryTestSuite)
+	s.Parser = Prune
+	s.ConstructEntry = func(v interface{}) types.Entry {
+		e := types.Entry{}
+		e.NormalizedPath = v.(string)
+		return e
+	}
+	suite.Run(t, s)
+}
@@ -0,0 +1,48 @@
+package primary
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+)
+
+// Regex is the regex primary
+//
+// regexPrimary => -regex Regex
+//nolint
+var Regex = Parser.add(&Primary{
+	Description:         "Returns true if the entry's normalized path matches regex",
+	DetailedDescription: regexDetailedDescription,
+	name:                "regex",
+	args:                "regex",
+	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
+		if len(tokens) == 0 {
+			return nil, nil, fmt.Errorf("requires additional arguments")
+		}
+		// Like -path, the regex is matched against the whole path.
+		re, err := regexp.Compile("^(?:" + tokens[0] + ")$")
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid regex: %v", err)
+		}
+		return types.ToEntryP(func(e types.Entry) bool {
+			return re.MatchString(e.NormalizedPath)
+		}), tokens[1:], nil
+	},
+})
+
+const regexDetailedDescription = `
+-regex regex
+
+Returns true if the entry's normalized path matches regex. The regex
+uses Go's regular expression syntax (https://golang.org/pkg/regexp/syntax).
+Like -path, the regex must match the entire path, not just a part of
+it. Use the (?i) flag for a case-insensitive match.
+
+Examples:
+  -regex '.*/i-[0-9a-f]{17}'    Returns true if the entry's cname is an
+                                EC2 instance ID
+
+  -regex '(?i).*\.(yml|yaml)'   Returns true if the entry's cname ends
+                                in .yml or .yaml, ignoring case
+`
@@ -0,0 +1,35 @@
+package primary
+
+import (
+	"testing"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type RegexPrimaryTestSuite struct {
+	primaryTestSuite
+}
+
+func (s *RegexPrimaryTestSuite) TestErrors() {
+	s.RETC("", "requires additional arguments")
+	s.RETC("(a", "invalid regex: .*missing closing \\)")
+}
+
+func (s *RegexPrimaryTestSuite) TestValidInput() {
+	s.RTC("a", "", "a", "ab")
+	s.RTC(".*/b+", "", "a/bbb", "a/bc")
+	s.RTC("a|b", "", "b", "ab")
+	s.RTC("(?i)a", "", "A", "b")
+}
+
+func TestRegexPrimary(t *testing.T) {
+	s := new(RegexPrimaryTestSuite)
+	s.Parser = Regex
+	s.ConstructEntry = func(v interface{}) types.Entry {
+		e := types.Entry{}
+		e.NormalizedPath = v.(string)
+		return e
+	}
+	suite.Run(t, s)
+}
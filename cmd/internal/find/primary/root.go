@@ -0,0 +1,58 @@
+package primary
+
+import (
+	"fmt"
+
+	"github.com/gobwas/glob"
+	"github.com/puppetlabs/wash/cmd/internal/find/params"
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+)
+
+// Root is the root primary
+//
+// rootPrimary => -root ShellPattern
+//nolint
+var Root = Parser.add(&Primary{
+	Description:         "Returns true if the entry's start path matches pattern",
+	DetailedDescription: rootDetailedDescription,
+	name:                "root",
+	args:                "pattern",
+	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
+		if len(tokens) == 0 {
+			return nil, nil, fmt.Errorf("requires additional arguments")
+		}
+		g, err := glob.Compile(tokens[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid pattern: %v", err)
+		}
+		p := types.ToEntryP(func(e types.Entry) bool {
+			return g.Match(e.StartPath)
+		})
+		// The start path is the same for every entry in the walk, so this
+		// prunes the entire walk if the start path doesn't match.
+		p.SetSchemaP(types.ToEntrySchemaP(func(s *types.EntrySchema) bool {
+			return g.Match(params.StartPath)
+		}))
+		return p, tokens[1:], nil
+	},
+})
+
+const rootDetailedDescription = `
+-root pattern
+
+Returns true if the start path of the entry's walk matches pattern, where
+the start path is one of the paths passed into find (as typed). -root makes
+it possible to scope different parts of the expression to different start
+paths in a single invocation. Combine it with -level to give each start path
+its own depth limit. For example,
+
+  find aws kubernetes -label \( -root aws -k '*ec2*instance' \) -o \( -root kubernetes -level -4 -k '*pod' \)
+
+prints the EC2 instances in aws and the pods that are less than 4 levels
+deep in kubernetes. The -label option prefixes each match with its start
+path.
+
+Since the start path is known before the walk begins, find skips the start
+paths that cannot satisfy the expression's -root primaries entirely. Use
+-prune to stop descending past a level, e.g. "-root kubernetes -level 3 -prune".
+`
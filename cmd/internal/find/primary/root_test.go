@@ -0,0 +1,46 @@
+package primary
+
+import (
+	"testing"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/params"
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type RootPrimaryTestSuite struct {
+	primaryTestSuite
+}
+
+func (s *RootPrimaryTestSuite) TearDownTest() {
+	s.primaryTestSuite.TeardownTest()
+	params.StartPath = ""
+}
+
+func (s *RootPrimaryTestSuite) TestErrors() {
+	s.RETC("", "requires additional arguments")
+	s.RETC("[a", "invalid pattern: unexpected end of input")
+}
+
+func (s *RootPrimaryTestSuite) TestValidInput() {
+	params.StartPath = "aws"
+	e := types.Entry{StartPath: "aws"}
+	s.RTC("aws -foo", "-foo", e)
+	s.RTC("a*", "", e)
+	s.RNTC("kubernetes", "", e)
+	s.RSTC("aws", "", &types.EntrySchema{})
+	s.RNSTC("kubernetes", "", &types.EntrySchema{})
+}
+
+func TestRootPrimary(t *testing.T) {
+	s := new(RootPrimaryTestSuite)
+	s.Parser = Root
+	s.SchemaPParser = types.EntryPredicateParser(Root.parseFunc).ToSchemaPParser()
+	s.ConstructEntry = func(v interface{}) types.Entry {
+		return v.(types.Entry)
+	}
+	s.ConstructEntrySchema = func(v interface{}) *types.EntrySchema {
+		return v.(*types.EntrySchema)
+	}
+	suite.Run(t, s)
+}
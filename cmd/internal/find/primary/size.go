@@ -11,13 +11,14 @@ import (
 // Size is the size primary
 //
 // sizePrimary => -size (+|-)?(\d+ | numeric.SizeRegex)
+//               | -size (\d+ | numeric.SizeRegex)..(\d+ | numeric.SizeRegex)
 //
 //nolint
 var Size = Parser.add(&Primary{
 	Description:         "Returns true if the entry's size attribute satisfies the given size predicate",
 	DetailedDescription: sizeDetailedDescription,
 	name:                "size",
-	args:                "[+|-]n[ckMGTP]",
+	args:                "[+|-]n[ckMGTP] | m..n",
 	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
 		if len(tokens) == 0 {
 			return nil, nil, fmt.Errorf("requires additional arguments")
@@ -48,6 +49,7 @@ var Size = Parser.add(&Primary{
 
 const sizeDetailedDescription = `
 -size [+|-]n[ckMGTP]
+-size m[ckMGTP]..n[ckMGTP]
 
 Returns true if the entry's size attribute is n 512-byte blocks,
 rounded up to the nearest block. If n is suffixed with a unit,
@@ -63,6 +65,10 @@ P        pebibytes (1024 tebibytes)
 If n is prefixed with a +/-, then the comparison returns true if
 the size is greater-than/less-than n.
 
+The second form returns true if the size is between m and n,
+inclusive. Both m and n must either be unitless (i.e. in 512-byte
+blocks), or have a unit.
+
 Examples:
   -size 2        Returns true if the entry's size is 2 512-byte blocks,
                  rounded up to the nearest block
@@ -75,4 +81,8 @@ Examples:
 
   -size +1k      Returns true if the entry's size is greater than 1
                  kibibyte
+
+  -size 100k..10M
+                 Returns true if the entry's size is between 100
+                 kibibytes and 10 mebibytes, inclusive
 `
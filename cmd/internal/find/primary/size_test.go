@@ -25,6 +25,8 @@ func (s *SizePrimaryTestSuite) TestErrors() {
 	RIVTC("+++++1")
 	RIVTC("+1kb")
 	RIVTC("+1kb")
+	RIVTC("1..2k")
+	RIVTC("2k..1k")
 }
 
 func (s *SizePrimaryTestSuite) TestValidInput() {
@@ -37,6 +39,8 @@ func (s *SizePrimaryTestSuite) TestValidInput() {
 	s.RTC("1k", "", 1 * numeric.BytesOf('k'), 1 * numeric.BytesOf('c'))
 	s.RTC("+1k", "", 2 * numeric.BytesOf('k'), 1 * numeric.BytesOf('k'))
 	s.RTC("-1k", "", 1 * numeric.BytesOf('c'), 1 * numeric.BytesOf('k'))
+	s.RTC("1..2", "", int64(1.5 * 512), int64(3 * 512))
+	s.RTC("100k..10M", "", 10 * numeric.BytesOf('M'), 99 * numeric.BytesOf('k'))
 }
 
 func TestSizePrimary(t *testing.T) {
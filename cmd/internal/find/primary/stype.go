@@ -0,0 +1,152 @@
+package primary
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/puppetlabs/wash/cmd/internal/find/parser/predicate"
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+)
+
+// Stype is the stype primary
+//
+// stypePrimary => -stype TypePathPattern
+//nolint
+var Stype = Parser.add(&Primary{
+	Description:         "Returns true if the entry's schema type path matches pattern",
+	DetailedDescription: stypeDetailedDescription,
+	name:                "stype",
+	args:                "pattern",
+	parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
+		if len(tokens) == 0 {
+			return nil, nil, fmt.Errorf("requires additional arguments")
+		}
+		m, err := compileTypePathPattern(tokens[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid pattern: %v", err)
+		}
+		return stypeP(m, false), tokens[1:], nil
+	},
+})
+
+// typePathMatcher matches type paths. A nil glob represents a "..."
+// segment, which matches zero or more segments.
+type typePathMatcher []glob.Glob
+
+func compileTypePathPattern(pattern string) (typePathMatcher, error) {
+	var m typePathMatcher
+	for _, segment := range strings.Split(pattern, "/") {
+		if len(segment) == 0 {
+			return nil, fmt.Errorf("empty segment in %v", pattern)
+		}
+		if segment == "..." {
+			m = append(m, nil)
+			continue
+		}
+		g, err := glob.Compile(segment)
+		if err != nil {
+			return nil, err
+		}
+		m = append(m, g)
+	}
+	return m, nil
+}
+
+// Match returns true if m matches the trailing segments of typePath.
+// This way, patterns don't depend on where find started its walk.
+func (m typePathMatcher) Match(typePath string) bool {
+	if len(typePath) == 0 {
+		return false
+	}
+	segments := strings.Split(typePath, "/")
+	for start := range segments {
+		if m.matches(segments[start:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns true if m matches all of the segments
+func (m typePathMatcher) matches(segments []string) bool {
+	if len(m) == 0 {
+		return len(segments) == 0
+	}
+	if m[0] == nil {
+		for i := 0; i <= len(segments); i++ {
+			if m[1:].matches(segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	return m[0].Match(segments[0]) && m[1:].matches(segments[1:])
+}
+
+func stypeP(m typePathMatcher, negated bool) types.EntryPredicate {
+	p := stypePredicate{
+		EntryPredicate: types.ToEntryP(func(e types.Entry) bool {
+			// stype is a schema predicate, so the entry predicate should
+			// always return true
+			return true
+		}),
+		m:       m,
+		negated: negated,
+	}
+	p.SetSchemaP(types.ToEntrySchemaP(func(s *types.EntrySchema) bool {
+		return m.Match(s.TypePath()) != negated
+	}))
+	p.RequireSchema()
+	return p
+}
+
+// The separate type's necessary to implement proper Negation semantics.
+type stypePredicate struct {
+	types.EntryPredicate
+	m       typePathMatcher
+	negated bool
+}
+
+func (p stypePredicate) Negate() predicate.Predicate {
+	return stypeP(p.m, !p.negated)
+}
+
+const stypeDetailedDescription = `
+-stype pattern
+
+Returns true if the entry's schema type path matches pattern. Unlike the
+kind primary, which matches the labels shown by stree, the stype primary
+matches the types that implement the entries. An entry's type path is
+
+  <plugin>/<root_type>/<parent1_type>/.../<type>
+
+where each type is the last segment of the entry's type ID. For example,
+an S3 object's type ID is "aws::github.com/puppetlabs/wash/plugin/aws/s3Object"
+so its type is "s3Object". The plugin's name is repeated before the first
+type of every plugin on the path, so a Kubernetes pod that's embedded in an
+EKS cluster has a type path like "aws/Root/.../eksCluster/kubernetes/pod".
+
+The pattern's segments are separated by "/". A "..." segment matches zero
+or more segments while the other segments are shell patterns that match a
+single segment. The pattern only needs to match the trailing segments of
+the type path so that it doesn't depend on where find starts its walk.
+Like the kind primary, the stype primary always returns false for
+schema-less entries.
+
+Since the type path is known from the entry's schema, find uses the schema
+to prune the branches that cannot contain a matching entry. This makes
+-stype much faster than an equivalent -path query on large plugins.
+
+Examples:
+  -stype 'aws/.../s3Object'     Returns true if the entry is an S3 object
+
+  -stype 's3Bucket/...'         Returns true if the entry is an S3 bucket
+                                or one of its descendants
+
+  -stype '*Instance'            Returns true if the entry's type ends in
+                                "Instance", e.g. an EC2 or GCP instance
+`
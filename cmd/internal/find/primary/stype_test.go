@@ -0,0 +1,62 @@
+package primary
+
+import (
+	"testing"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type StypePrimaryTestSuite struct {
+	primaryTestSuite
+}
+
+func (s *StypePrimaryTestSuite) TestErrors() {
+	s.RETC("", "requires additional arguments")
+	s.RETC("[a", "invalid pattern: unexpected end of input")
+	s.RETC("aws//s3Object", "invalid pattern: empty segment in aws//s3Object")
+}
+
+func (s *StypePrimaryTestSuite) TestValidInput() {
+	// Test the entry predicate
+	s.RNTC("a", "", types.Entry{})
+	// Test the schema predicate
+	s.RSTC("aws/.../s3Object", "", "aws/Root/profile/s3Bucket/s3Object", "aws/Root/profile/s3Bucket")
+	s.RSTC("aws/.../s3Object", "", "Registry/aws/Root/s3Object", "docker/Root/s3Object")
+	s.RSTC("s3Bucket/...", "", "aws/Root/s3Bucket", "aws/Root")
+	s.RSTC("s3Bucket/...", "", "aws/Root/s3Bucket/s3Object", "aws/Root/s3Bucket2")
+	s.RSTC("*Instance", "", "aws/Root/ec2Instance", "aws/Root/ec2Instance/console")
+	s.RNSTC("aws/...", "", "")
+}
+
+func (s *StypePrimaryTestSuite) TestStypeP_Negate() {
+	m, err := compileTypePathPattern("aws/.../s3Object")
+	if s.NoError(err) {
+		p := stypeP(m, false).Negate().(types.EntryPredicate)
+
+		schema := &types.EntrySchema{}
+		schema.SetTypePath("aws/Root/s3Bucket/s3Object")
+		s.False(p.SchemaP().P(schema))
+		schema.SetTypePath("aws/Root/s3Bucket")
+		s.True(p.SchemaP().P(schema))
+		s.True(p.SchemaRequired())
+
+		p = p.Negate().(types.EntryPredicate)
+		s.False(p.SchemaP().P(schema))
+	}
+}
+
+func TestStypePrimary(t *testing.T) {
+	s := new(StypePrimaryTestSuite)
+	s.Parser = Stype
+	s.SchemaPParser = types.EntryPredicateParser(Stype.parseFunc).ToSchemaPParser()
+	s.ConstructEntry = func(v interface{}) types.Entry {
+		return v.(types.Entry)
+	}
+	s.ConstructEntrySchema = func(v interface{}) *types.EntrySchema {
+		s := &types.EntrySchema{}
+		s.SetTypePath(v.(string))
+		return s
+	}
+	suite.Run(t, s)
+}
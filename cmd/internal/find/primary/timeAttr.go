@@ -30,12 +30,13 @@ func getTimeAttrValue(name string, e types.Entry) (time.Time, bool) {
 }
 
 // timeAttrPrimary => -<name> (+|-)?(\d+ | (numeric.DurationRegex)+)
+//                  | -<name> \d+..\d+ | (numeric.DurationRegex)+..(numeric.DurationRegex)+
 func newTimeAttrPrimary(name string) *Primary {
 	return Parser.add(&Primary{
 		Description:         fmt.Sprintf("Returns true if the entry's %v attribute satisfies the given time predicate", name),
 		DetailedDescription: timeAttrDetailedDescription(name),
 		name:                name,
-		args:                "[+|-]n[smhdw]",
+		args:                "[+|-]n[smhdw] | m..n",
 		parseFunc: func(tokens []string) (types.EntryPredicate, []string, error) {
 			if params.ReferenceTime.IsZero() {
 				panic("Attempting to parse a time primary without setting params.ReferenceTime")
@@ -76,6 +77,7 @@ func timeAttrDetailedDescription(name string) string {
 	// '{name}'
 	descr := `
 -{name} [+|-]n[smhdw]
+-{name} m[smhdw]..n[smhdw]
 
 Returns true if the entry's {name} attribute is exactly n days,
 rounded up to the nearest day. If n is suffixed with a unit, then
@@ -90,6 +92,12 @@ w        week   (7 days)
 If n is prefixed with a +/-, then the comparison returns true if the
 {name} is greater-than/less-than n.
 
+The m..n form returns true if the {name} is between m and n ago,
+inclusive. It is shorthand for a "-{name} +m -{name} -n"-style window.
+Both bounds must either be plain days or both have units, e.g. 1..7 or
+1h..2d. Use the -daystart option to align day-based windows with
+calendar days.
+
 Examples:
   -{name} 1        Returns true if the entry's {name} is exactly 1
                   day, rounded up to the nearest day
@@ -103,6 +111,9 @@ Examples:
   -{name} +1h      Returns true if the entry's {name} is more than one
                   hour ago
 
+  -{name} 1h..24h  Returns true if the entry's {name} is between one
+                  hour and one day ago
+
 NOTE: All comparisons are made with respect to the reference time
 `
 	return strings.NewReplacer("{name}", name).Replace(descr)
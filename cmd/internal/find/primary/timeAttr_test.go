@@ -76,6 +76,9 @@ func (s *TimeAttrPrimaryTestSuite) TestErrors() {
 	RIVTC("++++++1hr")
 	RIVTC("1h30min")
 	RIVTC("+1h30min")
+	RIVTC("1..2h")
+	RIVTC("+1h..2h")
+	RIVTC("2h..1h")
 }
 
 func (s *TimeAttrPrimaryTestSuite) TestValidInput() {
@@ -89,6 +92,10 @@ func (s *TimeAttrPrimaryTestSuite) TestValidInput() {
 	// useful unless they're used with the +/- modifiers.
 	s.RTC("+1h", "", 2*numeric.DurationOf('h'), 1*numeric.DurationOf('m'))
 	s.RTC("-1h", "", 1*numeric.DurationOf('m'), 1*numeric.DurationOf('h'))
+	// Test time windows
+	s.RTC("1h..24h", "", 2*numeric.DurationOf('h'), 1*numeric.DurationOf('m'))
+	s.RNTC("1h..24h", "", 2*numeric.DurationOf('d'))
+	s.RTC("2..3", "", 1*numeric.DurationOf('d')+12*numeric.DurationOf('h'), 4*numeric.DurationOf('d'))
 }
 
 func TestTimeAttrPrimary(t *testing.T) {
@@ -0,0 +1,95 @@
+package find
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+)
+
+// progressInterval is how often the progress option reports the walk's
+// progress
+const progressInterval = 5 * time.Second
+
+// progress tracks the walk's progress. It is reported periodically if the
+// progress option is set, and whenever find receives one of the
+// progressSignals.
+//
+// All of progress' methods are no-ops on a nil progress.
+type progress struct {
+	mux     sync.Mutex
+	start   time.Time
+	visited int
+	matches int
+	current string
+}
+
+func newProgress() *progress {
+	return &progress{start: time.Now()}
+}
+
+// visit records the visit of the entry at the given path
+func (p *progress) visit(normalizedPath string, matched bool) {
+	if p == nil {
+		return
+	}
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.visited++
+	if matched {
+		p.matches++
+	}
+	p.current = normalizedPath
+}
+
+func (p *progress) String() string {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	rate := 0.0
+	if elapsed := time.Since(p.start).Seconds(); elapsed > 0 {
+		rate = float64(p.visited) / elapsed
+	}
+	return fmt.Sprintf(
+		"find: visited %v entries (%.1f/s), %v matches, at %v",
+		p.visited,
+		rate,
+		p.matches,
+		p.current,
+	)
+}
+
+// report starts reporting the walk's progress. It returns a function that
+// stops the reporting.
+func (p *progress) report(periodically bool) (stop func()) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, progressSignals...)
+	var ticker *time.Ticker
+	var ticks <-chan time.Time
+	if periodically {
+		ticker = time.NewTicker(progressInterval)
+		ticks = ticker.C
+	}
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-sigCh:
+				cmdutil.ErrPrintf("%v\n", p)
+			case <-ticks:
+				cmdutil.ErrPrintf("%v\n", p)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sigCh)
+		if ticker != nil {
+			ticker.Stop()
+		}
+		close(done)
+	}
+}
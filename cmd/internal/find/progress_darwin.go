@@ -0,0 +1,10 @@
+package find
+
+import (
+	"os"
+	"syscall"
+)
+
+// progressSignals are the signals that make find report its progress.
+// SIGINFO is what Ctrl+T sends on BSD-derived systems.
+var progressSignals = []os.Signal{syscall.SIGINFO, syscall.SIGUSR1}
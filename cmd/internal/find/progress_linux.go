@@ -0,0 +1,10 @@
+package find
+
+import (
+	"os"
+	"syscall"
+)
+
+// progressSignals are the signals that make find report its progress.
+// Linux doesn't have SIGINFO.
+var progressSignals = []os.Signal{syscall.SIGUSR1}
@@ -0,0 +1,35 @@
+package find
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ProgressTestSuite struct {
+	suite.Suite
+}
+
+func (s *ProgressTestSuite) TestVisit() {
+	p := newProgress()
+	p.visit("./foo", true)
+	p.visit("./foo/bar", false)
+	s.Equal(2, p.visited)
+	s.Equal(1, p.matches)
+	s.Regexp(`^find: visited 2 entries \(\d+\.\d/s\), 1 matches, at ./foo/bar$`, p.String())
+}
+
+func (s *ProgressTestSuite) TestVisit_NilProgress() {
+	var p *progress
+	p.visit("./foo", true)
+}
+
+func (s *ProgressTestSuite) TestReport_Stops() {
+	p := newProgress()
+	stop := p.report(true)
+	stop()
+}
+
+func TestProgress(t *testing.T) {
+	suite.Run(t, new(ProgressTestSuite))
+}
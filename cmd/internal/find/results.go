@@ -0,0 +1,176 @@
+package find
+
+import (
+	"container/heap"
+	"sort"
+	"strings"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+)
+
+// sortKey describes how the sort option orders entries by an attribute
+type sortKey struct {
+	has  func(e types.Entry) bool
+	less func(a, b types.Entry) bool
+}
+
+var sortKeys = map[string]sortKey{
+	types.SortByMtime: sortKey{
+		has: func(e types.Entry) bool {
+			return e.Attributes.HasMtime()
+		},
+		less: func(a, b types.Entry) bool {
+			return a.Attributes.Mtime().Before(b.Attributes.Mtime())
+		},
+	},
+	types.SortBySize: sortKey{
+		has: func(e types.Entry) bool {
+			return e.Attributes.HasSize()
+		},
+		less: func(a, b types.Entry) bool {
+			return a.Attributes.Size() < b.Attributes.Size()
+		},
+	},
+	types.SortByName: sortKey{
+		has: func(e types.Entry) bool {
+			return true
+		},
+		less: func(a, b types.Entry) bool {
+			return strings.Compare(a.CName, b.CName) < 0
+		},
+	},
+}
+
+// results prints the entries that satisfy the expression. If the sort
+// option is set, then the entries are buffered and printed in sorted order
+// once the walk's finished. If the max-results option is also set, then only
+// the best max-results entries are buffered in a bounded heap. Otherwise,
+// the entries are printed as they're found, and the walk stops once
+// max-results entries are printed.
+type results struct {
+	// emit outputs a printed entry's path
+	emit    func(normalizedPath string)
+	label   bool
+	state   *runState
+	less    func(a, b types.Entry) bool
+	max     int
+	printed int
+	heap    entryHeap
+}
+
+func newResults(opts types.Options, state *runState) *results {
+	r := &results{
+		emit: func(normalizedPath string) {
+			cmdutil.Printf("%v\n", normalizedPath)
+		},
+		label: opts.Label,
+		state: state,
+		max:   opts.MaxResults,
+	}
+	key, ok := sortKeys[opts.Sort]
+	if !ok {
+		return r
+	}
+	r.less = func(a, b types.Entry) bool {
+		hasA, hasB := key.has(a), key.has(b)
+		if hasA != hasB {
+			// Entries without the attribute are always printed last
+			return hasA
+		}
+		if hasA {
+			if key.less(a, b) {
+				return !opts.Reverse
+			}
+			if key.less(b, a) {
+				return opts.Reverse
+			}
+		}
+		// Break ties with the path so that the output is deterministic
+		return a.NormalizedPath < b.NormalizedPath
+	}
+	// The heap's root is the worst buffered entry so that it can be
+	// replaced by a better one once the heap is full.
+	r.heap.less = func(a, b types.Entry) bool {
+		return r.less(b, a)
+	}
+	return r
+}
+
+func (r *results) add(e types.Entry) {
+	if r.less == nil {
+		if !r.done() {
+			r.print(e)
+		}
+		return
+	}
+	if r.max > 0 && r.heap.Len() >= r.max {
+		if !r.less(e, r.heap.entries[0]) {
+			return
+		}
+		r.heap.entries[0] = e
+		heap.Fix(&r.heap, 0)
+		return
+	}
+	heap.Push(&r.heap, e)
+}
+
+// done returns true if no more entries will be printed. The walker uses
+// this to stop early.
+func (r *results) done() bool {
+	return r.less == nil && r.max > 0 && r.printed >= r.max
+}
+
+// flush prints the buffered entries in sorted order
+func (r *results) flush() {
+	if r.less == nil {
+		return
+	}
+	entries := r.heap.entries
+	r.heap.entries = nil
+	sort.Slice(entries, func(i, j int) bool {
+		return r.less(entries[i], entries[j])
+	})
+	for _, e := range entries {
+		r.print(e)
+	}
+}
+
+func (r *results) print(e types.Entry) {
+	if r.label {
+		r.emit(e.StartPath + "\t" + e.NormalizedPath)
+	} else {
+		r.emit(e.NormalizedPath)
+	}
+	r.printed++
+	r.state.markMatch(e.NormalizedPath)
+}
+
+// entryHeap implements heap.Interface
+type entryHeap struct {
+	entries []types.Entry
+	less    func(a, b types.Entry) bool
+}
+
+func (h *entryHeap) Len() int {
+	return len(h.entries)
+}
+
+func (h *entryHeap) Less(i, j int) bool {
+	return h.less(h.entries[i], h.entries[j])
+}
+
+func (h *entryHeap) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+}
+
+func (h *entryHeap) Push(x interface{}) {
+	h.entries = append(h.entries, x.(types.Entry))
+}
+
+func (h *entryHeap) Pop() interface{} {
+	n := len(h.entries)
+	e := h.entries[n-1]
+	h.entries = h.entries[:n-1]
+	return e
+}
@@ -0,0 +1,212 @@
+package find
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/puppetlabs/wash/cmd/internal/find/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+)
+
+// runState persists a find run's progress so that an interrupted run can be
+// resumed (the resume option) and so that a completed run's matches can be
+// reused (the cached option). The state is stored as a journal of JSON
+// records, one per line. Each record is written as soon as it is known so
+// that the progress made before an interruption is not lost.
+//
+// All of runState's methods are no-ops on a nil runState. This way, the
+// walker doesn't need to check if the resume/cached options are set.
+type runState struct {
+	path    string
+	enc     *json.Encoder
+	f       *os.File
+	visited map[string]bool
+	done    map[string]bool
+	matches []string
+	// completed is the previous run's completion record. It is nil if the
+	// previous run did not complete.
+	completed *stateRecord
+}
+
+type stateRecord struct {
+	Op       string    `json:"op"`
+	Path     string    `json:"path,omitempty"`
+	Time     time.Time `json:"time,omitempty"`
+	ExitCode int       `json:"exitCode,omitempty"`
+}
+
+const (
+	// visitedOp records an entry that was visited
+	visitedOp = "visited"
+	// doneOp records an entry whose subtree was fully walked
+	doneOp = "done"
+	// matchOp records an entry that satisfied the expression
+	matchOp = "match"
+	// completeOp records the end of the run
+	completeOp = "complete"
+)
+
+// Make this a variable so that the tests can mock it
+var stateDir = func() (string, error) {
+	cdir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cdir, "wash", "find"), nil
+}
+
+// stateKey identifies a find run by its working directory and arguments.
+// The resume and cached options are excluded so that they can be toggled
+// between identical runs.
+func stateKey(cwd string, args []string) string {
+	h := sha256.New()
+	h.Write([]byte(cwd))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if strings.HasPrefix(arg, "-") {
+			name := strings.SplitN(strings.TrimLeft(arg, "-"), "=", 2)
+			if name[0] == types.ResumeFlag {
+				continue
+			}
+			if name[0] == types.CachedFlag {
+				if len(name) == 1 {
+					// Skip the ttl
+					i++
+				}
+				continue
+			}
+		}
+		h.Write([]byte{0})
+		h.Write([]byte(arg))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// loadState loads the state of the previous find run with the given
+// arguments.
+func loadState(args []string) (*runState, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	s := &runState{
+		path:    filepath.Join(dir, stateKey(cwd, args)+".json"),
+		visited: make(map[string]bool),
+		done:    make(map[string]bool),
+	}
+	f, err := os.Open(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	dec := json.NewDecoder(f)
+	for {
+		var r stateRecord
+		if err := dec.Decode(&r); err != nil {
+			// This is either io.EOF or a truncated record, which is possible
+			// if the previous run was killed while writing it. Either way,
+			// the remaining records can't be recovered.
+			break
+		}
+		s.apply(r)
+	}
+	return s, nil
+}
+
+func (s *runState) apply(r stateRecord) {
+	switch r.Op {
+	case visitedOp:
+		s.visited[r.Path] = true
+	case doneOp:
+		s.done[r.Path] = true
+	case matchOp:
+		s.matches = append(s.matches, r.Path)
+	case completeOp:
+		s.completed = &r
+	}
+}
+
+// isFresh returns true if the previous run completed less than ttl ago.
+func (s *runState) isFresh(ttl time.Duration) bool {
+	return s.completed != nil && time.Since(s.completed.Time) < ttl
+}
+
+// start starts recording the current run. If resume is false or the
+// previous run completed, then the previous run's state is discarded.
+func (s *runState) start(resume bool) error {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if !resume || s.completed != nil {
+		s.visited = make(map[string]bool)
+		s.done = make(map[string]bool)
+		s.matches = nil
+		s.completed = nil
+		flags |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(s.path, flags, 0640)
+	if err != nil {
+		return err
+	}
+	s.f = f
+	s.enc = json.NewEncoder(f)
+	return nil
+}
+
+func (s *runState) record(r stateRecord) {
+	if s == nil || s.enc == nil {
+		return
+	}
+	if err := s.enc.Encode(r); err != nil {
+		cmdutil.ErrPrintf("could not save find's progress: %v\n", err)
+		// Stop recording so that the error's only reported once
+		s.enc = nil
+	}
+}
+
+func (s *runState) isVisited(path string) bool {
+	return s != nil && s.visited[path]
+}
+
+func (s *runState) isDone(path string) bool {
+	return s != nil && s.done[path]
+}
+
+func (s *runState) markVisited(path string) {
+	s.record(stateRecord{Op: visitedOp, Path: path})
+}
+
+func (s *runState) markDone(path string) {
+	s.record(stateRecord{Op: doneOp, Path: path})
+}
+
+func (s *runState) markMatch(normalizedPath string) {
+	if s != nil {
+		s.matches = append(s.matches, normalizedPath)
+	}
+	s.record(stateRecord{Op: matchOp, Path: normalizedPath})
+}
+
+// complete records the end of the current run.
+func (s *runState) complete(exitCode int) {
+	if s == nil {
+		return
+	}
+	s.record(stateRecord{Op: completeOp, Time: time.Now(), ExitCode: exitCode})
+	if s.f != nil {
+		s.f.Close()
+	}
+}
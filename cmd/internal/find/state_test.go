@@ -0,0 +1,124 @@
+package find
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StateTestSuite struct {
+	suite.Suite
+	dir         string
+	oldStateDir func() (string, error)
+}
+
+func (s *StateTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "find-state")
+	if err != nil {
+		s.FailNow(err.Error())
+	}
+	s.dir = dir
+	s.oldStateDir = stateDir
+	stateDir = func() (string, error) {
+		return s.dir, nil
+	}
+}
+
+func (s *StateTestSuite) TearDownTest() {
+	stateDir = s.oldStateDir
+	os.RemoveAll(s.dir)
+}
+
+func (s *StateTestSuite) TestStateKey_IgnoresResumeAndCached() {
+	key := stateKey("/cwd", []string{"docker", "-name", "foo"})
+	s.Equal(key, stateKey("/cwd", []string{"docker", "-resume", "-name", "foo"}))
+	s.Equal(key, stateKey("/cwd", []string{"docker", "-cached", "5m", "-name", "foo"}))
+	s.Equal(key, stateKey("/cwd", []string{"docker", "-cached=5m", "-name", "foo"}))
+	s.NotEqual(key, stateKey("/cwd", []string{"docker", "-name", "bar"}))
+	s.NotEqual(key, stateKey("/other", []string{"docker", "-name", "foo"}))
+}
+
+func (s *StateTestSuite) TestLoadState_NoPreviousRun() {
+	state, err := loadState([]string{"foo"})
+	if s.NoError(err) {
+		s.Empty(state.visited)
+		s.Empty(state.done)
+		s.Nil(state.completed)
+		s.False(state.isFresh(time.Hour))
+	}
+}
+
+func (s *StateTestSuite) TestResume_InterruptedRun() {
+	args := []string{"foo"}
+	s.recordInterruptedRun(args)
+
+	state, err := loadState(args)
+	if s.NoError(err) && s.NoError(state.start(true)) {
+		s.True(state.isVisited("/foo"))
+		s.True(state.isDone("/foo/bar"))
+		s.False(state.isDone("/foo"))
+		s.Equal([]string{"./foo"}, state.matches)
+		state.complete(0)
+	}
+}
+
+func (s *StateTestSuite) TestStart_WithoutResume_DiscardsPreviousRun() {
+	args := []string{"foo"}
+	s.recordInterruptedRun(args)
+
+	state, err := loadState(args)
+	if s.NoError(err) && s.NoError(state.start(false)) {
+		s.False(state.isVisited("/foo"))
+		s.False(state.isDone("/foo/bar"))
+		state.complete(0)
+	}
+	state, err = loadState(args)
+	if s.NoError(err) {
+		s.Empty(state.visited)
+	}
+}
+
+func (s *StateTestSuite) TestIsFresh_CompletedRun() {
+	args := []string{"foo"}
+	state, err := loadState(args)
+	if s.NoError(err) && s.NoError(state.start(false)) {
+		state.markMatch("./foo")
+		state.complete(1)
+	}
+
+	state, err = loadState(args)
+	if s.NoError(err) {
+		s.True(state.isFresh(time.Hour))
+		s.False(state.isFresh(0))
+		s.Equal([]string{"./foo"}, state.matches)
+		s.Equal(1, state.completed.ExitCode)
+	}
+}
+
+func (s *StateTestSuite) TestNilState() {
+	var state *runState
+	s.False(state.isVisited("/foo"))
+	s.False(state.isDone("/foo"))
+	state.markVisited("/foo")
+	state.markDone("/foo")
+	state.markMatch("./foo")
+	state.complete(0)
+}
+
+func (s *StateTestSuite) recordInterruptedRun(args []string) {
+	state, err := loadState(args)
+	if s.NoError(err) && s.NoError(state.start(false)) {
+		state.markVisited("/foo")
+		state.markMatch("./foo")
+		state.markVisited("/foo/bar")
+		state.markDone("/foo/bar")
+		state.f.Close()
+	}
+}
+
+func TestState(t *testing.T) {
+	suite.Run(t, new(StateTestSuite))
+}
@@ -10,6 +10,11 @@ type Entry struct {
 	NormalizedPath string
 	SchemaKnown    bool
 	Schema         *EntrySchema
+	// StartPath is the start path of the walk that found the entry, and
+	// Depth is the entry's depth relative to it. They are set by the walker
+	// before the entry is visited.
+	StartPath string
+	Depth     uint
 }
 
 // NewEntry constructs a new `wash find` entry
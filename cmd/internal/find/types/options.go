@@ -2,26 +2,48 @@ package types
 
 import (
 	"flag"
+	"fmt"
 	"io/ioutil"
+	"strconv"
+	"time"
 
+	"github.com/puppetlabs/wash/cmd/internal/find/primary/numeric"
 	"github.com/puppetlabs/wash/cmd/util"
 )
 
 // Options represents the find command's options.
 type Options struct {
-	Depth    bool
-	Maxdepth int
-	Mindepth uint
-	Daystart bool
-	Fullmeta bool
-	Help     HelpOption
-	setFlags map[string]struct{}
+	Depth      bool
+	Maxdepth   int
+	Mindepth   uint
+	Daystart   bool
+	Fullmeta   bool
+	Force      bool
+	ReadLimit  int64
+	Parallel   int
+	Resume     bool
+	Cached     time.Duration
+	Xdev       bool
+	Progress   bool
+	Sort       string
+	Reverse    bool
+	MaxResults int
+	File       string
+	Search     string
+	Watch      time.Duration
+	Label      bool
+	Help       HelpOption
+	setFlags   map[string]struct{}
 }
 
 // DefaultMaxdepth is the default value of the maxdepth option.
 // It is set to the max value of a 32-bit integer.
 const DefaultMaxdepth = 1<<31 - 1
 
+// DefaultReadLimit is the default value of the readlimit option.
+// It is set to 1 mebibyte.
+const DefaultReadLimit = 1024 * 1024
+
 // NewOptions creates a new Options object
 func NewOptions() Options {
 	return Options{
@@ -29,10 +51,24 @@ func NewOptions() Options {
 		Mindepth: 0,
 		// We make Maxdepth an int because of the `meta` primary.
 		// See the comments in `primary/meta.go` for more details.
-		Maxdepth: DefaultMaxdepth,
-		Daystart: false,
-		Fullmeta: false,
-		setFlags: make(map[string]struct{}),
+		Maxdepth:   DefaultMaxdepth,
+		Daystart:   false,
+		Fullmeta:   false,
+		Force:      false,
+		ReadLimit:  DefaultReadLimit,
+		Parallel:   1,
+		Resume:     false,
+		Cached:     0,
+		Xdev:       false,
+		Progress:   false,
+		Sort:       "",
+		Reverse:    false,
+		MaxResults: 0,
+		File:       "",
+		Search:     "",
+		Watch:      0,
+		Label:      false,
+		setFlags:   make(map[string]struct{}),
 	}
 }
 
@@ -47,6 +83,43 @@ const (
 	DaystartFlag = "daystart"
 	// FullmetaFlag is the name of the fullmeta option's flag
 	FullmetaFlag = "fullmeta"
+	// ForceFlag is the name of the force option's flag
+	ForceFlag = "force"
+	// ReadLimitFlag is the name of the readlimit option's flag
+	ReadLimitFlag = "readlimit"
+	// ParallelFlag is the name of the parallel option's flag
+	ParallelFlag = "parallel"
+	// ResumeFlag is the name of the resume option's flag
+	ResumeFlag = "resume"
+	// CachedFlag is the name of the cached option's flag
+	CachedFlag = "cached"
+	// XdevFlag is the name of the xdev option's flag
+	XdevFlag = "xdev"
+	// ProgressFlag is the name of the progress option's flag
+	ProgressFlag = "progress"
+	// SortFlag is the name of the sort option's flag
+	SortFlag = "sort"
+	// ReverseFlag is the name of the reverse option's flag
+	ReverseFlag = "reverse"
+	// MaxResultsFlag is the name of the max-results option's flag
+	MaxResultsFlag = "max-results"
+	// FileFlag is the name of the file option's flag
+	FileFlag = "f"
+	// SearchFlag is the name of the search option's flag
+	SearchFlag = "search"
+	// WatchFlag is the name of the watch option's flag
+	WatchFlag = "watch"
+	// LabelFlag is the name of the label option's flag
+	LabelFlag = "label"
+)
+
+const (
+	// SortByMtime sorts the entries by their mtime
+	SortByMtime = "mtime"
+	// SortBySize sorts the entries by their size
+	SortBySize = "size"
+	// SortByName sorts the entries by their cname
+	SortByName = "name"
 )
 
 // IsSet returns true if the flag was set, false otherwise.
@@ -71,6 +144,20 @@ func (opts *Options) FlagSet() *flag.FlagSet {
 	fs.IntVar(&opts.Maxdepth, MaxdepthFlag, opts.Maxdepth, "")
 	fs.BoolVar(&opts.Daystart, DaystartFlag, opts.Daystart, "")
 	fs.BoolVar(&opts.Fullmeta, FullmetaFlag, opts.Fullmeta, "")
+	fs.BoolVar(&opts.Force, ForceFlag, opts.Force, "")
+	fs.Var((*sizeValue)(&opts.ReadLimit), ReadLimitFlag, "")
+	fs.IntVar(&opts.Parallel, ParallelFlag, opts.Parallel, "")
+	fs.BoolVar(&opts.Resume, ResumeFlag, opts.Resume, "")
+	fs.DurationVar(&opts.Cached, CachedFlag, opts.Cached, "")
+	fs.BoolVar(&opts.Xdev, XdevFlag, opts.Xdev, "")
+	fs.BoolVar(&opts.Progress, ProgressFlag, opts.Progress, "")
+	fs.Var((*sortValue)(&opts.Sort), SortFlag, "")
+	fs.BoolVar(&opts.Reverse, ReverseFlag, opts.Reverse, "")
+	fs.IntVar(&opts.MaxResults, MaxResultsFlag, opts.MaxResults, "")
+	fs.StringVar(&opts.File, FileFlag, opts.File, "")
+	fs.StringVar(&opts.Search, SearchFlag, opts.Search, "")
+	fs.DurationVar(&opts.Watch, WatchFlag, opts.Watch, "")
+	fs.BoolVar(&opts.Label, LabelFlag, opts.Label, "")
 	return fs
 }
 
@@ -78,18 +165,72 @@ func (opts *Options) FlagSet() *flag.FlagSet {
 // options
 func OptionsTable() *cmdutil.Table {
 	return cmdutil.NewTable(
-		[]string{"Flags:",                 ""},
-		[]string{"      -depth",           "Visit the children first before the parent (default false)"},
-		[]string{"      -mindepth depth",  "Do not print entries at levels less than depth (default 0)"},
-		[]string{"      -maxdepth depth",  "Do not print entries at levels greater than depth (default infinity)"},
-		[]string{"      -daystart",        "Set the reference time to the start of the current day (default false)"},
-		[]string{"      -fullmeta",        "Use the entry's full metadata in meta primary predicates (default false)"},
-		[]string{"  -h, -help",            "Print this usage"},
-		[]string{"  -h, -help <primary>",  "Print a detailed description of the specified primary (e.g. \"-help meta\")"},
-		[]string{"  -h, -help syntax",     "Print a detailed description of find's expression syntax"},
+		[]string{"Flags:", ""},
+		[]string{"      -depth", "Visit the children first before the parent (default false)"},
+		[]string{"      -mindepth depth", "Do not print entries at levels less than depth (default 0)"},
+		[]string{"      -maxdepth depth", "Do not print entries at levels greater than depth (default infinity)"},
+		[]string{"      -daystart", "Set the reference time to the start of the current day (default false)"},
+		[]string{"      -fullmeta", "Use the entry's full metadata in meta primary predicates (default false)"},
+		[]string{"      -force", "Do not ask for confirmation before deleting entries (default false)"},
+		[]string{"      -readlimit size", "Read at most size bytes of an entry's content in the grep primary (default 1M)"},
+		[]string{"      -parallel n", "List up to n sibling entries concurrently (default 1)"},
+		[]string{"      -resume", "Resume the previous identical run if it was interrupted (default false)"},
+		[]string{"      -cached ttl", "Reuse the matches of an identical run that completed less than ttl ago (default 0)"},
+		[]string{"      -xdev", "Do not descend into entries from a different plugin (default false)"},
+		[]string{"      -progress", "Periodically print the walk's progress to stderr (default false)"},
+		[]string{"      -sort mtime|size|name", "Print the entries in ascending order of the given attribute (default unsorted)"},
+		[]string{"      -reverse", "Print the sorted entries in descending order (default false)"},
+		[]string{"      -max-results n", "Print at most n entries (default infinity)"},
+		[]string{"      -f file", "Read an expression from file and combine it with the given expression (see the NOTE below)"},
+		[]string{"      -search name", "Combine the given expression with the saved search called name (see the NOTE below)"},
+		[]string{"      -watch interval", "Re-run the walk every interval, printing the added (+) and removed (-) matches"},
+		[]string{"      -label", "Prefix each printed entry with its start path and a tab (default false)"},
+		[]string{"  -h, -help", "Print this usage"},
+		[]string{"  -h, -help <primary>", "Print a detailed description of the specified primary (e.g. \"-help meta\")"},
+		[]string{"  -h, -help syntax", "Print a detailed description of find's expression syntax"},
 	)
 }
 
+// sizeValue is a flag.Value for sizes like 512, 10k or 1M. Plain
+// integers are interpreted as bytes.
+type sizeValue int64
+
+func (v *sizeValue) String() string {
+	return strconv.FormatInt(int64(*v), 10)
+}
+
+func (v *sizeValue) Set(str string) error {
+	n, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		n, err = numeric.ParseSize(str)
+		if err != nil {
+			return fmt.Errorf("%v: illegal size value", str)
+		}
+	}
+	if n <= 0 {
+		return fmt.Errorf("%v: size must be positive", str)
+	}
+	*v = sizeValue(n)
+	return nil
+}
+
+// sortValue is a flag.Value for the sort option's attribute
+type sortValue string
+
+func (v *sortValue) String() string {
+	return string(*v)
+}
+
+func (v *sortValue) Set(str string) error {
+	switch str {
+	case SortByMtime, SortBySize, SortByName:
+		*v = sortValue(str)
+		return nil
+	default:
+		return fmt.Errorf("%v: expected one of %v, %v or %v", str, SortByMtime, SortBySize, SortByName)
+	}
+}
+
 // HelpOption represents the -help option. If HasValue is set, then
 // that means the input was "-help <primary>|syntax". In that case,
 // only one of Primary/Syntax is set. Otherwise, the input was "-help".
@@ -98,12 +239,11 @@ func OptionsTable() *cmdutil.Table {
 // this does not implement the Value interface.
 type HelpOption struct {
 	Requested bool
-	HasValue bool
+	HasValue  bool
 	// Cannot use *primary.Primary here b/c doing so would introduce
 	// an import cycle. Resolving that import cycle for a slightly
 	// cleaner implementation is not worth the additional complexity
 	// associated with introducing more fine-grained packages.
 	Primary string
-	Syntax bool
+	Syntax  bool
 }
-
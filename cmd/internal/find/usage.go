@@ -46,6 +46,51 @@ func Usage() string {
 	u += "the specified attribute. For example, the -mtime primary will always return false\n"
 	u += "if the entry does not have an mtime attribute.\n"
 	u += "\n"
+	u += "NOTE: The -parallel option only lists sibling entries concurrently. The entries\n"
+	u += "are still visited and printed in order. Keep n small for plugins whose APIs are\n"
+	u += "rate-limited.\n"
+	u += "\n"
+	u += "NOTE: The -resume and -cached options save find's progress in the user's cache\n"
+	u += "directory. A run is identified by its working directory and its arguments, so\n"
+	u += "only identical runs share progress. -resume skips the entries that an interrupted\n"
+	u += "run already visited, and only prints the new matches. -cached prints the previous\n"
+	u += "run's matches without walking if that run completed less than ttl ago. -cached is\n"
+	u += "ignored if the expression contains -exec, -ok, -delete, -json or -printf.\n"
+	u += "\n"
+	u += "NOTE: The -xdev option still visits the entries that are on a different plugin,\n"
+	u += "like the plugin roots of the \"/\" mountpoint. It just doesn't descend into them.\n"
+	u += "\n"
+	u += "NOTE: The -sort option buffers the matching entries and prints them once the walk\n"
+	u += "finishes. Entries without the sorted attribute are printed last. With -max-results,\n"
+	u += "only the best n entries are buffered, so e.g. the 20 most recently modified objects\n"
+	u += "are found with \"-sort mtime -reverse -max-results 20\". Without -sort, find stops\n"
+	u += "walking once it prints n entries. Both options only affect printed entries, so they\n"
+	u += "are ignored if the expression contains -exec, -ok, -delete, -json or -printf.\n"
+	u += "\n"
+	u += "NOTE: The -f and -search options' expressions can span multiple lines, and a '#'\n"
+	u += "that starts a word begins a comment. They are combined with the given expression\n"
+	u += "using -and.\n"
+	u += "\n"
+	u += "NOTE: Saved searches are specified in wash.yaml under the find.searches key, e.g.\n"
+	u += "  find:\n"
+	u += "    searches:\n"
+	u += "      exited: -k '*container' -meta '.state' exited\n"
+	u += "Then \"" + use + " docker -search exited\" finds all the exited Docker containers.\n"
+	u += "\n"
+	u += "NOTE: The -watch option runs until find is interrupted. Its walks go through the\n"
+	u += "Wash server's cache, so changes show up once the cached entries expire. Use\n"
+	u += "'wash clear' to see them sooner. -resume and -cached are ignored in watch mode.\n"
+	u += "For example, \"" + use + " kubernetes -watch 30s -k '*pod' -meta '.status.phase' Failed\"\n"
+	u += "prints the pods as they fail and recover.\n"
+	u += "\n"
+	u += "NOTE: Use the -root primary to scope parts of the expression to specific start paths,\n"
+	u += "and the -label option to tell their matches apart. For example,\n"
+	u += "  " + use + " aws kubernetes -label \\( -root aws -k '*ec2*instance' \\) -o \\( -root kubernetes -k '*pod' \\)\n"
+	u += "Per-path depth limits are expressed with -level, e.g. \"-root kubernetes -level 3 -prune\".\n"
+	u += "\n"
+	u += "NOTE: find prints its progress to stderr when it receives SIGUSR1 (or SIGINFO, i.e.\n"
+	u += "Ctrl+T, on macOS and FreeBSD), even if the -progress option isn't set.\n"
+	u += "\n"
 	u += "NOTE: find exits with status 0 if all entries are processed successfully, greater\n"
 	u += "than 0 if errors occur. This is deliberately a very broad description, but if the\n"
 	u += "return value is non-zero, you should not rely on the correctness of find.\n"
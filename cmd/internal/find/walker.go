@@ -1,7 +1,12 @@
 package find
 
 import (
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/puppetlabs/wash/api/client"
+	"github.com/puppetlabs/wash/cmd/internal/find/params"
 	"github.com/puppetlabs/wash/cmd/internal/find/parser"
 	"github.com/puppetlabs/wash/cmd/internal/find/primary"
 	"github.com/puppetlabs/wash/cmd/internal/find/types"
@@ -13,24 +18,51 @@ type walker interface {
 	// Returns true if the walk is successful (i.e. does not
 	// have any errors), false otherwise.
 	Walk(path string) bool
+	// Flush prints the results that were buffered by the sort
+	// option. Call this after all the paths are walked.
+	Flush()
+	// Watch re-walks the paths every interval, printing the matches
+	// that were added or removed since the previous walk. It only
+	// returns if the walk cannot be repeated.
+	Watch(paths []string, interval time.Duration) bool
 }
 
 type walkerImpl struct {
-	p    types.EntryPredicate
-	opts types.Options
-	conn client.Client
+	p     types.EntryPredicate
+	opts  types.Options
+	conn  client.Client
+	state *runState
+	prog  *progress
+	// results prints the entries that satisfy p
+	results *results
+	// rootDevice is the device of the current walk's root. It is used by
+	// the xdev option.
+	rootDevice string
+	// sem bounds the number of concurrent List requests when the parallel
+	// option is set
+	sem chan struct{}
+}
+
+// listResult is the result of listing an entry's children
+type listResult struct {
+	children []types.Entry
+	err      error
 }
 
 // Make this a variable so that other tests can mock it
-var newWalker = func(r parser.Result, conn client.Client) walker {
+var newWalker = func(r parser.Result, conn client.Client, state *runState, prog *progress) walker {
 	return &walkerImpl{
-		p:    r.Predicate,
-		opts: r.Options,
-		conn: conn,
+		p:       r.Predicate,
+		opts:    r.Options,
+		conn:    conn,
+		state:   state,
+		prog:    prog,
+		results: newResults(r.Options, state),
 	}
 }
 
 func (w *walkerImpl) Walk(path string) bool {
+	params.StartPath = path
 	e, err := info(w.conn, path)
 	if err != nil {
 		cmdutil.ErrPrintf("%v\n", err)
@@ -50,10 +82,72 @@ func (w *walkerImpl) Walk(path string) bool {
 		// true here.
 		return true
 	}
-	return w.walk(e, 0)
+	w.rootDevice = device(e.TypeID)
+	return w.walk(e, 0, nil)
+}
+
+func (w *walkerImpl) Flush() {
+	w.results.flush()
+}
+
+func (w *walkerImpl) Watch(paths []string, interval time.Duration) bool {
+	return w.watch(paths, interval, 0)
+}
+
+// watch implements Watch. It stops after the given number of iterations if
+// iterations > 0.
+func (w *walkerImpl) watch(paths []string, interval time.Duration, iterations int) bool {
+	var previous map[string]bool
+	successful := true
+	for i := 0; iterations <= 0 || i < iterations; i++ {
+		if i > 0 {
+			time.Sleep(interval)
+		}
+		var added []string
+		current := make(map[string]bool)
+		w.results = newResults(w.opts, nil)
+		w.results.emit = func(normalizedPath string) {
+			if !previous[normalizedPath] {
+				added = append(added, normalizedPath)
+			}
+			current[normalizedPath] = true
+		}
+		successful = true
+		for _, path := range paths {
+			successful = w.Walk(path) && successful
+		}
+		w.Flush()
+		successful = primary.FlushExecBatches() && successful
+
+		var removed []string
+		for normalizedPath := range previous {
+			if !current[normalizedPath] {
+				removed = append(removed, normalizedPath)
+			}
+		}
+		sort.Strings(removed)
+		for _, normalizedPath := range added {
+			cmdutil.Printf("+ %v\n", normalizedPath)
+		}
+		for _, normalizedPath := range removed {
+			cmdutil.Printf("- %v\n", normalizedPath)
+		}
+		previous = current
+	}
+	return successful
 }
 
-func (w *walkerImpl) walk(e types.Entry, depth uint) bool {
+// walk walks e. If prefetched is non-nil, then it receives the result of
+// listing e's children.
+func (w *walkerImpl) walk(e types.Entry, depth uint, prefetched <-chan listResult) bool {
+	if w.state.isDone(e.Path) {
+		// e's subtree was fully walked by the resumed run
+		return true
+	}
+	if w.results.done() {
+		// The max-results option's limit was reached
+		return true
+	}
 	// If the Depth option is set, then we visit e after visiting its children.
 	// Otherwise, we visit e first.
 	successful := true
@@ -63,39 +157,131 @@ func (w *walkerImpl) walk(e types.Entry, depth uint) bool {
 	}
 	if !w.opts.Depth {
 		check(w.visit(e, depth))
+		if primary.Pruned() {
+			if successful {
+				w.state.markDone(e.Path)
+			}
+			return successful
+		}
+		if w.results.done() {
+			return successful
+		}
 	}
 	childDepth := depth + 1
-	if int(childDepth) <= w.opts.Maxdepth && e.Supports(plugin.ListAction()) {
-		if e.SchemaKnown {
-			if e.Schema == nil || len(e.Schema.Children()) == 0 {
-				// We've reached the end of our traversal
-				return successful
-			}
+	if w.shouldList(e, depth) {
+		var r listResult
+		if prefetched != nil {
+			r = <-prefetched
+		} else {
+			r.children, r.err = list(w.conn, e)
 		}
-		children, err := list(w.conn, e)
-		if err != nil {
-			cmdutil.ErrPrintf("could not get children of %v: %v\n", e.NormalizedPath, err)
+		if r.err != nil {
+			cmdutil.ErrPrintf("could not get children of %v: %v\n", e.NormalizedPath, r.err)
 			successful = false
 		} else {
-			for _, child := range children {
-				if e.SchemaKnown {
+			children := r.children
+			if e.SchemaKnown {
+				for i, child := range children {
 					// Note that e.Schema != nil here
-					child.SetSchema(e.Schema.GetChild(child.TypeID))
+					children[i].SetSchema(e.Schema.GetChild(child.TypeID))
 				}
-				check(w.walk(child, childDepth))
+			}
+			lists := w.prefetch(children, childDepth)
+			for i, child := range children {
+				check(w.walk(child, childDepth, lists[i]))
 			}
 		}
 	}
 	if w.opts.Depth {
 		check(w.visit(e, depth))
 	}
+	if successful && !w.results.done() {
+		w.state.markDone(e.Path)
+	}
 	return successful
 }
 
+// shouldList returns true if the walker should list e's children
+func (w *walkerImpl) shouldList(e types.Entry, depth uint) bool {
+	childDepth := depth + 1
+	if int(childDepth) > w.opts.Maxdepth || !e.Supports(plugin.ListAction()) {
+		return false
+	}
+	if w.opts.Xdev && !onSameDevice(w.rootDevice, device(e.TypeID)) {
+		return false
+	}
+	if e.SchemaKnown {
+		if e.Schema == nil || len(e.Schema.Children()) == 0 {
+			// We've reached the end of our traversal
+			return false
+		}
+	}
+	return true
+}
+
+// device returns the "device" of the given type ID. It is the xdev
+// option's analogue of a filesystem. Core plugin type IDs have the form
+// <plugin>::<package path>/<type>, so their device is everything before the
+// last "/". This way, the entries of a core plugin that's embedded in another
+// plugin (e.g. a Kubernetes tree inside an EKS cluster) are on a separate
+// device. Type IDs without a "/" are opaque, so their device is the plugin's
+// name.
+func device(typeID string) string {
+	if ix := strings.LastIndex(typeID, "/"); ix >= 0 {
+		return typeID[:ix]
+	}
+	if ix := strings.Index(typeID, "::"); ix >= 0 {
+		return typeID[:ix]
+	}
+	return typeID
+}
+
+// onSameDevice returns true if d1 and d2 are the same device. Entries without
+// a type ID are assumed to be on the same device as everything else.
+func onSameDevice(d1 string, d2 string) bool {
+	return d1 == "" || d2 == "" || d1 == d2
+}
+
+// prefetch concurrently lists the children of the given siblings when the
+// parallel option is set. The siblings themselves are still visited in
+// order by the main goroutine so that the output is deterministic and the
+// primaries are evaluated serially. The i'th returned channel receives the
+// i'th sibling's children. It is nil if the sibling should not be listed, or
+// if the parallel option is not set.
+func (w *walkerImpl) prefetch(siblings []types.Entry, depth uint) []chan listResult {
+	lists := make([]chan listResult, len(siblings))
+	if w.opts.Parallel <= 1 {
+		return lists
+	}
+	if w.sem == nil {
+		w.sem = make(chan struct{}, w.opts.Parallel)
+	}
+	for i, sibling := range siblings {
+		if !w.shouldList(sibling, depth) {
+			continue
+		}
+		// The channel's buffered so that the goroutine doesn't leak if the
+		// sibling's pruned.
+		ch := make(chan listResult, 1)
+		lists[i] = ch
+		go func(sibling types.Entry) {
+			w.sem <- struct{}{}
+			defer func() { <-w.sem }()
+			var r listResult
+			r.children, r.err = list(w.conn, sibling)
+			ch <- r
+		}(sibling)
+	}
+	return lists
+}
+
 func (w *walkerImpl) visit(e types.Entry, depth uint) bool {
-	if depth < w.opts.Mindepth {
+	primary.ResetPruned()
+	if depth < w.opts.Mindepth || w.state.isVisited(e.Path) {
 		return true
 	}
+	e.StartPath = params.StartPath
+	e.Depth = depth
 	if e.SchemaKnown {
 		if e.Schema == nil || !w.p.SchemaP().P(e.Schema) {
 			// This is possible if e's a sibling/ancestor to a satisfying
@@ -123,8 +309,13 @@ func (w *walkerImpl) visit(e types.Entry, depth uint) bool {
 			e.Metadata = meta
 		}
 	}
-	if w.p.P(e) {
-		cmdutil.Printf("%v\n", e.NormalizedPath)
+	matched := w.p.P(e)
+	w.prog.visit(e.NormalizedPath, matched)
+	if matched {
+		if !primary.PerformsActions() {
+			w.results.add(e)
+		}
 	}
+	w.state.markVisited(e.Path)
 	return true
 }
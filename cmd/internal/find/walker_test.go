@@ -32,6 +32,8 @@ func (s *WalkerTestSuite) SetupTest() {
 			}),
 		},
 		s.Suite.Client,
+		nil,
+		nil,
 	).(*walkerImpl)
 }
 
@@ -78,6 +80,21 @@ func (s *WalkerTestSuite) TestWalk_HappyCase() {
 	)
 }
 
+func (s *WalkerTestSuite) TestWalk_ParallelSet() {
+	s.setupDefaultMocksForWalk()
+	s.walker.opts.Parallel = 4
+	s.True(s.walker.Walk("."))
+	// The output should be the same as the serial walk's output
+	s.assertPrintedTree(
+		".",
+		"./foo",
+		"./foo/bar",
+		"./foo/bar/1",
+		"./foo/bar/2",
+		"./foo/baz",
+	)
+}
+
 func (s *WalkerTestSuite) TestWalk_WithSchema_HappyCase() {
 	// Set-up the mocks
 	fileSchema := func(path string, typeID string) *apitypes.EntrySchema {
@@ -127,6 +144,168 @@ func (s *WalkerTestSuite) TestWalk_WithSchema_HappyCase() {
 	)
 }
 
+func (s *WalkerTestSuite) TestWalk_Pruned() {
+	s.setupDefaultMocksForWalk()
+	pruneP, _, err := primary.Prune.Parse([]string{})
+	if s.NoError(err) {
+		s.walker.p = types.ToEntryP(func(e types.Entry) bool {
+			if e.NormalizedPath == "./foo/bar" {
+				return pruneP.IsSatisfiedBy(e)
+			}
+			return true
+		})
+	}
+	s.True(s.walker.Walk("."))
+	s.assertPrintedTree(
+		".",
+		"./foo",
+		"./foo/bar",
+		"./foo/baz",
+	)
+	s.Client.AssertNotCalled(s.T(), "List", s.toAbsPath("./foo/bar"))
+}
+
+func (s *WalkerTestSuite) TestWalk_Resumed() {
+	s.setupDefaultMocksForWalk()
+	// Simulate a run that was interrupted while walking ./foo's children
+	s.walker.state = &runState{
+		visited: map[string]bool{
+			s.toAbsPath("."):     true,
+			s.toAbsPath("./foo"): true,
+		},
+		done: map[string]bool{
+			s.toAbsPath("./foo/bar"): true,
+		},
+	}
+	s.True(s.walker.Walk("."))
+	s.assertPrintedTree(
+		"./foo/baz",
+	)
+	s.Client.AssertNotCalled(s.T(), "List", s.toAbsPath("./foo/bar"))
+}
+
+func (s *WalkerTestSuite) TestWalk_TracksProgress() {
+	s.setupDefaultMocksForWalk()
+	s.walker.prog = newProgress()
+	s.True(s.walker.Walk("."))
+	s.Equal(6, s.walker.prog.visited)
+	s.Equal(6, s.walker.prog.matches)
+	s.Equal("./foo/baz", s.walker.prog.current)
+}
+
+func (s *WalkerTestSuite) TestWalk_SortSet() {
+	s.setupDefaultMocksForWalk()
+	s.walker.results = newResults(types.Options{Sort: types.SortByName, Reverse: true}, nil)
+	s.True(s.walker.Walk("."))
+	// Sorted entries are printed when the walker is flushed
+	s.Empty(s.Stdout())
+	s.walker.Flush()
+	s.assertPrintedTree(
+		"./foo",
+		"./foo/baz",
+		"./foo/bar",
+		"./foo/bar/2",
+		"./foo/bar/1",
+		".",
+	)
+}
+
+func (s *WalkerTestSuite) TestWalk_SortAndMaxResultsSet() {
+	s.setupDefaultMocksForWalk()
+	s.walker.results = newResults(types.Options{Sort: types.SortByName, MaxResults: 3}, nil)
+	s.True(s.walker.Walk("."))
+	s.walker.Flush()
+	s.assertPrintedTree(
+		".",
+		"./foo/bar/1",
+		"./foo/bar/2",
+	)
+}
+
+func (s *WalkerTestSuite) TestWalk_MaxResultsSet() {
+	s.setupDefaultMocksForWalk()
+	s.walker.results = newResults(types.Options{MaxResults: 2}, nil)
+	s.True(s.walker.Walk("."))
+	s.walker.Flush()
+	s.assertPrintedTree(
+		".",
+		"./foo",
+	)
+	s.Client.AssertNotCalled(s.T(), "List", s.toAbsPath("./foo"))
+}
+
+func (s *WalkerTestSuite) TestWalk_LabelSet() {
+	s.setupDefaultMocksForWalk()
+	s.walker.results = newResults(types.Options{Label: true}, nil)
+	s.True(s.walker.Walk("."))
+	s.assertPrintedTree(
+		".\t.",
+		".\t./foo",
+		".\t./foo/bar",
+		".\t./foo/bar/1",
+		".\t./foo/bar/2",
+		".\t./foo/baz",
+	)
+}
+
+func (s *WalkerTestSuite) TestWatch() {
+	s.setupDefaultMocksForWalk()
+	// Set up the second walk, where ./foo/bar/1 is removed and ./foo/bar/3
+	// is added
+	s.setupMocksForWalk(nil, map[string][]apitypes.Entry{
+		".": []apitypes.Entry{s.toEntry("./foo", true, "")},
+		"./foo": []apitypes.Entry{
+			s.toEntry("./foo/bar", true, ""),
+			s.toEntry("./foo/baz", false, ""),
+		},
+		"./foo/bar": []apitypes.Entry{
+			s.toEntry("./foo/bar/2", false, ""),
+			s.toEntry("./foo/bar/3", false, ""),
+		},
+	})
+	s.True(s.walker.watch([]string{"."}, 0, 2))
+	s.assertPrintedTree(
+		"+ .",
+		"+ ./foo",
+		"+ ./foo/bar",
+		"+ ./foo/bar/1",
+		"+ ./foo/bar/2",
+		"+ ./foo/baz",
+		"+ ./foo/bar/3",
+		"- ./foo/bar/1",
+	)
+}
+
+func (s *WalkerTestSuite) TestWalk_XdevSet() {
+	s.Client.On("Info", ".").Return(s.toEntry(".", true, "aws::plugin/aws/eksCluster"), nil).Once()
+	s.Client.On("Schema", ".").Return((*apitypes.EntrySchema)(nil), nil).Once()
+	s.mockList(".", false, []apitypes.Entry{
+		s.toEntry("./k8s", true, "aws::plugin/kubernetes/namespaces"),
+		s.toEntry("./nodes", true, "aws::plugin/aws/nodes"),
+	}, nil)
+	s.mockList("./nodes", false, []apitypes.Entry{
+		s.toEntry("./nodes/1", false, "aws::plugin/aws/node"),
+	}, nil)
+
+	s.walker.opts.Xdev = true
+	s.True(s.walker.Walk("."))
+	s.assertPrintedTree(
+		".",
+		"./k8s",
+		"./nodes",
+		"./nodes/1",
+	)
+	s.Client.AssertNotCalled(s.T(), "List", s.toAbsPath("./k8s"))
+}
+
+func (s *WalkerTestSuite) TestDevice() {
+	s.Equal("aws::plugin/aws", device("aws::plugin/aws/eksCluster"))
+	s.Equal("docker", device("docker::container"))
+	s.Equal("", device(""))
+	s.True(onSameDevice("", "docker"))
+	s.False(onSameDevice("aws", "docker"))
+}
+
 func (s *WalkerTestSuite) TestWalk_MaxdepthSet() {
 	s.setupDefaultMocksForWalk()
 	s.walker.opts.Maxdepth = 2
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"runtime/pprof"
 	"strings"
@@ -13,52 +14,115 @@ import (
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/analytics"
 	"github.com/puppetlabs/wash/api"
+	"github.com/puppetlabs/wash/audit"
 	"github.com/puppetlabs/wash/fuse"
 	"github.com/puppetlabs/wash/plugin"
 	"github.com/puppetlabs/wash/plugin/aws"
+	"github.com/puppetlabs/wash/plugin/cloudflare"
+	"github.com/puppetlabs/wash/plugin/containerd"
+	"github.com/puppetlabs/wash/plugin/digitalocean"
 	"github.com/puppetlabs/wash/plugin/docker"
+	"github.com/puppetlabs/wash/plugin/elasticsearch"
 	"github.com/puppetlabs/wash/plugin/gcp"
+	"github.com/puppetlabs/wash/plugin/kafka"
 	"github.com/puppetlabs/wash/plugin/kubernetes"
+	"github.com/puppetlabs/wash/plugin/localhost"
+	"github.com/puppetlabs/wash/plugin/mqtt"
+	"github.com/puppetlabs/wash/plugin/mysql"
+	"github.com/puppetlabs/wash/plugin/nomad"
+	"github.com/puppetlabs/wash/plugin/openstack"
+	"github.com/puppetlabs/wash/plugin/postgres"
+	"github.com/puppetlabs/wash/plugin/puppetdb"
+	"github.com/puppetlabs/wash/plugin/rabbitmq"
+	"github.com/puppetlabs/wash/plugin/redis"
+	"github.com/puppetlabs/wash/plugin/sftp"
+	"github.com/puppetlabs/wash/plugin/terraform"
+	"github.com/puppetlabs/wash/plugin/vsphere"
+	"github.com/puppetlabs/wash/plugin/zookeeper"
+	"github.com/puppetlabs/wash/secrets"
+	"github.com/puppetlabs/wash/slowlog"
+	"github.com/puppetlabs/wash/tracing"
 
 	log "github.com/sirupsen/logrus"
 )
 
 // InternalPlugins lists the plugins enabled by default in Wash.
 var InternalPlugins = map[string]plugin.Root{
-	"aws":        &aws.Root{},
-	"docker":     &docker.Root{},
-	"gcp":        &gcp.Root{},
-	"kubernetes": &kubernetes.Root{},
+	"aws":           &aws.Root{},
+	"cloudflare":    &cloudflare.Root{},
+	"containerd":    &containerd.Root{},
+	"digitalocean":  &digitalocean.Root{},
+	"docker":        &docker.Root{},
+	"elasticsearch": &elasticsearch.Root{},
+	"gcp":           &gcp.Root{},
+	"kafka":         &kafka.Root{},
+	"kubernetes":    &kubernetes.Root{},
+	"localhost":     &localhost.Root{},
+	"mqtt":          &mqtt.Root{},
+	"mysql":         &mysql.Root{},
+	"nomad":         &nomad.Root{},
+	"openstack":     &openstack.Root{},
+	"postgres":      &postgres.Root{},
+	"puppetdb":      &puppetdb.Root{},
+	"rabbitmq":      &rabbitmq.Root{},
+	"redis":         &redis.Root{},
+	"s3":            &aws.S3Root{},
+	"sftp":          &sftp.Root{},
+	"terraform":     &terraform.Root{},
+	"vsphere":       &vsphere.Root{},
+	"zookeeper":     &zookeeper.Root{},
 }
 
 // Opts exposes additional configuration for server operation.
 type Opts struct {
 	CPUProfilePath string
-	LogFile        string
+	// LogFile is where logs are written. Logs are written to stdout if it's
+	// empty or "-".
+	LogFile string
+	// LogFormat can be "text" or "json".
+	LogFormat string
+	// LogSink can be "file", "syslog", or "journald". LogFile and its rotation
+	// settings only apply to "file".
+	LogSink string
+	// SyslogAddress is the syslog daemon's URL, e.g. udp://localhost:514. It's
+	// the local syslog daemon if empty.
+	SyslogAddress string
 	// LogLevel can be "warn", "info", "debug", or "trace".
-	LogLevel     string
-	PluginConfig map[string]map[string]interface{}
-}
-
-// SetupLogging configures log level and output file according to configured options.
-// If an output file was configured, returns a handle for you to close later.
-func (o Opts) SetupLogging() (*os.File, error) {
-	level, err := log.ParseLevel(o.LogLevel)
-	if err != nil {
-		return nil, fmt.Errorf("%v is not a valid level; use warn, info, debug, trace", o.LogLevel)
-	}
-
-	log.SetLevel(level)
-	if o.LogFile != "" {
-		logFH, err := os.Create(o.LogFile)
-		if err != nil {
-			return nil, err
-		}
-
-		log.SetOutput(logFH)
-		return logFH, nil
-	}
-	return nil, nil
+	LogLevel string
+	// LogMaxSize is the size, in megabytes, that LogFile is rotated at. It's
+	// not rotated by size if it's 0.
+	LogMaxSize int
+	// LogMaxAge is how long LogFile is written to before it's rotated. It's
+	// not rotated by age if it's 0.
+	LogMaxAge time.Duration
+	// LogMaxBackups is the number of rotated log files that are kept. All of
+	// them are kept if it's 0.
+	LogMaxBackups int
+	PluginConfig  map[string]map[string]interface{}
+	// AuditFile is where destructive actions are audited. They aren't audited
+	// if it's empty. AuditKeyFile is the key that the audit log's hashes are
+	// computed with. It's generated if it doesn't exist.
+	AuditFile    string
+	AuditKeyFile string
+	// SlowLogFile is where plugin methods and API requests that take at least
+	// SlowLogThreshold are recorded. They're only tracked in memory if it's
+	// empty, and not at all if the threshold is 0.
+	SlowLogFile      string
+	SlowLogThreshold time.Duration
+	// ReadOnly rejects the write, exec, delete, signal, rename, copy, create and setattr
+	// actions on every entry. ReadOnlyPlugins rejects them on the given plugins' entries.
+	ReadOnly        bool
+	ReadOnlyPlugins []string
+	// ActionRules restrict the actions that are permitted on entries.
+	ActionRules []plugin.ActionRule
+	// ConcurrencyLimits bound the concurrent plugin method invocations.
+	ConcurrencyLimits plugin.ConcurrencyLimits
+	// RetryPolicies configure how plugin methods that fail with a transient
+	// error are retried.
+	RetryPolicies plugin.RetryPolicies
+	// TracingEndpoint is the OTLP/HTTP endpoint that traces are exported to.
+	// Tracing is disabled if it's empty.
+	TracingEndpoint string
 }
 
 type controlChannels struct {
@@ -71,7 +135,7 @@ type Server struct {
 	mountpoint       string
 	socket           string
 	opts             Opts
-	logFH            *os.File
+	logFH            io.Closer
 	api              controlChannels
 	fuse             controlChannels
 	plugins          map[string]plugin.Root
@@ -114,12 +178,34 @@ func (s *Server) Start() (bool, error) {
 
 	successfullyLoadedPlugins := true
 	if !s.forVerifyInstall {
+		plugin.SetConcurrencyLimits(s.opts.ConcurrencyLimits)
+		plugin.SetRetryPolicies(s.opts.RetryPolicies)
 		successfullyLoadedPlugins = s.loadPlugins(registry)
 		if len(registry.Plugins()) == 0 {
 			return successfullyLoadedPlugins, fmt.Errorf("no plugins loaded. If you're planning on using Wash just for its external plugins, then go to https://puppetlabs.github.io/wash/docs/external-plugins")
 		}
 
 		plugin.InitCache()
+		if s.opts.AuditFile != "" {
+			key, err := audit.LoadKey(s.opts.AuditKeyFile)
+			if err != nil {
+				return successfullyLoadedPlugins, fmt.Errorf("could not load the audit key: %v", err)
+			}
+			if err := audit.Init(s.opts.AuditFile, key); err != nil {
+				return successfullyLoadedPlugins, fmt.Errorf("could not open the audit log: %v", err)
+			}
+		}
+		if err := slowlog.Init(s.opts.SlowLogFile, s.opts.SlowLogThreshold); err != nil {
+			return successfullyLoadedPlugins, fmt.Errorf("could not open the slow log: %v", err)
+		}
+		tracing.Init(tracing.Config{Endpoint: s.opts.TracingEndpoint})
+		plugin.SetReadOnly(s.opts.ReadOnly, s.opts.ReadOnlyPlugins)
+		if s.opts.ReadOnly {
+			log.Infof("Wash is in read-only mode")
+		}
+		if err := plugin.SetActionRules(s.opts.ActionRules); err != nil {
+			return successfullyLoadedPlugins, fmt.Errorf("invalid actions config: %v", err)
+		}
 
 		analyticsConfig, err := analytics.GetConfig()
 		if err != nil {
@@ -198,6 +284,12 @@ func (s *Server) shutdown() {
 	// Close any open journals on shutdown to ensure remaining entries are flushed to disk.
 	activity.CloseAll()
 
+	audit.Close()
+	slowlog.Close()
+
+	// Export any outstanding spans.
+	tracing.Shutdown()
+
 	// Flush any outstanding analytics hits. We do this asynchronously
 	// so that the server process isn't blocked on its cleanup (in case
 	// the network is slow).
@@ -255,7 +347,15 @@ func (s *Server) loadPlugins(registry *plugin.Registry) bool {
 		log.Infof("Loading %v", name)
 		wg.Add(1)
 		go func(name string, root plugin.Root) {
-			if err := registry.RegisterPlugin(root, s.opts.PluginConfig[name]); err != nil {
+			// Resolve the config's secrets here rather than when it's read so
+			// that they're only held by the plugins.
+			config, err := secrets.Resolve(s.opts.PluginConfig[name])
+			if err != nil {
+				err = fmt.Errorf("could not resolve the config's secrets: %v", err)
+			} else {
+				err = registry.RegisterPlugin(root, config)
+			}
+			if err != nil {
 				// %+v is a convention used by some errors to print additional context such as a stack trace
 				log.Warnf("%v failed to load: %+v", name, err)
 				if _, ok := InternalPlugins[name]; ok {
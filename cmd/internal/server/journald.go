@@ -0,0 +1,113 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// journaldSocket is where systemd-journald receives log entries using its
+// native protocol, https://systemd.io/JOURNAL_NATIVE_PROTOCOL.
+var journaldSocket = "/run/systemd/journal/socket"
+
+// journaldHook sends log entries to systemd-journald. Each entry's fields are
+// sent as journal fields, e.g. the plugin field becomes PLUGIN, so that they
+// can be queried with journalctl.
+type journaldHook struct {
+	conn *net.UnixConn
+}
+
+func setupJournald() (io.Closer, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journaldSocket, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to journald: %v", err)
+	}
+	hook := &journaldHook{conn: conn}
+	log.AddHook(hook)
+	log.SetOutput(ioutil.Discard)
+	return conn, nil
+}
+
+func (h *journaldHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h *journaldHook) Fire(entry *log.Entry) error {
+	_, err := h.conn.Write(journaldMessage(entry))
+	return err
+}
+
+// journaldPriorities maps logrus' levels to syslog priorities.
+var journaldPriorities = map[log.Level]int{
+	log.PanicLevel: 0,
+	log.FatalLevel: 2,
+	log.ErrorLevel: 3,
+	log.WarnLevel:  4,
+	log.InfoLevel:  6,
+	log.DebugLevel: 7,
+	log.TraceLevel: 7,
+}
+
+func journaldMessage(entry *log.Entry) []byte {
+	var buf bytes.Buffer
+	writeJournaldField(&buf, "MESSAGE", entry.Message)
+	writeJournaldField(&buf, "PRIORITY", fmt.Sprint(journaldPriorities[entry.Level]))
+	writeJournaldField(&buf, "SYSLOG_IDENTIFIER", "wash")
+
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if name := journaldFieldName(key); name != "" {
+			writeJournaldField(&buf, name, fmt.Sprint(entry.Data[key]))
+		}
+	}
+	return buf.Bytes()
+}
+
+// journaldFieldName converts key to a valid journal field name, which consists
+// of uppercase letters, digits and underscores and doesn't start with an
+// underscore (those are trusted fields that journald sets itself). It returns
+// an empty string if that's not possible.
+func journaldFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z':
+			return r - 'a' + 'A'
+		case 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	name = strings.TrimLeft(name, "_")
+	if name == "" || ('0' <= name[0] && name[0] <= '9') {
+		return ""
+	}
+	return name
+}
+
+func writeJournaldField(buf *bytes.Buffer, name string, value string) {
+	if !strings.Contains(value, "\n") {
+		fmt.Fprintf(buf, "%v=%v\n", name, value)
+		return
+	}
+	// Values containing newlines are written as the name, a newline, the
+	// value's length as a little-endian 64-bit integer, then the value.
+	buf.WriteString(name)
+	buf.WriteByte('\n')
+	var size [8]byte
+	binary.LittleEndian.PutUint64(size[:], uint64(len(value)))
+	buf.Write(size[:])
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournaldMessage(t *testing.T) {
+	entry := log.NewEntry(log.New()).WithFields(log.Fields{
+		"plugin":   "docker",
+		"duration": 0.5,
+		"_private": "dropped?",
+		"2fa":      "dropped",
+	})
+	entry.Level = log.WarnLevel
+	entry.Message = "plugin: List /docker"
+
+	expected := "MESSAGE=plugin: List /docker\n" +
+		"PRIORITY=4\n" +
+		"SYSLOG_IDENTIFIER=wash\n" +
+		"PRIVATE=dropped?\n" +
+		"DURATION=0.5\n" +
+		"PLUGIN=docker\n"
+	assert.Equal(t, expected, string(journaldMessage(entry)))
+}
+
+func TestJournaldMessage_Multiline(t *testing.T) {
+	entry := log.NewEntry(log.New())
+	entry.Level = log.InfoLevel
+	entry.Message = "stdout: a\nb"
+
+	expected := "MESSAGE\n" + "\x0b\x00\x00\x00\x00\x00\x00\x00" + "stdout: a\nb\n" +
+		"PRIORITY=6\n" +
+		"SYSLOG_IDENTIFIER=wash\n"
+	assert.Equal(t, expected, string(journaldMessage(entry)))
+}
+
+func TestJournaldFieldName(t *testing.T) {
+	assert.Equal(t, "PLUGIN", journaldFieldName("plugin"))
+	assert.Equal(t, "HTTP_STATUS_CODE", journaldFieldName("http.status_code"))
+	assert.Equal(t, "PRIVATE", journaldFieldName("_private"))
+	assert.Equal(t, "", journaldFieldName("2fa"))
+	assert.Equal(t, "", journaldFieldName("__"))
+}
@@ -0,0 +1,189 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log/syslog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	logsyslog "github.com/sirupsen/logrus/hooks/syslog"
+)
+
+// SetupLogging configures log level, format and output according to configured options.
+// If an output file or sink was configured, returns a handle for you to close later.
+func (o Opts) SetupLogging() (io.Closer, error) {
+	level, err := log.ParseLevel(o.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("%v is not a valid level; use warn, info, debug, trace", o.LogLevel)
+	}
+
+	switch o.LogFormat {
+	case "", "text":
+		// Keep the caller's formatter
+	case "json":
+		log.SetFormatter(&log.JSONFormatter{TimestampFormat: time.RFC3339Nano})
+	default:
+		return nil, fmt.Errorf("%v is not a valid log format; use text, json", o.LogFormat)
+	}
+
+	log.SetLevel(level)
+	switch o.LogSink {
+	case "", "file":
+		// Log to LogFile below
+	case "syslog":
+		return setupSyslog(o.SyslogAddress)
+	case "journald":
+		return setupJournald()
+	default:
+		return nil, fmt.Errorf("%v is not a valid log sink; use file, syslog, journald", o.LogSink)
+	}
+
+	if o.LogFile != "" && o.LogFile != "-" {
+		logFH, err := openRotatingFile(o.LogFile, int64(o.LogMaxSize)*1024*1024, o.LogMaxAge, o.LogMaxBackups)
+		if err != nil {
+			return nil, err
+		}
+
+		log.SetOutput(logFH)
+		return logFH, nil
+	}
+	return nil, nil
+}
+
+// setupSyslog sends logs to the syslog daemon at address, which is a URL like
+// udp://logs.example.com:514. It's the local syslog daemon if address is
+// empty. Messages are formatted with the configured log format.
+func setupSyslog(address string) (io.Closer, error) {
+	var network, raddr string
+	if address != "" {
+		u, err := url.Parse(address)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("%v is not a valid syslog address; use e.g. udp://localhost:514", address)
+		}
+		network, raddr = u.Scheme, u.Host
+	}
+	hook, err := logsyslog.NewSyslogHook(network, raddr, syslog.LOG_DAEMON|syslog.LOG_INFO, "wash")
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to syslog: %v", err)
+	}
+	log.AddHook(hook)
+	log.SetOutput(ioutil.Discard)
+	return hook.Writer, nil
+}
+
+// rotatingFile is a log file that's rotated once it exceeds maxSize bytes or
+// has been written to for maxAge. The rotated file is renamed to include the
+// time it was rotated, e.g. wash-server.log becomes
+// wash-server-2020-01-02T15-04-05.000.log. Only the newest maxBackups rotated
+// files are kept.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+
+	mux    sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+func openRotatingFile(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{path: path, maxSize: maxSize, maxAge: maxAge, maxBackups: maxBackups}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, err
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file, f.size, f.opened = file, info.Size(), time.Now()
+	return nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if f.size > 0 {
+		tooBig := f.maxSize > 0 && f.size+int64(len(p)) > f.maxSize
+		tooOld := f.maxAge > 0 && time.Since(f.opened) >= f.maxAge
+		if tooBig || tooOld {
+			if err := f.rotate(); err != nil {
+				// Keep writing to the current file rather than losing logs
+				fmt.Fprintf(os.Stderr, "Failed to rotate %v: %v\n", f.path, err)
+			}
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) Close() error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return f.file.Close()
+}
+
+func (f *rotatingFile) rotate() error {
+	ext := filepath.Ext(f.path)
+	prefix := strings.TrimSuffix(f.path, ext) + "-"
+	backup := prefix + time.Now().Format(backupTimeFormat) + ext
+	if err := os.Rename(f.path, backup); err != nil {
+		return err
+	}
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	if f.maxBackups <= 0 {
+		return nil
+	}
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return err
+	}
+	var backups []string
+	for _, match := range matches {
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(match, prefix), ext)
+		if _, err := time.Parse(backupTimeFormat, timestamp); err == nil {
+			backups = append(backups, match)
+		}
+	}
+	// The timestamps sort chronologically, so the oldest backups come first.
+	sort.Strings(backups)
+	for len(backups) > f.maxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
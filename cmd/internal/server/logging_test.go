@@ -0,0 +1,86 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingFile_RotatesBySize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wash-logging")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "wash.log")
+	f, err := openRotatingFile(path, 10, 0, 2)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := f.Write([]byte(line))
+		assert.NoError(t, err)
+		// Backups are named by the millisecond they're rotated at
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "fourth\n", string(content))
+
+	backups, err := filepath.Glob(filepath.Join(dir, "wash-*.log"))
+	assert.NoError(t, err)
+	if assert.Len(t, backups, 2) {
+		content, err := ioutil.ReadFile(backups[0])
+		assert.NoError(t, err)
+		assert.Equal(t, "second\n", string(content))
+		content, err = ioutil.ReadFile(backups[1])
+		assert.NoError(t, err)
+		assert.Equal(t, "third\n", string(content))
+	}
+}
+
+func TestRotatingFile_RotatesByAge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wash-logging")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "wash.log")
+	f, err := openRotatingFile(path, 0, time.Hour, 0)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.Write([]byte("old\n"))
+	assert.NoError(t, err)
+	f.opened = f.opened.Add(-2 * time.Hour)
+	_, err = f.Write([]byte("new\n"))
+	assert.NoError(t, err)
+
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "new\n", string(content))
+	backups, err := filepath.Glob(filepath.Join(dir, "wash-*.log"))
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+}
+
+func TestRotatingFile_AppendsToExistingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wash-logging")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "wash.log")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("previous\n"), 0640))
+	f, err := openRotatingFile(path, 100, 0, 0)
+	assert.NoError(t, err)
+	_, err = f.Write([]byte("current\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "previous\ncurrent\n", string(content))
+}
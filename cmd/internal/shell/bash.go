@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 )
 
 type bash struct {
@@ -49,9 +50,9 @@ func (b bash) Command(subcommands []string, rundir string) (*exec.Cmd, error) {
 	content += common
 
 	// Configure prompt and override `cd`
-	content += preparePrompt(`\e[0;36m`, `\e[0;32m`, `\e[m`, "export PS1") + `
+	content += preparePrompt("bash", `\e[0;36m`, `\e[0;32m`, `\e[m`, "export PS1") + `
 export PROMPT_COMMAND=prompter
-` + overrideCd() + `
+` + overrideCd() + bashCompletion(subcommands) + runStartup(rundir) + `
 [[ -s ~/.washrc ]] && source ~/.washrc
 `
 	if err := ioutil.WriteFile(rcpath, []byte(content), 0644); err != nil {
@@ -59,3 +60,23 @@ export PROMPT_COMMAND=prompter
 	}
 	return cmd, nil
 }
+
+// StrictMode exits on the first failed command, including failures in a pipeline.
+func (b bash) StrictMode() (string, error) {
+	return "set -eo pipefail\n", nil
+}
+
+// Create a completion function that asks `wash complete` for Wash paths. If there aren't any, bash
+// falls back to its default completion. Parents end in '/', so don't add a space after them.
+func bashCompletion(subcommands []string) string {
+	return `
+function _wash_complete() {
+	local IFS=$'\n'
+	COMPREPLY=($(wash complete --command "${COMP_WORDS[0]}" -- "${COMP_WORDS[COMP_CWORD]}" 2>/dev/null))
+	if [[ ${#COMPREPLY[@]} -eq 1 && ${COMPREPLY[0]} == */ ]]; then
+		compopt -o nospace
+	fi
+}
+complete -o default -F _wash_complete ` + strings.Join(subcommands, " ") + `
+`
+}
@@ -28,5 +28,9 @@ func TestBash(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, string(bits), "alias help='WASH_EMBEDDED=1 wash help'")
 
-	assert.FileExists(t, filepath.Join(tmpdir, ".bashrc"))
+	rc := filepath.Join(tmpdir, ".bashrc")
+	assert.FileExists(t, rc)
+	bits, err = ioutil.ReadFile(rc)
+	assert.NoError(t, err)
+	assert.Contains(t, string(bits), "complete -o default -F _wash_complete help")
 }
@@ -22,6 +22,11 @@ func (b basic) Command(subcommands []string, rundir string) (*exec.Cmd, error) {
 	return exec.Command(b.sh), nil
 }
 
+// StrictMode exits on the first failed command. POSIX shells don't have pipefail.
+func (b basic) StrictMode() (string, error) {
+	return "set -e\n", nil
+}
+
 // Helper to write executable wrappers for available Wash subcommands based on their aliases.
 // This is broadly useful because things like `xargs` ignore builtins and aliases.
 func writeAliases(subcommands []string, rundir string) error {
@@ -24,8 +24,14 @@ type Shell interface {
 	//   1. reconfigure subcommand aliases (in case they were overridden)
 	//   1. configure the prompt to show your location within the Wash hierarchy (use preparePrompt)
 	//   1. override cd so `cd` without arguments changes directory to $W (use overrideCd)
+	//   1. complete Wash paths for subcommands by calling `wash complete --command <subcommand>`
+	//   1. run the startup commands from WriteHooks, if there are any (use runStartup)
 	//   1. if ~/.washrc exists, load it
 	Command(subcommands []string, rundir string) (*exec.Cmd, error)
+
+	// StrictMode returns commands that make a non-interactive shell exit when a command fails, like
+	// `set -e`. They're run before the script. It returns an error if the shell doesn't support it.
+	StrictMode() (string, error)
 }
 
 // Get returns an implementation for the shell described by the SHELL environment variable.
@@ -35,6 +41,10 @@ func Get() Shell {
 		return bash{sh: sh}
 	case "zsh":
 		return zsh{sh: sh}
+	case "fish":
+		return fish{sh: sh}
+	case "pwsh", "powershell":
+		return powershell{sh: sh}
 	default:
 		// Basic is a fallback that doesn't fully implement the Shell semantics. It provides the common
 		// subset we can expect from a Bourne Shell.
@@ -47,10 +57,20 @@ func Get() Shell {
 
 // Create the declaration for a `prompter` function that generates the prompt
 //   `%F{cyan}wash ${prompt_path}%F{green} ❯%f `
-// with substitution for shell-specific portions of the function.
-func preparePrompt(cyan, green, reset, assign string) string {
+// with substitution for shell-specific portions of the function. If WASH_PROMPT_FORMAT is set (from
+// the prompt.format config), the prompt is instead rendered by `wash prompt --shell <name>`; the
+// default prompt is used if that fails.
+func preparePrompt(name, cyan, green, reset, assign string) string {
 	return `
 function prompter() {
+	if [ -n "${WASH_PROMPT_FORMAT}" ]; then
+		local custom_prompt
+		if custom_prompt=$(wash prompt --shell ` + name + `); then
+			` + assign + `="${custom_prompt}"
+			return
+		fi
+	fi
+
 	local prompt_path=$PWD
 
 	if [ -v W ]; then
@@ -79,3 +99,11 @@ func overrideCd() string {
 function cd { if (( $# == 0 )); then builtin cd $W; else builtin cd $*; fi }
 `
 }
+
+// Create a command that runs the startup commands from WriteHooks if there are any.
+func runStartup(rundir string) string {
+	path := startupPath(rundir)
+	return `
+if [ -x '` + path + `' ]; then '` + path + `'; fi
+`
+}
@@ -30,4 +30,12 @@ func TestGet(t *testing.T) {
 	os.Setenv("SHELL", "/usr/local/bin/bash")
 	sh = Get()
 	assert.IsType(t, bash{}, sh)
+
+	os.Setenv("SHELL", "/usr/local/bin/fish")
+	sh = Get()
+	assert.IsType(t, fish{}, sh)
+
+	os.Setenv("SHELL", "/usr/local/bin/pwsh")
+	sh = Get()
+	assert.IsType(t, powershell{}, sh)
 }
@@ -0,0 +1,120 @@
+package shell
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+)
+
+type fish struct {
+	sh string
+}
+
+func (f fish) Command(subcommands []string, rundir string) (*exec.Cmd, error) {
+	// Generate alias executables so things like `xargs` work.
+	if err := writeAliases(subcommands, rundir); err != nil {
+		return nil, err
+	}
+
+	// Generate and invoke a custom config.fish. Fish has a single config file for interactive and
+	// non-interactive shells, so it's loaded in place of both the non-interactive and interactive
+	// config.
+	// - config.fish will load the user's config.fish (if ~/.washenv is absent), then alias
+	//   subcommands, then load ~/.washenv (if present).
+	// - for interactive shells, it will then load the user's config.fish (if ~/.washrc is absent and
+	//   it wasn't already loaded), then configure the prompt and completion, then load ~/.washrc
+	//   (if present).
+	rcpath := filepath.Join(rundir, "config.fish")
+	cmd := exec.Command(f.sh, "--no-config", "--init-command", "source '"+rcpath+"'")
+
+	var common string
+	for _, alias := range subcommands {
+		common += "function " + alias + "; env WASH_EMBEDDED=1 wash " + alias + " $argv; end\n"
+	}
+
+	content := `set -l user_config (set -q XDG_CONFIG_HOME; and echo $XDG_CONFIG_HOME; or echo ~/.config)/fish/config.fish
+set -l user_config_loaded
+if not test -s ~/.washenv; and test -s $user_config
+	source $user_config
+	set user_config_loaded 1
+end
+` + common + `
+if test -s ~/.washenv; source ~/.washenv; end
+
+if status is-interactive
+	if not test -s ~/.washrc; and test -s $user_config; and test -z "$user_config_loaded"
+		source $user_config
+	end
+` + common + fishPrompt() + fishCd() + fishCompletion(subcommands) + `
+	if test -x '` + startupPath(rundir) + `'; '` + startupPath(rundir) + `'; end
+	if test -s ~/.washrc; source ~/.washrc; end
+end
+`
+	if err := ioutil.WriteFile(rcpath, []byte(content), 0644); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// StrictMode isn't supported because fish doesn't have an equivalent of `set -e`.
+func (f fish) StrictMode() (string, error) {
+	return "", fmt.Errorf("fish does not support exiting on the first failed command")
+}
+
+// Fish equivalent of preparePrompt.
+func fishPrompt() string {
+	return `
+function fish_prompt
+	if test -n "$WASH_PROMPT_FORMAT"
+		set -l custom_prompt (wash prompt --shell fish | string collect)
+		and begin
+			printf '%s' $custom_prompt
+			return
+		end
+	end
+
+	set -l prompt_path $PWD
+	if set -q W
+		# If the current directory is W, replace with '.'. Else if it's a subdir, show just subpath.
+		if test "$prompt_path" = "$W"
+			set prompt_path .
+		else
+			set prompt_path (string replace -- "$W/" '' $prompt_path)
+		end
+	end
+	if set -q HOME
+		set prompt_path (string replace -r -- '^'(string escape --style=regex $HOME) '~' $prompt_path)
+	end
+	printf '%swash %s%s ❯%s ' (set_color cyan) $prompt_path (set_color green) (set_color normal)
+end
+`
+}
+
+// Fish equivalent of overrideCd.
+func fishCd() string {
+	return `
+function cd
+	if test (count $argv) -eq 0
+		builtin cd $W
+	else
+		builtin cd $argv
+	end
+end
+`
+}
+
+// Create a completion function that asks `wash complete` for Wash paths. Fish adds file completions
+// as well, so paths outside of Wash still complete.
+func fishCompletion(subcommands []string) string {
+	content := `
+function __wash_complete
+	set -l tokens (commandline -opc)
+	wash complete --command $tokens[1] -- (commandline -ct) 2>/dev/null
+end
+`
+	for _, alias := range subcommands {
+		content += "complete -c " + alias + " -a '(__wash_complete)'\n"
+	}
+	return content
+}
@@ -0,0 +1,31 @@
+package shell
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFish(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "testFish")
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "Temporary directory required for further testing")
+	}
+	defer os.RemoveAll(tmpdir)
+
+	sh := fish{sh: "/usr/local/bin/fish"}
+	comm, err := sh.Command([]string{"help"}, tmpdir)
+	assert.NoError(t, err)
+	config := filepath.Join(tmpdir, "config.fish")
+	assert.Contains(t, comm.Args, "source '"+config+"'")
+
+	assert.FileExists(t, config)
+	bits, err := ioutil.ReadFile(config)
+	assert.NoError(t, err)
+	assert.Contains(t, string(bits), "function help; env WASH_EMBEDDED=1 wash help $argv; end")
+	assert.Contains(t, string(bits), "function fish_prompt")
+	assert.Contains(t, string(bits), "complete -c help -a '(__wash_complete)'")
+}
@@ -0,0 +1,69 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Hooks are shell-independent customizations of the Wash shell. They're installed for all shells,
+// including ones that Wash doesn't provide a customized environment for.
+type Hooks struct {
+	// Aliases maps names to Wash commands as they'd be typed in the Wash shell, e.g.
+	// "containers" => "wps docker/containers". Each alias is installed as an executable that
+	// appends its arguments to the command.
+	Aliases map[string]string
+	// Startup lists Wash commands to run when an interactive shell starts.
+	Startup []string
+}
+
+// WriteHooks installs the hooks in rundir. It should be called after the shell's Command so that
+// aliases can't override subcommands. Aliases that can't be installed are skipped; WriteHooks
+// returns an error for each of them.
+func WriteHooks(hooks Hooks, rundir string) []error {
+	var errs []error
+	names := make([]string, 0, len(hooks.Aliases))
+	for name := range hooks.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if name == "" || strings.ContainsAny(name, string(filepath.Separator)+" ") {
+			errs = append(errs, fmt.Errorf("invalid alias name %q", name))
+			continue
+		}
+		if err := writeHook(filepath.Join(rundir, name), hooks.Aliases[name]+` "$@"`); err != nil {
+			errs = append(errs, fmt.Errorf("unable to create alias %v: %v", name, err))
+		}
+	}
+
+	if len(hooks.Startup) > 0 {
+		if err := writeHook(startupPath(rundir), strings.Join(hooks.Startup, "\nWASH_EMBEDDED=1 wash ")); err != nil {
+			errs = append(errs, fmt.Errorf("unable to create startup commands: %v", err))
+		}
+	}
+	return errs
+}
+
+// Create an executable file at the given path that invokes the given wash command.
+func writeHook(path, command string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0750)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("it conflicts with an existing command")
+		}
+		return err
+	}
+
+	_, err = f.WriteString("#!/bin/sh\nWASH_EMBEDDED=1 wash " + command + "\n")
+	f.Close()
+	return err
+}
+
+// startupPath returns the path of the executable that runs the startup commands. Interactive shells
+// run it (if it exists) after configuring the prompt, but before loading ~/.washrc.
+func startupPath(rundir string) string {
+	return filepath.Join(rundir, ".washstartup")
+}
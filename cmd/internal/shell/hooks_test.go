@@ -0,0 +1,40 @@
+package shell
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteHooks(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "testHooks")
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "Temporary directory required for further testing")
+	}
+	defer os.RemoveAll(tmpdir)
+
+	assert.NoError(t, writeAliases([]string{"wps"}, tmpdir))
+	errs := WriteHooks(Hooks{
+		Aliases: map[string]string{
+			"containers": "wps docker/containers",
+			"wps":        "wps docker",
+			"a/b":        "ls",
+		},
+		Startup: []string{"docs .", "ls"},
+	}, tmpdir)
+	if assert.Len(t, errs, 2) {
+		assert.EqualError(t, errs[0], `invalid alias name "a/b"`)
+		assert.EqualError(t, errs[1], "unable to create alias wps: it conflicts with an existing command")
+	}
+
+	bits, err := ioutil.ReadFile(filepath.Join(tmpdir, "containers"))
+	assert.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\nWASH_EMBEDDED=1 wash wps docker/containers \"$@\"\n", string(bits))
+
+	bits, err = ioutil.ReadFile(startupPath(tmpdir))
+	assert.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\nWASH_EMBEDDED=1 wash docs .\nWASH_EMBEDDED=1 wash ls\n", string(bits))
+}
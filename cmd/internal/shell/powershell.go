@@ -0,0 +1,111 @@
+package shell
+
+import (
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+type powershell struct {
+	sh string
+}
+
+func (p powershell) Command(subcommands []string, rundir string) (*exec.Cmd, error) {
+	// PowerShell functions can't use native argument completion, so subcommands are only provided
+	// as the alias executables. They also make things like `xargs` work.
+	if err := writeAliases(subcommands, rundir); err != nil {
+		return nil, err
+	}
+
+	// Generate and dot-source a custom profile. PowerShell doesn't have a non-interactive config,
+	// so the profile will remove built-in aliases that would shadow subcommands, then load
+	// ~/.washenv (if present). For interactive shells, it will then load $PROFILE (if ~/.washrc is
+	// absent), then configure the prompt and completion, then load ~/.washrc (if present).
+	//
+	// ~/.washenv and ~/.washrc don't have the .ps1 extension that dot-sourcing requires, so they're
+	// evaluated with Invoke-Expression.
+	profile := filepath.Join(rundir, "profile.ps1")
+	cmd := exec.Command(p.sh, "-NoLogo", "-NoProfile", "-NoExit", "-Command", ". '"+profile+"'")
+
+	quoted := make([]string, len(subcommands))
+	for i, alias := range subcommands {
+		quoted[i] = "'" + alias + "'"
+	}
+	names := strings.Join(quoted, ", ")
+
+	common := `foreach ($name in @(` + names + `)) {
+	if (Test-Path "Alias:$name") { Remove-Item "Alias:$name" -Force }
+}
+`
+	content := common + `if (Test-Path ~/.washenv) { Invoke-Expression (Get-Content -Raw ~/.washenv) }
+
+if ([Environment]::UserInteractive -and -not [Console]::IsInputRedirected) {
+	if (-not (Test-Path ~/.washrc) -and (Test-Path $PROFILE)) { . $PROFILE }
+	# Remove aliases again in case $PROFILE added them.
+` + common + powershellPrompt() + powershellCd() + powershellCompletion(names) + `
+	if (Test-Path '` + startupPath(rundir) + `') { & '` + startupPath(rundir) + `' }
+	if (Test-Path ~/.washrc) { Invoke-Expression (Get-Content -Raw ~/.washrc) }
+}
+`
+	if err := ioutil.WriteFile(profile, []byte(content), 0644); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// StrictMode stops on the first error, including native commands that exit with a non-zero code.
+func (p powershell) StrictMode() (string, error) {
+	return "$ErrorActionPreference = 'Stop'\n$PSNativeCommandUseErrorActionPreference = $true\n", nil
+}
+
+// PowerShell equivalent of preparePrompt.
+func powershellPrompt() string {
+	return `
+function prompt {
+	if ($env:WASH_PROMPT_FORMAT) {
+		$customPrompt = (wash prompt --shell powershell) -join "` + "`" + `n"
+		if ($LASTEXITCODE -eq 0) { return $customPrompt }
+	}
+
+	$promptPath = $PWD.Path
+	if ($env:W) {
+		# If the current directory is W, replace with '.'. Else if it's a subdir, show just subpath.
+		if ($promptPath -eq $env:W) {
+			$promptPath = '.'
+		} elseif ($promptPath.StartsWith("$env:W/")) {
+			$promptPath = $promptPath.Substring($env:W.Length + 1)
+		}
+	}
+	if ($HOME -and $promptPath.StartsWith($HOME)) {
+		$promptPath = '~' + $promptPath.Substring($HOME.Length)
+	}
+	$esc = [char]27
+	"$esc[0;36mwash $promptPath$esc[0;32m ❯$esc[m "
+}
+`
+}
+
+// PowerShell equivalent of overrideCd.
+func powershellCd() string {
+	return `
+if (Test-Path Alias:cd) { Remove-Item Alias:cd -Force }
+function cd {
+	if ($args.Count -eq 0) { Set-Location $env:W } else { Set-Location @args }
+}
+`
+}
+
+// Register a completer that asks `wash complete` for Wash paths. If there aren't any, PowerShell
+// falls back to its default completion.
+func powershellCompletion(names string) string {
+	return `
+Register-ArgumentCompleter -Native -CommandName ` + names + ` -ScriptBlock {
+	param($wordToComplete, $commandAst, $cursorPosition)
+	$command = $commandAst.CommandElements[0].Value
+	wash complete --command $command -- $wordToComplete 2>$null | ForEach-Object {
+		[System.Management.Automation.CompletionResult]::new($_, $_, 'ProviderItem', $_)
+	}
+}
+`
+}
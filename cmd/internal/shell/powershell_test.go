@@ -0,0 +1,32 @@
+package shell
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPowershell(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "testPowershell")
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "Temporary directory required for further testing")
+	}
+	defer os.RemoveAll(tmpdir)
+
+	sh := powershell{sh: "/usr/local/bin/pwsh"}
+	comm, err := sh.Command([]string{"help", "wexec"}, tmpdir)
+	assert.NoError(t, err)
+	profile := filepath.Join(tmpdir, "profile.ps1")
+	assert.Contains(t, comm.Args, ". '"+profile+"'")
+
+	assert.FileExists(t, filepath.Join(tmpdir, "wexec"))
+	assert.FileExists(t, profile)
+	bits, err := ioutil.ReadFile(profile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(bits), "foreach ($name in @('help', 'wexec'))")
+	assert.Contains(t, string(bits), "function prompt")
+	assert.Contains(t, string(bits), "Register-ArgumentCompleter -Native -CommandName 'help', 'wexec'")
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 )
 
 type zsh struct {
@@ -59,10 +60,10 @@ fi
 	content += common
 
 	// Configure prompt and override `cd`
-	content += preparePrompt("%F{cyan}", "%F{green}", "%f", "PROMPT") + `
+	content += preparePrompt("zsh", "%F{cyan}", "%F{green}", "%f", "PROMPT") + `
 autoload -Uz add-zsh-hook
 add-zsh-hook precmd prompter
-` + overrideCd() + `
+` + overrideCd() + zshCompletion(subcommands) + runStartup(rundir) + `
 if [[ -s ~/.washrc ]]; then source ~/.washrc; fi
 `
 	if err := ioutil.WriteFile(filepath.Join(rundir, ".zshrc"), []byte(content), 0644); err != nil {
@@ -70,3 +71,32 @@ if [[ -s ~/.washrc ]]; then source ~/.washrc; fi
 	}
 	return cmd, nil
 }
+
+// StrictMode exits on the first failed command, including failures in a pipeline.
+func (z zsh) StrictMode() (string, error) {
+	return "setopt errexit pipefail\n", nil
+}
+
+// Create a completion function that asks `wash complete` for Wash paths. If there aren't any, zsh
+// falls back to completing files. Parents end in '/', so don't add a space after them. Subcommands
+// are aliases, so complete_aliases is needed for zsh to use the completion function for them.
+func zshCompletion(subcommands []string) string {
+	return `
+function _wash_complete() {
+	local -a completions
+	completions=("${(@f)$(wash complete --command "${words[1]}" -- "${words[CURRENT]}" 2>/dev/null)}")
+	completions=(${completions:#})
+	if (( ${#completions} == 0 )); then
+		_files
+		return
+	fi
+	compadd -Q -S '' -- ${(M)completions:#*/}
+	compadd -Q -- ${completions:#*/}
+}
+if (( ! $+functions[compdef] )); then
+	autoload -Uz compinit && compinit -u
+fi
+setopt complete_aliases
+compdef _wash_complete ` + strings.Join(subcommands, " ") + `
+`
+}
@@ -28,5 +28,9 @@ func TestZsh(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, string(bits), "alias help='WASH_EMBEDDED=1 wash help'")
 
-	assert.FileExists(t, filepath.Join(tmpdir, ".zshrc"))
+	rc := filepath.Join(tmpdir, ".zshrc")
+	assert.FileExists(t, rc)
+	bits, err = ioutil.ReadFile(rc)
+	assert.NoError(t, err)
+	assert.Contains(t, string(bits), "compdef _wash_complete help")
 }
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -10,6 +12,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/puppetlabs/wash/api/client"
 	apitypes "github.com/puppetlabs/wash/api/types"
 	cmdutil "github.com/puppetlabs/wash/cmd/util"
 	"github.com/puppetlabs/wash/plugin"
@@ -22,13 +25,63 @@ func lsCommand() *cobra.Command {
 		Long: `Lists the children of the specified paths, or current directory if
 no path is specified. If the -l option is set, then the name,
 last modified time, and supported actions are displayed for
-each child.`,
+each child, along with the partial metadata fields that are
+selected with --meta. Metadata keys are separated by '.', e.g.
+"--meta labels.app". Symlinks are displayed with their targets,
+e.g. "my-volume -> ../../../volumes/my-volume".
+
+Children are listed in the order that their plugin returns them
+unless -t or -S is set. Use -R to list the children of parents
+recursively (up to --depth levels), and --json to print the
+listed entries as a JSON array. Use --limit to list at most n
+children of each parent. The server then lists a single page of
+them instead of all of them, which is much faster for huge
+collections like S3 prefixes.
+
+Use --format to print each listed entry with a Go template
+instead. The template refers to the entry's fields by their
+JSON keys: name, cname, path, type_id, actions, attributes,
+symlink_target and metadata (the partial metadata), e.g.
+'{{.cname}} {{.attributes.size}} {{.metadata.State}}'. See
+'wash info --help' for the template's functions.
+
+Use -U (--stream) to print a huge directory's children as soon as
+the server lists them, which is much faster than waiting for all
+of them. The children aren't sorted, and -l separates their columns
+with tabs since they can't be aligned. -U only supports a single
+path, and can't be combined with -t, -S, -r, -R, --json or --limit.`,
 		RunE: toRunE(lsMain),
 	}
 	lsCmd.Flags().BoolP("long", "l", false, "List in long format")
+	lsCmd.Flags().StringArrayP("meta", "m", nil, "Include the given partial metadata field in the long format")
+	lsCmd.Flags().BoolP("sort-mtime", "t", false, "Sort by last modified time, newest first")
+	lsCmd.Flags().BoolP("sort-size", "S", false, "Sort by size, largest first")
+	lsCmd.Flags().BoolP("reverse", "r", false, "Reverse the sort order")
+	lsCmd.Flags().BoolP("recursive", "R", false, "List the children of parents recursively")
+	lsCmd.Flags().Int("depth", -1, "With -R, descend at most n levels below the paths")
+	lsCmd.Flags().Int("limit", 0, "List at most n children of each parent")
+	lsCmd.Flags().Bool("json", false, "Print the listed entries as JSON")
+	lsCmd.Flags().String("format", "", "Print each listed entry with the given Go template")
+	lsCmd.Flags().BoolP("stream", "U", false, "Print each child as soon as it's listed, without sorting or aligning them")
 	return lsCmd
 }
 
+// lsOptions are the options that affect how the items are printed
+type lsOptions struct {
+	long    bool
+	meta    []string
+	sortBy  string
+	reverse bool
+}
+
+// numColumns returns the number of columns in each row
+func (opts lsOptions) numColumns() int {
+	if !opts.long {
+		return 1
+	}
+	return 4 + len(opts.meta)
+}
+
 func formatTime(t time.Time) string {
 	return t.Format(time.RFC822)
 }
@@ -41,52 +94,199 @@ func cname(entry apitypes.Entry) string {
 	return cname
 }
 
+// sortEntries sorts entries by the given field. Entries that don't have
+// the field are listed last.
+func sortEntries(entries []apitypes.Entry, sortBy string, reverse bool) {
+	switch sortBy {
+	case "mtime":
+		sort.SliceStable(entries, func(i int, j int) bool {
+			a, b := entries[i].Attributes, entries[j].Attributes
+			if !a.HasMtime() || !b.HasMtime() {
+				return a.HasMtime()
+			}
+			return a.Mtime().After(b.Mtime())
+		})
+	case "size":
+		sort.SliceStable(entries, func(i int, j int) bool {
+			a, b := entries[i].Attributes, entries[j].Attributes
+			if !a.HasSize() || !b.HasSize() {
+				return a.HasSize()
+			}
+			return a.Size() > b.Size()
+		})
+	}
+	if reverse {
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+	}
+}
+
+func formatMeta(entry apitypes.Entry, key string) string {
+	v, ok := lookupKey(entry.Metadata, key)
+	if !ok {
+		return "-"
+	}
+	if str, ok := v.(string); ok {
+		return str
+	}
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(bytes)
+}
+
+// item should be a "file"/"dir" type item. itemEntries returns
+// the entries that are listed for that item
+func itemEntries(item lsItem, opts lsOptions) []apitypes.Entry {
+	if item.Type() != dirItem {
+		return []apitypes.Entry{item.entry}
+	}
+	entries := append([]apitypes.Entry{}, item.children...)
+	sortEntries(entries, opts.sortBy, opts.reverse)
+	return entries
+}
+
 // item should be a "file"/"dir" type item. formatItem returns
 // an array of rows representing that item's entries
-func formatItem(item lsItem, longFormat bool) [][]string {
-	var entries []apitypes.Entry
+func formatItem(item lsItem, opts lsOptions) [][]string {
+	entries := itemEntries(item, opts)
 	if item.Type() != dirItem {
 		// Print the path for "file" items. This is consistent
 		// with the built-in ls
-		item.entry.CName = item.path
-		entries = append(entries, item.entry)
-	} else {
-		entries = item.children
+		entries[0].CName = item.path
 	}
 
 	var rows [][]string
 	for _, entry := range entries {
-		var row []string
-
-		if !longFormat {
-			row = []string{cname(entry)}
-		} else {
-			mtimeStr, sizeStr := "<mtime unknown>", "<size unknown>"
-			if entry.Attributes.HasMtime() {
-				mtimeStr = formatTime(entry.Attributes.Mtime())
-			}
-			if entry.Attributes.HasSize() {
-				sizeStr = strconv.FormatUint(entry.Attributes.Size(), 10)
-			}
+		rows = append(rows, formatEntry(entry, opts))
+	}
 
-			sort.Strings(entry.Actions)
-			verbs := strings.Join(entry.Actions, ", ")
-			row = []string{verbs, sizeStr, mtimeStr, cname(entry)}
+	return rows
+}
+
+// formatEntry returns the row that represents the entry
+func formatEntry(entry apitypes.Entry, opts lsOptions) []string {
+	if !opts.long {
+		return []string{cname(entry)}
+	}
+
+	mtimeStr, sizeStr := "<mtime unknown>", "<size unknown>"
+	if entry.Attributes.HasMtime() {
+		mtimeStr = formatTime(entry.Attributes.Mtime())
+	}
+	if entry.Attributes.HasSize() {
+		sizeStr = strconv.FormatUint(entry.Attributes.Size(), 10)
+	}
+
+	sort.Strings(entry.Actions)
+	verbs := strings.Join(entry.Actions, ", ")
+	row := []string{verbs, sizeStr, mtimeStr}
+	for _, key := range opts.meta {
+		row = append(row, formatMeta(entry, key))
+	}
+	name := cname(entry)
+	if entry.SymlinkTarget != "" {
+		// This is consistent with the built-in ls
+		name += " -> " + entry.SymlinkTarget
+	}
+	return append(row, name)
+}
+
+// lsStream prints each of the path's children as soon as the server's listed
+// it. The rows can't be aligned because they're printed before all of them
+// are known, so their columns are separated by tabs instead.
+func lsStream(conn client.Client, path string, opts lsOptions, tmpl *cmdutil.Template) exitCode {
+	entry, err := conn.Info(path)
+	if err != nil {
+		cmdutil.ErrPrintf("ls: %v: %v\n", path, err)
+		return exitCode{1}
+	}
+	if !entry.Supports(plugin.ListAction()) {
+		entry.CName = path
+		if err := printStreamedEntry(entry, opts, tmpl); err != nil {
+			cmdutil.ErrPrintf("ls: %v: %v\n", path, err)
+			return exitCode{1}
 		}
+		return exitCode{0}
+	}
 
-		rows = append(rows, row)
+	packets, err := conn.ListStream(path)
+	if err != nil {
+		cmdutil.ErrPrintf("ls: %v: %v\n", path, err)
+		return exitCode{1}
 	}
+	ec := 0
+	for pkt := range packets {
+		if pkt.Err != nil {
+			cmdutil.ErrPrintf("ls: %v: %v\n", path, pkt.Err)
+			ec = 1
+			continue
+		}
+		if err := printStreamedEntry(*pkt.Entry, opts, tmpl); err != nil {
+			cmdutil.ErrPrintf("ls: %v: %v\n", pkt.Entry.Path, err)
+			ec = 1
+		}
+	}
+	return exitCode{ec}
+}
 
-	return rows
+func printStreamedEntry(entry apitypes.Entry, opts lsOptions, tmpl *cmdutil.Template) error {
+	if tmpl != nil {
+		out, err := tmpl.Format(entry)
+		if err != nil {
+			return err
+		}
+		cmdutil.Println(out)
+		return nil
+	}
+	cmdutil.Println(strings.Join(formatEntry(entry, opts), "\t"))
+	return nil
 }
 
 // Pads a row to ensure the same number of columns.
 // Note that the name is put at the beginning so directories are listed on the left.
-func pad(str string, longFormat bool) []string {
-	if longFormat {
-		return []string{str, "", "", ""}
+func pad(str string, opts lsOptions) []string {
+	row := make([]string, opts.numColumns())
+	row[0] = str
+	return row
+}
+
+// listChildren lists the children of path. If limit is positive, then it only
+// lists the first page of up to limit children.
+func listChildren(conn client.Client, path string, limit int) ([]apitypes.Entry, error) {
+	if limit <= 0 {
+		return conn.List(path)
 	}
-	return []string{str}
+	page, err := conn.ListPage(path, limit, "")
+	if err != nil {
+		return nil, err
+	}
+	return page.Entries, nil
+}
+
+// listRecursively returns the items for the descendants of the "dir" item
+// that are parents, in pre-order. It descends at most depth levels if depth
+// is not negative. Each parent's children are listed up to limit, like
+// listChildren.
+func listRecursively(conn client.Client, item lsItem, depth int, limit int) []lsItem {
+	if depth == 0 {
+		return nil
+	}
+	var items []lsItem
+	for _, child := range item.children {
+		if !child.Supports(plugin.ListAction()) {
+			continue
+		}
+		childItem := lsItem{path: filepath.Join(item.path, child.CName), entry: child}
+		childItem.children, childItem.err = listChildren(conn, childItem.path, limit)
+		items = append(items, childItem)
+		if childItem.err == nil {
+			items = append(items, listRecursively(conn, childItem, depth-1, limit)...)
+		}
+	}
+	return items
 }
 
 func lsMain(cmd *cobra.Command, args []string) exitCode {
@@ -94,12 +294,79 @@ func lsMain(cmd *cobra.Command, args []string) exitCode {
 	if len(args) > 0 {
 		paths = args
 	}
-	longFormat, err := cmd.Flags().GetBool("long")
+	var opts lsOptions
+	var err error
+	if opts.long, err = cmd.Flags().GetBool("long"); err != nil {
+		panic(err.Error())
+	}
+	if opts.meta, err = cmd.Flags().GetStringArray("meta"); err != nil {
+		panic(err.Error())
+	}
+	sortMtime, err := cmd.Flags().GetBool("sort-mtime")
+	if err != nil {
+		panic(err.Error())
+	}
+	sortSize, err := cmd.Flags().GetBool("sort-size")
+	if err != nil {
+		panic(err.Error())
+	}
+	switch {
+	case sortMtime && sortSize:
+		cmdutil.ErrPrintf("ls: -t and -S cannot be used together\n")
+		return exitCode{1}
+	case sortMtime:
+		opts.sortBy = "mtime"
+	case sortSize:
+		opts.sortBy = "size"
+	}
+	if opts.reverse, err = cmd.Flags().GetBool("reverse"); err != nil {
+		panic(err.Error())
+	}
+	recursive, err := cmd.Flags().GetBool("recursive")
+	if err != nil {
+		panic(err.Error())
+	}
+	depth, err := cmd.Flags().GetInt("depth")
+	if err != nil {
+		panic(err.Error())
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		panic(err.Error())
+	}
+	if limit < 0 {
+		cmdutil.ErrPrintf("ls: the limit must not be negative, got %v\n", limit)
+		return exitCode{1}
+	}
+	asJSON, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		panic(err.Error())
+	}
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		panic(err.Error())
+	}
+	var tmpl *cmdutil.Template
+	if format != "" {
+		if tmpl, err = cmdutil.NewTemplate(format); err != nil {
+			cmdutil.ErrPrintf("ls: %v\n", err)
+			return exitCode{1}
+		}
+	}
+
+	stream, err := cmd.Flags().GetBool("stream")
 	if err != nil {
 		panic(err.Error())
 	}
 
 	conn := cmdutil.NewClient()
+	if stream {
+		if len(paths) > 1 || opts.sortBy != "" || opts.reverse || recursive || asJSON || limit > 0 {
+			cmdutil.ErrPrintf("ls: -U only supports a single path, and can't be combined with -t, -S, -r, -R, --json or --limit\n")
+			return exitCode{1}
+		}
+		return lsStream(conn, paths[0], opts, tmpl)
+	}
 	items := make([]lsItem, len(paths))
 
 	// Fetch the required data
@@ -113,7 +380,7 @@ func lsMain(cmd *cobra.Command, args []string) exitCode {
 			item.path = path
 			item.entry, item.err = conn.Info(path)
 			if item.err == nil && item.Type() == dirItem {
-				item.children, item.err = conn.List(path)
+				item.children, item.err = listChildren(conn, path, limit)
 			}
 
 			items[ix] = item
@@ -138,6 +405,23 @@ func lsMain(cmd *cobra.Command, args []string) exitCode {
 		itemSlice[item.Type()] = append(itemSlice[item.Type()], item)
 	}
 	errorItems, fileItems, dirItems := itemSlice[errorItem], itemSlice[fileItem], itemSlice[dirItem]
+	if recursive {
+		var allDirItems []lsItem
+		for _, item := range dirItems {
+			allDirItems = append(allDirItems, item)
+			allDirItems = append(allDirItems, listRecursively(conn, item, depth, limit)...)
+		}
+		// Errors from listing descendants are printed with the
+		// other errors
+		dirItems = nil
+		for _, item := range allDirItems {
+			if item.err != nil {
+				errorItems = append(errorItems, item)
+			} else {
+				dirItems = append(dirItems, item)
+			}
+		}
+	}
 
 	// Print the items out. Start with the "error" items.
 	ec := 0
@@ -145,25 +429,55 @@ func lsMain(cmd *cobra.Command, args []string) exitCode {
 		ec = 1
 		cmdutil.ErrPrintf("ls: %v: %v\n", item.path, item.err)
 	}
+
+	if asJSON {
+		entries := []apitypes.Entry{}
+		for _, item := range append(fileItems, dirItems...) {
+			entries = append(entries, itemEntries(item, opts)...)
+		}
+		bytes, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			cmdutil.ErrPrintf("ls: could not marshal the entries: %v\n", err)
+			return exitCode{1}
+		}
+		cmdutil.Println(string(bytes))
+		return exitCode{ec}
+	}
+
+	if tmpl != nil {
+		for _, item := range append(fileItems, dirItems...) {
+			for _, entry := range itemEntries(item, opts) {
+				out, err := tmpl.Format(entry)
+				if err != nil {
+					ec = 1
+					cmdutil.ErrPrintf("ls: %v: %v\n", entry.Path, err)
+					continue
+				}
+				cmdutil.Println(out)
+			}
+		}
+		return exitCode{ec}
+	}
+
 	// Now print the "file"/"dir" items as a table to maintain
 	// consistent padding. To do that, we'll need to generate
 	// the table's rows. Start with the "file" items
 	var rows [][]string
 	for _, item := range fileItems {
-		rows = append(rows, formatItem(item, longFormat)...)
+		rows = append(rows, formatItem(item, opts)...)
 	}
 	// Now move on to the "dir" items
-	newline := pad("", longFormat)
-	if len(items) != len(dirItems) {
+	newline := pad("", opts)
+	if len(errorItems)+len(fileItems) > 0 {
 		// An "error"/"file" item was printed so include a newline
 		rows = append(rows, newline)
 	}
-	multiplePaths := len(items) > 1
+	multiplePaths := len(items) > 1 || len(dirItems) > 1
 	for ix, item := range dirItems {
 		if multiplePaths {
-			rows = append(rows, pad(fmt.Sprintf("%v:", item.path), longFormat))
+			rows = append(rows, pad(fmt.Sprintf("%v:", item.path), opts))
 		}
-		rows = append(rows, formatItem(item, longFormat)...)
+		rows = append(rows, formatItem(item, opts)...)
 		if ix != (len(dirItems) - 1) {
 			rows = append(rows, newline)
 		}
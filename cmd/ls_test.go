@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/puppetlabs/wash/api/client"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func lsTestEntry(cname string, size uint64, mtime time.Time) apitypes.Entry {
+	var attr plugin.EntryAttributes
+	if size > 0 {
+		attr.SetSize(size)
+	}
+	if !mtime.IsZero() {
+		attr.SetMtime(mtime)
+	}
+	return apitypes.Entry{CName: cname, Actions: []string{"read"}, Attributes: attr}
+}
+
+func cnames(entries []apitypes.Entry) []string {
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.CName)
+	}
+	return names
+}
+
+func TestSortEntries(t *testing.T) {
+	now := time.Now()
+	newEntries := func() []apitypes.Entry {
+		return []apitypes.Entry{
+			lsTestEntry("old", 20, now.Add(-time.Hour)),
+			lsTestEntry("unknown", 0, time.Time{}),
+			lsTestEntry("new", 10, now),
+		}
+	}
+
+	entries := newEntries()
+	sortEntries(entries, "", false)
+	assert.Equal(t, []string{"old", "unknown", "new"}, cnames(entries))
+
+	// Entries without the field are listed last
+	entries = newEntries()
+	sortEntries(entries, "mtime", false)
+	assert.Equal(t, []string{"new", "old", "unknown"}, cnames(entries))
+
+	entries = newEntries()
+	sortEntries(entries, "size", false)
+	assert.Equal(t, []string{"old", "new", "unknown"}, cnames(entries))
+
+	entries = newEntries()
+	sortEntries(entries, "size", true)
+	assert.Equal(t, []string{"unknown", "new", "old"}, cnames(entries))
+}
+
+func TestFormatMeta(t *testing.T) {
+	entry := apitypes.Entry{Metadata: map[string]interface{}{
+		"State":  "running",
+		"labels": map[string]interface{}{"app": "web"},
+		"ports":  []interface{}{float64(80), float64(443)},
+	}}
+	assert.Equal(t, "running", formatMeta(entry, "State"))
+	assert.Equal(t, "web", formatMeta(entry, "labels.app"))
+	assert.Equal(t, "[80,443]", formatMeta(entry, "ports"))
+	assert.Equal(t, "-", formatMeta(entry, "labels.missing"))
+}
+
+func TestFormatEntry(t *testing.T) {
+	entry := apitypes.Entry{
+		CName:    "foo",
+		Actions:  []string{"read", "list"},
+		Metadata: map[string]interface{}{"State": "running"},
+	}
+	assert.Equal(t, []string{"foo/"}, formatEntry(entry, lsOptions{}))
+
+	opts := lsOptions{long: true, meta: []string{"State", "missing"}}
+	expected := []string{"list, read", "<size unknown>", "<mtime unknown>", "running", "-", "foo/"}
+	assert.Equal(t, expected, formatEntry(entry, opts))
+	assert.Len(t, expected, opts.numColumns())
+
+	entry = apitypes.Entry{CName: "my-volume", SymlinkTarget: "../../volumes/my-volume"}
+	assert.Equal(t, "my-volume -> ../../volumes/my-volume", formatEntry(entry, lsOptions{long: true})[3])
+}
+
+func TestListRecursively(t *testing.T) {
+	conn := &cmdtest.MockClient{}
+	conn.On("List", "dir/a").Return([]apitypes.Entry{{CName: "b", Actions: []string{"list"}}}, nil)
+	conn.On("List", "dir/a/b").Return([]apitypes.Entry{}, nil)
+	conn.On("List", "dir/c").Return([]apitypes.Entry{}, errors.New("list failed"))
+	item := lsItem{
+		path:  "dir",
+		entry: apitypes.Entry{Actions: []string{"list"}},
+		children: []apitypes.Entry{
+			{CName: "a", Actions: []string{"list"}},
+			{CName: "file", Actions: []string{"read"}},
+			{CName: "c", Actions: []string{"list"}},
+		},
+	}
+
+	items := listRecursively(conn, item, -1, 0)
+	if assert.Len(t, items, 3) {
+		assert.Equal(t, "dir/a", items[0].path)
+		assert.Equal(t, "dir/a/b", items[1].path)
+		assert.Equal(t, "dir/c", items[2].path)
+		assert.EqualError(t, items[2].err, "list failed")
+	}
+
+	// Depth limits the descendants that are listed
+	items = listRecursively(conn, item, 1, 0)
+	assert.Len(t, items, 2)
+	conn.AssertNumberOfCalls(t, "List", 5)
+
+	assert.Empty(t, listRecursively(conn, item, 0, 0))
+}
+
+func TestListChildren_Limit(t *testing.T) {
+	conn := &cmdtest.MockClient{}
+	page := apitypes.ListPage{Entries: []apitypes.Entry{{CName: "a"}}, ContinuationToken: "a"}
+	conn.On("ListPage", "dir", 1, "").Return(page, nil)
+	children, err := listChildren(conn, "dir", 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, page.Entries, children)
+	}
+	conn.AssertNotCalled(t, "List", mock.Anything)
+
+	conn.On("ListPage", "bad", 1, "").Return(apitypes.ListPage{}, errors.New("list failed"))
+	_, err = listChildren(conn, "bad", 1)
+	assert.EqualError(t, err, "list failed")
+}
+
+func setupLsTest(conn *cmdtest.MockClient) func() {
+	origNewClient := cmdutil.NewClient
+	cmdutil.NewClient = func() client.Client { return conn }
+	return func() { cmdutil.NewClient = origNewClient }
+}
+
+func TestLsMain_JSON(t *testing.T) {
+	conn := &cmdtest.MockClient{}
+	defer setupLsTest(conn)()
+	stdout, _, restore := captureOutput()
+	defer restore()
+
+	conn.On("Info", "dir").Return(apitypes.Entry{CName: "dir", Actions: []string{"list"}}, nil)
+	conn.On("List", "dir").Return([]apitypes.Entry{
+		{CName: "small", Actions: []string{"read"}, Attributes: sizeAttr(1)},
+		{CName: "big", Actions: []string{"read"}, Attributes: sizeAttr(100)},
+	}, nil)
+
+	cmd := lsCommand()
+	assert.NoError(t, cmd.Flags().Set("json", "true"))
+	assert.NoError(t, cmd.Flags().Set("sort-size", "true"))
+	assert.Equal(t, exitCode{0}, lsMain(cmd, []string{"dir"}))
+	var entries []apitypes.Entry
+	if assert.NoError(t, json.Unmarshal(stdout.Bytes(), &entries)) {
+		assert.Equal(t, []string{"big", "small"}, cnames(entries))
+	}
+}
+
+func TestLsMain_Limit(t *testing.T) {
+	conn := &cmdtest.MockClient{}
+	defer setupLsTest(conn)()
+	stdout, _, restore := captureOutput()
+	defer restore()
+
+	conn.On("Info", "dir").Return(apitypes.Entry{CName: "dir", Actions: []string{"list"}}, nil)
+	page := apitypes.ListPage{Entries: []apitypes.Entry{{CName: "a", Actions: []string{"read"}}}, ContinuationToken: "a"}
+	conn.On("ListPage", "dir", 1, "").Return(page, nil)
+
+	cmd := lsCommand()
+	assert.NoError(t, cmd.Flags().Set("limit", "1"))
+	assert.Equal(t, exitCode{0}, lsMain(cmd, []string{"dir"}))
+	assert.Regexp(t, `^a\s*$`, stdout.String())
+	conn.AssertNotCalled(t, "List", mock.Anything)
+}
+
+func TestLsMain_InvalidFlags(t *testing.T) {
+	conn := &cmdtest.MockClient{}
+	defer setupLsTest(conn)()
+	_, stderr, restore := captureOutput()
+	defer restore()
+
+	cmd := lsCommand()
+	assert.NoError(t, cmd.Flags().Set("limit", "-1"))
+	assert.Equal(t, exitCode{1}, lsMain(cmd, []string{"dir"}))
+	assert.Contains(t, stderr.String(), "ls: the limit must not be negative, got -1")
+
+	cmd = lsCommand()
+	assert.NoError(t, cmd.Flags().Set("sort-mtime", "true"))
+	assert.NoError(t, cmd.Flags().Set("sort-size", "true"))
+	assert.Equal(t, exitCode{1}, lsMain(cmd, []string{"dir"}))
+	assert.Contains(t, stderr.String(), "ls: -t and -S cannot be used together")
+
+	cmd = lsCommand()
+	assert.NoError(t, cmd.Flags().Set("stream", "true"))
+	assert.NoError(t, cmd.Flags().Set("limit", "1"))
+	assert.Equal(t, exitCode{1}, lsMain(cmd, []string{"dir"}))
+	assert.Contains(t, stderr.String(), "ls: -U only supports a single path")
+	conn.AssertNotCalled(t, "Info", mock.Anything)
+}
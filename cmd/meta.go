@@ -14,12 +14,29 @@ func metaCommand() *cobra.Command {
 		Long: `Prints the metadata of the given entries. By default, meta prints the
 full metadata as returned by the metadata endpoint. Specify the
 --partial flag to instead print the partial metadata, a (possibly)
-reduced set of metadata that's returned when entries are enumerated.`,
+reduced set of metadata that's returned when entries are enumerated.
+
+Specify the --query flag to only print the values that are selected by a
+jq-like path expression. Use .key to select an object's key, ."key" or
+["key"] for keys with special characters, [n] to select an array's nth
+element, and [] to select every element of an array. For example,
+'.State.Status' or '.Mounts[].Source'. Queries with [] print the
+list of selected values.
+
+The tsv output format prints strings without quotes and arrays one
+element per line, which is convenient for scripts.
+
+Specify the --decompress flag to also print the compression format and
+decompressed size of entries whose content is gzip or zstd compressed.`,
+		Example: `meta docker/containers/redis -q .State.Status -o tsv
+  print the status of the redis container`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: toRunE(metaMain),
 	}
-	metaCmd.Flags().StringP("output", "o", "yaml", "Set the output format (json, yaml, or text)")
+	metaCmd.Flags().StringP("output", "o", "yaml", "Set the output format (json, yaml, text, toml, or tsv)")
 	metaCmd.Flags().BoolP("partial", "p", false, "Print the partial metadata instead")
+	metaCmd.Flags().StringP("query", "q", "", "Only print the values selected by the given jq-like path expression")
+	metaCmd.Flags().BoolP("decompress", "z", false, "Include the compression and decompressed_size of compressed content")
 	return metaCmd
 }
 
@@ -34,14 +51,41 @@ func metaMain(cmd *cobra.Command, args []string) exitCode {
 		panic(err.Error())
 	}
 
+	queryExpr, err := cmd.Flags().GetString("query")
+	if err != nil {
+		panic(err.Error())
+	}
+	decompress, err := cmd.Flags().GetBool("decompress")
+	if err != nil {
+		panic(err.Error())
+	}
+	if decompress && showPartialMetadata {
+		cmdutil.ErrPrintf("--decompress can't be used with --partial\n")
+		return exitCode{1}
+	}
+
 	marshaller, err := cmdutil.NewMarshaller(output)
 	if err != nil {
 		cmdutil.ErrPrintf(err.Error())
 		return exitCode{1}
 	}
 
+	var query *cmdutil.Query
+	if queryExpr != "" {
+		q, err := cmdutil.NewQuery(queryExpr)
+		if err != nil {
+			cmdutil.ErrPrintf("%v\n", err)
+			return exitCode{1}
+		}
+		query = &q
+	}
+
 	conn := cmdutil.NewClient()
-	metadataMap := make(map[string]map[string]interface{})
+	getMetadata := conn.Metadata
+	if decompress {
+		getMetadata = conn.DecompressedMetadata
+	}
+	metadataMap := make(map[string]interface{})
 
 	// Fetch the data.
 	ec := 0
@@ -64,7 +108,7 @@ func metaMain(cmd *cobra.Command, args []string) exitCode {
 				metadata = e.Metadata
 			} else {
 				var err error
-				metadata, err = conn.Metadata(path)
+				metadata, err = getMetadata(path)
 				if err != nil {
 					ec = 1
 					cmdutil.SafeErrPrintf("%v: %v\n", path, err)
@@ -72,8 +116,23 @@ func metaMain(cmd *cobra.Command, args []string) exitCode {
 				}
 			}
 
+			var result interface{} = metadata
+			if query != nil {
+				values, err := query.Eval(metadata)
+				if err != nil {
+					ec = 1
+					cmdutil.SafeErrPrintf("%v: %v\n", path, err)
+					return
+				}
+				if query.Iterates() {
+					result = values
+				} else {
+					result = values[0]
+				}
+			}
+
 			metadataMapMux.Lock()
-			metadataMap[path] = metadata
+			metadataMap[path] = result
 			metadataMapMux.Unlock()
 		}(path)
 	}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/puppetlabs/wash/api/client"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func mkdirCommand() *cobra.Command {
+	use, aliases := generateShellAlias("mkdir")
+	mkdirCmd := &cobra.Command{
+		Use:     use + " [-p] <path>...",
+		Aliases: aliases,
+		Short:   "Creates directories at the specified paths",
+		Long: `Creates a directory at each path. A wash directory is created by its parent's
+create action, so its parent must support it. For example, S3 and Storage
+prefixes are created as empty "<name>/" objects. This works without going
+through the FUSE mount. Local paths are created like the system's mkdir does.
+
+Use -p to also create the missing parents, and to ignore paths that are
+already directories.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: toRunE(mkdirMain),
+	}
+	mkdirCmd.Flags().BoolP("parents", "p", false, "Create the missing parents, and ignore existing directories")
+
+	return mkdirCmd
+}
+
+func mkdirMain(cmd *cobra.Command, args []string) exitCode {
+	parents, err := cmd.Flags().GetBool("parents")
+	if err != nil {
+		panic(err.Error())
+	}
+
+	conn := cmdutil.NewClient()
+	ec := 0
+	for _, path := range args {
+		if err := mkdir(conn, path, parents); err != nil {
+			cmdutil.ErrPrintf("%v\n", err)
+			ec = 1
+		}
+	}
+	return exitCode{ec}
+}
+
+// mkdir creates a directory at path. If parents is true, then it also creates
+// path's missing parents, and it doesn't fail if path is already a directory.
+func mkdir(conn client.Client, path string, parents bool) error {
+	if !cmdutil.IsWashPath(path) {
+		if parents {
+			return os.MkdirAll(path, 0750)
+		}
+		return os.Mkdir(path, 0750)
+	}
+
+	if e, err := conn.Info(path); err == nil {
+		if !parents {
+			return fmt.Errorf("%v: already exists", path)
+		}
+		if !e.Supports(plugin.ListAction()) {
+			return fmt.Errorf("%v: not a directory", path)
+		}
+		return nil
+	}
+	// We can't tell if Info's error is a "not found" error, so treat the
+	// entry as missing. Creating it will return a better error.
+	if parents {
+		if err := mkdir(conn, filepath.Dir(path), true); err != nil {
+			return err
+		}
+	}
+	var attr plugin.EntryAttributes
+	attr.SetMode(os.ModeDir | 0750)
+	_, err := createWashEntry(conn, path, attr)
+	return err
+}
+
+// createWashEntry creates the wash entry at path through its parent's create
+// action. attr is the entry's attributes; it's a parent if attr's mode is a
+// directory.
+func createWashEntry(conn client.Client, path string, attr plugin.EntryAttributes) (apitypes.Entry, error) {
+	parent, name := filepath.Dir(path), filepath.Base(path)
+	e, err := conn.Create(parent, name, attr)
+	if err != nil {
+		return e, fmt.Errorf("could not create %v: %v", path, err)
+	}
+	return e, nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func dirAttr() plugin.EntryAttributes {
+	var attr plugin.EntryAttributes
+	attr.SetMode(os.ModeDir | 0750)
+	return attr
+}
+
+func TestMkdir_Wash(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	conn := &cmdtest.MockClient{}
+	notFound := errors.New("not found")
+	conn.On("Info", "/wash/s3/bucket/reports").Return(apitypes.Entry{}, notFound)
+	conn.On("Create", "/wash/s3/bucket", "reports", dirAttr()).Return(apitypes.Entry{CName: "reports"}, nil)
+	assert.NoError(t, mkdir(conn, "/wash/s3/bucket/reports", false))
+	conn.AssertExpectations(t)
+
+	conn = &cmdtest.MockClient{}
+	conn.On("Info", "/wash/s3/bucket").Return(apitypes.Entry{Actions: []string{"list", "create"}}, nil)
+	assert.EqualError(t, mkdir(conn, "/wash/s3/bucket", false), "/wash/s3/bucket: already exists")
+	assert.NoError(t, mkdir(conn, "/wash/s3/bucket", true))
+	conn.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+
+	conn = &cmdtest.MockClient{}
+	conn.On("Info", "/wash/s3/bucket/a.txt").Return(apitypes.Entry{Actions: []string{"read"}}, nil)
+	assert.EqualError(t, mkdir(conn, "/wash/s3/bucket/a.txt", true), "/wash/s3/bucket/a.txt: not a directory")
+}
+
+func TestMkdir_WashParents(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	conn := &cmdtest.MockClient{}
+	notFound := errors.New("not found")
+	conn.On("Info", "/wash/s3/bucket/a/b").Return(apitypes.Entry{}, notFound)
+	conn.On("Info", "/wash/s3/bucket/a").Return(apitypes.Entry{}, notFound)
+	conn.On("Info", "/wash/s3/bucket").Return(apitypes.Entry{Actions: []string{"list", "create"}}, nil)
+	conn.On("Create", "/wash/s3/bucket", "a", dirAttr()).Return(apitypes.Entry{CName: "a"}, nil).Once()
+	conn.On("Create", "/wash/s3/bucket/a", "b", dirAttr()).Return(apitypes.Entry{CName: "b"}, nil).Once()
+	assert.NoError(t, mkdir(conn, "/wash/s3/bucket/a/b", true))
+	conn.AssertExpectations(t)
+}
+
+func TestMkdir_CreateFails(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	conn := &cmdtest.MockClient{}
+	conn.On("Info", "/wash/docker/containers/foo").Return(apitypes.Entry{}, errors.New("not found"))
+	conn.On("Create", "/wash/docker/containers", "foo", dirAttr()).Return(apitypes.Entry{}, errors.New("the create action is not supported"))
+	err := mkdir(conn, "/wash/docker/containers/foo", false)
+	assert.EqualError(t, err, "could not create /wash/docker/containers/foo: the create action is not supported")
+}
+
+func TestMkdir_Local(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "testMkdir")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(tmpdir)
+
+	conn := &cmdtest.MockClient{}
+	dir := filepath.Join(tmpdir, "a", "b")
+	assert.Error(t, mkdir(conn, dir, false))
+	assert.NoError(t, mkdir(conn, dir, true))
+	assert.DirExists(t, dir)
+	assert.NoError(t, mkdir(conn, dir, true))
+}
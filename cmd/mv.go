@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func mvCommand() *cobra.Command {
+	use, aliases := generateShellAlias("mv")
+	mvCmd := &cobra.Command{
+		Use:     use + " [-f] <source>... <dest>",
+		Aliases: aliases,
+		Short:   "Moves entries between wash paths and local paths",
+		Long: `Moves each source to dest. Sources and dest can be any combination of wash
+paths and local paths. If there are multiple sources or dest is a directory, then
+each source is moved into dest.
+
+Local paths are renamed, as are wash entries that support the rename action when
+dest is in the same directory as the source. Everything else is moved by copying
+the source to dest (see "wash cp"), which creates a missing wash dest, and then
+deleting the source. The source is only deleted if the entire copy succeeded,
+and wash sources must support the delete action. mv prompts for confirmation
+before overwriting an existing dest unless -f is set.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: toRunE(mvMain),
+	}
+	mvCmd.Flags().BoolP("force", "f", false, "Do not prompt before overwriting an existing dest")
+	mvCmd.Flags().BoolP("quiet", "q", false, "Do not print the moved files or the move's progress")
+
+	return mvCmd
+}
+
+func mvMain(cmd *cobra.Command, args []string) exitCode {
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		panic(err.Error())
+	}
+	quiet, err := cmd.Flags().GetBool("quiet")
+	if err != nil {
+		panic(err.Error())
+	}
+
+	sources := args[:len(args)-1]
+	dest := args[len(args)-1]
+
+	c := &copier{
+		conn:      cmdutil.NewClient(),
+		recursive: true,
+		quiet:     quiet,
+	}
+
+	_, destIsDir, err := c.stat(dest)
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	if len(sources) > 1 && !destIsDir {
+		cmdutil.ErrPrintf("%v is not a directory\n", dest)
+		return exitCode{1}
+	}
+
+	ec := 0
+	for _, src := range sources {
+		dst := dest
+		if destIsDir {
+			dst = filepath.Join(dest, filepath.Base(src))
+		}
+		if err := move(c, src, dst, force); err != nil {
+			cmdutil.ErrPrintf("%v\n", err)
+			ec = 1
+		}
+	}
+	return exitCode{ec}
+}
+
+func move(c *copier, src string, dst string, force bool) error {
+	absSrc, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	absDst, err := filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+	if absSrc == absDst {
+		return fmt.Errorf("%v and %v are the same", src, dst)
+	}
+	if strings.HasPrefix(absDst, absSrc+string(filepath.Separator)) {
+		return fmt.Errorf("cannot move %v into itself", src)
+	}
+
+	// Do the safety checks before anything's copied
+	srcIsWash := cmdutil.IsWashPath(src)
+	renameable, deletable := false, true
+	if srcIsWash {
+		e, err := c.conn.Info(src)
+		if err != nil {
+			return fmt.Errorf("%v: %v", src, err)
+		}
+		// Entries can only be renamed within their parent.
+		renameable = e.Supports(plugin.RenameAction()) &&
+			cmdutil.IsWashPath(dst) &&
+			filepath.Dir(absSrc) == filepath.Dir(absDst)
+		deletable = e.Supports(plugin.DeleteAction())
+		if !renameable && !deletable {
+			return fmt.Errorf("cannot move %v: it does not support the delete action", src)
+		}
+	}
+	exists, _, err := c.stat(dst)
+	if err != nil {
+		return err
+	}
+	if exists && !deletable {
+		// Renaming can't replace dest, so the source would be copied instead.
+		return fmt.Errorf("cannot move %v onto an existing %v: it does not support the delete action", src, dst)
+	}
+	if exists && !force && plugin.IsInteractive() {
+		input, err := cmdutil.Prompt(fmt.Sprintf("overwrite %v?", dst), cmdutil.YesOrNoP)
+		if err != nil {
+			return fmt.Errorf("failed to get confirmation: %v", err)
+		}
+		if !input.(bool) {
+			return nil
+		}
+	}
+
+	if renameable && !exists {
+		if err := c.conn.Rename(src, filepath.Base(absDst)); err != nil {
+			return fmt.Errorf("could not rename %v to %v: %v", src, dst, err)
+		}
+		if !c.quiet {
+			cmdutil.Printf("%v -> %v\n", src, dst)
+		}
+		return nil
+	}
+
+	if !srcIsWash && !cmdutil.IsWashPath(dst) {
+		if err := os.Rename(src, dst); err == nil {
+			if !c.quiet {
+				cmdutil.Printf("%v -> %v\n", src, dst)
+			}
+			return nil
+		}
+		// The rename fails if src and dst are on different filesystems, so
+		// fallback to copying.
+	}
+
+	if err := c.copy(src, dst); err != nil {
+		return fmt.Errorf("%v\n%v was not deleted because it could not be copied", err, src)
+	}
+	if !srcIsWash {
+		return os.RemoveAll(src)
+	}
+	deleted, err := c.conn.Delete(src)
+	if err != nil {
+		return fmt.Errorf("%v was copied to %v, but could not be deleted: %v", src, dst, err)
+	}
+	if !deleted {
+		cmdutil.Printf("%v has been marked for deletion and will eventually be deleted\n", src)
+	}
+	return nil
+}
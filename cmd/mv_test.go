@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+)
+
+func TestMove_InvalidPaths(t *testing.T) {
+	c := &copier{conn: &cmdtest.MockClient{}, recursive: true, quiet: true}
+	assert.EqualError(t, move(c, "/tmp/a", "/tmp/a", false), "/tmp/a and /tmp/a are the same")
+	assert.EqualError(t, move(c, "/tmp/a", "/tmp/a/b", false), "cannot move /tmp/a into itself")
+}
+
+func TestMove_RenamesWithinParent(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	conn := &cmdtest.MockClient{}
+	c := &copier{conn: conn, recursive: true, quiet: true}
+	conn.On("Info", "/wash/s3/bucket/a.txt").Return(apitypes.Entry{Actions: []string{"read", "rename", "delete"}}, nil)
+	conn.On("Info", "/wash/s3/bucket/b.txt").Return(apitypes.Entry{}, errors.New("not found"))
+	conn.On("Rename", "/wash/s3/bucket/a.txt", "b.txt").Return(nil)
+	assert.NoError(t, move(c, "/wash/s3/bucket/a.txt", "/wash/s3/bucket/b.txt", false))
+	conn.AssertExpectations(t)
+	conn.AssertNotCalled(t, "Copy", mock.Anything, mock.Anything)
+	conn.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestMove_CopiesThenDeletes(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	conn := &cmdtest.MockClient{}
+	c := &copier{conn: conn, recursive: true, quiet: true}
+	conn.On("Info", "/wash/s3/bucket/a.txt").Return(apitypes.Entry{Actions: []string{"read", "rename", "delete"}}, nil)
+	conn.On("Info", "/wash/s3/other/a.txt").Return(apitypes.Entry{}, errors.New("not found"))
+	conn.On("Create", "/wash/s3/other", "a.txt", fileAttr()).Return(apitypes.Entry{CName: "a.txt"}, nil)
+	conn.On("Copy", "/wash/s3/bucket/a.txt", "/wash/s3/other/a.txt").Return(nil)
+	conn.On("Delete", "/wash/s3/bucket/a.txt").Return(true, nil)
+	assert.NoError(t, move(c, "/wash/s3/bucket/a.txt", "/wash/s3/other/a.txt", false))
+	conn.AssertExpectations(t)
+	conn.AssertNotCalled(t, "Rename", mock.Anything, mock.Anything)
+}
+
+func TestMove_DoesNotDeleteIfCopyFails(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	conn := &cmdtest.MockClient{}
+	c := &copier{conn: conn, recursive: true, quiet: true}
+	conn.On("Info", "/wash/s3/bucket/a.txt").Return(apitypes.Entry{Actions: []string{"read", "delete"}}, nil)
+	conn.On("Info", "/wash/s3/other/a.txt").Return(apitypes.Entry{Actions: []string{"read", "write"}}, nil)
+	conn.On("Copy", "/wash/s3/bucket/a.txt", "/wash/s3/other/a.txt").Return(errors.New("access denied"))
+	err := move(c, "/wash/s3/bucket/a.txt", "/wash/s3/other/a.txt", true)
+	assert.EqualError(t, err, "could not copy /wash/s3/bucket/a.txt to /wash/s3/other/a.txt: access denied\n/wash/s3/bucket/a.txt was not deleted because it could not be copied")
+	conn.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestMove_RequiresDelete(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	conn := &cmdtest.MockClient{}
+	c := &copier{conn: conn, recursive: true, quiet: true}
+	conn.On("Info", "/wash/docker/containers/foo/log").Return(apitypes.Entry{Actions: []string{"read"}}, nil)
+	err := move(c, "/wash/docker/containers/foo/log", "/tmp/log", false)
+	assert.EqualError(t, err, "cannot move /wash/docker/containers/foo/log: it does not support the delete action")
+
+	// Renaming can't replace an existing dest
+	conn = &cmdtest.MockClient{}
+	c.conn = conn
+	conn.On("Info", "/wash/s3/bucket/a.txt").Return(apitypes.Entry{Actions: []string{"read", "rename"}}, nil)
+	conn.On("Info", "/wash/s3/bucket/b.txt").Return(apitypes.Entry{Actions: []string{"read", "write"}}, nil)
+	err = move(c, "/wash/s3/bucket/a.txt", "/wash/s3/bucket/b.txt", true)
+	assert.EqualError(t, err, "cannot move /wash/s3/bucket/a.txt onto an existing /wash/s3/bucket/b.txt: it does not support the delete action")
+	conn.AssertNotCalled(t, "Rename", mock.Anything, mock.Anything)
+}
+
+func TestMove_Local(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "testMove")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(tmpdir)
+	src, dst := filepath.Join(tmpdir, "a.txt"), filepath.Join(tmpdir, "b.txt")
+	if !assert.NoError(t, ioutil.WriteFile(src, []byte("hello"), 0640)) {
+		return
+	}
+
+	conn := &cmdtest.MockClient{}
+	c := &copier{conn: conn, recursive: true, quiet: true}
+	if assert.NoError(t, move(c, src, dst, false)) {
+		assert.NoFileExists(t, src)
+		content, err := ioutil.ReadFile(dst)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(content))
+	}
+	conn.AssertNotCalled(t, "Info", mock.Anything)
+}
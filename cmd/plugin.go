@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func pluginCommand() *cobra.Command {
+	pluginCmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Lists, enables and disables the running server's plugins",
+		Long: `Lists the running Wash server's plugins and whether they're enabled. Use the enable and disable
+subcommands to add or remove a plugin's subtree without restarting the server, e.g. to take a
+misbehaving provider out of the tree. Disabling a plugin doesn't change your config, so it's
+enabled again once the server restarts.`,
+		Args: cobra.NoArgs,
+		RunE: toRunE(pluginListMain),
+	}
+	pluginCmd.AddCommand(&cobra.Command{
+		Use:   "enable <name>...",
+		Short: "Enables the given plugins",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  toRunE(pluginEnableMain),
+	})
+	pluginCmd.AddCommand(&cobra.Command{
+		Use:   "disable <name>...",
+		Short: "Disables the given plugins",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  toRunE(pluginDisableMain),
+	})
+	return pluginCmd
+}
+
+func pluginListMain(cmd *cobra.Command, args []string) exitCode {
+	conn := cmdutil.NewClient()
+	plugins, err := conn.Plugins()
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	cmdutil.Print(formatPlugins(plugins))
+	return exitCode{0}
+}
+
+func formatPlugins(plugins []plugin.PluginStatus) string {
+	rows := make([][]string, len(plugins))
+	for i, p := range plugins {
+		status := "enabled"
+		if !p.Enabled {
+			status = "disabled"
+		}
+		rows[i] = []string{p.Name, status}
+	}
+	headers := []cmdutil.ColumnHeader{
+		{ShortName: "name", FullName: "NAME"},
+		{ShortName: "status", FullName: "STATUS"},
+	}
+	return cmdutil.NewTableWithHeaders(headers, rows).Format()
+}
+
+func pluginEnableMain(cmd *cobra.Command, args []string) exitCode {
+	conn := cmdutil.NewClient()
+	return setPluginsEnabled(args, conn.EnablePlugin, "enabled")
+}
+
+func pluginDisableMain(cmd *cobra.Command, args []string) exitCode {
+	conn := cmdutil.NewClient()
+	return setPluginsEnabled(args, conn.DisablePlugin, "disabled")
+}
+
+func setPluginsEnabled(names []string, set func(string) error, verb string) exitCode {
+	ec := 0
+	for _, name := range names {
+		if err := set(name); err != nil {
+			ec = 1
+			cmdutil.ErrPrintf("%v: %v\n", name, err)
+		} else {
+			cmdutil.Printf("%v %v\n", verb, name)
+		}
+	}
+	return exitCode{ec}
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/puppetlabs/wash/api/client"
+	"github.com/puppetlabs/wash/cmd/internal/config"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func promptCommand() *cobra.Command {
+	promptCmd := &cobra.Command{
+		Use:   "prompt --shell <shell>",
+		Short: "Prints the Wash shell's prompt",
+		Long: `Renders the prompt.format config with the current directory's Wash context. The Wash shell's
+prompter calls prompt when prompt.format is set; it's not meant to be invoked directly.
+
+The format is a Go template (https://golang.org/pkg/text/template) that can refer to
+  * .path, the current directory relative to the Wash root
+  * .plugin, the plugin the current directory is in
+  * .context, the path segment after the plugin, which is usually the profile, project or
+    Kubernetes context
+  * .segments, the current directory's path segments below the Wash root
+  * .destructive, true if the current directory or any of its children can be deleted or signalled
+  * .colors.<color>, the shell's escape sequence for red, green, yellow, blue, magenta, cyan or
+    reset`,
+		Args:   cobra.NoArgs,
+		Hidden: true,
+		RunE:   toRunE(promptMain),
+	}
+	promptCmd.Flags().String("shell", "", "The shell the prompt is rendered for: bash, zsh, fish or powershell")
+	return promptCmd
+}
+
+// promptContext is the data that prompt.format is rendered with.
+type promptContext struct {
+	Path        string            `json:"path"`
+	Plugin      string            `json:"plugin"`
+	Context     string            `json:"context"`
+	Segments    []string          `json:"segments"`
+	Destructive bool              `json:"destructive"`
+	Colors      map[string]string `json:"colors"`
+}
+
+var promptColorCodes = map[string]string{
+	"red":     "31",
+	"green":   "32",
+	"yellow":  "33",
+	"blue":    "34",
+	"magenta": "35",
+	"cyan":    "36",
+}
+
+// promptColors returns the shell-specific escape sequences for each color.
+// Bash needs non-printing sequences to be wrapped in \[ and \] so that it can
+// calculate the prompt's width, and zsh has its own color sequences. Fish and
+// PowerShell print the prompt as is.
+func promptColors(shell string) map[string]string {
+	colors := make(map[string]string)
+	for name, code := range promptColorCodes {
+		switch shell {
+		case "bash":
+			colors[name] = `\[\e[0;` + code + `m\]`
+		case "zsh":
+			colors[name] = "%F{" + name + "}"
+		default:
+			colors[name] = "\x1b[0;" + code + "m"
+		}
+	}
+	switch shell {
+	case "bash":
+		colors["reset"] = `\[\e[m\]`
+	case "zsh":
+		colors["reset"] = "%f"
+	default:
+		colors["reset"] = "\x1b[m"
+	}
+	return colors
+}
+
+// escapePrompt escapes characters that the shell interprets in a prompt.
+func escapePrompt(shell string, str string) string {
+	switch shell {
+	case "bash":
+		return strings.Replace(str, `\`, `\\`, -1)
+	case "zsh":
+		return strings.Replace(str, "%", "%%", -1)
+	default:
+		return str
+	}
+}
+
+func promptMain(cmd *cobra.Command, args []string) exitCode {
+	shell, err := cmd.Flags().GetString("shell")
+	if err != nil {
+		panic(err.Error())
+	}
+	switch shell {
+	case "bash", "zsh", "fish", "powershell":
+	default:
+		cmdutil.ErrPrintf("unsupported shell %v; must be bash, zsh, fish or powershell\n", shell)
+		return exitCode{1}
+	}
+
+	tmpl, err := cmdutil.NewTemplate(viper.GetString(config.PromptFormatKey))
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	ctx := newPromptContext(cmdutil.NewClient(), cwd, os.Getenv("W"), os.Getenv("HOME"))
+
+	ctx.Path = escapePrompt(shell, ctx.Path)
+	ctx.Plugin = escapePrompt(shell, ctx.Plugin)
+	ctx.Context = escapePrompt(shell, ctx.Context)
+	for i, segment := range ctx.Segments {
+		ctx.Segments[i] = escapePrompt(shell, segment)
+	}
+	ctx.Colors = promptColors(shell)
+
+	prompt, err := tmpl.Format(ctx)
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	cmdutil.Print(prompt)
+	return exitCode{0}
+}
+
+// newPromptContext describes the Wash context of cwd. Its path is shortened
+// like the default prompt's.
+func newPromptContext(conn client.Client, cwd, mountpoint, home string) promptContext {
+	ctx := promptContext{Path: cwd, Segments: []string{}}
+	if mountpoint != "" {
+		if cwd == mountpoint {
+			ctx.Path = "."
+		} else if rel := strings.TrimPrefix(cwd, mountpoint+string(filepath.Separator)); rel != cwd {
+			ctx.Path = rel
+			ctx.Segments = strings.Split(rel, string(filepath.Separator))
+		}
+	}
+	if home != "" && strings.HasPrefix(ctx.Path, home) {
+		ctx.Path = "~" + strings.TrimPrefix(ctx.Path, home)
+	}
+	if len(ctx.Segments) == 0 {
+		return ctx
+	}
+
+	ctx.Plugin = ctx.Segments[0]
+	if len(ctx.Segments) > 1 {
+		ctx.Context = ctx.Segments[1]
+	}
+	ctx.Destructive = isDestructive(conn, cwd)
+	return ctx
+}
+
+// isDestructive returns true if the entry at path or any of its children can
+// be deleted or signalled. Entries are retrieved from the API, so this is
+// usually served from the cache.
+func isDestructive(conn client.Client, path string) bool {
+	canDestroy := func(actions []string) bool {
+		for _, action := range actions {
+			if action == plugin.DeleteAction().Name || action == plugin.SignalAction().Name {
+				return true
+			}
+		}
+		return false
+	}
+
+	entry, err := conn.Info(path)
+	if err != nil {
+		return false
+	}
+	if canDestroy(entry.Actions) {
+		return true
+	}
+	if !entry.Supports(plugin.ListAction()) {
+		return false
+	}
+	children, err := conn.List(path)
+	if err != nil {
+		return false
+	}
+	for _, child := range children {
+		if canDestroy(child.Actions) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+)
+
+func TestNewPromptContext(t *testing.T) {
+	conn := &cmdtest.MockClient{}
+
+	ctx := newPromptContext(conn, "/wash", "/wash", "/home/user")
+	assert.Equal(t, ".", ctx.Path)
+	assert.Empty(t, ctx.Plugin)
+	assert.False(t, ctx.Destructive)
+
+	ctx = newPromptContext(conn, "/home/user/src", "/wash", "/home/user")
+	assert.Equal(t, "~/src", ctx.Path)
+	assert.Empty(t, ctx.Segments)
+
+	conn.On("Info", "/wash/docker/containers").Return(apitypes.Entry{Actions: []string{"list"}}, nil)
+	conn.On("List", "/wash/docker/containers").Return([]apitypes.Entry{
+		{CName: "web", Actions: []string{"list", "exec", "delete"}},
+	}, nil)
+	ctx = newPromptContext(conn, "/wash/docker/containers", "/wash", "/home/user")
+	assert.Equal(t, "docker/containers", ctx.Path)
+	assert.Equal(t, "docker", ctx.Plugin)
+	assert.Equal(t, "containers", ctx.Context)
+	assert.Equal(t, []string{"docker", "containers"}, ctx.Segments)
+	assert.True(t, ctx.Destructive)
+
+	conn.On("Info", "/wash/docker/volumes").Return(apitypes.Entry{Actions: []string{"list"}}, nil)
+	conn.On("List", "/wash/docker/volumes").Return([]apitypes.Entry{
+		{CName: "data", Actions: []string{"list"}},
+	}, nil)
+	ctx = newPromptContext(conn, "/wash/docker/volumes", "/wash", "/home/user")
+	assert.False(t, ctx.Destructive)
+}
+
+func TestEscapePrompt(t *testing.T) {
+	assert.Equal(t, "100%% done", escapePrompt("zsh", "100% done"))
+	assert.Equal(t, `a\\b`, escapePrompt("bash", `a\b`))
+}
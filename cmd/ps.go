@@ -5,11 +5,14 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 
 	"github.com/puppetlabs/wash/api/client"
@@ -25,12 +28,39 @@ func psCommand() *cobra.Command {
 		Aliases: aliases,
 		Short:   "Lists the processes running on the indicated compute instances",
 		Long: `Captures /proc/*/{cmdline,stat,statm} on each node by executing 'cat' on them. Collects the output
-to display running processes on all listed nodes. Errors on paths that don't implement exec.`,
+to display running processes on all listed nodes.
+
+Any entry that supports exec can be a node. Entries that report their operating system's login shell
+use it to decide how to collect processes; other entries are assumed to have a POSIX shell. If a
+path is a parent that doesn't support exec, then ps runs on its children that do, so
+'wash ps docker/containers' lists the processes in every container.
+
+Nodes are queried concurrently, up to --parallel at a time. When stderr is a terminal, ps reports
+its progress while it waits on the nodes.`,
+		Example: `ps --columns node,pid,cmd --sort pid
+  list the PIDs and commands of the processes on the nodes in the current directory, sorted by PID
+
+ps --filter nginx docker/containers
+  list the nginx processes in every Docker container`,
 		RunE: toRunE(psMain),
 	}
+	psCmd.Flags().StringSlice("columns", psColumns, "Comma-separated list of the columns to display")
+	psCmd.Flags().String("sort", "node", "Sort processes by one of "+strings.Join(psColumns, ", "))
+	psCmd.Flags().BoolP("reverse", "r", false, "Reverse the sort order")
+	psCmd.Flags().String("filter", "", "Only display processes whose command matches the regular expression")
+	psCmd.Flags().IntP("parallel", "p", 10, "Query up to n nodes concurrently")
 	return psCmd
 }
 
+var psColumns = []string{"node", "pid", "time", "cmd"}
+
+var psHeaders = map[string]cmdutil.ColumnHeader{
+	"node": {ShortName: "node", FullName: "NODE"},
+	"pid":  {ShortName: "pid", FullName: "PID"},
+	"time": {ShortName: "time", FullName: "TIME"},
+	"cmd":  {ShortName: "cmd", FullName: "COMMAND"},
+}
+
 func collectOutput(ch <-chan apitypes.ExecPacket) (string, error) {
 	exit := 0
 	var stdout, stderr string
@@ -56,40 +86,204 @@ func collectOutput(ch <-chan apitypes.ExecPacket) (string, error) {
 }
 
 type psresult struct {
+	node    string
 	pid     int
 	active  time.Duration
 	command string
 }
 
-func formatStats(paths []string, results map[string][]psresult) string {
-	headers := []cmdutil.ColumnHeader{
-		{ShortName: "node", FullName: "NODE"},
-		{ShortName: "pid", FullName: "PID"},
-		{ShortName: "time", FullName: "TIME"},
-		{ShortName: "cmd", FullName: "COMMAND"},
+// shortenNode shortens path segments to probably-unique short strings, like
+// `ku*s/do*p/de*t/pods/redis`.
+func shortenNode(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments[:len(segments)-1] {
+		if len(segment) > 4 {
+			segments[i] = segment[:2] + "*" + segment[len(segment)-1:]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+type psOptions struct {
+	columns []string
+	sortBy  string
+	reverse bool
+	filter  *regexp.Regexp
+}
+
+func newPsOptions(cmd *cobra.Command) (psOptions, error) {
+	var opts psOptions
+	var err error
+	if opts.columns, err = cmd.Flags().GetStringSlice("columns"); err != nil {
+		panic(err.Error())
+	}
+	for _, column := range opts.columns {
+		if _, ok := psHeaders[column]; !ok {
+			return opts, fmt.Errorf("unknown column %v; columns must be one of %v", column, strings.Join(psColumns, ", "))
+		}
+	}
+	if opts.sortBy, err = cmd.Flags().GetString("sort"); err != nil {
+		panic(err.Error())
+	}
+	if _, ok := psHeaders[opts.sortBy]; !ok {
+		return opts, fmt.Errorf("cannot sort by %v; must be one of %v", opts.sortBy, strings.Join(psColumns, ", "))
+	}
+	if opts.reverse, err = cmd.Flags().GetBool("reverse"); err != nil {
+		panic(err.Error())
+	}
+	filter, err := cmd.Flags().GetString("filter")
+	if err != nil {
+		panic(err.Error())
+	}
+	if filter != "" {
+		if opts.filter, err = regexp.Compile(filter); err != nil {
+			return opts, fmt.Errorf("invalid filter %v: %v", filter, err)
+		}
 	}
-	var table [][]string
+	return opts, nil
+}
+
+// selectStats filters and sorts the results from each node. Processes are
+// grouped by node in the order the nodes were given unless they're sorted
+// by another column.
+func selectStats(paths []string, results map[string][]psresult, opts psOptions) []psresult {
+	var stats []psresult
 	for _, path := range paths {
-		// Shorten path segments to probably-unique short strings, like `ku*s/do*p/de*t/pods/redis`.
 		for _, st := range results[path] {
-			segments := strings.Split(strings.Trim(path, "/"), "/")
-			for i, segment := range segments[:len(segments)-1] {
-				if len(segment) > 4 {
-					segments[i] = segment[:2] + "*" + segment[len(segment)-1:]
-				}
+			if opts.filter == nil || opts.filter.MatchString(st.command) {
+				stats = append(stats, st)
 			}
+		}
+	}
 
-			table = append(table, []string{
-				strings.Join(segments, "/"),
-				strconv.Itoa(st.pid),
-				cmdutil.FormatDuration(st.active),
-				st.command,
-			})
+	var less func(a, b psresult) bool
+	switch opts.sortBy {
+	case "pid":
+		less = func(a, b psresult) bool { return a.pid < b.pid }
+	case "time":
+		less = func(a, b psresult) bool { return a.active < b.active }
+	case "cmd":
+		less = func(a, b psresult) bool { return a.command < b.command }
+	}
+	if less != nil {
+		sort.SliceStable(stats, func(i, j int) bool { return less(stats[i], stats[j]) })
+	}
+	if opts.reverse {
+		for i, j := 0, len(stats)-1; i < j; i, j = i+1, j-1 {
+			stats[i], stats[j] = stats[j], stats[i]
 		}
 	}
+	return stats
+}
+
+func formatStats(stats []psresult, columns []string) string {
+	headers := make([]cmdutil.ColumnHeader, len(columns))
+	for i, column := range columns {
+		headers[i] = psHeaders[column]
+	}
+	table := make([][]string, len(stats))
+	for i, st := range stats {
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			switch column {
+			case "node":
+				row[j] = shortenNode(st.node)
+			case "pid":
+				row[j] = strconv.Itoa(st.pid)
+			case "time":
+				row[j] = cmdutil.FormatDuration(st.active)
+			case "cmd":
+				row[j] = st.command
+			}
+		}
+		table[i] = row
+	}
 	return cmdutil.NewTableWithHeaders(headers, table).Format()
 }
 
+// psNodes returns the entries that ps runs on. Paths that don't support exec
+// are expanded to their children that do.
+func psNodes(conn client.Client, paths []string) ([]apitypes.Entry, bool) {
+	var nodes []apitypes.Entry
+	ok := true
+	for _, path := range paths {
+		entry, err := conn.Info(path)
+		if err != nil {
+			cmdutil.ErrPrintf("errored on %v: %v\n", path, err)
+			ok = false
+			continue
+		}
+		if entry.Supports(plugin.ExecAction()) {
+			nodes = append(nodes, entry)
+			continue
+		}
+		if !entry.Supports(plugin.ListAction()) {
+			cmdutil.ErrPrintf("errored on %v: entry does not support exec\n", path)
+			ok = false
+			continue
+		}
+		children, err := conn.List(path)
+		if err != nil {
+			cmdutil.ErrPrintf("errored on %v: %v\n", path, err)
+			ok = false
+			continue
+		}
+		found := false
+		for _, child := range children {
+			if child.Supports(plugin.ExecAction()) {
+				nodes = append(nodes, child)
+				found = true
+			}
+		}
+		if !found {
+			cmdutil.ErrPrintf("errored on %v: neither the entry nor its children support exec\n", path)
+			ok = false
+		}
+	}
+	return nodes, ok
+}
+
+// psProgress reports how many nodes have finished on stderr. It only
+// reports progress when stderr is a terminal.
+type psProgress struct {
+	enabled bool
+	total   int
+	done    int
+	mux     sync.Mutex
+}
+
+func newPsProgress(total int) *psProgress {
+	fd := os.Stderr.Fd()
+	return &psProgress{
+		enabled: isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd),
+		total:   total,
+	}
+}
+
+func (p *psProgress) increment() {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.done++
+	if p.enabled {
+		cmdutil.SafeErrPrint(fmt.Sprintf("\r%v/%v nodes", p.done, p.total))
+	}
+}
+
+// clear erases the progress line so that it doesn't mix with the output.
+func (p *psProgress) clear() {
+	if p.enabled && p.done > 0 {
+		cmdutil.SafeErrPrint("\r\033[K")
+	}
+}
+
+// errorf clears the progress line before printing an error.
+func (p *psProgress) errorf(msg string, a ...interface{}) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.clear()
+	cmdutil.ErrPrintf(msg, a...)
+}
+
 func psMain(cmd *cobra.Command, args []string) exitCode {
 	var paths []string
 	if len(args) > 0 {
@@ -104,27 +298,42 @@ func psMain(cmd *cobra.Command, args []string) exitCode {
 		paths = []string{cwd}
 	}
 
+	opts, err := newPsOptions(cmd)
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	parallel, err := cmd.Flags().GetInt("parallel")
+	if err != nil {
+		panic(err.Error())
+	}
+	if parallel < 1 {
+		parallel = 1
+	}
+
 	conn := cmdutil.NewClient()
+	nodes, ok := psNodes(conn, paths)
 
 	results := make(map[string][]psresult)
+	nodePaths := make([]string, len(nodes))
 	// Prepulate the map so it doesn't change size while all the goroutines are adding data.
-	for _, path := range paths {
-		results[path] = []psresult{}
+	for i, node := range nodes {
+		nodePaths[i] = node.Path
+		results[node.Path] = []psresult{}
 	}
 
-	var wg sync.WaitGroup
-	wg.Add(len(paths))
-	for i, path := range paths {
-		go func(k string, idx int) {
-			defer wg.Done()
+	var failed bool
+	progress := newPsProgress(len(nodes))
+	pool := cmdutil.NewPool(parallel)
+	for _, node := range nodes {
+		node := node
+		pool.Submit(func() {
+			defer pool.Done()
+			defer progress.increment()
 
-			entry, err := conn.Info(k)
-			if err != nil {
-				cmdutil.ErrPrintf("errored on %v: %v\n", k, err)
-			}
 			var loginShell plugin.Shell
-			if entry.Attributes.HasOS() {
-				loginShell = entry.Attributes.OS().LoginShell
+			if node.Attributes.HasOS() {
+				loginShell = node.Attributes.OS().LoginShell
 			}
 			if loginShell == plugin.UnknownShell {
 				// Assume posix if unknown
@@ -132,33 +341,51 @@ func psMain(cmd *cobra.Command, args []string) exitCode {
 			}
 
 			dispatcher := dispatchers[loginShell]
-			ch, err := dispatcher.execPS(conn, k)
+			stats, err := dispatcher.ps(conn, node.Path)
 			if err != nil {
-				cmdutil.ErrPrintf("errored on %v: %v\n", k, err)
+				progress.errorf("errored on %v: %v\n", node.Path, err)
+				progress.mux.Lock()
+				failed = true
+				progress.mux.Unlock()
 				return
 			}
-			out, err := collectOutput(ch)
-			if err != nil {
-				cmdutil.ErrPrintf("errored on %v: %v\n", k, err)
-				return
+			for i := range stats {
+				stats[i].node = node.Path
 			}
-
-			results[k], err = dispatcher.parseOutput(out)
-			if err != nil {
-				cmdutil.ErrPrintf("errored on %v: %v\n", k, err)
-			}
-		}(path, i)
+			progress.mux.Lock()
+			results[node.Path] = stats
+			progress.mux.Unlock()
+		})
 	}
+	pool.Finish()
+	progress.clear()
 
-	wg.Wait()
-	cmdutil.Print(formatStats(paths, results))
+	cmdutil.Print(formatStats(selectStats(nodePaths, results, opts), opts.columns))
+	if !ok || failed {
+		return exitCode{1}
+	}
 	return exitCode{0}
 }
 
-var dispatchers = []struct {
+type psDispatcher struct {
 	execPS      func(client.Client, string) (<-chan apitypes.ExecPacket, error)
 	parseOutput func(string) ([]psresult, error)
-}{
+}
+
+// ps collects the processes running on the named node.
+func (d psDispatcher) ps(conn client.Client, name string) ([]psresult, error) {
+	ch, err := d.execPS(conn, name)
+	if err != nil {
+		return nil, err
+	}
+	out, err := collectOutput(ch)
+	if err != nil {
+		return nil, err
+	}
+	return d.parseOutput(out)
+}
+
+var dispatchers = []psDispatcher{
 	{}, // Unknown
 	{ // POSIX shell
 		execPS: func(conn client.Client, name string) (<-chan apitypes.ExecPacket, error) {
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectStats(t *testing.T) {
+	results := map[string][]psresult{
+		"/a": {{node: "/a", pid: 3, active: time.Second, command: "nginx"}, {node: "/a", pid: 1, command: "init"}},
+		"/b": {{node: "/b", pid: 2, active: time.Minute, command: "nginx -g"}},
+	}
+	paths := []string{"/b", "/a"}
+
+	stats := selectStats(paths, results, psOptions{sortBy: "node"})
+	assert.Equal(t, []int{2, 3, 1}, psPids(stats))
+
+	stats = selectStats(paths, results, psOptions{sortBy: "pid"})
+	assert.Equal(t, []int{1, 2, 3}, psPids(stats))
+
+	stats = selectStats(paths, results, psOptions{sortBy: "time", reverse: true})
+	assert.Equal(t, []int{2, 3, 1}, psPids(stats))
+
+	stats = selectStats(paths, results, psOptions{sortBy: "cmd", filter: regexp.MustCompile("^nginx")})
+	assert.Equal(t, []int{3, 2}, psPids(stats))
+}
+
+func TestFormatStatsColumns(t *testing.T) {
+	stats := []psresult{{node: "/docker/containers/web", pid: 7, command: "nginx"}}
+	out := formatStats(stats, []string{"pid", "node"})
+	assert.Regexp(t, `^PID +NODE`, out)
+	assert.Contains(t, out, "do*r/co*s/web")
+	assert.NotContains(t, out, "nginx")
+}
+
+func psPids(stats []psresult) []int {
+	pids := make([]int, len(stats))
+	for i, st := range stats {
+		pids[i] = st.pid
+	}
+	return pids
+}
@@ -140,12 +140,14 @@ then starts your system shell with shortcuts configured for wash subcommands.`,
 		rootCmd.SetUsageTemplate(embeddedUsageTemplate)
 	} else {
 		// Omit server from embedded cases because a daemon is already running.
-		addServerArgs(rootCmd, "warn")
+		addServerArgs(rootCmd, "warn", "")
 		addCommand(rootCmd, serverCommand())
 		// The root*Flag variables are declared in rootMain.go
 		rootCmd.Flags().StringVarP(&rootCommandFlag, "command", "c", "", "Run the supplied string and exit")
 		rootCmd.Flags().BoolVar(&rootVersionFlag, "version", false, "Print the Wash version")
 		rootCmd.Flags().BoolVar(&rootVerifyInstallFlag, "verify-install", false, "Verifies a given Wash installation")
+		rootCmd.Flags().BoolVar(&rootStrictFlag, "strict", false, "Exit on the first failed command when running a script or -c")
+		rootCmd.Flags().StringVar(&rootReportFlag, "report", "", "Write the failed Wash commands of a --strict script to a file as JSON lines")
 
 		// Omit validate because it's meant to be run independently to test a plugin and should not be
 		// part of normal shell interaction.
@@ -161,11 +163,31 @@ then starts your system shell with shortcuts configured for wash subcommands.`,
 	addCommand(rootCmd, clearCommand())
 	addCommand(rootCmd, tailCommand())
 	addCommand(rootCmd, historyCommand())
+	addCommand(rootCmd, auditCommand())
 	addCommand(rootCmd, infoCommand())
 	addCommand(rootCmd, streeCommand())
 	addCommand(rootCmd, docsCommand())
 	addCommand(rootCmd, deleteCommand())
 	addCommand(rootCmd, signalCommand())
+	addCommand(rootCmd, cpCommand())
+	addCommand(rootCmd, mvCommand())
+	addCommand(rootCmd, mkdirCommand())
+	addCommand(rootCmd, touchCommand())
+	addCommand(rootCmd, diffCommand())
+	addCommand(rootCmd, watchCommand())
+	addCommand(rootCmd, topCommand())
+	addCommand(rootCmd, duCommand())
+	addCommand(rootCmd, grepCommand())
+	addCommand(rootCmd, editCommand())
+	addCommand(rootCmd, syncCommand())
+	addCommand(rootCmd, treeCommand())
+	addCommand(rootCmd, statCommand())
+	addCommand(rootCmd, pluginCommand())
+	addCommand(rootCmd, completionCommand())
+	// Completion and prompts run on every tab press and prompt, so don't register their
+	// invocations to GA.
+	rootCmd.AddCommand(completeCommand())
+	rootCmd.AddCommand(promptCommand())
 
 	return rootCmd
 }
@@ -189,9 +211,11 @@ func Execute() int {
 		// err is something Cobra-related, like e.g. a malformed
 		// flag. Print the error, then return.
 		cmdutil.ErrPrintf("Error: %v\n", err)
+		recordStrictFailure(1)
 		return 1
 	}
 
+	recordStrictFailure(exitCode.value)
 	return exitCode.value
 }
 
@@ -1,14 +1,17 @@
 package cmd
 
 import (
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"golang.org/x/sys/unix"
 
+	"github.com/puppetlabs/wash/cmd/internal/config"
 	"github.com/puppetlabs/wash/cmd/internal/server"
 	"github.com/puppetlabs/wash/cmd/internal/shell"
 	cmdutil "github.com/puppetlabs/wash/cmd/util"
@@ -16,12 +19,15 @@ import (
 	"github.com/puppetlabs/wash/plugin"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // These root*Flag variables are defined in root.go
 var rootCommandFlag string
 var rootVersionFlag bool
 var rootVerifyInstallFlag bool
+var rootStrictFlag bool
+var rootReportFlag string
 
 // Start the Wash server then present the default system shell. The server will be running in the
 // current process, while the shell will be in a separate child process. We'd like the server to be
@@ -48,6 +54,15 @@ func rootMain(cmd *cobra.Command, args []string) exitCode {
 		execfile = args[0]
 	}
 
+	if rootStrictFlag && execfile == "" && rootCommandFlag == "" {
+		cmdutil.ErrPrintf("--strict requires a script or -c\n")
+		return exitCode{1}
+	}
+	if rootReportFlag != "" && !rootStrictFlag {
+		cmdutil.ErrPrintf("--report requires --strict\n")
+		return exitCode{1}
+	}
+
 	// Interactivity is true if a script (execfile) isn't specified, a command isn't specified, and
 	// we're not running verify-install. We skip verify-install so we don't try to fork right before
 	// running verification; verify-install should have no interactive behavior.
@@ -134,11 +149,15 @@ func rootMain(cmd *cobra.Command, args []string) exitCode {
 	}
 
 	subc := flattenSubcommands(cmd.Commands())
-	comm, err := shell.Get().Command(subc, rundir)
+	sh := shell.Get()
+	comm, err := sh.Command(subc, rundir)
 	if err != nil {
 		cmdutil.ErrPrintf("%v\n", err)
 		return exitCode{1}
 	}
+	for _, err := range shell.WriteHooks(shellHooks(plugins), rundir) {
+		cmdutil.ErrPrintf("%v\n", err)
+	}
 
 	if execfile != "" {
 		file, err := os.Open(execfile)
@@ -153,6 +172,19 @@ func rootMain(cmd *cobra.Command, args []string) exitCode {
 	} else {
 		comm.Stdin = os.Stdin
 	}
+
+	// In strict mode, run the shell's strict mode commands before the script. Wash commands record
+	// their failures so that they can be reported once the script's done.
+	var failuresPath string
+	if rootStrictFlag {
+		preamble, err := sh.StrictMode()
+		if err != nil {
+			cmdutil.ErrPrintf("%v\n", err)
+			return exitCode{1}
+		}
+		comm.Stdin = io.MultiReader(strings.NewReader(preamble), comm.Stdin)
+		failuresPath = filepath.Join(rundir, "failures.jsonl")
+	}
 	comm.Stdout = os.Stdout
 	comm.Stderr = os.Stderr
 	if comm.Env == nil {
@@ -163,6 +195,13 @@ func rootMain(cmd *cobra.Command, args []string) exitCode {
 		"W="+mountpath,
 		"PATH="+rundir+string(os.PathListSeparator)+os.Getenv("PATH"),
 	)
+	if failuresPath != "" {
+		comm.Env = append(comm.Env, strictReportEnv+"="+failuresPath)
+	}
+	// Pass the prompt format on to the shell's prompter, which renders it with `wash prompt`.
+	if format := viper.GetString(config.PromptFormatKey); format != "" {
+		comm.Env = append(comm.Env, "WASH_PROMPT_FORMAT="+format)
+	}
 	comm.Dir = mountpath
 
 	if startErr := comm.Start(); startErr != nil {
@@ -193,6 +232,10 @@ func rootMain(cmd *cobra.Command, args []string) exitCode {
 		}
 	}
 
+	if failuresPath != "" && !reportStrictFailures(failuresPath, rootReportFlag) && exit.value == 0 {
+		exit.value = 1
+	}
+
 	if plugin.IsInteractive() {
 		cmdutil.Println("Goodbye!")
 	}
@@ -226,8 +269,9 @@ func flattenSubcommands(subcommands []*cobra.Command) []string {
 			panic("all subcommands should have non-empty usage")
 		}
 		name := tokens[0]
-		// Specifically skip server as undocumented when running in wash shell.
-		if name == "server" {
+		// Specifically skip server as undocumented when running in wash shell. Hidden commands
+		// like complete are only meant to be used by the shell integration.
+		if name == "server" || subcommand.Hidden {
 			continue
 		}
 
@@ -246,6 +290,35 @@ func flattenSubcommands(subcommands []*cobra.Command) []string {
 	return subc
 }
 
+// shellHooks returns the aliases and startup commands from the shell config, along with helpers
+// contributed by plugins. User-defined aliases take precedence over plugin helpers.
+func shellHooks(plugins map[string]plugin.Root) shell.Hooks {
+	hooks := shell.Hooks{
+		Aliases: viper.GetStringMapString(config.ShellAliasesKey),
+		Startup: viper.GetStringSlice(config.ShellStartupKey),
+	}
+
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		root, ok := plugins[name].(plugin.HasShellHelpers)
+		if !ok {
+			continue
+		}
+		for _, helper := range root.ShellHelpers() {
+			if _, ok := hooks.Aliases[helper.Name]; ok {
+				log.Warnf("Skipping the %v plugin's %v helper because it conflicts with an alias", name, helper.Name)
+				continue
+			}
+			hooks.Aliases[helper.Name] = helper.Command
+		}
+	}
+	return hooks
+}
+
 func symlinkWash(rundir string) (ok bool) {
 	washPath, err := os.Executable()
 	if err != nil {
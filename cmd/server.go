@@ -29,12 +29,28 @@ func serverCommand() *cobra.Command {
 		Use:   "server <mountpoint>",
 		Short: "Sets up the Wash daemon (API and FUSE servers)",
 		Long: `Initializes all of the plugins, then sets up the Wash daemon (its API and FUSE servers).
-To stop it, make sure you're not using the filesystem at <mountpoint>, then enter Ctrl-C.`,
+To stop it, make sure you're not using the filesystem at <mountpoint>, then enter Ctrl-C.
+
+The server logs to --logfile, which defaults to ~/.wash/wash-server.log.
+Use '--logfile -' to log to stdout instead, and --logformat json for structured logs. The log file
+is rotated once it reaches --logmaxsize megabytes or is --logmaxage old.
+
+With --daemon, the server runs in the background instead and records its pid in --pidfile. Its
+stdout and stderr go to a .out file next to the log file. Use the status, stop and restart
+subcommands to manage it.`,
 		Args:   cobra.MinimumNArgs(1),
 		PreRun: bindServerArgs,
 		RunE:   toRunE(serverMain),
 	}
-	addServerArgs(serverCmd, "info")
+	addServerArgs(serverCmd, "info", defaultLogFile())
+	serverCmd.Flags().Bool("daemon", false, "Run the server in the background")
+	serverCmd.Flags().String("pidfile", "", "Write the server's pid to a file. Defaults to the wash-server.pid file in Wash's cache directory with --daemon")
+	serverCmd.Flags().Duration("timeout", 60*time.Second, "With --daemon, how long to wait for the server to start")
+
+	serverCmd.AddCommand(serverStatusCommand())
+	serverCmd.AddCommand(serverStopCommand())
+	serverCmd.AddCommand(serverRestartCommand())
+	serverCmd.AddCommand(serverStatsCommand())
 
 	return serverCmd
 }
@@ -47,6 +63,38 @@ func serverMain(cmd *cobra.Command, args []string) exitCode {
 		return exitCode{1}
 	}
 
+	daemon, err := cmd.Flags().GetBool("daemon")
+	if err != nil {
+		panic(err.Error())
+	}
+	pidfile, err := cmd.Flags().GetString("pidfile")
+	if err != nil {
+		panic(err.Error())
+	}
+	if daemon {
+		if pidfile == "" {
+			pidfile = defaultServerFile("wash-server.pid")
+		}
+		logfile := viper.GetString("logfile")
+		if logfile == "" || logfile == "-" {
+			logfile = defaultLogFile()
+		}
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			panic(err.Error())
+		}
+		return startDaemon(daemonArgs(cmd, mountpoint, logfile, pidfile), logfile, pidfile, timeout)
+	}
+	if pidfile != "" {
+		if pid, err := runningDaemon(pidfile); err != nil {
+			cmdutil.ErrPrintf("%v\n", err)
+			return exitCode{1}
+		} else if pid != 0 {
+			cmdutil.ErrPrintf("The Wash server is already running (pid %v)\n", pid)
+			return exitCode{1}
+		}
+	}
+
 	log.SetFormatter(&log.TextFormatter{
 		FullTimestamp:   true,
 		TimestampFormat: time.RFC3339Nano,
@@ -67,22 +115,58 @@ func serverMain(cmd *cobra.Command, args []string) exitCode {
 		log.Warn(err)
 		return exitCode{1}
 	}
+	if pidfile != "" {
+		state := daemonState{
+			Mountpoint: mountpoint,
+			Socket:     config.Socket,
+			LogFile:    viper.GetString("logfile"),
+			Args:       os.Args[1:],
+		}
+		if err := writePidfile(pidfile, state); err != nil {
+			log.Warnf("Could not write the pidfile: %v", err)
+		}
+		defer removePidfile(pidfile)
+	}
 	srv.Wait(sigCh)
 	return exitCode{0}
 }
 
-func addServerArgs(cmd *cobra.Command, defaultLogLevel string) {
+func addServerArgs(cmd *cobra.Command, defaultLogLevel string, defaultLogFile string) {
+	logFileUsage := "Set the log file's location, or - for stdout"
+	if defaultLogFile == "" {
+		logFileUsage += ". Defaults to stdout"
+	}
 	cmd.Flags().String("loglevel", defaultLogLevel, "Set the logging level")
-	cmd.Flags().String("logfile", "", "Set the log file's location. Defaults to stdout")
+	cmd.Flags().String("logfile", defaultLogFile, logFileUsage)
+	cmd.Flags().String("logformat", "text", "Set the logging format (text or json)")
+	cmd.Flags().String("logsink", "file", "Set where logs are sent (file, syslog, or journald)")
+	cmd.Flags().Int("logmaxsize", 100, "Rotate the log file once it reaches this many megabytes. 0 disables it")
+	cmd.Flags().Duration("logmaxage", 0, "Rotate the log file once it's been written to for this long. 0 disables it")
+	cmd.Flags().Int("logmaxbackups", 5, "Set the number of rotated log files to keep. 0 keeps all of them")
 	cmd.Flags().String("cpuprofile", "", "Write cpu profile to file")
+	cmd.Flags().Bool("read-only", false, "Reject the write, exec, delete, signal, rename, copy, create and setattr actions")
+	cmd.Flags().Duration("slowlog-threshold", 5*time.Second, "Record plugin methods and API requests that take at least this long to the slow log. 0 disables it")
+	cmd.Flags().StringSlice("debug-external", nil, "Record each invocation of the given external plugins to --debug-external-dir")
+	cmd.Flags().String("debug-external-dir", defaultServerFile("debug-external"), "Set the directory of the --debug-external invocations")
 	cmd.Flags().String("config-file", config.DefaultFile(), "Set the config file's location")
+	cmd.Flags().String("profile", "", "Apply the config file's named profile")
 }
 
 func bindServerArgs(cmd *cobra.Command, args []string) {
 	// Only bind config lookup when invoking the specific command as viper bindings are global.
+	errz.Fatal(viper.BindPFlag(config.ProfileKey, cmd.Flags().Lookup("profile")))
 	errz.Fatal(viper.BindPFlag("loglevel", cmd.Flags().Lookup("loglevel")))
 	errz.Fatal(viper.BindPFlag("logfile", cmd.Flags().Lookup("logfile")))
+	errz.Fatal(viper.BindPFlag("logformat", cmd.Flags().Lookup("logformat")))
+	errz.Fatal(viper.BindPFlag("logsink", cmd.Flags().Lookup("logsink")))
+	errz.Fatal(viper.BindPFlag("logmaxsize", cmd.Flags().Lookup("logmaxsize")))
+	errz.Fatal(viper.BindPFlag("logmaxage", cmd.Flags().Lookup("logmaxage")))
+	errz.Fatal(viper.BindPFlag("logmaxbackups", cmd.Flags().Lookup("logmaxbackups")))
 	errz.Fatal(viper.BindPFlag("cpuprofile", cmd.Flags().Lookup("cpuprofile")))
+	errz.Fatal(viper.BindPFlag("read-only", cmd.Flags().Lookup("read-only")))
+	errz.Fatal(viper.BindPFlag("slowlog.threshold", cmd.Flags().Lookup("slowlog-threshold")))
+	errz.Fatal(viper.BindPFlag("debug-external", cmd.Flags().Lookup("debug-external")))
+	errz.Fatal(viper.BindPFlag("debug-external-dir", cmd.Flags().Lookup("debug-external-dir")))
 }
 
 // serverOptsFor returns map of plugins and server.Opts for the given command.
@@ -92,7 +176,7 @@ func serverOptsFor(cmd *cobra.Command) (map[string]plugin.Root, server.Opts, err
 	if err != nil {
 		panic(err.Error())
 	}
-	if err := config.ReadFrom(configFile); err != nil {
+	if err := config.ReadFrom(configFile, viper.GetString(config.ProfileKey)); err != nil {
 		return nil, server.Opts{}, err
 	}
 
@@ -152,8 +236,18 @@ func serverOptsFor(cmd *cobra.Command) (map[string]plugin.Root, server.Opts, err
 	if err := viper.UnmarshalKey("external-plugins", &externalPlugins); err != nil {
 		return nil, server.Opts{}, fmt.Errorf("failed to unmarshal the external-plugins key: %v", err)
 	}
+	debugExternal := make(map[string]bool)
+	for _, name := range viper.GetStringSlice("debug-external") {
+		debugExternal[name] = true
+	}
 	for _, spec := range externalPlugins {
-		intPlugin, err := spec.Load()
+		var debugDir string
+		if debugExternal[spec.Name()] {
+			debugDir = filepath.Join(viper.GetString("debug-external-dir"), spec.Name())
+			log.Infof("Recording the invocations of %v to %v", spec.Script, debugDir)
+			delete(debugExternal, spec.Name())
+		}
+		intPlugin, err := spec.LoadWithDebugDump(debugDir)
 		if err != nil {
 			log.Warnf("%v failed to load: %+v", spec.Script, err)
 			continue
@@ -165,10 +259,32 @@ func serverOptsFor(cmd *cobra.Command) (map[string]plugin.Root, server.Opts, err
 		}
 		plugins[name] = intPlugin
 	}
+	for name := range debugExternal {
+		log.Warnf("Cannot debug %v: it isn't an external plugin", name)
+	}
 
 	pluginConfig := make(map[string]map[string]interface{})
+	var readOnlyPlugins []string
+	concurrencyLimits := plugin.ConcurrencyLimits{
+		Global:  viper.GetInt("concurrency.global"),
+		Plugin:  viper.GetInt("concurrency.plugin"),
+		Plugins: make(map[string]int),
+	}
+	retryPolicies := plugin.RetryPolicies{
+		Default: retryPolicyFor("retry", plugin.DefaultRetryPolicy),
+		Plugins: make(map[string]plugin.RetryPolicy),
+	}
 	for name := range plugins {
 		pluginConfig[name] = viper.GetStringMap(name)
+		if viper.GetBool(name + ".read-only") {
+			readOnlyPlugins = append(readOnlyPlugins, name)
+		}
+		if viper.IsSet(name + ".concurrency") {
+			concurrencyLimits.Plugins[name] = viper.GetInt(name + ".concurrency")
+		}
+		if viper.IsSet(name + ".retry") {
+			retryPolicies.Plugins[name] = retryPolicyFor(name+".retry", retryPolicies.Default)
+		}
 	}
 
 	// Developer flag to enable a local filesystem for testing core functionality.
@@ -177,15 +293,58 @@ func serverOptsFor(cmd *cobra.Command) (map[string]plugin.Root, server.Opts, err
 		pluginConfig["local"] = map[string]interface{}{"basepath": localfsPath}
 	}
 
+	var actionRules []plugin.ActionRule
+	if err := viper.UnmarshalKey("actions", &actionRules); err != nil {
+		return nil, server.Opts{}, fmt.Errorf("failed to unmarshal the actions key: %v", err)
+	}
+
+	// Fallback to OpenTelemetry's standard variable for the tracing endpoint.
+	tracingEndpoint := viper.GetString("tracing.endpoint")
+	if tracingEndpoint == "" {
+		tracingEndpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+
 	// Return the options
 	return plugins, server.Opts{
-		CPUProfilePath: viper.GetString("cpuprofile"),
-		LogFile:        viper.GetString("logfile"),
-		LogLevel:       viper.GetString("loglevel"),
-		PluginConfig:   pluginConfig,
+		CPUProfilePath:    viper.GetString("cpuprofile"),
+		LogFile:           viper.GetString("logfile"),
+		LogFormat:         viper.GetString("logformat"),
+		LogSink:           viper.GetString("logsink"),
+		SyslogAddress:     viper.GetString("syslog.address"),
+		LogLevel:          viper.GetString("loglevel"),
+		LogMaxSize:        viper.GetInt("logmaxsize"),
+		LogMaxAge:         viper.GetDuration("logmaxage"),
+		LogMaxBackups:     viper.GetInt("logmaxbackups"),
+		PluginConfig:      pluginConfig,
+		AuditFile:         config.AuditFile,
+		AuditKeyFile:      config.AuditKeyFile,
+		SlowLogFile:       viper.GetString(config.SlowLogFileKey),
+		SlowLogThreshold:  viper.GetDuration("slowlog.threshold"),
+		TracingEndpoint:   tracingEndpoint,
+		ReadOnly:          viper.GetBool("read-only"),
+		ReadOnlyPlugins:   readOnlyPlugins,
+		ActionRules:       actionRules,
+		ConcurrencyLimits: concurrencyLimits,
+		RetryPolicies:     retryPolicies,
 	}, nil
 }
 
+// retryPolicyFor reads the retry policy that's configured under key. Options
+// that aren't set default to the fallback's.
+func retryPolicyFor(key string, fallback plugin.RetryPolicy) plugin.RetryPolicy {
+	policy := fallback
+	if viper.IsSet(key + ".max-attempts") {
+		policy.MaxAttempts = viper.GetInt(key + ".max-attempts")
+	}
+	if viper.IsSet(key + ".initial-backoff") {
+		policy.InitialBackoff = viper.GetDuration(key + ".initial-backoff")
+	}
+	if viper.IsSet(key + ".max-backoff") {
+		policy.MaxBackoff = viper.GetDuration(key + ".max-backoff")
+	}
+	return policy
+}
+
 func promptEnabledPlugins() (map[string]plugin.Root, error) {
 	// Prompt them for the list of enabled plugins. This should look something
 	// like
@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+)
+
+// defaultServerFile returns the path of a file that the detached server keeps in
+// Wash's cache directory, next to the default socket.
+func defaultServerFile(name string) string {
+	cdir, err := os.UserCacheDir()
+	if err != nil {
+		// Fall back to the current directory; --pidfile/--logfile can override it.
+		return name
+	}
+	return filepath.Join(cdir, "wash", name)
+}
+
+// defaultLogFile returns the server's default log file, which is kept in ~/.wash so that it's
+// easy to find.
+func defaultLogFile() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return defaultServerFile("wash-server.log")
+	}
+	return filepath.Join(homeDir, ".wash", "wash-server.log")
+}
+
+func addPidfileArg(cmd *cobra.Command) {
+	cmd.Flags().String("pidfile", defaultServerFile("wash-server.pid"), "Set the location of the detached server's pidfile")
+}
+
+func serverStatusCommand() *cobra.Command {
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Reports whether a detached Wash server is running",
+		Long: `Reports whether the Wash server that was started with --daemon is running, along with its
+mountpoint and whether its API socket accepts connections. Exits 1 if it isn't running.`,
+		Args: cobra.NoArgs,
+		RunE: toRunE(serverStatusMain),
+	}
+	addPidfileArg(statusCmd)
+	return statusCmd
+}
+
+func serverStopCommand() *cobra.Command {
+	stopCmd := &cobra.Command{
+		Use:   "stop",
+		Short: "Stops a detached Wash server",
+		Long: `Stops the Wash server that was started with --daemon, waiting for it to shut down its API
+server and unmount its filesystem. Make sure you're not using the filesystem first.`,
+		Args: cobra.NoArgs,
+		RunE: toRunE(serverStopMain),
+	}
+	addPidfileArg(stopCmd)
+	stopCmd.Flags().Duration("timeout", 30*time.Second, "How long to wait for the server to stop")
+	return stopCmd
+}
+
+func serverRestartCommand() *cobra.Command {
+	restartCmd := &cobra.Command{
+		Use:   "restart",
+		Short: "Restarts a detached Wash server",
+		Long: `Stops the Wash server that was started with --daemon, then starts it again in the background
+with the same mountpoint and options.`,
+		Args: cobra.NoArgs,
+		RunE: toRunE(serverRestartMain),
+	}
+	addPidfileArg(restartCmd)
+	restartCmd.Flags().Duration("timeout", 30*time.Second, "How long to wait for the server to stop and start")
+	return restartCmd
+}
+
+// daemonState is what a detached server records next to its pidfile so that the client commands
+// can report on and restart it.
+type daemonState struct {
+	Mountpoint string   `json:"mountpoint"`
+	Socket     string   `json:"socket"`
+	LogFile    string   `json:"logfile"`
+	Args       []string `json:"args"`
+}
+
+func daemonStatePath(pidfile string) string {
+	return strings.TrimSuffix(pidfile, filepath.Ext(pidfile)) + ".json"
+}
+
+// writePidfile records the current process as the detached server.
+func writePidfile(pidfile string, state daemonState) error {
+	if err := os.MkdirAll(filepath.Dir(pidfile), 0750); err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(daemonStatePath(pidfile), data, 0640); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(pidfile, []byte(strconv.Itoa(os.Getpid())+"\n"), 0640)
+}
+
+// removePidfile removes the pidfile if it still belongs to the current process.
+func removePidfile(pidfile string) {
+	if pid, err := readPidfile(pidfile); err == nil && pid == os.Getpid() {
+		os.Remove(pidfile)
+		os.Remove(daemonStatePath(pidfile))
+	}
+}
+
+func readPidfile(pidfile string) (int, error) {
+	data, err := ioutil.ReadFile(pidfile)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+func readDaemonState(pidfile string) (daemonState, error) {
+	var state daemonState
+	data, err := ioutil.ReadFile(daemonStatePath(pidfile))
+	if err != nil {
+		return state, err
+	}
+	err = json.Unmarshal(data, &state)
+	return state, err
+}
+
+// processRunning returns true if a process with the given pid exists.
+func processRunning(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
+
+// runningDaemon returns the pid of the detached server recorded in pidfile, or 0 if it isn't
+// running. Stale pidfiles are ignored.
+func runningDaemon(pidfile string) (int, error) {
+	pid, err := readPidfile(pidfile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("could not read pidfile %v: %v", pidfile, err)
+	}
+	if !processRunning(pid) {
+		return 0, nil
+	}
+	return pid, nil
+}
+
+// daemonArgs returns the arguments that run the server in the foreground with the same options
+// as cmd. The logfile and pidfile are always passed so that the detached server writes to them.
+func daemonArgs(cmd *cobra.Command, mountpoint string, logfile string, pidfile string) []string {
+	args := []string{"server", mountpoint, "--logfile", logfile, "--pidfile", pidfile}
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		switch flag.Name {
+		case "daemon", "logfile", "pidfile":
+		case "debug-external":
+			// The slice's String() is bracketed, so pass its elements instead.
+			plugins, err := cmd.Flags().GetStringSlice(flag.Name)
+			if err != nil {
+				panic(err.Error())
+			}
+			args = append(args, "--"+flag.Name, strings.Join(plugins, ","))
+		default:
+			args = append(args, "--"+flag.Name, flag.Value.String())
+		}
+	})
+	return args
+}
+
+// daemonOutputPath returns the file that a detached server's stdout and stderr go to. It's separate
+// from logfile because logfile is rotated by the server's logger, which doesn't know about them.
+func daemonOutputPath(logfile string) string {
+	return strings.TrimSuffix(logfile, filepath.Ext(logfile)) + ".out"
+}
+
+// startDaemon starts `wash <args>` in a new session that's detached from the terminal, with its
+// output going to daemonOutputPath(logfile). It waits until the server has written its pidfile,
+// which happens once it's started.
+func startDaemon(args []string, logfile string, pidfile string, timeout time.Duration) exitCode {
+	if pid, err := runningDaemon(pidfile); err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	} else if pid != 0 {
+		cmdutil.ErrPrintf("The Wash server is already running (pid %v)\n", pid)
+		return exitCode{1}
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		cmdutil.ErrPrintf("Could not find the wash executable: %v\n", err)
+		return exitCode{1}
+	}
+	if err := os.MkdirAll(filepath.Dir(logfile), 0750); err != nil {
+		cmdutil.ErrPrintf("Could not create the log file's directory: %v\n", err)
+		return exitCode{1}
+	}
+	// Capture anything the server writes outside of its logger, such as panics.
+	outfile := daemonOutputPath(logfile)
+	out, err := os.OpenFile(outfile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		cmdutil.ErrPrintf("Could not open the server's output file: %v\n", err)
+		return exitCode{1}
+	}
+	defer out.Close()
+
+	comm := exec.Command(executable, args...)
+	comm.Stdout = out
+	comm.Stderr = out
+	comm.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := comm.Start(); err != nil {
+		cmdutil.ErrPrintf("Could not start the Wash server: %v\n", err)
+		return exitCode{1}
+	}
+
+	exitedCh := make(chan error, 1)
+	go func() {
+		exitedCh <- comm.Wait()
+	}()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	deadline := time.After(timeout)
+	for {
+		select {
+		case <-exitedCh:
+			cmdutil.ErrPrintf("The Wash server exited during startup. See %v and %v for details.\n", logfile, outfile)
+			return exitCode{1}
+		case <-deadline:
+			cmdutil.ErrPrintf("The Wash server (pid %v) is still starting. See %v for its progress.\n", comm.Process.Pid, logfile)
+			return exitCode{0}
+		case <-ticker.C:
+			if pid, _ := readPidfile(pidfile); pid == comm.Process.Pid {
+				cmdutil.Printf("Started the Wash server (pid %v). Its logs are in %v.\n", pid, logfile)
+				return exitCode{0}
+			}
+		}
+	}
+}
+
+// stopDaemon sends SIGTERM to the detached server, then waits for it to exit.
+func stopDaemon(pidfile string, timeout time.Duration) exitCode {
+	pid, err := runningDaemon(pidfile)
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	if pid == 0 {
+		cmdutil.ErrPrintf("The Wash server is not running\n")
+		return exitCode{1}
+	}
+
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+		cmdutil.ErrPrintf("Could not stop the Wash server (pid %v): %v\n", pid, err)
+		return exitCode{1}
+	}
+	for start := time.Now(); time.Since(start) < timeout; time.Sleep(100 * time.Millisecond) {
+		if !processRunning(pid) {
+			cmdutil.Printf("Stopped the Wash server (pid %v)\n", pid)
+			return exitCode{0}
+		}
+	}
+	cmdutil.ErrPrintf("Timed out waiting for the Wash server (pid %v) to stop. Is its filesystem still in use?\n", pid)
+	return exitCode{1}
+}
+
+func serverStatusMain(cmd *cobra.Command, args []string) exitCode {
+	pidfile, err := cmd.Flags().GetString("pidfile")
+	if err != nil {
+		panic(err.Error())
+	}
+
+	pid, err := runningDaemon(pidfile)
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	if pid == 0 {
+		cmdutil.Println("The Wash server is not running")
+		return exitCode{1}
+	}
+
+	cmdutil.Printf("The Wash server is running (pid %v)\n", pid)
+	state, err := readDaemonState(pidfile)
+	if err != nil {
+		cmdutil.ErrPrintf("Could not read the server's state: %v\n", err)
+		return exitCode{0}
+	}
+	cmdutil.Printf("  Mountpoint: %v\n", state.Mountpoint)
+	cmdutil.Printf("  Log file:   %v\n", state.LogFile)
+	socketStatus := "accepting connections"
+	if conn, err := net.DialTimeout("unix", state.Socket, time.Second); err != nil {
+		socketStatus = "not accepting connections"
+	} else {
+		conn.Close()
+	}
+	cmdutil.Printf("  Socket:     %v (%v)\n", state.Socket, socketStatus)
+	return exitCode{0}
+}
+
+func serverStopMain(cmd *cobra.Command, args []string) exitCode {
+	pidfile, err := cmd.Flags().GetString("pidfile")
+	if err != nil {
+		panic(err.Error())
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		panic(err.Error())
+	}
+	return stopDaemon(pidfile, timeout)
+}
+
+func serverRestartMain(cmd *cobra.Command, args []string) exitCode {
+	pidfile, err := cmd.Flags().GetString("pidfile")
+	if err != nil {
+		panic(err.Error())
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		panic(err.Error())
+	}
+
+	// Read the state before stopping the server, because the server removes it on exit.
+	state, err := readDaemonState(pidfile)
+	if err != nil {
+		cmdutil.ErrPrintf("Could not read the server's state: %v\n", err)
+		return exitCode{1}
+	}
+	if exit := stopDaemon(pidfile, timeout); exit.value != 0 {
+		return exit
+	}
+	// A server that was started in the foreground with --pidfile may have logged to stdout.
+	args, logfile := state.Args, state.LogFile
+	if logfile == "" {
+		logfile = defaultLogFile()
+		args = append(args, "--logfile", logfile)
+	}
+	return startDaemon(args, logfile, pidfile, timeout)
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPidfile(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "testPidfile")
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "Temporary directory required for further testing")
+	}
+	defer os.RemoveAll(tmpdir)
+
+	pidfile := filepath.Join(tmpdir, "wash", "wash-server.pid")
+	pid, err := runningDaemon(pidfile)
+	assert.NoError(t, err)
+	assert.Zero(t, pid)
+
+	state := daemonState{Mountpoint: "/mnt", Socket: "/tmp/wash.sock", LogFile: "/tmp/wash.log", Args: []string{"server", "/mnt"}}
+	if assert.NoError(t, writePidfile(pidfile, state)) {
+		pid, err = runningDaemon(pidfile)
+		assert.NoError(t, err)
+		assert.Equal(t, os.Getpid(), pid)
+
+		actual, err := readDaemonState(pidfile)
+		assert.NoError(t, err)
+		assert.Equal(t, state, actual)
+	}
+
+	removePidfile(pidfile)
+	assert.NoFileExists(t, pidfile)
+	assert.NoFileExists(t, daemonStatePath(pidfile))
+}
+
+func TestRunningDaemonIgnoresStalePidfiles(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "testPidfile")
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "Temporary directory required for further testing")
+	}
+	defer os.RemoveAll(tmpdir)
+
+	// Pids are much smaller than this on supported platforms.
+	pidfile := filepath.Join(tmpdir, "wash-server.pid")
+	assert.NoError(t, ioutil.WriteFile(pidfile, []byte(strconv.Itoa(1<<30)+"\n"), 0640))
+	pid, err := runningDaemon(pidfile)
+	assert.NoError(t, err)
+	assert.Zero(t, pid)
+
+	// A pidfile that belongs to another process isn't removed.
+	removePidfile(pidfile)
+	assert.FileExists(t, pidfile)
+
+	assert.NoError(t, ioutil.WriteFile(pidfile, []byte("garbage"), 0640))
+	_, err = runningDaemon(pidfile)
+	assert.Error(t, err)
+}
+
+func TestDaemonArgs(t *testing.T) {
+	cmd := serverCommand()
+	assert.NoError(t, cmd.Flags().Parse([]string{"--daemon", "--loglevel", "debug", "--logfile", "/tmp/old.log", "--debug-external", "foo,bar"}))
+	assert.Equal(t,
+		[]string{"server", "/mnt", "--logfile", "/tmp/wash.log", "--pidfile", "/tmp/wash.pid", "--debug-external", "foo,bar", "--loglevel", "debug"},
+		daemonArgs(cmd, "/mnt", "/tmp/wash.log", "/tmp/wash.pid"),
+	)
+}
+
+func TestDaemonOutputPath(t *testing.T) {
+	assert.Equal(t, "/tmp/wash/wash-server.out", daemonOutputPath("/tmp/wash/wash-server.log"))
+	assert.Equal(t, "/tmp/wash/server.out", daemonOutputPath("/tmp/wash/server"))
+}
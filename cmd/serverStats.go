@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/puppetlabs/wash/api/client"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/slowlog"
+	"github.com/spf13/cobra"
+)
+
+func serverStatsCommand() *cobra.Command {
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Prints the latency and errors of each plugin's methods",
+		Long: `Prints how many times the running Wash server has invoked each plugin's List, Read, Metadata,
+Exec and other methods, how many of those invocations failed, and how long they took. Cached results
+aren't invocations, so they aren't counted. The methods that took the longest in total are printed
+first, which shows the provider that's making your shell slow.
+
+P95 is estimated from a latency histogram. The same data is available in Prometheus' text format
+from the API's /metrics endpoint.
+
+With --slow, the paths and methods whose plugin method invocations or API requests took at least
+--slowlog-threshold (5s by default) are printed instead, slowest first. Each of those operations is
+also recorded to the slow log (slow.log in Wash's cache directory). The CACHE column is the most recent
+operation's cache status: a request is a cache hit if it didn't invoke any plugin methods.`,
+		Args: cobra.NoArgs,
+		RunE: toRunE(serverStatsMain),
+	}
+	statsCmd.Flags().String("plugin", "", "Only print the given plugin's methods")
+	statsCmd.Flags().Bool("json", false, "Print the stats as JSON")
+	statsCmd.Flags().Bool("slow", false, "Print the slowest operations")
+	statsCmd.Flags().Int("limit", 20, "With --slow, print at most this many operations. 0 prints all of them")
+	return statsCmd
+}
+
+func serverStatsMain(cmd *cobra.Command, args []string) exitCode {
+	pluginName, err := cmd.Flags().GetString("plugin")
+	if err != nil {
+		panic(err.Error())
+	}
+	asJSON, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		panic(err.Error())
+	}
+	slow, err := cmd.Flags().GetBool("slow")
+	if err != nil {
+		panic(err.Error())
+	}
+
+	conn := cmdutil.NewClient()
+	if slow {
+		limit, err := cmd.Flags().GetInt("limit")
+		if err != nil {
+			panic(err.Error())
+		}
+		return printSlowOperations(conn, pluginName, limit, asJSON)
+	}
+	metrics, err := conn.Metrics()
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	var selected []plugin.MethodStats
+	for _, stats := range metrics {
+		if pluginName == "" || stats.Plugin == pluginName {
+			selected = append(selected, stats)
+		}
+	}
+
+	if asJSON {
+		data, err := json.Marshal(selected)
+		if err != nil {
+			cmdutil.ErrPrintf("%v\n", err)
+			return exitCode{1}
+		}
+		cmdutil.Println(string(data))
+		return exitCode{0}
+	}
+	if len(selected) == 0 {
+		cmdutil.Println("No plugin methods have been invoked")
+		return exitCode{0}
+	}
+	cmdutil.Print(formatServerStats(selected))
+	return exitCode{0}
+}
+
+func formatServerStats(metrics []plugin.MethodStats) string {
+	sort.SliceStable(metrics, func(i, j int) bool {
+		return metrics[i].TotalSeconds > metrics[j].TotalSeconds
+	})
+	rows := make([][]string, len(metrics))
+	for i, stats := range metrics {
+		rows[i] = []string{
+			stats.Plugin,
+			stats.Method,
+			strconv.FormatUint(stats.Count, 10),
+			fmt.Sprintf("%v (%.1f%%)", stats.Errors, 100*float64(stats.Errors)/float64(stats.Count)),
+			formatSeconds(stats.TotalSeconds / float64(stats.Count)),
+			formatSeconds(stats.Quantile(0.95)),
+			formatSeconds(stats.MaxSeconds),
+			formatSeconds(stats.TotalSeconds),
+		}
+	}
+	headers := []cmdutil.ColumnHeader{
+		{ShortName: "plugin", FullName: "PLUGIN"},
+		{ShortName: "method", FullName: "METHOD"},
+		{ShortName: "calls", FullName: "CALLS"},
+		{ShortName: "errors", FullName: "ERRORS"},
+		{ShortName: "mean", FullName: "MEAN"},
+		{ShortName: "p95", FullName: "P95"},
+		{ShortName: "max", FullName: "MAX"},
+		{ShortName: "total", FullName: "TOTAL"},
+	}
+	return cmdutil.NewTableWithHeaders(headers, rows).Format()
+}
+
+func printSlowOperations(conn client.Client, pluginName string, limit int, asJSON bool) exitCode {
+	// Filter before limiting so that --plugin gets up to limit operations.
+	requestLimit := limit
+	if pluginName != "" {
+		requestLimit = 0
+	}
+	offenders, err := conn.SlowOperations(requestLimit)
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	selected := []slowlog.Offender{}
+	for _, offender := range offenders {
+		if pluginName == "" || offender.Plugin == pluginName {
+			selected = append(selected, offender)
+		}
+	}
+	if limit > 0 && len(selected) > limit {
+		selected = selected[:limit]
+	}
+
+	if asJSON {
+		data, err := json.Marshal(selected)
+		if err != nil {
+			cmdutil.ErrPrintf("%v\n", err)
+			return exitCode{1}
+		}
+		cmdutil.Println(string(data))
+		return exitCode{0}
+	}
+	if len(selected) == 0 {
+		cmdutil.Println("No slow operations have been recorded")
+		return exitCode{0}
+	}
+	cmdutil.Print(formatSlowOperations(selected))
+	return exitCode{0}
+}
+
+func formatSlowOperations(offenders []slowlog.Offender) string {
+	rows := make([][]string, len(offenders))
+	for i, offender := range offenders {
+		rows[i] = []string{
+			offender.Kind,
+			offender.Path,
+			offender.Method,
+			strconv.FormatUint(offender.Count, 10),
+			formatSeconds(offender.MaxDuration.Seconds()),
+			formatSeconds(offender.TotalDuration.Seconds() / float64(offender.Count)),
+			offender.Last.Cache,
+			offender.Last.Time.Format(time.RFC3339),
+		}
+	}
+	headers := []cmdutil.ColumnHeader{
+		{ShortName: "kind", FullName: "KIND"},
+		{ShortName: "path", FullName: "PATH"},
+		{ShortName: "method", FullName: "METHOD"},
+		{ShortName: "count", FullName: "COUNT"},
+		{ShortName: "max", FullName: "MAX"},
+		{ShortName: "mean", FullName: "MEAN"},
+		{ShortName: "cache", FullName: "CACHE"},
+		{ShortName: "last", FullName: "LAST"},
+	}
+	return cmdutil.NewTableWithHeaders(headers, rows).Format()
+}
+
+func formatSeconds(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second))
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	apitypes "github.com/puppetlabs/wash/api/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func statCommand() *cobra.Command {
+	use, aliases := generateShellAlias("stat")
+	statCmd := &cobra.Command{
+		Use:     use + " [<path>...]",
+		Aliases: aliases,
+		Short:   "Prints everything wash knows about the entries at the specified paths",
+		Long: `Prints the entry's name, type ID, supported actions, attributes, and the cache
+state of its list, read and metadata ops. Defaults to the current directory if no
+path is provided. Use --json to print the same information as a JSON object for
+other tools.
+
+An op's cache state is "cached" if its result is in the cache, "not cached" if it
+isn't, and "disabled" if the entry disables caching for the op.`,
+		RunE: toRunE(statMain),
+	}
+	statCmd.Flags().Bool("json", false, "Print the entries' stats as JSON")
+	return statCmd
+}
+
+// entryStat is the JSON representation of an entry's stats
+type entryStat struct {
+	apitypes.Entry
+	Cache map[string]plugin.OpCacheState `json:"cache"`
+}
+
+func statMain(cmd *cobra.Command, args []string) exitCode {
+	paths := []string{"."}
+	if len(args) > 0 {
+		paths = args
+	}
+	asJSON, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		panic(err.Error())
+	}
+
+	conn := cmdutil.NewClient()
+	ec := 0
+	for i, path := range paths {
+		e, err := conn.Info(path)
+		if err != nil {
+			cmdutil.ErrPrintf("%v: %v\n", path, err)
+			ec = 1
+			continue
+		}
+		cache, err := conn.CacheState(path)
+		if err != nil {
+			cmdutil.ErrPrintf("%v: could not get the cache state: %v\n", path, err)
+			ec = 1
+			continue
+		}
+		stat := entryStat{Entry: e, Cache: cache}
+
+		if asJSON {
+			bytes, err := json.MarshalIndent(stat, "", "  ")
+			if err != nil {
+				cmdutil.ErrPrintf("%v: %v\n", path, err)
+				ec = 1
+				continue
+			}
+			cmdutil.Println(string(bytes))
+			continue
+		}
+		if i > 0 {
+			cmdutil.Println()
+		}
+		cmdutil.Print(formatStat(stat))
+	}
+	return exitCode{ec}
+}
+
+// The attributes are printed in this order
+var statAttributes = []string{"size", "mode", "atime", "mtime", "ctime", "crtime", "os"}
+
+// The ops are printed in this order
+var statOps = []string{"List", "Read", "Metadata"}
+
+func formatStat(stat entryStat) string {
+	var rows [][]string
+	add := func(field string, value string) {
+		rows = append(rows, []string{field + ":", value})
+	}
+	add("Path", stat.Path)
+	add("Name", stat.Name)
+	if stat.CName != stat.Name {
+		add("CName", stat.CName)
+	}
+	typeID := stat.TypeID
+	if typeID == "" {
+		typeID = "unknown"
+	}
+	add("Type", typeID)
+	add("Actions", strings.Join(stat.Actions, ", "))
+
+	attributes := stat.Attributes.ToMap()
+	for _, attr := range statAttributes {
+		v, ok := attributes[attr]
+		if !ok {
+			continue
+		}
+		var value string
+		switch attr {
+		case "size":
+			value = fmt.Sprintf("%v (%v)", v, cmdutil.FormatBytes(v.(uint64)))
+		case "os":
+			bytes, err := json.Marshal(v)
+			if err != nil {
+				value = fmt.Sprintf("%v", v)
+			} else {
+				value = string(bytes)
+			}
+		default:
+			value = fmt.Sprintf("%v", v)
+		}
+		add(strings.Title(attr), value)
+	}
+
+	var cache []string
+	for _, op := range statOps {
+		state, ok := stat.Cache[op]
+		if !ok {
+			continue
+		}
+		cache = append(cache, op+": "+formatOpCacheState(state))
+	}
+	add("Cache", strings.Join(cache, "; "))
+	return cmdutil.NewTable(rows...).Format()
+}
+
+func formatOpCacheState(state plugin.OpCacheState) string {
+	switch {
+	case state.TTL < 0:
+		return "disabled"
+	case state.Cached:
+		return fmt.Sprintf("cached (TTL %v)", formatTTL(state.TTL))
+	default:
+		return fmt.Sprintf("not cached (TTL %v)", formatTTL(state.TTL))
+	}
+}
+
+func formatTTL(ttl time.Duration) string {
+	// A TTL of 0 uses the cache's default
+	if ttl == 0 {
+		ttl = time.Minute
+	}
+	return ttl.String()
+}
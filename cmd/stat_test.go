@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/puppetlabs/wash/api/client"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func TestFormatOpCacheState(t *testing.T) {
+	cases := []struct {
+		state    plugin.OpCacheState
+		expected string
+	}{
+		{plugin.OpCacheState{TTL: -1}, "disabled"},
+		{plugin.OpCacheState{TTL: -1, Cached: true}, "disabled"},
+		{plugin.OpCacheState{TTL: 0, Cached: true}, "cached (TTL 1m0s)"},
+		{plugin.OpCacheState{TTL: 5 * time.Second}, "not cached (TTL 5s)"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, formatOpCacheState(c.state))
+	}
+}
+
+func TestFormatStat(t *testing.T) {
+	var attr plugin.EntryAttributes
+	attr.SetSize(2048)
+	attr.SetMode(0640)
+	stat := entryStat{
+		Entry: apitypes.Entry{
+			Path:       "/wash/docker/containers/foo",
+			Name:       "foo",
+			CName:      "foo",
+			TypeID:     "docker::container",
+			Actions:    []string{"list", "exec"},
+			Attributes: attr,
+		},
+		Cache: map[string]plugin.OpCacheState{
+			"Metadata": {TTL: -1},
+			"List":     {TTL: 0, Cached: true},
+			"Read":     {TTL: 5 * time.Second},
+		},
+	}
+	out := formatStat(stat)
+	assert.Regexp(t, `(?m)^Path:\s+/wash/docker/containers/foo$`, out)
+	assert.Regexp(t, `(?m)^Name:\s+foo$`, out)
+	assert.NotContains(t, out, "CName:")
+	assert.Regexp(t, `(?m)^Type:\s+docker::container$`, out)
+	assert.Regexp(t, `(?m)^Actions:\s+list, exec$`, out)
+	assert.Regexp(t, `(?m)^Size:\s+2048 \(2\.0K\)$`, out)
+	assert.Regexp(t, `(?m)^Mode:\s+-rw-r-----$`, out)
+	assert.Regexp(t, `(?m)^Cache:\s+List: cached \(TTL 1m0s\); Read: not cached \(TTL 5s\); Metadata: disabled$`, out)
+	// The size's printed before the mode
+	assert.Regexp(t, `(?s)Size:.*Mode:`, out)
+
+	stat.CName = "foo#bar"
+	stat.TypeID = ""
+	out = formatStat(stat)
+	assert.Regexp(t, `(?m)^CName:\s+foo#bar$`, out)
+	assert.Regexp(t, `(?m)^Type:\s+unknown$`, out)
+}
+
+func TestStatMain(t *testing.T) {
+	stdout, stderr, restore := captureOutput()
+	defer restore()
+
+	conn := &cmdtest.MockClient{}
+	origNewClient := cmdutil.NewClient
+	cmdutil.NewClient = func() client.Client { return conn }
+	defer func() { cmdutil.NewClient = origNewClient }()
+
+	entry := apitypes.Entry{Path: "/wash/foo", Name: "foo", CName: "foo", Actions: []string{"read"}}
+	cache := map[string]plugin.OpCacheState{"Read": {TTL: time.Minute, Cached: true}}
+	conn.On("Info", "/wash/foo").Return(entry, nil)
+	conn.On("CacheState", "/wash/foo").Return(cache, nil)
+	conn.On("Info", "/wash/missing").Return(apitypes.Entry{}, errors.New("not found"))
+	conn.On("Info", "/wash/bar").Return(entry, nil)
+	conn.On("CacheState", "/wash/bar").Return(map[string]plugin.OpCacheState{}, errors.New("cache unavailable"))
+
+	cmd := statCommand()
+	assert.NoError(t, cmd.Flags().Set("json", "true"))
+	assert.Equal(t, exitCode{0}, statMain(cmd, []string{"/wash/foo"}))
+	var stat map[string]interface{}
+	if assert.NoError(t, json.Unmarshal(stdout.Bytes(), &stat)) {
+		assert.Equal(t, "/wash/foo", stat["path"])
+		assert.Equal(t, map[string]interface{}{"Read": map[string]interface{}{"ttl": float64(time.Minute), "cached": true}}, stat["cache"])
+	}
+
+	assert.Equal(t, exitCode{1}, statMain(statCommand(), []string{"/wash/missing", "/wash/bar"}))
+	assert.Contains(t, stderr.String(), "/wash/missing: not found")
+	assert.Contains(t, stderr.String(), "/wash/bar: could not get the cache state: cache unavailable")
+}
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/xlab/treeprint"
 
@@ -19,9 +21,16 @@ hierarchy. Non-singleton types are bracketed with "[]".
 
 If a subdirectory is listed in 'stree' but not visible in your directory then you are
 likely lacking permissions to enumerate that type of resource. View the 'whistory' entry
-for listing the directory to see why it's not included.`,
+for listing the directory to see why it's not included.
+
+Use --output dot to print the stree as a Graphviz graph (e.g. 'stree -o dot docker | dot -Tsvg'),
+or --output json to consume it from other tools. Both include each type's supported actions and
+signals. Types that contain themselves, like directories, are drawn as a cycle in the graph and
+marked as recursive in the JSON.`,
 		RunE: toRunE(streeMain),
 	}
+	streeCmd.Flags().StringP("output", "o", "tree", "Set the output format (tree, dot, or json)")
+	streeCmd.Flags().IntP("depth", "L", -1, "Descend at most n levels below the paths. -1 means no limit")
 	return streeCmd
 }
 
@@ -30,7 +39,23 @@ func streeMain(cmd *cobra.Command, args []string) exitCode {
 	if len(paths) == 0 {
 		paths = []string{"."}
 	}
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		panic(err.Error())
+	}
+	depth, err := cmd.Flags().GetInt("depth")
+	if err != nil {
+		panic(err.Error())
+	}
+	switch output {
+	case "tree", "dot", "json":
+	default:
+		cmdutil.ErrPrintf("unknown output format %v; must be tree, dot, or json\n", output)
+		return exitCode{1}
+	}
+
 	conn := cmdutil.NewClient()
+	var schemaPaths []string
 	schemas := make(map[string]*apitypes.EntrySchema)
 	for _, path := range paths {
 		schema, err := conn.Schema(path)
@@ -42,24 +67,48 @@ func streeMain(cmd *cobra.Command, args []string) exitCode {
 			cmdutil.ErrPrintf("%v: 'stree' requires entry schema support\n", path)
 			continue
 		}
+		schemaPaths = append(schemaPaths, path)
 		schemas[path] = schema
 	}
-	for path, schema := range schemas {
-		stree := treeprint.New()
-		fill(stree, schema, make(map[string]bool))
-		stree.SetValue(path)
-		cmdutil.Print(stree.String())
+
+	switch output {
+	case "dot":
+		for _, path := range schemaPaths {
+			cmdutil.Print(streeDot(path, schemas[path], depth))
+		}
+	case "json":
+		nodes := make(map[string]*streeNode)
+		for _, path := range schemaPaths {
+			nodes[path] = newStreeNode(schemas[path], depth, make(map[string]bool))
+		}
+		marshaller, err := cmdutil.NewMarshaller(cmdutil.JSON)
+		if err != nil {
+			panic(err.Error())
+		}
+		out, err := marshaller.Marshal(nodes)
+		if err != nil {
+			cmdutil.ErrPrintf("%v\n", err)
+			return exitCode{1}
+		}
+		cmdutil.Println(out)
+	default:
+		for _, path := range schemaPaths {
+			stree := treeprint.New()
+			fill(stree, schemas[path], depth, make(map[string]bool))
+			stree.SetValue(path)
+			cmdutil.Print(stree.String())
+		}
 	}
 	return exitCode{0}
 }
 
-func fill(stree treeprint.Tree, schema *apitypes.EntrySchema, visited map[string]bool) treeprint.Tree {
+func fill(stree treeprint.Tree, schema *apitypes.EntrySchema, depth int, visited map[string]bool) treeprint.Tree {
 	value := schema.Label()
 	if !schema.Singleton() {
 		value = fmt.Sprintf("[%v]", value)
 	}
 	stree.SetValue(value)
-	if visited[schema.Path()] {
+	if visited[schema.Path()] || depth == 0 {
 		return stree
 	}
 	visited[schema.Path()] = true
@@ -68,7 +117,93 @@ func fill(stree treeprint.Tree, schema *apitypes.EntrySchema, visited map[string
 		// set a stub value. Note that the value will be reset to the
 		// correct value in the recursive call, so this is OK.
 		subtree := stree.AddBranch("foo")
-		fill(subtree, child, visited)
+		fill(subtree, child, depth-1, visited)
 	}
 	return stree
 }
+
+// streeNode is the JSON representation of an stree. A recursive node is a
+// type that's one of its own ancestors, so its children are omitted.
+type streeNode struct {
+	Label     string       `json:"label"`
+	TypeID    string       `json:"type_id"`
+	Singleton bool         `json:"singleton"`
+	Actions   []string     `json:"actions"`
+	Signals   []string     `json:"signals,omitempty"`
+	Recursive bool         `json:"recursive,omitempty"`
+	Children  []*streeNode `json:"children,omitempty"`
+}
+
+func newStreeNode(schema *apitypes.EntrySchema, depth int, visited map[string]bool) *streeNode {
+	node := &streeNode{
+		Label:     schema.Label(),
+		TypeID:    schema.TypeID(),
+		Singleton: schema.Singleton(),
+		Actions:   schema.Actions(),
+		Signals:   streeSignals(schema),
+		Recursive: visited[schema.Path()],
+	}
+	if node.Recursive || depth == 0 {
+		return node
+	}
+	visited[schema.Path()] = true
+	for _, child := range schema.Children() {
+		node.Children = append(node.Children, newStreeNode(child, depth-1, visited))
+	}
+	delete(visited, schema.Path())
+	return node
+}
+
+func streeSignals(schema *apitypes.EntrySchema) []string {
+	var signals []string
+	for _, signal := range schema.Signals() {
+		signals = append(signals, signal.Name())
+	}
+	return signals
+}
+
+// streeDot returns the stree as a Graphviz digraph. Each type is a node
+// labeled with its supported actions and signals. A recursive type's edge
+// points back to its ancestor.
+func streeDot(path string, schema *apitypes.EntrySchema, depth int) string {
+	var nodes, edges strings.Builder
+	ids := make(map[string]string)
+	var visit func(*apitypes.EntrySchema, int) string
+	visit = func(schema *apitypes.EntrySchema, depth int) string {
+		if id, ok := ids[schema.Path()]; ok {
+			return id
+		}
+		id := "n" + strconv.Itoa(len(ids))
+		ids[schema.Path()] = id
+
+		label := schema.Label()
+		if !schema.Singleton() {
+			label = "[" + label + "]"
+		}
+		lines := []string{label}
+		if actions := schema.Actions(); len(actions) > 0 {
+			lines = append(lines, strings.Join(actions, ", "))
+		}
+		if signals := streeSignals(schema); len(signals) > 0 {
+			lines = append(lines, "signals: "+strings.Join(signals, ", "))
+		}
+		fmt.Fprintf(&nodes, "\t%v [label=%v];\n", id, dotQuote(strings.Join(lines, "\n")))
+
+		if depth != 0 {
+			for _, child := range schema.Children() {
+				fmt.Fprintf(&edges, "\t%v -> %v;\n", id, visit(child, depth-1))
+			}
+		}
+		return id
+	}
+	visit(schema, depth)
+
+	return "digraph " + dotQuote(path) + " {\n\tnode [shape=box];\n" + nodes.String() + edges.String() + "}\n"
+}
+
+// dotQuote quotes a string as a DOT ID. Newlines become centered line breaks.
+func dotQuote(str string) string {
+	str = strings.Replace(str, `\`, `\\`, -1)
+	str = strings.Replace(str, `"`, `\"`, -1)
+	return `"` + strings.Replace(str, "\n", `\n`, -1) + `"`
+}
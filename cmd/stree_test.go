@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitypes "github.com/puppetlabs/wash/api/types"
+)
+
+func streeTestSchema(t *testing.T) *apitypes.EntrySchema {
+	var schema apitypes.EntrySchema
+	err := json.Unmarshal([]byte(`{
+		"fs::root": {"label": "fs", "singleton": true, "actions": ["list"], "children": ["fs::dir", "fs::file"]},
+		"fs::dir": {"label": "dir", "actions": ["list", "delete"], "children": ["fs::dir", "fs::file"]},
+		"fs::file": {"label": "file", "actions": ["read"], "children": []}
+	}`), &schema)
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "A schema is required for further testing")
+	}
+	return &schema
+}
+
+func TestNewStreeNode(t *testing.T) {
+	schema := streeTestSchema(t)
+	file := &streeNode{Label: "file", TypeID: "fs::file", Actions: []string{"read"}}
+	expected := &streeNode{
+		Label:     "fs",
+		TypeID:    "fs::root",
+		Singleton: true,
+		Actions:   []string{"list"},
+		Children: []*streeNode{
+			{
+				Label:   "dir",
+				TypeID:  "fs::dir",
+				Actions: []string{"list", "delete"},
+				Children: []*streeNode{
+					{Label: "dir", TypeID: "fs::dir", Actions: []string{"list", "delete"}, Recursive: true},
+					file,
+				},
+			},
+			file,
+		},
+	}
+	assert.Equal(t, expected, newStreeNode(schema, -1, make(map[string]bool)))
+
+	// Depth limits the children
+	actual := newStreeNode(schema, 1, make(map[string]bool))
+	if assert.Len(t, actual.Children, 2) {
+		assert.Empty(t, actual.Children[0].Children)
+	}
+}
+
+func TestStreeDot(t *testing.T) {
+	schema := streeTestSchema(t)
+	expected := `digraph "docker/\"fs\"" {
+	node [shape=box];
+	n0 [label="fs\nlist"];
+	n1 [label="[dir]\nlist, delete"];
+	n2 [label="[file]\nread"];
+	n3 [label="[file]\nread"];
+	n1 -> n1;
+	n1 -> n2;
+	n0 -> n1;
+	n0 -> n3;
+}
+`
+	assert.Equal(t, expected, streeDot(`docker/"fs"`, schema, -1))
+
+	expected = `digraph "fs" {
+	node [shape=box];
+	n0 [label="fs\nlist"];
+}
+`
+	assert.Equal(t, expected, streeDot("fs", schema, 0))
+}
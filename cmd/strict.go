@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/puppetlabs/wash/cmd/internal/config"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+)
+
+// strictReportEnv names the file that Wash commands in a strict script record
+// their failures to.
+const strictReportEnv = "WASH_STRICT_REPORT"
+
+// strictFailure is a Wash command that failed in a strict script. Failures are
+// reported as JSON lines.
+type strictFailure struct {
+	Command  string   `json:"command"`
+	Args     []string `json:"args"`
+	ExitCode int      `json:"exit_code"`
+}
+
+// recordStrictFailure records that the current Wash command exited with code.
+// It only records failures of commands that were run by a strict script.
+func recordStrictFailure(code int) {
+	path := os.Getenv(strictReportEnv)
+	if code == 0 || path == "" || !config.Embedded || len(os.Args) < 2 {
+		return
+	}
+
+	data, err := json.Marshal(strictFailure{Command: os.Args[1], Args: os.Args[2:], ExitCode: code})
+	if err != nil {
+		panic(err.Error())
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		cmdutil.ErrPrintf("could not record failure in %v: %v\n", path, err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		cmdutil.ErrPrintf("could not record failure in %v: %v\n", path, err)
+	}
+}
+
+// reportStrictFailures copies the failures that were recorded in path to the
+// report file. If report is empty, the failures are printed on stderr. The
+// report file is created even if nothing failed.
+func reportStrictFailures(path string, report string) bool {
+	var out io.Writer = cmdutil.Stderr
+	if report != "" {
+		reportFile, err := os.Create(report)
+		if err != nil {
+			cmdutil.ErrPrintf("could not write report to %v: %v\n", report, err)
+			return false
+		}
+		defer reportFile.Close()
+		out = reportFile
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// Nothing failed
+			return true
+		}
+		cmdutil.ErrPrintf("could not read failures from %v: %v\n", path, err)
+		return false
+	}
+	defer f.Close()
+	if _, err := io.Copy(out, f); err != nil {
+		cmdutil.ErrPrintf("could not write report: %v\n", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/puppetlabs/wash/cmd/internal/config"
+)
+
+func TestStrictFailures(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "testStrict")
+	if !assert.NoError(t, err) {
+		assert.FailNow(t, "Temporary directory required for further testing")
+	}
+	defer os.RemoveAll(tmpdir)
+
+	failures := filepath.Join(tmpdir, "failures.jsonl")
+	report := filepath.Join(tmpdir, "report.jsonl")
+
+	// Nothing's recorded outside of a strict script
+	oldArgs, oldEmbedded := os.Args, config.Embedded
+	defer func() { os.Args, config.Embedded = oldArgs, oldEmbedded }()
+	os.Args = []string{"wash", "wls", "docker/containers"}
+	config.Embedded = true
+	recordStrictFailure(1)
+	assert.True(t, reportStrictFailures(failures, report))
+	data, err := ioutil.ReadFile(report)
+	assert.NoError(t, err)
+	assert.Empty(t, data)
+
+	os.Setenv(strictReportEnv, failures)
+	defer os.Unsetenv(strictReportEnv)
+	recordStrictFailure(0)
+	recordStrictFailure(2)
+	assert.True(t, reportStrictFailures(failures, report))
+	data, err = ioutil.ReadFile(report)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"command":"wls","args":["docker/containers"],"exit_code":2}`+"\n", string(data))
+}
@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/puppetlabs/wash/api/client"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func syncCommand() *cobra.Command {
+	use, aliases := generateShellAlias("sync")
+	syncCmd := &cobra.Command{
+		Use:     use + " [flags] <source> <dest>",
+		Aliases: aliases,
+		Short:   "Synchronizes a wash path and a local directory",
+		Long: `Makes dest look like source, like rsync. One of source and dest is a wash path
+and the other is a local directory, so sync can back up a remote store or seed it
+from a local copy.
+
+A file is transferred if it's missing from dest, if its size differs, or if the
+source's mtime is newer than dest's mtime. Files whose size or mtime is unknown
+are always transferred. With --checksum, files are compared by their content's
+checksum instead, which requires reading both copies.
+
+Files and directories that are missing from a wash dest are created by their
+parent's create action, so their parent must support it. With --delete, files in dest that are not in source are
+deleted; wash entries are deleted with their delete action. Use --dry-run (-n)
+to print what would be done without doing it.`,
+		Args: cobra.ExactArgs(2),
+		RunE: toRunE(syncMain),
+	}
+	syncCmd.Flags().BoolP("dry-run", "n", false, "Print what would be transferred or deleted without doing it")
+	syncCmd.Flags().Bool("delete", false, "Delete files in dest that are not in source")
+	syncCmd.Flags().BoolP("checksum", "c", false, "Compare files by their content's checksum instead of their size and mtime")
+	syncCmd.Flags().IntP("parallel", "p", 4, "Transfer up to n files concurrently")
+	return syncCmd
+}
+
+func syncMain(cmd *cobra.Command, args []string) exitCode {
+	src, dst := args[0], args[1]
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		panic(err.Error())
+	}
+	del, err := cmd.Flags().GetBool("delete")
+	if err != nil {
+		panic(err.Error())
+	}
+	checksum, err := cmd.Flags().GetBool("checksum")
+	if err != nil {
+		panic(err.Error())
+	}
+	parallel, err := cmd.Flags().GetInt("parallel")
+	if err != nil {
+		panic(err.Error())
+	}
+	if parallel < 1 {
+		parallel = 1
+	}
+	if cmdutil.IsWashPath(src) == cmdutil.IsWashPath(dst) {
+		cmdutil.ErrPrintf("one of source and dest must be a wash path, and the other a local directory\n")
+		return exitCode{1}
+	}
+
+	conn := cmdutil.NewClient()
+	c := &copier{conn: conn, recursive: true, quiet: true}
+	srcFiles, err := syncListing(conn, src)
+	if err != nil {
+		cmdutil.ErrPrintf("%v: %v\n", src, err)
+		return exitCode{1}
+	}
+	dstFiles, err := syncListing(conn, dst)
+	if err != nil {
+		if !os.IsNotExist(err) || cmdutil.IsWashPath(dst) {
+			cmdutil.ErrPrintf("%v: %v\n", dst, err)
+			return exitCode{1}
+		}
+		// The local dest will be created
+		dstFiles = make(map[string]syncFile)
+	}
+
+	var mkdirs, transfers, deletions []string
+	for _, rel := range sortedKeys(srcFiles) {
+		s := srcFiles[rel]
+		d, ok := dstFiles[rel]
+		if s.isDir {
+			if !ok || !d.isDir {
+				mkdirs = append(mkdirs, rel)
+			}
+			continue
+		}
+		if !ok || d.isDir {
+			transfers = append(transfers, rel)
+			continue
+		}
+		differs, err := s.differsFrom(d, checksum, conn, filepath.Join(src, rel), filepath.Join(dst, rel))
+		if err != nil {
+			cmdutil.ErrPrintf("%v: %v\n", rel, err)
+			continue
+		}
+		if differs {
+			transfers = append(transfers, rel)
+		}
+	}
+	if del {
+		deletions = syncDeletions(srcFiles, dstFiles)
+	}
+
+	ec := 0
+	var mux sync.Mutex
+	fail := func(err error) {
+		mux.Lock()
+		ec = 1
+		mux.Unlock()
+		cmdutil.SafeErrPrintf("%v\n", err)
+	}
+
+	// Create the missing directories before the transfers so that concurrent
+	// transfers don't race to create them. They're sorted, so parents are
+	// created before their children.
+	for _, rel := range mkdirs {
+		cmdutil.Printf("mkdir %v\n", rel)
+		if dryRun {
+			continue
+		}
+		if err := mkdir(conn, filepath.Join(dst, rel), true); err != nil {
+			fail(err)
+		}
+	}
+
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for _, rel := range transfers {
+		from, to := filepath.Join(src, rel), filepath.Join(dst, rel)
+		cmdutil.SafePrintf("copy %v\n", rel)
+		if dryRun {
+			continue
+		}
+		wg.Add(1)
+		go func(from, to string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if !cmdutil.IsWashPath(to) {
+				if err := os.MkdirAll(filepath.Dir(to), 0750); err != nil {
+					fail(err)
+					return
+				}
+			}
+			if err := c.copyFile(from, to); err != nil {
+				fail(err)
+			}
+		}(from, to)
+	}
+	wg.Wait()
+
+	for _, rel := range deletions {
+		cmdutil.Printf("delete %v\n", rel)
+		if dryRun {
+			continue
+		}
+		path := filepath.Join(dst, rel)
+		if cmdutil.IsWashPath(path) {
+			if _, err := conn.Delete(path); err != nil {
+				fail(fmt.Errorf("%v: %v", path, err))
+			}
+		} else if err := os.RemoveAll(path); err != nil {
+			fail(err)
+		}
+	}
+	return exitCode{ec}
+}
+
+// syncFile describes a file or directory in a sync listing
+type syncFile struct {
+	isDir    bool
+	size     uint64
+	hasSize  bool
+	mtime    time.Time
+	hasMtime bool
+}
+
+// differsFrom returns true if s needs to be transferred to d
+func (s syncFile) differsFrom(d syncFile, checksum bool, conn client.Client, srcPath string, dstPath string) (bool, error) {
+	if checksum {
+		srcSum, err := syncChecksum(conn, srcPath)
+		if err != nil {
+			return false, err
+		}
+		dstSum, err := syncChecksum(conn, dstPath)
+		if err != nil {
+			return false, err
+		}
+		return !bytes.Equal(srcSum, dstSum), nil
+	}
+	if !s.hasSize || !d.hasSize || !s.hasMtime || !d.hasMtime {
+		return true, nil
+	}
+	return s.size != d.size || s.mtime.After(d.mtime), nil
+}
+
+// syncDeletions returns the files and directories in dst that aren't in src.
+// Children are ordered before their parents so that they're deleted first.
+func syncDeletions(src map[string]syncFile, dst map[string]syncFile) []string {
+	var deletions []string
+	keys := sortedKeys(dst)
+	for i := len(keys) - 1; i >= 0; i-- {
+		if _, ok := src[keys[i]]; !ok {
+			deletions = append(deletions, keys[i])
+		}
+	}
+	return deletions
+}
+
+func syncChecksum(conn client.Client, path string) ([]byte, error) {
+	var rdr io.ReadCloser
+	var err error
+	if cmdutil.IsWashPath(path) {
+		rdr, err = conn.Read(path)
+	} else {
+		rdr, err = os.Open(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, rdr); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// syncListing returns the files and directories under root, keyed by their
+// path relative to root.
+func syncListing(conn client.Client, root string) (map[string]syncFile, error) {
+	files := make(map[string]syncFile)
+	if !cmdutil.IsWashPath(root) {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil || rel == "." {
+				return err
+			}
+			files[rel] = syncFile{
+				isDir:    info.IsDir(),
+				size:     uint64(info.Size()),
+				hasSize:  true,
+				mtime:    info.ModTime(),
+				hasMtime: true,
+			}
+			return nil
+		})
+		return files, err
+	}
+
+	var walk func(dir string, rel string) error
+	walk = func(dir string, rel string) error {
+		children, err := conn.List(dir)
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			childRel := filepath.Join(rel, child.CName)
+			f := syncFileOf(child)
+			files[childRel] = f
+			if f.isDir {
+				if err := walk(filepath.Join(dir, child.CName), childRel); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return files, walk(root, "")
+}
+
+func syncFileOf(e apitypes.Entry) syncFile {
+	f := syncFile{isDir: e.Supports(plugin.ListAction())}
+	if e.Attributes.HasSize() {
+		f.size, f.hasSize = e.Attributes.Size(), true
+	}
+	if e.Attributes.HasMtime() {
+		f.mtime, f.hasMtime = e.Attributes.Mtime(), true
+	}
+	return f
+}
+
+func sortedKeys(files map[string]syncFile) []string {
+	keys := make([]string, 0, len(files))
+	for k := range files {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func TestSyncFile_DiffersFrom(t *testing.T) {
+	now := time.Now()
+	known := syncFile{size: 5, hasSize: true, mtime: now, hasMtime: true}
+	cases := []struct {
+		name     string
+		src      syncFile
+		dst      syncFile
+		expected bool
+	}{
+		{"same size and mtime", known, known, false},
+		{"older source", known, syncFile{size: 5, hasSize: true, mtime: now.Add(time.Hour), hasMtime: true}, false},
+		{"newer source", known, syncFile{size: 5, hasSize: true, mtime: now.Add(-time.Hour), hasMtime: true}, true},
+		{"different size", known, syncFile{size: 6, hasSize: true, mtime: now, hasMtime: true}, true},
+		{"unknown size", syncFile{mtime: now, hasMtime: true}, known, true},
+		{"unknown mtime", known, syncFile{size: 5, hasSize: true}, true},
+	}
+	for _, c := range cases {
+		differs, err := c.src.differsFrom(c.dst, false, &cmdtest.MockClient{}, "", "")
+		if assert.NoError(t, err, c.name) {
+			assert.Equal(t, c.expected, differs, c.name)
+		}
+	}
+}
+
+func TestSyncFile_DiffersFrom_Checksum(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+	tmpdir, err := ioutil.TempDir("", "testSync")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(tmpdir)
+	local := filepath.Join(tmpdir, "a.txt")
+	if !assert.NoError(t, ioutil.WriteFile(local, []byte("hello"), 0640)) {
+		return
+	}
+
+	// The content is compared even if the size and mtime match
+	now := time.Now()
+	f := syncFile{size: 5, hasSize: true, mtime: now, hasMtime: true}
+	conn := &cmdtest.MockClient{}
+	conn.On("Read", "/wash/s3/bucket/a.txt").Return(ioutil.NopCloser(strings.NewReader("world")), nil).Once()
+	differs, err := f.differsFrom(f, true, conn, "/wash/s3/bucket/a.txt", local)
+	if assert.NoError(t, err) {
+		assert.True(t, differs)
+	}
+
+	conn.On("Read", "/wash/s3/bucket/a.txt").Return(ioutil.NopCloser(strings.NewReader("hello")), nil).Once()
+	differs, err = syncFile{}.differsFrom(syncFile{}, true, conn, "/wash/s3/bucket/a.txt", local)
+	if assert.NoError(t, err) {
+		assert.False(t, differs)
+	}
+	conn.AssertExpectations(t)
+}
+
+func TestSyncListing_Wash(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	mtime := time.Now()
+	var fileAttr plugin.EntryAttributes
+	fileAttr.SetSize(5).SetMtime(mtime)
+	conn := &cmdtest.MockClient{}
+	conn.On("List", "/wash/s3/bucket").Return([]apitypes.Entry{
+		{CName: "a.txt", Actions: []string{"read"}, Attributes: fileAttr},
+		{CName: "dir", Actions: []string{"list"}},
+	}, nil)
+	conn.On("List", "/wash/s3/bucket/dir").Return([]apitypes.Entry{
+		{CName: "b.txt", Actions: []string{"read"}},
+	}, nil)
+
+	files, err := syncListing(conn, "/wash/s3/bucket")
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]syncFile{
+			"a.txt":     {size: 5, hasSize: true, mtime: mtime, hasMtime: true},
+			"dir":       {isDir: true},
+			"dir/b.txt": {},
+		}, files)
+	}
+}
+
+func TestSyncListing_Local(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "testSync")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(tmpdir)
+	if !assert.NoError(t, os.Mkdir(filepath.Join(tmpdir, "dir"), 0750)) {
+		return
+	}
+	if !assert.NoError(t, ioutil.WriteFile(filepath.Join(tmpdir, "dir", "a.txt"), []byte("hello"), 0640)) {
+		return
+	}
+
+	files, err := syncListing(&cmdtest.MockClient{}, tmpdir)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"dir", "dir/a.txt"}, sortedKeys(files))
+		assert.True(t, files["dir"].isDir)
+		assert.Equal(t, uint64(5), files["dir/a.txt"].size)
+		assert.True(t, files["dir/a.txt"].hasMtime)
+	}
+
+	_, err = syncListing(&cmdtest.MockClient{}, filepath.Join(tmpdir, "missing"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSyncDeletions_DeletesChildrenBeforeParents(t *testing.T) {
+	src := map[string]syncFile{
+		"keep":       {isDir: true},
+		"keep/a.txt": {},
+	}
+	dst := map[string]syncFile{
+		"keep":          {isDir: true},
+		"keep/a.txt":    {},
+		"keep/b.txt":    {},
+		"old":           {isDir: true},
+		"old/sub":       {isDir: true},
+		"old/sub/c.txt": {},
+		"old-d.txt":     {},
+	}
+	assert.Equal(t, []string{"old/sub/c.txt", "old/sub", "old-d.txt", "old", "keep/b.txt"}, syncDeletions(src, dst))
+	assert.Empty(t, syncDeletions(dst, dst))
+}
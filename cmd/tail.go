@@ -2,13 +2,19 @@ package cmd
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Benchkram/errz"
+	"github.com/fatih/color"
 	"github.com/hpcloud/tail"
 	"github.com/puppetlabs/wash/api/client"
 	apitypes "github.com/puppetlabs/wash/api/types"
@@ -21,10 +27,29 @@ func tailCommand() *cobra.Command {
 		Use:   "tail -f [<file>...]",
 		Short: "Displays new output of files or resources with the stream action",
 		Long: `Output any new updates to files and/or resources (that support the stream action). Mimics
-'tail -f' for remote logs, and calls '/usr/bin/tail' if '-f' is omitted.`,
+'tail -f' for remote logs, and calls '/usr/bin/tail' if '-f' is omitted.
+
+With -f, a path can also be a glob, e.g. 'kubernetes/*/*/pods/*/*/log' (quote it so that your
+shell doesn't expand it). Globs are re-evaluated every --rescan interval so that new entries
+that match them are followed as they appear. Output from multiple sources is separated with
+headers, or each line is prefixed with its colored source when --prefix is set or a glob is
+used.
+
+Use --include and --exclude to only print the lines that match (or don't match) a regular
+expression. Many streams start with some of their recent output. Use --since to skip the lines
+whose leading RFC 3339 timestamp is older than the given duration; lines without a timestamp
+are always printed.`,
+		Example: `tail -f --since 5m --include error 'docker/containers/*/log'
+  follow the logs of every Docker container, including containers that are started later, and
+  print the lines that contain "error"`,
 		RunE: toRunE(tailMain),
 	}
 	tailCmd.Flags().BoolP("follow", "f", false, "Follow new output")
+	tailCmd.Flags().Bool("prefix", false, "Prefix each line with its source instead of printing headers")
+	tailCmd.Flags().String("include", "", "Only print lines that match the given regular expression")
+	tailCmd.Flags().String("exclude", "", "Do not print lines that match the given regular expression")
+	tailCmd.Flags().Duration("since", 0, "Skip lines whose leading timestamp is older than the given duration")
+	tailCmd.Flags().Duration("rescan", 10*time.Second, "How often to look for new entries that match the globs")
 	return tailCmd
 }
 
@@ -175,27 +200,165 @@ func tailMain(cmd *cobra.Command, args []string) exitCode {
 		args = []string{"."}
 	}
 
-	conn := cmdutil.NewClient()
-	agg := make(chan line)
+	t := &tailer{
+		conn:     cmdutil.NewClient(),
+		agg:      make(chan line),
+		followed: make(map[string]io.Closer),
+	}
+	if t.prefix, err = cmd.Flags().GetBool("prefix"); err != nil {
+		panic(err.Error())
+	}
+	if t.include, err = tailFilter(cmd, "include"); err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	if t.exclude, err = tailFilter(cmd, "exclude"); err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	since, err := cmd.Flags().GetDuration("since")
+	if err != nil {
+		panic(err.Error())
+	}
+	if since > 0 {
+		t.since = time.Now().Add(-since)
+	}
+	rescan, err := cmd.Flags().GetDuration("rescan")
+	if err != nil {
+		panic(err.Error())
+	}
 
-	// Try streaming as a resource, then as a file if that failed for predictable reasons
+	var globs []string
 	for _, path := range args {
-		if closer := tailStream(conn, agg, path); closer != nil {
-			defer func() { errz.Log(closer.Close()) }()
+		if strings.ContainsAny(path, "*?[") {
+			globs = append(globs, path)
 			continue
 		}
+		t.follow(path, true)
+	}
+	if len(globs) > 0 {
+		t.prefix = true
+		t.followGlobs(globs)
+		if rescan > 0 {
+			go func() {
+				for range time.Tick(rescan) {
+					t.followGlobs(globs)
+				}
+			}()
+		}
+	}
+	defer t.close()
+
+	t.print()
+	return exitCode{0}
+}
+
+// tailFilter returns the regular expression in the given flag, or nil if the
+// flag's not set.
+func tailFilter(cmd *cobra.Command, flag string) (*regexp.Regexp, error) {
+	expr, err := cmd.Flags().GetString(flag)
+	if err != nil {
+		panic(err.Error())
+	}
+	if expr == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%v expression %v: %v", flag, expr, err)
+	}
+	return re, nil
+}
+
+// tailer follows files and streams, and prints their lines
+type tailer struct {
+	conn    client.Client
+	agg     chan line
+	prefix  bool
+	include *regexp.Regexp
+	exclude *regexp.Regexp
+	since   time.Time
+	// followed contains every path that was followed, including the paths
+	// that could not be followed so that globs don't retry them
+	followed map[string]io.Closer
+	mux      sync.Mutex
+}
 
-		// Unable to read as a stream, try as a file.
-		if closer := tailFile(agg, path); closer != nil {
-			defer func() { errz.Log(closer.Close()) }()
+// follow tries streaming path as a resource, then as a file if that failed
+// for predictable reasons. If explicit is false, then path is skipped if it
+// can't be streamed and is not a file.
+func (t *tailer) follow(path string, explicit bool) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if _, ok := t.followed[path]; ok {
+		return
+	}
+	if closer := tailStream(t.conn, t.agg, path); closer != nil {
+		t.followed[path] = closer
+		return
+	}
+	if !explicit {
+		if finfo, err := os.Stat(path); err != nil || finfo.IsDir() {
+			t.followed[path] = ioutil.NopCloser(nil)
+			return
+		}
+	}
+
+	// Unable to read as a stream, try as a file.
+	t.followed[path] = tailFile(t.agg, path)
+}
+
+func (t *tailer) followGlobs(globs []string) {
+	for _, glob := range globs {
+		matches, err := filepath.Glob(glob)
+		if err != nil {
+			cmdutil.SafeErrPrintf("invalid glob %v: %v\n", glob, err)
+			continue
 		}
+		for _, match := range matches {
+			t.follow(match, false)
+		}
+	}
+}
+
+func (t *tailer) close() {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	for _, closer := range t.followed {
+		errz.Log(closer.Close())
 	}
+}
+
+// The colors that are used to distinguish the sources in prefix mode
+var tailColors = []color.Attribute{
+	color.FgCyan,
+	color.FgGreen,
+	color.FgYellow,
+	color.FgBlue,
+	color.FgMagenta,
+}
 
-	// Print from aggregate channel
+// print prints the lines from the aggregate channel
+func (t *tailer) print() {
 	var last string
-	for ln := range agg {
+	prefixes := make(map[string]string)
+	for ln := range t.agg {
 		if ln.Err != nil {
-			cmdutil.ErrPrintf("%v\n", ln.source, ln.Err)
+			cmdutil.ErrPrintf("%v: %v\n", ln.source, ln.Err)
+			continue
+		}
+		if !t.matches(ln.Text) {
+			continue
+		}
+
+		if t.prefix {
+			prefix, ok := prefixes[ln.source]
+			if !ok {
+				c := color.New(tailColors[len(prefixes)%len(tailColors)])
+				prefix = c.Sprint(ln.source + " | ")
+				prefixes[ln.source] = prefix
+			}
+			cmdutil.Println(prefix + ln.Text)
 			continue
 		}
 
@@ -210,6 +373,34 @@ func tailMain(cmd *cobra.Command, args []string) exitCode {
 
 		cmdutil.Println(ln.Text)
 	}
+}
 
-	return exitCode{0}
+// matches returns true if text should be printed
+func (t *tailer) matches(text string) bool {
+	if t.include != nil && !t.include.MatchString(text) {
+		return false
+	}
+	if t.exclude != nil && t.exclude.MatchString(text) {
+		return false
+	}
+	if !t.since.IsZero() {
+		if ts, ok := lineTimestamp(text); ok && ts.Before(t.since) {
+			return false
+		}
+	}
+	return true
+}
+
+// lineTimestamp parses the RFC 3339 timestamp at the start of text, if it has
+// one. The timestamp can be enclosed in brackets.
+func lineTimestamp(text string) (time.Time, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return time.Time{}, false
+	}
+	ts, err := time.Parse(time.RFC3339Nano, strings.Trim(fields[0], "[]"))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return ts, true
 }
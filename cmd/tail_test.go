@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"regexp"
 	"testing"
 	"time"
 
@@ -73,3 +74,28 @@ func assertLine(t *testing.T, out <-chan line, source, msg string, before, after
 	assert.True(t, before.Before(ln.Time))
 	assert.True(t, after.After(ln.Time))
 }
+
+func TestTailerMatches(t *testing.T) {
+	tl := &tailer{
+		include: regexp.MustCompile("error"),
+		exclude: regexp.MustCompile("ignored"),
+		since:   time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	assert.True(t, tl.matches("an error"))
+	assert.False(t, tl.matches("a warning"))
+	assert.False(t, tl.matches("an ignored error"))
+	assert.True(t, tl.matches("2020-01-01T12:00:01Z an error"))
+	assert.True(t, tl.matches("[2020-01-01T12:30:00.5+00:00] an error"))
+	assert.False(t, tl.matches("2020-01-01T11:59:59Z an error"))
+}
+
+func TestLineTimestamp(t *testing.T) {
+	ts, ok := lineTimestamp("2020-01-01T12:00:00.123Z GET /")
+	if assert.True(t, ok) {
+		assert.Equal(t, time.Date(2020, 1, 1, 12, 0, 0, 123000000, time.UTC), ts)
+	}
+	_, ok = lineTimestamp("GET / 2020-01-01T12:00:00Z")
+	assert.False(t, ok)
+	_, ok = lineTimestamp("")
+	assert.False(t, ok)
+}
@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/spf13/cobra"
+
+	"github.com/puppetlabs/wash/api/client"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// defaultCPUKeys and defaultMemKeys are the metadata keys that top checks for
+// an entry's CPU and memory usage, in order. They cover the plugins that report
+// their entries' usage in their metadata.
+var defaultCPUKeys = []string{"cpu_percent", "cpuPercent", "cpu.percent", "cpu_usage"}
+var defaultMemKeys = []string{"memory_percent", "memoryPercent", "memory.percent", "mem_percent"}
+
+func topCommand() *cobra.Command {
+	use, aliases := generateShellAlias("top")
+	topCmd := &cobra.Command{
+		Use:     use + " [<path>...]",
+		Aliases: aliases,
+		Short:   "Displays the CPU and memory usage of the entries at the specified paths",
+		Long: `Displays a live-refreshing view of the CPU and memory usage of the entries
+at the specified paths. If a path is a parent, then its children are displayed
+instead. Defaults to the current directory if no path is provided.
+
+An entry's usage is read from its metadata using the first of the --cpu-key and
+--mem-key keys that it has. Keys are separated by '.', e.g. "stats.cpu". If an
+entry's metadata doesn't have any of the keys, but the entry supports exec, then
+top runs a small POSIX script on it that reports the load average and the
+percentage of memory that's in use (like 'wash ps' does). Entries that support
+neither are skipped.`,
+		RunE: toRunE(topMain),
+	}
+	topCmd.Flags().DurationP("interval", "n", 5*time.Second, "How often to refresh the view")
+	topCmd.Flags().Bool("once", false, "Print the view once and exit")
+	topCmd.Flags().StringP("sort", "s", "cpu", "Sort the entries by cpu, mem, or path")
+	topCmd.Flags().StringP("filter", "f", "", "Only display the entries whose path matches the given glob")
+	topCmd.Flags().StringSlice("cpu-key", defaultCPUKeys, "The metadata keys that hold an entry's CPU usage")
+	topCmd.Flags().StringSlice("mem-key", defaultMemKeys, "The metadata keys that hold an entry's memory usage")
+	topCmd.Flags().IntP("parallel", "p", 10, "Fetch up to n entries' usage concurrently")
+	return topCmd
+}
+
+func topMain(cmd *cobra.Command, args []string) exitCode {
+	paths := []string{"."}
+	if len(args) > 0 {
+		paths = args
+	}
+	t := &top{conn: cmdutil.NewClient()}
+	interval, err := cmd.Flags().GetDuration("interval")
+	if err != nil {
+		panic(err.Error())
+	}
+	once, err := cmd.Flags().GetBool("once")
+	if err != nil {
+		panic(err.Error())
+	}
+	if t.sortBy, err = cmd.Flags().GetString("sort"); err != nil {
+		panic(err.Error())
+	}
+	if t.sortBy != "cpu" && t.sortBy != "mem" && t.sortBy != "path" {
+		cmdutil.ErrPrintf("invalid sort key %v; expected cpu, mem, or path\n", t.sortBy)
+		return exitCode{1}
+	}
+	filter, err := cmd.Flags().GetString("filter")
+	if err != nil {
+		panic(err.Error())
+	}
+	if filter != "" {
+		if t.filter, err = glob.Compile(filter); err != nil {
+			cmdutil.ErrPrintf("invalid filter %v: %v\n", filter, err)
+			return exitCode{1}
+		}
+	}
+	if t.cpuKeys, err = cmd.Flags().GetStringSlice("cpu-key"); err != nil {
+		panic(err.Error())
+	}
+	if t.memKeys, err = cmd.Flags().GetStringSlice("mem-key"); err != nil {
+		panic(err.Error())
+	}
+	if t.parallel, err = cmd.Flags().GetInt("parallel"); err != nil {
+		panic(err.Error())
+	}
+	if t.parallel < 1 {
+		t.parallel = 1
+	}
+
+	targets := t.targets(paths)
+	if len(targets) == 0 {
+		cmdutil.ErrPrintf("no entries to display\n")
+		return exitCode{1}
+	}
+	for {
+		usages := t.collect(targets)
+		if !once {
+			// Clear the screen
+			cmdutil.Print("\033[H\033[2J")
+			cmdutil.Printf("%v  %v entries, refreshed every %v\n\n", time.Now().Format("15:04:05"), len(usages), interval)
+		}
+		cmdutil.Print(t.format(usages))
+		if once {
+			return exitCode{0}
+		}
+		time.Sleep(interval)
+	}
+}
+
+// top collects the CPU and memory usage of a set of entries
+type top struct {
+	conn     client.Client
+	sortBy   string
+	filter   glob.Glob
+	cpuKeys  []string
+	memKeys  []string
+	parallel int
+}
+
+type usage struct {
+	path   string
+	cpu    float64
+	hasCPU bool
+	mem    float64
+	hasMem bool
+}
+
+// targets returns the entries whose usage is displayed
+func (t *top) targets(paths []string) []apitypes.Entry {
+	var targets []apitypes.Entry
+	for _, path := range paths {
+		e, err := t.conn.Info(path)
+		if err != nil {
+			cmdutil.ErrPrintf("%v: %v\n", path, err)
+			continue
+		}
+		e.Path = path
+		if !e.Supports(plugin.ListAction()) {
+			targets = append(targets, e)
+			continue
+		}
+		children, err := t.conn.List(path)
+		if err != nil {
+			cmdutil.ErrPrintf("%v: %v\n", path, err)
+			continue
+		}
+		for _, child := range children {
+			child.Path = filepath.Join(path, child.CName)
+			targets = append(targets, child)
+		}
+	}
+	if t.filter == nil {
+		return targets
+	}
+	filtered := targets[:0]
+	for _, e := range targets {
+		if t.filter.Match(e.Path) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// collect fetches the targets' current usage. Targets whose usage can't be
+// fetched are skipped.
+func (t *top) collect(targets []apitypes.Entry) []usage {
+	var mux sync.Mutex
+	var usages []usage
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, t.parallel)
+	for _, e := range targets {
+		wg.Add(1)
+		go func(e apitypes.Entry) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			u, ok := t.usageOf(e)
+			if !ok {
+				return
+			}
+			mux.Lock()
+			usages = append(usages, u)
+			mux.Unlock()
+		}(e)
+	}
+	wg.Wait()
+	return usages
+}
+
+func (t *top) usageOf(e apitypes.Entry) (usage, bool) {
+	u := usage{path: e.Path}
+	// Clear the cache so that we get the current usage
+	if _, err := t.conn.Clear(e.Path, apitypes.ClearOptions{}); err != nil {
+		cmdutil.SafeErrPrintf("%v: %v\n", e.Path, err)
+		return u, false
+	}
+	meta, err := t.conn.Metadata(e.Path)
+	if err != nil {
+		cmdutil.SafeErrPrintf("%v: %v\n", e.Path, err)
+		return u, false
+	}
+	u.cpu, u.hasCPU = lookupNumber(meta, t.cpuKeys)
+	u.mem, u.hasMem = lookupNumber(meta, t.memKeys)
+	if u.hasCPU || u.hasMem || !e.Supports(plugin.ExecAction()) {
+		return u, u.hasCPU || u.hasMem
+	}
+
+	ch, err := t.conn.Exec(e.Path, "sh", []string{}, apitypes.ExecOptions{Input: topScript})
+	if err != nil {
+		cmdutil.SafeErrPrintf("%v: %v\n", e.Path, err)
+		return u, false
+	}
+	out, err := collectOutput(ch)
+	if err != nil {
+		cmdutil.SafeErrPrintf("%v: %v\n", e.Path, err)
+		return u, false
+	}
+	if u.cpu, u.mem, err = parseTopOutput(out); err != nil {
+		cmdutil.SafeErrPrintf("%v: %v\n", e.Path, err)
+		return u, false
+	}
+	u.hasCPU, u.hasMem = true, true
+	return u, true
+}
+
+func (t *top) format(usages []usage) string {
+	sort.Slice(usages, func(i, j int) bool {
+		a, b := usages[i], usages[j]
+		switch t.sortBy {
+		case "cpu":
+			if a.cpu != b.cpu {
+				return a.cpu > b.cpu
+			}
+		case "mem":
+			if a.mem != b.mem {
+				return a.mem > b.mem
+			}
+		}
+		return a.path < b.path
+	})
+	headers := []cmdutil.ColumnHeader{
+		{ShortName: "path", FullName: "PATH"},
+		{ShortName: "cpu", FullName: "CPU"},
+		{ShortName: "mem", FullName: "MEM%"},
+	}
+	rows := make([][]string, len(usages))
+	for i, u := range usages {
+		rows[i] = []string{u.path, formatUsage(u.cpu, u.hasCPU), formatUsage(u.mem, u.hasMem)}
+	}
+	return cmdutil.NewTableWithHeaders(headers, rows).Format()
+}
+
+func formatUsage(n float64, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return strconv.FormatFloat(n, 'f', 1, 64)
+}
+
+// lookupNumber returns the value of the first key in keys that m has and
+// whose value is a number. Keys are separated by '.'.
+func lookupNumber(m map[string]interface{}, keys []string) (float64, bool) {
+	for _, key := range keys {
+		v, _ := lookupKey(m, key)
+		switch n := v.(type) {
+		case float64:
+			return n, true
+		case int:
+			return float64(n), true
+		case int64:
+			return float64(n), true
+		}
+	}
+	return 0, false
+}
+
+// lookupKey returns the value of the given key in m. Keys are separated by
+// '.'.
+func lookupKey(m map[string]interface{}, key string) (interface{}, bool) {
+	var v interface{} = m
+	for _, segment := range strings.Split(key, ".") {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = obj[segment]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// The script prints the 1-minute load average, the total memory and the
+// available memory (in kB), separated by newlines.
+const topScript = `
+cut -d' ' -f1 /proc/loadavg
+awk '/^MemTotal:/ {t=$2} /^MemAvailable:/ {a=$2} END {print t; print a}' /proc/meminfo
+`
+
+// parseTopOutput parses topScript's output into the load average and the
+// percentage of memory that's in use.
+func parseTopOutput(out string) (float64, float64, error) {
+	var fields []float64
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(line, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("could not parse %q: %v", line, err)
+		}
+		fields = append(fields, n)
+	}
+	if len(fields) != 3 {
+		return 0, 0, fmt.Errorf("expected 3 values, got %v:\n%v", len(fields), out)
+	}
+	load, total, available := fields[0], fields[1], fields[2]
+	if total <= 0 {
+		return 0, 0, fmt.Errorf("invalid total memory %v", total)
+	}
+	return load, 100 * (total - available) / total, nil
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupNumber(t *testing.T) {
+	m := map[string]interface{}{
+		"cpu_percent": "high",
+		"stats": map[string]interface{}{
+			"cpu": float64(12.5),
+		},
+		"memory_percent": float64(40),
+	}
+
+	n, ok := lookupNumber(m, []string{"cpu_percent", "stats.cpu"})
+	assert.True(t, ok)
+	assert.Equal(t, 12.5, n)
+
+	n, ok = lookupNumber(m, []string{"memory_percent"})
+	assert.True(t, ok)
+	assert.Equal(t, float64(40), n)
+
+	_, ok = lookupNumber(m, []string{"stats.cpu.percent", "missing"})
+	assert.False(t, ok)
+}
+
+func TestParseTopOutput(t *testing.T) {
+	load, mem, err := parseTopOutput("0.52\n2000\n500\n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0.52, load)
+		assert.Equal(t, float64(75), mem)
+	}
+
+	_, _, err = parseTopOutput("0.52\n")
+	assert.Error(t, err)
+	_, _, err = parseTopOutput("foo\n2000\n500\n")
+	assert.Error(t, err)
+	_, _, err = parseTopOutput("0.52\n0\n0\n")
+	assert.Error(t, err)
+}
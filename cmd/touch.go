@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/puppetlabs/wash/api/client"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func touchCommand() *cobra.Command {
+	use, aliases := generateShellAlias("touch")
+	touchCmd := &cobra.Command{
+		Use:     use + " [-c] <path>...",
+		Aliases: aliases,
+		Short:   "Creates empty entries, or updates the times of existing ones",
+		Long: `Creates an empty entry at each path that doesn't exist. A wash entry is created
+by its parent's create action, so its parent must support it. For example, new
+S3 and Storage objects can be created in buckets and prefixes. This works
+without going through the FUSE mount.
+
+If the path exists, then its atime and mtime are set to the current time
+instead. Wash entries must support the setattr action for that. Local paths
+are handled like the system's touch does. Use -c to not create missing paths.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: toRunE(touchMain),
+	}
+	touchCmd.Flags().BoolP("no-create", "c", false, "Do not create missing paths")
+
+	return touchCmd
+}
+
+func touchMain(cmd *cobra.Command, args []string) exitCode {
+	noCreate, err := cmd.Flags().GetBool("no-create")
+	if err != nil {
+		panic(err.Error())
+	}
+
+	conn := cmdutil.NewClient()
+	now := time.Now()
+	ec := 0
+	for _, path := range args {
+		if err := touch(conn, path, noCreate, now); err != nil {
+			cmdutil.ErrPrintf("%v\n", err)
+			ec = 1
+		}
+	}
+	return exitCode{ec}
+}
+
+// touch creates an empty entry at path unless it exists or noCreate is true.
+// An existing entry's atime and mtime are set to now.
+func touch(conn client.Client, path string, noCreate bool, now time.Time) error {
+	if !cmdutil.IsWashPath(path) {
+		err := os.Chtimes(path, now, now)
+		if !os.IsNotExist(err) || noCreate {
+			return err
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0640)
+		if err != nil {
+			return err
+		}
+		return f.Close()
+	}
+
+	if e, err := conn.Info(path); err == nil {
+		if !e.Supports(plugin.SetAttrAction()) {
+			return fmt.Errorf("%v: cannot update its times: it does not support the setattr action", path)
+		}
+		var attr plugin.EntryAttributes
+		attr.SetAtime(now).SetMtime(now)
+		if err := conn.SetAttr(path, attr); err != nil {
+			return fmt.Errorf("%v: %v", path, err)
+		}
+		return nil
+	}
+	// We can't tell if Info's error is a "not found" error, so treat the
+	// entry as missing. Creating it will return a better error.
+	if noCreate {
+		return nil
+	}
+	var attr plugin.EntryAttributes
+	attr.SetMode(0640)
+	_, err := createWashEntry(conn, path, attr)
+	return err
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func TestTouch_WashCreates(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	var attr plugin.EntryAttributes
+	attr.SetMode(0640)
+	conn := &cmdtest.MockClient{}
+	conn.On("Info", "/wash/s3/bucket/new.txt").Return(apitypes.Entry{}, errors.New("not found"))
+	conn.On("Create", "/wash/s3/bucket", "new.txt", attr).Return(apitypes.Entry{CName: "new.txt"}, nil)
+	assert.NoError(t, touch(conn, "/wash/s3/bucket/new.txt", false, time.Now()))
+	conn.AssertExpectations(t)
+
+	// -c doesn't create missing entries
+	conn = &cmdtest.MockClient{}
+	conn.On("Info", "/wash/s3/bucket/new.txt").Return(apitypes.Entry{}, errors.New("not found"))
+	assert.NoError(t, touch(conn, "/wash/s3/bucket/new.txt", true, time.Now()))
+	conn.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTouch_WashUpdatesTimes(t *testing.T) {
+	os.Setenv("W", "/wash")
+	defer os.Unsetenv("W")
+
+	now := time.Now()
+	var attr plugin.EntryAttributes
+	attr.SetAtime(now).SetMtime(now)
+	conn := &cmdtest.MockClient{}
+	conn.On("Info", "/wash/docker/volumes/data/a.txt").Return(apitypes.Entry{Actions: []string{"read", "setattr"}}, nil)
+	conn.On("SetAttr", "/wash/docker/volumes/data/a.txt", attr).Return(nil)
+	assert.NoError(t, touch(conn, "/wash/docker/volumes/data/a.txt", false, now))
+	conn.AssertExpectations(t)
+
+	conn = &cmdtest.MockClient{}
+	conn.On("Info", "/wash/docker/containers/foo").Return(apitypes.Entry{Actions: []string{"exec"}}, nil)
+	err := touch(conn, "/wash/docker/containers/foo", false, now)
+	assert.EqualError(t, err, "/wash/docker/containers/foo: cannot update its times: it does not support the setattr action")
+	conn.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTouch_Local(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "testTouch")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(tmpdir)
+
+	conn := &cmdtest.MockClient{}
+	path := filepath.Join(tmpdir, "a.txt")
+	assert.NoError(t, touch(conn, path, true, time.Now()))
+	assert.NoFileExists(t, path)
+
+	assert.NoError(t, touch(conn, path, false, time.Now()))
+	assert.FileExists(t, path)
+
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	assert.NoError(t, touch(conn, path, false, past))
+	info, err := os.Stat(path)
+	if assert.NoError(t, err) {
+		assert.True(t, info.ModTime().Equal(past))
+	}
+}
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/xlab/treeprint"
+
+	"github.com/puppetlabs/wash/api/client"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func treeCommand() *cobra.Command {
+	use, aliases := generateShellAlias("tree")
+	treeCmd := &cobra.Command{
+		Use:     use + " [<path>...]",
+		Aliases: aliases,
+		Short:   "Displays the tree of entries under the specified paths",
+		Long: `Displays the tree of entries under the specified paths, down to --depth levels.
+Defaults to the current directory if no path is provided. Each entry can be
+annotated with its supported actions (--actions), its size (--size), and selected
+fields of its partial metadata (--meta, repeatable). Metadata keys are separated by
+'.', e.g. "--meta labels.app".
+
+Use --json to print each tree as a JSON object for other tools. Each object has
+the entry's name and path, the requested annotations, and its children.`,
+		RunE: toRunE(treeMain),
+	}
+	treeCmd.Flags().IntP("depth", "L", 2, "Descend at most n levels below the paths")
+	treeCmd.Flags().BoolP("actions", "a", false, "Annotate entries with their supported actions")
+	treeCmd.Flags().BoolP("size", "s", false, "Annotate entries with their size")
+	treeCmd.Flags().StringArrayP("meta", "m", nil, "Annotate entries with the given partial metadata field")
+	treeCmd.Flags().Bool("json", false, "Print the trees as JSON")
+	treeCmd.Flags().IntP("parallel", "p", 10, "List up to n entries concurrently")
+	return treeCmd
+}
+
+func treeMain(cmd *cobra.Command, args []string) exitCode {
+	paths := []string{"."}
+	if len(args) > 0 {
+		paths = args
+	}
+	t := &treeWalker{conn: cmdutil.NewClient()}
+	var err error
+	if t.depth, err = cmd.Flags().GetInt("depth"); err != nil {
+		panic(err.Error())
+	}
+	if t.actions, err = cmd.Flags().GetBool("actions"); err != nil {
+		panic(err.Error())
+	}
+	if t.size, err = cmd.Flags().GetBool("size"); err != nil {
+		panic(err.Error())
+	}
+	if t.meta, err = cmd.Flags().GetStringArray("meta"); err != nil {
+		panic(err.Error())
+	}
+	asJSON, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		panic(err.Error())
+	}
+	parallel, err := cmd.Flags().GetInt("parallel")
+	if err != nil {
+		panic(err.Error())
+	}
+	if parallel < 1 {
+		parallel = 1
+	}
+	t.sem = make(chan struct{}, parallel)
+
+	for _, path := range paths {
+		e, err := t.conn.Info(path)
+		if err != nil {
+			t.fail(path, err)
+			continue
+		}
+		e.Name = path
+		root := t.walk(e, path, 0)
+		if asJSON {
+			bytes, err := json.MarshalIndent(root, "", "  ")
+			if err != nil {
+				t.fail(path, err)
+				continue
+			}
+			cmdutil.Println(string(bytes))
+			continue
+		}
+		tree := treeprint.New()
+		root.fill(tree)
+		cmdutil.Print(tree.String())
+	}
+	if t.failed {
+		return exitCode{1}
+	}
+	return exitCode{0}
+}
+
+// treeWalker builds the tree of entries under a path
+type treeWalker struct {
+	conn    client.Client
+	depth   int
+	actions bool
+	size    bool
+	meta    []string
+	// sem bounds the number of concurrent List calls
+	sem    chan struct{}
+	mux    sync.Mutex
+	failed bool
+}
+
+type treeNode struct {
+	Name     string                 `json:"name"`
+	Path     string                 `json:"path"`
+	Actions  []string               `json:"actions,omitempty"`
+	Size     *uint64                `json:"size,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Children []*treeNode            `json:"children,omitempty"`
+}
+
+func (t *treeWalker) walk(e apitypes.Entry, path string, depth int) *treeNode {
+	n := &treeNode{Name: e.Name, Path: path}
+	if t.actions {
+		n.Actions = e.Actions
+	}
+	if t.size && e.Attributes.HasSize() {
+		size := e.Attributes.Size()
+		n.Size = &size
+	}
+	for _, key := range t.meta {
+		if v, ok := lookupKey(e.Metadata, key); ok {
+			if n.Metadata == nil {
+				n.Metadata = make(map[string]interface{})
+			}
+			n.Metadata[key] = v
+		}
+	}
+	if depth >= t.depth || !e.Supports(plugin.ListAction()) {
+		return n
+	}
+
+	t.sem <- struct{}{}
+	children, err := t.conn.List(path)
+	<-t.sem
+	if err != nil {
+		t.fail(path, err)
+		return n
+	}
+	n.Children = make([]*treeNode, len(children))
+	var wg sync.WaitGroup
+	for i, child := range children {
+		wg.Add(1)
+		go func(i int, child apitypes.Entry) {
+			defer wg.Done()
+			n.Children[i] = t.walk(child, filepath.Join(path, child.CName), depth+1)
+		}(i, child)
+	}
+	wg.Wait()
+	return n
+}
+
+func (t *treeWalker) fail(path string, err error) {
+	t.mux.Lock()
+	t.failed = true
+	t.mux.Unlock()
+	cmdutil.SafeErrPrintf("%v: %v\n", path, err)
+}
+
+// fill sets tree's value to the node's annotated name and adds the node's
+// children as branches
+func (n *treeNode) fill(tree treeprint.Tree) {
+	value := n.Name
+	if len(n.Actions) > 0 {
+		value += " [" + strings.Join(n.Actions, ", ") + "]"
+	}
+	if n.Size != nil {
+		value += " (" + cmdutil.FormatBytes(*n.Size) + ")"
+	}
+	for _, key := range sortedMetaKeys(n.Metadata) {
+		v, err := json.Marshal(n.Metadata[key])
+		if err != nil {
+			v = []byte(fmt.Sprintf("%v", n.Metadata[key]))
+		}
+		value += fmt.Sprintf(" %v=%s", key, v)
+	}
+	tree.SetValue(value)
+	for _, child := range n.Children {
+		// The stub value is reset in the recursive call. See stree's fill.
+		child.fill(tree.AddBranch("foo"))
+	}
+}
+
+func sortedMetaKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
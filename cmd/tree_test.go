@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xlab/treeprint"
+
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/cmd/internal/cmdtest"
+)
+
+func newTreeTestClient() *cmdtest.MockClient {
+	conn := &cmdtest.MockClient{}
+	conn.On("List", "dir").Return([]apitypes.Entry{
+		{
+			Name:       "a",
+			CName:      "a",
+			Actions:    []string{"read"},
+			Attributes: sizeAttr(10),
+			Metadata:   map[string]interface{}{"labels": map[string]interface{}{"app": "web"}},
+		},
+		{Name: "sub", CName: "sub", Actions: []string{"list"}},
+	}, nil)
+	conn.On("List", "dir/sub").Return([]apitypes.Entry{
+		{Name: "b", CName: "b", Actions: []string{"read"}},
+	}, nil)
+	return conn
+}
+
+func newTestTreeWalker(conn *cmdtest.MockClient, depth int) *treeWalker {
+	return &treeWalker{conn: conn, depth: depth, sem: make(chan struct{}, 2)}
+}
+
+func TestTreeWalk(t *testing.T) {
+	walker := newTestTreeWalker(newTreeTestClient(), 2)
+	root := walker.walk(apitypes.Entry{Name: "dir", Actions: []string{"list"}}, "dir", 0)
+	expected := &treeNode{
+		Name: "dir",
+		Path: "dir",
+		Children: []*treeNode{
+			{Name: "a", Path: "dir/a"},
+			{Name: "sub", Path: "dir/sub", Children: []*treeNode{{Name: "b", Path: "dir/sub/b"}}},
+		},
+	}
+	assert.Equal(t, expected, root)
+	assert.False(t, walker.failed)
+}
+
+func TestTreeWalk_Depth(t *testing.T) {
+	conn := newTreeTestClient()
+	walker := newTestTreeWalker(conn, 1)
+	root := walker.walk(apitypes.Entry{Name: "dir", Actions: []string{"list"}}, "dir", 0)
+	if assert.Len(t, root.Children, 2) {
+		assert.Empty(t, root.Children[1].Children)
+	}
+	conn.AssertNotCalled(t, "List", "dir/sub")
+}
+
+func TestTreeWalk_Annotations(t *testing.T) {
+	walker := newTestTreeWalker(newTreeTestClient(), 1)
+	walker.actions = true
+	walker.size = true
+	walker.meta = []string{"labels.app", "missing"}
+	root := walker.walk(apitypes.Entry{Name: "dir", Actions: []string{"list"}}, "dir", 0)
+	if assert.Len(t, root.Children, 2) {
+		a := root.Children[0]
+		assert.Equal(t, []string{"read"}, a.Actions)
+		if assert.NotNil(t, a.Size) {
+			assert.Equal(t, uint64(10), *a.Size)
+		}
+		assert.Equal(t, map[string]interface{}{"labels.app": "web"}, a.Metadata)
+
+		// Entries without a size or the metadata aren't annotated with them
+		sub := root.Children[1]
+		assert.Nil(t, sub.Size)
+		assert.Nil(t, sub.Metadata)
+	}
+}
+
+func TestTreeWalk_ListFails(t *testing.T) {
+	_, stderr, restore := captureOutput()
+	defer restore()
+
+	conn := &cmdtest.MockClient{}
+	conn.On("List", "dir").Return([]apitypes.Entry{}, errors.New("list failed"))
+	walker := newTestTreeWalker(conn, 2)
+	root := walker.walk(apitypes.Entry{Name: "dir", Actions: []string{"list"}}, "dir", 0)
+	assert.Empty(t, root.Children)
+	assert.True(t, walker.failed)
+	assert.Contains(t, stderr.String(), "dir: list failed")
+}
+
+func TestTreeNodeFill(t *testing.T) {
+	size := uint64(2048)
+	root := &treeNode{
+		Name:    "dir",
+		Actions: []string{"list"},
+		Children: []*treeNode{
+			{Name: "a", Actions: []string{"read"}, Size: &size, Metadata: map[string]interface{}{"state": "running", "cpu": 2.5}},
+			{Name: "b"},
+		},
+	}
+	tree := treeprint.New()
+	root.fill(tree)
+	expected := "dir [list]\n" +
+		"├── a [read] (2.0K) cpu=2.5 state=\"running\"\n" +
+		"└── b\n"
+	assert.Equal(t, expected, tree.String())
+}
+
+func TestTreeNodeJSON(t *testing.T) {
+	size := uint64(10)
+	root := &treeNode{Name: "dir", Path: "dir", Children: []*treeNode{{Name: "a", Path: "dir/a", Size: &size}}}
+	bytes, err := json.Marshal(root)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"name": "dir", "path": "dir", "children": [{"name": "a", "path": "dir/a", "size": 10}]}`, string(bytes))
+	}
+}
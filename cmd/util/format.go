@@ -85,6 +85,17 @@ func SafePrint(a ...interface{}) {
 	Print(a...)
 }
 
+// SafeErrPrint is a thread-safe wrapper to fmt.Print that prints to
+// cmdutil.Stderr. Unlike ErrPrintf, it does not color the output.
+func SafeErrPrint(a ...interface{}) {
+	stderrMux.Lock()
+	defer stderrMux.Unlock()
+	_, err := fmt.Fprint(Stderr, a...)
+	if err != nil {
+		panic(err)
+	}
+}
+
 // FormatDuration formats a duration as `[[dd-]hh:]mm:ss` according to
 // http://pubs.opengroup.org/onlinepubs/9699919799/utilities/ps.html.
 func FormatDuration(dur time.Duration) string {
@@ -107,3 +118,20 @@ func FormatDuration(dur time.Duration) string {
 		return fmt.Sprintf("%02d:%02d.%02d", m, s, f)
 	}
 }
+
+// FormatBytes formats a size in bytes using the largest binary unit (K, M,
+// G, T or P) that keeps the number at or above 1, e.g. 1536 is formatted as
+// 1.5K.
+func FormatBytes(n uint64) string {
+	const units = "KMGTP"
+	if n < 1024 {
+		return fmt.Sprintf("%dB", n)
+	}
+	size := float64(n) / 1024
+	i := 0
+	for size >= 1024 && i < len(units)-1 {
+		size /= 1024
+		i++
+	}
+	return fmt.Sprintf("%.1f%c", size, units[i])
+}
@@ -1,12 +1,14 @@
 package cmdutil
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	"github.com/ghodss/yaml"
 )
 
@@ -17,6 +19,10 @@ const (
 	YAML = "yaml"
 	// TEXT represents an easily greppable text format
 	TEXT = "text"
+	// TOML represents the TOML marshaller
+	TOML = "toml"
+	// TSV represents a tab-separated format for scripts
+	TSV = "tsv"
 )
 
 // Marshaller is a type that marshals a given value
@@ -97,8 +103,12 @@ func NewMarshaller(format string) (Marshaller, error) {
 		}), nil
 	case TEXT:
 		return Marshaller(toText), nil
+	case TOML:
+		return Marshaller(toTOML), nil
+	case TSV:
+		return Marshaller(toTSV), nil
 	default:
-		return nil, fmt.Errorf("the %v format is not supported. Supported formats are 'json' or 'yaml'", format)
+		return nil, fmt.Errorf("the %v format is not supported. Supported formats are 'json', 'yaml', 'text', 'toml' or 'tsv'", format)
 	}
 }
 
@@ -170,6 +180,106 @@ func textBuilder(v interface{}, prefix string) (string, error) {
 	}
 }
 
+func toTOML(v interface{}) ([]byte, error) {
+	goType, err := marshalToJSONGoType(v)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := goType.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("only objects can be marshalled to TOML")
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(goType); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// toTSV marshals v to tab-separated values. Primitive values are printed
+// as-is (strings without quotes) so that scripts can consume them directly.
+// Arrays are printed one element per line. Objects are printed as key-value
+// lines, and arrays of objects are printed as a table whose header row is
+// the objects' keys. Nested objects and arrays are printed as compact JSON.
+func toTSV(v interface{}) ([]byte, error) {
+	goType, err := marshalToJSONGoType(v)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	switch val := goType.(type) {
+	case map[string]interface{}:
+		keys := sortedKeys(val)
+		for _, k := range keys {
+			lines = append(lines, tsvField(k)+"\t"+tsvField(val[k]))
+		}
+	case []interface{}:
+		var objs []map[string]interface{}
+		for _, elem := range val {
+			obj, ok := elem.(map[string]interface{})
+			if !ok {
+				objs = nil
+				break
+			}
+			objs = append(objs, obj)
+		}
+		if objs == nil {
+			for _, elem := range val {
+				lines = append(lines, tsvField(elem))
+			}
+			break
+		}
+		keySet := make(map[string]interface{})
+		for _, obj := range objs {
+			for k := range obj {
+				keySet[k] = nil
+			}
+		}
+		keys := sortedKeys(keySet)
+		lines = append(lines, strings.Join(keys, "\t"))
+		for _, obj := range objs {
+			fields := make([]string, len(keys))
+			for i, k := range keys {
+				fields[i] = tsvField(obj[k])
+			}
+			lines = append(lines, strings.Join(fields, "\t"))
+		}
+	default:
+		lines = append(lines, tsvField(val))
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+var tsvEscaper = strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+
+func tsvField(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return tsvEscaper.Replace(val)
+	case float64:
+		// Avoid the exponent format for large numbers
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprintf("%v", val)
+		}
+		return tsvEscaper.Replace(string(data))
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // return value should be a map/array/primitive value type
 func marshalToJSONGoType(v interface{}) (interface{}, error) {
 	jsonBytes, err := json.Marshal(v)
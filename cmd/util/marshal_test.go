@@ -0,0 +1,33 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTSV(t *testing.T) {
+	m, err := NewMarshaller(TSV)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	marshal := func(v interface{}) string {
+		s, err := m.Marshal(v)
+		assert.NoError(t, err)
+		return s
+	}
+
+	assert.Equal(t, "running\n", marshal("running"))
+	assert.Equal(t, "1000000\n", marshal(1000000))
+	assert.Equal(t, "/a\n/b\n", marshal([]string{"/a", "/b"}))
+	assert.Equal(t, "a\t1\nb\t{\"c\":true}\n", marshal(map[string]interface{}{
+		"b": map[string]bool{"c": true},
+		"a": 1,
+	}))
+	assert.Equal(t, "name\tstate\nfoo\trunning\nbar\t\n", marshal([]map[string]string{
+		{"name": "foo", "state": "running"},
+		{"name": "bar"},
+	}))
+	assert.Equal(t, "a\\tb\\nc\n", marshal("a\tb\nc"))
+}
@@ -0,0 +1,22 @@
+package cmdutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IsWashPath returns true if path is inside the Wash mountpoint. The Wash
+// shell stores the mountpoint in the W environment variable; if W is not set,
+// then all paths are treated as local paths.
+func IsWashPath(path string) bool {
+	mountpoint := os.Getenv("W")
+	if len(mountpoint) == 0 {
+		return false
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	return path == mountpoint || strings.HasPrefix(path, mountpoint+string(filepath.Separator))
+}
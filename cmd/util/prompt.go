@@ -2,6 +2,8 @@ package cmdutil
 
 import (
 	"fmt"
+	"io"
+	"os"
 )
 
 // InputParser represents a parser that parses input
@@ -19,12 +21,19 @@ var YesOrNoP InputParser = func(input string) (confirmed interface{}, err error)
 // then passes the input over to the supplied parser. The actual
 // prompt displayed to the user is "{msg} ".
 func Prompt(msg string, parser InputParser) (interface{}, error) {
+	return PromptFrom(os.Stdin, msg, parser)
+}
+
+// PromptFrom is like Prompt, but it waits for input on r instead of stdin.
+// r is read one byte at a time so that none of the input after the response
+// is consumed.
+func PromptFrom(r io.Reader, msg string, parser InputParser) (interface{}, error) {
 	stderrMux.Lock()
 	defer stderrMux.Unlock()
 
 	var input string
 	fmt.Fprintf(Stderr, "%s ", msg)
-	_, err := fmt.Scanln(&input)
+	_, err := fmt.Fscanln(r, &input)
 	if err != nil {
 		return nil, err
 	}
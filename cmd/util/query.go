@@ -0,0 +1,229 @@
+package cmdutil
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Query is a jq-like path expression that selects values from
+// JSON-like data (maps, arrays and primitive values). It supports
+// the following segments:
+//
+//     .           the value itself
+//     .key        the value of an object's key
+//     ."key"      the value of a key that contains special characters
+//     ["key"]     same as ."key"
+//     [n]         the nth element of an array. Negative indexes count
+//                 from the end of the array.
+//     []          every element of an array, or every value of an object
+//
+// Segments can be chained, e.g. .containers[].image or
+// .metadata.labels["app.kubernetes.io/name"]. Like jq, selecting a
+// missing key or an out-of-range index yields null.
+type Query struct {
+	expr     string
+	segments []querySegment
+}
+
+type querySegmentType int
+
+const (
+	keySegment querySegmentType = iota
+	indexSegment
+	iterateSegment
+)
+
+type querySegment struct {
+	typ   querySegmentType
+	key   string
+	index int
+}
+
+// NewQuery parses the given query expression.
+func NewQuery(expr string) (Query, error) {
+	q := Query{expr: expr}
+	s := strings.TrimSpace(expr)
+	if !strings.HasPrefix(s, ".") && !strings.HasPrefix(s, "[") {
+		return q, fmt.Errorf("invalid query %v: queries must start with '.' or '['", expr)
+	}
+	for len(s) > 0 {
+		var seg querySegment
+		var err error
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			switch {
+			case len(s) == 0 || s[0] == '[':
+				// "." or ".[...]"
+				continue
+			case s[0] == '"':
+				seg.typ = keySegment
+				seg.key, s, err = parseQuotedKey(s)
+			default:
+				end := strings.IndexAny(s, ".[")
+				if end < 0 {
+					end = len(s)
+				}
+				seg.typ = keySegment
+				seg.key, s = s[:end], s[end:]
+				if seg.key == "" {
+					err = fmt.Errorf("expected a key after '.'")
+				}
+			}
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				err = fmt.Errorf("missing ']'")
+				break
+			}
+			inner := strings.TrimSpace(s[1:end])
+			switch {
+			case inner == "":
+				seg.typ = iterateSegment
+				s = s[end+1:]
+			case inner[0] == '"':
+				// The key can contain ']', so parse it before looking
+				// for the closing bracket.
+				var rest string
+				seg.typ = keySegment
+				seg.key, rest, err = parseQuotedKey(strings.TrimLeft(s[1:], " "))
+				if err == nil {
+					rest = strings.TrimLeft(rest, " ")
+					if !strings.HasPrefix(rest, "]") {
+						err = fmt.Errorf("missing ']'")
+					} else {
+						s = rest[1:]
+					}
+				}
+			default:
+				seg.typ = indexSegment
+				seg.index, err = strconv.Atoi(inner)
+				if err != nil {
+					err = fmt.Errorf("invalid index %v", inner)
+				}
+				s = s[end+1:]
+			}
+		default:
+			err = fmt.Errorf("unexpected %q", s[0])
+		}
+		if err != nil {
+			return q, fmt.Errorf("invalid query %v: %v", expr, err)
+		}
+		q.segments = append(q.segments, seg)
+	}
+	return q, nil
+}
+
+// parseQuotedKey parses the quoted key at the start of s. It returns the
+// key and the rest of s.
+func parseQuotedKey(s string) (string, string, error) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			key, err := strconv.Unquote(s[:i+1])
+			return key, s[i+1:], err
+		}
+	}
+	return "", "", fmt.Errorf("unterminated key %v", s)
+}
+
+// Eval evaluates the query against v, which should be a JSON Go type
+// (see https://golang.org/pkg/encoding/json/#Unmarshal). It returns the
+// selected values. Queries without an iteration segment return a single
+// value.
+func (q Query) Eval(v interface{}) ([]interface{}, error) {
+	values := []interface{}{v}
+	for _, seg := range q.segments {
+		var next []interface{}
+		for _, value := range values {
+			selected, err := seg.eval(value)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %v", q.expr, err)
+			}
+			next = append(next, selected...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+// Iterates returns true if the query can select more than one value.
+func (q Query) Iterates() bool {
+	for _, seg := range q.segments {
+		if seg.typ == iterateSegment {
+			return true
+		}
+	}
+	return false
+}
+
+func (seg querySegment) eval(v interface{}) ([]interface{}, error) {
+	if v == nil {
+		// Like jq, indexing null yields null
+		if seg.typ == iterateSegment {
+			return nil, fmt.Errorf("cannot iterate over null")
+		}
+		return []interface{}{nil}, nil
+	}
+	switch seg.typ {
+	case keySegment:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot index %v with %q", typeName(v), seg.key)
+		}
+		return []interface{}{obj[seg.key]}, nil
+	case indexSegment:
+		arr, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot index %v with %v", typeName(v), seg.index)
+		}
+		i := seg.index
+		if i < 0 {
+			i += len(arr)
+		}
+		if i < 0 || i >= len(arr) {
+			return []interface{}{nil}, nil
+		}
+		return []interface{}{arr[i]}, nil
+	default:
+		switch t := v.(type) {
+		case []interface{}:
+			return t, nil
+		case map[string]interface{}:
+			keys := make([]string, 0, len(t))
+			for k := range t {
+				keys = append(keys, k)
+			}
+			// Iterate in a stable order
+			sort.Strings(keys)
+			values := make([]interface{}, len(keys))
+			for i, k := range keys {
+				values[i] = t[k]
+			}
+			return values, nil
+		default:
+			return nil, fmt.Errorf("cannot iterate over %v", typeName(v))
+		}
+	}
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case float64, int, int64, uint64:
+		return "a number"
+	default:
+		return fmt.Sprintf("a %T", v)
+	}
+}
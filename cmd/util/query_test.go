@@ -0,0 +1,59 @@
+package cmdutil
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery(t *testing.T) {
+	var v interface{}
+	err := json.Unmarshal([]byte(`{
+		"State": {"Status": "running"},
+		"Mounts": [{"Source": "/a"}, {"Source": "/b"}],
+		"Labels": {"app.kubernetes.io/name": "web", "tier": "front"}
+	}`), &v)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	eval := func(expr string) []interface{} {
+		q, err := NewQuery(expr)
+		if !assert.NoError(t, err, expr) {
+			return nil
+		}
+		values, err := q.Eval(v)
+		assert.NoError(t, err, expr)
+		return values
+	}
+
+	assert.Equal(t, []interface{}{v}, eval("."))
+	assert.Equal(t, []interface{}{"running"}, eval(".State.Status"))
+	assert.Equal(t, []interface{}{"/b"}, eval(".Mounts[1].Source"))
+	assert.Equal(t, []interface{}{"/b"}, eval(".Mounts[-1].Source"))
+	assert.Equal(t, []interface{}{"/a", "/b"}, eval(".Mounts[].Source"))
+	assert.Equal(t, []interface{}{"web"}, eval(`.Labels["app.kubernetes.io/name"]`))
+	assert.Equal(t, []interface{}{"web"}, eval(`.Labels."app.kubernetes.io/name"`))
+	assert.Equal(t, []interface{}{"web", "front"}, eval(".Labels[]"))
+	assert.Equal(t, []interface{}{nil}, eval(".Missing.Key"))
+	assert.Equal(t, []interface{}{nil}, eval(".Mounts[5]"))
+
+	q, err := NewQuery(".State.Status.Foo")
+	if assert.NoError(t, err) {
+		_, err = q.Eval(v)
+		assert.EqualError(t, err, `.State.Status.Foo: cannot index a string with "Foo"`)
+	}
+
+	for _, expr := range []string{"State", ".a..b", ".a[1", ".a[x]", `.a["b]`} {
+		_, err := NewQuery(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestQueryIterates(t *testing.T) {
+	q, _ := NewQuery(".a[].b")
+	assert.True(t, q.Iterates())
+	q, _ = NewQuery(".a[0].b")
+	assert.False(t, q.Iterates())
+}
@@ -0,0 +1,65 @@
+package cmdutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Template formats values with a Go template, e.g. '{{.name}} {{.attributes.size}}'.
+// Values are marshalled to JSON before they're formatted, so the template
+// refers to fields by their JSON keys. Numbers are printed as they appear
+// in the JSON, so large sizes aren't printed in exponent format.
+//
+// Besides the builtin functions, templates can use
+//   * json, which marshals a value to compact JSON
+//   * join, which joins a list of values with a separator, e.g.
+//     '{{join .actions ","}}'
+type Template struct {
+	tmpl *template.Template
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join": func(values []interface{}, sep string) string {
+		strs := make([]string, len(values))
+		for i, v := range values {
+			strs[i] = fmt.Sprintf("%v", v)
+		}
+		return strings.Join(strs, sep)
+	},
+}
+
+// NewTemplate parses the given template.
+func NewTemplate(format string) (*Template, error) {
+	tmpl, err := template.New("format").Funcs(templateFuncs).Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format: %v", err)
+	}
+	return &Template{tmpl: tmpl}, nil
+}
+
+// Format formats v with the template.
+func (t *Template) Format(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var goType interface{}
+	if err := decoder.Decode(&goType); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, goType); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,27 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplate(t *testing.T) {
+	v := map[string]interface{}{
+		"name":       "foo",
+		"actions":    []string{"list", "read"},
+		"attributes": map[string]interface{}{"size": uint64(123456789)},
+		"metadata":   map[string]interface{}{"labels": map[string]string{"app": "web"}},
+	}
+
+	tmpl, err := NewTemplate(`{{.name}} {{.attributes.size}} {{join .actions ","}} {{json .metadata.labels}}`)
+	if assert.NoError(t, err) {
+		out, err := tmpl.Format(v)
+		if assert.NoError(t, err) {
+			assert.Equal(t, `foo 123456789 list,read {"app":"web"}`, out)
+		}
+	}
+
+	_, err = NewTemplate("{{.name")
+	assert.Error(t, err)
+}
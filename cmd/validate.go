@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/rand"
 	"os"
 	"os/signal"
+	"sort"
 	"strings"
 	"sync"
 	"syscall"
@@ -36,14 +40,23 @@ when needed.
 
 Each line represents validation of an entry type. The 'lrsx' fields represent support for 'list',
 'read', 'stream', and 'execute' methods respectively, with '-' representing lack of support for a
-method.`,
+method.
+
+Besides invoking its methods, validate checks each entry's metadata, that its type ID is one of its
+parent's child types (when the plugin provides a schema), and that its attributes are sane: times
+shouldn't be in the future and the size attribute should match the entry's content. Use --stress to
+also make concurrent list, read and metadata calls on each entry, bypassing the cache. Use --report
+to write the results for each entry as JSON or as a JUnit report, e.g. to gate a release in CI.`,
 		Args:   cobra.ExactArgs(1),
 		PreRun: bindServerArgs,
 		RunE:   toRunE(validateMain),
 	}
 	validateCmd.Flags().IntP("parallel", "p", 10, "Number of entries to validate in parallel")
 	validateCmd.Flags().BoolP("all", "a", false, "Validate all entries rather than an example at each level of hierarchy")
-	addServerArgs(validateCmd, "warn")
+	validateCmd.Flags().Int("stress", 0, "Make n concurrent rounds of list, read and metadata calls on each entry, bypassing the cache")
+	validateCmd.Flags().String("report", "", "Write a report of the results to a file")
+	validateCmd.Flags().String("report-format", "json", "The report's format, json or junit")
+	addServerArgs(validateCmd, "warn", "")
 	return validateCmd
 }
 
@@ -74,6 +87,27 @@ func validateMain(cmd *cobra.Command, args []string) exitCode {
 		return exitCode{1}
 	}
 
+	stress, err := cmd.Flags().GetInt("stress")
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+
+	reportPath, err := cmd.Flags().GetString("report")
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	reportFormat, err := cmd.Flags().GetString("report-format")
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	if reportFormat != "json" && reportFormat != "junit" {
+		cmdutil.ErrPrintf("invalid report format %v; must be json or junit\n", reportFormat)
+		return exitCode{1}
+	}
+
 	plug := args[0]
 	root, ok := plugins[plug]
 	if !ok {
@@ -153,14 +187,21 @@ func validateMain(cmd *cobra.Command, args []string) exitCode {
 	}
 
 	// We use a worker pool to limit work-in-progress. Put the plugin on the worker pool.
-	wp := cmdutil.NewPool(parallel)
+	v := validator{
+		pw:     pw,
+		wp:     cmdutil.NewPool(parallel),
+		all:    all,
+		stress: stress,
+		errs:   errs,
+		report: &validateReport{Plugin: plug},
+	}
 	entries.Range(func(_ string, e plugin.Entry) bool {
-		wp.Submit(func() { processEntry(ctx, pw, wp, e, all, errs) })
+		v.wp.Submit(func() { v.processEntry(ctx, e, nil) })
 		return true
 	})
 
 	// Wait for work to complete.
-	wp.Finish()
+	v.wp.Finish()
 
 	// Leave time for progress to finish rendering.
 	time.Sleep(100 * time.Millisecond)
@@ -170,6 +211,12 @@ func validateMain(cmd *cobra.Command, args []string) exitCode {
 	// routine to complete.
 	close(errs)
 	wg.Wait()
+	if reportPath != "" {
+		if err := v.report.write(reportPath, reportFormat); err != nil {
+			cmdutil.ErrPrintf("Unable to write report to %v: %v\n", reportPath, err)
+			return exitCode{1}
+		}
+	}
 	if erred > 0 {
 		cmdutil.ErrPrintf("Found %v errors.\n", erred)
 		return exitCode{1}
@@ -256,36 +303,66 @@ func withTimeout(ctx context.Context, method, name string,
 	return obj, cancelFunc, nil
 }
 
-func processEntry(ctx context.Context, pw progress.Writer, wp cmdutil.Pool, e plugin.Entry, all bool, errs chan<- error) {
-	defer wp.Done()
+// validator validates entries. Entries are validated in parallel on its worker pool, and errors
+// are sent to errs as they're found. Each entry's results are added to the report.
+type validator struct {
+	pw     progress.Writer
+	wp     cmdutil.Pool
+	all    bool
+	stress int
+	errs   chan<- error
+	report *validateReport
+}
+
+// processEntry validates e. parentSchema is the schema of e's parent, if it has one.
+func (v validator) processEntry(ctx context.Context, e plugin.Entry, parentSchema *plugin.EntrySchema) {
+	defer v.wp.Done()
 	name := plugin.ID(e)
 	crit := newCriteria(e)
+	result := validateResult{Path: name, TypeID: crit.typeID}
+	start := time.Now()
+	defer func() {
+		result.Duration = time.Since(start).Seconds()
+		v.report.add(result)
+	}()
+	fail := func(err error) {
+		result.Failures = append(result.Failures, err.Error())
+		v.errs <- err
+	}
+
 	schema, err := plugin.Schema(e)
 	if err != nil {
-		errs <- err
+		fail(err)
 		return
 	}
 	if schema != nil {
 		crit.label = schema.Label
 		crit.singleton = schema.Singleton
 	}
-	tracker := progress.Tracker{Message: fmt.Sprintf("Testing %s %s", crit, name), Total: 4}
-	pw.AppendTracker(&tracker)
+	if parentSchema != nil {
+		result.Checks = append(result.Checks, "schema")
+		if err := checkSchema(name, crit.typeID, schema, parentSchema); err != nil {
+			fail(err)
+		}
+	}
+	tracker := progress.Tracker{Message: fmt.Sprintf("Testing %s %s", crit, name), Total: 6}
+	v.pw.AppendTracker(&tracker)
 
 	if plugin.ListAction().IsSupportedOn(e) {
+		result.Checks = append(result.Checks, "list")
 		obj, cancelFunc, err := withTimeout(ctx, "list", name, func(ctx context.Context) (interface{}, error) {
 			return plugin.List(ctx, e.(plugin.Parent))
 		})
 		if err != nil {
-			errs <- err
+			fail(err)
 			return
 		}
 		cancelFunc()
 		entries := obj.(*plugin.EntryMap)
 
-		if all {
+		if v.all {
 			entries.Range(func(_ string, entry plugin.Entry) bool {
-				wp.Submit(func() { processEntry(ctx, pw, wp, entry, all, errs) })
+				v.wp.Submit(func() { v.processEntry(ctx, entry, schema) })
 				return true
 			})
 		} else {
@@ -314,13 +391,14 @@ func processEntry(ctx context.Context, pw progress.Writer, wp cmdutil.Pool, e pl
 
 			for _, items := range groups {
 				entry := items[rand.Intn(len(items))]
-				wp.Submit(func() { processEntry(ctx, pw, wp, entry, all, errs) })
+				v.wp.Submit(func() { v.processEntry(ctx, entry, schema) })
 			}
 		}
 	}
 	tracker.Increment(1)
 
 	if plugin.ReadAction().IsSupportedOn(e) {
+		result.Checks = append(result.Checks, "read")
 		_, cancelFunc, err := withTimeout(ctx, "read", name, func(ctx context.Context) (interface{}, error) {
 			data, err := plugin.Read(ctx, e, 0, 1)
 			if err == io.EOF {
@@ -329,19 +407,34 @@ func processEntry(ctx context.Context, pw progress.Writer, wp cmdutil.Pool, e pl
 			return data, err
 		})
 		if err != nil {
-			errs <- err
+			fail(err)
 			return
 		}
 		cancelFunc()
 	}
 	tracker.Increment(1)
 
+	result.Checks = append(result.Checks, "metadata", "attributes")
+	_, cancelFunc, err := withTimeout(ctx, "metadata", name, func(ctx context.Context) (interface{}, error) {
+		return plugin.Metadata(ctx, e)
+	})
+	if err != nil {
+		fail(err)
+		return
+	}
+	cancelFunc()
+	for _, err := range checkAttributes(ctx, e) {
+		fail(err)
+	}
+	tracker.Increment(1)
+
 	if plugin.StreamAction().IsSupportedOn(e) {
+		result.Checks = append(result.Checks, "stream")
 		obj, cancelFunc, err := withTimeout(ctx, "stream", name, func(ctx context.Context) (interface{}, error) {
 			return plugin.Stream(ctx, e.(plugin.Streamable))
 		})
 		if err != nil {
-			errs <- err
+			fail(err)
 			return
 		}
 		obj.(io.Closer).Close()
@@ -350,12 +443,13 @@ func processEntry(ctx context.Context, pw progress.Writer, wp cmdutil.Pool, e pl
 	tracker.Increment(1)
 
 	if plugin.ExecAction().IsSupportedOn(e) {
+		result.Checks = append(result.Checks, "exec")
 		const testMessage = "hello"
 		obj, cancelFunc, err := withTimeout(ctx, "exec", name, func(ctx context.Context) (interface{}, error) {
 			return plugin.Exec(ctx, e.(plugin.Execable), "echo", []string{testMessage}, plugin.ExecOptions{})
 		})
 		if err != nil {
-			errs <- err
+			fail(err)
 			return
 		}
 		cmd := obj.(plugin.ExecCommand)
@@ -363,25 +457,34 @@ func processEntry(ctx context.Context, pw progress.Writer, wp cmdutil.Pool, e pl
 		var output string
 		for chunk := range cmd.OutputCh() {
 			if err := chunk.Err; err != nil {
-				errs <- err
+				fail(err)
 			} else if chunk.StreamID == plugin.Stdout {
 				output += chunk.Data
 			} else if chunk.StreamID == plugin.Stderr {
-				errs <- fmt.Errorf("Unexpected error output on Exec: %v", chunk.Data)
+				fail(fmt.Errorf("Unexpected error output on Exec: %v", chunk.Data))
 			}
 		}
 
 		if msg := strings.Trim(output, "\n"); msg != testMessage {
-			errs <- fmt.Errorf("Unexpected output on Exec: %v", msg)
+			fail(fmt.Errorf("Unexpected output on Exec: %v", msg))
 		}
 
 		if exitCode, err := cmd.ExitCode(); err != nil {
-			errs <- fmt.Errorf("Error getting exit code for 'echo': %v", err)
+			fail(fmt.Errorf("Error getting exit code for 'echo': %v", err))
 		} else if exitCode != 0 {
-			errs <- fmt.Errorf("Non-zero exit code for 'echo': %v", exitCode)
+			fail(fmt.Errorf("Non-zero exit code for 'echo': %v", exitCode))
 		}
 		cancelFunc()
 	}
+	tracker.Increment(1)
+
+	if v.stress > 0 {
+		result.Checks = append(result.Checks, "stress")
+		result.Stress = stressEntry(ctx, e, v.stress)
+		for _, err := range result.Stress.errors {
+			fail(err)
+		}
+	}
 	tracker.MarkAsDone()
 }
 
@@ -389,3 +492,238 @@ func formatErr(msg, method string, err error) error {
 	helpURL := "https://puppetlabs.github.io/wash/docs/external_plugins/#" + method
 	return fmt.Errorf("%v: %v\nSee %v for response format", msg, err, helpURL)
 }
+
+// checkSchema checks that an entry's type ID is one of its parent's child types, and that the
+// entry has a schema if its parent does.
+func checkSchema(name, typeID string, schema, parentSchema *plugin.EntrySchema) error {
+	if schema == nil {
+		return fmt.Errorf("%v has no schema, but its parent does", name)
+	}
+	for _, childTypeID := range parentSchema.Children {
+		if childTypeID == typeID {
+			return nil
+		}
+	}
+	return fmt.Errorf("the type ID %v of %v is not one of its parent's child types %v", typeID, name, parentSchema.Children)
+}
+
+// Times can be slightly in the future because of clock skew between Wash and the plugin's API.
+const allowedClockSkew = 24 * time.Hour
+
+// checkAttributes checks that an entry's attributes are sane.
+func checkAttributes(ctx context.Context, e plugin.Entry) []error {
+	var errs []error
+	name := plugin.ID(e)
+	attr := plugin.Attributes(e)
+
+	future := time.Now().Add(allowedClockSkew)
+	times := []struct {
+		name string
+		has  bool
+		t    time.Time
+	}{
+		{"atime", attr.HasAtime(), attr.Atime()},
+		{"mtime", attr.HasMtime(), attr.Mtime()},
+		{"ctime", attr.HasCtime(), attr.Ctime()},
+		{"crtime", attr.HasCrtime(), attr.Crtime()},
+	}
+	for _, t := range times {
+		if !t.has {
+			continue
+		}
+		if t.t.IsZero() {
+			errs = append(errs, fmt.Errorf("%v of %v is set to the zero time", t.name, name))
+		} else if t.t.After(future) {
+			errs = append(errs, fmt.Errorf("%v of %v is in the future: %v", t.name, name, t.t))
+		}
+	}
+	if attr.HasCrtime() && attr.HasMtime() && attr.Crtime().After(attr.Mtime()) {
+		errs = append(errs, fmt.Errorf("crtime of %v is after its mtime", name))
+	}
+
+	// Check that the content's size matches the size attribute by reading the byte before and
+	// after the end of the content.
+	if attr.HasSize() && plugin.ReadAction().IsSupportedOn(e) {
+		size := int64(attr.Size())
+		_, cancelFunc, err := withTimeout(ctx, "read", name, func(ctx context.Context) (interface{}, error) {
+			if size > 0 {
+				data, err := plugin.Read(ctx, e, 1, size-1)
+				if err != nil && err != io.EOF {
+					return nil, err
+				}
+				if len(data) != 1 {
+					return nil, fmt.Errorf("content is smaller than its size attribute %v", size)
+				}
+			}
+			data, err := plugin.Read(ctx, e, 1, size)
+			if err != nil && err != io.EOF {
+				return nil, err
+			}
+			if len(data) != 0 {
+				return nil, fmt.Errorf("content is larger than its size attribute %v", size)
+			}
+			return nil, nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			cancelFunc()
+		}
+	}
+	return errs
+}
+
+// stressStats summarizes the calls made by stressEntry.
+type stressStats struct {
+	Calls      int     `json:"calls"`
+	Errors     int     `json:"errors"`
+	MaxLatency float64 `json:"max_latency_seconds"`
+	errors     []error
+	mux        sync.Mutex
+}
+
+// stressEntry makes rounds concurrent rounds of list, read and metadata calls on e. The entry's
+// cache is cleared before each call so that the calls reach the plugin. Only the first error of
+// each method is kept.
+func stressEntry(ctx context.Context, e plugin.Entry, rounds int) *stressStats {
+	stats := &stressStats{}
+	name := plugin.ID(e)
+	methods := map[string]func(context.Context) (interface{}, error){
+		"metadata": func(ctx context.Context) (interface{}, error) {
+			return plugin.Metadata(ctx, e)
+		},
+	}
+	if plugin.ListAction().IsSupportedOn(e) {
+		methods["list"] = func(ctx context.Context) (interface{}, error) {
+			return plugin.List(ctx, e.(plugin.Parent))
+		}
+	}
+	if plugin.ReadAction().IsSupportedOn(e) {
+		methods["read"] = func(ctx context.Context) (interface{}, error) {
+			data, err := plugin.Read(ctx, e, 1, 0)
+			if err == io.EOF {
+				err = nil
+			}
+			return data, err
+		}
+	}
+
+	failed := make(map[string]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < rounds; i++ {
+		for method, fn := range methods {
+			wg.Add(1)
+			go func(method string, fn func(context.Context) (interface{}, error)) {
+				defer wg.Done()
+				plugin.ClearCacheFor(name, false)
+				start := time.Now()
+				_, cancelFunc, err := withTimeout(ctx, method, name, fn)
+				latency := time.Since(start).Seconds()
+
+				stats.mux.Lock()
+				defer stats.mux.Unlock()
+				stats.Calls++
+				if latency > stats.MaxLatency {
+					stats.MaxLatency = latency
+				}
+				if err != nil {
+					stats.Errors++
+					if !failed[method] {
+						failed[method] = true
+						stats.errors = append(stats.errors, fmt.Errorf("Stress testing: %v", err))
+					}
+					return
+				}
+				cancelFunc()
+			}(method, fn)
+		}
+	}
+	wg.Wait()
+	return stats
+}
+
+// validateResult is the result of validating an entry.
+type validateResult struct {
+	Path     string       `json:"path"`
+	TypeID   string       `json:"type_id,omitempty"`
+	Checks   []string     `json:"checks"`
+	Failures []string     `json:"failures,omitempty"`
+	Duration float64      `json:"duration_seconds"`
+	Stress   *stressStats `json:"stress,omitempty"`
+}
+
+// validateReport collects the results of validating a plugin.
+type validateReport struct {
+	Plugin  string           `json:"plugin"`
+	Results []validateResult `json:"results"`
+	mux     sync.Mutex
+}
+
+func (r *validateReport) add(result validateResult) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.Results = append(r.Results, result)
+}
+
+// write writes the report to path in the given format, json or junit. Results are sorted by path.
+func (r *validateReport) write(path string, format string) error {
+	sort.Slice(r.Results, func(i, j int) bool {
+		return r.Results[i].Path < r.Results[j].Path
+	})
+
+	var data []byte
+	var err error
+	if format == "junit" {
+		data, err = xml.MarshalIndent(r.junit(), "", "  ")
+		data = append([]byte(xml.Header), data...)
+	} else {
+		data, err = json.MarshalIndent(r, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      float64         `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junit converts the report to a JUnit test suite. Each entry is a test case that's classed by
+// its type ID.
+func (r *validateReport) junit() junitTestSuite {
+	suite := junitTestSuite{Name: r.Plugin, Tests: len(r.Results)}
+	for _, result := range r.Results {
+		testCase := junitTestCase{ClassName: result.TypeID, Name: result.Path, Time: result.Duration}
+		if testCase.ClassName == "" {
+			testCase.ClassName = r.Plugin
+		}
+		if len(result.Failures) > 0 {
+			suite.Failures++
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("%v failures", len(result.Failures)),
+				Text:    strings.Join(result.Failures, "\n"),
+			}
+		}
+		suite.Time += result.Duration
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	return suite
+}
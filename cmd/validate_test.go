@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func TestCheckSchema(t *testing.T) {
+	parentSchema := &plugin.EntrySchema{}
+	parentSchema.Children = []string{"foo::container"}
+	schema := &plugin.EntrySchema{}
+
+	assert.NoError(t, checkSchema("/foo/c1", "foo::container", schema, parentSchema))
+	assert.EqualError(t, checkSchema("/foo/c1", "foo::volume", schema, parentSchema),
+		"the type ID foo::volume of /foo/c1 is not one of its parent's child types [foo::container]")
+	assert.EqualError(t, checkSchema("/foo/c1", "foo::container", nil, parentSchema),
+		"/foo/c1 has no schema, but its parent does")
+}
+
+func TestValidateReportJUnit(t *testing.T) {
+	report := &validateReport{Plugin: "foo"}
+	report.add(validateResult{Path: "/foo", Checks: []string{"list"}, Duration: 1})
+	report.add(validateResult{
+		Path:     "/foo/c1",
+		TypeID:   "foo::container",
+		Checks:   []string{"list", "exec"},
+		Failures: []string{"a", "b"},
+		Duration: 2,
+	})
+
+	suite := report.junit()
+	assert.Equal(t, "foo", suite.Name)
+	assert.Equal(t, 2, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, 3.0, suite.Time)
+	if assert.Len(t, suite.TestCases, 2) {
+		assert.Equal(t, "foo", suite.TestCases[0].ClassName)
+		assert.Nil(t, suite.TestCases[0].Failure)
+		assert.Equal(t, "foo::container", suite.TestCases[1].ClassName)
+		assert.Equal(t, &junitFailure{Message: "2 failures", Text: "a\nb"}, suite.TestCases[1].Failure)
+	}
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/puppetlabs/wash/api/client"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+func watchCommand() *cobra.Command {
+	use, aliases := generateShellAlias("watch")
+	watchCmd := &cobra.Command{
+		Use:     use + " [--interval <duration>] [--content] <path> [<path>]...",
+		Aliases: aliases,
+		Short:   "Prints the changes to the entries at the specified paths",
+		Long: `Monitors the entries at the specified paths and prints their changes as
+they happen. Each change is printed on its own line as
+  <time> <path>: added <child>            (a child was added)
+  <time> <path>: removed <child>          (a child was removed)
+  <time> <path>: metadata <change>        (a metadata field changed; see 'wash diff --meta')
+  <time> <path>: content changed
+Content changes are only watched if --content is set, since the content has to be
+read to detect them.
+
+Plugins do not report changes, so watch polls the entries every interval. It
+clears the entries' cache before each poll so that the changes show up as soon
+as possible. Stop it with Ctrl-C.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: toRunE(watchMain),
+	}
+	watchCmd.Flags().DurationP("interval", "n", 5*time.Second, "How often to poll the entries")
+	watchCmd.Flags().Bool("content", false, "Also watch the entries' content")
+
+	return watchCmd
+}
+
+func watchMain(cmd *cobra.Command, args []string) exitCode {
+	interval, err := cmd.Flags().GetDuration("interval")
+	if err != nil {
+		panic(err.Error())
+	}
+	if interval <= 0 {
+		cmdutil.ErrPrintf("the interval must be positive\n")
+		return exitCode{1}
+	}
+	watchContent, err := cmd.Flags().GetBool("content")
+	if err != nil {
+		panic(err.Error())
+	}
+
+	conn := cmdutil.NewClient()
+	watchers := make([]*watcher, len(args))
+	for i, path := range args {
+		watchers[i] = &watcher{conn: conn, path: path, watchContent: watchContent}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for first := true; ; first = false {
+		var wg sync.WaitGroup
+		for _, w := range watchers {
+			wg.Add(1)
+			go func(w *watcher) {
+				defer wg.Done()
+				w.poll(!first)
+			}(w)
+		}
+		wg.Wait()
+		<-ticker.C
+	}
+}
+
+// watcher polls an entry for changes
+type watcher struct {
+	conn         client.Client
+	path         string
+	watchContent bool
+	children     map[string]bool
+	metadata     interface{}
+	contentHash  []byte
+	failing      bool
+}
+
+// poll fetches the entry's current state. If report is true, then it prints
+// the differences between the current state and the previous state.
+func (w *watcher) poll(report bool) {
+	if report {
+		if _, err := w.conn.Clear(w.path, apitypes.ClearOptions{}); err != nil {
+			w.fail(err)
+			return
+		}
+	}
+	e, err := w.conn.Info(w.path)
+	if err != nil {
+		w.fail(err)
+		return
+	}
+	if w.failing {
+		w.failing = false
+		w.printf("available again")
+	}
+
+	if e.Supports(plugin.ListAction()) {
+		entries, err := w.conn.List(w.path)
+		if err != nil {
+			w.fail(err)
+			return
+		}
+		children := make(map[string]bool, len(entries))
+		for _, child := range entries {
+			children[child.CName] = true
+		}
+		if report {
+			for _, name := range sortedDifference(children, w.children) {
+				w.printf("added %v", name)
+			}
+			for _, name := range sortedDifference(w.children, children) {
+				w.printf("removed %v", name)
+			}
+		}
+		w.children = children
+	}
+
+	metadata, err := normalizedMetadata(w.conn, w.path)
+	if err != nil {
+		w.fail(err)
+		return
+	}
+	if report && w.metadata != nil {
+		for _, change := range metadataDiff("", w.metadata, metadata) {
+			w.printf("metadata %v", change)
+		}
+	}
+	w.metadata = metadata
+
+	if w.watchContent && e.Supports(plugin.ReadAction()) {
+		hash, err := w.hashContent()
+		if err != nil {
+			w.fail(err)
+			return
+		}
+		if report && string(hash) != string(w.contentHash) {
+			w.printf("content changed")
+		}
+		w.contentHash = hash
+	}
+}
+
+func (w *watcher) hashContent() ([]byte, error) {
+	rdr, err := w.conn.Read(w.path)
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, rdr); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// fail reports err once, until the entry is available again. This way, an
+// entry that's deleted doesn't print the same error every interval.
+func (w *watcher) fail(err error) {
+	if w.failing {
+		return
+	}
+	w.failing = true
+	cmdutil.SafeErrPrintf("%v %v: %v\n", time.Now().Format(time.RFC3339), w.path, err)
+}
+
+func (w *watcher) printf(msg string, a ...interface{}) {
+	cmdutil.SafePrintf("%v %v: "+msg+"\n", append([]interface{}{time.Now().Format(time.RFC3339), w.path}, a...)...)
+}
+
+// sortedDifference returns the sorted keys of a that aren't in b
+func sortedDifference(a map[string]bool, b map[string]bool) []string {
+	var diff []string
+	for k := range a {
+		if !b[k] {
+			diff = append(diff, k)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
@@ -7,6 +7,7 @@ import (
 	"os/user"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"bazil.org/fuse"
@@ -14,6 +15,7 @@ import (
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/analytics"
 	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/tracing"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -72,6 +74,11 @@ func (f *fuseNode) String() string {
 	return plugin.ID(f.entry)
 }
 
+// startSpan starts a tracing span for the FUSE operation op on f.
+func (f *fuseNode) startSpan(ctx context.Context, op string) (context.Context, *tracing.Span) {
+	return tracing.Start(ctx, "FUSE: "+op, tracing.String("wash.path", f.String()))
+}
+
 // Applies attributes where non-default, and sets defaults otherwise.
 func applyAttr(a *fuse.Attr, attr plugin.EntryAttributes, defaultMode os.FileMode) {
 	// Setting a.Valid to 1 second avoids frequent Attr calls.
@@ -141,6 +148,48 @@ func (f *fuseNode) refind(ctx context.Context) (plugin.Entry, error) {
 	return plugin.FindEntry(ctx, parent, segments)
 }
 
+// Sets the mode, atime and mtime in req on the current entry. They're ignored
+// if the entry doesn't support the setattr action so that tools like touch and
+// editors that preserve a file's mode still work on it.
+func (f *fuseNode) setAttr(ctx context.Context, req *fuse.SetattrRequest) error {
+	var attr plugin.EntryAttributes
+	if req.Valid.Mode() {
+		attr.SetMode(req.Mode.Perm())
+	}
+	if req.Valid.AtimeNow() {
+		attr.SetAtime(time.Now())
+	} else if req.Valid.Atime() {
+		attr.SetAtime(req.Atime)
+	}
+	if req.Valid.MtimeNow() {
+		attr.SetMtime(time.Now())
+	} else if req.Valid.Mtime() {
+		attr.SetMtime(req.Mtime)
+	}
+	if !attr.HasMode() && !attr.HasAtime() && !attr.HasMtime() {
+		return nil
+	}
+	if !plugin.SetAttrAction().IsSupportedOn(f.entry) {
+		log.Debugf("FUSE: Ignoring Setattr %v: %+v", f, attr.ToMap())
+		return nil
+	}
+
+	ctx, span := f.startSpan(ctx, "SetAttr")
+	defer span.End()
+	if err := plugin.SetAttrWithAnalytics(ctx, f.entry.(plugin.SetAttrable), attr); err != nil {
+		span.RecordError(err)
+		activity.Warnf(ctx, "FUSE: Setattr %v errored: %v", f, err)
+		if plugin.IsActionNotPermittedErr(err) {
+			return syscall.EPERM
+		}
+		if plugin.IsInvalidInputErr(err) {
+			return syscall.EINVAL
+		}
+		return err
+	}
+	return nil
+}
+
 // ServeFuseFS starts serving a fuse filesystem that lists the registered plugins.
 // It returns three values:
 //   1. A channel to initiate the shutdown (stopCh).
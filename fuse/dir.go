@@ -9,6 +9,7 @@ import (
 	"bazil.org/fuse/fs"
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/tracing"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -20,7 +21,9 @@ type dir struct {
 
 var _ fs.Node = (*dir)(nil)
 var _ = fs.NodeRequestLookuper(&dir{})
-var _ = fs.HandleReadDirAller(&dir{})
+var _ = fs.NodeRenamer(&dir{})
+var _ = fs.NodeCreater(&dir{})
+var _ = fs.NodeMkdirer(&dir{})
 
 func newDir(p *dir, e plugin.Parent) *dir {
 	return &dir{newFuseNode("d", p, e)}
@@ -47,9 +50,13 @@ func (d *dir) Lookup(ctx context.Context, req *fuse.LookupRequest, resp *fuse.Lo
 	// Find is only occasionally useful and happens a lot. Log it to debug like other activity, but
 	// leave it out of activity because it introduces history entries for miscellaneous shell commands.
 	log.Debugf("FUSE: Find %v in %v", req.Name, d)
+	ctx, span := d.startSpan(ctx, "Find")
+	defer span.End()
+	span.SetAttributes(tracing.String("wash.name", req.Name))
 
 	entries, err := d.children(ctx)
 	if err != nil {
+		span.RecordError(err)
 		activity.Warnf(ctx, "FUSE: Find %v in %v errored: %v", req.Name, d, err)
 		return nil, syscall.ENOENT
 	}
@@ -61,6 +68,11 @@ func (d *dir) Lookup(ctx context.Context, req *fuse.LookupRequest, resp *fuse.Lo
 		return nil, syscall.ENOENT
 	}
 
+	if plugin.SymlinkTarget(entry) != "" {
+		log.Debugf("FUSE: Found symlink %v/%v", d, cname)
+		return newSymlink(d, entry), nil
+	}
+
 	if plugin.ListAction().IsSupportedOn(entry) {
 		childdir := newDir(d, entry.(plugin.Parent))
 		log.Debugf("FUSE: Found directory %v", childdir)
@@ -71,33 +83,153 @@ func (d *dir) Lookup(ctx context.Context, req *fuse.LookupRequest, resp *fuse.Lo
 	return newFile(d, entry), nil
 }
 
-// ReadDirAll lists all children of the directory.
-func (d *dir) ReadDirAll(ctx context.Context) ([]fuse.Dirent, error) {
-	activity.Record(ctx, "FUSE: List %v", d)
+var _ = fs.NodeOpener(&dir{})
+
+// Open opens the directory for READDIR. Its children are listed in the
+// background by the returned handle so that huge directories start returning
+// entries before they've been completely listed.
+func (d *dir) Open(ctx context.Context, req *fuse.OpenRequest, resp *fuse.OpenResponse) (fs.Handle, error) {
+	activity.Record(ctx, "FUSE: Open %v: %+v", d, *req)
+	entry, err := d.refind(ctx)
+	if err != nil {
+		activity.Warnf(ctx, "FUSE: Open errored %v, %v", d, err)
+		return nil, err
+	}
+	if !plugin.ListAction().IsSupportedOn(entry) {
+		return nil, syscall.ENOENT
+	}
+	return newDirHandle(ctx, d, entry.(plugin.Parent), uint64(req.Node)), nil
+}
+
+// Rename renames one of the directory's children. Entries can only be renamed
+// within their parent, so moving an entry to another directory returns EXDEV.
+// Tools like mv then fallback to copying the entry.
+func (d *dir) Rename(ctx context.Context, req *fuse.RenameRequest, newDir fs.Node) error {
+	activity.Record(ctx, "FUSE: Rename %v in %v to %v", req.OldName, d, req.NewName)
+	ctx, span := d.startSpan(ctx, "Rename")
+	defer span.End()
+	span.SetAttributes(tracing.String("wash.name", req.OldName), tracing.String("wash.new_name", req.NewName))
+
+	if newDir != fs.Node(d) {
+		return syscall.EXDEV
+	}
 
 	entries, err := d.children(ctx)
 	if err != nil {
-		activity.Warnf(ctx, "FUSE: List %v errored: %v", d, err)
+		span.RecordError(err)
+		activity.Warnf(ctx, "FUSE: Rename %v in %v errored: %v", req.OldName, d, err)
+		return syscall.ENOENT
+	}
+	entry, ok := entries.Load(req.OldName)
+	if !ok {
+		return syscall.ENOENT
+	}
+	if !plugin.RenameAction().IsSupportedOn(entry) {
+		return syscall.ENOTSUP
+	}
+
+	if err := plugin.RenameWithAnalytics(ctx, entry.(plugin.Renameable), req.NewName); err != nil {
+		span.RecordError(err)
+		activity.Warnf(ctx, "FUSE: Rename %v in %v errored: %v", req.OldName, d, err)
+		if plugin.IsActionNotPermittedErr(err) {
+			return syscall.EPERM
+		}
+		if plugin.IsInvalidInputErr(err) {
+			return syscall.EINVAL
+		}
+		return err
+	}
+	return nil
+}
+
+// Create creates a file in the directory and opens it. It's called when a file
+// that doesn't exist is opened with O_CREATE, e.g. by touch.
+func (d *dir) Create(ctx context.Context, req *fuse.CreateRequest, resp *fuse.CreateResponse) (fs.Node, fs.Handle, error) {
+	entry, err := d.create(ctx, req.Name, req.Mode)
+	if err != nil {
+		return nil, nil, err
+	}
+	f := newFile(d, entry)
+	handle, err := f.Open(ctx, &fuse.OpenRequest{Header: req.Header, Flags: req.Flags}, &resp.OpenResponse)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, handle, nil
+}
+
+// Mkdir creates a directory in the directory.
+func (d *dir) Mkdir(ctx context.Context, req *fuse.MkdirRequest) (fs.Node, error) {
+	entry, err := d.create(ctx, req.Name, os.ModeDir|req.Mode)
+	if err != nil {
+		return nil, err
+	}
+	if !plugin.ListAction().IsSupportedOn(entry) {
+		activity.Warnf(ctx, "FUSE: Mkdir %v in %v created an entry that isn't a directory", req.Name, d)
+		return nil, syscall.EIO
+	}
+	return newDir(d, entry.(plugin.Parent)), nil
+}
+
+func (d *dir) create(ctx context.Context, name string, mode os.FileMode) (plugin.Entry, error) {
+	activity.Record(ctx, "FUSE: Create %v in %v with mode %v", name, d, mode)
+	ctx, span := d.startSpan(ctx, "Create")
+	defer span.End()
+	span.SetAttributes(tracing.String("wash.name", name))
+
+	parent, err := d.refind(ctx)
+	if err != nil {
+		span.RecordError(err)
+		activity.Warnf(ctx, "FUSE: Create %v in %v errored: %v", name, d, err)
 		return nil, err
 	}
+	if !plugin.CreateAction().IsSupportedOn(parent) {
+		return nil, syscall.ENOTSUP
+	}
 
-	res := make([]fuse.Dirent, 0, entries.Len())
-	entries.Range(func(cname string, entry plugin.Entry) bool {
-		var de fuse.Dirent
-		de.Name = cname
-		if plugin.ListAction().IsSupportedOn(entry) {
-			de.Type = fuse.DT_Dir
-		} else {
-			de.Type = fuse.DT_File
+	var attr plugin.EntryAttributes
+	attr.SetMode(mode)
+	entry, err := plugin.CreateWithAnalytics(ctx, parent.(plugin.Creatable), name, attr)
+	if err != nil {
+		span.RecordError(err)
+		activity.Warnf(ctx, "FUSE: Create %v in %v errored: %v", name, d, err)
+		if plugin.IsActionNotPermittedErr(err) {
+			return nil, syscall.EPERM
+		}
+		if plugin.IsInvalidInputErr(err) {
+			return nil, syscall.EINVAL
 		}
-		res = append(res, de)
-		return true
-	})
-	activity.Record(ctx, "FUSE: Listed in %v: %+v", d, res)
-	return res, nil
+		return nil, err
+	}
+	return entry, nil
+}
+
+var _ = fs.NodeSetattrer(&dir{})
+
+// Setattr changes the directory's mode, atime and mtime, e.g. for chmod.
+func (d *dir) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *fuse.SetattrResponse) error {
+	activity.Record(ctx, "FUSE: Setattr %v: %+v", d, *req)
+	if err := d.setAttr(ctx, req); err != nil {
+		return err
+	}
+	return d.fillAttr(ctx, &resp.Attr)
 }
 
 func (d *dir) Attr(ctx context.Context, a *fuse.Attr) error {
+	if err := d.fillAttr(ctx, a); err != nil {
+		return err
+	}
+	// Attr is not a particularly interesting call and happens a lot. Log it to debug like other
+	// activity, but leave it out of activity because it introduces history entries for lots of
+	// miscellaneous shell activity.
+	if d.parent == nil {
+		log.Tracef("FUSE: Attr %v", d)
+	} else {
+		log.Debugf("FUSE: Attr %v: %+v", d, *a)
+	}
+	return nil
+}
+
+func (d *dir) fillAttr(ctx context.Context, a *fuse.Attr) error {
 	// FUSE caches nodes for a long time, meaning there's a chance that
 	// f's attributes are outdated. 'refind' requests the entry from its
 	// parent to ensure it has updated attributes.
@@ -111,14 +243,11 @@ func (d *dir) Attr(ctx context.Context, a *fuse.Attr) error {
 	// is not strictly necessary for the other FUSE operations, we choose to
 	// leave it alone.
 
-	applyAttr(a, plugin.Attributes(entry), os.ModeDir|0550)
-	// Attr is not a particularly interesting call and happens a lot. Log it to debug like other
-	// activity, but leave it out of activity because it introduces history entries for lots of
-	// miscellaneous shell activity.
-	if d.parent == nil {
-		log.Tracef("FUSE: Attr %v", d)
-	} else {
-		log.Debugf("FUSE: Attr %v: %+v", d, *a)
+	mode := os.ModeDir | 0550
+	if plugin.CreateAction().IsSupportedOn(entry) {
+		// Let tools know that they can create children.
+		mode |= 0220
 	}
+	applyAttr(a, plugin.Attributes(entry), mode)
 	return nil
 }
@@ -0,0 +1,140 @@
+package fuse
+
+import (
+	"context"
+	"sync"
+	"syscall"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/analytics"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// dirHandle serves READDIR requests for an open directory. The directory's
+// children are listed in the background and appended to data as they're
+// emitted, so reads return the children that have been listed so far instead
+// of waiting for the whole listing to finish.
+type dirHandle struct {
+	d      *dir
+	cancel context.CancelFunc
+
+	mux  sync.Mutex
+	cond *sync.Cond
+	data []byte
+	done bool
+	err  error
+}
+
+var _ = fs.HandleReader(&dirHandle{})
+var _ = fs.HandleReleaser(&dirHandle{})
+
+func newDirHandle(ctx context.Context, d *dir, p plugin.Parent, inode uint64) *dirHandle {
+	// The listing outlives the Open request, so it gets its own context. Pass
+	// along the request's journal and analytics client.
+	listCtx, cancel := context.WithCancel(context.Background())
+	listCtx = context.WithValue(listCtx, activity.JournalKey, ctx.Value(activity.JournalKey))
+	listCtx = context.WithValue(listCtx, analytics.ClientKey, ctx.Value(analytics.ClientKey))
+
+	h := &dirHandle{d: d, cancel: cancel}
+	h.cond = sync.NewCond(&h.mux)
+	go h.list(listCtx, p, inode)
+	return h
+}
+
+func (h *dirHandle) list(ctx context.Context, p plugin.Parent, inode uint64) {
+	activity.Record(ctx, "FUSE: List %v", h.d)
+	ctx, span := h.d.startSpan(ctx, "List")
+	defer span.End()
+
+	numEntries := 0
+	err := plugin.ListEachWithAnalytics(ctx, p, func(entry plugin.Entry) error {
+		cname := plugin.CName(entry)
+		de := fuse.Dirent{
+			Inode: fs.GenerateDynamicInode(inode, cname),
+			Name:  cname,
+			Type:  direntType(entry),
+		}
+		h.mux.Lock()
+		h.data = fuse.AppendDirent(h.data, de)
+		h.mux.Unlock()
+		h.cond.Broadcast()
+		numEntries++
+		// Stop listing if the handle's been released.
+		return ctx.Err()
+	})
+	if ctx.Err() != nil {
+		activity.Record(ctx, "FUSE: Stopped listing %v after %v entries because it was released", h.d, numEntries)
+	} else if err != nil {
+		span.RecordError(err)
+		activity.Warnf(ctx, "FUSE: List %v errored: %v", h.d, err)
+		if plugin.IsActionNotPermittedErr(err) {
+			err = syscall.EPERM
+		}
+	} else {
+		activity.Record(ctx, "FUSE: Listed %v entries in %v", numEntries, h.d)
+	}
+
+	h.mux.Lock()
+	h.done = true
+	h.err = err
+	h.mux.Unlock()
+	h.cond.Broadcast()
+}
+
+// Read waits until there are children past the requested offset or the
+// listing's finished, then returns as many of them as fit. An empty response
+// tells the kernel that there aren't any more children, so listing errors are
+// only returned once all of the children that were listed before the error
+// have been read.
+func (h *dirHandle) Read(ctx context.Context, req *fuse.ReadRequest, resp *fuse.ReadResponse) error {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	// Wake up the wait loop if the request's interrupted.
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go func() {
+		select {
+		case <-ctx.Done():
+			h.mux.Lock()
+			h.cond.Broadcast()
+			h.mux.Unlock()
+		case <-stopCh:
+		}
+	}()
+
+	for !h.done && int64(len(h.data)) <= req.Offset {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		h.cond.Wait()
+	}
+
+	if int64(len(h.data)) <= req.Offset {
+		return h.err
+	}
+	end := req.Offset + int64(req.Size)
+	if end > int64(len(h.data)) {
+		end = int64(len(h.data))
+	}
+	resp.Data = append(resp.Data[:0], h.data[req.Offset:end]...)
+	return nil
+}
+
+// Release stops the listing if it hasn't finished yet.
+func (h *dirHandle) Release(ctx context.Context, req *fuse.ReleaseRequest) error {
+	activity.Record(ctx, "FUSE: Release %v: %+v", h.d, *req)
+	h.cancel()
+	return nil
+}
+
+func direntType(entry plugin.Entry) fuse.DirentType {
+	if plugin.SymlinkTarget(entry) != "" {
+		return fuse.DT_Link
+	} else if plugin.ListAction().IsSupportedOn(entry) {
+		return fuse.DT_Dir
+	}
+	return fuse.DT_File
+}
@@ -0,0 +1,84 @@
+package fuse
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/puppetlabs/wash/datastore"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/plugin/plugintest"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type dirHandleTestSuite struct {
+	suite.Suite
+}
+
+func (suite *dirHandleTestSuite) SetupTest() {
+	plugin.SetTestCache(datastore.NewMemCache())
+}
+
+func (suite *dirHandleTestSuite) TearDownTest() {
+	plugin.UnsetTestCache()
+}
+
+// readAll reads the handle's dirents until it returns an empty response.
+func (suite *dirHandleTestSuite) readAll(handle fs.Handle) ([]byte, error) {
+	var data []byte
+	for {
+		req := &fuse.ReadRequest{Dir: true, Offset: int64(len(data)), Size: 4096}
+		var resp fuse.ReadResponse
+		if err := handle.(fs.HandleReader).Read(context.Background(), req, &resp); err != nil {
+			return data, err
+		}
+		if len(resp.Data) == 0 {
+			return data, nil
+		}
+		data = append(data, resp.Data...)
+	}
+}
+
+func (suite *dirHandleTestSuite) TestRead_ReturnsChildren() {
+	p := plugintest.NewMockParent()
+	p.On("List", mock.Anything).Return([]plugin.Entry{plugintest.NewMockParent(), plugintest.NewMockBase()}, nil).Once()
+
+	d := newDir(nil, p)
+	handle, err := d.Open(context.Background(), &fuse.OpenRequest{Header: fuse.Header{Node: 1}}, &fuse.OpenResponse{})
+	if !suite.NoError(err) {
+		return
+	}
+	defer func() {
+		suite.NoError(handle.(fs.HandleReleaser).Release(context.Background(), &fuse.ReleaseRequest{}))
+	}()
+
+	var expected []byte
+	expected = fuse.AppendDirent(expected, fuse.Dirent{Inode: fs.GenerateDynamicInode(1, "mockp"), Name: "mockp", Type: fuse.DT_Dir})
+	expected = fuse.AppendDirent(expected, fuse.Dirent{Inode: fs.GenerateDynamicInode(1, "mock"), Name: "mock", Type: fuse.DT_File})
+	data, err := suite.readAll(handle)
+	if suite.NoError(err) {
+		suite.Equal(expected, data)
+	}
+}
+
+func (suite *dirHandleTestSuite) TestRead_ReturnsListError() {
+	p := plugintest.NewMockParent()
+	expectedErr := fmt.Errorf("an error")
+	p.On("List", mock.Anything).Return([]plugin.Entry{}, expectedErr)
+
+	d := newDir(nil, p)
+	handle, err := d.Open(context.Background(), &fuse.OpenRequest{Header: fuse.Header{Node: 1}}, &fuse.OpenResponse{})
+	if !suite.NoError(err) {
+		return
+	}
+	data, err := suite.readAll(handle)
+	suite.Empty(data)
+	suite.Equal(expectedErr, err)
+}
+
+func TestDirHandle(t *testing.T) {
+	suite.Run(t, new(dirHandleTestSuite))
+}
@@ -12,6 +12,7 @@ import (
 	"bazil.org/fuse/fuseutil"
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/tracing"
 )
 
 // ==== FUSE file Interface ====
@@ -58,9 +59,10 @@ func newFile(p *dir, e plugin.Entry) *file {
 	return &file{fuseNode: newFuseNode("f", p, e), writers: make(map[fuse.HandleID]struct{})}
 }
 
+// Decompressed files aren't file-like because their size attribute is the compressed size.
 func (f *file) isFileLikeEntry() bool {
 	attr := plugin.Attributes(f.entry)
-	return attr.HasSize()
+	return attr.HasSize() && !f.decompressing()
 }
 
 // If currently writing a file-like object, we should use local content to fulfil many requests.
@@ -166,7 +168,13 @@ func (f *file) Open(ctx context.Context, req *fuse.OpenRequest, resp *fuse.OpenR
 
 	if f.isFileLikeEntry() || req.Flags.IsReadOnly() {
 		// Get the entry's readable size if we expect to do any reads or keep a local representation.
-		size, err := plugin.Size(ctx, f.entry)
+		var size uint64
+		var err error
+		if f.decompressing() {
+			size, _, err = plugin.DecompressedSize(ctx, f.entry)
+		} else {
+			size, err = plugin.Size(ctx, f.entry)
+		}
 		if err != nil {
 			activity.Warnf(ctx, "FUSE: Size errored %v, %v", f, err)
 			return nil, err
@@ -221,15 +229,26 @@ var _ = fs.HandleReader(&file{})
 func (f *file) Read(ctx context.Context, req *fuse.ReadRequest, resp *fuse.ReadResponse) error {
 	f.mux.Lock()
 	defer f.mux.Unlock()
+	ctx, span := f.startSpan(ctx, "Read")
+	defer span.End()
+	span.SetAttributes(tracing.Int("wash.size", int64(req.Size)), tracing.Int("wash.offset", req.Offset))
 
 	if f.useLocalContent() {
 		fuseutil.HandleRead(req, resp, f.data)
 	} else {
-		data, err := plugin.ReadWithAnalytics(ctx, f.entry, int64(req.Size), req.Offset)
+		read := plugin.ReadWithAnalytics
+		if f.decompressing() {
+			read = plugin.ReadDecompressedWithAnalytics
+		}
+		data, err := read(ctx, f.entry, int64(req.Size), req.Offset)
 		if err != nil && err != io.EOF {
+			span.RecordError(err)
 			activity.Warnf(ctx, "FUSE: Read errored %v, %v", f, err)
 			// If we don't ignore EOF, then cat will display an input/output error message
 			// for entries with unknown content size.
+			if plugin.IsActionNotPermittedErr(err) {
+				return syscall.EPERM
+			}
 			return err
 		}
 		resp.Data = data
@@ -244,6 +263,9 @@ var _ = fs.HandleWriter(&file{})
 func (f *file) Write(ctx context.Context, req *fuse.WriteRequest, resp *fuse.WriteResponse) error {
 	f.mux.Lock()
 	defer f.mux.Unlock()
+	ctx, span := f.startSpan(ctx, "Write")
+	defer span.End()
+	span.SetAttributes(tracing.Int("wash.size", int64(len(req.Data))), tracing.Int("wash.offset", req.Offset))
 
 	// Ensure handle is in list of writers.
 	f.writers[req.Handle] = struct{}{}
@@ -253,6 +275,7 @@ func (f *file) Write(ctx context.Context, req *fuse.WriteRequest, resp *fuse.Wri
 		if start := int64(len(f.data)); req.Offset > start {
 			data, err := f.load(ctx, start, req.Offset)
 			if err != nil {
+				span.RecordError(err)
 				activity.Warnf(ctx, "FUSE: Write errored %v, %v", f, err)
 				return err
 			}
@@ -301,6 +324,8 @@ func (f *file) Flush(ctx context.Context, req *fuse.FlushRequest) error {
 	if _, ok := f.writers[req.Handle]; !ok {
 		return nil
 	}
+	ctx, span := f.startSpan(ctx, "Flush")
+	defer span.End()
 
 	// If this handle had an open writer, write current data.
 	dataLen := int64(len(f.data))
@@ -314,6 +339,7 @@ func (f *file) Flush(ctx context.Context, req *fuse.FlushRequest) error {
 			// Missing some data, load the remainder before writing.
 			data, err := f.load(ctx, dataLen, int64(f.readSize))
 			if err != nil && err != io.EOF {
+				span.RecordError(err)
 				activity.Warnf(ctx, "FUSE: Error loading %v, %v", f, err)
 				return err
 			}
@@ -328,8 +354,25 @@ func (f *file) Flush(ctx context.Context, req *fuse.FlushRequest) error {
 		}
 	}
 
-	if err := plugin.WriteWithAnalytics(ctx, f.entry.(plugin.Writable), f.data); err != nil {
+	data := f.data
+	if f.decompressing() {
+		_, compression, err := plugin.DecompressedSize(ctx, f.entry)
+		if err == nil {
+			data, err = plugin.Compress(data, compression)
+		}
+		if err != nil {
+			span.RecordError(err)
+			activity.Warnf(ctx, "FUSE: Error compressing %v, %v", f, err)
+			return err
+		}
+	}
+
+	if err := plugin.WriteWithAnalytics(ctx, f.entry.(plugin.Writable), data); err != nil {
+		span.RecordError(err)
 		activity.Warnf(ctx, "FUSE: Error writing %v, %v", f, err)
+		if plugin.IsActionNotPermittedErr(err) {
+			return syscall.EPERM
+		}
 		return err
 	}
 
@@ -373,6 +416,19 @@ func (f *file) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *fuse
 		}
 	}
 
+	if err := f.setAttr(ctx, req); err != nil {
+		return err
+	}
+	if !f.useLocalContent() {
+		// Fetch the updated attributes like Attr does.
+		entry, err := f.refind(ctx)
+		if err != nil {
+			activity.Warnf(ctx, "FUSE: Setattr errored %v, %v", f, err)
+			return err
+		}
+		f.entry = entry
+	}
+
 	f.fillAttr(&resp.Attr)
 	return nil
 }
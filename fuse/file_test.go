@@ -3,12 +3,13 @@ package fuse
 import (
 	"context"
 	"testing"
+	"time"
 
 	"bazil.org/fuse"
 	"bazil.org/fuse/fs"
 	"github.com/puppetlabs/wash/datastore"
 	"github.com/puppetlabs/wash/plugin"
-	plugintest "github.com/puppetlabs/wash/plugin/test"
+	"github.com/puppetlabs/wash/plugin/plugintest"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -269,6 +270,32 @@ func (suite *fileTestSuite) TestSetAttr_NoHandle() {
 	suite.Error(err)
 }
 
+func (suite *fileTestSuite) TestSetAttr_SetsModeAndMtime() {
+	m := plugintest.NewMockSetAttr()
+	mtime := time.Now()
+	var expected plugin.EntryAttributes
+	expected.SetMode(0640)
+	expected.SetMtime(mtime)
+	m.On("SetAttr", mock.Anything, expected).Return(nil)
+
+	f := newFile(nil, m)
+	req := fuse.SetattrRequest{Valid: fuse.SetattrMode | fuse.SetattrMtime, Mode: 0640, Mtime: mtime}
+	var resp fuse.SetattrResponse
+	err := f.Setattr(suite.ctx, &req, &resp)
+	suite.NoError(err)
+	m.AssertExpectations(suite.T())
+}
+
+func (suite *fileTestSuite) TestSetAttr_Unsupported() {
+	m := plugintest.NewMockReadWrite()
+
+	f := newFile(nil, m)
+	req := fuse.SetattrRequest{Valid: fuse.SetattrMode, Mode: 0640}
+	var resp fuse.SetattrResponse
+	err := f.Setattr(suite.ctx, &req, &resp)
+	suite.NoError(err)
+}
+
 func (suite *fileTestSuite) assertFileHandle(handle fs.Handle) bool {
 	return suite.Implements((*fs.HandleReader)(nil), handle) &&
 		suite.Implements((*fs.HandleWriter)(nil), handle) &&
@@ -0,0 +1,43 @@
+package fuse
+
+import (
+	"context"
+	"os"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	log "github.com/sirupsen/logrus"
+)
+
+// ==== FUSE Symlink Interface ====
+
+// symlink represents an entry that links to another entry. Its target is
+// relative to its parent, so the link works regardless of the mountpoint.
+type symlink struct {
+	fuseNode
+}
+
+var _ fs.Node = (*symlink)(nil)
+var _ = fs.NodeReadlinker(&symlink{})
+
+func newSymlink(p *dir, e plugin.Entry) *symlink {
+	return &symlink{newFuseNode("l", p, e)}
+}
+
+func (l *symlink) Attr(ctx context.Context, a *fuse.Attr) error {
+	applyAttr(a, plugin.Attributes(l.entry), os.ModeSymlink|0777)
+	// Symlinks always have the same mode, and their size is their target's length.
+	a.Mode = os.ModeSymlink | 0777
+	a.Size = uint64(len(plugin.SymlinkTarget(l.entry)))
+	log.Debugf("FUSE: Attr %v: %+v", l, *a)
+	return nil
+}
+
+// Readlink returns the symlink's target.
+func (l *symlink) Readlink(ctx context.Context, req *fuse.ReadlinkRequest) (string, error) {
+	target := plugin.SymlinkTarget(l.entry)
+	activity.Record(ctx, "FUSE: Readlink %v: %v", l, target)
+	return target, nil
+}
@@ -0,0 +1,28 @@
+package fuse
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"bazil.org/fuse"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSymlink(t *testing.T) {
+	entry := plugin.NewSymlinkEntry("data", "/docker/volumes/data")
+	entry.SetTestID("/docker/containers/redis/volumes/data")
+	l := newSymlink(nil, entry)
+
+	var attr fuse.Attr
+	if assert.NoError(t, l.Attr(context.Background(), &attr)) {
+		assert.Equal(t, os.ModeSymlink|0777, attr.Mode)
+		assert.Equal(t, uint64(len("../../../volumes/data")), attr.Size)
+	}
+
+	target, err := l.Readlink(context.Background(), &fuse.ReadlinkRequest{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "../../../volumes/data", target)
+	}
+}
@@ -0,0 +1,81 @@
+package fuse
+
+import (
+	"context"
+	"sync"
+	"syscall"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// decompressXattr toggles whether a file's gzip or zstd compressed content is
+// transparently decompressed. Set it to 1 to turn decompression on, e.g. with
+// `setfattr -n user.wash.decompress -v 1 <file>`, and remove it to turn it
+// off. Writes to the file are compressed with the same format.
+const decompressXattr = "user.wash.decompress"
+
+// decompressed contains the IDs of the entries whose content is decompressed.
+// It's keyed by ID because the kernel can forget a file's node, and the next
+// Lookup creates a new one.
+var decompressed sync.Map
+
+func (f *file) decompressing() bool {
+	_, ok := decompressed.Load(plugin.ID(f.entry))
+	return ok
+}
+
+var _ = fs.NodeGetxattrer(&file{})
+
+func (f *file) Getxattr(ctx context.Context, req *fuse.GetxattrRequest, resp *fuse.GetxattrResponse) error {
+	if req.Name != decompressXattr || !f.decompressing() {
+		return fuse.ErrNoXattr
+	}
+	resp.Xattr = []byte("1")
+	return nil
+}
+
+var _ = fs.NodeListxattrer(&file{})
+
+func (f *file) Listxattr(ctx context.Context, req *fuse.ListxattrRequest, resp *fuse.ListxattrResponse) error {
+	if f.decompressing() {
+		resp.Append(decompressXattr)
+	}
+	return nil
+}
+
+var _ = fs.NodeSetxattrer(&file{})
+
+func (f *file) Setxattr(ctx context.Context, req *fuse.SetxattrRequest) error {
+	if req.Name != decompressXattr {
+		return syscall.ENOTSUP
+	}
+	if !plugin.ReadAction().IsSupportedOn(f.entry) {
+		activity.Warnf(ctx, "FUSE: Decompression unsupported on non-readable entry %v", f)
+		return syscall.ENOTSUP
+	}
+
+	switch string(req.Xattr) {
+	case "1", "true":
+		decompressed.Store(plugin.ID(f.entry), struct{}{})
+	case "0", "false":
+		decompressed.Delete(plugin.ID(f.entry))
+	default:
+		return syscall.EINVAL
+	}
+	activity.Record(ctx, "FUSE: Setxattr %v=%s on %v", req.Name, req.Xattr, f)
+	return nil
+}
+
+var _ = fs.NodeRemovexattrer(&file{})
+
+func (f *file) Removexattr(ctx context.Context, req *fuse.RemovexattrRequest) error {
+	if req.Name != decompressXattr || !f.decompressing() {
+		return fuse.ErrNoXattr
+	}
+	decompressed.Delete(plugin.ID(f.entry))
+	activity.Record(ctx, "FUSE: Removexattr %v on %v", req.Name, f)
+	return nil
+}
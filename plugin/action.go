@@ -1,5 +1,11 @@
 package plugin
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // MethodSignature defines what method signature is supported for a method.
 type MethodSignature int
 
@@ -8,6 +14,7 @@ const (
 	UnsupportedSignature MethodSignature = iota
 	DefaultSignature
 	BlockReadableSignature
+	PaginatedSignature
 )
 
 // Action represents a Wash action.
@@ -29,6 +36,10 @@ func newAction(name string, protocol string, corePluginEntrySignatureFunc func(e
 	return a
 }
 
+// metadataAction represents the metadata method. Every entry supports it so
+// it isn't one of the Actions, but action rules can still restrict it.
+var metadataAction = Action{Name: "metadata", Protocol: "Entry"}
+
 // IsSupportedOn returns true if the action's supported
 // on the specified entry, false otherwise.
 func (a Action) IsSupportedOn(entry Entry) bool {
@@ -45,6 +56,9 @@ func (a Action) signature(entry Entry) MethodSignature {
 }
 
 var listAction = newAction("list", "Parent", func(e Entry) MethodSignature {
+	if _, ok := e.(PaginatedLister); ok {
+		return PaginatedSignature
+	}
 	if _, ok := e.(Parent); ok {
 		return DefaultSignature
 	}
@@ -96,6 +110,41 @@ var signalAction = newAction("signal", "Signalable", func(e Entry) MethodSignatu
 	return UnsupportedSignature
 })
 
+var renameAction = newAction("rename", "Renameable", func(e Entry) MethodSignature {
+	if _, ok := e.(Renameable); ok {
+		return DefaultSignature
+	}
+	return UnsupportedSignature
+})
+
+var copyAction = newAction("copy", "Copyable", func(e Entry) MethodSignature {
+	if _, ok := e.(Copyable); ok {
+		return DefaultSignature
+	}
+	return UnsupportedSignature
+})
+
+var createAction = newAction("create", "Creatable", func(e Entry) MethodSignature {
+	if _, ok := e.(Creatable); ok {
+		return DefaultSignature
+	}
+	return UnsupportedSignature
+})
+
+var setAttrAction = newAction("setattr", "SetAttrable", func(e Entry) MethodSignature {
+	if _, ok := e.(SetAttrable); ok {
+		return DefaultSignature
+	}
+	return UnsupportedSignature
+})
+
+var searchAction = newAction("search", "Searchable", func(e Entry) MethodSignature {
+	if _, ok := e.(Searchable); ok {
+		return DefaultSignature
+	}
+	return UnsupportedSignature
+})
+
 // ListAction represents the list action
 func ListAction() Action {
 	return listAction
@@ -131,6 +180,31 @@ func SignalAction() Action {
 	return signalAction
 }
 
+// RenameAction represents the rename action
+func RenameAction() Action {
+	return renameAction
+}
+
+// CopyAction represents the copy action
+func CopyAction() Action {
+	return copyAction
+}
+
+// CreateAction represents the create action
+func CreateAction() Action {
+	return createAction
+}
+
+// SetAttrAction represents the setattr action
+func SetAttrAction() Action {
+	return setAttrAction
+}
+
+// SearchAction represents the search action
+func SearchAction() Action {
+	return searchAction
+}
+
 // Actions returns all of the available Wash actions as a map
 // of <action_name> => <action_object>.
 func Actions() map[string]Action {
@@ -144,7 +218,9 @@ func Actions() map[string]Action {
 }
 
 // SupportedActionsOf returns all of the given
-// entry's supported actions.
+// entry's supported actions. Actions that aren't
+// permitted on the entry, e.g. because of an
+// ActionRule, are omitted.
 func SupportedActionsOf(entry Entry) []string {
 	var supportedActions []string
 
@@ -156,14 +232,14 @@ func SupportedActionsOf(entry Entry) []string {
 	case externalPlugin:
 		for _, action := range actions {
 			signature := t.MethodSignature(action.Name)
-			if signature != UnsupportedSignature {
+			if signature != UnsupportedSignature && action.checkPermitted(entry) == nil {
 				supportedActions = append(supportedActions, action.Name)
 			}
 		}
 	default:
 		for _, action := range actions {
 			signature := action.corePluginEntrySignatureFunc(entry)
-			if signature != UnsupportedSignature {
+			if signature != UnsupportedSignature && action.checkPermitted(entry) == nil {
 				supportedActions = append(supportedActions, action.Name)
 			}
 		}
@@ -171,3 +247,139 @@ func SupportedActionsOf(entry Entry) []string {
 
 	return supportedActions
 }
+
+// ActionNotPermittedErr indicates that an action was rejected because it isn't
+// permitted on the entry, e.g. because Wash is in read-only mode.
+type ActionNotPermittedErr struct {
+	Action Action
+	Reason string
+}
+
+func (e ActionNotPermittedErr) Error() string {
+	return fmt.Sprintf("the %v action is not permitted: %v", e.Action.Name, e.Reason)
+}
+
+// IsActionNotPermittedErr returns true if err is an ActionNotPermittedErr
+// error object
+func IsActionNotPermittedErr(err error) bool {
+	_, ok := err.(ActionNotPermittedErr)
+	return ok
+}
+
+// mutatingActions are the actions that are rejected in read-only mode.
+var mutatingActions = map[string]bool{
+	writeAction.Name:   true,
+	execAction.Name:    true,
+	deleteAction.Name:  true,
+	signalAction.Name:  true,
+	renameAction.Name:  true,
+	copyAction.Name:    true,
+	createAction.Name:  true,
+	setAttrAction.Name: true,
+}
+
+var readOnly struct {
+	all     bool
+	plugins map[string]bool
+}
+
+// SetReadOnly rejects the write, exec, delete, signal, rename, copy, create and setattr
+// actions on every entry if all is true. Otherwise, they're only rejected on the given
+// plugins' entries. The actions are rejected regardless of whether the entries
+// support them.
+func SetReadOnly(all bool, plugins []string) {
+	readOnly.all = all
+	readOnly.plugins = make(map[string]bool)
+	for _, name := range plugins {
+		readOnly.plugins[name] = true
+	}
+}
+
+// ActionRule restricts the actions that are permitted on the entries at or
+// below Path, which is relative to the Wash root, e.g. "aws" or
+// "docker/containers". If Allow is set, then only its actions are permitted.
+// Otherwise, all actions except for Deny's are permitted.
+type ActionRule struct {
+	Path  string
+	Allow []string
+	Deny  []string
+}
+
+func (r ActionRule) permits(a Action) bool {
+	if r.Allow != nil {
+		return containsAction(r.Allow, a)
+	}
+	return !containsAction(r.Deny, a)
+}
+
+func containsAction(names []string, a Action) bool {
+	for _, name := range names {
+		if name == a.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// actionRules are sorted by descending path length so that an entry's most
+// specific rule is found first.
+var actionRules []ActionRule
+
+// SetActionRules restricts the actions that are permitted on the rules'
+// entries. Only an entry's most specific rule, the one with the longest
+// matching path, applies to it. This makes it possible to e.g. deny an action
+// on a plugin, but allow it on some of the plugin's entries.
+func SetActionRules(rules []ActionRule) error {
+	sortedRules := make([]ActionRule, 0, len(rules))
+	for _, rule := range rules {
+		rule.Path = strings.Trim(rule.Path, "/")
+		if rule.Path == "" {
+			return fmt.Errorf("an action rule is missing its path")
+		}
+		if rule.Allow != nil && rule.Deny != nil {
+			return fmt.Errorf("the %v action rule can't both allow and deny actions", rule.Path)
+		}
+		for _, name := range append(append([]string{}, rule.Allow...), rule.Deny...) {
+			if _, ok := actions[name]; !ok && name != metadataAction.Name {
+				return fmt.Errorf("the %v action rule has an unknown action %v", rule.Path, name)
+			}
+		}
+		sortedRules = append(sortedRules, rule)
+	}
+	sort.SliceStable(sortedRules, func(i, j int) bool {
+		return len(sortedRules[i].Path) > len(sortedRules[j].Path)
+	})
+	actionRules = sortedRules
+	return nil
+}
+
+func actionRuleOf(entry Entry) (ActionRule, bool) {
+	id := strings.Trim(entry.eb().id, "/")
+	for _, rule := range actionRules {
+		if id == rule.Path || strings.HasPrefix(id, rule.Path+"/") {
+			return rule, true
+		}
+	}
+	return ActionRule{}, false
+}
+
+// checkPermitted returns an ActionNotPermittedErr if the action isn't
+// permitted on the entry.
+func (a Action) checkPermitted(entry Entry) error {
+	name := pluginName(entry)
+	if name != "" && isPluginDisabled(name) {
+		return ActionNotPermittedErr{Action: a, Reason: fmt.Sprintf("the %v plugin is disabled", name)}
+	}
+	if mutatingActions[a.Name] {
+		if readOnly.all {
+			return ActionNotPermittedErr{Action: a, Reason: "Wash is in read-only mode"}
+		}
+		if readOnly.plugins[name] {
+			return ActionNotPermittedErr{Action: a, Reason: fmt.Sprintf("the %v plugin is read-only", name)}
+		}
+	}
+	if rule, ok := actionRuleOf(entry); ok && !rule.permits(a) {
+		return ActionNotPermittedErr{Action: a, Reason: fmt.Sprintf("it is restricted on %v", rule.Path)}
+	}
+	return nil
+}
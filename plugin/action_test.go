@@ -0,0 +1,89 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetActionRules_ValidatesRules(t *testing.T) {
+	defer func() { assert.NoError(t, SetActionRules(nil)) }()
+
+	err := SetActionRules([]ActionRule{{Path: "/", Deny: []string{"delete"}}})
+	assert.EqualError(t, err, "an action rule is missing its path")
+
+	err = SetActionRules([]ActionRule{{Path: "aws", Allow: []string{"list"}, Deny: []string{"delete"}}})
+	assert.EqualError(t, err, "the aws action rule can't both allow and deny actions")
+
+	err = SetActionRules([]ActionRule{{Path: "aws", Deny: []string{"destroy"}}})
+	assert.EqualError(t, err, "the aws action rule has an unknown action destroy")
+}
+
+func TestActionRules(t *testing.T) {
+	err := SetActionRules([]ActionRule{
+		{Path: "aws", Deny: []string{"delete", "signal"}},
+		{Path: "/aws/dev/", Deny: []string{}},
+		{Path: "docker/containers", Allow: []string{"list", "read"}},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer func() { assert.NoError(t, SetActionRules(nil)) }()
+
+	entry := newMethodWrappersTestsMockEntry("foo")
+	checkPermitted := func(id string, a Action) error {
+		entry.SetTestID(id)
+		return a.checkPermitted(entry)
+	}
+
+	// Deny
+	assert.NoError(t, checkPermitted("/aws/prod/foo", WriteAction()))
+	err = checkPermitted("/aws/prod/foo", DeleteAction())
+	assert.True(t, IsActionNotPermittedErr(err))
+	assert.EqualError(t, err, "the delete action is not permitted: it is restricted on aws")
+
+	// The most specific rule applies
+	assert.NoError(t, checkPermitted("/aws/dev/foo", DeleteAction()))
+
+	// Allow
+	assert.NoError(t, checkPermitted("/docker/containers/foo", ReadAction()))
+	err = checkPermitted("/docker/containers/foo", ExecAction())
+	assert.EqualError(t, err, "the exec action is not permitted: it is restricted on docker/containers")
+
+	// Paths only match whole segments
+	assert.NoError(t, checkPermitted("/awsfoo/bar", DeleteAction()))
+	assert.NoError(t, checkPermitted("/docker/volumes/foo", ExecAction()))
+
+	// SupportedActionsOf omits the entry's restricted actions
+	entry.SetTestID("/aws/prod/foo")
+	assert.ElementsMatch(t, []string{"list", "read", "write", "rename", "copy", "create", "setattr", "search"}, SupportedActionsOf(entry))
+	entry.SetTestID("/aws/dev/foo")
+	assert.ElementsMatch(t, []string{"list", "read", "write", "delete", "signal", "rename", "copy", "create", "setattr", "search"}, SupportedActionsOf(entry))
+}
+
+func TestActionRules_Metadata(t *testing.T) {
+	err := SetActionRules([]ActionRule{{Path: "aws", Deny: []string{"metadata"}}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer func() { assert.NoError(t, SetActionRules(nil)) }()
+
+	entry := newMethodWrappersTestsMockEntry("foo")
+	entry.SetTestID("/aws/foo")
+	_, err = Metadata(context.Background(), entry)
+	assert.True(t, IsActionNotPermittedErr(err))
+	assert.EqualError(t, err, "the metadata action is not permitted: it is restricted on aws")
+}
+
+func TestReadOnly_SupportedActionsOf(t *testing.T) {
+	entry := newMethodWrappersTestsMockEntry("foo")
+	entry.SetTestID("/aws/foo")
+
+	SetReadOnly(false, []string{"aws"})
+	defer SetReadOnly(false, nil)
+	assert.ElementsMatch(t, []string{"list", "read", "search"}, SupportedActionsOf(entry))
+
+	entry.SetTestID("/docker/foo")
+	assert.ElementsMatch(t, []string{"list", "read", "write", "delete", "signal", "rename", "copy", "create", "setattr", "search"}, SupportedActionsOf(entry))
+}
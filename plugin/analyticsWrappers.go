@@ -14,6 +14,20 @@ func ListWithAnalytics(ctx context.Context, p Parent) (*EntryMap, error) {
 	return List(ctx, p)
 }
 
+// ListEachWithAnalytics is a wrapper to plugin.ListEach. Use it when you need to
+// report a 'List' invocation to analytics. Otherwise, use plugin.ListEach.
+func ListEachWithAnalytics(ctx context.Context, p Parent, f func(Entry) error) error {
+	submitMethodInvocation(ctx, p, "List")
+	return ListEach(ctx, p, f)
+}
+
+// ListPageWithAnalytics is a wrapper to plugin.ListPage. Use it when you need to
+// report a 'List' invocation to analytics. Otherwise, use plugin.ListPage.
+func ListPageWithAnalytics(ctx context.Context, p Parent, limit int, continuationToken string) ([]Entry, string, error) {
+	submitMethodInvocation(ctx, p, "List")
+	return ListPage(ctx, p, limit, continuationToken)
+}
+
 // ReadWithAnalytics is a wrapper to plugin.Read. Use it when you need to report
 // a 'Read' invocation to analytics. Otherwise, use plugin.Read.
 func ReadWithAnalytics(ctx context.Context, e Entry, size int64, offset int64) ([]byte, error) {
@@ -21,6 +35,13 @@ func ReadWithAnalytics(ctx context.Context, e Entry, size int64, offset int64) (
 	return Read(ctx, e, size, offset)
 }
 
+// ReadDecompressedWithAnalytics is a wrapper to plugin.ReadDecompressed. Use it when you need
+// to report a 'Read' invocation to analytics. Otherwise, use plugin.ReadDecompressed.
+func ReadDecompressedWithAnalytics(ctx context.Context, e Entry, size int64, offset int64) ([]byte, error) {
+	submitMethodInvocation(ctx, e, "Read")
+	return ReadDecompressed(ctx, e, size, offset)
+}
+
 // StreamWithAnalytics is a wrapper to s#Stream. Use it when you need to report a 'Stream'
 // invocation to analytics. Otherwise, use s#Stream.
 func StreamWithAnalytics(ctx context.Context, s Streamable) (io.ReadCloser, error) {
@@ -42,6 +63,13 @@ func ExecWithAnalytics(ctx context.Context, e Execable, cmd string, args []strin
 	return Exec(ctx, e, cmd, args, opts)
 }
 
+// SearchWithAnalytics is a wrapper to plugin.Search. Use it when you need to report a
+// 'Search' invocation to analytics. Otherwise, use plugin.Search.
+func SearchWithAnalytics(ctx context.Context, s Searchable, pattern string) ([]SearchMatch, error) {
+	submitMethodInvocation(ctx, s, "Search")
+	return Search(ctx, s, pattern)
+}
+
 // SignalWithAnalytics is a wrapper to plugin.Signal. Use it when you need to report a
 // 'Signal' invocation to analytics. Otherwise, use plugin.Signal.
 func SignalWithAnalytics(ctx context.Context, s Signalable, signal string) error {
@@ -49,6 +77,34 @@ func SignalWithAnalytics(ctx context.Context, s Signalable, signal string) error
 	return Signal(ctx, s, signal)
 }
 
+// RenameWithAnalytics is a wrapper to plugin.Rename. Use it when you need to report a
+// 'Rename' invocation to analytics. Otherwise, use plugin.Rename.
+func RenameWithAnalytics(ctx context.Context, r Renameable, newName string) error {
+	submitMethodInvocation(ctx, r, "Rename")
+	return Rename(ctx, r, newName)
+}
+
+// CopyWithAnalytics is a wrapper to plugin.Copy. Use it when you need to report a
+// 'Copy' invocation to analytics. Otherwise, use plugin.Copy.
+func CopyWithAnalytics(ctx context.Context, c Copyable, dstParent Parent, dstName string) error {
+	submitMethodInvocation(ctx, c, "Copy")
+	return Copy(ctx, c, dstParent, dstName)
+}
+
+// CreateWithAnalytics is a wrapper to plugin.Create. Use it when you need to report a
+// 'Create' invocation to analytics. Otherwise, use plugin.Create.
+func CreateWithAnalytics(ctx context.Context, c Creatable, name string, attr EntryAttributes) (Entry, error) {
+	submitMethodInvocation(ctx, c, "Create")
+	return Create(ctx, c, name, attr)
+}
+
+// SetAttrWithAnalytics is a wrapper to plugin.SetAttr. Use it when you need to report a
+// 'SetAttr' invocation to analytics. Otherwise, use plugin.SetAttr.
+func SetAttrWithAnalytics(ctx context.Context, s SetAttrable, attr EntryAttributes) error {
+	submitMethodInvocation(ctx, s, "SetAttr")
+	return SetAttr(ctx, s, attr)
+}
+
 // DeleteWithAnalytics is a wrapper to plugin.Delete. Use it when you need to report a
 // 'Delete' invocation to analytics. Otherwise, use plugin.Delete.
 func DeleteWithAnalytics(ctx context.Context, d Deletable) (bool, error) {
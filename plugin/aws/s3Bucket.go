@@ -1,8 +1,10 @@
 package aws
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"sort"
 	"strings"
 	"time"
@@ -21,13 +23,28 @@ import (
 )
 
 // listObjects is a helper that lists the objects which start with a specific
-// prefix. We don't make it a method of s3Bucket because the helper's also
+// prefix. It collects the entries emitted by streamObjects.
+func listObjects(ctx context.Context, client *s3Client.S3, bucket string, prefix string) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	err := streamObjects(ctx, client, bucket, prefix, func(entry plugin.Entry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// streamObjects is a helper that lists the objects which start with a specific
+// prefix, passing them to emit one page at a time so that large buckets can be
+// consumed incrementally. We don't make it a method of s3Bucket because the helper's also
 // used by s3ObjectPrefix. While we could pass-around the same s3Bucket object to all
 // its s3ObjectPrefix children, doing so is not a good idea because (1) it is a bit overkill
 // to pass-around an entire object just to access only one of its methods and (2),
 // it makes it difficult to refresh the shared s3Bucket object when the original object
 // is evicted from the cache.
-func listObjects(ctx context.Context, client *s3Client.S3, bucket string, prefix string) ([]plugin.Entry, error) {
+func streamObjects(ctx context.Context, client *s3Client.S3, bucket string, prefix string, emit func(plugin.Entry) error) error {
 	// TODO: Clarify this a bit more later. For now, this should be enough.
 	//
 	// Everything's an object in S3. There is no such thing as a "hierarchy", meaning
@@ -106,45 +123,141 @@ func listObjects(ctx context.Context, client *s3Client.S3, bucket string, prefix
 		Prefix:    awsSDK.String(prefix),
 		Delimiter: awsSDK.String("/"),
 	}
-	resp, err := client.ListObjectsWithContext(ctx, request)
+	var emitErr error
+	err := client.ListObjectsPagesWithContext(ctx, request, func(resp *s3Client.ListObjectsOutput, lastPage bool) bool {
+		emitErr = emitObjects(ctx, client, bucket, prefix, resp.CommonPrefixes, resp.Contents, emit)
+		return emitErr == nil
+	})
 	if err != nil {
-		return nil, err
+		return err
+	}
+	return emitErr
+}
+
+// listObjectsPage is a helper that lists up to limit of the objects which start with a
+// specific prefix, starting from the page identified by continuationToken. It returns
+// the page's entries and the token of the next page, which is empty if it's the last one.
+// See streamObjects for how the objects are represented hierarchically.
+func listObjectsPage(ctx context.Context, client *s3Client.S3, bucket string, prefix string, limit int, continuationToken string) ([]plugin.Entry, string, error) {
+	request := &s3Client.ListObjectsV2Input{
+		Bucket:    awsSDK.String(bucket),
+		Prefix:    awsSDK.String(prefix),
+		Delimiter: awsSDK.String("/"),
+	}
+	if limit > 0 {
+		request.MaxKeys = awsSDK.Int64(int64(limit))
+	}
+	if continuationToken != "" {
+		request.ContinuationToken = awsSDK.String(continuationToken)
+	}
+	resp, err := client.ListObjectsV2WithContext(ctx, request)
+	if err != nil {
+		return nil, "", err
 	}
-	numPrefixes := len(resp.CommonPrefixes)
-	numObjects := len(resp.Contents)
-	entries := make([]plugin.Entry, 0, numPrefixes+numObjects)
 
+	var entries []plugin.Entry
+	_ = emitObjects(ctx, client, bucket, prefix, resp.CommonPrefixes, resp.Contents, func(entry plugin.Entry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	return entries, awsSDK.StringValue(resp.NextContinuationToken), nil
+}
+
+// emitObjects passes the entries for a page of the objects which start with a specific
+// prefix to emit, stopping if emit returns an error.
+func emitObjects(
+	ctx context.Context,
+	client *s3Client.S3,
+	bucket string,
+	prefix string,
+	commonPrefixes []*s3Client.CommonPrefix,
+	contents []*s3Client.Object,
+	emit func(plugin.Entry) error,
+) error {
 	activity.Record(
 		ctx,
 		"(Bucket %v, Prefix %v): Retrieved %v prefixes and %v objects",
 		bucket,
 		prefix,
-		numPrefixes,
-		numObjects,
+		len(commonPrefixes),
+		len(contents),
 	)
 
-	// resp.CommonPrefixes represents all of the object keys
-	for _, p := range resp.CommonPrefixes {
+	// commonPrefixes represents all of the object keys
+	for _, p := range commonPrefixes {
 		commonPrefix := awsSDK.StringValue(p.Prefix)
 		name := strings.TrimPrefix(commonPrefix, prefix)
 		if name != "/" {
 			name = strings.TrimSuffix(name, "/")
 		}
 
-		entries = append(entries, newS3ObjectPrefix(name, bucket, commonPrefix, client))
+		if err := emit(newS3ObjectPrefix(name, bucket, commonPrefix, client)); err != nil {
+			return err
+		}
 	}
 
-	for _, o := range resp.Contents {
+	for _, o := range contents {
 		key := awsSDK.StringValue(o.Key)
 		name := strings.TrimPrefix(key, prefix)
 		if name == "" {
 			// key == <prefix> so skip it. This is what the AWS console does.
 			continue
 		}
-		entries = append(entries, newS3Object(o, name, bucket, key, client))
+		if err := emit(newS3Object(o, name, bucket, key, client)); err != nil {
+			return err
+		}
 	}
 
-	return entries, nil
+	return nil
+}
+
+// createObject is a helper that creates an empty object named name under the prefix. If
+// attr's mode is a directory, then it creates a "<name>/" object instead, which is how the
+// S3 console creates folders, and returns the corresponding prefix.
+func createObject(ctx context.Context, client *s3Client.S3, bucket string, prefix string, name string, attr plugin.EntryAttributes) (plugin.Entry, error) {
+	key := prefix + name
+	isDir := attr.HasMode() && attr.Mode().IsDir()
+	if isDir {
+		key += "/"
+	}
+	// PutObject replaces an existing object, so check that the key's free first.
+	if err := checkKeyIsFree(ctx, client, bucket, key); err != nil {
+		return nil, err
+	}
+	resp, err := client.PutObjectWithContext(ctx, &s3Client.PutObjectInput{
+		Bucket: awsSDK.String(bucket),
+		Key:    awsSDK.String(key),
+		Body:   bytes.NewReader(nil),
+	})
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "S3 object create response: %+v", *resp)
+
+	if isDir {
+		return newS3ObjectPrefix(name, bucket, key, client), nil
+	}
+	o := &s3Client.Object{
+		Key:          awsSDK.String(key),
+		LastModified: awsSDK.Time(time.Now()),
+		Size:         awsSDK.Int64(0),
+	}
+	return newS3Object(o, name, bucket, key, client), nil
+}
+
+// checkKeyIsFree returns an error if an object with the key exists.
+func checkKeyIsFree(ctx context.Context, client *s3Client.S3, bucket string, key string) error {
+	_, err := client.HeadObjectWithContext(ctx, &s3Client.HeadObjectInput{
+		Bucket: awsSDK.String(bucket),
+		Key:    awsSDK.String(key),
+	})
+	if err == nil {
+		return fmt.Errorf("an object with the key %v already exists", key)
+	}
+	if awsErr, ok := err.(awserr.RequestFailure); ok && awsErr.StatusCode() == http.StatusNotFound {
+		return nil
+	}
+	return err
 }
 
 // deleteObjects is a helper that deletes all objects that start with a specific prefix.
@@ -171,7 +284,11 @@ func newS3Bucket(name string, crtime time.Time, session *session.Session) *s3Buc
 	}
 	bucket.crtime = crtime
 	bucket.client = s3Client.New(session)
-	bucket.cwcli = cloudwatch.New(session)
+	// S3-compatible endpoints don't have CloudWatch, so only AWS' buckets
+	// report their size.
+	if awsSDK.StringValue(session.Config.Endpoint) == "" {
+		bucket.cwcli = cloudwatch.New(session)
+	}
 	bucket.session = session
 	bucket.
 		Attributes().
@@ -201,6 +318,27 @@ func (b *s3Bucket) List(ctx context.Context) ([]plugin.Entry, error) {
 	return listObjects(ctx, b.client, b.Name(), "")
 }
 
+func (b *s3Bucket) StreamList(ctx context.Context, emit func(plugin.Entry) error) error {
+	if _, err := b.getRegion(ctx); err != nil {
+		return err
+	}
+	return streamObjects(ctx, b.client, b.Name(), "", emit)
+}
+
+func (b *s3Bucket) ListPage(ctx context.Context, limit int, continuationToken string) ([]plugin.Entry, string, error) {
+	if _, err := b.getRegion(ctx); err != nil {
+		return nil, "", err
+	}
+	return listObjectsPage(ctx, b.client, b.Name(), "", limit, continuationToken)
+}
+
+func (b *s3Bucket) Create(ctx context.Context, name string, attr plugin.EntryAttributes) (plugin.Entry, error) {
+	if _, err := b.getRegion(ctx); err != nil {
+		return nil, err
+	}
+	return createObject(ctx, b.client, b.Name(), "", name, attr)
+}
+
 func (b *s3Bucket) Delete(ctx context.Context) (bool, error) {
 	// According to https://docs.aws.amazon.com/AmazonS3/latest/dev/delete-or-empty-bucket.html,
 	// we must delete the bucket's objects and object versions (for versioned buckets) before
@@ -260,6 +398,10 @@ func (b *s3Bucket) Metadata(ctx context.Context) (plugin.JSONObject, error) {
 	metadata.Region = region
 	metadata.Crtime = b.crtime
 
+	if b.cwcli == nil {
+		return plugin.ToJSONObject(metadata), nil
+	}
+
 	// Get some metrics.
 	today := time.Now()
 	before := today.AddDate(0, 0, -3)
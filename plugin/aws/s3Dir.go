@@ -49,7 +49,13 @@ func (s *s3Dir) ChildSchemas() []*plugin.EntrySchema {
 
 // List lists the buckets.
 func (s *s3Dir) List(ctx context.Context) ([]plugin.Entry, error) {
-	resp, err := s.client.ListBucketsWithContext(ctx, nil)
+	return listBuckets(ctx, s.client, s.session)
+}
+
+// listBuckets is a helper that lists the buckets visible to client. It's
+// shared by s3Dir and s3Endpoint.
+func listBuckets(ctx context.Context, client *s3Client.S3, session *session.Session) ([]plugin.Entry, error) {
+	resp, err := client.ListBucketsWithContext(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("Error listing buckets: %w", err)
 	}
@@ -61,7 +67,7 @@ func (s *s3Dir) List(ctx context.Context) ([]plugin.Entry, error) {
 		buckets[i] = newS3Bucket(
 			awsSDK.StringValue(bucket.Name),
 			awsSDK.TimeValue(bucket.CreationDate),
-			s.session,
+			session,
 		)
 	}
 
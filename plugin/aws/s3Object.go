@@ -3,8 +3,11 @@ package aws
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io/ioutil"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
@@ -123,6 +126,108 @@ func (o *s3Object) Delete(ctx context.Context) (bool, error) {
 	return true, err
 }
 
+// Rename renames the object by copying it to its new key, then deleting the
+// original. S3 doesn't support renaming objects in place.
+func (o *s3Object) Rename(ctx context.Context, newName string) error {
+	newKey := strings.TrimSuffix(o.key, o.Name()) + newName
+	// CopyObject replaces an existing object, so check that newKey's free first.
+	if err := checkKeyIsFree(ctx, o.client, o.bucket, newKey); err != nil {
+		return err
+	}
+
+	if err := o.copyTo(ctx, o.client, o.bucket, newKey); err != nil {
+		return err
+	}
+	_, err := o.client.DeleteObjectWithContext(ctx, &s3Client.DeleteObjectInput{
+		Bucket: awsSDK.String(o.bucket),
+		Key:    awsSDK.String(o.key),
+	})
+	if err != nil {
+		return fmt.Errorf("%v was copied to %v, but could not be deleted: %v", o.key, newKey, err)
+	}
+	return nil
+}
+
+// Copy copies the object to a bucket or a prefix. S3 copies it, so its content
+// isn't downloaded.
+func (o *s3Object) Copy(ctx context.Context, dstParent plugin.Parent, dstName string) error {
+	switch p := dstParent.(type) {
+	case *s3Bucket:
+		// Use the bucket's region-specific client.
+		if _, err := p.getRegion(ctx); err != nil {
+			return err
+		}
+		return o.copyTo(ctx, p.client, p.Name(), dstName)
+	case *s3ObjectPrefix:
+		return o.copyTo(ctx, p.client, p.bucket, p.prefix+dstName)
+	default:
+		return fmt.Errorf("objects can only be copied to a bucket or a prefix, not %v", plugin.ID(dstParent))
+	}
+}
+
+// SetAttr stores the mode, atime and mtime in the object's user metadata as
+// the mode, atime and mtime keys, like s3fs does. S3 can only change an
+// object's metadata by copying the object onto itself, so its content isn't
+// downloaded.
+func (o *s3Object) SetAttr(ctx context.Context, attr plugin.EntryAttributes) error {
+	head, err := o.client.HeadObjectWithContext(ctx, &s3Client.HeadObjectInput{
+		Bucket: awsSDK.String(o.bucket),
+		Key:    awsSDK.String(o.key),
+	})
+	if err != nil {
+		return err
+	}
+
+	metadata := head.Metadata
+	if metadata == nil {
+		metadata = make(map[string]*string)
+	}
+	if attr.HasMode() {
+		metadata["mode"] = awsSDK.String(strconv.FormatUint(uint64(attr.Mode()), 8))
+	}
+	if attr.HasAtime() {
+		metadata["atime"] = awsSDK.String(strconv.FormatInt(attr.Atime().Unix(), 10))
+	}
+	if attr.HasMtime() {
+		metadata["mtime"] = awsSDK.String(strconv.FormatInt(attr.Mtime().Unix(), 10))
+	}
+
+	// Replacing the metadata also replaces the object's headers, so keep them.
+	resp, err := o.client.CopyObjectWithContext(ctx, &s3Client.CopyObjectInput{
+		Bucket:             awsSDK.String(o.bucket),
+		CopySource:         awsSDK.String(url.PathEscape(o.bucket + "/" + o.key)),
+		Key:                awsSDK.String(o.key),
+		Metadata:           metadata,
+		MetadataDirective:  awsSDK.String(s3Client.MetadataDirectiveReplace),
+		CacheControl:       head.CacheControl,
+		ContentDisposition: head.ContentDisposition,
+		ContentEncoding:    head.ContentEncoding,
+		ContentLanguage:    head.ContentLanguage,
+		ContentType:        head.ContentType,
+		StorageClass:       head.StorageClass,
+	})
+	if err != nil {
+		return err
+	}
+	activity.Record(ctx, "S3 object setattr response: %+v", *resp)
+	return nil
+}
+
+// copyTo copies the object to the given bucket and key. client must be the
+// destination bucket's client.
+func (o *s3Object) copyTo(ctx context.Context, client *s3Client.S3, bucket string, key string) error {
+	resp, err := client.CopyObjectWithContext(ctx, &s3Client.CopyObjectInput{
+		Bucket:     awsSDK.String(bucket),
+		CopySource: awsSDK.String(url.PathEscape(o.bucket + "/" + o.key)),
+		Key:        awsSDK.String(key),
+	})
+	if err != nil {
+		return err
+	}
+	activity.Record(ctx, "S3 object copy response: %+v", *resp)
+	return nil
+}
+
 const s3ObjectDescription = `
 This is an S3 object. See the bucket's docs for more details on
 why we have this kind of entry.
@@ -46,6 +46,22 @@ func (d *s3ObjectPrefix) List(ctx context.Context) ([]plugin.Entry, error) {
 	return listObjects(ctx, d.client, d.bucket, d.prefix)
 }
 
+// StreamList is List, except that it emits the S3 objects and S3 object
+// prefixes one page at a time.
+func (d *s3ObjectPrefix) StreamList(ctx context.Context, emit func(plugin.Entry) error) error {
+	return streamObjects(ctx, d.client, d.bucket, d.prefix, emit)
+}
+
+// ListPage lists a page of the S3 objects and S3 object prefixes that are
+// prefixed by the current S3 object prefix
+func (d *s3ObjectPrefix) ListPage(ctx context.Context, limit int, continuationToken string) ([]plugin.Entry, string, error) {
+	return listObjectsPage(ctx, d.client, d.bucket, d.prefix, limit, continuationToken)
+}
+
+func (d *s3ObjectPrefix) Create(ctx context.Context, name string, attr plugin.EntryAttributes) (plugin.Entry, error) {
+	return createObject(ctx, d.client, d.bucket, d.prefix, name, attr)
+}
+
 func (d *s3ObjectPrefix) Delete(ctx context.Context) (bool, error) {
 	err := deleteObjects(ctx, d.client, d.bucket, d.prefix)
 	return true, err
@@ -0,0 +1,205 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/puppetlabs/wash/plugin"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	s3Client "github.com/aws/aws-sdk-go/service/s3"
+)
+
+// S3Root is the root of the s3 plugin. It exposes the buckets of S3-compatible
+// services like MinIO, Ceph RGW and Wasabi. Unlike the AWS plugin, it does not
+// use AWS profiles. Instead, each endpoint's credentials are configured in
+// Wash's config file. It lives in the aws package so that it can reuse the S3
+// bucket, prefix and object entries.
+type S3Root struct {
+	plugin.EntryBase
+	endpoints []plugin.Entry
+}
+
+type s3EndpointConfig struct {
+	url             string
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	pathStyle       bool
+}
+
+// Init for root
+func (r *S3Root) Init(cfg map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("s3")
+	r.DisableDefaultCaching()
+
+	r.endpoints = []plugin.Entry{}
+	return plugin.ParseNamedConfigs("s3", cfg, "endpoints", func(name string, config interface{}) error {
+		endpointCfg, err := parseS3EndpointConfig(config)
+		if err != nil {
+			return err
+		}
+		endpoint, err := newS3Endpoint(name, endpointCfg)
+		if err != nil {
+			return err
+		}
+		r.endpoints = append(r.endpoints, endpoint)
+		return nil
+	})
+}
+
+// parseS3EndpointConfig parses one of the s3.endpoints configs
+func parseS3EndpointConfig(cfgI interface{}) (s3EndpointConfig, error) {
+	cfg := s3EndpointConfig{region: "us-east-1"}
+	m, ok := cfgI.(map[string]interface{})
+	if !ok {
+		return cfg, fmt.Errorf("expected a map, not %v", cfgI)
+	}
+
+	strOpts := map[string]*string{
+		"url":               &cfg.url,
+		"region":            &cfg.region,
+		"access_key_id":     &cfg.accessKeyID,
+		"secret_access_key": &cfg.secretAccessKey,
+	}
+	for key, ptr := range strOpts {
+		valueI, ok := m[key]
+		if !ok {
+			continue
+		}
+		value, ok := valueI.(string)
+		if !ok {
+			return cfg, fmt.Errorf("%v must be a string, not %v", key, valueI)
+		}
+		*ptr = value
+	}
+	if cfg.url == "" {
+		return cfg, fmt.Errorf("url must be set")
+	}
+
+	if pathStyleI, ok := m["path_style"]; ok {
+		pathStyle, ok := pathStyleI.(bool)
+		if !ok {
+			return cfg, fmt.Errorf("path_style must be a boolean, not %v", pathStyleI)
+		}
+		cfg.pathStyle = pathStyle
+	}
+	return cfg, nil
+}
+
+// Schema returns the root's schema
+func (r *S3Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "s3").
+		SetDescription(s3RootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *S3Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&s3Endpoint{}).Schema(),
+	}
+}
+
+// List lists the configured endpoints
+func (r *S3Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	return r.endpoints, nil
+}
+
+// NewS3Endpoint returns an entry that lists the buckets of the S3-compatible
+// endpoint at url. Plugins for providers with S3-compatible storage (like
+// DigitalOcean Spaces) use it to reuse the S3 bucket entries.
+func NewS3Endpoint(name string, url string, region string, accessKeyID string, secretAccessKey string) (plugin.Entry, error) {
+	return newS3Endpoint(name, s3EndpointConfig{
+		url:             url,
+		region:          region,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+	})
+}
+
+// s3Endpoint represents a configured S3-compatible endpoint
+type s3Endpoint struct {
+	plugin.EntryBase
+	session *session.Session
+	client  *s3Client.S3
+}
+
+func newS3Endpoint(name string, cfg s3EndpointConfig) (*s3Endpoint, error) {
+	awsCfg := awsSDK.NewConfig().
+		WithEndpoint(cfg.url).
+		WithRegion(cfg.region).
+		WithS3ForcePathStyle(cfg.pathStyle)
+	if cfg.accessKeyID != "" {
+		awsCfg = awsCfg.WithCredentials(credentials.NewStaticCredentials(cfg.accessKeyID, cfg.secretAccessKey, ""))
+	} else {
+		awsCfg = awsCfg.WithCredentials(credentials.AnonymousCredentials)
+	}
+	// Don't load the shared AWS config since these endpoints are independent
+	// of AWS profiles.
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *awsCfg,
+		SharedConfigState: session.SharedConfigDisable,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := &s3Endpoint{
+		EntryBase: plugin.NewEntry(name),
+	}
+	endpoint.session = sess
+	endpoint.client = s3Client.New(sess)
+	return endpoint, nil
+}
+
+func (e *s3Endpoint) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(e, "endpoint").
+		SetDescription(s3EndpointDescription)
+}
+
+func (e *s3Endpoint) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&s3Bucket{}).Schema(),
+	}
+}
+
+// List lists the endpoint's buckets
+func (e *s3Endpoint) List(ctx context.Context) ([]plugin.Entry, error) {
+	return listBuckets(ctx, e.client, e.session)
+}
+
+const s3RootDescription = `
+This is the s3 plugin root. It lists the S3-compatible endpoints (e.g. MinIO,
+Ceph RGW, Wasabi) configured in Wash's config file, e.g.
+
+  s3:
+    endpoints:
+      minio:
+        url: http://localhost:9000
+        access_key_id: minioadmin
+        secret_access_key: minioadmin
+        path_style: true
+      wasabi:
+        url: https://s3.us-west-1.wasabisys.com
+        region: us-west-1
+        access_key_id: ...
+        secret_access_key: ...
+
+The region defaults to 'us-east-1'. Set 'path_style' to true for services that
+don't support virtual-hosted-style bucket URLs. If no credentials are set, then
+requests are sent anonymously.
+
+Endpoints are independent of AWS profiles. Their buckets behave like the
+AWS plugin's S3 buckets, except that their metadata doesn't include the
+bucket's size since that comes from CloudWatch.
+`
+
+const s3EndpointDescription = `
+This is an S3-compatible endpoint. Its children are the buckets that are
+visible to the configured credentials.
+`
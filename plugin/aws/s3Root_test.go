@@ -0,0 +1,83 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseS3EndpointConfig(t *testing.T) {
+	cfg, err := parseS3EndpointConfig(map[string]interface{}{
+		"url":               "http://localhost:9000",
+		"access_key_id":     "minioadmin",
+		"secret_access_key": "miniosecret",
+		"path_style":        true,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, s3EndpointConfig{
+			url:             "http://localhost:9000",
+			region:          "us-east-1",
+			accessKeyID:     "minioadmin",
+			secretAccessKey: "miniosecret",
+			pathStyle:       true,
+		}, cfg)
+	}
+
+	cfg, err = parseS3EndpointConfig(map[string]interface{}{
+		"url":    "https://s3.us-west-1.wasabisys.com",
+		"region": "us-west-1",
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, s3EndpointConfig{url: "https://s3.us-west-1.wasabisys.com", region: "us-west-1"}, cfg)
+	}
+}
+
+func TestParseS3EndpointConfig_Errors(t *testing.T) {
+	cases := []struct {
+		cfg      interface{}
+		expected string
+	}{
+		{"http://localhost:9000", "expected a map, not http://localhost:9000"},
+		{map[string]interface{}{}, "url must be set"},
+		{map[string]interface{}{"url": 9000}, "url must be a string, not 9000"},
+		{map[string]interface{}{"url": "http://localhost:9000", "region": true}, "region must be a string, not true"},
+		{map[string]interface{}{"url": "http://localhost:9000", "path_style": "yes"}, "path_style must be a boolean, not yes"},
+	}
+	for _, c := range cases {
+		_, err := parseS3EndpointConfig(c.cfg)
+		assert.EqualError(t, err, c.expected)
+	}
+}
+
+func TestS3RootInit(t *testing.T) {
+	r := &S3Root{}
+	assert.NoError(t, r.Init(map[string]interface{}{}))
+	assert.Empty(t, r.endpoints)
+
+	err := r.Init(map[string]interface{}{
+		"endpoints": map[string]interface{}{
+			"wasabi": map[string]interface{}{"url": "https://s3.us-west-1.wasabisys.com", "region": "us-west-1"},
+			"minio":  map[string]interface{}{"url": "http://localhost:9000", "path_style": true},
+		},
+	})
+	if assert.NoError(t, err) {
+		entries, err := r.List(context.Background())
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, "minio", plugin.Name(entries[0]))
+			assert.Equal(t, "wasabi", plugin.Name(entries[1]))
+
+			minio := entries[0].(*s3Endpoint)
+			assert.Equal(t, "http://localhost:9000", *minio.session.Config.Endpoint)
+			assert.Equal(t, "us-east-1", *minio.session.Config.Region)
+			assert.True(t, *minio.session.Config.S3ForcePathStyle)
+		}
+	}
+
+	err = r.Init(map[string]interface{}{
+		"endpoints": map[string]interface{}{"minio": map[string]interface{}{}},
+	})
+	assert.EqualError(t, err, "s3.endpoints.minio config is invalid: url must be set")
+}
@@ -2,12 +2,17 @@ package plugin
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/puppetlabs/wash/datastore"
+	"github.com/puppetlabs/wash/tracing"
 )
 
 // KeyType is used to create a unique key type for looking up context values.
@@ -74,6 +79,21 @@ func opKeyRegex(op string, path string) *regexp.Regexp {
 	return regexp.MustCompile(expr)
 }
 
+// listKeysRegex returns a regex that matches the List and ListPage results
+// cached for path.
+func listKeysRegex(path string) *regexp.Regexp {
+	opRegex := "^(" + defaultOpCodeToNameMap[ListOp] + "|" + listPageOpName + ")::"
+
+	var expr string
+	if path == "/" {
+		expr = opRegex + "/$"
+	} else {
+		expr = opRegex + "/" + regexp.QuoteMeta(strings.Trim(path, "/")) + "$"
+	}
+
+	return regexp.MustCompile(expr)
+}
+
 // This returns a regex that matches <op>::<path> and <op>::<child_path>
 // where <child_path> is a descendant of <path>.
 func allOpKeysIncludingChildrenRegex(path string) *regexp.Regexp {
@@ -100,14 +120,132 @@ func ClearCacheFor(path string, clearAncestorList bool) []string {
 	if clearAncestorList {
 		// If we can find the source ancestor, clear it and everything below it from cache.
 		if sourceAncestorID := getSourceAncestorPathFromCache(path); sourceAncestorID != "" {
-			listOpName := defaultOpCodeToNameMap[ListOp]
-			deleted = append(deleted, cache.Delete(opKeyRegex(listOpName, sourceAncestorID))...)
+			deleted = append(deleted, cache.Delete(listKeysRegex(sourceAncestorID))...)
 		}
 	}
 
 	return deleted
 }
 
+// ClearCacheForOps is ClearCacheFor restricted to the results of the given ops, which must be
+// "List", "Read" or "Metadata". The source ancestor's list is only cleared if "List" is one of them.
+// If ops is empty, it's the same as ClearCacheFor(path, true).
+func ClearCacheForOps(path string, ops []string) ([]string, error) {
+	trimmedPath := strings.Trim(path, "/")
+	return clearCacheMatching(regexp.QuoteMeta(trimmedPath), "/"+trimmedPath, ops)
+}
+
+// ClearCacheForGlob is ClearCacheForOps for every cached entry whose path matches the provided
+// glob. The glob's syntax is the same as filepath.Match's, so wildcards don't match "/".
+func ClearCacheForGlob(glob string, ops []string) ([]string, error) {
+	if _, err := filepath.Match(glob, ""); err != nil {
+		return nil, fmt.Errorf("invalid glob %v: %v", glob, err)
+	}
+	trimmedGlob := strings.Trim(glob, "/")
+	var literalPath string
+	if !strings.ContainsAny(trimmedGlob, `*?[\`) {
+		literalPath = "/" + trimmedGlob
+	}
+	return clearCacheMatching(globRegex(trimmedGlob), literalPath, ops)
+}
+
+// clearCacheMatching removes the ops' results for all paths matching pathExpr (a regex for a path
+// without its leading slash) and their children. Source ancestors are found for the paths of the
+// removed results. They're also found for literalPath (if set), which may only be cached in its
+// parent's list.
+func clearCacheMatching(pathExpr string, literalPath string, ops []string) ([]string, error) {
+	opExpr := opQualifier
+	clearAncestorList := true
+	if len(ops) > 0 {
+		clearAncestorList = false
+		var opNames []string
+		for _, op := range ops {
+			valid := false
+			for _, opName := range defaultOpCodeToNameMap {
+				valid = valid || op == opName
+			}
+			if !valid {
+				return nil, fmt.Errorf("unknown op %v; must be List, Read or Metadata", op)
+			}
+			if op == defaultOpCodeToNameMap[ListOp] {
+				// Listed pages are cleared along with the list.
+				clearAncestorList = true
+				opNames = append(opNames, listPageOpName)
+			}
+			opNames = append(opNames, op)
+		}
+		opExpr = "^(" + strings.Join(opNames, "|") + ")::"
+	}
+
+	var rx *regexp.Regexp
+	if pathExpr == "" {
+		rx = regexp.MustCompile(opExpr + "/.*")
+	} else {
+		rx = regexp.MustCompile(opExpr + "/" + pathExpr + "($|/.*)")
+	}
+	deleted := cache.Delete(rx)
+	if !clearAncestorList {
+		return deleted, nil
+	}
+
+	// Find all the source ancestors before clearing any of their lists. Otherwise we'd search
+	// further up the tree for matches whose source ancestor was already cleared.
+	var paths []string
+	if literalPath != "" {
+		paths = append(paths, literalPath)
+	}
+	pathRx := regexp.MustCompile("^/" + pathExpr + "$")
+	for _, key := range deleted {
+		if ix := strings.Index(key, "::"); ix >= 0 && pathRx.MatchString(key[ix+2:]) {
+			paths = append(paths, key[ix+2:])
+		}
+	}
+	var ancestors []string
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		if ancestor := getSourceAncestorPathFromCache(path); ancestor != "" && !seen[ancestor] {
+			seen[ancestor] = true
+			ancestors = append(ancestors, ancestor)
+		}
+	}
+
+	for _, ancestor := range ancestors {
+		deleted = append(deleted, cache.Delete(listKeysRegex(ancestor))...)
+	}
+	return deleted, nil
+}
+
+// globRegex converts a filepath.Match glob to an equivalent regex. The glob must be valid.
+func globRegex(glob string) string {
+	var expr strings.Builder
+	inClass := false
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case c == '\\' && i+1 < len(glob):
+			i++
+			expr.WriteString(regexp.QuoteMeta(string(glob[i])))
+		case inClass && c == ']':
+			inClass = false
+			expr.WriteByte(c)
+		case inClass && (c == '-' || (c == '^' && glob[i-1] == '[')):
+			expr.WriteByte(c)
+		case inClass:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		case c == '[':
+			inClass = true
+			expr.WriteByte(c)
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return expr.String()
+}
+
 // Get the path for the ancestor that prefetched an entry at path. If none are found in the cache,
 // returns an empty string. This may be overly aggressive in some cases where it finds an ancestor
 // but it's not the immediate source ancestor; this seems like an acceptable compromise to make
@@ -150,6 +288,30 @@ func splitID(entryID string) (string, string) {
 	return parentID, cname
 }
 
+// OpCacheState describes the cache state of one of an entry's ops.
+// A negative TTL means that the op's caching is disabled.
+type OpCacheState struct {
+	TTL    time.Duration `json:"ttl"`
+	Cached bool          `json:"cached"`
+}
+
+// CacheStateOf returns the cache state of e's List, Read, and Metadata
+// ops, keyed by the op's name.
+func CacheStateOf(e Entry) map[string]OpCacheState {
+	state := make(map[string]OpCacheState)
+	for op, opName := range defaultOpCodeToNameMap {
+		opState := OpCacheState{TTL: e.eb().ttl[op]}
+		if opState.TTL >= 0 && e.eb().id != "" {
+			// Get returns the cached error if the op failed, which still
+			// counts as a cached result.
+			value, err := cache.Get(opName, e.eb().id)
+			opState.Cached = value != nil || err != nil
+		}
+		state[opName] = opState
+	}
+	return state
+}
+
 type opFunc func() (interface{}, error)
 
 // CachedOp caches the given op's result for the duration specified by the
@@ -171,6 +333,9 @@ func CachedOp(ctx context.Context, opName string, entry Entry, ttl time.Duration
 			panic(fmt.Sprintf("The opName %v conflicts with Cached%v", opName, actionOpName))
 		}
 	}
+	if opName == listPageOpName {
+		panic(fmt.Sprintf("The opName %v conflicts with ListPage", opName))
+	}
 
 	if ttl < 0 {
 		panic("plugin.CachedOp: received a negative TTL")
@@ -214,51 +379,294 @@ func (c DuplicateCNameErr) Error() string {
 // querying a specific entry.
 func cachedList(ctx context.Context, p Parent) (*EntryMap, error) {
 	cachedEntries, err := cachedDefaultOp(ctx, ListOp, p, func() (interface{}, error) {
-		// Including the entry's ID allows plugin authors to use any Cached* methods defined on the
-		// children after their creation. This is necessary when the child's Cached* methods are used
-		// to calculate its attributes. Note that the child's ID is set in cachedOp.
-		entries, err := p.List(context.WithValue(ctx, parentID, p.eb().id))
+		return listChildren(ctx, p, nil)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cachedEntries.(*EntryMap), nil
+}
+
+// cachedListEach is cachedList, except that it passes each child to f instead
+// of returning them. If the parent's List result isn't cached, then f is
+// called with each child as soon as it's been processed rather than after
+// all of them have been listed. Note that the children aren't passed to f in
+// any particular order.
+//
+// The children are listed outside of the cache's locks so that a slow f
+// doesn't hold up other cache operations. This also means that concurrent
+// calls can each invoke the plugin. A StreamLister's streamed children aren't
+// kept, so only its errors are cached; that keeps huge directories out of
+// memory. Lookups still go through cachedList, which caches them.
+//
+// If f returns an error, then cachedListEach stops listing and returns the
+// error. Nothing's cached in that case since the error's the consumer's, not
+// the plugin's. Nor is anything cached if the listing's cancelled.
+func cachedListEach(ctx context.Context, p Parent, f func(Entry) error) error {
+	opName := defaultOpCodeToNameMap[ListOp]
+	ttl := p.eb().ttl[ListOp]
+	if ttl >= 0 {
+		ensureCacheID(ctx, opName, p)
+		cachedEntries, err := cache.Get(opName, p.eb().id)
 		if err != nil {
-			return nil, err
+			return err
 		}
-
-		searchedEntries := newEntryMap()
-		for _, entry := range entries {
-			cname := CName(entry)
-
-			if duplicateEntry, ok := searchedEntries.mp[cname]; ok {
-				return nil, DuplicateCNameErr{
-					ParentID:                 p.eb().id,
-					FirstChildName:           duplicateEntry.eb().name,
-					FirstChildSlashReplacer:  duplicateEntry.eb().slashReplacer,
-					SecondChildName:          entry.eb().name,
-					SecondChildSlashReplacer: entry.eb().slashReplacer,
-					CName:                    cname,
+		if cachedEntries != nil {
+			// Pass along a snapshot of the cached result. The snapshot ensures
+			// that f can use the cache (e.g. to delete one of the children)
+			// without deadlocking on the map's lock.
+			var entries []Entry
+			cachedEntries.(*EntryMap).Range(func(_ string, entry Entry) bool {
+				entries = append(entries, entry)
+				return true
+			})
+			for _, entry := range entries {
+				if err := f(entry); err != nil {
+					return err
 				}
 			}
+			return nil
+		}
+	}
 
-			if entry.eb().isInaccessible {
-				// Skip entries that are expected to be inaccessible.
-				continue
-			}
+	var consumerErr error
+	children, err := listChildren(ctx, p, func(entry Entry) error {
+		if consumerErr == nil {
+			consumerErr = f(entry)
+		}
+		return consumerErr
+	})
+	if consumerErr != nil {
+		return consumerErr
+	}
+	if ttl < 0 {
+		return err
+	}
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// The cancellation's specific to this caller, e.g. a FUSE handle
+		// that was released early, so it mustn't become everyone's result.
+		return err
+	}
+	if err == nil && children == nil {
+		// The children were streamed without being kept, so there's
+		// nothing to cache.
+		return nil
+	}
+	// Another caller may have cached the result while we were listing. Keep
+	// theirs since it's just as fresh.
+	_, _ = cache.GetOrUpdate(opName, p.eb().id, ttl, false, func() (interface{}, error) {
+		if err != nil {
+			return nil, err
+		}
+		return children, nil
+	})
+	return err
+}
 
-			searchedEntries.mp[cname] = entry
+// listChildren invokes the parent's StreamList method if it's a StreamLister
+// and its List method otherwise, setting each child's ID and checking that
+// their cnames are unique. If emit's set, then each child is passed to it once
+// it's been processed. Listing stops if emit returns an error. Children that
+// are passed along before an error's found will still have been emitted.
+//
+// The children are returned as an EntryMap, except when a StreamLister's
+// children are emitted. Those aren't kept so that huge directories can be
+// streamed without holding all of them in memory; the returned map is nil.
+func listChildren(ctx context.Context, p Parent, emit func(Entry) error) (*EntryMap, error) {
+	// Including the entry's ID allows plugin authors to use any Cached* methods defined on the
+	// children after their creation. This is necessary when the child's Cached* methods are used
+	// to calculate its attributes. Note that the child's ID is set in cachedOp.
+	spanCtx, span := startMethodSpan(ctx, p, "List")
+	listCtx := context.WithValue(spanCtx, parentID, p.eb().id)
+
+	s, streaming := p.(StreamLister)
+	var searchedEntries *EntryMap
+	if !streaming || emit == nil {
+		searchedEntries = newEntryMap()
+	}
+	seen := make(map[string]childName)
+	add := func(entry Entry) error {
+		if added, err := addChild(p, seen, entry); !added || err != nil {
+			return err
+		}
+		if searchedEntries != nil {
+			searchedEntries.mp[CName(entry)] = entry
+		}
+		if emit != nil {
+			return emit(entry)
+		}
+		return nil
+	}
 
-			// Ensure ID is set on all entries so that we can use it for caching later in places
-			// where the context doesn't include the parent's ID.
-			setChildID(p.eb().id, entry)
+	if streaming {
+		// StreamList isn't retried because some of the children may already have
+		// been emitted. Its span includes the time it takes to consume them since
+		// they're emitted while the plugin's listing.
+		numEntries := 0
+		err := s.StreamList(listCtx, func(entry Entry) error {
+			numEntries++
+			return add(entry)
+		})
+		span.RecordError(err)
+		span.SetAttributes(tracing.Int("wash.entries", int64(numEntries)))
+		span.End()
+		if err != nil {
+			return nil, err
+		}
+		return searchedEntries, nil
+	}
 
-			passAlongWrappedTypes(p, entry)
+	var entries []Entry
+	err := retry(spanCtx, span, func() (err error) {
+		entries, err = p.List(listCtx)
+		return
+	})
+	span.RecordError(err)
+	span.SetAttributes(tracing.Int("wash.entries", int64(len(entries))))
+	// End the span before the children are emitted so that the time it takes
+	// to consume them isn't attributed to the plugin.
+	span.End()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if err := add(entry); err != nil {
+			return nil, err
 		}
+	}
 
-		return searchedEntries, nil
+	return searchedEntries, nil
+}
+
+// childName is what addChild needs to know about a child that's already been
+// added to report a duplicate cname. It's kept instead of the child so that
+// streamed children can be dropped once they've been emitted.
+type childName struct {
+	name          string
+	slashReplacer rune
+}
+
+// addChild records one of p's children in seen, setting its ID. It returns
+// false if the child was skipped because it's expected to be inaccessible, and
+// a DuplicateCNameErr if seen already has a child with the same cname.
+func addChild(p Parent, seen map[string]childName, entry Entry) (bool, error) {
+	cname := CName(entry)
+
+	if duplicate, ok := seen[cname]; ok {
+		return false, DuplicateCNameErr{
+			ParentID:                 p.eb().id,
+			FirstChildName:           duplicate.name,
+			FirstChildSlashReplacer:  duplicate.slashReplacer,
+			SecondChildName:          entry.eb().name,
+			SecondChildSlashReplacer: entry.eb().slashReplacer,
+			CName:                    cname,
+		}
+	}
+
+	if entry.eb().isInaccessible {
+		// Skip entries that are expected to be inaccessible.
+		return false, nil
+	}
+
+	seen[cname] = childName{name: entry.eb().name, slashReplacer: entry.eb().slashReplacer}
+
+	// Ensure ID is set on all entries so that we can use it for caching later in places
+	// where the context doesn't include the parent's ID.
+	setChildID(p.eb().id, entry)
+
+	passAlongWrappedTypes(p, entry)
+	return true, nil
+}
+
+// listPageOpName is the op that listed pages are cached under. A parent's
+// pages share its List TTL, and they're cleared whenever its list is.
+const listPageOpName = "ListPage"
+
+// pageKey identifies a listed page.
+type pageKey struct {
+	limit             int
+	continuationToken string
+}
+
+type cachedPage struct {
+	entries   []Entry
+	nextToken string
+}
+
+// pageCache holds the pages that have been listed for a parent.
+type pageCache struct {
+	mux   sync.Mutex
+	pages map[pageKey]cachedPage
+}
+
+// listPage invokes the parent's ListPage method, setting each child's ID and
+// checking that their cnames are unique within the page. Pages are cached by
+// their limit and continuation token. Errors aren't cached.
+func listPage(ctx context.Context, p PaginatedLister, limit int, continuationToken string) ([]Entry, string, error) {
+	ttl := p.eb().ttl[ListOp]
+	if ttl < 0 {
+		return fetchPage(ctx, p, limit, continuationToken)
+	}
+
+	ensureCacheID(ctx, listPageOpName, p)
+	cached, err := cache.GetOrUpdate(listPageOpName, p.eb().id, ttl, false, func() (interface{}, error) {
+		return &pageCache{pages: make(map[pageKey]cachedPage)}, nil
 	})
+	if err != nil {
+		return nil, "", err
+	}
+	pages := cached.(*pageCache)
+
+	key := pageKey{limit: limit, continuationToken: continuationToken}
+	pages.mux.Lock()
+	page, ok := pages.pages[key]
+	pages.mux.Unlock()
+	if ok {
+		return page.entries, page.nextToken, nil
+	}
 
+	entries, nextToken, err := fetchPage(ctx, p, limit, continuationToken)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
+	pages.mux.Lock()
+	pages.pages[key] = cachedPage{entries: entries, nextToken: nextToken}
+	pages.mux.Unlock()
+	return entries, nextToken, nil
+}
 
-	return cachedEntries.(*EntryMap), nil
+// fetchPage lists a page for listPage.
+func fetchPage(ctx context.Context, p PaginatedLister, limit int, continuationToken string) ([]Entry, string, error) {
+	spanCtx, span := startMethodSpan(ctx, p, "ListPage")
+	span.SetAttributes(tracing.Int("wash.limit", int64(limit)))
+	var entries []Entry
+	var nextToken string
+	err := retry(spanCtx, span, func() (err error) {
+		entries, nextToken, err = p.ListPage(context.WithValue(spanCtx, parentID, p.eb().id), limit, continuationToken)
+		return
+	})
+	span.RecordError(err)
+	span.SetAttributes(tracing.Int("wash.entries", int64(len(entries))))
+	span.End()
+	if err != nil {
+		return nil, "", err
+	}
+
+	seen := make(map[string]childName)
+	page := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		added, err := addChild(p, seen, entry)
+		if err != nil {
+			return nil, "", err
+		}
+		if added {
+			page = append(page, entry)
+		}
+	}
+	return page, nextToken, nil
 }
 
 // cachedRead caches an entry's Read method
@@ -269,8 +677,15 @@ func cachedRead(ctx context.Context, e Entry) (entryContent, error) {
 			// Both external and core plugin entries that have the default Read signature
 			// implement the Readable interface, so we can go ahead and cast directly.
 			r := e.(Readable)
-			rawContent, err := r.Read(ctx)
+			ctx, span := startMethodSpan(ctx, e, "Read")
+			defer span.End()
+			var rawContent []byte
+			err := retry(ctx, span, func() (err error) {
+				rawContent, err = r.Read(ctx)
+				return
+			})
 			if err != nil {
+				span.RecordError(err)
 				return nil, err
 			}
 			return newEntryContent(rawContent), nil
@@ -289,6 +704,21 @@ func cachedRead(ctx context.Context, e Entry) (entryContent, error) {
 				// We should never hit this code-path
 				panic("attempting to retrieve the content of a non-readable entry")
 			}
+			blockRead := readFunc
+			readFunc = func(ctx context.Context, size int64, offset int64) ([]byte, error) {
+				ctx, span := startMethodSpan(ctx, e, "Read")
+				defer span.End()
+				span.SetAttributes(tracing.Int("wash.size", size), tracing.Int("wash.offset", offset))
+				var data []byte
+				err := retry(ctx, span, func() (err error) {
+					data, err = blockRead(ctx, size, offset)
+					return
+				})
+				if err != io.EOF {
+					span.RecordError(err)
+				}
+				return data, err
+			}
 			content := newBlockReadableEntryContent(readFunc)
 			if attr := e.eb().attributes; attr.HasSize() {
 				content.sz = attr.Size()
@@ -311,7 +741,15 @@ func cachedRead(ctx context.Context, e Entry) (entryContent, error) {
 // cachedMetadata caches an entry's Metadata method
 func cachedMetadata(ctx context.Context, e Entry) (JSONObject, error) {
 	cachedMetadata, err := cachedDefaultOp(ctx, MetadataOp, e, func() (interface{}, error) {
-		return e.Metadata(ctx)
+		ctx, span := startMethodSpan(ctx, e, "Metadata")
+		defer span.End()
+		var meta JSONObject
+		err := retry(ctx, span, func() (err error) {
+			meta, err = e.Metadata(ctx)
+			return
+		})
+		span.RecordError(err)
+		return meta, err
 	})
 
 	if err != nil {
@@ -343,6 +781,13 @@ func cachedOp(ctx context.Context, opName string, entry Entry, ttl time.Duration
 		return op()
 	}
 
+	ensureCacheID(ctx, opName, entry)
+	return cache.GetOrUpdate(opName, entry.eb().id, ttl, false, op)
+}
+
+// ensureCacheID sets the entry's ID from the context's parent ID if it isn't
+// set yet. The ID's the key that the entry's op results are cached under.
+func ensureCacheID(ctx context.Context, opName string, entry Entry) {
 	if entry.eb().id == "" {
 		// Try to set the ID based on parent ID
 		if obj := ctx.Value(parentID); obj != nil {
@@ -351,8 +796,6 @@ func cachedOp(ctx context.Context, opName string, entry Entry, ttl time.Duration
 			panic(fmt.Sprintf("Cached op %v on %v had no cache ID and context did not include parent ID", opName, entry.eb().name))
 		}
 	}
-
-	return cache.GetOrUpdate(opName, entry.eb().id, ttl, false, op)
 }
 
 func setChildID(parentID string, child Entry) {
@@ -160,7 +160,7 @@ func mockEntryMap(child string, prefetched bool) *EntryMap {
 func (suite *CacheTestSuite) TestClearCache_WithParent() {
 	path := "/a/b"
 	rxEntry := allOpKeysIncludingChildrenRegex(path)
-	rxParent := opKeyRegex(defaultOpCodeToNameMap[ListOp], "/a")
+	rxParent := listKeysRegex("/a")
 
 	suite.cache.On("Get", "List", "/a").Return(mockEntryMap("b", false), nil)
 	suite.cache.On("Get", "List", "").Return(mockEntryMap("a", false), nil)
@@ -173,7 +173,7 @@ func (suite *CacheTestSuite) TestClearCache_WithParent() {
 func (suite *CacheTestSuite) TestClearCache_Prefetched() {
 	path := "/a/b"
 	rxEntry := allOpKeysIncludingChildrenRegex(path)
-	rxParent := opKeyRegex(defaultOpCodeToNameMap[ListOp], "/a")
+	rxParent := listKeysRegex("/a")
 
 	suite.cache.On("Get", "List", "/a").Return(mockEntryMap("b", true), nil)
 	suite.cache.On("Get", "List", "").Return(mockEntryMap("a", false), nil)
@@ -186,7 +186,7 @@ func (suite *CacheTestSuite) TestClearCache_Prefetched() {
 func (suite *CacheTestSuite) TestClearCache_SourceAncestor() {
 	path := "/a/b/c"
 	rxEntry := allOpKeysIncludingChildrenRegex(path)
-	rxParent := opKeyRegex(defaultOpCodeToNameMap[ListOp], "/a")
+	rxParent := listKeysRegex("/a")
 	entriesB := mockEntryMap("b", true)
 	entriesA := mockEntryMap("a", false)
 
@@ -200,6 +200,62 @@ func (suite *CacheTestSuite) TestClearCache_SourceAncestor() {
 	suite.Equal([]string{"List:" + path, "Read:" + path, "List:/a"}, deleted)
 }
 
+func (suite *CacheTestSuite) TestClearCacheForOps() {
+	path := "/a/b"
+	rxEntry := regexp.MustCompile("^(Read|Metadata)::/a/b($|/.*)")
+
+	suite.cache.On("Delete", rxEntry).Return([]string{"Read::/a/b", "Metadata::/a/b/c"})
+	deleted, err := ClearCacheForOps(path, []string{"Read", "Metadata"})
+	if suite.NoError(err) {
+		suite.Equal([]string{"Read::/a/b", "Metadata::/a/b/c"}, deleted)
+	}
+
+	_, err = ClearCacheForOps(path, []string{"Stream"})
+	suite.Error(err)
+}
+
+func (suite *CacheTestSuite) TestClearCacheForOps_ListClearsPages() {
+	path := "/a/b"
+	rxEntry := regexp.MustCompile("^(ListPage|List)::/a/b($|/.*)")
+	rxParent := listKeysRegex("/a")
+
+	suite.cache.On("Get", "List", "/a").Return(mockEntryMap("b", false), nil)
+	suite.cache.On("Get", "List", "").Return(mockEntryMap("a", false), nil)
+	suite.cache.On("Delete", rxEntry).Return([]string{"ListPage::/a/b", "List::/a/b"})
+	suite.cache.On("Delete", rxParent).Return([]string{"List::/a", "ListPage::/a"})
+	deleted, err := ClearCacheForOps(path, []string{"List"})
+	if suite.NoError(err) {
+		suite.Equal([]string{"ListPage::/a/b", "List::/a/b", "List::/a", "ListPage::/a"}, deleted)
+	}
+}
+
+func (suite *CacheTestSuite) TestClearCacheForGlob() {
+	rxEntry := regexp.MustCompile(opQualifier + "/a/[^/]*($|/.*)")
+	rxParent := listKeysRegex("/a")
+
+	// Both matches share a source ancestor, whose list should only be cleared once.
+	suite.cache.On("Get", "List", "/a").Return(mockEntryMap("b", false), nil)
+	suite.cache.On("Get", "List", "").Return(mockEntryMap("a", false), nil)
+	suite.cache.On("Delete", rxEntry).Return([]string{"List::/a/b", "Read::/a/b/c", "Read::/a/d"})
+	suite.cache.On("Delete", rxParent).Return([]string{"List::/a"})
+	deleted, err := ClearCacheForGlob("/a/*", nil)
+	if suite.NoError(err) {
+		suite.Equal([]string{"List::/a/b", "Read::/a/b/c", "Read::/a/d", "List::/a"}, deleted)
+	}
+	suite.cache.AssertNumberOfCalls(suite.T(), "Delete", 2)
+
+	_, err = ClearCacheForGlob("/a/[", nil)
+	suite.Error(err)
+}
+
+func (suite *CacheTestSuite) TestGlobRegex() {
+	suite.Equal("a/[^/]*/b", globRegex("a/*/b"))
+	suite.Equal("a[^/]c", globRegex("a?c"))
+	suite.Equal("[a-c]x", globRegex("[a-c]x"))
+	suite.Equal("[^\\.]", globRegex("[^.]"))
+	suite.Equal("a\\*\\.b", globRegex("a\\*.b"))
+}
+
 type cacheTestsMockEntry struct {
 	EntryBase
 	mock.Mock
@@ -423,6 +479,278 @@ func (suite *CacheTestSuite) TestCachedListSetEntryID() {
 	}
 }
 
+func (suite *CacheTestSuite) TestCachedListEach_EmitsListedChildren() {
+	ctx := context.Background()
+	child1 := newCacheTestsMockEntry("child1")
+	child2 := newCacheTestsMockEntry("child2")
+	entry := newCacheTestsMockEntry("parent")
+	entry.SetTestID("/parent")
+	entry.DisableDefaultCaching()
+	entry.On("List", mock.Anything).Return([]Entry{child1, child2}, nil).Once()
+
+	var ids []string
+	err := cachedListEach(ctx, entry, func(child Entry) error {
+		ids = append(ids, child.eb().id)
+		return nil
+	})
+	if suite.NoError(err) {
+		suite.Equal([]string{"/parent/child1", "/parent/child2"}, ids)
+	}
+}
+
+func (suite *CacheTestSuite) TestCachedListEach_EmitsCachedChildren() {
+	ctx := context.Background()
+	child := newCacheTestsMockEntry("child")
+	entry := newCacheTestsMockEntry("parent")
+	entry.SetTestID("/parent")
+	cachedChildren := newEntryMap()
+	cachedChildren.mp["child"] = child
+	suite.cache.On("Get", "List", "/parent").Return(cachedChildren, nil).Once()
+
+	var children []Entry
+	err := cachedListEach(ctx, entry, func(child Entry) error {
+		children = append(children, child)
+		return nil
+	})
+	if suite.NoError(err) {
+		suite.Equal([]Entry{child}, children)
+	}
+	entry.AssertNotCalled(suite.T(), "List", mock.Anything)
+}
+
+func (suite *CacheTestSuite) TestCachedListEach_StopsEmittingOnError() {
+	ctx := context.Background()
+	entry := newCacheTestsMockEntry("parent")
+	entry.SetTestID("/parent")
+	entry.DisableDefaultCaching()
+	mockChildren := []Entry{newCacheTestsMockEntry("child1"), newCacheTestsMockEntry("child2")}
+	entry.On("List", mock.Anything).Return(mockChildren, nil).Once()
+
+	expectedErr := fmt.Errorf("an error")
+	calls := 0
+	err := cachedListEach(ctx, entry, func(Entry) error {
+		calls++
+		return expectedErr
+	})
+	suite.Equal(expectedErr, err)
+	suite.Equal(1, calls)
+}
+
+func (suite *CacheTestSuite) TestCachedListEach_CachesListedChildren() {
+	ctx := context.Background()
+	child := newCacheTestsMockEntry("child")
+	entry := newCacheTestsMockEntry("parent")
+	entry.SetTestID("/parent")
+	entry.On("List", mock.Anything).Return([]Entry{child}, nil).Once()
+	suite.cache.On("Get", "List", "/parent").Return(nil, nil).Once()
+	expectedChildren := newEntryMap()
+	expectedChildren.mp["child"] = child
+	suite.cache.On("GetOrUpdate", "List", "/parent", mock.Anything, false, mock.MatchedBy(suite.makeGenerateValueMatcher(expectedChildren))).Return(expectedChildren, nil).Once()
+
+	err := cachedListEach(ctx, entry, func(Entry) error { return nil })
+	if suite.NoError(err) {
+		suite.cache.AssertExpectations(suite.T())
+	}
+}
+
+func (suite *CacheTestSuite) TestCachedListEach_CachesPluginErrors() {
+	ctx := context.Background()
+	entry := newCacheTestsMockEntry("parent")
+	entry.SetTestID("/parent")
+	expectedErr := fmt.Errorf("an error")
+	entry.On("List", mock.Anything).Return([]Entry{}, expectedErr).Once()
+	suite.cache.On("Get", "List", "/parent").Return(nil, nil).Once()
+	generatesErr := func(generateValue func() (interface{}, error)) bool {
+		_, err := generateValue()
+		return err == expectedErr
+	}
+	suite.cache.On("GetOrUpdate", "List", "/parent", mock.Anything, false, mock.MatchedBy(generatesErr)).Return(nil, expectedErr).Once()
+
+	err := cachedListEach(ctx, entry, func(Entry) error { return nil })
+	suite.Equal(expectedErr, err)
+	suite.cache.AssertExpectations(suite.T())
+}
+
+func (suite *CacheTestSuite) TestCachedListEach_DoesNotCacheConsumerErrors() {
+	ctx := context.Background()
+	entry := newCacheTestsMockEntry("parent")
+	entry.SetTestID("/parent")
+	entry.On("List", mock.Anything).Return([]Entry{newCacheTestsMockEntry("child")}, nil).Once()
+	suite.cache.On("Get", "List", "/parent").Return(nil, nil).Once()
+
+	expectedErr := fmt.Errorf("client disconnected")
+	err := cachedListEach(ctx, entry, func(Entry) error { return expectedErr })
+	suite.Equal(expectedErr, err)
+	suite.cache.AssertNotCalled(suite.T(), "GetOrUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CacheTestSuite) TestCachedListEach_DoesNotCacheCancellations() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	entry := &cacheTestsMockStreamListerEntry{newCacheTestsMockEntry("parent")}
+	entry.SetTestID("/parent")
+	entry.On("StreamList", mock.Anything).Return([]Entry{}, context.Canceled).Once()
+	suite.cache.On("Get", "List", "/parent").Return(nil, nil).Once()
+
+	err := cachedListEach(ctx, entry, func(Entry) error { return nil })
+	suite.Equal(context.Canceled, err)
+	suite.cache.AssertNotCalled(suite.T(), "GetOrUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CacheTestSuite) TestCachedListEach_ReturnsCachedErrors() {
+	ctx := context.Background()
+	entry := newCacheTestsMockEntry("parent")
+	entry.SetTestID("/parent")
+	expectedErr := fmt.Errorf("an error")
+	suite.cache.On("Get", "List", "/parent").Return(nil, expectedErr).Once()
+
+	err := cachedListEach(ctx, entry, func(Entry) error { return nil })
+	suite.Equal(expectedErr, err)
+	entry.AssertNotCalled(suite.T(), "List", mock.Anything)
+}
+
+type cacheTestsMockPaginatedEntry struct {
+	*cacheTestsMockEntry
+}
+
+func (e *cacheTestsMockPaginatedEntry) ListPage(ctx context.Context, limit int, continuationToken string) ([]Entry, string, error) {
+	args := e.Called(ctx, limit, continuationToken)
+	return args.Get(0).([]Entry), args.String(1), args.Error(2)
+}
+
+func (suite *CacheTestSuite) TestListPage_CachesPagesByLimitAndToken() {
+	ctx := context.Background()
+	entry := &cacheTestsMockPaginatedEntry{newCacheTestsMockEntry("parent")}
+	entry.SetTestID("/parent")
+	a, b, c := newCacheTestsMockEntry("a"), newCacheTestsMockEntry("b"), newCacheTestsMockEntry("c")
+	entry.On("ListPage", mock.Anything, 2, "").Return([]Entry{a, b}, "b", nil).Once()
+	entry.On("ListPage", mock.Anything, 2, "b").Return([]Entry{c}, "", nil).Once()
+	entry.On("ListPage", mock.Anything, 3, "").Return([]Entry{a, b, c}, "", nil).Once()
+	pages := &pageCache{pages: make(map[pageKey]cachedPage)}
+	suite.cache.On("GetOrUpdate", "ListPage", "/parent", mock.Anything, false, mock.Anything).Return(pages, nil)
+
+	for i := 0; i < 2; i++ {
+		page, token, err := listPage(ctx, entry, 2, "")
+		if suite.NoError(err) {
+			suite.Equal([]Entry{a, b}, page)
+			suite.Equal("b", token)
+		}
+		page, token, err = listPage(ctx, entry, 2, "b")
+		if suite.NoError(err) {
+			suite.Equal([]Entry{c}, page)
+			suite.Equal("", token)
+		}
+		page, _, err = listPage(ctx, entry, 3, "")
+		if suite.NoError(err) {
+			suite.Equal([]Entry{a, b, c}, page)
+		}
+	}
+	entry.AssertExpectations(suite.T())
+}
+
+func (suite *CacheTestSuite) TestListPage_DoesNotCacheErrors() {
+	ctx := context.Background()
+	entry := &cacheTestsMockPaginatedEntry{newCacheTestsMockEntry("parent")}
+	entry.SetTestID("/parent")
+	child := newCacheTestsMockEntry("child")
+	expectedErr := fmt.Errorf("an error")
+	entry.On("ListPage", mock.Anything, 2, "").Return([]Entry{}, "", expectedErr).Once()
+	entry.On("ListPage", mock.Anything, 2, "").Return([]Entry{child}, "", nil).Once()
+	pages := &pageCache{pages: make(map[pageKey]cachedPage)}
+	suite.cache.On("GetOrUpdate", "ListPage", "/parent", mock.Anything, false, mock.Anything).Return(pages, nil)
+
+	_, _, err := listPage(ctx, entry, 2, "")
+	suite.Equal(expectedErr, err)
+	page, _, err := listPage(ctx, entry, 2, "")
+	if suite.NoError(err) {
+		suite.Equal([]Entry{child}, page)
+	}
+	entry.AssertExpectations(suite.T())
+}
+
+type cacheTestsMockStreamListerEntry struct {
+	*cacheTestsMockEntry
+}
+
+func (m *cacheTestsMockStreamListerEntry) StreamList(ctx context.Context, emit func(Entry) error) error {
+	args := m.Called(ctx)
+	for _, child := range args.Get(0).([]Entry) {
+		if err := emit(child); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (suite *CacheTestSuite) TestCachedListEach_StreamLister_EmitsStreamedChildren() {
+	ctx := context.Background()
+	child1 := newCacheTestsMockEntry("child1")
+	child2 := newCacheTestsMockEntry("child2")
+	entry := &cacheTestsMockStreamListerEntry{newCacheTestsMockEntry("parent")}
+	entry.SetTestID("/parent")
+	entry.DisableDefaultCaching()
+	entry.On("StreamList", mock.Anything).Return([]Entry{child1, child2}, nil).Once()
+
+	var ids []string
+	err := cachedListEach(ctx, entry, func(child Entry) error {
+		ids = append(ids, child.eb().id)
+		return nil
+	})
+	if suite.NoError(err) {
+		suite.Equal([]string{"/parent/child1", "/parent/child2"}, ids)
+	}
+	entry.AssertNotCalled(suite.T(), "List", mock.Anything)
+}
+
+func (suite *CacheTestSuite) TestCachedListEach_StreamLister_DoesNotKeepStreamedChildren() {
+	ctx := context.Background()
+	entry := &cacheTestsMockStreamListerEntry{newCacheTestsMockEntry("parent")}
+	entry.SetTestID("/parent")
+	entry.On("StreamList", mock.Anything).Return([]Entry{newCacheTestsMockEntry("child")}, nil).Once()
+	suite.cache.On("Get", "List", "/parent").Return(nil, nil).Once()
+
+	calls := 0
+	err := cachedListEach(ctx, entry, func(Entry) error {
+		calls++
+		return nil
+	})
+	if suite.NoError(err) {
+		suite.Equal(1, calls)
+	}
+	suite.cache.AssertNotCalled(suite.T(), "GetOrUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CacheTestSuite) TestCachedListEach_StreamLister_ReturnsError() {
+	ctx := context.Background()
+	child := newCacheTestsMockEntry("child")
+	entry := &cacheTestsMockStreamListerEntry{newCacheTestsMockEntry("parent")}
+	entry.SetTestID("/parent")
+	entry.DisableDefaultCaching()
+	expectedErr := fmt.Errorf("an error")
+	entry.On("StreamList", mock.Anything).Return([]Entry{child}, expectedErr).Once()
+
+	var children []Entry
+	err := cachedListEach(ctx, entry, func(child Entry) error {
+		children = append(children, child)
+		return nil
+	})
+	suite.Equal(expectedErr, err)
+	// The children that were streamed before the error are still emitted.
+	suite.Equal([]Entry{child}, children)
+}
+
+func (suite *CacheTestSuite) TestCachedListEach_StreamLister_ReturnsDuplicateCNameErr() {
+	ctx := context.Background()
+	entry := &cacheTestsMockStreamListerEntry{newCacheTestsMockEntry("parent")}
+	entry.SetTestID("/parent")
+	entry.DisableDefaultCaching()
+	mockChildren := []Entry{newCacheTestsMockEntry("child"), newCacheTestsMockEntry("child")}
+	entry.On("StreamList", mock.Anything).Return(mockChildren, nil).Once()
+
+	err := cachedListEach(ctx, entry, func(Entry) error { return nil })
+	suite.IsType(DuplicateCNameErr{}, err)
+}
+
 func (suite *CacheTestSuite) TestCachedRead_DefaultOp() {
 	// This also tests a successful read of a ReadableCorePluginEntry
 	mockRawContent := []byte("some raw content")
@@ -565,6 +893,20 @@ func (suite *CacheTestSuite) TestCachedMetadata() {
 	})
 }
 
+func (suite *CacheTestSuite) TestCacheStateOf() {
+	entry := newCacheTestsMockEntry("mock")
+	entry.SetTestID("id")
+	entry.DisableCachingFor(ReadOp)
+	suite.cache.On("Get", "List", "id").Return([]string{"child"}, nil).Once()
+	suite.cache.On("Get", "Metadata", "id").Return(nil, nil).Once()
+
+	state := CacheStateOf(entry)
+	suite.Equal(OpCacheState{TTL: 15 * time.Second, Cached: true}, state["List"])
+	suite.Equal(OpCacheState{TTL: -1, Cached: false}, state["Read"])
+	suite.Equal(OpCacheState{TTL: 15 * time.Second, Cached: false}, state["Metadata"])
+	suite.cache.AssertNotCalled(suite.T(), "Get", "Read", "id")
+}
+
 func (suite *CacheTestSuite) TestSplitID() {
 	parentID, cname := splitID("/a/b")
 	suite.Equal("/a", parentID)
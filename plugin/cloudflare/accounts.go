@@ -0,0 +1,92 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type accountsDir struct {
+	plugin.EntryBase
+	client *client
+}
+
+func newAccountsDir(c *client) *accountsDir {
+	a := &accountsDir{
+		EntryBase: plugin.NewEntry("accounts"),
+	}
+	a.client = c
+	return a
+}
+
+func (a *accountsDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(a, "accounts").IsSingleton()
+}
+
+func (a *accountsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&account{}).Schema(),
+	}
+}
+
+type accountInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// List lists the accounts that the token can access
+func (a *accountsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	err := a.client.getAll(ctx, "/accounts", func(result json.RawMessage) error {
+		var accounts []accountInfo
+		if err := json.Unmarshal(result, &accounts); err != nil {
+			return err
+		}
+		for _, info := range accounts {
+			entries = append(entries, newAccount(a.client, info))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+type account struct {
+	plugin.EntryBase
+	client *client
+	id     string
+}
+
+func newAccount(c *client, info accountInfo) *account {
+	a := &account{
+		EntryBase: plugin.NewEntry(info.Name),
+	}
+	a.client = c
+	a.id = info.ID
+	a.SetPartialMetadata(info)
+	a.DisableDefaultCaching()
+	return a
+}
+
+func (a *account) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(a, "account").
+		SetPartialMetadataSchema(accountInfo{})
+}
+
+func (a *account) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&workersDir{}).Schema(),
+		(&kvDir{}).Schema(),
+	}
+}
+
+func (a *account) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{
+		newWorkersDir(a.client, a.id),
+		newKVDir(a.client, a.id),
+	}, nil
+}
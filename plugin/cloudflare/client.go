@@ -0,0 +1,124 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const apiURL = "https://api.cloudflare.com/client/v4"
+
+// client is a minimal Cloudflare v4 API client
+type client struct {
+	baseURL string
+	token   string
+}
+
+func newClient(token string) *client {
+	return &client{baseURL: apiURL, token: token}
+}
+
+// path joins the escaped segments into an API path
+func path(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	return "/" + strings.Join(escaped, "/")
+}
+
+// response is the envelope of the API's JSON responses
+type response struct {
+	Success bool            `json:"success"`
+	Result  json.RawMessage `json:"result"`
+	Errors  []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+	ResultInfo struct {
+		Page       int    `json:"page"`
+		TotalPages int    `json:"total_pages"`
+		Cursor     string `json:"cursor"`
+	} `json:"result_info"`
+}
+
+// raw sends a request and returns the response's body
+func (c *client) raw(ctx context.Context, method string, p string, query url.Values, body io.Reader, contentType string) ([]byte, error) {
+	u := c.baseURL + p
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		var r response
+		if json.Unmarshal(respBody, &r) == nil && len(r.Errors) > 0 {
+			return nil, fmt.Errorf("%v %v: %v (code %v)", method, p, r.Errors[0].Message, r.Errors[0].Code)
+		}
+		return nil, fmt.Errorf("%v %v: %v", method, p, resp.Status)
+	}
+	return respBody, nil
+}
+
+// do sends a request with body (marshalled as JSON) and returns the
+// response's envelope. Body can be nil.
+func (c *client) do(ctx context.Context, method string, p string, query url.Values, body interface{}) (*response, error) {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return nil, err
+		}
+	}
+	respBody, err := c.raw(ctx, method, p, query, &reqBody, "application/json")
+	if err != nil {
+		return nil, err
+	}
+	var r response
+	if err := json.Unmarshal(respBody, &r); err != nil {
+		return nil, err
+	}
+	if !r.Success && len(r.Errors) > 0 {
+		return nil, fmt.Errorf("%v %v: %v (code %v)", method, p, r.Errors[0].Message, r.Errors[0].Code)
+	}
+	return &r, nil
+}
+
+// getAll gets every page of a paginated list and unmarshals each page's
+// results with unmarshal.
+func (c *client) getAll(ctx context.Context, p string, unmarshal func(json.RawMessage) error) error {
+	for page := 1; ; page++ {
+		query := url.Values{"page": {fmt.Sprint(page)}, "per_page": {"50"}}
+		r, err := c.do(ctx, http.MethodGet, p, query, nil)
+		if err != nil {
+			return err
+		}
+		if err := unmarshal(r.Result); err != nil {
+			return err
+		}
+		if page >= r.ResultInfo.TotalPages {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client for a fake API that's served by handler
+func newTestClient(handler http.HandlerFunc) (*client, func()) {
+	srv := httptest.NewServer(handler)
+	c := newClient("secret")
+	c.baseURL = srv.URL
+	return c, srv.Close
+}
+
+func TestPath(t *testing.T) {
+	assert.Equal(t, "/zones", path("zones"))
+	assert.Equal(t, "/zones/abc/dns_records", path("zones", "abc", "dns_records"))
+	assert.Equal(t, "/values/a%2Fb%20c", path("values", "a/b c"))
+}
+
+func TestClientRaw(t *testing.T) {
+	c, cleanup := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/ok":
+			assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+			_, _ = w.Write([]byte("raw body"))
+		case "/api-error":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"success": false, "errors": [{"code": 10000, "message": "Authentication error"}]}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	defer cleanup()
+
+	body, err := c.raw(context.Background(), http.MethodGet, "/ok", nil, nil, "text/plain")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "raw body", string(body))
+	}
+
+	_, err = c.raw(context.Background(), http.MethodGet, "/api-error", nil, nil, "")
+	assert.EqualError(t, err, "GET /api-error: Authentication error (code 10000)")
+
+	_, err = c.raw(context.Background(), http.MethodDelete, "/missing", nil, nil, "")
+	assert.EqualError(t, err, "DELETE /missing: 404 Not Found")
+}
+
+func TestClientDo(t *testing.T) {
+	c, cleanup := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err == nil && body["fail"] == true {
+			_, _ = w.Write([]byte(`{"success": false, "errors": [{"code": 1004, "message": "DNS Validation Error"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": {"id": "abc"}}`))
+	})
+	defer cleanup()
+
+	r, err := c.do(context.Background(), http.MethodPut, "/zones/abc", nil, map[string]interface{}{"name": "example.com"})
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"id": "abc"}`, string(r.Result))
+	}
+
+	_, err = c.do(context.Background(), http.MethodPut, "/zones/abc", nil, map[string]interface{}{"fail": true})
+	assert.EqualError(t, err, "PUT /zones/abc: DNS Validation Error (code 1004)")
+}
+
+func TestClientGetAll(t *testing.T) {
+	var requested []string
+	c, cleanup := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "50", r.URL.Query().Get("per_page"))
+		page := r.URL.Query().Get("page")
+		requested = append(requested, page)
+		_, _ = w.Write([]byte(`{"success": true, "result": ["` + page + `"], "result_info": {"page": ` + page + `, "total_pages": 3}}`))
+	})
+	defer cleanup()
+
+	var results []string
+	err := c.getAll(context.Background(), "/zones", func(result json.RawMessage) error {
+		var page []string
+		if err := json.Unmarshal(result, &page); err != nil {
+			return err
+		}
+		results = append(results, page...)
+		return nil
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"1", "2", "3"}, requested)
+		assert.Equal(t, []string{"1", "2", "3"}, results)
+	}
+}
@@ -0,0 +1,268 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type kvDir struct {
+	plugin.EntryBase
+	client    *client
+	accountID string
+}
+
+func newKVDir(c *client, accountID string) *kvDir {
+	k := &kvDir{
+		EntryBase: plugin.NewEntry("kv"),
+	}
+	k.client = c
+	k.accountID = accountID
+	return k
+}
+
+func (k *kvDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(k, "kv").IsSingleton()
+}
+
+func (k *kvDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&kvNamespace{}).Schema(),
+	}
+}
+
+type kvNamespaceInfo struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// List lists the account's KV namespaces
+func (k *kvDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	err := k.client.getAll(ctx, path("accounts", k.accountID, "storage", "kv", "namespaces"), func(result json.RawMessage) error {
+		var namespaces []kvNamespaceInfo
+		if err := json.Unmarshal(result, &namespaces); err != nil {
+			return err
+		}
+		for _, info := range namespaces {
+			entries = append(entries, newKVNamespace(k.client, k.accountID, info))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// kvKeyInfo is the key's partial metadata
+type kvKeyInfo struct {
+	Name       string      `json:"name"`
+	Expiration int64       `json:"expiration,omitempty"`
+	Metadata   interface{} `json:"metadata,omitempty"`
+}
+
+// kvNode is a level in a namespace's key tree
+type kvNode struct {
+	children map[string]*kvNode
+	key      *kvKeyInfo
+}
+
+func (n *kvNode) child(name string) *kvNode {
+	if n.children == nil {
+		n.children = make(map[string]*kvNode)
+	}
+	child, ok := n.children[name]
+	if !ok {
+		child = &kvNode{}
+		n.children[name] = child
+	}
+	return child
+}
+
+type kvNamespace struct {
+	plugin.EntryBase
+	client     *client
+	valuesPath string
+	keysPath   string
+}
+
+func newKVNamespace(c *client, accountID string, info kvNamespaceInfo) *kvNamespace {
+	k := &kvNamespace{
+		EntryBase: plugin.NewEntry(info.Title),
+	}
+	k.client = c
+	k.keysPath = path("accounts", accountID, "storage", "kv", "namespaces", info.ID, "keys")
+	k.valuesPath = path("accounts", accountID, "storage", "kv", "namespaces", info.ID, "values")
+	k.SetPartialMetadata(info)
+	k.SetTTLOf(plugin.ListOp, 30*time.Second)
+	return k
+}
+
+func (k *kvNamespace) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(k, "namespace").
+		SetDescription(kvNamespaceDescription).
+		SetPartialMetadataSchema(kvNamespaceInfo{})
+}
+
+func (k *kvNamespace) ChildSchemas() []*plugin.EntrySchema {
+	return kvChildSchemas()
+}
+
+// List lists all of the namespace's keys and returns the top level of the
+// key tree. The KV API doesn't support delimiters, so the tree's built from
+// the full list.
+func (k *kvNamespace) List(ctx context.Context) ([]plugin.Entry, error) {
+	root := &kvNode{}
+	var count int
+	cursor := ""
+	for {
+		query := url.Values{"limit": {"1000"}}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+		r, err := k.client.do(ctx, http.MethodGet, k.keysPath, query, nil)
+		if err != nil {
+			return nil, err
+		}
+		var keys []kvKeyInfo
+		if err := json.Unmarshal(r.Result, &keys); err != nil {
+			return nil, err
+		}
+		for i := range keys {
+			node := root
+			for _, level := range strings.Split(keys[i].Name, "/") {
+				node = node.child(level)
+			}
+			node.key = &keys[i]
+		}
+		count += len(keys)
+		if cursor = r.ResultInfo.Cursor; cursor == "" {
+			break
+		}
+	}
+	activity.Record(ctx, "Listed %v keys in KV namespace %v", count, k.Name())
+	return kvEntries(k, root), nil
+}
+
+// kvEntries returns the entries for node's children. If a key is also a
+// prefix of other keys, then the prefix's directory name ends in '#'.
+func kvEntries(ns *kvNamespace, node *kvNode) []plugin.Entry {
+	names := make([]string, 0, len(node.children))
+	for name := range node.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var entries []plugin.Entry
+	for _, name := range names {
+		child := node.children[name]
+		if child.key != nil {
+			entries = append(entries, newKVKey(ns, name, *child.key))
+		}
+		if len(child.children) > 0 {
+			dirName := name
+			if child.key != nil {
+				dirName += "#"
+			}
+			entries = append(entries, newKVPrefix(ns, dirName, child))
+		}
+	}
+	return entries
+}
+
+func kvChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&kvPrefix{}).Schema(),
+		(&kvKey{}).Schema(),
+	}
+}
+
+type kvPrefix struct {
+	plugin.EntryBase
+	ns   *kvNamespace
+	node *kvNode
+}
+
+func newKVPrefix(ns *kvNamespace, name string, node *kvNode) *kvPrefix {
+	p := &kvPrefix{
+		EntryBase: plugin.NewEntry(name),
+	}
+	p.ns = ns
+	p.node = node
+	return p
+}
+
+func (p *kvPrefix) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(p, "prefix")
+}
+
+func (p *kvPrefix) ChildSchemas() []*plugin.EntrySchema {
+	return kvChildSchemas()
+}
+
+// List lists the keys that were found when the namespace was listed
+func (p *kvPrefix) List(ctx context.Context) ([]plugin.Entry, error) {
+	return kvEntries(p.ns, p.node), nil
+}
+
+type kvKey struct {
+	plugin.EntryBase
+	ns  *kvNamespace
+	key string
+}
+
+func newKVKey(ns *kvNamespace, name string, info kvKeyInfo) *kvKey {
+	k := &kvKey{
+		EntryBase: plugin.NewEntry(name),
+	}
+	k.ns = ns
+	k.key = info.Name
+	k.SetPartialMetadata(info)
+	k.DisableCachingFor(plugin.ReadOp)
+	return k
+}
+
+func (k *kvKey) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(k, "key").
+		SetPartialMetadataSchema(kvKeyInfo{})
+}
+
+func (k *kvKey) valuePath() string {
+	return k.ns.valuesPath + "/" + url.PathEscape(k.key)
+}
+
+func (k *kvKey) Read(ctx context.Context) ([]byte, error) {
+	return k.ns.client.raw(ctx, http.MethodGet, k.valuePath(), nil, nil, "")
+}
+
+func (k *kvKey) Write(ctx context.Context, b []byte) error {
+	activity.Record(ctx, "Writing %v bytes to KV key %v", len(b), k.key)
+	_, err := k.ns.client.raw(ctx, http.MethodPut, k.valuePath(), nil, bytes.NewReader(b), "application/octet-stream")
+	return err
+}
+
+func (k *kvKey) Delete(ctx context.Context) (bool, error) {
+	activity.Record(ctx, "Deleting KV key %v", k.key)
+	if _, err := k.ns.client.raw(ctx, http.MethodDelete, k.valuePath(), nil, nil, ""); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+const kvNamespaceDescription = `
+This is a Workers KV namespace. Its keys are grouped into directories by
+splitting them on '/'. If a key is also a prefix of other keys, then the
+directory for that prefix ends in '#'. Keys can be read, written and
+deleted.
+`
@@ -0,0 +1,86 @@
+package cloudflare
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKVNamespaceList(t *testing.T) {
+	c, cleanup := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/a1/storage/kv/namespaces/ns1/keys", r.URL.Path)
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{
+				"success": true,
+				"result": [{"name": "config"}, {"name": "config/flags"}],
+				"result_info": {"cursor": "next"}
+			}`))
+			return
+		}
+		assert.Equal(t, "next", r.URL.Query().Get("cursor"))
+		_, _ = w.Write([]byte(`{
+			"success": true,
+			"result": [{"name": "users/1"}, {"name": "users/2"}],
+			"result_info": {"cursor": ""}
+		}`))
+	})
+	defer cleanup()
+
+	ns := newKVNamespace(c, "a1", kvNamespaceInfo{ID: "ns1", Title: "app"})
+	entries, err := ns.List(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, plugin.Name(entry))
+	}
+	// config is both a key and a prefix
+	assert.Equal(t, []string{"config", "config#", "users"}, names)
+	assert.Equal(t, "config", entries[0].(*kvKey).key)
+
+	entries, err = entries[2].(*kvPrefix).List(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, entries, 2) {
+		assert.Equal(t, "1", plugin.Name(entries[0]))
+		assert.Equal(t, "users/1", entries[0].(*kvKey).key)
+	}
+}
+
+func TestKVKey(t *testing.T) {
+	var written []byte
+	deleted := false
+	c, cleanup := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/a1/storage/kv/namespaces/ns1/values/users%2F1", r.URL.EscapedPath())
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte("alice"))
+			return
+		case http.MethodPut:
+			assert.Equal(t, "application/octet-stream", r.Header.Get("Content-Type"))
+			written, _ = ioutil.ReadAll(r.Body)
+		case http.MethodDelete:
+			deleted = true
+		}
+		_, _ = w.Write([]byte(`{"success": true}`))
+	})
+	defer cleanup()
+
+	ns := newKVNamespace(c, "a1", kvNamespaceInfo{ID: "ns1", Title: "app"})
+	k := newKVKey(ns, "1", kvKeyInfo{Name: "users/1"})
+	content, err := k.Read(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "alice", string(content))
+	}
+
+	assert.NoError(t, k.Write(context.Background(), []byte("bob")))
+	assert.Equal(t, "bob", string(written))
+
+	ok, err := k.Delete(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, deleted)
+}
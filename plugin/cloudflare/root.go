@@ -0,0 +1,82 @@
+// Package cloudflare presents a filesystem hierarchy for Cloudflare.
+//
+// It uses Cloudflare's v4 API with an API token to expose zones and their
+// DNS records, Workers and Workers KV namespaces.
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Root of the cloudflare plugin
+type Root struct {
+	plugin.EntryBase
+	client *client
+}
+
+// Init for root
+func (r *Root) Init(cfg map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("cloudflare")
+	r.DisableDefaultCaching()
+
+	token := os.Getenv("CLOUDFLARE_API_TOKEN")
+	if tokenI, ok := cfg["token"]; ok {
+		if token, ok = tokenI.(string); !ok {
+			return fmt.Errorf("cloudflare.token config must be a string, not %v", tokenI)
+		}
+	}
+	if token != "" {
+		r.client = newClient(token)
+	}
+	return nil
+}
+
+// Schema returns the root's schema
+func (r *Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "cloudflare").
+		SetDescription(rootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&zonesDir{}).Schema(),
+		(&accountsDir{}).Schema(),
+	}
+}
+
+// List lists the zones and accounts directories if a token's configured.
+func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	if r.client == nil {
+		return []plugin.Entry{}, nil
+	}
+	return []plugin.Entry{
+		newZonesDir(r.client),
+		newAccountsDir(r.client),
+	}, nil
+}
+
+const rootDescription = `
+This is the cloudflare plugin root. It uses the API token configured in
+wash.yaml or the CLOUDFLARE_API_TOKEN environment variable, e.g.
+
+  cloudflare:
+    token: <API token>
+
+The token's permissions determine what's listed. The 'zones' directory
+lists each zone's DNS records, which can be read, written (to update their
+content) and deleted. The 'accounts' directory lists each account's Workers,
+whose scripts can be read and whose logs can be tailed, and its Workers KV
+namespaces, whose keys are grouped into directories by '/'.
+
+Record metadata includes the record's type, content, TTL and whether it's
+proxied, so you can audit your DNS with things like
+
+  find cloudflare/zones -meta .type CNAME -meta .proxied false
+`
@@ -0,0 +1,46 @@
+package cloudflare
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInit(t *testing.T) {
+	os.Unsetenv("CLOUDFLARE_API_TOKEN")
+	r := &Root{}
+	if assert.NoError(t, r.Init(map[string]interface{}{})) {
+		assert.Nil(t, r.client)
+		entries, err := r.List(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+	}
+
+	if assert.NoError(t, r.Init(map[string]interface{}{"token": "secret"})) {
+		assert.Equal(t, "secret", r.client.token)
+		entries, err := r.List(context.Background())
+		if assert.NoError(t, err) && assert.Len(t, entries, 2) {
+			assert.Equal(t, "zones", plugin.Name(entries[0]))
+			assert.Equal(t, "accounts", plugin.Name(entries[1]))
+		}
+	}
+
+	err := r.Init(map[string]interface{}{"token": 5})
+	assert.EqualError(t, err, "cloudflare.token config must be a string, not 5")
+}
+
+func TestInit_TokenFromEnv(t *testing.T) {
+	os.Setenv("CLOUDFLARE_API_TOKEN", "from-env")
+	defer os.Unsetenv("CLOUDFLARE_API_TOKEN")
+
+	r := &Root{}
+	if assert.NoError(t, r.Init(map[string]interface{}{})) {
+		assert.Equal(t, "from-env", r.client.token)
+	}
+	if assert.NoError(t, r.Init(map[string]interface{}{"token": "from-config"})) {
+		assert.Equal(t, "from-config", r.client.token)
+	}
+}
@@ -0,0 +1,160 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type workersDir struct {
+	plugin.EntryBase
+	client    *client
+	accountID string
+}
+
+func newWorkersDir(c *client, accountID string) *workersDir {
+	w := &workersDir{
+		EntryBase: plugin.NewEntry("workers"),
+	}
+	w.client = c
+	w.accountID = accountID
+	return w
+}
+
+func (w *workersDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(w, "workers").IsSingleton()
+}
+
+func (w *workersDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&worker{}).Schema(),
+	}
+}
+
+// workerInfo is the worker's partial metadata
+type workerInfo struct {
+	ID         string    `json:"id"`
+	ETag       string    `json:"etag"`
+	CreatedOn  time.Time `json:"created_on"`
+	ModifiedOn time.Time `json:"modified_on"`
+}
+
+// List lists the account's Workers
+func (w *workersDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	r, err := w.client.do(ctx, http.MethodGet, path("accounts", w.accountID, "workers", "scripts"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var scripts []workerInfo
+	if err := json.Unmarshal(r.Result, &scripts); err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(scripts))
+	for i, info := range scripts {
+		entries[i] = newWorker(w.client, w.accountID, info)
+	}
+	return entries, nil
+}
+
+type worker struct {
+	plugin.EntryBase
+	client    *client
+	accountID string
+}
+
+func newWorker(c *client, accountID string, info workerInfo) *worker {
+	w := &worker{
+		EntryBase: plugin.NewEntry(info.ID),
+	}
+	w.client = c
+	w.accountID = accountID
+	w.
+		SetPartialMetadata(info).
+		Attributes().
+		SetCrtime(info.CreatedOn).
+		SetMtime(info.ModifiedOn).
+		SetCtime(info.ModifiedOn).
+		SetAtime(info.ModifiedOn)
+	return w
+}
+
+func (w *worker) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(w, "worker").
+		SetDescription(workerDescription).
+		SetPartialMetadataSchema(workerInfo{})
+}
+
+func (w *worker) scriptPath(segments ...string) string {
+	return path(append([]string{"accounts", w.accountID, "workers", "scripts", w.Name()}, segments...)...)
+}
+
+// Read returns the worker's script
+func (w *worker) Read(ctx context.Context) ([]byte, error) {
+	return w.client.raw(ctx, http.MethodGet, w.scriptPath(), nil, nil, "")
+}
+
+// Stream tails the worker's logs, like 'wrangler tail'. Each event is
+// written as a line of JSON.
+func (w *worker) Stream(ctx context.Context) (io.ReadCloser, error) {
+	r, err := w.client.do(ctx, http.MethodPost, w.scriptPath("tails"), nil, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	var tail struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(r.Result, &tail); err != nil {
+		return nil, err
+	}
+	dialer := websocket.Dialer{Subprotocols: []string{"trace-v1"}}
+	conn, _, err := dialer.DialContext(ctx, tail.URL, nil)
+	if err != nil {
+		w.deleteTail(ctx, tail.ID)
+		return nil, err
+	}
+	activity.Record(ctx, "Tailing worker %v", w.Name())
+
+	pr, pw := io.Pipe()
+	go func() {
+		<-ctx.Done()
+		activity.Record(ctx, "Closing tail of worker %v: %v", w.Name(), conn.Close())
+	}()
+	go func() {
+		defer func() {
+			// Use a new context since ctx is likely cancelled.
+			w.deleteTail(context.Background(), tail.ID)
+			activity.Record(ctx, "Closing write pipe: %v", pw.Close())
+		}()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				activity.Record(ctx, "Stopped tailing worker %v: %v", w.Name(), err)
+				return
+			}
+			if _, err := pw.Write(append(msg, '\n')); err != nil {
+				// The reader was closed.
+				return
+			}
+		}
+	}()
+	return pr, nil
+}
+
+func (w *worker) deleteTail(ctx context.Context, id string) {
+	_, err := w.client.do(ctx, http.MethodDelete, w.scriptPath("tails", id), nil, nil)
+	activity.Record(ctx, "Deleted tail %v of worker %v: %v", id, w.Name(), err)
+}
+
+const workerDescription = `
+This is a Cloudflare Worker. Reading it returns its script, and tailing it
+streams its logs (including console.log output and exceptions) as lines of
+JSON.
+`
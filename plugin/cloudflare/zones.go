@@ -0,0 +1,248 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type zonesDir struct {
+	plugin.EntryBase
+	client *client
+}
+
+func newZonesDir(c *client) *zonesDir {
+	z := &zonesDir{
+		EntryBase: plugin.NewEntry("zones"),
+	}
+	z.client = c
+	return z
+}
+
+func (z *zonesDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(z, "zones").IsSingleton()
+}
+
+func (z *zonesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&zone{}).Schema(),
+	}
+}
+
+// zoneInfo is the zone's partial metadata
+type zoneInfo struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Paused      bool      `json:"paused"`
+	Type        string    `json:"type"`
+	NameServers []string  `json:"name_servers"`
+	CreatedOn   time.Time `json:"created_on"`
+	ModifiedOn  time.Time `json:"modified_on"`
+	Account     struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"account"`
+	Plan struct {
+		Name string `json:"name"`
+	} `json:"plan"`
+}
+
+// List lists the zones that the token can access
+func (z *zonesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	err := z.client.getAll(ctx, "/zones", func(result json.RawMessage) error {
+		var zones []zoneInfo
+		if err := json.Unmarshal(result, &zones); err != nil {
+			return err
+		}
+		for _, info := range zones {
+			entries = append(entries, newZone(z.client, info))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+type zone struct {
+	plugin.EntryBase
+	client *client
+	id     string
+}
+
+func newZone(c *client, info zoneInfo) *zone {
+	z := &zone{
+		EntryBase: plugin.NewEntry(info.Name),
+	}
+	z.client = c
+	z.id = info.ID
+	z.
+		SetPartialMetadata(info).
+		Attributes().
+		SetCrtime(info.CreatedOn).
+		SetMtime(info.ModifiedOn).
+		SetCtime(info.ModifiedOn).
+		SetAtime(info.ModifiedOn)
+	return z
+}
+
+func (z *zone) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(z, "zone").
+		SetDescription(zoneDescription).
+		SetPartialMetadataSchema(zoneInfo{})
+}
+
+func (z *zone) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&dnsRecord{}).Schema(),
+	}
+}
+
+// List lists the zone's DNS records
+func (z *zone) List(ctx context.Context) ([]plugin.Entry, error) {
+	var records []dnsRecordInfo
+	err := z.client.getAll(ctx, path("zones", z.id, "dns_records"), func(result json.RawMessage) error {
+		var page []dnsRecordInfo
+		if err := json.Unmarshal(result, &page); err != nil {
+			return err
+		}
+		records = append(records, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Listing %v DNS records in %v", len(records), z.Name())
+
+	// Records are named by their name and type. Only records that share
+	// both are disambiguated by their ID.
+	counts := make(map[string]int)
+	for _, r := range records {
+		counts[r.Name+"_"+r.Type]++
+	}
+	entries := make([]plugin.Entry, len(records))
+	for i, r := range records {
+		name := r.Name + "_" + r.Type
+		if counts[name] > 1 && len(r.ID) >= 8 {
+			name += "_" + r.ID[:8]
+		}
+		entries[i] = newDNSRecord(z.client, name, r)
+	}
+	return entries, nil
+}
+
+// dnsRecordInfo is the record's metadata
+type dnsRecordInfo struct {
+	ID         string    `json:"id"`
+	ZoneID     string    `json:"zone_id"`
+	Type       string    `json:"type"`
+	Name       string    `json:"name"`
+	Content    string    `json:"content"`
+	TTL        int       `json:"ttl"`
+	Priority   *int      `json:"priority,omitempty"`
+	Proxiable  bool      `json:"proxiable"`
+	Proxied    bool      `json:"proxied"`
+	Locked     bool      `json:"locked"`
+	CreatedOn  time.Time `json:"created_on"`
+	ModifiedOn time.Time `json:"modified_on"`
+}
+
+type dnsRecord struct {
+	plugin.EntryBase
+	client *client
+	info   dnsRecordInfo
+}
+
+func newDNSRecord(c *client, name string, info dnsRecordInfo) *dnsRecord {
+	r := &dnsRecord{
+		EntryBase: plugin.NewEntry(name),
+	}
+	r.client = c
+	r.info = info
+	r.
+		SetPartialMetadata(info).
+		Attributes().
+		SetCrtime(info.CreatedOn).
+		SetMtime(info.ModifiedOn).
+		SetCtime(info.ModifiedOn).
+		SetAtime(info.ModifiedOn).
+		SetSize(uint64(len(info.Content) + 1))
+	return r
+}
+
+func (r *dnsRecord) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "record").
+		SetDescription(dnsRecordDescription).
+		SetPartialMetadataSchema(dnsRecordInfo{})
+}
+
+func (r *dnsRecord) recordPath() string {
+	return path("zones", r.info.ZoneID, "dns_records", r.info.ID)
+}
+
+// Read returns the record's content
+func (r *dnsRecord) Read(ctx context.Context) ([]byte, error) {
+	return []byte(r.info.Content + "\n"), nil
+}
+
+// Write updates the record's content. The record's other fields are kept.
+func (r *dnsRecord) Write(ctx context.Context, b []byte) error {
+	content := string(bytesTrimNewline(b))
+	if content == "" {
+		return fmt.Errorf("a DNS record's content cannot be empty")
+	}
+	body := map[string]interface{}{
+		"type":    r.info.Type,
+		"name":    r.info.Name,
+		"content": content,
+		"ttl":     r.info.TTL,
+		"proxied": r.info.Proxied,
+	}
+	if r.info.Priority != nil {
+		body["priority"] = *r.info.Priority
+	}
+	activity.Record(ctx, "Updating the content of %v record %v to %v", r.info.Type, r.info.Name, content)
+	_, err := r.client.do(ctx, http.MethodPut, r.recordPath(), nil, body)
+	return err
+}
+
+func (r *dnsRecord) Delete(ctx context.Context) (bool, error) {
+	activity.Record(ctx, "Deleting %v record %v", r.info.Type, r.info.Name)
+	if _, err := r.client.do(ctx, http.MethodDelete, r.recordPath(), nil, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// bytesTrimNewline trims a trailing newline, which is usually added by
+// commands like echo
+func bytesTrimNewline(b []byte) []byte {
+	if n := len(b); n > 0 && b[n-1] == '\n' {
+		b = b[:n-1]
+		if n := len(b); n > 0 && b[n-1] == '\r' {
+			b = b[:n-1]
+		}
+	}
+	return b
+}
+
+const zoneDescription = `
+This is a Cloudflare zone. It lists the zone's DNS records.
+`
+
+const dnsRecordDescription = `
+This is a DNS record. It's named by its name and type; records that share
+both are suffixed with the first 8 characters of their ID. Reading it
+returns its content, writing to it updates its content, and deleting it
+deletes the record.
+`
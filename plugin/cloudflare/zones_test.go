@@ -0,0 +1,100 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZoneList(t *testing.T) {
+	c, cleanup := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/z1/dns_records", r.URL.Path)
+		_, _ = w.Write([]byte(`{
+			"success": true,
+			"result": [
+				{"id": "1111111111", "type": "A", "name": "example.com", "content": "203.0.113.1"},
+				{"id": "2222222222", "type": "A", "name": "example.com", "content": "203.0.113.2"},
+				{"id": "3333333333", "type": "CNAME", "name": "www.example.com", "content": "example.com"}
+			],
+			"result_info": {"page": 1, "total_pages": 1}
+		}`))
+	})
+	defer cleanup()
+
+	entries, err := newZone(c, zoneInfo{ID: "z1", Name: "example.com"}).List(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, plugin.Name(entry))
+	}
+	assert.Equal(t, []string{"example.com_A_11111111", "example.com_A_22222222", "www.example.com_CNAME"}, names)
+	assert.Equal(t, uint64(len("example.com\n")), entries[2].(*dnsRecord).Attributes().Size())
+}
+
+func TestDNSRecord(t *testing.T) {
+	var body map[string]interface{}
+	deleted := false
+	c, cleanup := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/z1/dns_records/r1", r.URL.Path)
+		switch r.Method {
+		case http.MethodPut:
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		case http.MethodDelete:
+			deleted = true
+		}
+		_, _ = w.Write([]byte(`{"success": true, "result": {"id": "r1"}}`))
+	})
+	defer cleanup()
+
+	priority := 10
+	r := newDNSRecord(c, "example.com_MX", dnsRecordInfo{
+		ID:       "r1",
+		ZoneID:   "z1",
+		Type:     "MX",
+		Name:     "example.com",
+		Content:  "mail.example.com",
+		TTL:      300,
+		Priority: &priority,
+	})
+	content, err := r.Read(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "mail.example.com\n", string(content))
+	}
+
+	if assert.NoError(t, r.Write(context.Background(), []byte("mx.example.com\r\n"))) {
+		assert.Equal(t, map[string]interface{}{
+			"type":     "MX",
+			"name":     "example.com",
+			"content":  "mx.example.com",
+			"ttl":      float64(300),
+			"proxied":  false,
+			"priority": float64(10),
+		}, body)
+	}
+	assert.EqualError(t, r.Write(context.Background(), []byte("\n")), "a DNS record's content cannot be empty")
+
+	ok, err := r.Delete(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, deleted)
+}
+
+func TestBytesTrimNewline(t *testing.T) {
+	cases := map[string]string{
+		"":                "",
+		"203.0.113.1":     "203.0.113.1",
+		"203.0.113.1\n":   "203.0.113.1",
+		"203.0.113.1\r\n": "203.0.113.1",
+		"a\n\n":           "a\n",
+		"a\r":             "a\r",
+	}
+	for input, expected := range cases {
+		assert.Equal(t, expected, string(bytesTrimNewline([]byte(input))), input)
+	}
+}
@@ -0,0 +1,140 @@
+package plugin
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is a content compression format.
+type Compression string
+
+// These are the compression formats that Wash can decompress. NoCompression
+// represents uncompressed content.
+const (
+	NoCompression Compression = ""
+	Gzip          Compression = "gzip"
+	Zstd          Compression = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// DetectCompression returns the compression format of the given content. It
+// returns NoCompression if the content isn't compressed with a format that
+// Wash supports.
+func DetectCompression(content []byte) Compression {
+	switch {
+	case bytes.HasPrefix(content, gzipMagic):
+		return Gzip
+	case bytes.HasPrefix(content, zstdMagic):
+		return Zstd
+	default:
+		return NoCompression
+	}
+}
+
+// Decompress decompresses the given content. Content that isn't compressed
+// is returned as-is. It also returns the content's compression format.
+func Decompress(content []byte) ([]byte, Compression, error) {
+	compression := DetectCompression(content)
+	switch compression {
+	case Gzip:
+		rdr, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, compression, fmt.Errorf("could not decompress the gzip content: %v", err)
+		}
+		defer rdr.Close()
+		decompressed, err := ioutil.ReadAll(rdr)
+		if err != nil {
+			return nil, compression, fmt.Errorf("could not decompress the gzip content: %v", err)
+		}
+		return decompressed, compression, nil
+	case Zstd:
+		decoder, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, compression, err
+		}
+		defer decoder.Close()
+		decompressed, err := decoder.DecodeAll(content, nil)
+		if err != nil {
+			return nil, compression, fmt.Errorf("could not decompress the zstd content: %v", err)
+		}
+		return decompressed, compression, nil
+	default:
+		return content, compression, nil
+	}
+}
+
+// Compress compresses the given content with the given format. Content is
+// returned as-is for NoCompression.
+func Compress(content []byte, compression Compression) ([]byte, error) {
+	switch compression {
+	case Gzip:
+		var buf bytes.Buffer
+		wtr := gzip.NewWriter(&buf)
+		if _, err := wtr.Write(content); err != nil {
+			return nil, err
+		}
+		if err := wtr.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case Zstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		compressed := encoder.EncodeAll(content, nil)
+		return compressed, encoder.Close()
+	case NoCompression:
+		return content, nil
+	default:
+		return nil, fmt.Errorf("unknown compression format %v", compression)
+	}
+}
+
+// decompressedContent is an entry's decompressed content.
+type decompressedContent struct {
+	*entryContentImpl
+	compression Compression
+}
+
+// decompressedReadOp names the cached result of decompressing an entry's
+// content.
+const decompressedReadOp = "DecompressedRead"
+
+// cachedDecompressedRead reads all of the entry's content and decompresses
+// it. The decompressed content's cached with the entry's Read TTL so that
+// reading it in blocks doesn't decompress it for every block.
+func cachedDecompressedRead(ctx context.Context, e Entry) (*decompressedContent, error) {
+	cachedContent, err := cachedOp(ctx, decompressedReadOp, e, e.eb().ttl[ReadOp], func() (interface{}, error) {
+		size, err := Size(ctx, e)
+		if err != nil {
+			return nil, err
+		}
+		raw, err := Read(ctx, e, int64(size), 0)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		decompressed, compression, err := Decompress(raw)
+		if err != nil {
+			return nil, err
+		}
+		return &decompressedContent{
+			entryContentImpl: newEntryContent(decompressed),
+			compression:      compression,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cachedContent.(*decompressedContent), nil
+}
@@ -0,0 +1,49 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressAndDecompress(t *testing.T) {
+	content := []byte("some content that's compressed")
+	for _, compression := range []Compression{Gzip, Zstd} {
+		compressed, err := Compress(content, compression)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.Equal(t, compression, DetectCompression(compressed))
+
+		decompressed, detected, err := Decompress(compressed)
+		if assert.NoError(t, err) {
+			assert.Equal(t, content, decompressed)
+			assert.Equal(t, compression, detected)
+		}
+	}
+}
+
+func TestDecompress_UncompressedContent(t *testing.T) {
+	content := []byte("some content")
+	decompressed, compression, err := Decompress(content)
+	if assert.NoError(t, err) {
+		assert.Equal(t, content, decompressed)
+		assert.Equal(t, NoCompression, compression)
+	}
+
+	compressed, err := Compress(content, NoCompression)
+	if assert.NoError(t, err) {
+		assert.Equal(t, content, compressed)
+	}
+}
+
+func TestDecompress_CorruptContent(t *testing.T) {
+	_, compression, err := Decompress([]byte{0x1f, 0x8b, 0x00})
+	assert.Equal(t, Gzip, compression)
+	assert.Error(t, err)
+}
+
+func TestCompress_UnknownFormat(t *testing.T) {
+	_, err := Compress([]byte("some content"), Compression("lz4"))
+	assert.EqualError(t, err, "unknown compression format lz4")
+}
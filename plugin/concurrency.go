@@ -0,0 +1,112 @@
+package plugin
+
+import (
+	"context"
+	"sync"
+)
+
+// ConcurrencyLimits bound the number of plugin method invocations that can
+// run at the same time. Invocations over a limit are queued in the order that
+// they were made. A limit of 0 means that there's no limit.
+type ConcurrencyLimits struct {
+	// Global bounds the invocations of all the plugins.
+	Global int
+	// Plugin bounds the invocations of each plugin that isn't in Plugins.
+	Plugin int
+	// Plugins bounds the invocations of specific plugins.
+	Plugins map[string]int
+}
+
+// semaphore is a counting semaphore. Go queues a channel's blocked senders
+// in FIFO order, so waiters acquire it in the order they started waiting.
+type semaphore chan struct{}
+
+func newSemaphore(limit int) semaphore {
+	if limit <= 0 {
+		return nil
+	}
+	return make(semaphore, limit)
+}
+
+type concurrencyLimiter struct {
+	limits  ConcurrencyLimits
+	global  semaphore
+	mux     sync.Mutex
+	plugins map[string]semaphore
+}
+
+var limiter *concurrencyLimiter
+
+// SetConcurrencyLimits limits the concurrent plugin method invocations.
+func SetConcurrencyLimits(limits ConcurrencyLimits) {
+	limiter = &concurrencyLimiter{
+		limits:  limits,
+		global:  newSemaphore(limits.Global),
+		plugins: make(map[string]semaphore),
+	}
+}
+
+func (l *concurrencyLimiter) pluginSemaphore(plugin string) semaphore {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	sem, ok := l.plugins[plugin]
+	if !ok {
+		limit, ok := l.limits.Plugins[plugin]
+		if !ok {
+			limit = l.limits.Plugin
+		}
+		sem = newSemaphore(limit)
+		l.plugins[plugin] = sem
+	}
+	return sem
+}
+
+// acquire blocks until the plugin can invoke a method. The plugin's
+// semaphore is acquired first so that a busy plugin can't queue more than its
+// limit for the global semaphore, which keeps it from starving the other
+// plugins.
+func (l *concurrencyLimiter) acquire(ctx context.Context, plugin string) (release func(), err error) {
+	var acquired []semaphore
+	release = func() {
+		for _, sem := range acquired {
+			<-sem
+		}
+	}
+	for _, sem := range []semaphore{l.pluginSemaphore(plugin), l.global} {
+		if sem == nil {
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+			acquired = append(acquired, sem)
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
+
+type concurrencyKey int
+
+const invocationSlotKey concurrencyKey = iota
+
+// acquireInvocationSlot waits for the plugin's turn to invoke a method. It
+// returns the context that the method should be invoked with and a function
+// that releases the slot once the method returns.
+//
+// Methods that are invoked with the returned context, e.g. a List that lists
+// another entry, use the caller's slot. Otherwise, they'd deadlock if every
+// slot is held by a method that's waiting on them.
+func acquireInvocationSlot(ctx context.Context, plugin string) (context.Context, func()) {
+	l := limiter
+	if l == nil || ctx.Value(invocationSlotKey) != nil {
+		return ctx, func() {}
+	}
+	release, err := l.acquire(ctx, plugin)
+	if err != nil {
+		// The method will see that ctx is done, so let it return the error.
+		return ctx, func() {}
+	}
+	return context.WithValue(ctx, invocationSlotKey, true), release
+}
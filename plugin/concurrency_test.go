@@ -0,0 +1,83 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrencyLimiter_BoundsInvocations(t *testing.T) {
+	l := &concurrencyLimiter{
+		limits:  ConcurrencyLimits{Global: 2, Plugin: 1},
+		global:  newSemaphore(2),
+		plugins: make(map[string]semaphore),
+	}
+	ctx := context.Background()
+
+	releaseFoo, err := l.acquire(ctx, "foo")
+	assert.NoError(t, err)
+	releaseBar, err := l.acquire(ctx, "bar")
+	assert.NoError(t, err)
+
+	// foo's at its limit, and the global limit's been reached.
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	_, err = l.acquire(timeoutCtx, "foo")
+	cancel()
+	assert.Equal(t, context.DeadlineExceeded, err)
+	timeoutCtx, cancel = context.WithTimeout(ctx, 10*time.Millisecond)
+	_, err = l.acquire(timeoutCtx, "baz")
+	cancel()
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// A failed acquire doesn't hold on to the plugin's slot.
+	releaseBar()
+	releaseBaz, err := l.acquire(ctx, "baz")
+	assert.NoError(t, err)
+	releaseBaz()
+
+	acquired := make(chan struct{})
+	go func() {
+		release, err := l.acquire(ctx, "foo")
+		assert.NoError(t, err)
+		release()
+		close(acquired)
+	}()
+	releaseFoo()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Error("timed out waiting for foo's slot")
+	}
+}
+
+func TestConcurrencyLimiter_PluginLimits(t *testing.T) {
+	l := &concurrencyLimiter{
+		limits:  ConcurrencyLimits{Plugins: map[string]int{"foo": 1}},
+		plugins: make(map[string]semaphore),
+	}
+	assert.Equal(t, 1, cap(l.pluginSemaphore("foo")))
+	// There's no default plugin limit.
+	assert.Nil(t, l.pluginSemaphore("bar"))
+}
+
+func TestAcquireInvocationSlot_NestedInvocationsUseTheCallersSlot(t *testing.T) {
+	SetConcurrencyLimits(ConcurrencyLimits{Global: 1})
+	defer func() { limiter = nil }()
+
+	ctx, release := acquireInvocationSlot(context.Background(), "foo")
+	defer release()
+
+	done := make(chan struct{})
+	go func() {
+		_, releaseNested := acquireInvocationSlot(ctx, "foo")
+		releaseNested()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("the nested invocation deadlocked")
+	}
+}
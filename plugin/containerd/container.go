@@ -0,0 +1,231 @@
+package containerd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"syscall"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/google/uuid"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/volume"
+	"golang.org/x/sys/unix"
+)
+
+// containerInfo is the container's partial metadata
+type containerInfo struct {
+	containers.Container
+	Status string `json:"status"`
+	PID    uint32 `json:"pid"`
+}
+
+type container struct {
+	plugin.EntryBase
+	namespace string
+	container containerd.Container
+	labels    map[string]string
+}
+
+func newContainer(ctx context.Context, ns string, c containerd.Container) (*container, error) {
+	info, err := c.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	meta := containerInfo{Container: info, Status: string(containerd.Stopped)}
+	// The spec is large and is available via the container's metadata.json
+	// file, so don't include it in the partial metadata.
+	meta.Spec = nil
+	if task, err := c.Task(ctx, nil); err == nil {
+		meta.PID = task.Pid()
+		if status, err := task.Status(ctx); err == nil {
+			meta.Status = string(status.Status)
+		}
+	} else if !errdefs.IsNotFound(err) {
+		return nil, err
+	}
+
+	cntr := &container{
+		EntryBase: plugin.NewEntry(c.ID()),
+	}
+	cntr.namespace = ns
+	cntr.container = c
+	cntr.labels = info.Labels
+	cntr.
+		SetPartialMetadata(meta).
+		Attributes().
+		SetCrtime(info.CreatedAt).
+		SetMtime(info.UpdatedAt).
+		SetCtime(info.UpdatedAt).
+		SetAtime(info.UpdatedAt)
+	return cntr, nil
+}
+
+func (c *container) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(c, "container").
+		SetDescription(containerDescription).
+		SetPartialMetadataSchema(containerInfo{}).
+		SetMetadataSchema(containers.Container{}).
+		AddSignal("stop", "Terminates the container's task. Equivalent to 'ctr task kill <container>'").
+		AddSignal("pause", "Pauses the container's task. Equivalent to 'ctr task pause <container>'").
+		AddSignal("resume", "Resumes the container's task. Equivalent to 'ctr task resume <container>'").
+		AddSignalGroup("linux", `\Asig.+`, "Consists of all the supported POSIX signals like SIGHUP, SIGKILL. Equivalent to\n'ctr task kill -s <signal> <container>'")
+}
+
+func (c *container) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&containerLog{}).Schema(),
+		(&plugin.MetadataJSONFile{}).Schema(),
+		(&volume.FS{}).Schema(),
+	}
+}
+
+func (c *container) nsCtx(ctx context.Context) context.Context {
+	return namespaces.WithNamespace(ctx, c.namespace)
+}
+
+// Metadata returns the container's full info, including its spec
+func (c *container) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	info, err := c.container.Info(c.nsCtx(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return plugin.ToJSONObject(info), nil
+}
+
+func (c *container) List(ctx context.Context) ([]plugin.Entry, error) {
+	meta, err := plugin.NewMetadataJSONFile(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	entries := []plugin.Entry{meta}
+	if logFile, ok := newContainerLog(c.labels); ok {
+		entries = append(entries, logFile)
+	}
+	// Use a small maxdepth because containers can have lots of files and Exec is fast.
+	return append(entries, volume.NewFS(ctx, "fs", c, 3)), nil
+}
+
+func (c *container) Signal(ctx context.Context, signal string) error {
+	task, err := c.container.Task(c.nsCtx(ctx), nil)
+	if err != nil {
+		return err
+	}
+	switch signal {
+	case "stop":
+		signal = "sigterm"
+	case "pause":
+		activity.Record(ctx, "Pausing container %v", c.Name())
+		return task.Pause(c.nsCtx(ctx))
+	case "resume":
+		activity.Record(ctx, "Resuming container %v", c.Name())
+		return task.Resume(c.nsCtx(ctx))
+	}
+	sig := unix.SignalNum(strings.ToUpper(signal))
+	if sig == 0 {
+		return fmt.Errorf("unsupported signal %v", signal)
+	}
+	activity.Record(ctx, "Sending %v to container %v", signal, c.Name())
+	return task.Kill(c.nsCtx(ctx), sig)
+}
+
+// Exec runs the command in the container's task, like 'ctr task exec'
+func (c *container) Exec(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	nsCtx := c.nsCtx(ctx)
+	task, err := c.container.Task(nsCtx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, fmt.Errorf("container %v is not running", c.Name())
+		}
+		return nil, err
+	}
+	spec, err := c.container.Spec(nsCtx)
+	if err != nil {
+		return nil, err
+	}
+	pspec := spec.Process
+	pspec.Args = append([]string{cmd}, args...)
+	pspec.Terminal = opts.Tty
+
+	execCmd := plugin.NewExecCommand(ctx)
+	var process containerd.Process
+	var stdin io.Reader
+	if opts.Stdin != nil {
+		// Close the process' stdin once we've sent all of the input so that
+		// commands that read until EOF finish.
+		stdin = &stdinCloser{r: opts.Stdin, close: func() {
+			if err := process.CloseIO(nsCtx, containerd.WithStdinCloser); err != nil {
+				activity.Record(ctx, "Failed to close stdin of exec in %v: %v", c.Name(), err)
+			}
+		}}
+	}
+	ioOpts := []cio.Opt{cio.WithStreams(stdin, execCmd.Stdout(), execCmd.Stderr())}
+	if opts.Tty {
+		ioOpts = append(ioOpts, cio.WithTerminal)
+	}
+	process, err = task.Exec(nsCtx, "wash-"+uuid.New().String(), pspec, cio.NewCreator(ioOpts...))
+	if err != nil {
+		return nil, err
+	}
+	statusC, err := process.Wait(nsCtx)
+	if err != nil {
+		_, _ = process.Delete(nsCtx)
+		return nil, err
+	}
+	activity.Record(ctx, "Exec %v on %v", pspec.Args, c.Name())
+	if err := process.Start(nsCtx); err != nil {
+		_, _ = process.Delete(nsCtx)
+		return nil, err
+	}
+	execCmd.SetStopFunc(func() {
+		if err := process.Kill(nsCtx, syscall.SIGKILL); err != nil {
+			activity.Record(ctx, "Failed to kill exec in %v: %v", c.Name(), err)
+		}
+	})
+
+	go func() {
+		status := <-statusC
+		code, _, err := status.Result()
+		// Wait for the output to be copied before cleaning up the process.
+		process.IO().Wait()
+		if _, delErr := process.Delete(nsCtx); delErr != nil {
+			activity.Record(ctx, "Failed to delete exec in %v: %v", c.Name(), delErr)
+		}
+		activity.Record(ctx, "Exec on %v exited %v: %v", c.Name(), code, err)
+		if err != nil {
+			execCmd.SetExitCodeErr(err)
+		} else {
+			execCmd.SetExitCode(int(code))
+		}
+		execCmd.CloseStreamsWithError(err)
+	}()
+	return execCmd, nil
+}
+
+// stdinCloser calls close once r's exhausted
+type stdinCloser struct {
+	r     io.Reader
+	close func()
+}
+
+func (s *stdinCloser) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	if err == io.EOF {
+		s.close()
+	}
+	return n, err
+}
+
+const containerDescription = `
+This is a containerd container. Its partial metadata includes the status of
+its task, and its full metadata includes its OCI spec. Running containers
+support exec and signals. Containers that are managed by Kubernetes also
+include their log.
+`
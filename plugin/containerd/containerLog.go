@@ -0,0 +1,118 @@
+package containerd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hpcloud/tail"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// podLogsDir is where the kubelet has the CRI plugin write container logs
+const podLogsDir = "/var/log/pods"
+
+// containerLog is the log of a Kubernetes-managed container. containerd
+// itself doesn't capture container output; the CRI plugin writes it to
+// /var/log/pods/<namespace>_<pod>_<pod uid>/<container>/<restart count>.log.
+type containerLog struct {
+	plugin.EntryBase
+	dir string
+}
+
+// newContainerLog returns the container's log if the container's labels
+// identify it as a Kubernetes container.
+func newContainerLog(labels map[string]string) (*containerLog, bool) {
+	ns := labels["io.kubernetes.pod.namespace"]
+	pod := labels["io.kubernetes.pod.name"]
+	uid := labels["io.kubernetes.pod.uid"]
+	name := labels["io.kubernetes.container.name"]
+	if ns == "" || pod == "" || uid == "" || name == "" {
+		return nil, false
+	}
+	l := &containerLog{
+		EntryBase: plugin.NewEntry("log"),
+	}
+	l.dir = filepath.Join(podLogsDir, fmt.Sprintf("%v_%v_%v", ns, pod, uid), name)
+	l.DisableCachingFor(plugin.ReadOp)
+	return l, true
+}
+
+func (l *containerLog) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(l, "log").IsSingleton()
+}
+
+// path returns the path of the log of the container's latest restart
+func (l *containerLog) path() (string, error) {
+	matches, err := filepath.Glob(filepath.Join(l.dir, "*.log"))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no logs found in %v", l.dir)
+	}
+	restartCount := func(path string) int {
+		n, _ := strconv.Atoi(strings.TrimSuffix(filepath.Base(path), ".log"))
+		return n
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return restartCount(matches[i]) < restartCount(matches[j])
+	})
+	return matches[len(matches)-1], nil
+}
+
+func (l *containerLog) Read(ctx context.Context) ([]byte, error) {
+	path, err := l.path()
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(path)
+}
+
+var endOfFileLocation = tail.SeekInfo{Offset: 0, Whence: io.SeekEnd}
+
+func (l *containerLog) Stream(ctx context.Context) (io.ReadCloser, error) {
+	path, err := l.path()
+	if err != nil {
+		return nil, err
+	}
+	tailer, err := tail.TailFile(path, tail.Config{
+		Follow:    true,
+		ReOpen:    true,
+		MustExist: true,
+		Location:  &endOfFileLocation,
+		Logger:    tail.DiscardingLogger,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		defer func() {
+			activity.Record(ctx, "Stopping tail of %v: %v", path, tailer.Stop())
+			activity.Record(ctx, "Closing write pipe: %v", w.Close())
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case line, ok := <-tailer.Lines:
+				if !ok {
+					return
+				}
+				if _, err := io.WriteString(w, line.Text+"\n"); err != nil {
+					// The reader was closed.
+					return
+				}
+			}
+		}
+	}()
+	return r, nil
+}
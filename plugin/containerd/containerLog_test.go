@@ -0,0 +1,65 @@
+package containerd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewContainerLog(t *testing.T) {
+	labels := map[string]string{
+		"io.kubernetes.pod.namespace":  "default",
+		"io.kubernetes.pod.name":       "web-5d8f",
+		"io.kubernetes.pod.uid":        "0b1c",
+		"io.kubernetes.container.name": "nginx",
+	}
+	l, ok := newContainerLog(labels)
+	if assert.True(t, ok) {
+		assert.Equal(t, filepath.Join(podLogsDir, "default_web-5d8f_0b1c", "nginx"), l.dir)
+	}
+
+	delete(labels, "io.kubernetes.pod.uid")
+	_, ok = newContainerLog(labels)
+	assert.False(t, ok)
+
+	_, ok = newContainerLog(nil)
+	assert.False(t, ok)
+}
+
+func TestContainerLogRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	l := &containerLog{dir: dir}
+	_, err = l.Read(context.Background())
+	assert.EqualError(t, err, "no logs found in "+dir)
+
+	// The latest restart's log is read, which isn't the last in lexical order
+	for name, content := range map[string]string{"0.log": "first", "2.log": "third", "10.log": "latest"} {
+		if !assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)) {
+			return
+		}
+	}
+	content, err := l.Read(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "latest", string(content))
+	}
+}
+
+func TestStdinCloser(t *testing.T) {
+	closed := 0
+	r := &stdinCloser{r: strings.NewReader("input"), close: func() { closed++ }}
+	content, err := ioutil.ReadAll(r)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "input", string(content))
+	}
+	assert.Equal(t, 1, closed)
+}
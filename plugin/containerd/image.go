@@ -0,0 +1,46 @@
+package containerd
+
+import (
+	"context"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/images"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type image struct {
+	plugin.EntryBase
+}
+
+func newImage(ctx context.Context, img containerd.Image) *image {
+	meta := img.Metadata()
+	i := &image{
+		EntryBase: plugin.NewEntry(meta.Name),
+	}
+	i.
+		SetPartialMetadata(meta).
+		Attributes().
+		SetCrtime(meta.CreatedAt).
+		SetMtime(meta.UpdatedAt).
+		SetCtime(meta.UpdatedAt).
+		SetAtime(meta.UpdatedAt)
+	if size, err := img.Size(ctx); err == nil {
+		i.Attributes().SetSize(uint64(size))
+	} else {
+		activity.Record(ctx, "Could not get the size of image %v: %v", meta.Name, err)
+	}
+	return i
+}
+
+func (i *image) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(i, "image").
+		SetDescription(imageDescription).
+		SetPartialMetadataSchema(images.Image{})
+}
+
+const imageDescription = `
+This is a containerd image. Its metadata includes the image's labels and
+target descriptor, and its size is the size of the image's content.
+`
@@ -0,0 +1,128 @@
+package containerd
+
+import (
+	"context"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type namespace struct {
+	plugin.EntryBase
+	client *containerd.Client
+}
+
+func newNamespace(client *containerd.Client, name string) *namespace {
+	ns := &namespace{
+		EntryBase: plugin.NewEntry(name),
+	}
+	ns.client = client
+	return ns
+}
+
+func (ns *namespace) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(ns, "namespace")
+}
+
+func (ns *namespace) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&containersDir{}).Schema(),
+		(&imagesDir{}).Schema(),
+	}
+}
+
+func (ns *namespace) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{
+		newContainersDir(ns.client, ns.Name()),
+		newImagesDir(ns.client, ns.Name()),
+	}, nil
+}
+
+type containersDir struct {
+	plugin.EntryBase
+	client    *containerd.Client
+	namespace string
+}
+
+func newContainersDir(client *containerd.Client, ns string) *containersDir {
+	d := &containersDir{
+		EntryBase: plugin.NewEntry("containers"),
+	}
+	d.client = client
+	d.namespace = ns
+	d.SetTTLOf(plugin.ListOp, 10*time.Second)
+	return d
+}
+
+func (d *containersDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(d, "containers").IsSingleton()
+}
+
+func (d *containersDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&container{}).Schema(),
+	}
+}
+
+// List lists the namespace's containers
+func (d *containersDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	nsCtx := namespaces.WithNamespace(ctx, d.namespace)
+	cntrs, err := d.client.Containers(nsCtx)
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Listing %v containers in namespace %v", len(cntrs), d.namespace)
+	entries := make([]plugin.Entry, 0, len(cntrs))
+	for _, c := range cntrs {
+		cntr, err := newContainer(nsCtx, d.namespace, c)
+		if err != nil {
+			// The container was likely deleted while we were listing the others.
+			activity.Record(ctx, "Skipping container %v: %v", c.ID(), err)
+			continue
+		}
+		entries = append(entries, cntr)
+	}
+	return entries, nil
+}
+
+type imagesDir struct {
+	plugin.EntryBase
+	client    *containerd.Client
+	namespace string
+}
+
+func newImagesDir(client *containerd.Client, ns string) *imagesDir {
+	d := &imagesDir{
+		EntryBase: plugin.NewEntry("images"),
+	}
+	d.client = client
+	d.namespace = ns
+	return d
+}
+
+func (d *imagesDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(d, "images").IsSingleton()
+}
+
+func (d *imagesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&image{}).Schema(),
+	}
+}
+
+// List lists the namespace's images
+func (d *imagesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	nsCtx := namespaces.WithNamespace(ctx, d.namespace)
+	imgs, err := d.client.ListImages(nsCtx)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(imgs))
+	for i, img := range imgs {
+		entries[i] = newImage(nsCtx, img)
+	}
+	return entries, nil
+}
@@ -0,0 +1,125 @@
+// Package containerd presents a filesystem hierarchy for containerd.
+//
+// It talks to the containerd API directly, so it works on hosts without a
+// Docker daemon like k3s nodes and raw containerd hosts.
+package containerd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/containerd/containerd"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// defaultAddresses are the sockets that are tried, in order, if no address
+// is configured. The second is k3s' embedded containerd.
+var defaultAddresses = []string{
+	"/run/containerd/containerd.sock",
+	"/run/k3s/containerd/containerd.sock",
+}
+
+// Root of the containerd plugin
+type Root struct {
+	plugin.EntryBase
+	address string
+	mux     sync.Mutex
+	client  *containerd.Client
+}
+
+// Init for root
+func (r *Root) Init(cfg map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("containerd")
+	r.DisableDefaultCaching()
+
+	if addressI, ok := cfg["address"]; ok {
+		address, ok := addressI.(string)
+		if !ok {
+			return fmt.Errorf("containerd.address config must be a string, not %v", addressI)
+		}
+		r.address = address
+	}
+	return nil
+}
+
+// Schema returns the root's schema
+func (r *Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "containerd").
+		SetDescription(rootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&namespace{}).Schema(),
+	}
+}
+
+// connect returns a client for the configured (or first available) socket.
+// The client's connected lazily so that the plugin can be loaded on hosts
+// without containerd.
+func (r *Root) connect(ctx context.Context) (*containerd.Client, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.client != nil {
+		return r.client, nil
+	}
+
+	address := r.address
+	if address == "" {
+		for _, candidate := range defaultAddresses {
+			if _, err := os.Stat(candidate); err == nil {
+				address = candidate
+				break
+			}
+		}
+		if address == "" {
+			return nil, fmt.Errorf("could not find a containerd socket; set containerd.address in wash.yaml")
+		}
+	}
+	activity.Record(ctx, "Connecting to containerd at %v", address)
+	client, err := containerd.New(address)
+	if err != nil {
+		return nil, err
+	}
+	r.client = client
+	return client, nil
+}
+
+// List lists the containerd namespaces.
+func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	client, err := r.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names, err := client.NamespaceService().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(names))
+	for i, name := range names {
+		entries[i] = newNamespace(client, name)
+	}
+	return entries, nil
+}
+
+const rootDescription = `
+This is the containerd plugin root. It lists containerd's namespaces (e.g.
+'default', or 'k8s.io' for Kubernetes-managed containers). It connects to
+/run/containerd/containerd.sock or, on k3s nodes,
+/run/k3s/containerd/containerd.sock. Use a different socket with
+
+  containerd:
+    address: /path/to/containerd.sock
+
+Note that the socket is usually only accessible to root.
+
+Each namespace includes its containers and images. Running containers
+support exec and signals, and Kubernetes-managed containers include their
+log.
+`
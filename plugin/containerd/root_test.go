@@ -0,0 +1,21 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInit(t *testing.T) {
+	r := &Root{}
+	if assert.NoError(t, r.Init(map[string]interface{}{})) {
+		assert.Empty(t, r.address)
+	}
+
+	if assert.NoError(t, r.Init(map[string]interface{}{"address": "/run/custom/containerd.sock"})) {
+		assert.Equal(t, "/run/custom/containerd.sock", r.address)
+	}
+
+	err := r.Init(map[string]interface{}{"address": 5})
+	assert.EqualError(t, err, "containerd.address config must be a string, not 5")
+}
@@ -0,0 +1,134 @@
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/digitalocean/godo"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type accountConfig struct {
+	token                 string
+	spacesAccessKeyID     string
+	spacesSecretAccessKey string
+	spacesRegions         []string
+}
+
+// defaultSpacesRegions are the regions that support Spaces
+var defaultSpacesRegions = []string{"ams3", "fra1", "nyc3", "sfo2", "sgp1"}
+
+func parseAccountConfig(cfgI interface{}) (accountConfig, error) {
+	cfg := accountConfig{spacesRegions: defaultSpacesRegions}
+	m, ok := cfgI.(map[string]interface{})
+	if !ok {
+		return cfg, fmt.Errorf("expected a map, not %v", cfgI)
+	}
+	strOpts := map[string]*string{
+		"token":                    &cfg.token,
+		"spaces_access_key_id":     &cfg.spacesAccessKeyID,
+		"spaces_secret_access_key": &cfg.spacesSecretAccessKey,
+	}
+	for key, ptr := range strOpts {
+		valueI, ok := m[key]
+		if !ok {
+			continue
+		}
+		if *ptr, ok = valueI.(string); !ok {
+			return cfg, fmt.Errorf("%v must be a string, not %v", key, valueI)
+		}
+	}
+	if cfg.token == "" {
+		return cfg, fmt.Errorf("token must be set")
+	}
+	if regionsI, ok := m["spaces_regions"]; ok {
+		regions, ok := regionsI.([]interface{})
+		if !ok {
+			return cfg, fmt.Errorf("spaces_regions must be a list of regions, not %v", regionsI)
+		}
+		cfg.spacesRegions = make([]string, len(regions))
+		for i, regionI := range regions {
+			if cfg.spacesRegions[i], ok = regionI.(string); !ok {
+				return cfg, fmt.Errorf("spaces_regions must be a list of regions, not %v", regionsI)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+type account struct {
+	plugin.EntryBase
+	client *godo.Client
+	cfg    accountConfig
+}
+
+func newAccount(name string, cfg accountConfig) *account {
+	a := &account{
+		EntryBase: plugin.NewEntry(name),
+	}
+	a.client = godo.NewFromToken(cfg.token)
+	a.cfg = cfg
+	a.DisableDefaultCaching()
+	return a
+}
+
+func (a *account) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(a, "account").
+		SetDescription(accountDescription)
+}
+
+func (a *account) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&dropletsDir{}).Schema(),
+		(&databasesDir{}).Schema(),
+		(&kubernetesDir{}).Schema(),
+		(&spacesDir{}).Schema(),
+	}
+}
+
+// List lists the types of resources in the account
+func (a *account) List(ctx context.Context) ([]plugin.Entry, error) {
+	entries := []plugin.Entry{
+		newDropletsDir(a.client),
+		newDatabasesDir(a.client),
+		newKubernetesDir(a.client),
+	}
+	if a.cfg.spacesAccessKeyID != "" {
+		entries = append(entries, newSpacesDir(a.cfg))
+	}
+	return entries, nil
+}
+
+// Metadata returns the account's info
+func (a *account) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	acct, _, err := a.client.Account.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return plugin.ToJSONObject(acct), nil
+}
+
+// listAll calls list for each page of results, stopping at the last page
+func listAll(list func(opt *godo.ListOptions) (*godo.Response, error)) error {
+	opt := &godo.ListOptions{PerPage: 200}
+	for {
+		resp, err := list(opt)
+		if err != nil {
+			return err
+		}
+		if resp.Links == nil || resp.Links.IsLastPage() {
+			return nil
+		}
+		page, err := resp.Links.CurrentPage()
+		if err != nil {
+			return err
+		}
+		opt.Page = page + 1
+	}
+}
+
+const accountDescription = `
+This is a DigitalOcean account. Its metadata includes the account's limits
+and status.
+`
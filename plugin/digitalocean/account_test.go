@@ -0,0 +1,96 @@
+package digitalocean
+
+import (
+	"context"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAccountConfig(t *testing.T) {
+	cfg, err := parseAccountConfig(map[string]interface{}{"token": "secret"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, accountConfig{token: "secret", spacesRegions: defaultSpacesRegions}, cfg)
+	}
+
+	cfg, err = parseAccountConfig(map[string]interface{}{
+		"token":                    "secret",
+		"spaces_access_key_id":     "key",
+		"spaces_secret_access_key": "shh",
+		"spaces_regions":           []interface{}{"nyc3", "fra1"},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, accountConfig{
+			token:                 "secret",
+			spacesAccessKeyID:     "key",
+			spacesSecretAccessKey: "shh",
+			spacesRegions:         []string{"nyc3", "fra1"},
+		}, cfg)
+	}
+
+	cases := []struct {
+		cfg interface{}
+		err string
+	}{
+		{"secret", "expected a map, not secret"},
+		{map[string]interface{}{"token": 5}, "token must be a string, not 5"},
+		{map[string]interface{}{"spaces_access_key_id": "key"}, "token must be set"},
+		{map[string]interface{}{"token": "secret", "spaces_regions": "nyc3"}, "spaces_regions must be a list of regions, not nyc3"},
+		{map[string]interface{}{"token": "secret", "spaces_regions": []interface{}{"nyc3", 3}}, "spaces_regions must be a list of regions, not [nyc3 3]"},
+	}
+	for _, c := range cases {
+		_, err := parseAccountConfig(c.cfg)
+		assert.EqualError(t, err, c.err)
+	}
+}
+
+func TestAccountList(t *testing.T) {
+	entries, err := newAccount("work", accountConfig{token: "secret"}).List(context.Background())
+	if assert.NoError(t, err) {
+		var names []string
+		for _, entry := range entries {
+			names = append(names, plugin.Name(entry))
+		}
+		assert.Equal(t, []string{"droplets", "databases", "kubernetes"}, names)
+	}
+
+	entries, err = newAccount("work", accountConfig{
+		token:                 "secret",
+		spacesAccessKeyID:     "key",
+		spacesSecretAccessKey: "shh",
+		spacesRegions:         []string{"nyc3"},
+	}).List(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, entries, 4) {
+		assert.Equal(t, "spaces", plugin.Name(entries[3]))
+	}
+}
+
+func TestListAll(t *testing.T) {
+	const url = "https://api.digitalocean.com/v2/droplets?page="
+	pages := []*godo.Pages{
+		{Next: url + "2", Last: url + "3"},
+		{Prev: url + "1", Next: url + "3", Last: url + "3"},
+		{Prev: url + "2"},
+	}
+
+	var requested []int
+	err := listAll(func(opt *godo.ListOptions) (*godo.Response, error) {
+		assert.Equal(t, 200, opt.PerPage)
+		requested = append(requested, opt.Page)
+		return &godo.Response{Links: &godo.Links{Pages: pages[len(requested)-1]}}, nil
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []int{0, 2, 3}, requested)
+	}
+
+	requested = nil
+	err = listAll(func(opt *godo.ListOptions) (*godo.Response, error) {
+		requested = append(requested, opt.Page)
+		return &godo.Response{}, nil
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []int{0}, requested)
+	}
+}
@@ -0,0 +1,97 @@
+package digitalocean
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type databasesDir struct {
+	plugin.EntryBase
+	client *godo.Client
+}
+
+func newDatabasesDir(client *godo.Client) *databasesDir {
+	d := &databasesDir{
+		EntryBase: plugin.NewEntry("databases"),
+	}
+	d.client = client
+	return d
+}
+
+func (d *databasesDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(d, "databases").IsSingleton()
+}
+
+func (d *databasesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&database{}).Schema(),
+	}
+}
+
+// List lists the account's managed database clusters
+func (d *databasesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	err := listAll(func(opt *godo.ListOptions) (*godo.Response, error) {
+		dbs, resp, err := d.client.Databases.List(ctx, opt)
+		for _, db := range dbs {
+			entries = append(entries, newDatabase(db))
+		}
+		return resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+type database struct {
+	plugin.EntryBase
+}
+
+func newDatabase(db godo.Database) *database {
+	d := &database{
+		EntryBase: plugin.NewEntry(db.Name),
+	}
+	// Don't expose any passwords in the metadata.
+	if db.Connection != nil {
+		conn := *db.Connection
+		conn.Password = ""
+		conn.URI = ""
+		db.Connection = &conn
+	}
+	if db.PrivateConnection != nil {
+		conn := *db.PrivateConnection
+		conn.Password = ""
+		conn.URI = ""
+		db.PrivateConnection = &conn
+	}
+	users := make([]godo.DatabaseUser, len(db.Users))
+	for i, user := range db.Users {
+		user.Password = ""
+		users[i] = user
+	}
+	db.Users = users
+	d.
+		SetPartialMetadata(db).
+		Attributes().
+		SetCrtime(db.CreatedAt).
+		SetMtime(db.CreatedAt).
+		SetCtime(db.CreatedAt).
+		SetAtime(db.CreatedAt)
+	return d
+}
+
+func (d *database) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(d, "database").
+		SetDescription(databaseDescription).
+		SetPartialMetadataSchema(godo.Database{})
+}
+
+const databaseDescription = `
+This is a DigitalOcean managed database cluster. Its metadata includes the
+cluster's engine, version, size and connection details and users (without
+their passwords).
+`
@@ -0,0 +1,148 @@
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/transport"
+	"github.com/puppetlabs/wash/volume"
+)
+
+type dropletsDir struct {
+	plugin.EntryBase
+	client *godo.Client
+}
+
+func newDropletsDir(client *godo.Client) *dropletsDir {
+	d := &dropletsDir{
+		EntryBase: plugin.NewEntry("droplets"),
+	}
+	d.client = client
+	d.SetTTLOf(plugin.ListOp, 30*time.Second)
+	return d
+}
+
+func (d *dropletsDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(d, "droplets").IsSingleton()
+}
+
+func (d *dropletsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&droplet{}).Schema(),
+	}
+}
+
+// List lists the account's droplets
+func (d *dropletsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	err := listAll(func(opt *godo.ListOptions) (*godo.Response, error) {
+		droplets, resp, err := d.client.Droplets.List(ctx, opt)
+		for i := range droplets {
+			entries = append(entries, newDroplet(d.client, droplets[i]))
+		}
+		return resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Listing %v droplets", len(entries))
+	return entries, nil
+}
+
+type droplet struct {
+	plugin.EntryBase
+	client *godo.Client
+	id     int
+	ip     string
+}
+
+func newDroplet(client *godo.Client, d godo.Droplet) *droplet {
+	drop := &droplet{
+		EntryBase: plugin.NewEntry(d.Name),
+	}
+	drop.client = client
+	drop.id = d.ID
+	drop.ip, _ = d.PublicIPv4()
+	drop.SetPartialMetadata(d)
+	if created, err := time.Parse(time.RFC3339, d.Created); err == nil {
+		drop.
+			Attributes().
+			SetCrtime(created).
+			SetMtime(created).
+			SetCtime(created).
+			SetAtime(created)
+	}
+	return drop
+}
+
+func (d *droplet) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(d, "droplet").
+		SetDescription(dropletDescription).
+		SetPartialMetadataSchema(godo.Droplet{}).
+		AddSignal("start", "Powers on the droplet").
+		AddSignal("stop", "Gracefully shuts down the droplet").
+		AddSignal("restart", "Gracefully reboots the droplet").
+		AddSignal("power_off", "Powers off the droplet. This is like pulling the power cord").
+		AddSignal("power_cycle", "Power cycles the droplet. This is like pressing the reset button")
+}
+
+func (d *droplet) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&plugin.MetadataJSONFile{}).Schema(),
+		(&volume.FS{}).Schema(),
+	}
+}
+
+func (d *droplet) List(ctx context.Context) ([]plugin.Entry, error) {
+	meta, err := plugin.NewMetadataJSONFile(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	// Use a small maxdepth because VMs can have lots of files and Exec is fast.
+	return []plugin.Entry{meta, volume.NewFS(ctx, "fs", d, 3)}, nil
+}
+
+// Exec runs the command on the droplet over SSH. Droplets are provisioned
+// with a root user; the user and key can be overridden in ~/.ssh/config.
+func (d *droplet) Exec(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	if d.ip == "" {
+		return nil, fmt.Errorf("droplet %v does not have a public IPv4 address", d.Name())
+	}
+	identity := transport.Identity{Host: d.ip, FallbackUser: "root"}
+	return transport.ExecSSH(ctx, identity, append([]string{cmd}, args...), opts)
+}
+
+func (d *droplet) Signal(ctx context.Context, signal string) error {
+	var err error
+	actions := d.client.DropletActions
+	switch signal {
+	case "start":
+		_, _, err = actions.PowerOn(ctx, d.id)
+	case "stop":
+		_, _, err = actions.Shutdown(ctx, d.id)
+	case "restart":
+		_, _, err = actions.Reboot(ctx, d.id)
+	case "power_off":
+		_, _, err = actions.PowerOff(ctx, d.id)
+	case "power_cycle":
+		_, _, err = actions.PowerCycle(ctx, d.id)
+	default:
+		return fmt.Errorf("unsupported signal %v", signal)
+	}
+	if err == nil {
+		activity.Record(ctx, "Sent %v to droplet %v (%v)", signal, d.Name(), strconv.Itoa(d.id))
+	}
+	return err
+}
+
+const dropletDescription = `
+This is a DigitalOcean droplet. Exec'ing it runs the command over SSH as root
+(override the user and key in ~/.ssh/config). Its children include the
+droplet's filesystem.
+`
@@ -0,0 +1,58 @@
+package digitalocean
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDropletsDirList(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/droplets", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{
+			"droplets": [
+				{"id": 1, "name": "web", "created_at": "2020-01-01T00:00:00Z"},
+				{"id": 2, "name": "db", "created_at": "2020-01-02T00:00:00Z"}
+			],
+			"links": {}
+		}`))
+	}))
+	defer srv.Close()
+
+	client := godo.NewFromToken("secret")
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	entries, err := newDropletsDir(client).List(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, entries, 2) {
+		assert.Equal(t, "web", plugin.Name(entries[0]))
+		assert.Equal(t, 1, entries[0].(*droplet).id)
+		assert.Equal(t, "db", plugin.Name(entries[1]))
+		assert.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), entries[1].(*droplet).Attributes().Crtime())
+	}
+}
+
+func TestNewDroplet(t *testing.T) {
+	d := newDroplet(nil, godo.Droplet{
+		ID:   1,
+		Name: "web",
+		Networks: &godo.Networks{V4: []godo.NetworkV4{
+			{IPAddress: "10.0.0.2", Type: "private"},
+			{IPAddress: "203.0.113.5", Type: "public"},
+		}},
+	})
+	assert.Equal(t, "203.0.113.5", d.ip)
+	assert.False(t, d.Attributes().HasCrtime())
+
+	d = newDroplet(nil, godo.Droplet{ID: 2, Name: "db"})
+	_, err := d.Exec(context.Background(), "uname", nil, plugin.ExecOptions{})
+	assert.EqualError(t, err, "droplet db does not have a public IPv4 address")
+
+	assert.EqualError(t, d.Signal(context.Background(), "hibernate"), "unsupported signal hibernate")
+}
@@ -0,0 +1,124 @@
+package digitalocean
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type kubernetesDir struct {
+	plugin.EntryBase
+	client *godo.Client
+}
+
+func newKubernetesDir(client *godo.Client) *kubernetesDir {
+	k := &kubernetesDir{
+		EntryBase: plugin.NewEntry("kubernetes"),
+	}
+	k.client = client
+	return k
+}
+
+func (k *kubernetesDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(k, "kubernetes").IsSingleton()
+}
+
+func (k *kubernetesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&kubernetesCluster{}).Schema(),
+	}
+}
+
+// List lists the account's Kubernetes clusters
+func (k *kubernetesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	err := listAll(func(opt *godo.ListOptions) (*godo.Response, error) {
+		clusters, resp, err := k.client.Kubernetes.List(ctx, opt)
+		for _, cluster := range clusters {
+			entries = append(entries, newKubernetesCluster(k.client, cluster))
+		}
+		return resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+type kubernetesCluster struct {
+	plugin.EntryBase
+	client *godo.Client
+	id     string
+}
+
+func newKubernetesCluster(client *godo.Client, cluster *godo.KubernetesCluster) *kubernetesCluster {
+	k := &kubernetesCluster{
+		EntryBase: plugin.NewEntry(cluster.Name),
+	}
+	k.client = client
+	k.id = cluster.ID
+	k.
+		SetPartialMetadata(cluster).
+		Attributes().
+		SetCrtime(cluster.CreatedAt).
+		SetMtime(cluster.UpdatedAt).
+		SetCtime(cluster.UpdatedAt).
+		SetAtime(cluster.UpdatedAt)
+	return k
+}
+
+func (k *kubernetesCluster) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(k, "cluster").
+		SetDescription(kubernetesClusterDescription).
+		SetPartialMetadataSchema(godo.KubernetesCluster{})
+}
+
+func (k *kubernetesCluster) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&kubeconfig{}).Schema(),
+	}
+}
+
+func (k *kubernetesCluster) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{newKubeconfig(k.client, k.id)}, nil
+}
+
+// kubeconfig is the cluster's kubeconfig file
+type kubeconfig struct {
+	plugin.EntryBase
+	client    *godo.Client
+	clusterID string
+}
+
+func newKubeconfig(client *godo.Client, clusterID string) *kubeconfig {
+	k := &kubeconfig{
+		EntryBase: plugin.NewEntry("kubeconfig"),
+	}
+	k.client = client
+	k.clusterID = clusterID
+	return k
+}
+
+func (k *kubeconfig) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(k, "kubeconfig").IsSingleton()
+}
+
+func (k *kubeconfig) Read(ctx context.Context) ([]byte, error) {
+	config, _, err := k.client.Kubernetes.GetKubeConfig(ctx, k.clusterID)
+	if err != nil {
+		return nil, err
+	}
+	return config.KubeconfigYAML, nil
+}
+
+const kubernetesClusterDescription = `
+This is a DigitalOcean Kubernetes cluster. Its metadata includes the
+cluster's version and node pools. Its 'kubeconfig' file contains the
+cluster's credentials, so you can add it to the Kubernetes plugin with
+something like
+
+  cat digitalocean/personal/kubernetes/mycluster/kubeconfig > ~/.kube/do-mycluster
+  export KUBECONFIG=~/.kube/config:~/.kube/do-mycluster
+`
@@ -0,0 +1,83 @@
+// Package digitalocean presents a filesystem hierarchy for DigitalOcean.
+//
+// It uses the API tokens configured in wash.yaml (or DIGITALOCEAN_ACCESS_TOKEN)
+// to access droplets, Spaces, managed databases and Kubernetes clusters.
+package digitalocean
+
+import (
+	"context"
+	"os"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Root of the digitalocean plugin
+type Root struct {
+	plugin.EntryBase
+	accounts []plugin.Entry
+}
+
+// Init for root
+func (r *Root) Init(cfg map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("digitalocean")
+	r.DisableDefaultCaching()
+
+	r.accounts = []plugin.Entry{}
+	if _, ok := cfg["accounts"]; !ok {
+		if token := os.Getenv("DIGITALOCEAN_ACCESS_TOKEN"); token != "" {
+			r.accounts = append(r.accounts, newAccount("default", accountConfig{token: token}))
+		}
+		return nil
+	}
+	return plugin.ParseNamedConfigs("digitalocean", cfg, "accounts", func(name string, config interface{}) error {
+		accountCfg, err := parseAccountConfig(config)
+		if err != nil {
+			return err
+		}
+		r.accounts = append(r.accounts, newAccount(name, accountCfg))
+		return nil
+	})
+}
+
+// Schema returns the root's schema
+func (r *Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "digitalocean").
+		SetDescription(rootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&account{}).Schema(),
+	}
+}
+
+// List lists the configured accounts.
+func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	return r.accounts, nil
+}
+
+const rootDescription = `
+This is the digitalocean plugin root. It lists the DigitalOcean accounts
+configured in wash.yaml, e.g.
+
+  digitalocean:
+    accounts:
+      personal:
+        token: <API token>
+      work:
+        token: <API token>
+        spaces_access_key_id: <Spaces access key>
+        spaces_secret_access_key: <Spaces secret key>
+        spaces_regions: [nyc3, fra1]
+
+If no accounts are configured, then the account for the
+DIGITALOCEAN_ACCESS_TOKEN environment variable is listed as 'default'.
+
+Each account includes its droplets, managed databases and Kubernetes
+clusters. If Spaces keys are configured, then it also includes its Spaces,
+which behave like S3 buckets. Droplets support exec over SSH and power
+signals like 'start', 'stop' and 'restart'.
+`
@@ -0,0 +1,52 @@
+package digitalocean
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInit(t *testing.T) {
+	os.Unsetenv("DIGITALOCEAN_ACCESS_TOKEN")
+	r := &Root{}
+	assert.NoError(t, r.Init(map[string]interface{}{}))
+	assert.Empty(t, r.accounts)
+
+	err := r.Init(map[string]interface{}{
+		"accounts": map[string]interface{}{
+			"work":     map[string]interface{}{"token": "work-token"},
+			"personal": map[string]interface{}{"token": "personal-token"},
+		},
+	})
+	if assert.NoError(t, err) {
+		entries, err := r.List(context.Background())
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, "personal", plugin.Name(entries[0]))
+			assert.Equal(t, "work", plugin.Name(entries[1]))
+			assert.Equal(t, "work-token", entries[1].(*account).cfg.token)
+		}
+	}
+
+	err = r.Init(map[string]interface{}{"accounts": []interface{}{"work"}})
+	assert.EqualError(t, err, "digitalocean.accounts config must be a map of names to configs, not [work]")
+
+	err = r.Init(map[string]interface{}{
+		"accounts": map[string]interface{}{"work": map[string]interface{}{}},
+	})
+	assert.EqualError(t, err, "digitalocean.accounts.work config is invalid: token must be set")
+}
+
+func TestInit_AccessTokenFromEnv(t *testing.T) {
+	os.Setenv("DIGITALOCEAN_ACCESS_TOKEN", "env-token")
+	defer os.Unsetenv("DIGITALOCEAN_ACCESS_TOKEN")
+
+	r := &Root{}
+	if assert.NoError(t, r.Init(map[string]interface{}{})) && assert.Len(t, r.accounts, 1) {
+		assert.Equal(t, "default", plugin.Name(r.accounts[0]))
+		assert.Equal(t, "env-token", r.accounts[0].(*account).cfg.token)
+	}
+}
@@ -0,0 +1,55 @@
+package digitalocean
+
+import (
+	"context"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/plugin/aws"
+)
+
+// spacesDir lists the Spaces regions. Spaces is S3-compatible, so each
+// region reuses the s3 plugin's endpoint entry.
+type spacesDir struct {
+	plugin.EntryBase
+	cfg accountConfig
+}
+
+func newSpacesDir(cfg accountConfig) *spacesDir {
+	s := &spacesDir{
+		EntryBase: plugin.NewEntry("spaces"),
+	}
+	s.cfg = cfg
+	s.DisableDefaultCaching()
+	return s
+}
+
+func (s *spacesDir) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "spaces").
+		SetDescription(spacesDescription).
+		IsSingleton()
+}
+
+func (s *spacesDir) ChildSchemas() []*plugin.EntrySchema {
+	return (&aws.S3Root{}).ChildSchemas()
+}
+
+// List lists the configured Spaces regions
+func (s *spacesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	entries := make([]plugin.Entry, 0, len(s.cfg.spacesRegions))
+	for _, region := range s.cfg.spacesRegions {
+		url := "https://" + region + ".digitaloceanspaces.com"
+		endpoint, err := aws.NewS3Endpoint(region, url, region, s.cfg.spacesAccessKeyID, s.cfg.spacesSecretAccessKey)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, endpoint)
+	}
+	return entries, nil
+}
+
+const spacesDescription = `
+This lists the account's Spaces, grouped by region. Spaces are S3-compatible,
+so they behave like S3 buckets. The listed regions can be configured via the
+account's 'spaces_regions' key.
+`
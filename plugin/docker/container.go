@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/puppetlabs/wash/activity"
@@ -19,6 +20,8 @@ type container struct {
 	plugin.EntryBase
 	id     string
 	client *client.Client
+	// volumes are the names of the volumes that the container mounts.
+	volumes []string
 }
 
 func newContainer(inst types.Container, client *client.Client) *container {
@@ -36,6 +39,11 @@ func newContainer(inst types.Container, client *client.Client) *container {
 	}
 	cont.id = inst.ID
 	cont.client = client
+	for _, m := range inst.Mounts {
+		if m.Type == mount.TypeVolume {
+			cont.volumes = append(cont.volumes, m.Name)
+		}
+	}
 
 	startTime := time.Unix(inst.Created, 0)
 	cont.
@@ -77,6 +85,7 @@ func (c *container) ChildSchemas() []*plugin.EntrySchema {
 		(&containerLogFile{}).Schema(),
 		(&plugin.MetadataJSONFile{}).Schema(),
 		(&vol.FS{}).Schema(),
+		(&containerVolumesDir{}).Schema(),
 	}
 }
 
@@ -91,7 +100,7 @@ func (c *container) List(ctx context.Context) ([]plugin.Entry, error) {
 
 	// Include a view of the remote filesystem using volume.FS. Use a small maxdepth because
 	// VMs can have lots of files and Exec is fast.
-	return []plugin.Entry{clf, cm, vol.NewFS(ctx, "fs", c, 3)}, nil
+	return []plugin.Entry{clf, cm, vol.NewFS(ctx, "fs", c, 3), newContainerVolumesDir(c)}, nil
 }
 
 func (c *container) Delete(ctx context.Context) (bool, error) {
@@ -101,6 +110,10 @@ func (c *container) Delete(ctx context.Context) (bool, error) {
 	return true, err
 }
 
+func (c *container) Rename(ctx context.Context, newName string) error {
+	return c.client.ContainerRename(ctx, c.id, newName)
+}
+
 func (c *container) Exec(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
 	command := append([]string{cmd}, args...)
 	activity.Record(ctx, "Exec %v on %v", command, c.Name())
@@ -0,0 +1,48 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// containerVolumesDir contains links to the volumes that a container mounts.
+type containerVolumesDir struct {
+	plugin.EntryBase
+	volumes []string
+}
+
+func newContainerVolumesDir(container *container) *containerVolumesDir {
+	vd := &containerVolumesDir{
+		EntryBase: plugin.NewEntry("volumes"),
+	}
+	vd.volumes = container.volumes
+	return vd
+}
+
+func (vd *containerVolumesDir) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(vd, "volumes").
+		SetDescription(containerVolumesDirDescription).
+		IsSingleton()
+}
+
+func (vd *containerVolumesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&plugin.SymlinkEntry{}).Schema(),
+	}
+}
+
+func (vd *containerVolumesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	entries := make([]plugin.Entry, len(vd.volumes))
+	for i, name := range vd.volumes {
+		// Links are relative to this dir, which is docker/containers/<container>/volumes.
+		entries[i] = plugin.NewSymlinkEntry(name, "../../../volumes/"+name)
+	}
+	return entries, nil
+}
+
+const containerVolumesDirDescription = `
+This contains links to the volumes that the container mounts. Each link's
+target is the volume in docker/volumes.
+`
@@ -278,6 +278,16 @@ func (v *volume) VolumeDelete(ctx context.Context, path string) (bool, error) {
 	return true, nil
 }
 
+func (v *volume) VolumeCopy(ctx context.Context, src string, dst string) error {
+	_, err := v.runInTemporaryContainer(ctx, []string{"cp", mountpoint + src, mountpoint + dst})
+	return err
+}
+
+func (v *volume) VolumeSetAttr(ctx context.Context, path string, attr plugin.EntryAttributes) error {
+	_, err := v.runInTemporaryContainer(ctx, volpkg.SetAttrCommand(mountpoint+path, attr))
+	return err
+}
+
 const volumeDescription = `
 This is a Docker volume. We create a temporary Docker container whenever
 Wash invokes a currently uncached List/Read/Stream action on it or one of
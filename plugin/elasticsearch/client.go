@@ -0,0 +1,96 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// client is a minimal Elasticsearch REST API client
+type client struct {
+	baseURL  *url.URL
+	username string
+	password string
+}
+
+func newClient(clusterURL string) (*client, error) {
+	u, err := url.Parse(clusterURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("expected an http:// or https:// URL, not %v", clusterURL)
+	}
+	c := &client{baseURL: u}
+	if u.User != nil {
+		c.username = u.User.Username()
+		c.password, _ = u.User.Password()
+		u.User = nil
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return c, nil
+}
+
+func (c *client) get(ctx context.Context, path string, v interface{}) error {
+	return c.do(ctx, http.MethodGet, path, nil, v)
+}
+
+// do sends a request with body and unmarshals the JSON response into v.
+// body can be an io.Reader or a value that's marshalled to JSON. Either can
+// be nil.
+func (c *client) do(ctx context.Context, method string, path string, body interface{}, v interface{}) error {
+	var reqBody io.Reader
+	switch b := body.(type) {
+	case nil:
+	case io.Reader:
+		reqBody = b
+	default:
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			return err
+		}
+		reqBody = &buf
+	}
+
+	req, err := http.NewRequest(method, c.baseURL.String()+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		var apiErr struct {
+			Error struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Error.Reason != "" {
+			return fmt.Errorf("%v %v: %v: %v", method, path, apiErr.Error.Type, apiErr.Error.Reason)
+		}
+		return fmt.Errorf("%v %v: %v", method, path, resp.Status)
+	}
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, v)
+}
@@ -0,0 +1,82 @@
+package elasticsearch
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type cluster struct {
+	plugin.EntryBase
+	client *client
+}
+
+func newCluster(name string, c *client) *cluster {
+	cl := &cluster{
+		EntryBase: plugin.NewEntry(name),
+	}
+	cl.client = c
+	cl.DisableDefaultCaching()
+	return cl
+}
+
+func (c *cluster) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(c, "cluster").
+		SetDescription(clusterDescription)
+}
+
+func (c *cluster) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&index{}).Schema(),
+	}
+}
+
+// catIndex is an entry in the _cat/indices output. Numbers are returned
+// as strings.
+type catIndex struct {
+	Health    string `json:"health"`
+	Status    string `json:"status"`
+	Index     string `json:"index"`
+	UUID      string `json:"uuid"`
+	Primaries string `json:"pri"`
+	Replicas  string `json:"rep"`
+	DocsCount string `json:"docs.count"`
+	StoreSize string `json:"store.size"`
+}
+
+// List lists the cluster's indices, excluding hidden indices
+func (c *cluster) List(ctx context.Context) ([]plugin.Entry, error) {
+	var indices []catIndex
+	if err := c.client.get(ctx, "/_cat/indices?format=json&bytes=b&s=index", &indices); err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, 0, len(indices))
+	for _, idx := range indices {
+		info := indexInfo{
+			Health: idx.Health,
+			Status: idx.Status,
+			UUID:   idx.UUID,
+		}
+		info.Primaries, _ = strconv.Atoi(idx.Primaries)
+		info.Replicas, _ = strconv.Atoi(idx.Replicas)
+		info.DocsCount, _ = strconv.ParseInt(idx.DocsCount, 10, 64)
+		info.StoreSize, _ = strconv.ParseInt(idx.StoreSize, 10, 64)
+		entries = append(entries, newIndex(c.client, idx.Index, info))
+	}
+	return entries, nil
+}
+
+// Metadata returns the cluster's health
+func (c *cluster) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	var health plugin.JSONObject
+	if err := c.client.get(ctx, "/_cluster/health", &health); err != nil {
+		return nil, err
+	}
+	return health, nil
+}
+
+const clusterDescription = `
+This is an Elasticsearch cluster. Its metadata is the cluster's health.
+`
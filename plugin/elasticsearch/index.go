@@ -0,0 +1,207 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type indexInfo struct {
+	Health    string `json:"health"`
+	Status    string `json:"status"`
+	UUID      string `json:"uuid"`
+	Primaries int    `json:"primaries"`
+	Replicas  int    `json:"replicas"`
+	DocsCount int64  `json:"docs_count"`
+	StoreSize int64  `json:"store_size"`
+}
+
+type index struct {
+	plugin.EntryBase
+	client *client
+}
+
+func newIndex(c *client, name string, info indexInfo) *index {
+	idx := &index{
+		EntryBase: plugin.NewEntry(name),
+	}
+	idx.client = c
+	idx.
+		SetPartialMetadata(info).
+		Attributes().
+		SetSize(uint64(info.StoreSize))
+	return idx
+}
+
+func (i *index) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(i, "index").
+		SetDescription(indexDescription).
+		SetPartialMetadataSchema(indexInfo{})
+}
+
+func (i *index) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&indexFile{}).Schema(),
+	}
+}
+
+// List lists the index's mappings and settings files
+func (i *index) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{
+		newIndexFile(i, "mappings.json", "/_mapping"),
+		newIndexFile(i, "settings.json", "/_settings"),
+	}, nil
+}
+
+func (i *index) path() string {
+	return "/" + url.PathEscape(i.Name())
+}
+
+// Delete deletes the index
+func (i *index) Delete(ctx context.Context) (bool, error) {
+	activity.Record(ctx, "Deleting index %v", i.Name())
+	if err := i.client.do(ctx, "DELETE", i.path(), nil, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// scrollSize is the number of hits that are fetched per scroll request
+const scrollSize = 500
+
+type searchResponse struct {
+	ScrollID string `json:"_scroll_id"`
+	Hits     struct {
+		Hits []json.RawMessage `json:"hits"`
+	} `json:"hits"`
+}
+
+// Exec searches the index. If the joined cmd and args start with '{', they're
+// sent as the search request's body. Otherwise, they're treated as a query
+// string. Each hit is written to stdout as a line of JSON.
+func (i *index) Exec(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	query := strings.TrimSpace(strings.Join(append([]string{cmd}, args...), " "))
+	var body interface{}
+	if strings.HasPrefix(query, "{") {
+		body = strings.NewReader(query)
+	} else {
+		body = map[string]interface{}{
+			"query": map[string]interface{}{
+				"query_string": map[string]interface{}{
+					"query": query,
+				},
+			},
+		}
+	}
+	activity.Record(ctx, "Searching %v with %v", i.Name(), query)
+
+	execCmd := plugin.NewExecCommand(ctx)
+	go func() {
+		err := i.search(ctx, body, execCmd.Stdout())
+		if err != nil {
+			fmt.Fprintln(execCmd.Stderr(), err)
+		}
+		execCmd.CloseStreamsWithError(nil)
+		if err != nil {
+			execCmd.SetExitCode(1)
+		} else {
+			execCmd.SetExitCode(0)
+		}
+	}()
+	return execCmd, nil
+}
+
+// search scrolls through all of the hits for the search request's body,
+// writing each hit as NDJSON.
+func (i *index) search(ctx context.Context, body interface{}, w io.Writer) error {
+	var resp searchResponse
+	path := fmt.Sprintf("%v/_search?scroll=1m&size=%v", i.path(), scrollSize)
+	if err := i.client.do(ctx, "POST", path, body, &resp); err != nil {
+		return err
+	}
+	defer func() {
+		if resp.ScrollID != "" {
+			// Free the scroll context. This is best-effort since the
+			// scroll will expire anyways.
+			_ = i.client.do(context.Background(), "DELETE", "/_search/scroll", map[string]interface{}{
+				"scroll_id": resp.ScrollID,
+			}, nil)
+		}
+	}()
+
+	for len(resp.Hits.Hits) > 0 {
+		for _, hit := range resp.Hits.Hits {
+			if _, err := w.Write(append(hit, '\n')); err != nil {
+				return err
+			}
+		}
+		scrollID := resp.ScrollID
+		resp = searchResponse{}
+		err := i.client.do(ctx, "POST", "/_search/scroll", map[string]interface{}{
+			"scroll":    "1m",
+			"scroll_id": scrollID,
+		}, &resp)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexFile is a readable JSON file containing some part of an index's
+// configuration.
+type indexFile struct {
+	plugin.EntryBase
+	index *index
+	path  string
+}
+
+func newIndexFile(i *index, name string, path string) *indexFile {
+	f := &indexFile{
+		EntryBase: plugin.NewEntry(name),
+	}
+	f.index = i
+	f.path = path
+	return f
+}
+
+func (f *indexFile) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(f, "file")
+}
+
+// Read returns the pretty-printed JSON
+func (f *indexFile) Read(ctx context.Context) ([]byte, error) {
+	var resp map[string]json.RawMessage
+	if err := f.index.client.get(ctx, f.index.path()+f.path, &resp); err != nil {
+		return nil, err
+	}
+	// The response is keyed by the index name.
+	content, ok := resp[f.index.Name()]
+	if !ok {
+		return nil, fmt.Errorf("the response for %v did not include index %v", f.path, f.index.Name())
+	}
+	var v interface{}
+	if err := json.Unmarshal(content, &v); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(v, "", "  ")
+}
+
+const indexDescription = `
+This is an Elasticsearch index. Its metadata includes the index's health,
+document count and store size. Its mappings and settings are available as
+the 'mappings.json' and 'settings.json' files.
+
+Exec'ing the index searches it, streaming all of the hits to stdout as
+NDJSON. A query that starts with '{' is sent as the search request's body.
+Anything else is treated as a Lucene query string.
+
+Indices can be deleted with 'delete'.
+`
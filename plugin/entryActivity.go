@@ -0,0 +1,67 @@
+package plugin
+
+import (
+	"sync"
+	"time"
+
+	"github.com/puppetlabs/wash/datastore"
+)
+
+// MethodInvocation describes an invocation of an entry's method.
+type MethodInvocation struct {
+	Method   string        `json:"method"`
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration"`
+	// JournalID identifies the activity journal of the command that invoked
+	// the method, which is described by Command.
+	JournalID string `json:"journal_id,omitempty"`
+	Command   string `json:"command,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+const (
+	// Only the most recent invocations of each entry's methods are kept, for
+	// the most recently used entries.
+	maxEntryInvocations = 20
+	maxEntryActivities  = 5000
+	entryActivityTTL    = 24 * time.Hour
+)
+
+var entryActivities = datastore.NewMemCache().Limit(maxEntryActivities)
+
+type entryActivity struct {
+	mux         sync.Mutex
+	invocations []MethodInvocation
+}
+
+func recordEntryInvocation(id string, invocation MethodInvocation) {
+	if id == "" {
+		return
+	}
+	obj, err := entryActivities.GetOrUpdate("", id, entryActivityTTL, true, func() (interface{}, error) {
+		return &entryActivity{}, nil
+	})
+	if err != nil {
+		return
+	}
+	activity := obj.(*entryActivity)
+	activity.mux.Lock()
+	defer activity.mux.Unlock()
+	activity.invocations = append(activity.invocations, invocation)
+	if n := len(activity.invocations); n > maxEntryInvocations {
+		activity.invocations = append([]MethodInvocation{}, activity.invocations[n-maxEntryInvocations:]...)
+	}
+}
+
+// ActivityOf returns the recent invocations of e's methods, oldest first.
+// Cached results aren't invocations, so they aren't included.
+func ActivityOf(e Entry) []MethodInvocation {
+	obj, _ := entryActivities.Get("", e.eb().id)
+	if obj == nil {
+		return nil
+	}
+	activity := obj.(*entryActivity)
+	activity.mux.Lock()
+	defer activity.mux.Unlock()
+	return append([]MethodInvocation{}, activity.invocations...)
+}
@@ -0,0 +1,39 @@
+package plugin
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActivityOf(t *testing.T) {
+	entry := newCacheTestsMockEntry("foo")
+	entry.SetTestID("/entryActivityTest/foo")
+	assert.Empty(t, ActivityOf(entry))
+
+	start := time.Now()
+	for i := 0; i < maxEntryInvocations+5; i++ {
+		recordEntryInvocation(entry.id, MethodInvocation{
+			Method:   fmt.Sprintf("Method%v", i),
+			Time:     start.Add(time.Duration(i) * time.Second),
+			Duration: time.Millisecond,
+		})
+	}
+
+	// Only the most recent invocations are kept, oldest first.
+	invocations := ActivityOf(entry)
+	if assert.Len(t, invocations, maxEntryInvocations) {
+		assert.Equal(t, "Method5", invocations[0].Method)
+		assert.Equal(t, fmt.Sprintf("Method%v", maxEntryInvocations+4), invocations[maxEntryInvocations-1].Method)
+	}
+
+	// ActivityOf returns a copy
+	invocations[0].Method = "changed"
+	assert.Equal(t, "Method5", ActivityOf(entry)[0].Method)
+
+	// Invocations without an entry ID aren't recorded
+	recordEntryInvocation("", MethodInvocation{Method: "List"})
+	assert.Len(t, ActivityOf(entry), maxEntryInvocations)
+}
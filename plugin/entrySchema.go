@@ -72,7 +72,7 @@ func schema(e Entry) (*EntrySchema, error) {
 		// We start by putting in a stub value for s so that we preserve the insertion
 		// order. We'll then update this value once the "Children" array's been calculated.
 		schema.graph.Put(typeID, EntrySchema{})
-		for _, root := range t.pluginRoots {
+		for _, root := range t.enabledPluginRoots() {
 			childSchema, err := Schema(root)
 			if err != nil {
 				return nil, fmt.Errorf("failed to retrieve the %v plugin's schema: %v", root.eb().name, err)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"sync"
 	"syscall"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/kballard/go-shellquote"
 	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/tracing"
 )
 
 // Command is a wrapper to exec.Cmd. It handles context-cancellation cleanup
@@ -43,7 +45,8 @@ type command struct {
 // own process group. When the context is cancelled, a SIGTERM signal will
 // be sent to the command's process group. If after five seconds the command's
 // process has not been terminated, then a SIGKILL signal is sent to the
-// command's process group.
+// command's process group. If ctx is being traced, then the command's
+// TRACEPARENT environment variable is set to the current span.
 func NewCommand(ctx context.Context, cmd string, args ...string) Command {
 	if ctx == nil {
 		panic("plugin.newCommand called with a nil context")
@@ -58,6 +61,9 @@ func NewCommand(ctx context.Context, cmd string, args ...string) Command {
 	cmdObj.SysProcAttr = &syscall.SysProcAttr{
 		Setpgid: true,
 	}
+	if traceParent := tracing.TraceParent(ctx); traceParent != "" {
+		cmdObj.Env = append(os.Environ(), "TRACEPARENT="+traceParent)
+	}
 	return cmdObj
 }
 
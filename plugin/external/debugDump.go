@@ -0,0 +1,259 @@
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kballard/go-shellquote"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// maxDebugStreamSize is the number of bytes of each invocation's stdin,
+	// stdout and stderr that are dumped. The rest is counted, but discarded.
+	maxDebugStreamSize = 1024 * 1024
+	// maxDebugDumpSize is the total size of a plugin's dumps. The oldest
+	// dumps are removed once it's exceeded.
+	maxDebugDumpSize = 100 * 1024 * 1024
+)
+
+// debugDumper records each of an external plugin's invocations to its own
+// directory in dir. The directory contains the invocation's argv, stdin,
+// stdout, stderr and timing.
+type debugDumper struct {
+	dir   string
+	mux   sync.Mutex
+	seq   int
+	dumps []debugDump
+	size  int64
+}
+
+type debugDump struct {
+	path string
+	size int64
+}
+
+func newDebugDumper(dir string) (*debugDumper, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	d := &debugDumper{dir: dir}
+
+	// Account for the dumps of previous runs so that they count towards
+	// maxDebugDumpSize. Their names start with a timestamp, so ReadDir
+	// returns them oldest first.
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, info.Name())
+		size, err := dirSize(path)
+		if err != nil {
+			return nil, err
+		}
+		d.dumps = append(d.dumps, debugDump{path: path, size: size})
+		d.size += size
+	}
+	return d, nil
+}
+
+// wrap returns a Command that records cmd's invocation once it's finished.
+func (d *debugDumper) wrap(method string, argv []string, cmd Command) Command {
+	debugCmd := &debugCommand{Command: cmd, dumper: d, method: method, argv: argv}
+	debugCmd.stdin.name = "stdin"
+	debugCmd.stdout.name = "stdout"
+	debugCmd.stderr.name = "stderr"
+	return debugCmd
+}
+
+func (d *debugDumper) dump(cmd *debugCommand, err error) {
+	end := time.Now()
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.seq++
+	name := fmt.Sprintf("%v-%04d-%v", cmd.start.Format("20060102T150405.000000"), d.seq, cmd.method)
+	path := filepath.Join(d.dir, name)
+	if err := os.Mkdir(path, 0750); err != nil {
+		log.Warnf("external: could not dump %v: %v", cmd, err)
+		return
+	}
+
+	var timing strings.Builder
+	fmt.Fprintf(&timing, "start: %v\n", cmd.start.Format(time.RFC3339Nano))
+	fmt.Fprintf(&timing, "end: %v\n", end.Format(time.RFC3339Nano))
+	fmt.Fprintf(&timing, "duration: %v\n", end.Sub(cmd.start))
+	fmt.Fprintf(&timing, "exit code: %v\n", cmd.ExitCode())
+	if err != nil {
+		fmt.Fprintf(&timing, "error: %v\n", err)
+	}
+	files := map[string][]byte{
+		"argv":   []byte(shellquote.Join(cmd.argv...) + "\n"),
+		"stdin":  cmd.stdin.Bytes(),
+		"stdout": cmd.stdout.Bytes(),
+		"stderr": cmd.stderr.Bytes(),
+	}
+	for _, stream := range []*cappedBuffer{&cmd.stdin, &cmd.stdout, &cmd.stderr} {
+		if discarded := stream.Discarded(); discarded > 0 {
+			fmt.Fprintf(&timing, "%v truncated: %v bytes discarded\n", stream.name, discarded)
+		}
+	}
+	files["timing"] = []byte(timing.String())
+
+	var size int64
+	for file, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(path, file), content, 0640); err != nil {
+			log.Warnf("external: could not dump %v: %v", cmd, err)
+		}
+		size += int64(len(content))
+	}
+	d.dumps = append(d.dumps, debugDump{path: path, size: size})
+	d.size += size
+
+	// Remove the oldest dumps, but always keep the latest one.
+	for d.size > maxDebugDumpSize && len(d.dumps) > 1 {
+		oldest := d.dumps[0]
+		if err := os.RemoveAll(oldest.path); err != nil {
+			log.Warnf("external: could not remove %v: %v", oldest.path, err)
+		}
+		d.dumps = d.dumps[1:]
+		d.size -= oldest.size
+	}
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// debugCommand tees the command's stdin, stdout and stderr, and dumps them
+// once the command's finished.
+type debugCommand struct {
+	Command
+	dumper                *debugDumper
+	method                string
+	argv                  []string
+	start                 time.Time
+	stdin, stdout, stderr cappedBuffer
+	dumpOnce              sync.Once
+}
+
+func (cmd *debugCommand) String() string {
+	return fmt.Sprint(cmd.Command)
+}
+
+func (cmd *debugCommand) Start() error {
+	cmd.start = time.Now()
+	err := cmd.Command.Start()
+	if err != nil {
+		cmd.finish(err)
+	}
+	return err
+}
+
+func (cmd *debugCommand) Run() error {
+	// Don't use Command#Run because it won't invoke our Start and Wait.
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	return cmd.Wait()
+}
+
+func (cmd *debugCommand) Wait() error {
+	err := cmd.Command.Wait()
+	cmd.finish(err)
+	return err
+}
+
+func (cmd *debugCommand) finish(err error) {
+	cmd.dumpOnce.Do(func() {
+		cmd.dumper.dump(cmd, err)
+	})
+}
+
+func (cmd *debugCommand) SetStdout(stdout io.Writer) {
+	cmd.Command.SetStdout(io.MultiWriter(stdout, &cmd.stdout))
+}
+
+func (cmd *debugCommand) SetStderr(stderr io.Writer) {
+	cmd.Command.SetStderr(io.MultiWriter(stderr, &cmd.stderr))
+}
+
+func (cmd *debugCommand) SetStdin(stdin io.Reader) {
+	cmd.Command.SetStdin(io.TeeReader(stdin, &cmd.stdin))
+}
+
+func (cmd *debugCommand) StdoutPipe() (io.ReadCloser, error) {
+	return teePipe(cmd.Command.StdoutPipe, &cmd.stdout)
+}
+
+func (cmd *debugCommand) StderrPipe() (io.ReadCloser, error) {
+	return teePipe(cmd.Command.StderrPipe, &cmd.stderr)
+}
+
+func teePipe(pipe func() (io.ReadCloser, error), w io.Writer) (io.ReadCloser, error) {
+	rdr, err := pipe()
+	if err != nil {
+		return nil, err
+	}
+	return teeReadCloser{Reader: io.TeeReader(rdr, w), Closer: rdr}, nil
+}
+
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// cappedBuffer is a thread-safe buffer that keeps the first
+// maxDebugStreamSize bytes that are written to it. Writes always succeed so
+// that they don't fail the command.
+type cappedBuffer struct {
+	name      string
+	mux       sync.Mutex
+	buf       bytes.Buffer
+	discarded int64
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	n := len(p)
+	if remaining := maxDebugStreamSize - b.buf.Len(); n > remaining {
+		b.discarded += int64(n - remaining)
+		p = p[:remaining]
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+func (b *cappedBuffer) Bytes() []byte {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return append([]byte{}, b.buf.Bytes()...)
+}
+
+func (b *cappedBuffer) Discarded() int64 {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.discarded
+}
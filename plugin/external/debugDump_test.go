@@ -0,0 +1,78 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readDumpFile(t *testing.T, dump string, file string) string {
+	content, err := ioutil.ReadFile(filepath.Join(dump, file))
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	return string(content)
+}
+
+func TestDebugDumper(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wash-debug-dump")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	dumper, err := newDebugDumper(dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+	argv := []string{"sh", "-c", "cat; echo failed >&2; exit 2"}
+	var stdout, stderr bytes.Buffer
+	cmd := dumper.wrap("write", argv, NewCommand(context.Background(), argv[0], argv[1:]...))
+	cmd.SetStdin(strings.NewReader("some input"))
+	cmd.SetStdout(&stdout)
+	cmd.SetStderr(&stderr)
+	assert.Error(t, cmd.Run())
+	assert.Equal(t, 2, cmd.ExitCode())
+
+	// The command's output is still passed along
+	assert.Equal(t, "some input", stdout.String())
+	assert.Equal(t, "failed\n", stderr.String())
+
+	dumps, err := filepath.Glob(filepath.Join(dir, "*-write"))
+	if !assert.NoError(t, err) || !assert.Len(t, dumps, 1) {
+		return
+	}
+	assert.Equal(t, "sh -c 'cat; echo failed >&2; exit 2'\n", readDumpFile(t, dumps[0], "argv"))
+	assert.Equal(t, "some input", readDumpFile(t, dumps[0], "stdin"))
+	assert.Equal(t, "some input", readDumpFile(t, dumps[0], "stdout"))
+	assert.Equal(t, "failed\n", readDumpFile(t, dumps[0], "stderr"))
+	timing := readDumpFile(t, dumps[0], "timing")
+	assert.Contains(t, timing, "duration: ")
+	assert.Contains(t, timing, "exit code: 2\n")
+	assert.Contains(t, timing, "error: exit status 2\n")
+
+	// A new dumper accounts for the existing dumps
+	dumper, err = newDebugDumper(dir)
+	if assert.NoError(t, err) && assert.Len(t, dumper.dumps, 1) {
+		assert.Equal(t, dumps[0], dumper.dumps[0].path)
+		assert.NotZero(t, dumper.size)
+	}
+}
+
+func TestCappedBuffer(t *testing.T) {
+	var buf cappedBuffer
+	n, err := buf.Write(make([]byte, maxDebugStreamSize-1))
+	assert.NoError(t, err)
+	assert.Equal(t, maxDebugStreamSize-1, n)
+	n, err = buf.Write([]byte("abc"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Len(t, buf.Bytes(), maxDebugStreamSize)
+	assert.Equal(t, int64(2), buf.Discarded())
+}
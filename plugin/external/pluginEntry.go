@@ -40,6 +40,7 @@ type decodedExternalPluginEntry struct {
 	Attributes         plugin.EntryAttributes `json:"attributes"`
 	PartialMetadata    plugin.JSONObject      `json:"partial_metadata"`
 	State              string                 `json:"state"`
+	SymlinkTarget      string                 `json:"symlink_target"`
 }
 
 type methodTuple struct {
@@ -64,6 +65,10 @@ func (m *methodTuple) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+type listImpl struct {
+	Paginated bool `json:"paginated"`
+}
+
 type execImpl struct {
 	Transport string             `json:"transport"`
 	Options   transport.Identity `json:"options"`
@@ -105,6 +110,15 @@ func (e decodedExternalPluginEntry) getMungedMethods() (map[string]methodInfo, e
 			}
 			info.tupleValue = []byte(content)
 		case "list":
+			// Check if we have ["list", {"paginated": <paginated?>}] or ["list", <entries>].
+			var impl listImpl
+			if err := json.Unmarshal(tuple.Value, &impl); err == nil {
+				if impl.Paginated {
+					info.signature = plugin.PaginatedSignature
+				}
+				break
+			}
+
 			var decodedEntries []decodedExternalPluginEntry
 			if err := json.Unmarshal(tuple.Value, &decodedEntries); err != nil {
 				return nil, fmt.Errorf("implementation of list must conform to %v, not %v", listFormat, string(tuple.Value))
@@ -186,11 +200,12 @@ func (e decodedExternalPluginEntry) toExternalPluginEntry(ctx context.Context, s
 	}
 
 	entry := &pluginEntry{
-		EntryBase:   plugin.NewEntry(e.Name),
-		methods:     methods,
-		state:       e.State,
-		schemaKnown: schemaKnown,
-		rawTypeID:   e.TypeID,
+		EntryBase:     plugin.NewEntry(e.Name),
+		methods:       methods,
+		state:         e.State,
+		schemaKnown:   schemaKnown,
+		rawTypeID:     e.TypeID,
+		symlinkTarget: e.SymlinkTarget,
 	}
 	entry.SetAttributes(e.Attributes)
 	entry.SetPartialMetadata(e.PartialMetadata)
@@ -229,6 +244,8 @@ type pluginEntry struct {
 	methods   map[string]methodInfo
 	state     string
 	rawTypeID string
+	// symlinkTarget is empty if the entry isn't a symlink.
+	symlinkTarget string
 	// schemaKnown is set by the root. We use it to enforce the invariant
 	// "If the root implements schema, all entries must implement schema"
 	// when decoding external plugin entries.
@@ -238,6 +255,12 @@ type pluginEntry struct {
 	schemaGraphs map[string]*linkedhashmap.Map
 }
 
+// SymlinkTarget returns the entry's symlink_target. It's empty if the entry
+// isn't a symlink.
+func (e *pluginEntry) SymlinkTarget() string {
+	return e.symlinkTarget
+}
+
 func (e *pluginEntry) setCacheTTLs(ttls decodedCacheTTLs) {
 	if ttls.List != 0 {
 		e.SetTTLOf(plugin.ListOp, ttls.List*time.Second)
@@ -405,6 +428,35 @@ func (e *pluginEntry) List(ctx context.Context) ([]plugin.Entry, error) {
 		}
 	}
 
+	return e.toEntries(ctx, decodedEntries)
+}
+
+const listPageFormat = "{\"entries\":[{\"name\":\"entry1\",\"methods\":[\"list\"]}],\"continuation_token\":\"token\"}"
+
+type decodedListPage struct {
+	Entries           []decodedExternalPluginEntry `json:"entries"`
+	ContinuationToken string                       `json:"continuation_token"`
+}
+
+// ListPage is only invoked on entries whose list method is paginated.
+func (e *pluginEntry) ListPage(ctx context.Context, limit int, continuationToken string) ([]plugin.Entry, string, error) {
+	inv, err := e.script.InvokeAndWait(ctx, "list", e, strconv.Itoa(limit), continuationToken)
+	if err != nil {
+		return nil, "", err
+	}
+	var page decodedListPage
+	if err := json.Unmarshal(inv.Stdout().Bytes(), &page); err != nil {
+		return nil, "", newStdoutDecodeErr(ctx, "the page", err, inv, listPageFormat)
+	}
+	entries, err := e.toEntries(ctx, page.Entries)
+	if err != nil {
+		return nil, "", err
+	}
+	return entries, page.ContinuationToken, nil
+}
+
+// toEntries creates the entries for the decoded children of e.
+func (e *pluginEntry) toEntries(ctx context.Context, decodedEntries []decodedExternalPluginEntry) ([]plugin.Entry, error) {
 	entries := make([]plugin.Entry, len(decodedEntries))
 	for i, decodedExternalPluginEntry := range decodedEntries {
 		if coreEnt, ok := coreEntries[decodedExternalPluginEntry.TypeID]; ok {
@@ -483,6 +535,64 @@ func (e *pluginEntry) Signal(ctx context.Context, signal string) error {
 	return err
 }
 
+func (e *pluginEntry) Rename(ctx context.Context, newName string) error {
+	_, err := e.script.InvokeAndWait(ctx, "rename", e, newName)
+	return err
+}
+
+func (e *pluginEntry) Copy(ctx context.Context, dstParent plugin.Parent, dstName string) error {
+	_, err := e.script.InvokeAndWait(ctx, "copy", e, plugin.ID(dstParent), dstName)
+	return err
+}
+
+func (e *pluginEntry) SetAttr(ctx context.Context, attr plugin.EntryAttributes) error {
+	attrJSON, err := json.Marshal(attr)
+	if err != nil {
+		return err
+	}
+	_, err = e.script.InvokeAndWait(ctx, "setattr", e, string(attrJSON))
+	return err
+}
+
+const createFormat = "{\"name\":\"entry1\",\"methods\":[\"read\"]}"
+
+func (e *pluginEntry) Create(ctx context.Context, name string, attr plugin.EntryAttributes) (plugin.Entry, error) {
+	attrJSON, err := json.Marshal(attr)
+	if err != nil {
+		return nil, err
+	}
+	inv, err := e.script.InvokeAndWait(ctx, "create", e, name, string(attrJSON))
+	if err != nil {
+		return nil, err
+	}
+	var decodedEntry decodedExternalPluginEntry
+	if err := json.Unmarshal(inv.Stdout().Bytes(), &decodedEntry); err != nil {
+		return nil, newStdoutDecodeErr(ctx, "the created entry", err, inv, createFormat)
+	}
+
+	entry, err := decodedEntry.toExternalPluginEntry(ctx, e.schemaKnown, false)
+	if err != nil {
+		return nil, err
+	}
+	entry.script = e.script
+	entry.schemaGraphs = e.schemaGraphs
+	return entry, nil
+}
+
+const searchFormat = "[{\"path\":\"foo/bar.log\",\"line\":3,\"text\":\"an error\"}]"
+
+func (e *pluginEntry) Search(ctx context.Context, pattern string) ([]plugin.SearchMatch, error) {
+	inv, err := e.script.InvokeAndWait(ctx, "search", e, pattern)
+	if err != nil {
+		return nil, err
+	}
+	var matches []plugin.SearchMatch
+	if err := json.Unmarshal(inv.Stdout().Bytes(), &matches); err != nil {
+		return nil, newStdoutDecodeErr(ctx, "the search's matches", err, inv, searchFormat)
+	}
+	return matches, nil
+}
+
 func (e *pluginEntry) Delete(ctx context.Context) (deleted bool, err error) {
 	inv, err := e.script.InvokeAndWait(ctx, "delete", e)
 	if err != nil {
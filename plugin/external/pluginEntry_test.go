@@ -195,6 +195,15 @@ func (suite *ExternalPluginEntryTestSuite) TestDecodeExternalPluginEntryWithCach
 	}
 }
 
+func (suite *ExternalPluginEntryTestSuite) TestDecodeExternalPluginEntryWithSymlinkTarget() {
+	decodedEntry := newMockDecodedEntry("name")
+	decodedEntry.SymlinkTarget = "../foo"
+	entry, err := decodedEntry.toExternalPluginEntry(context.Background(), false, false)
+	if suite.NoError(err) {
+		suite.Equal("../foo", entry.SymlinkTarget())
+	}
+}
+
 func (suite *ExternalPluginEntryTestSuite) TestDecodeExternalPluginEntryWithSlashReplacer() {
 	decodedEntry := newMockDecodedEntry("name/")
 	decodedEntry.SlashReplacer = "a string"
@@ -621,6 +630,57 @@ func (suite *ExternalPluginEntryTestSuite) TestBlockRead() {
 	}
 }
 
+func (suite *ExternalPluginEntryTestSuite) TestDecodeExternalPluginEntry_PaginatedList() {
+	decodedEntry := decodedExternalPluginEntry{
+		Name:    "decodedEntry",
+		Methods: rawMethods(`["list", {"paginated": true}]`),
+	}
+
+	entry, err := decodedEntry.toExternalPluginEntry(context.Background(), false, false)
+	if suite.NoError(err) {
+		suite.Equal(plugin.PaginatedSignature, entry.methods["list"].signature)
+		suite.Nil(entry.methods["list"].tupleValue)
+		suite.False(plugin.IsPrefetched(entry))
+	}
+}
+
+func (suite *ExternalPluginEntryTestSuite) TestListPage() {
+	mockScript := &mockPluginScript{path: "plugin_script"}
+	entry := &pluginEntry{
+		EntryBase: plugin.NewEntry("foo"),
+		script:    mockScript,
+	}
+	entry.SetTestID("/foo")
+
+	ctx := context.Background()
+	mockInvokeAndWait := func(stdout []byte, err error) {
+		mockScript.OnInvokeAndWait(ctx, "list", entry, "10", "token").Return(mockInvocation(stdout), err).Once()
+	}
+
+	// Test that if InvokeAndWait errors, then ListPage returns its error
+	mockErr := fmt.Errorf("execution error")
+	mockInvokeAndWait([]byte{}, mockErr)
+	_, _, err := entry.ListPage(ctx, 10, "token")
+	suite.EqualError(err, mockErr.Error())
+
+	// Test that ListPage returns an error if stdout does not have the right
+	// output format
+	mockInvokeAndWait([]byte("[]"), nil)
+	_, _, err = entry.ListPage(ctx, 10, "token")
+	suite.Regexp(regexp.MustCompile("stdout"), err)
+
+	// Test that ListPage properly decodes the page from stdout
+	stdout := `{"entries": [{"name": "bar", "methods": ["read"]}], "continuation_token": "next"}`
+	mockInvokeAndWait([]byte(stdout), nil)
+	entries, nextToken, err := entry.ListPage(ctx, 10, "token")
+	if suite.NoError(err) {
+		if suite.Equal(1, len(entries)) {
+			suite.Equal("bar", plugin.Name(entries[0]))
+		}
+		suite.Equal("next", nextToken)
+	}
+}
+
 func (suite *ExternalPluginEntryTestSuite) TestListReadWithMethodResults() {
 	mockScript := &mockPluginScript{path: "plugin_script"}
 	entry := &pluginEntry{
@@ -1096,6 +1156,44 @@ func (suite *ExternalPluginEntryTestSuite) TestDelete() {
 	}
 }
 
+func (suite *ExternalPluginEntryTestSuite) TestSearch() {
+	mockScript := &mockPluginScript{path: "plugin_script"}
+	entry := &pluginEntry{
+		EntryBase: plugin.NewEntry("foo"),
+		methods:   map[string]methodInfo{"search": methodInfo{}},
+		script:    mockScript,
+	}
+	entry.SetTestID("/foo")
+
+	ctx := context.Background()
+	mockInvokeAndWait := func(stdout []byte, err error) {
+		mockScript.OnInvokeAndWait(ctx, "search", entry, "err.r").Return(mockInvocation(stdout), err).Once()
+	}
+
+	// Test that if InvokeAndWait errors, then Search returns its error
+	mockErr := fmt.Errorf("execution error")
+	mockInvokeAndWait([]byte{}, mockErr)
+	_, err := entry.Search(ctx, "err.r")
+	suite.EqualError(err, mockErr.Error())
+
+	// Test that Search returns an error if stdout does not have the right
+	// output format
+	mockInvokeAndWait([]byte("bad format"), nil)
+	_, err = entry.Search(ctx, "err.r")
+	suite.Regexp(regexp.MustCompile("stdout"), err)
+
+	// Test that Search decodes the matches from stdout
+	mockInvokeAndWait([]byte(`[{"path":"bar.log","line":3,"text":"an error"},{"text":"another error"}]`), nil)
+	matches, err := entry.Search(ctx, "err.r")
+	if suite.NoError(err) {
+		expected := []plugin.SearchMatch{
+			{Path: "bar.log", Line: 3, Text: "an error"},
+			{Text: "another error"},
+		}
+		suite.Equal(expected, matches)
+	}
+}
+
 // TODO: Add tests for stdoutStreamer, Stream and Exec
 // once the API for Stream and Exec's at a more stable
 // state.
@@ -13,6 +13,7 @@ import (
 // pluginRoot represents an external plugin's root.
 type pluginRoot struct {
 	pluginEntry
+	shellHelpers []plugin.ShellHelper
 }
 
 // Init initializes the external plugin root
@@ -75,6 +76,21 @@ it's safe to omit name from the response to 'init'`, r.script.Path()))
 		r.schemaGraphs = r.partitionSchemaGraph(val.(*linkedhashmap.Map))
 	}
 
+	// Shell helpers are part of the init response, but not of the root entry.
+	var decodedHelpers struct {
+		ShellHelpers []plugin.ShellHelper `json:"shell_helpers"`
+	}
+	if err := json.Unmarshal(inv.Stdout().Bytes(), &decodedHelpers); err != nil {
+		return newStdoutDecodeErr(
+			context.Background(),
+			"the plugin's shell helpers",
+			err,
+			inv,
+			`{"shell_helpers":[{"name":"helper","command":"ls myplugin"}]}`,
+		)
+	}
+	r.shellHelpers = decodedHelpers.ShellHelpers
+
 	return nil
 }
 
@@ -84,6 +100,11 @@ func (r *pluginRoot) WrappedTypes() plugin.SchemaMap {
 	return nil
 }
 
+// ShellHelpers returns the shell helpers from the plugin's init response
+func (r *pluginRoot) ShellHelpers() []plugin.ShellHelper {
+	return r.shellHelpers
+}
+
 // partitionSchemaGraph partitions graph into a map of <type_id> => <schema_graph>
 func (r *pluginRoot) partitionSchemaGraph(graph *linkedhashmap.Map) map[string]*linkedhashmap.Map {
 	var populate func(*linkedhashmap.Map, string, plugin.EntrySchema, map[string]bool)
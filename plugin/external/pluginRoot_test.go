@@ -19,7 +19,7 @@ type ExternalPluginRootTestSuite struct {
 
 func (suite *ExternalPluginRootTestSuite) TestInit() {
 	mockScript := &mockPluginScript{path: "plugin_script"}
-	root := &pluginRoot{pluginEntry{
+	root := &pluginRoot{pluginEntry: pluginEntry{
 		EntryBase: plugin.NewEntry("foo"),
 		script:    mockScript,
 	}}
@@ -67,7 +67,7 @@ func (suite *ExternalPluginRootTestSuite) TestInit() {
 
 func (suite *ExternalPluginRootTestSuite) TestInitWithConfig() {
 	mockScript := &mockPluginScript{path: "plugin_script"}
-	root := &pluginRoot{pluginEntry{
+	root := &pluginRoot{pluginEntry: pluginEntry{
 		EntryBase: plugin.NewEntry("foo"),
 		script:    mockScript,
 	}}
@@ -82,9 +82,31 @@ func (suite *ExternalPluginRootTestSuite) TestInitWithConfig() {
 	suite.NoError(root.Init(map[string]interface{}{"key": []string{"value"}}))
 }
 
+func (suite *ExternalPluginRootTestSuite) TestInitWithShellHelpers() {
+	mockScript := &mockPluginScript{path: "plugin_script"}
+	root := &pluginRoot{pluginEntry: pluginEntry{
+		EntryBase: plugin.NewEntry("foo"),
+		script:    mockScript,
+	}}
+
+	stdout := `{"shell_helpers":[{"name":"foops","command":"wps foo"}]}`
+	mockScript.OnInvokeAndWait(
+		mock.Anything,
+		"init",
+		nil,
+		"{}",
+	).Return(mockInvocation([]byte(stdout)), nil).Once()
+
+	if suite.NoError(root.Init(nil)) {
+		suite.Equal([]plugin.ShellHelper{
+			{Name: "foops", Command: "wps foo"},
+		}, root.ShellHelpers())
+	}
+}
+
 func (suite *ExternalPluginRootTestSuite) TestInitWithSchema_SetsSchemaKnownVariable() {
 	mockScript := &mockPluginScript{path: "plugin_script"}
-	root := &pluginRoot{pluginEntry{
+	root := &pluginRoot{pluginEntry: pluginEntry{
 		EntryBase: plugin.NewEntry("foo"),
 		script:    mockScript,
 	}}
@@ -102,7 +124,7 @@ func (suite *ExternalPluginRootTestSuite) TestInitWithSchema_SetsSchemaKnownVari
 
 func (suite *ExternalPluginRootTestSuite) TestInitWithSchema_PrefetchedSchema_ReturnsErrorIfUnmarshallingSchemaFails() {
 	mockScript := &mockPluginScript{path: "plugin_script"}
-	root := &pluginRoot{pluginEntry{
+	root := &pluginRoot{pluginEntry: pluginEntry{
 		EntryBase: plugin.NewEntry("foo"),
 		script:    mockScript,
 	}}
@@ -123,7 +145,7 @@ func (suite *ExternalPluginRootTestSuite) TestInitWithSchema_PrefetchedSchema_Re
 
 func (suite *ExternalPluginRootTestSuite) TestInitWithSchema_PrefetchedSchema_PartitionsSchemaGraph() {
 	mockScript := &mockPluginScript{path: "plugin_script"}
-	root := &pluginRoot{pluginEntry{
+	root := &pluginRoot{pluginEntry: pluginEntry{
 		EntryBase: plugin.NewEntry("fooPlugin"),
 		script:    mockScript,
 	}}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/tracing"
 )
 
 // pluginScript represents an external plugin's script
@@ -78,6 +79,8 @@ func newInvokeError(msg string, inv invocation) error {
 
 type externalPluginScriptImpl struct {
 	path string
+	// debug dumps each invocation when set.
+	debug *debugDumper
 }
 
 func (s externalPluginScriptImpl) Path() string {
@@ -92,8 +95,11 @@ func (s externalPluginScriptImpl) InvokeAndWait(
 	entry *pluginEntry,
 	args ...string,
 ) (invocation, error) {
+	ctx, span := tracing.Start(ctx, "external: "+method, tracing.String("wash.command", s.Path()))
+	defer span.End()
 	inv := s.NewInvocation(ctx, method, entry, args...)
 	err := inv.RunAndWait(ctx)
+	span.RecordError(err)
 	return inv, err
 }
 
@@ -104,15 +110,17 @@ func (s externalPluginScriptImpl) NewInvocation(
 	args ...string,
 ) invocation {
 	if method == "init" {
-		return &invocationImpl{Command: NewCommand(ctx, s.Path(), append([]string{"init"}, args...)...)}
+		args = append([]string{"init"}, args...)
+	} else {
+		if entry == nil {
+			msg := fmt.Sprintf("s.NewInvocation called with method '%v' and entry == nil", method)
+			panic(msg)
+		}
+		args = append([]string{method, plugin.ID(entry), entry.state}, args...)
 	}
-	if entry == nil {
-		msg := fmt.Sprintf("s.NewInvocation called with method '%v' and entry == nil", method)
-		panic(msg)
+	cmd := NewCommand(ctx, s.Path(), args...)
+	if s.debug != nil {
+		cmd = s.debug.wrap(method, append([]string{s.Path()}, args...), cmd)
 	}
-	return &invocationImpl{Command: NewCommand(
-		ctx,
-		s.Path(),
-		append([]string{method, plugin.ID(entry), entry.state}, args...)...,
-	)}
+	return &invocationImpl{Command: cmd}
 }
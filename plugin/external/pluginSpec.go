@@ -22,6 +22,15 @@ func (s PluginSpec) Name() string {
 
 // Load ensures the external plugin represents an executable artifact and create a plugin Root.
 func (s PluginSpec) Load() (plugin.Root, error) {
+	return s.LoadWithDebugDump("")
+}
+
+// LoadWithDebugDump is like Load, except that each of the plugin's
+// invocations are also recorded to their own directory in debugDir when it
+// isn't empty. The directory contains the invocation's argv, stdin, stdout,
+// stderr and timing. Each stream is capped at 1 MB, and the oldest
+// invocations are removed once debugDir exceeds 100 MB.
+func (s PluginSpec) LoadWithDebugDump(debugDir string) (plugin.Root, error) {
 	fi, err := os.Stat(s.Script)
 	if err != nil {
 		return nil, err
@@ -31,9 +40,15 @@ func (s PluginSpec) Load() (plugin.Root, error) {
 		return nil, fmt.Errorf("script %v is not executable", s.Script)
 	}
 
-	root := &pluginRoot{pluginEntry{
+	script := externalPluginScriptImpl{path: s.Script}
+	if debugDir != "" {
+		if script.debug, err = newDebugDumper(debugDir); err != nil {
+			return nil, fmt.Errorf("could not create the debug directory: %v", err)
+		}
+	}
+	root := &pluginRoot{pluginEntry: pluginEntry{
 		EntryBase: plugin.NewEntry(s.Name()),
-		script:    externalPluginScriptImpl{path: s.Script},
+		script:    script,
 	}}
 	return root, nil
 }
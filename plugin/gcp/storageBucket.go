@@ -73,6 +73,10 @@ func (s *storageBucket) List(ctx context.Context) ([]plugin.Entry, error) {
 	return listBucket(ctx, bucket, "")
 }
 
+func (s *storageBucket) Create(ctx context.Context, name string, attr plugin.EntryAttributes) (plugin.Entry, error) {
+	return createObject(ctx, s.Bucket(s.Name()), "", name, attr)
+}
+
 func (s *storageBucket) Delete(ctx context.Context) (bool, error) {
 	// GCP only deletes empty buckets, so we'll need to delete all of its
 	// objects before deleting the bucket.
@@ -132,6 +136,29 @@ func listBucket(ctx context.Context, bucket *storage.BucketHandle, prefix string
 	return entries, nil
 }
 
+// createObject creates an empty object named name under the prefix. If attr's mode is a
+// directory, then it creates a "<name>/" object instead, which is how the Cloud Console
+// creates folders, and returns the corresponding prefix.
+func createObject(ctx context.Context, bucket *storage.BucketHandle, prefix string, name string, attr plugin.EntryAttributes) (plugin.Entry, error) {
+	key := prefix + name
+	isDir := attr.HasMode() && attr.Mode().IsDir()
+	if isDir {
+		key += delimiter
+	}
+	// The precondition fails the write if the object already exists.
+	obj := bucket.Object(key).If(storage.Conditions{DoesNotExist: true})
+	wr := obj.NewWriter(ctx)
+	if err := wr.Close(); err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Created %v", key)
+
+	if isDir {
+		return newStorageObjectPrefix(bucket, name, key, wr.Attrs()), nil
+	}
+	return newStorageObject(name, bucket.Object(key), wr.Attrs()), nil
+}
+
 func deleteObjects(ctx context.Context, bucket *storage.BucketHandle, prefix string) error {
 	// Unfortunately, GCP doesn't have a BatchDelete endpoint so we will have to
 	// delete each object one at a time.
@@ -2,6 +2,7 @@ package gcp
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 
 	"cloud.google.com/go/storage"
@@ -61,6 +62,26 @@ func (s *storageObject) Delete(ctx context.Context) (bool, error) {
 	return true, err
 }
 
+// Copy copies the object to a bucket or a prefix. Storage copies it, so its
+// content isn't downloaded.
+func (s *storageObject) Copy(ctx context.Context, dstParent plugin.Parent, dstName string) error {
+	var dst *storage.ObjectHandle
+	switch p := dstParent.(type) {
+	case *storageBucket:
+		dst = p.Bucket(p.Name()).Object(dstName)
+	case *storageObjectPrefix:
+		dst = p.bucket.Object(p.prefix + dstName)
+	default:
+		return fmt.Errorf("objects can only be copied to a bucket or a prefix, not %v", plugin.ID(dstParent))
+	}
+	attrs, err := dst.CopierFrom(s.ObjectHandle).Run(ctx)
+	if err != nil {
+		return err
+	}
+	activity.Record(ctx, "Copied %v to %v/%v", s.ObjectName(), attrs.Bucket, attrs.Name)
+	return nil
+}
+
 const storageObjectDescription = `
 This is a Storage object. See the bucket's docs for more details
 on why we have this kind of entry.
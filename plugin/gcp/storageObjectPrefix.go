@@ -38,6 +38,10 @@ func (s *storageObjectPrefix) List(ctx context.Context) ([]plugin.Entry, error)
 	return listBucket(ctx, s.bucket, s.prefix)
 }
 
+func (s *storageObjectPrefix) Create(ctx context.Context, name string, attr plugin.EntryAttributes) (plugin.Entry, error) {
+	return createObject(ctx, s.bucket, s.prefix, name, attr)
+}
+
 func (s *storageObjectPrefix) Delete(ctx context.Context) (bool, error) {
 	err := deleteObjects(ctx, s.bucket, s.prefix)
 	return true, err
@@ -0,0 +1,90 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type clusterMetadata struct {
+	Brokers []string `json:"brokers"`
+	Version string   `json:"version"`
+}
+
+// cluster represents a configured Kafka cluster
+type cluster struct {
+	plugin.EntryBase
+	cfg    clusterConfig
+	mux    sync.Mutex
+	client sarama.Client
+	admin  sarama.ClusterAdmin
+}
+
+func newCluster(name string, cfg clusterConfig) *cluster {
+	c := &cluster{
+		EntryBase: plugin.NewEntry(name),
+	}
+	c.cfg = cfg
+	c.DisableDefaultCaching()
+	c.SetPartialMetadata(clusterMetadata{
+		Brokers: cfg.brokers,
+		Version: cfg.version.String(),
+	})
+	return c
+}
+
+func (c *cluster) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(c, "cluster").
+		SetPartialMetadataSchema(clusterMetadata{})
+}
+
+func (c *cluster) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&topicsDir{}).Schema(),
+		(&consumerGroupsDir{}).Schema(),
+	}
+}
+
+// List lists the topics and consumer groups directories
+func (c *cluster) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{
+		newTopicsDir(c),
+		newConsumerGroupsDir(c),
+	}, nil
+}
+
+// getClient returns the cluster's client, connecting to the brokers if needed.
+func (c *cluster) getClient(ctx context.Context) (sarama.Client, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.client != nil && !c.client.Closed() {
+		return c.client, nil
+	}
+	activity.Record(ctx, "Connecting to Kafka brokers %v", c.cfg.brokers)
+	client, err := sarama.NewClient(c.cfg.brokers, c.cfg.saramaConfig())
+	if err != nil {
+		return nil, err
+	}
+	c.client = client
+	return client, nil
+}
+
+// getAdmin returns the cluster's admin client, connecting to the brokers if needed.
+func (c *cluster) getAdmin(ctx context.Context) (sarama.ClusterAdmin, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.admin != nil {
+		return c.admin, nil
+	}
+	activity.Record(ctx, "Connecting to Kafka brokers %v as an admin", c.cfg.brokers)
+	admin, err := sarama.NewClusterAdmin(c.cfg.brokers, c.cfg.saramaConfig())
+	if err != nil {
+		return nil, err
+	}
+	c.admin = admin
+	return admin, nil
+}
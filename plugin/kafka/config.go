@@ -0,0 +1,84 @@
+package kafka
+
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+)
+
+type clusterConfig struct {
+	brokers []string
+	version sarama.KafkaVersion
+	// startOffset is either sarama.OffsetNewest, sarama.OffsetOldest, or
+	// a negative number of messages back from the newest offset.
+	startOffset int64
+	// relativeOffset is true if startOffset is relative to the newest offset.
+	relativeOffset bool
+}
+
+// parseClusterConfig parses one of the kafka.clusters configs
+func parseClusterConfig(cfgI interface{}) (clusterConfig, error) {
+	cfg := clusterConfig{
+		version:     sarama.V2_0_0_0,
+		startOffset: sarama.OffsetNewest,
+	}
+	m, ok := cfgI.(map[string]interface{})
+	if !ok {
+		return cfg, fmt.Errorf("expected a map, not %v", cfgI)
+	}
+
+	brokers, ok := m["brokers"].([]interface{})
+	if !ok || len(brokers) == 0 {
+		return cfg, fmt.Errorf("brokers must be a non-empty array of strings, not %v", m["brokers"])
+	}
+	for _, elem := range brokers {
+		broker, ok := elem.(string)
+		if !ok {
+			return cfg, fmt.Errorf("brokers must be a non-empty array of strings, not %v", brokers)
+		}
+		cfg.brokers = append(cfg.brokers, broker)
+	}
+
+	if versionI, ok := m["version"]; ok {
+		versionStr, ok := versionI.(string)
+		if !ok {
+			return cfg, fmt.Errorf("version must be a string, not %v", versionI)
+		}
+		version, err := sarama.ParseKafkaVersion(versionStr)
+		if err != nil {
+			return cfg, fmt.Errorf("version is invalid: %v", err)
+		}
+		cfg.version = version
+	}
+
+	if offsetI, ok := m["start_offset"]; ok {
+		switch offset := offsetI.(type) {
+		case string:
+			switch offset {
+			case "newest":
+				cfg.startOffset = sarama.OffsetNewest
+			case "oldest":
+				cfg.startOffset = sarama.OffsetOldest
+			default:
+				return cfg, fmt.Errorf("start_offset must be 'newest', 'oldest', or a negative number, not %v", offset)
+			}
+		case int:
+			if offset >= 0 {
+				return cfg, fmt.Errorf("start_offset must be 'newest', 'oldest', or a negative number, not %v", offset)
+			}
+			cfg.startOffset = int64(offset)
+			cfg.relativeOffset = true
+		default:
+			return cfg, fmt.Errorf("start_offset must be 'newest', 'oldest', or a negative number, not %v", offset)
+		}
+	}
+	return cfg, nil
+}
+
+func (c clusterConfig) saramaConfig() *sarama.Config {
+	cfg := sarama.NewConfig()
+	cfg.ClientID = "wash"
+	cfg.Version = c.version
+	cfg.Producer.Return.Successes = true
+	return cfg
+}
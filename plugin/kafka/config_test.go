@@ -0,0 +1,108 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseClusterConfig(t *testing.T) {
+	brokers := []interface{}{"kafka1:9092", "kafka2:9092"}
+	cases := []struct {
+		name     string
+		cfg      interface{}
+		expected clusterConfig
+	}{
+		{
+			"defaults",
+			map[string]interface{}{"brokers": brokers},
+			clusterConfig{brokers: []string{"kafka1:9092", "kafka2:9092"}, version: sarama.V2_0_0_0, startOffset: sarama.OffsetNewest},
+		},
+		{
+			"version",
+			map[string]interface{}{"brokers": brokers, "version": "1.1.0"},
+			clusterConfig{brokers: []string{"kafka1:9092", "kafka2:9092"}, version: sarama.V1_1_0_0, startOffset: sarama.OffsetNewest},
+		},
+		{
+			"oldest start_offset",
+			map[string]interface{}{"brokers": brokers, "start_offset": "oldest"},
+			clusterConfig{brokers: []string{"kafka1:9092", "kafka2:9092"}, version: sarama.V2_0_0_0, startOffset: sarama.OffsetOldest},
+		},
+		{
+			"relative start_offset",
+			map[string]interface{}{"brokers": brokers, "start_offset": -100},
+			clusterConfig{brokers: []string{"kafka1:9092", "kafka2:9092"}, version: sarama.V2_0_0_0, startOffset: -100, relativeOffset: true},
+		},
+	}
+	for _, c := range cases {
+		cfg, err := parseClusterConfig(c.cfg)
+		if assert.NoError(t, err, c.name) {
+			assert.Equal(t, c.expected, cfg, c.name)
+		}
+	}
+}
+
+func TestParseClusterConfig_Errors(t *testing.T) {
+	brokers := []interface{}{"kafka1:9092"}
+	offsetErr := "start_offset must be 'newest', 'oldest', or a negative number, not "
+	cases := []struct {
+		cfg      interface{}
+		expected string
+	}{
+		{"kafka1:9092", "expected a map, not kafka1:9092"},
+		{map[string]interface{}{}, "brokers must be a non-empty array of strings, not <nil>"},
+		{map[string]interface{}{"brokers": []interface{}{}}, "brokers must be a non-empty array of strings, not []"},
+		{map[string]interface{}{"brokers": []interface{}{9092}}, "brokers must be a non-empty array of strings, not [9092]"},
+		{map[string]interface{}{"brokers": brokers, "version": 2}, "version must be a string, not 2"},
+		{map[string]interface{}{"brokers": brokers, "start_offset": "latest"}, offsetErr + "latest"},
+		{map[string]interface{}{"brokers": brokers, "start_offset": 0}, offsetErr + "0"},
+		{map[string]interface{}{"brokers": brokers, "start_offset": 100}, offsetErr + "100"},
+		{map[string]interface{}{"brokers": brokers, "start_offset": -1.5}, offsetErr + "-1.5"},
+	}
+	for _, c := range cases {
+		_, err := parseClusterConfig(c.cfg)
+		assert.EqualError(t, err, c.expected)
+	}
+
+	_, err := parseClusterConfig(map[string]interface{}{"brokers": brokers, "version": "foo"})
+	assert.Error(t, err)
+}
+
+func TestSaramaConfig(t *testing.T) {
+	cfg := clusterConfig{version: sarama.V1_1_0_0}.saramaConfig()
+	assert.Equal(t, "wash", cfg.ClientID)
+	assert.Equal(t, sarama.V1_1_0_0, cfg.Version)
+	assert.True(t, cfg.Producer.Return.Successes)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestInit(t *testing.T) {
+	r := &Root{}
+	assert.NoError(t, r.Init(map[string]interface{}{}))
+	assert.Empty(t, r.clusters)
+
+	err := r.Init(map[string]interface{}{
+		"clusters": map[string]interface{}{
+			"prod": map[string]interface{}{"brokers": []interface{}{"kafka:9092"}},
+			"dev":  map[string]interface{}{"brokers": []interface{}{"localhost:9092"}},
+		},
+	})
+	if assert.NoError(t, err) {
+		entries, err := r.List(context.Background())
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, "dev", plugin.Name(entries[0]))
+			assert.Equal(t, "prod", plugin.Name(entries[1]))
+		}
+	}
+
+	err = r.Init(map[string]interface{}{
+		"clusters": map[string]interface{}{
+			"prod": map[string]interface{}{"brokers": []interface{}{"kafka:9092"}, "start_offset": 5},
+		},
+	})
+	assert.EqualError(t, err, "kafka.clusters.prod config is invalid: start_offset must be 'newest', 'oldest', or a negative number, not 5")
+}
@@ -0,0 +1,183 @@
+package kafka
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Shopify/sarama"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type consumerGroupsDir struct {
+	plugin.EntryBase
+	cluster *cluster
+}
+
+func newConsumerGroupsDir(c *cluster) *consumerGroupsDir {
+	consumerGroupsDir := &consumerGroupsDir{
+		EntryBase: plugin.NewEntry("consumer_groups"),
+	}
+	consumerGroupsDir.cluster = c
+	return consumerGroupsDir
+}
+
+func (gs *consumerGroupsDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(gs, "consumer_groups").IsSingleton()
+}
+
+func (gs *consumerGroupsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&consumerGroup{}).Schema(),
+	}
+}
+
+// List lists the cluster's consumer groups
+func (gs *consumerGroupsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	admin, err := gs.cluster.getAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := admin.ListConsumerGroups()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	descriptions, err := admin.DescribeConsumerGroups(names)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, 0, len(descriptions))
+	for _, desc := range descriptions {
+		entries = append(entries, newConsumerGroup(gs.cluster, desc))
+	}
+	return entries, nil
+}
+
+type consumerGroupInfo struct {
+	State        string `json:"state"`
+	ProtocolType string `json:"protocol_type"`
+	Protocol     string `json:"protocol"`
+	Members      int    `json:"members"`
+}
+
+type partitionOffset struct {
+	Partition       int32 `json:"partition"`
+	CommittedOffset int64 `json:"committed_offset"`
+	NewestOffset    int64 `json:"newest_offset"`
+	Lag             int64 `json:"lag"`
+}
+
+type consumerGroupMetadata struct {
+	consumerGroupInfo
+	MemberInfo []memberInfo                 `json:"member_info"`
+	Offsets    map[string][]partitionOffset `json:"offsets"`
+	TotalLag   int64                        `json:"total_lag"`
+}
+
+type memberInfo struct {
+	ID       string `json:"id"`
+	ClientID string `json:"client_id"`
+	Host     string `json:"host"`
+}
+
+type consumerGroup struct {
+	plugin.EntryBase
+	cluster *cluster
+}
+
+func newConsumerGroup(c *cluster, desc *sarama.GroupDescription) *consumerGroup {
+	g := &consumerGroup{
+		EntryBase: plugin.NewEntry(desc.GroupId),
+	}
+	g.cluster = c
+	g.SetPartialMetadata(groupInfo(desc))
+	return g
+}
+
+func groupInfo(desc *sarama.GroupDescription) consumerGroupInfo {
+	return consumerGroupInfo{
+		State:        desc.State,
+		ProtocolType: desc.ProtocolType,
+		Protocol:     desc.Protocol,
+		Members:      len(desc.Members),
+	}
+}
+
+func (g *consumerGroup) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(g, "consumer_group").
+		SetDescription(consumerGroupDescription).
+		SetPartialMetadataSchema(consumerGroupInfo{}).
+		SetMetadataSchema(consumerGroupMetadata{})
+}
+
+// Metadata includes the group's members and each partition's committed
+// offset and lag.
+func (g *consumerGroup) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	admin, err := g.cluster.getAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client, err := g.cluster.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions, err := admin.DescribeConsumerGroups([]string{g.Name()})
+	if err != nil {
+		return nil, err
+	}
+	var meta consumerGroupMetadata
+	if len(descriptions) > 0 {
+		meta.consumerGroupInfo = groupInfo(descriptions[0])
+		for id, member := range descriptions[0].Members {
+			meta.MemberInfo = append(meta.MemberInfo, memberInfo{
+				ID:       id,
+				ClientID: member.ClientId,
+				Host:     member.ClientHost,
+			})
+		}
+	}
+
+	resp, err := admin.ListConsumerGroupOffsets(g.Name(), nil)
+	if err != nil {
+		return nil, err
+	}
+	meta.Offsets = make(map[string][]partitionOffset)
+	for topic, partitions := range resp.Blocks {
+		for partition, block := range partitions {
+			if block.Offset < 0 {
+				// No committed offset
+				continue
+			}
+			offset := partitionOffset{
+				Partition:       partition,
+				CommittedOffset: block.Offset,
+			}
+			if offset.NewestOffset, err = client.GetOffset(topic, partition, sarama.OffsetNewest); err != nil {
+				return nil, err
+			}
+			offset.Lag = offset.NewestOffset - offset.CommittedOffset
+			meta.TotalLag += offset.Lag
+			meta.Offsets[topic] = append(meta.Offsets[topic], offset)
+		}
+		sort.Slice(meta.Offsets[topic], func(i, j int) bool {
+			return meta.Offsets[topic][i].Partition < meta.Offsets[topic][j].Partition
+		})
+	}
+	return plugin.ToJSONObject(meta), nil
+}
+
+const consumerGroupDescription = `
+This is a Kafka consumer group. Its metadata includes the group's members
+and, for each topic that it consumes, each partition's committed offset and
+lag. The total lag is also included, so you can find lagging groups with
+something like
+
+  find kafka/local/consumer_groups -meta .total_lag +1000
+`
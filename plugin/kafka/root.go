@@ -0,0 +1,78 @@
+// Package kafka presents a filesystem hierarchy for Kafka clusters.
+//
+// Clusters are configured in wash.yaml. Each cluster exposes its topics,
+// which can be tailed and written to, and its consumer groups.
+package kafka
+
+import (
+	"context"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Root of the kafka plugin
+type Root struct {
+	plugin.EntryBase
+	clusters []plugin.Entry
+}
+
+// Init for root
+func (r *Root) Init(cfg map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("kafka")
+	r.DisableDefaultCaching()
+
+	r.clusters = []plugin.Entry{}
+	return plugin.ParseNamedConfigs("kafka", cfg, "clusters", func(name string, config interface{}) error {
+		clusterCfg, err := parseClusterConfig(config)
+		if err != nil {
+			return err
+		}
+		r.clusters = append(r.clusters, newCluster(name, clusterCfg))
+		return nil
+	})
+}
+
+// Schema returns the root's schema
+func (r *Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "kafka").
+		SetDescription(rootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&cluster{}).Schema(),
+	}
+}
+
+// List lists the configured clusters.
+func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	return r.clusters, nil
+}
+
+const rootDescription = `
+This is the kafka plugin root. It lists the Kafka clusters configured in
+wash.yaml, e.g.
+
+  kafka:
+    clusters:
+      local:
+        brokers: [localhost:9092]
+      prod:
+        brokers: [kafka-1:9092, kafka-2:9092]
+        version: 2.3.0
+        start_offset: -100
+
+Each cluster includes its topics and its consumer groups. Topics include
+their partitions and offsets as metadata. Tailing a topic prints its
+messages, starting from 'start_offset' (one of 'newest', 'oldest', or a
+negative number of messages back from the newest offset in each partition;
+defaults to 'newest'). Writing to a topic produces a message, e.g.
+
+  echo 'hello' > kafka/local/topics/greetings
+
+Consumer groups include each partition's committed offset and lag as
+metadata.
+`
@@ -0,0 +1,226 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type topicInfo struct {
+	Partitions int `json:"partitions"`
+}
+
+type partitionMetadata struct {
+	ID           int32   `json:"id"`
+	Leader       string  `json:"leader"`
+	Replicas     []int32 `json:"replicas"`
+	ISR          []int32 `json:"isr"`
+	OldestOffset int64   `json:"oldest_offset"`
+	NewestOffset int64   `json:"newest_offset"`
+}
+
+type topicMetadata struct {
+	topicInfo
+	Messages      int64               `json:"messages"`
+	PartitionInfo []partitionMetadata `json:"partition_info"`
+	Config        map[string]string   `json:"config"`
+}
+
+type topic struct {
+	plugin.EntryBase
+	cluster *cluster
+}
+
+func newTopic(c *cluster, name string, partitions int) *topic {
+	t := &topic{
+		EntryBase: plugin.NewEntry(name),
+	}
+	t.cluster = c
+	t.SetPartialMetadata(topicInfo{Partitions: partitions})
+	return t
+}
+
+func (t *topic) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(t, "topic").
+		SetDescription(topicDescription).
+		SetPartialMetadataSchema(topicInfo{}).
+		SetMetadataSchema(topicMetadata{})
+}
+
+// Metadata includes each partition's replicas and offsets, and the topic's config.
+func (t *topic) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	client, err := t.cluster.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	partitions, err := client.Partitions(t.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	meta := topicMetadata{topicInfo: topicInfo{Partitions: len(partitions)}}
+	for _, id := range partitions {
+		p := partitionMetadata{ID: id}
+		if leader, err := client.Leader(t.Name(), id); err == nil {
+			p.Leader = leader.Addr()
+		}
+		p.Replicas, _ = client.Replicas(t.Name(), id)
+		p.ISR, _ = client.InSyncReplicas(t.Name(), id)
+		if p.OldestOffset, err = client.GetOffset(t.Name(), id, sarama.OffsetOldest); err != nil {
+			return nil, err
+		}
+		if p.NewestOffset, err = client.GetOffset(t.Name(), id, sarama.OffsetNewest); err != nil {
+			return nil, err
+		}
+		meta.Messages += p.NewestOffset - p.OldestOffset
+		meta.PartitionInfo = append(meta.PartitionInfo, p)
+	}
+
+	admin, err := t.cluster.getAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := admin.DescribeConfig(sarama.ConfigResource{
+		Type: sarama.TopicResource,
+		Name: t.Name(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	meta.Config = make(map[string]string)
+	for _, entry := range entries {
+		meta.Config[entry.Name] = entry.Value
+	}
+	return plugin.ToJSONObject(meta), nil
+}
+
+// Stream consumes all of the topic's partitions, starting from the cluster's
+// configured start_offset. Each message is written on its own line.
+func (t *topic) Stream(ctx context.Context) (io.ReadCloser, error) {
+	client, err := t.cluster.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return nil, err
+	}
+	partitions, err := client.Partitions(t.Name())
+	if err != nil {
+		consumer.Close()
+		return nil, err
+	}
+
+	var pcs []sarama.PartitionConsumer
+	for _, id := range partitions {
+		offset, err := t.startOffset(client, id)
+		if err == nil {
+			var pc sarama.PartitionConsumer
+			if pc, err = consumer.ConsumePartition(t.Name(), id, offset); err == nil {
+				pcs = append(pcs, pc)
+				continue
+			}
+		}
+		for _, pc := range pcs {
+			pc.Close()
+		}
+		consumer.Close()
+		return nil, fmt.Errorf("could not consume partition %v: %v", id, err)
+	}
+	activity.Record(ctx, "Consuming %v partitions of %v", len(pcs), t.Name())
+
+	r, w := io.Pipe()
+	// Messages from all of the partitions are written to the same pipe,
+	// so serialize the writes.
+	var writeMux sync.Mutex
+	var wg sync.WaitGroup
+	for _, pc := range pcs {
+		wg.Add(1)
+		go func(pc sarama.PartitionConsumer) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case msg := <-pc.Messages():
+					writeMux.Lock()
+					_, err := w.Write(append(msg.Value, '\n'))
+					writeMux.Unlock()
+					if err != nil {
+						// The reader was closed.
+						return
+					}
+				}
+			}
+		}(pc)
+	}
+	go func() {
+		wg.Wait()
+		for _, pc := range pcs {
+			pc.Close()
+		}
+		consumer.Close()
+		activity.Record(ctx, "Closing write pipe: %v", w.CloseWithError(ctx.Err()))
+	}()
+	return r, nil
+}
+
+func (t *topic) startOffset(client sarama.Client, partition int32) (int64, error) {
+	if !t.cluster.cfg.relativeOffset {
+		return t.cluster.cfg.startOffset, nil
+	}
+	oldest, err := client.GetOffset(t.Name(), partition, sarama.OffsetOldest)
+	if err != nil {
+		return 0, err
+	}
+	newest, err := client.GetOffset(t.Name(), partition, sarama.OffsetNewest)
+	if err != nil {
+		return 0, err
+	}
+	if offset := newest + t.cluster.cfg.startOffset; offset > oldest {
+		return offset, nil
+	}
+	return oldest, nil
+}
+
+// Write produces a message whose value is data.
+func (t *topic) Write(ctx context.Context, data []byte) error {
+	client, err := t.cluster.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		return err
+	}
+	defer producer.Close()
+
+	partition, offset, err := producer.SendMessage(&sarama.ProducerMessage{
+		Topic: t.Name(),
+		Value: sarama.ByteEncoder(data),
+	})
+	if err != nil {
+		return err
+	}
+	activity.Record(ctx, "Produced a message to %v at partition %v, offset %v", t.Name(), partition, offset)
+	return nil
+}
+
+const topicDescription = `
+This is a Kafka topic. Its metadata includes each partition's leader,
+replicas and offsets, along with the topic's config. Tailing the topic
+prints its messages (one per line) starting from the cluster's configured
+start_offset, e.g.
+
+  tail -f kafka/local/topics/events
+
+Writing to the topic produces a single message, e.g.
+
+  echo '{"hello": "world"}' > kafka/local/topics/events
+`
@@ -0,0 +1,57 @@
+package kafka
+
+import (
+	"context"
+	"sort"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type topicsDir struct {
+	plugin.EntryBase
+	cluster *cluster
+}
+
+func newTopicsDir(c *cluster) *topicsDir {
+	topicsDir := &topicsDir{
+		EntryBase: plugin.NewEntry("topics"),
+	}
+	topicsDir.cluster = c
+	return topicsDir
+}
+
+func (ts *topicsDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(ts, "topics").IsSingleton()
+}
+
+func (ts *topicsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&topic{}).Schema(),
+	}
+}
+
+// List lists the cluster's topics
+func (ts *topicsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	client, err := ts.cluster.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.RefreshMetadata(); err != nil {
+		return nil, err
+	}
+	names, err := client.Topics()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	entries := make([]plugin.Entry, 0, len(names))
+	for _, name := range names {
+		partitions, err := client.Partitions(name)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, newTopic(ts.cluster, name, len(partitions)))
+	}
+	return entries, nil
+}
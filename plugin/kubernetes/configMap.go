@@ -0,0 +1,130 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+type configMap struct {
+	plugin.EntryBase
+	cmi typedv1.ConfigMapInterface
+}
+
+func newConfigMap(cmi typedv1.ConfigMapInterface, cm *corev1.ConfigMap) *configMap {
+	c := &configMap{
+		EntryBase: plugin.NewEntry(cm.Name),
+	}
+	c.cmi = cmi
+	c.
+		SetPartialMetadata(cm).
+		Attributes().
+		SetCrtime(cm.CreationTimestamp.Time).
+		SetMtime(cm.CreationTimestamp.Time).
+		SetCtime(cm.CreationTimestamp.Time).
+		SetAtime(cm.CreationTimestamp.Time)
+	return c
+}
+
+func (c *configMap) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(c, "configmap").
+		SetDescription(configMapDescription).
+		SetPartialMetadataSchema(corev1.ConfigMap{})
+}
+
+func (c *configMap) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&configMapKey{}).Schema(),
+	}
+}
+
+// List returns the config map's keys. It gets the config map instead of
+// using the listed one so that the keys are up-to-date.
+func (c *configMap) List(ctx context.Context) ([]plugin.Entry, error) {
+	cm, err := c.cmi.Get(ctx, c.Name(), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	for key := range cm.BinaryData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	entries := make([]plugin.Entry, len(keys))
+	for i, key := range keys {
+		entries[i] = newConfigMapKey(c, key, cm)
+	}
+	return entries, nil
+}
+
+// Create adds an empty key to the config map. Config map keys can't have
+// children, so Create returns an error if attr's mode is a directory.
+func (c *configMap) Create(ctx context.Context, name string, attr plugin.EntryAttributes) (plugin.Entry, error) {
+	if attr.HasMode() && attr.Mode().IsDir() {
+		return nil, fmt.Errorf("config map keys cannot be directories")
+	}
+	var cm *corev1.ConfigMap
+	err := c.update(ctx, func(latest *corev1.ConfigMap) error {
+		if hasKey(latest, name) {
+			return fmt.Errorf("the key %v already exists", name)
+		}
+		if latest.Data == nil {
+			latest.Data = make(map[string]string)
+		}
+		latest.Data[name] = ""
+		cm = latest
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newConfigMapKey(c, name, cm), nil
+}
+
+func (c *configMap) Delete(ctx context.Context) (bool, error) {
+	err := c.cmi.Delete(ctx, c.Name(), metav1.DeleteOptions{})
+	return true, err
+}
+
+// update gets the latest config map, changes it with fn, then updates it.
+// The update fails if the config map changed after it was fetched, so it
+// won't undo someone else's change.
+func (c *configMap) update(ctx context.Context, fn func(*corev1.ConfigMap) error) error {
+	cm, err := c.cmi.Get(ctx, c.Name(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if err := fn(cm); err != nil {
+		return err
+	}
+	cm, err = c.cmi.Update(ctx, cm, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	activity.Record(ctx, "Updated config map %v/%v to resource version %v", cm.Namespace, cm.Name, cm.ResourceVersion)
+	return nil
+}
+
+func hasKey(cm *corev1.ConfigMap, key string) bool {
+	if _, ok := cm.Data[key]; ok {
+		return true
+	}
+	_, ok := cm.BinaryData[key]
+	return ok
+}
+
+const configMapDescription = `
+This is a Kubernetes config map. Its keys are its children, so you can read
+and write them like files. Creating a file in the config map (e.g. with touch)
+adds an empty key.
+`
@@ -0,0 +1,78 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/puppetlabs/wash/plugin"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type configMapKey struct {
+	plugin.EntryBase
+	cm      *configMap
+	content []byte
+}
+
+func newConfigMapKey(cm *configMap, key string, latest *corev1.ConfigMap) *configMapKey {
+	k := &configMapKey{
+		EntryBase: plugin.NewEntry(key),
+	}
+	k.cm = cm
+	if data, ok := latest.BinaryData[key]; ok {
+		k.content = data
+	} else {
+		k.content = []byte(latest.Data[key])
+	}
+	k.Attributes().SetSize(uint64(len(k.content)))
+	return k
+}
+
+func (k *configMapKey) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(k, "key").
+		SetDescription(configMapKeyDescription)
+}
+
+func (k *configMapKey) Read(ctx context.Context) ([]byte, error) {
+	return k.content, nil
+}
+
+// Write replaces the key's value. Values that aren't valid UTF-8 are stored
+// as binary data because config map data must be a string.
+func (k *configMapKey) Write(ctx context.Context, p []byte) error {
+	return k.cm.update(ctx, func(latest *corev1.ConfigMap) error {
+		if !hasKey(latest, k.Name()) {
+			return fmt.Errorf("the key %v no longer exists", k.Name())
+		}
+		delete(latest.Data, k.Name())
+		delete(latest.BinaryData, k.Name())
+		if utf8.Valid(p) {
+			if latest.Data == nil {
+				latest.Data = make(map[string]string)
+			}
+			latest.Data[k.Name()] = string(p)
+		} else {
+			if latest.BinaryData == nil {
+				latest.BinaryData = make(map[string][]byte)
+			}
+			latest.BinaryData[k.Name()] = p
+		}
+		return nil
+	})
+}
+
+func (k *configMapKey) Delete(ctx context.Context) (bool, error) {
+	err := k.cm.update(ctx, func(latest *corev1.ConfigMap) error {
+		delete(latest.Data, k.Name())
+		delete(latest.BinaryData, k.Name())
+		return nil
+	})
+	return true, err
+}
+
+const configMapKeyDescription = `
+This is a key in a Kubernetes config map. Reading it returns the key's value,
+and writing it replaces the value.
+`
@@ -0,0 +1,47 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/puppetlabs/wash/plugin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+type configMapsDir struct {
+	plugin.EntryBase
+	client *k8s.Clientset
+	ns     string
+}
+
+func newConfigMapsDir(ns *namespace) *configMapsDir {
+	cms := &configMapsDir{
+		EntryBase: plugin.NewEntry("configmaps"),
+	}
+	cms.client = ns.client
+	cms.ns = ns.Name()
+	return cms
+}
+
+func (cms *configMapsDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(cms, "configmaps").IsSingleton()
+}
+
+func (cms *configMapsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&configMap{}).Schema(),
+	}
+}
+
+func (cms *configMapsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	cmi := cms.client.CoreV1().ConfigMaps(cms.ns)
+	cmList, err := cmi.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(cmList.Items))
+	for i, cm := range cmList.Items {
+		entries[i] = newConfigMap(cmi, &cm)
+	}
+	return entries, nil
+}
@@ -26,6 +26,7 @@ func newNamespace(name string, meta *corev1.Namespace, c *k8s.Clientset, cfg *re
 	ns.resources = []plugin.Entry{
 		newPodsDir(ns),
 		newPVCSDir(ns),
+		newConfigMapsDir(ns),
 	}
 	// TODO: Figure out other attributes that we could set here, if any.
 	ns.SetPartialMetadata(meta)
@@ -43,6 +44,7 @@ func (n *namespace) ChildSchemas() []*plugin.EntrySchema {
 	return []*plugin.EntrySchema{
 		(&podsDir{}).Schema(),
 		(&pvcsDir{}).Schema(),
+		(&configMapsDir{}).Schema(),
 	}
 }
 
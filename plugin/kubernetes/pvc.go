@@ -254,6 +254,20 @@ func (v *pvc) VolumeDelete(ctx context.Context, path string) (bool, error) {
 	return true, nil
 }
 
+func (v *pvc) VolumeCopy(ctx context.Context, src string, dst string) error {
+	_, err := v.exec(ctx, func(base string) []string {
+		return []string{"cp", base + src, base + dst}
+	}, nil)
+	return err
+}
+
+func (v *pvc) VolumeSetAttr(ctx context.Context, path string, attr plugin.EntryAttributes) error {
+	_, err := v.exec(ctx, func(base string) []string {
+		return volume.SetAttrCommand(base+path, attr)
+	}, nil)
+	return err
+}
+
 const pvcDescription = `
 This is a Kubernetes persistent volume claim. We create a temporary Kubernetes
 pod whenever Wash invokes a currently uncached List/Read/Stream/Write action on
@@ -0,0 +1,89 @@
+package localhost
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/shirou/gopsutil/process"
+)
+
+// fdsDir represents a process' open file descriptors. It mirrors
+// /proc/<pid>/fd.
+type fdsDir struct {
+	plugin.EntryBase
+	pid int32
+}
+
+func newFdsDir(p *process.Process) *fdsDir {
+	fds := &fdsDir{
+		EntryBase: plugin.NewEntry("fd"),
+	}
+	fds.pid = p.Pid
+	fds.DisableCachingFor(plugin.ListOp)
+	return fds
+}
+
+func (fds *fdsDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(fds, "fd").IsSingleton()
+}
+
+func (fds *fdsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&fd{}).Schema(),
+	}
+}
+
+func (fds *fdsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	dir := procPath(fds.pid, "fd")
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	activity.Record(ctx, "Listing %v file descriptors in %v", len(infos), fds)
+	entries := make([]plugin.Entry, 0, len(infos))
+	for _, info := range infos {
+		target, err := os.Readlink(procPath(fds.pid, "fd", info.Name()))
+		if err != nil {
+			// The fd was likely closed after we read the directory.
+			continue
+		}
+		entries = append(entries, newFd(info.Name(), target))
+	}
+	return entries, nil
+}
+
+type fdInfo struct {
+	FD     string `json:"fd"`
+	Target string `json:"target"`
+}
+
+// fd represents an open file descriptor. Reading it returns the
+// descriptor's target, which is a file path, socket, pipe, etc.
+type fd struct {
+	plugin.EntryBase
+	target string
+}
+
+func newFd(name string, target string) *fd {
+	f := &fd{
+		EntryBase: plugin.NewEntry(name),
+	}
+	f.target = target
+	f.DisableDefaultCaching()
+	f.SetPartialMetadata(fdInfo{FD: name, Target: target})
+	return f
+}
+
+func (f *fd) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(f, "descriptor").
+		SetPartialMetadataSchema(fdInfo{})
+}
+
+func (f *fd) Read(ctx context.Context) ([]byte, error) {
+	return []byte(f.target + "\n"), nil
+}
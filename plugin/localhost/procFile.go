@@ -0,0 +1,85 @@
+package localhost
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/shirou/gopsutil/process"
+)
+
+// procFile represents a readable file containing some of a process'
+// details, like its cmdline or environment.
+type procFile struct {
+	plugin.EntryBase
+	read func(context.Context) ([]byte, error)
+}
+
+// procPath returns the path to the given file in pid's /proc directory
+func procPath(pid int32, name ...string) string {
+	return filepath.Join(append([]string{"/proc", strconv.Itoa(int(pid))}, name...)...)
+}
+
+// newProcFile creates a procFile that reads /proc/<pid>/<name>. If munge
+// is non-nil, then it is applied to the file's raw content.
+func newProcFile(p *process.Process, name string, munge func([]byte) []byte) *procFile {
+	return newProcFileWithReadFunc(name, func(ctx context.Context) ([]byte, error) {
+		content, err := ioutil.ReadFile(procPath(p.Pid, name))
+		if err != nil {
+			return nil, err
+		}
+		if munge != nil {
+			content = munge(content)
+		}
+		return content, nil
+	})
+}
+
+// newCmdlineFile creates a procFile containing the process' cmdline. Unlike
+// the other proc files, this works on all platforms supported by gopsutil.
+func newCmdlineFile(p *process.Process) *procFile {
+	return newProcFileWithReadFunc("cmdline", func(ctx context.Context) ([]byte, error) {
+		cmdline, err := p.CmdlineWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(cmdline + "\n"), nil
+	})
+}
+
+func newProcFileWithReadFunc(name string, read func(context.Context) ([]byte, error)) *procFile {
+	f := &procFile{
+		EntryBase: plugin.NewEntry(name),
+	}
+	f.read = read
+	// The content changes with the process' state, so don't cache it.
+	f.DisableCachingFor(plugin.ReadOp)
+	return f
+}
+
+func (f *procFile) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(f, "file").SetDescription(procFileDescription)
+}
+
+func (f *procFile) Read(ctx context.Context) ([]byte, error) {
+	return f.read(ctx)
+}
+
+// nulsToNewlines converts the NUL-separated content of files like
+// /proc/<pid>/environ to newline-separated content.
+func nulsToNewlines(content []byte) []byte {
+	content = bytes.TrimRight(content, "\x00")
+	if len(content) == 0 {
+		return content
+	}
+	return []byte(strings.Replace(string(content), "\x00", "\n", -1) + "\n")
+}
+
+const procFileDescription = `
+This is one of a process' details, like its cmdline, environment
+or /proc status.
+`
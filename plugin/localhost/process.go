@@ -0,0 +1,153 @@
+package localhost
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/shirou/gopsutil/process"
+)
+
+// processInfo is the process' partial metadata
+type processInfo struct {
+	PID        int32     `json:"pid"`
+	PPID       int32     `json:"ppid"`
+	Name       string    `json:"name"`
+	Cmdline    []string  `json:"cmdline"`
+	Username   string    `json:"username"`
+	Status     string    `json:"status"`
+	CreateTime time.Time `json:"create_time"`
+	CPUPercent float64   `json:"cpu_percent"`
+	RSS        uint64    `json:"rss"`
+	VMS        uint64    `json:"vms"`
+	NumThreads int32     `json:"num_threads"`
+}
+
+// processMetadata is the process' full metadata. It includes things that are
+// too expensive to fetch for every process when listing them.
+type processMetadata struct {
+	processInfo
+	MemoryPercent float32 `json:"memory_percent"`
+	NumFDs        int32   `json:"num_fds"`
+	Exe           string  `json:"exe"`
+	Cwd           string  `json:"cwd"`
+}
+
+// getProcessInfo fetches p's partial metadata. Only the name is required;
+// everything else is best-effort since unprivileged users cannot read all
+// of another user's process details.
+func getProcessInfo(ctx context.Context, p *process.Process) (processInfo, error) {
+	info := processInfo{PID: p.Pid}
+	var err error
+	if info.Name, err = p.NameWithContext(ctx); err != nil {
+		return info, err
+	}
+	info.PPID, _ = p.PpidWithContext(ctx)
+	info.Cmdline, _ = p.CmdlineSliceWithContext(ctx)
+	info.Username, _ = p.UsernameWithContext(ctx)
+	info.Status, _ = p.StatusWithContext(ctx)
+	if createTime, err := p.CreateTimeWithContext(ctx); err == nil {
+		// CreateTime is in milliseconds since the epoch
+		info.CreateTime = time.Unix(0, createTime*int64(time.Millisecond))
+	}
+	info.CPUPercent, _ = p.CPUPercentWithContext(ctx)
+	if memInfo, err := p.MemoryInfoWithContext(ctx); err == nil {
+		info.RSS = memInfo.RSS
+		info.VMS = memInfo.VMS
+	}
+	info.NumThreads, _ = p.NumThreadsWithContext(ctx)
+	return info, nil
+}
+
+type proc struct {
+	plugin.EntryBase
+	process *process.Process
+}
+
+func newProc(p *process.Process, info processInfo) *proc {
+	pr := &proc{
+		EntryBase: plugin.NewEntry(strconv.Itoa(int(p.Pid))),
+	}
+	pr.process = p
+	pr.
+		SetPartialMetadata(info).
+		Attributes().
+		SetCrtime(info.CreateTime).
+		SetMtime(info.CreateTime).
+		SetCtime(info.CreateTime).
+		SetAtime(info.CreateTime)
+	return pr
+}
+
+func (p *proc) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(p, "process").
+		SetDescription(processDescription).
+		SetPartialMetadataSchema(processInfo{}).
+		SetMetadataSchema(processMetadata{}).
+		AddSignal("stop", "Terminates the process. Equivalent to 'kill -s SIGTERM <pid>'").
+		AddSignal("pause", "Suspends the process. Equivalent to 'kill -s SIGSTOP <pid>'").
+		AddSignal("resume", "Resumes a suspended process. Equivalent to 'kill -s SIGCONT <pid>'").
+		AddSignalGroup("linux", `\Asig.+`, "Consists of all the supported POSIX signals like SIGHUP, SIGKILL. Equivalent to\n'kill -s <signal> <pid>'")
+}
+
+func (p *proc) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&procFile{}).Schema(),
+		(&fdsDir{}).Schema(),
+		(&plugin.MetadataJSONFile{}).Schema(),
+	}
+}
+
+func (p *proc) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	info, err := getProcessInfo(ctx, p.process)
+	if err != nil {
+		return nil, err
+	}
+	meta := processMetadata{processInfo: info}
+	meta.MemoryPercent, _ = p.process.MemoryPercentWithContext(ctx)
+	meta.NumFDs, _ = p.process.NumFDsWithContext(ctx)
+	meta.Exe, _ = p.process.ExeWithContext(ctx)
+	meta.Cwd, _ = p.process.CwdWithContext(ctx)
+	return plugin.ToJSONObject(meta), nil
+}
+
+func (p *proc) List(ctx context.Context) ([]plugin.Entry, error) {
+	meta, err := plugin.NewMetadataJSONFile(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	return []plugin.Entry{
+		newCmdlineFile(p.process),
+		newProcFile(p.process, "environ", nulsToNewlines),
+		newProcFile(p.process, "status", nil),
+		newFdsDir(p.process),
+		meta,
+	}, nil
+}
+
+func (p *proc) Signal(ctx context.Context, signal string) error {
+	switch signal {
+	case "stop":
+		signal = "sigterm"
+	case "pause":
+		signal = "sigstop"
+	case "resume":
+		signal = "sigcont"
+	}
+	sig, ok := signals[signal]
+	if !ok {
+		return fmt.Errorf("unsupported signal %v", signal)
+	}
+	activity.Record(ctx, "Sending %v to process %v", signal, p.process.Pid)
+	return p.process.SendSignalWithContext(ctx, sig)
+}
+
+const processDescription = `
+This is a process running on the local machine. Its children include the
+process' cmdline, environment, /proc status, and open file descriptors.
+Note that the environ, status and fd entries are only available on Linux.
+`
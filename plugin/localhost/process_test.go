@@ -0,0 +1,48 @@
+package localhost
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/shirou/gopsutil/process"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNulsToNewlines(t *testing.T) {
+	for content, expected := range map[string]string{
+		"":                   "",
+		"\x00":               "",
+		"A=1\x00B=2\x00":     "A=1\nB=2\n",
+		"A=1\x00B=2":         "A=1\nB=2\n",
+		"A=1\x00\x00\x00":    "A=1\n",
+		"A=1\x00\x00B=2\x00": "A=1\n\nB=2\n",
+	} {
+		assert.Equal(t, expected, string(nulsToNewlines([]byte(content))), "content %q", content)
+	}
+}
+
+func TestProcPath(t *testing.T) {
+	assert.Equal(t, "/proc/42", procPath(42))
+	assert.Equal(t, "/proc/42/fd/3", procPath(42, "fd", "3"))
+}
+
+func TestProc(t *testing.T) {
+	p, err := process.NewProcess(int32(os.Getpid()))
+	if !assert.NoError(t, err) {
+		return
+	}
+	info, err := getProcessInfo(context.Background(), p)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int32(os.Getpid()), info.PID)
+	assert.NotEmpty(t, info.Name)
+
+	pr := newProc(p, info)
+	assert.Equal(t, info.Name, plugin.PartialMetadata(pr)["name"])
+	assert.Implements(t, (*plugin.Parent)(nil), pr)
+	assert.Implements(t, (*plugin.Signalable)(nil), pr)
+	assert.EqualError(t, pr.Signal(context.Background(), "sigfoo"), "unsupported signal sigfoo")
+}
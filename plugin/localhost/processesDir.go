@@ -0,0 +1,54 @@
+package localhost
+
+import (
+	"context"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/shirou/gopsutil/process"
+)
+
+type processesDir struct {
+	plugin.EntryBase
+}
+
+func newProcessesDir() *processesDir {
+	processesDir := &processesDir{
+		EntryBase: plugin.NewEntry("processes"),
+	}
+	// Processes come and go quickly, so keep the cached list short-lived.
+	processesDir.SetTTLOf(plugin.ListOp, 5*time.Second)
+	return processesDir
+}
+
+func (ps *processesDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(ps, "processes").IsSingleton()
+}
+
+func (ps *processesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&proc{}).Schema(),
+	}
+}
+
+// List lists the processes running on the local machine
+func (ps *processesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	procs, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	activity.Record(ctx, "Listing %v processes in %v", len(procs), ps)
+	entries := make([]plugin.Entry, 0, len(procs))
+	for _, p := range procs {
+		info, err := getProcessInfo(ctx, p)
+		if err != nil {
+			// The process likely exited while we were listing the others.
+			activity.Record(ctx, "Skipping process %v: %v", p.Pid, err)
+			continue
+		}
+		entries = append(entries, newProc(p, info))
+	}
+	return entries, nil
+}
@@ -0,0 +1,101 @@
+// Package localhost presents a filesystem hierarchy for the local machine.
+//
+// It reads the local process table (and /proc on Linux) to expose running
+// processes, and runs commands locally when the root is exec'ed.
+package localhost
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/plugin/external"
+)
+
+// Root of the localhost plugin
+type Root struct {
+	plugin.EntryBase
+	resources []plugin.Entry
+}
+
+// Init for root
+func (r *Root) Init(map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("localhost")
+	r.DisableDefaultCaching()
+	r.resources = []plugin.Entry{
+		newProcessesDir(),
+	}
+	return nil
+}
+
+// Schema returns the root's schema
+func (r *Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "localhost").
+		SetDescription(rootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&processesDir{}).Schema(),
+	}
+}
+
+// List lists the types of resources the localhost plugin exposes.
+func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	return r.resources, nil
+}
+
+// Exec runs the command on the local machine. This is what lets commands
+// like 'wash ps localhost' work.
+func (r *Root) Exec(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	if opts.Elevate && os.Geteuid() != 0 {
+		args = append([]string{cmd}, args...)
+		cmd = "sudo"
+	}
+	inv := external.NewCommand(ctx, cmd, args...)
+	execCmd := plugin.NewExecCommand(ctx)
+	inv.SetStdout(execCmd.Stdout())
+	inv.SetStderr(execCmd.Stderr())
+	if opts.Stdin != nil {
+		inv.SetStdin(opts.Stdin)
+	} else {
+		inv.SetStdin(strings.NewReader(""))
+	}
+	activity.Record(ctx, "Exec %v on %v", inv, r.Name())
+	if err := inv.Start(); err != nil {
+		return nil, err
+	}
+	// external.Command handles context-cancellation cleanup for us, so we
+	// don't have to use execCmd.SetStopFunc.
+	go func() {
+		err := inv.Wait()
+		execCmd.CloseStreamsWithError(nil)
+		exitCode := inv.ExitCode()
+		activity.Record(ctx, "Exec on %v exited %v", r.Name(), exitCode)
+		if exitCode < 0 {
+			execCmd.SetExitCodeErr(err)
+		} else {
+			execCmd.SetExitCode(exitCode)
+		}
+	}()
+	return execCmd, nil
+}
+
+const rootDescription = `
+This is the localhost plugin root. It lets you interact with the machine
+that the Wash daemon is running on. Its processes are listed under the
+'processes' directory, where each process' metadata includes its cmdline,
+CPU usage, and memory usage.
+
+Processes support the 'signal' action, so you can do something like
+
+  signal sigterm localhost/processes/1234
+
+Exec'ing the root runs the command locally, so commands like 'wash ps localhost'
+work the same way that they do on containers and VMs.
+`
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/audit"
+	"github.com/puppetlabs/wash/tracing"
 )
 
 // InvalidInputErr indicates that the method invocation received invalid
@@ -114,9 +118,73 @@ func Schema(e Entry) (*EntrySchema, error) {
 //
 // Note that List's results could be cached.
 func List(ctx context.Context, p Parent) (*EntryMap, error) {
+	if err := listAction.checkPermitted(p); err != nil {
+		return nil, err
+	}
 	return cachedList(ctx, p)
 }
 
+// ListEach is List, except that it passes each of the parent's children to f
+// instead of returning them. When the children aren't cached, f receives each
+// child as soon as it's available so that callers can start consuming them
+// before the parent's finished listing. The children aren't passed to f in any
+// particular order. ListEach stops calling f once it returns an error, and
+// returns that error.
+func ListEach(ctx context.Context, p Parent, f func(Entry) error) error {
+	if err := listAction.checkPermitted(p); err != nil {
+		return err
+	}
+	return cachedListEach(ctx, p, f)
+}
+
+// ListPage lists up to limit of the parent's children, starting from the page
+// identified by continuationToken. It returns the page along with the token of
+// the next page, which is empty once there aren't any more children. The first
+// page's token is empty.
+//
+// Parents that implement PaginatedLister list the page themselves. Otherwise,
+// the page is taken from the parent's (cached) List result, with the children
+// sorted by cname. Then the token's the cname of the previous page's last
+// child, and a limit of 0 returns all of the remaining children.
+func ListPage(ctx context.Context, p Parent, limit int, continuationToken string) ([]Entry, string, error) {
+	if err := listAction.checkPermitted(p); err != nil {
+		return nil, "", err
+	}
+	if limit < 0 {
+		return nil, "", InvalidInputErr{fmt.Sprintf("the limit must not be negative, got %v", limit)}
+	}
+	if ListAction().signature(p) == PaginatedSignature {
+		return listPage(ctx, p.(PaginatedLister), limit, continuationToken)
+	}
+
+	children, err := cachedList(ctx, p)
+	if err != nil {
+		return nil, "", err
+	}
+	cnames := make([]string, 0, children.Len())
+	children.Range(func(cname string, _ Entry) bool {
+		cnames = append(cnames, cname)
+		return true
+	})
+	sort.Strings(cnames)
+	// Skip the children up to and including the previous page's last child.
+	start := sort.Search(len(cnames), func(i int) bool { return cnames[i] > continuationToken })
+	end := len(cnames)
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+	page := make([]Entry, 0, end-start)
+	for _, cname := range cnames[start:end] {
+		child, _ := children.Load(cname)
+		page = append(page, child)
+	}
+	var nextToken string
+	if end < len(cnames) {
+		nextToken = cnames[end-1]
+	}
+	return page, nextToken, nil
+}
+
 // Read reads up to size bits of the entry's content starting at the given offset.
 // It will panic if the entry does not support the read action. Callers can use
 // len(data) to check the amount of data that was actually read.
@@ -129,6 +197,9 @@ func Read(ctx context.Context, e Entry, size int64, offset int64) (data []byte,
 	if !ReadAction().IsSupportedOn(e) {
 		panic("plugin.Read called on a non-readable entry")
 	}
+	if err = readAction.checkPermitted(e); err != nil {
+		return
+	}
 	if size < 0 {
 		return nil, fmt.Errorf("called with a negative size %v", size)
 	}
@@ -172,7 +243,7 @@ func Size(ctx context.Context, e Entry) (uint64, error) {
 		return attr.Size(), nil
 	}
 
-	if !ReadAction().IsSupportedOn(e) {
+	if !ReadAction().IsSupportedOn(e) || readAction.checkPermitted(e) != nil {
 		return 0, nil
 	}
 
@@ -183,6 +254,46 @@ func Size(ctx context.Context, e Entry) (uint64, error) {
 	return data.size(), nil
 }
 
+// ReadDecompressed is Read for the entry's decompressed content. Gzip and zstd
+// compressed content is decompressed, while other content is read as-is. The
+// decompressed content is cached with the entry's Read TTL.
+func ReadDecompressed(ctx context.Context, e Entry, size int64, offset int64) ([]byte, error) {
+	if !ReadAction().IsSupportedOn(e) {
+		panic("plugin.ReadDecompressed called on a non-readable entry")
+	}
+	if err := readAction.checkPermitted(e); err != nil {
+		return nil, err
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("called with a negative size %v", size)
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("called with a negative offset %v", offset)
+	}
+	content, err := cachedDecompressedRead(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	return content.read(ctx, size, offset)
+}
+
+// DecompressedSize returns the size of the entry's decompressed content and
+// the content's compression format. It reads all of the entry's content to do
+// so.
+func DecompressedSize(ctx context.Context, e Entry) (uint64, Compression, error) {
+	if !ReadAction().IsSupportedOn(e) {
+		panic("plugin.DecompressedSize called on a non-readable entry")
+	}
+	if err := readAction.checkPermitted(e); err != nil {
+		return 0, NoCompression, err
+	}
+	content, err := cachedDecompressedRead(ctx, e)
+	if err != nil {
+		return 0, NoCompression, err
+	}
+	return content.size(), content.compression, nil
+}
+
 // PartialMetadata returns the entry's partial metadata, a subset of the entry's
 // metadata that is typically provided by the plugin API's List endpoint. If the
 // entry didn't specify any partial metadata, then this returns Attributes(e).ToMap()
@@ -193,26 +304,214 @@ func PartialMetadata(e Entry) JSONObject {
 
 // Metadata returns the entry's metadata. Note that Metadata's results could be cached.
 func Metadata(ctx context.Context, e Entry) (JSONObject, error) {
+	if err := metadataAction.checkPermitted(e); err != nil {
+		return nil, err
+	}
 	return cachedMetadata(ctx, e)
 }
 
 // Exec execs the command on the given entry.
 func Exec(ctx context.Context, e Execable, cmd string, args []string, opts ExecOptions) (ExecCommand, error) {
-	return e.Exec(ctx, cmd, args, opts)
+	if err := execAction.checkPermitted(e); err != nil {
+		return nil, err
+	}
+	ctx, span := startMethodSpan(ctx, e, "Exec")
+	defer span.End()
+	span.SetAttributes(tracing.String("wash.command", cmd))
+	execCmd, err := e.Exec(ctx, cmd, args, opts)
+	span.RecordError(err)
+	audit.Record(ctx, e.eb().id, "exec", append([]string{cmd}, args...), err)
+	return execCmd, err
 }
 
 // Stream streams the entry's content for updates.
 func Stream(ctx context.Context, s Streamable) (io.ReadCloser, error) {
-	return s.Stream(ctx)
+	if err := streamAction.checkPermitted(s); err != nil {
+		return nil, err
+	}
+	ctx, span := startMethodSpan(ctx, s, "Stream")
+	defer span.End()
+	var rdr io.ReadCloser
+	err := retry(ctx, span, func() (err error) {
+		rdr, err = s.Stream(ctx)
+		return
+	})
+	span.RecordError(err)
+	return rdr, err
+}
+
+// Search returns the lines of the entry's content, or of its descendants'
+// content, that match pattern, a Go regular expression.
+func Search(ctx context.Context, s Searchable, pattern string) ([]SearchMatch, error) {
+	if err := searchAction.checkPermitted(s); err != nil {
+		return nil, err
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, InvalidInputErr{fmt.Sprintf("invalid pattern %v: %v", pattern, err)}
+	}
+	ctx, span := startMethodSpan(ctx, s, "Search")
+	defer span.End()
+	span.SetAttributes(tracing.String("wash.pattern", pattern))
+	var matches []SearchMatch
+	err := retry(ctx, span, func() (err error) {
+		matches, err = s.Search(ctx, pattern)
+		return
+	})
+	span.RecordError(err)
+	span.SetAttributes(tracing.Int("wash.matches", int64(len(matches))))
+	return matches, err
 }
 
 // Write sends the supplied buffer to the entry.
 func Write(ctx context.Context, a Writable, b []byte) error {
-	return a.Write(ctx, b)
+	if err := writeAction.checkPermitted(a); err != nil {
+		return err
+	}
+	ctx, span := startMethodSpan(ctx, a, "Write")
+	defer span.End()
+	span.SetAttributes(tracing.Int("wash.size", int64(len(b))))
+	err := a.Write(ctx, b)
+	span.RecordError(err)
+	audit.Record(ctx, a.eb().id, "write", []string{fmt.Sprintf("%v bytes", len(b))}, err)
+	return err
+}
+
+// Rename renames the entry to newName within its parent.
+func Rename(ctx context.Context, r Renameable, newName string) error {
+	if err := renameAction.checkPermitted(r); err != nil {
+		return err
+	}
+	if newName == "" || strings.Contains(newName, "/") {
+		return InvalidInputErr{fmt.Sprintf("invalid name %q: it must be non-empty and can't contain a /", newName)}
+	}
+	spanCtx, span := startMethodSpan(ctx, r, "Rename")
+	span.SetAttributes(tracing.String("wash.new_name", newName))
+	err := r.Rename(spanCtx, newName)
+	span.RecordError(err)
+	span.End()
+	audit.Record(ctx, r.eb().id, "rename", []string{newName}, err)
+	if err != nil {
+		return err
+	}
+
+	// The entry's path changed, so clear the entry and its children's cache,
+	// and its parent's cached list result so that the new name's listed.
+	ClearCacheFor(r.eb().id, true)
+	parentID, _ := splitID(r.eb().id)
+	cache.Delete(listKeysRegex(parentID))
+	return nil
+}
+
+// CanCopyTo returns true if the entry supports the copy action and dstParent
+// is a parent in the same plugin, i.e. if the entry can be copied to one of
+// dstParent's children.
+func CanCopyTo(e Entry, dstParent Entry) bool {
+	return copyAction.IsSupportedOn(e) &&
+		listAction.IsSupportedOn(dstParent) &&
+		pluginName(dstParent) == pluginName(e)
+}
+
+// Copy copies the entry to a child of dstParent named dstName. dstParent must
+// be in the same plugin as the entry.
+func Copy(ctx context.Context, c Copyable, dstParent Parent, dstName string) error {
+	if err := copyAction.checkPermitted(c); err != nil {
+		return err
+	}
+	if dstName == "" || strings.Contains(dstName, "/") {
+		return InvalidInputErr{fmt.Sprintf("invalid name %q: it must be non-empty and can't contain a /", dstName)}
+	}
+	if pluginName(dstParent) != pluginName(c) {
+		return InvalidInputErr{fmt.Sprintf("%v can only be copied within the %v plugin", ID(c), pluginName(c))}
+	}
+	dstID := strings.TrimSuffix(ID(dstParent), "/") + "/" + dstName
+	spanCtx, span := startMethodSpan(ctx, c, "Copy")
+	span.SetAttributes(tracing.String("wash.destination", dstID))
+	err := c.Copy(spanCtx, dstParent, dstName)
+	span.RecordError(err)
+	span.End()
+	audit.Record(ctx, c.eb().id, "copy", []string{dstID}, err)
+	if err != nil {
+		return err
+	}
+
+	// Clear the replaced entry's cache (if there was one), and dstParent's
+	// cached list result so that the copy's listed.
+	ClearCacheFor(dstID, false)
+	cache.Delete(listKeysRegex(ID(dstParent)))
+	return nil
+}
+
+// Create creates a child of the parent named name. If attr's mode is a directory,
+// then the child's a parent. Otherwise, it's empty.
+func Create(ctx context.Context, c Creatable, name string, attr EntryAttributes) (Entry, error) {
+	if err := createAction.checkPermitted(c); err != nil {
+		return nil, err
+	}
+	if name == "" || strings.Contains(name, "/") {
+		return nil, InvalidInputErr{fmt.Sprintf("invalid name %q: it must be non-empty and can't contain a /", name)}
+	}
+	spanCtx, span := startMethodSpan(ctx, c, "Create")
+	span.SetAttributes(tracing.String("wash.name", name))
+	entry, err := c.Create(context.WithValue(spanCtx, parentID, c.eb().id), name, attr)
+	span.RecordError(err)
+	span.End()
+	audit.Record(ctx, c.eb().id, "create", []string{name}, err)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set up the child like List does, then clear the parent's cached list result
+	// so that the child's listed.
+	setChildID(c.eb().id, entry)
+	passAlongWrappedTypes(c, entry)
+	ClearCacheFor(entry.eb().id, false)
+	cache.Delete(listKeysRegex(c.eb().id))
+	return entry, nil
+}
+
+// SetAttr sets the entry's mode, atime and/or mtime to the ones in attr.
+func SetAttr(ctx context.Context, s SetAttrable, attr EntryAttributes) error {
+	if err := setAttrAction.checkPermitted(s); err != nil {
+		return err
+	}
+	if attr.HasCtime() || attr.HasCrtime() || attr.HasOS() || attr.HasSize() {
+		return InvalidInputErr{"only the mode, atime and mtime attributes can be set"}
+	}
+	if !attr.HasMode() && !attr.HasAtime() && !attr.HasMtime() {
+		return InvalidInputErr{"at least one of the mode, atime and mtime attributes must be set"}
+	}
+	if attr.HasMode() && attr.Mode() != attr.Mode().Perm() {
+		return InvalidInputErr{fmt.Sprintf("invalid mode %v: only the permission bits can be set", attr.Mode())}
+	}
+	spanCtx, span := startMethodSpan(ctx, s, "SetAttr")
+	var args []string
+	for k, v := range attr.ToMap() {
+		args = append(args, fmt.Sprintf("%v=%v", k, v))
+	}
+	sort.Strings(args)
+	span.SetAttributes(tracing.String("wash.attributes", strings.Join(args, " ")))
+	err := s.SetAttr(spanCtx, attr)
+	span.RecordError(err)
+	span.End()
+	audit.Record(ctx, s.eb().id, "setattr", args, err)
+	if err != nil {
+		return err
+	}
+
+	// The entry's attributes are part of its parent's cached list result, so
+	// clear that too.
+	ClearCacheFor(s.eb().id, false)
+	parentID, _ := splitID(s.eb().id)
+	cache.Delete(listKeysRegex(parentID))
+	return nil
 }
 
 // Signal signals the entry with the specified signal
 func Signal(ctx context.Context, s Signalable, signal string) error {
+	if err := signalAction.checkPermitted(s); err != nil {
+		return err
+	}
+
 	// Signals are case-insensitive
 	signal = strings.ToLower(signal)
 
@@ -250,7 +549,12 @@ func Signal(ctx context.Context, s Signalable, signal string) error {
 	}
 
 	// Go ahead and send the signal
-	err = s.Signal(ctx, signal)
+	spanCtx, span := startMethodSpan(ctx, s, "Signal")
+	span.SetAttributes(tracing.String("wash.signal", signal))
+	err = s.Signal(spanCtx, signal)
+	span.RecordError(err)
+	span.End()
+	audit.Record(ctx, s.eb().id, "signal", []string{signal}, err)
 	if err != nil {
 		return err
 	}
@@ -263,7 +567,14 @@ func Signal(ctx context.Context, s Signalable, signal string) error {
 
 // Delete deletes the given entry.
 func Delete(ctx context.Context, d Deletable) (deleted bool, err error) {
-	deleted, err = d.Delete(ctx)
+	if err = deleteAction.checkPermitted(d); err != nil {
+		return
+	}
+	spanCtx, span := startMethodSpan(ctx, d, "Delete")
+	deleted, err = d.Delete(spanCtx)
+	span.RecordError(err)
+	span.End()
+	audit.Record(ctx, d.eb().id, "delete", nil, err)
 	if err != nil {
 		return
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"regexp"
 	"testing"
 	"time"
@@ -78,6 +79,31 @@ func (m *methodWrappersTestsMockEntry) Signal(ctx context.Context, signal string
 	return args.Error(0)
 }
 
+func (m *methodWrappersTestsMockEntry) Rename(ctx context.Context, newName string) error {
+	args := m.Called(ctx, newName)
+	return args.Error(0)
+}
+
+func (m *methodWrappersTestsMockEntry) Copy(ctx context.Context, dstParent Parent, dstName string) error {
+	args := m.Called(ctx, dstParent, dstName)
+	return args.Error(0)
+}
+
+func (m *methodWrappersTestsMockEntry) Create(ctx context.Context, name string, attr EntryAttributes) (Entry, error) {
+	args := m.Called(ctx, name, attr)
+	return args.Get(0).(Entry), args.Error(1)
+}
+
+func (m *methodWrappersTestsMockEntry) SetAttr(ctx context.Context, attr EntryAttributes) error {
+	args := m.Called(ctx, attr)
+	return args.Error(0)
+}
+
+func (m *methodWrappersTestsMockEntry) Search(ctx context.Context, pattern string) ([]SearchMatch, error) {
+	args := m.Called(ctx, pattern)
+	return args.Get(0).([]SearchMatch), args.Error(1)
+}
+
 func (m *methodWrappersTestsMockEntry) Read(ctx context.Context) ([]byte, error) {
 	args := m.Called(ctx)
 	return args.Get(0).([]byte), args.Error(1)
@@ -319,7 +345,7 @@ func (suite *MethodWrappersTestSuite) TestSignal_SendsSignalAndUpdatesCache() {
 
 	suite.cache.On("Get", "List", "/foo").Return(mockEntryMap("bar", false), nil)
 	suite.cache.On("Delete", allOpKeysIncludingChildrenRegex(e.eb().id)).Return([]string{})
-	suite.cache.On("Delete", opKeyRegex("List", "/foo")).Return([]string{})
+	suite.cache.On("Delete", listKeysRegex("/foo")).Return([]string{})
 
 	// Also test case-insensitivity here
 	err := Signal(ctx, e, "START")
@@ -329,6 +355,222 @@ func (suite *MethodWrappersTestSuite) TestSignal_SendsSignalAndUpdatesCache() {
 	}
 }
 
+func (suite *MethodWrappersTestSuite) TestRename_ReturnsInvalidInputErrForInvalidName() {
+	ctx := context.Background()
+	e := newMethodWrappersTestsMockEntry("bar")
+
+	for _, name := range []string{"", "baz/qux"} {
+		err := Rename(ctx, e, name)
+		suite.True(IsInvalidInputErr(err))
+	}
+	e.AssertNotCalled(suite.T(), "Rename", mock.Anything, mock.Anything)
+}
+
+func (suite *MethodWrappersTestSuite) TestRename_RenamesAndUpdatesCache() {
+	ctx := context.Background()
+	e := newMethodWrappersTestsMockEntry("bar")
+	e.SetTestID("/foo/bar")
+
+	e.On("Rename", ctx, "baz").Return(nil)
+
+	suite.cache.On("Get", "List", "/foo").Return(mockEntryMap("bar", false), nil)
+	suite.cache.On("Delete", allOpKeysIncludingChildrenRegex(e.eb().id)).Return([]string{})
+	suite.cache.On("Delete", listKeysRegex("/foo")).Return([]string{})
+
+	err := Rename(ctx, e, "baz")
+	if suite.NoError(err) {
+		e.AssertExpectations(suite.T())
+		suite.cache.AssertExpectations(suite.T())
+	}
+}
+
+func (suite *MethodWrappersTestSuite) TestCopy_ReturnsInvalidInputErrForInvalidDestination() {
+	ctx := context.Background()
+	e := newMethodWrappersTestsMockEntry("bar")
+	e.SetTestID("/foo/bar")
+	dstParent := newMethodWrappersTestsMockEntry("baz")
+	dstParent.SetTestID("/foo/baz")
+
+	for _, name := range []string{"", "qux/quux"} {
+		err := Copy(ctx, e, dstParent, name)
+		suite.True(IsInvalidInputErr(err))
+	}
+
+	otherPluginParent := newMethodWrappersTestsMockEntry("baz")
+	otherPluginParent.SetTestID("/other/baz")
+	suite.False(CanCopyTo(e, otherPluginParent))
+	err := Copy(ctx, e, otherPluginParent, "qux")
+	suite.True(IsInvalidInputErr(err))
+	e.AssertNotCalled(suite.T(), "Copy", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *MethodWrappersTestSuite) TestCopy_CopiesAndUpdatesCache() {
+	ctx := context.Background()
+	e := newMethodWrappersTestsMockEntry("bar")
+	e.SetTestID("/foo/bar")
+	dstParent := newMethodWrappersTestsMockEntry("baz")
+	dstParent.SetTestID("/foo/baz")
+	suite.True(CanCopyTo(e, dstParent))
+
+	e.On("Copy", ctx, dstParent, "qux").Return(nil)
+
+	suite.cache.On("Delete", allOpKeysIncludingChildrenRegex("/foo/baz/qux")).Return([]string{})
+	suite.cache.On("Delete", listKeysRegex("/foo/baz")).Return([]string{})
+
+	err := Copy(ctx, e, dstParent, "qux")
+	if suite.NoError(err) {
+		e.AssertExpectations(suite.T())
+		suite.cache.AssertExpectations(suite.T())
+	}
+}
+
+func (suite *MethodWrappersTestSuite) TestSearch_ReturnsInvalidInputErrForInvalidPattern() {
+	ctx := context.Background()
+	e := newMethodWrappersTestsMockEntry("foo")
+
+	_, err := Search(ctx, e, "(")
+	suite.True(IsInvalidInputErr(err))
+	e.AssertNotCalled(suite.T(), "Search", mock.Anything, mock.Anything)
+}
+
+func (suite *MethodWrappersTestSuite) TestSearch_ReturnsMatches() {
+	ctx := context.Background()
+	e := newMethodWrappersTestsMockEntry("foo")
+	expected := []SearchMatch{{Path: "bar.log", Line: 3, Text: "an error"}}
+	e.On("Search", mock.Anything, "err.r").Return(expected, nil)
+
+	matches, err := Search(ctx, e, "err.r")
+	if suite.NoError(err) {
+		suite.Equal(expected, matches)
+	}
+}
+
+func (suite *MethodWrappersTestSuite) TestCreate_ReturnsInvalidInputErrForInvalidName() {
+	ctx := context.Background()
+	p := newMethodWrappersTestsMockEntry("foo")
+
+	for _, name := range []string{"", "bar/baz"} {
+		_, err := Create(ctx, p, name, EntryAttributes{})
+		suite.True(IsInvalidInputErr(err))
+	}
+	p.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *MethodWrappersTestSuite) TestCreate_SetsTheChildIDAndUpdatesCache() {
+	ctx := context.Background()
+	p := newMethodWrappersTestsMockEntry("foo")
+	p.SetTestID("/foo")
+	child := newMethodWrappersTestsMockEntry("bar/baz")
+	child.SetTestID("")
+	var attr EntryAttributes
+	attr.SetMode(os.ModeDir | 0750)
+
+	p.On("Create", mock.Anything, "bar", attr).Return(child, nil)
+
+	suite.cache.On("Delete", allOpKeysIncludingChildrenRegex("/foo/bar#baz")).Return([]string{})
+	suite.cache.On("Delete", listKeysRegex("/foo")).Return([]string{})
+
+	entry, err := Create(ctx, p, "bar", attr)
+	if suite.NoError(err) {
+		suite.Equal(child, entry)
+		suite.Equal("/foo/bar#baz", ID(entry))
+		p.AssertExpectations(suite.T())
+		suite.cache.AssertExpectations(suite.T())
+	}
+}
+
+func (suite *MethodWrappersTestSuite) TestSetAttr_ReturnsInvalidInputErrForInvalidAttributes() {
+	ctx := context.Background()
+	e := newMethodWrappersTestsMockEntry("bar")
+
+	var noAttr, sizeAttr, typeAttr EntryAttributes
+	sizeAttr.SetSize(10)
+	typeAttr.SetMode(os.ModeDir | 0755)
+	for _, attr := range []EntryAttributes{noAttr, sizeAttr, typeAttr} {
+		err := SetAttr(ctx, e, attr)
+		suite.True(IsInvalidInputErr(err))
+	}
+	e.AssertNotCalled(suite.T(), "SetAttr", mock.Anything, mock.Anything)
+}
+
+func (suite *MethodWrappersTestSuite) TestSetAttr_SetsAttributesAndUpdatesCache() {
+	ctx := context.Background()
+	e := newMethodWrappersTestsMockEntry("bar")
+	e.SetTestID("/foo/bar")
+	var attr EntryAttributes
+	attr.SetMode(0640)
+	attr.SetMtime(time.Now())
+
+	e.On("SetAttr", mock.Anything, attr).Return(nil)
+
+	suite.cache.On("Delete", allOpKeysIncludingChildrenRegex(e.eb().id)).Return([]string{})
+	suite.cache.On("Delete", listKeysRegex("/foo")).Return([]string{})
+
+	err := SetAttr(ctx, e, attr)
+	if suite.NoError(err) {
+		e.AssertExpectations(suite.T())
+		suite.cache.AssertExpectations(suite.T())
+	}
+}
+
+func (suite *MethodWrappersTestSuite) TestListPage_ReturnsInvalidInputErrForNegativeLimit() {
+	p := newMethodWrappersTestsMockEntry("foo")
+	_, _, err := ListPage(context.Background(), p, -1, "")
+	suite.IsType(InvalidInputErr{}, err)
+	p.AssertNotCalled(suite.T(), "List", mock.Anything)
+}
+
+func (suite *MethodWrappersTestSuite) TestListPage_PagesThroughListedChildren() {
+	ctx := context.Background()
+	p := newMethodWrappersTestsMockEntry("foo")
+	p.SetTestID("/foo")
+	a := newMethodWrappersTestsMockEntry("a")
+	b := newMethodWrappersTestsMockEntry("b")
+	c := newMethodWrappersTestsMockEntry("c")
+	p.On("List", mock.Anything).Return([]Entry{c, a, b}, nil)
+
+	page, token, err := ListPage(ctx, p, 2, "")
+	if suite.NoError(err) {
+		suite.Equal([]Entry{a, b}, page)
+		suite.Equal("b", token)
+	}
+	page, token, err = ListPage(ctx, p, 2, token)
+	if suite.NoError(err) {
+		suite.Equal([]Entry{c}, page)
+		suite.Equal("", token)
+	}
+	page, token, err = ListPage(ctx, p, 0, "a")
+	if suite.NoError(err) {
+		suite.Equal([]Entry{b, c}, page)
+		suite.Equal("", token)
+	}
+}
+
+type methodWrappersTestsMockPaginatedEntry struct {
+	*methodWrappersTestsMockEntry
+}
+
+func (m *methodWrappersTestsMockPaginatedEntry) ListPage(ctx context.Context, limit int, continuationToken string) ([]Entry, string, error) {
+	args := m.Called(ctx, limit, continuationToken)
+	return args.Get(0).([]Entry), args.String(1), args.Error(2)
+}
+
+func (suite *MethodWrappersTestSuite) TestListPage_PaginatedLister() {
+	p := &methodWrappersTestsMockPaginatedEntry{newMethodWrappersTestsMockEntry("foo")}
+	p.SetTestID("/foo")
+	child := newMethodWrappersTestsMockEntry("bar")
+	child.SetTestID("")
+	p.On("ListPage", mock.Anything, 10, "token").Return([]Entry{child}, "next", nil)
+
+	page, token, err := ListPage(context.Background(), p, 10, "token")
+	if suite.NoError(err) {
+		suite.Equal([]Entry{child}, page)
+		suite.Equal("/foo/bar", ID(child))
+		suite.Equal("next", token)
+	}
+	p.AssertNotCalled(suite.T(), "List", mock.Anything)
+}
+
 func (suite *MethodWrappersTestSuite) TestSignal_SchemaKnown_ReturnsInvalidInputErrForInvalidSignal() {
 	ctx := context.Background()
 	e := newMethodWrappersTestsMockEntry("foo")
@@ -384,7 +626,7 @@ func (suite *MethodWrappersTestSuite) TestDelete_EntryDeletionInProgress_Updates
 
 	suite.cache.On("Get", "List", "/foo").Return(mockEntryMap("bar", false), nil)
 	suite.cache.On("Delete", allOpKeysIncludingChildrenRegex(e.eb().id)).Return([]string{})
-	suite.cache.On("Delete", opKeyRegex("List", "/foo")).Return([]string{})
+	suite.cache.On("Delete", listKeysRegex("/foo")).Return([]string{})
 
 	deleted, err := Delete(context.Background(), e)
 	if suite.NoError(err) {
@@ -445,6 +687,59 @@ func (suite *MethodWrappersTestSuite) TestDelete_DeletedEntry_NoCachedListResult
 	}
 }
 
+func (suite *MethodWrappersTestSuite) TestReadOnly_RejectsMutatingActions() {
+	ctx := context.Background()
+	e := newMethodWrappersTestsMockEntry("bar")
+	e.SetTestID("/foo/bar")
+
+	SetReadOnly(true, nil)
+	defer SetReadOnly(false, nil)
+
+	err := Write(ctx, e, []byte("something"))
+	suite.True(IsActionNotPermittedErr(err))
+	suite.EqualError(err, "the write action is not permitted: Wash is in read-only mode")
+	err = Signal(ctx, e, "start")
+	suite.True(IsActionNotPermittedErr(err))
+	_, err = Delete(ctx, e)
+	suite.True(IsActionNotPermittedErr(err))
+	err = Rename(ctx, e, "baz")
+	suite.True(IsActionNotPermittedErr(err))
+	err = Copy(ctx, e, e, "baz")
+	suite.True(IsActionNotPermittedErr(err))
+	_, err = Create(ctx, e, "baz", EntryAttributes{})
+	suite.True(IsActionNotPermittedErr(err))
+	err = SetAttr(ctx, e, EntryAttributes{})
+	suite.True(IsActionNotPermittedErr(err))
+	e.AssertNotCalled(suite.T(), "Write", mock.Anything, mock.Anything)
+	e.AssertNotCalled(suite.T(), "Signal", mock.Anything, mock.Anything)
+	e.AssertNotCalled(suite.T(), "Delete", mock.Anything)
+	e.AssertNotCalled(suite.T(), "Rename", mock.Anything, mock.Anything)
+	e.AssertNotCalled(suite.T(), "Copy", mock.Anything, mock.Anything, mock.Anything)
+	e.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+	e.AssertNotCalled(suite.T(), "SetAttr", mock.Anything, mock.Anything)
+}
+
+func (suite *MethodWrappersTestSuite) TestReadOnly_RejectsMutatingActionsOnReadOnlyPlugins() {
+	ctx := context.Background()
+	data := []byte("something")
+
+	SetReadOnly(false, []string{"foo"})
+	defer SetReadOnly(false, nil)
+
+	e := newMethodWrappersTestsMockEntry("bar")
+	e.SetTestID("/foo/bar")
+	err := Write(ctx, e, data)
+	suite.True(IsActionNotPermittedErr(err))
+	suite.EqualError(err, "the write action is not permitted: the foo plugin is read-only")
+	e.AssertNotCalled(suite.T(), "Write", mock.Anything, mock.Anything)
+
+	other := newMethodWrappersTestsMockEntry("bar")
+	other.SetTestID("/other/bar")
+	other.On("Write", ctx, data).Return(nil).Once()
+	suite.NoError(Write(ctx, other, data))
+	other.AssertExpectations(suite.T())
+}
+
 func TestMethodWrappers(t *testing.T) {
 	suite.Run(t, new(MethodWrappersTestSuite))
 }
@@ -0,0 +1,102 @@
+package plugin
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// LatencyBuckets are the upper bounds, in seconds, of the latency histograms
+// kept for each plugin's methods.
+var LatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// MethodStats describes the invocations of a plugin's method since the server
+// started. Cached results aren't invocations, so they aren't included.
+type MethodStats struct {
+	Plugin string `json:"plugin"`
+	Method string `json:"method"`
+	Count  uint64 `json:"count"`
+	Errors uint64 `json:"errors"`
+	// TotalSeconds and MaxSeconds are the total and longest latency.
+	TotalSeconds float64 `json:"total_seconds"`
+	MaxSeconds   float64 `json:"max_seconds"`
+	// Buckets[i] is the number of invocations that took at most
+	// LatencyBuckets[i] seconds. Slower invocations are only in Count.
+	Buckets []uint64 `json:"buckets"`
+}
+
+// Quantile estimates the latency, in seconds, that the given fraction of
+// invocations completed within. It's the upper bound of the bucket containing
+// the quantile, or MaxSeconds if that's beyond the last bucket.
+func (s MethodStats) Quantile(q float64) float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	rank := q * float64(s.Count)
+	for i, count := range s.Buckets {
+		if float64(count) >= rank {
+			if bound := LatencyBuckets[i]; bound < s.MaxSeconds {
+				return bound
+			}
+			return s.MaxSeconds
+		}
+	}
+	return s.MaxSeconds
+}
+
+type methodKey struct {
+	plugin, method string
+}
+
+var methodMetrics = struct {
+	mux   sync.Mutex
+	stats map[methodKey]*MethodStats
+}{stats: make(map[methodKey]*MethodStats)}
+
+func recordMethodInvocation(plugin string, method string, duration time.Duration, err error) {
+	methodMetrics.mux.Lock()
+	defer methodMetrics.mux.Unlock()
+
+	key := methodKey{plugin, method}
+	stats, ok := methodMetrics.stats[key]
+	if !ok {
+		stats = &MethodStats{Plugin: plugin, Method: method, Buckets: make([]uint64, len(LatencyBuckets))}
+		methodMetrics.stats[key] = stats
+	}
+
+	seconds := duration.Seconds()
+	stats.Count++
+	if err != nil {
+		stats.Errors++
+	}
+	stats.TotalSeconds += seconds
+	if seconds > stats.MaxSeconds {
+		stats.MaxSeconds = seconds
+	}
+	for i, bound := range LatencyBuckets {
+		if seconds <= bound {
+			stats.Buckets[i]++
+		}
+	}
+}
+
+// MethodMetrics returns the stats of each plugin's invoked methods, sorted by
+// plugin and method.
+func MethodMetrics() []MethodStats {
+	methodMetrics.mux.Lock()
+	defer methodMetrics.mux.Unlock()
+
+	metrics := make([]MethodStats, 0, len(methodMetrics.stats))
+	for _, stats := range methodMetrics.stats {
+		snapshot := *stats
+		snapshot.Buckets = append([]uint64{}, stats.Buckets...)
+		metrics = append(metrics, snapshot)
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].Plugin != metrics[j].Plugin {
+			return metrics[i].Plugin < metrics[j].Plugin
+		}
+		return metrics[i].Method < metrics[j].Method
+	})
+	return metrics
+}
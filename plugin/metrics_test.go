@@ -0,0 +1,56 @@
+package plugin
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func findMethodStats(plugin string, method string) *MethodStats {
+	for _, stats := range MethodMetrics() {
+		if stats.Plugin == plugin && stats.Method == method {
+			return &stats
+		}
+	}
+	return nil
+}
+
+func TestRecordMethodInvocation(t *testing.T) {
+	recordMethodInvocation("metricsTest", "List", 3*time.Millisecond, nil)
+	recordMethodInvocation("metricsTest", "List", 200*time.Millisecond, errors.New("failed"))
+	recordMethodInvocation("metricsTest", "List", 20*time.Second, nil)
+	recordMethodInvocation("metricsTest", "Read", time.Millisecond, nil)
+
+	stats := findMethodStats("metricsTest", "List")
+	if assert.NotNil(t, stats) {
+		assert.Equal(t, uint64(3), stats.Count)
+		assert.Equal(t, uint64(1), stats.Errors)
+		assert.InDelta(t, 20.203, stats.TotalSeconds, 0.0001)
+		assert.Equal(t, 20.0, stats.MaxSeconds)
+		// The buckets are cumulative, and the 20s invocation is beyond them.
+		assert.Equal(t, []uint64{1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2}, stats.Buckets)
+	}
+	stats = findMethodStats("metricsTest", "Read")
+	if assert.NotNil(t, stats) {
+		assert.Equal(t, uint64(1), stats.Count)
+	}
+
+	// MethodMetrics returns a snapshot
+	stats.Buckets[0] = 100
+	assert.Equal(t, uint64(1), findMethodStats("metricsTest", "Read").Buckets[0])
+}
+
+func TestMethodStatsQuantile(t *testing.T) {
+	stats := MethodStats{Count: 4, MaxSeconds: 20, Buckets: []uint64{1, 1, 1, 1, 1, 3, 3, 3, 3, 3, 3}}
+	assert.Equal(t, 0.005, stats.Quantile(0.25))
+	assert.Equal(t, 0.25, stats.Quantile(0.5))
+	assert.Equal(t, 20.0, stats.Quantile(0.95))
+
+	// Estimates don't exceed the slowest invocation
+	stats = MethodStats{Count: 1, MaxSeconds: 0.3, Buckets: []uint64{0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1}}
+	assert.Equal(t, 0.3, stats.Quantile(0.95))
+
+	assert.Equal(t, 0.0, MethodStats{}.Quantile(0.95))
+}
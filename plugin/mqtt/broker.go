@@ -0,0 +1,195 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// discoveryWindow is how long the broker subscribes to '#' when discovering
+// topics
+const discoveryWindow = 2 * time.Second
+
+type broker struct {
+	plugin.EntryBase
+	url *url.URL
+}
+
+func newBroker(name string, brokerURL string) (*broker, error) {
+	u, err := url.Parse(brokerURL)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "tcp", "ssl", "tls", "ws", "wss":
+	default:
+		return nil, fmt.Errorf("expected a tcp://, ssl://, ws:// or wss:// URL, not %v", brokerURL)
+	}
+	b := &broker{
+		EntryBase: plugin.NewEntry(name),
+	}
+	b.url = u
+	b.SetTTLOf(plugin.ListOp, 30*time.Second)
+	return b, nil
+}
+
+func (b *broker) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(b, "broker").
+		SetDescription(brokerDescription)
+}
+
+func (b *broker) ChildSchemas() []*plugin.EntrySchema {
+	return topicChildSchemas()
+}
+
+// messageInfo is a topic's partial metadata. It describes the last message
+// that was seen on the topic during discovery.
+type messageInfo struct {
+	Topic    string    `json:"topic"`
+	Retained bool      `json:"retained"`
+	QoS      byte      `json:"qos"`
+	Size     int       `json:"size"`
+	Received time.Time `json:"received"`
+}
+
+// topicNode is a level in the topic tree
+type topicNode struct {
+	children map[string]*topicNode
+	msg      *messageInfo
+}
+
+func (n *topicNode) child(name string) *topicNode {
+	if n.children == nil {
+		n.children = make(map[string]*topicNode)
+	}
+	child, ok := n.children[name]
+	if !ok {
+		child = &topicNode{}
+		n.children[name] = child
+	}
+	return child
+}
+
+// List discovers the broker's topics and lists the top level of the tree
+func (b *broker) List(ctx context.Context) ([]plugin.Entry, error) {
+	client, err := connect(ctx, b.url)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Disconnect(0)
+
+	var mux sync.Mutex
+	root := &topicNode{}
+	handler := func(_ paho.Client, m paho.Message) {
+		mux.Lock()
+		defer mux.Unlock()
+		node := root
+		for _, level := range strings.Split(m.Topic(), "/") {
+			node = node.child(level)
+		}
+		node.msg = &messageInfo{
+			Topic:    m.Topic(),
+			Retained: m.Retained(),
+			QoS:      m.Qos(),
+			Size:     len(m.Payload()),
+			Received: time.Now(),
+		}
+	}
+	if err := wait(ctx, client.Subscribe("#", 0, handler)); err != nil {
+		return nil, err
+	}
+	select {
+	case <-time.After(discoveryWindow):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	client.Unsubscribe("#").WaitTimeout(connectTimeout)
+
+	mux.Lock()
+	defer mux.Unlock()
+	entries := topicEntries(b.url, "", root)
+	activity.Record(ctx, "Discovered %v top-level topics on %v", len(entries), b.Name())
+	return entries, nil
+}
+
+// topicEntries returns the entries for node's children. A child with a
+// message is a topic file. A child with subtopics is a directory; if it's
+// also a topic, then the directory's name ends in '#' (like MQTT's
+// multi-level wildcard) so that it doesn't conflict with the file.
+func topicEntries(u *url.URL, prefix string, node *topicNode) []plugin.Entry {
+	names := make([]string, 0, len(node.children))
+	for name := range node.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var entries []plugin.Entry
+	for _, name := range names {
+		child := node.children[name]
+		topic := prefix + name
+		if child.msg != nil {
+			entries = append(entries, newTopic(u, name, *child.msg))
+		}
+		if len(child.children) > 0 {
+			dirName := name
+			if child.msg != nil {
+				dirName += "#"
+			}
+			entries = append(entries, newTopicDir(u, dirName, topic+"/", child))
+		}
+	}
+	return entries
+}
+
+type topicDir struct {
+	plugin.EntryBase
+	url    *url.URL
+	prefix string
+	node   *topicNode
+}
+
+func newTopicDir(u *url.URL, name string, prefix string, node *topicNode) *topicDir {
+	d := &topicDir{
+		EntryBase: plugin.NewEntry(name),
+	}
+	d.url = u
+	d.prefix = prefix
+	d.node = node
+	return d
+}
+
+func (d *topicDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(d, "topics")
+}
+
+func (d *topicDir) ChildSchemas() []*plugin.EntrySchema {
+	return topicChildSchemas()
+}
+
+// List lists the subtopics that were discovered when the broker was listed
+func (d *topicDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	return topicEntries(d.url, d.prefix, d.node), nil
+}
+
+func topicChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&topicDir{}).Schema(),
+		(&topic{}).Schema(),
+	}
+}
+
+const brokerDescription = `
+This is an MQTT broker. Its topic tree is rediscovered at most every 30
+seconds by subscribing to '#' for a couple of seconds. If a topic has both
+a message and subtopics, then its subtopics are listed in a directory whose
+name ends in '#'.
+`
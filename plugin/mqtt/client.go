@@ -0,0 +1,58 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/google/uuid"
+	"github.com/puppetlabs/wash/activity"
+)
+
+// connectTimeout is how long to wait when connecting to a broker
+const connectTimeout = 10 * time.Second
+
+// connect connects to the broker. Each operation uses its own connection so
+// that concurrent subscriptions to the same topic don't interfere with each
+// other.
+func connect(ctx context.Context, brokerURL *url.URL) (paho.Client, error) {
+	u := *brokerURL
+	opts := paho.NewClientOptions().
+		SetClientID("wash-" + uuid.New().String()).
+		SetConnectTimeout(connectTimeout).
+		SetAutoReconnect(false)
+	if u.User != nil {
+		password, _ := u.User.Password()
+		opts.SetUsername(u.User.Username()).SetPassword(password)
+		u.User = nil
+	}
+	opts.AddBroker(u.String())
+
+	client := paho.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(connectTimeout) {
+		return nil, fmt.Errorf("timed out connecting to %v", u.Host)
+	}
+	if err := token.Error(); err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Connected to MQTT broker %v", u.Host)
+	return client, nil
+}
+
+// wait waits for the token to complete or for the context to be cancelled
+func wait(ctx context.Context, token paho.Token) error {
+	done := make(chan struct{})
+	go func() {
+		token.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
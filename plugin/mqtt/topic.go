@@ -0,0 +1,119 @@
+package mqtt
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// retainedWait is how long Read waits for a topic's retained message
+const retainedWait = 1 * time.Second
+
+type topic struct {
+	plugin.EntryBase
+	url   *url.URL
+	topic string
+}
+
+func newTopic(u *url.URL, name string, info messageInfo) *topic {
+	t := &topic{
+		EntryBase: plugin.NewEntry(name),
+	}
+	t.url = u
+	t.topic = info.Topic
+	t.DisableCachingFor(plugin.ReadOp)
+	t.
+		SetPartialMetadata(info).
+		Attributes().
+		SetMtime(info.Received).
+		SetSize(uint64(info.Size))
+	return t
+}
+
+func (t *topic) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(t, "topic").
+		SetDescription(topicDescription).
+		SetPartialMetadataSchema(messageInfo{})
+}
+
+// Read returns the topic's retained message, or nothing if it doesn't have one
+func (t *topic) Read(ctx context.Context) ([]byte, error) {
+	client, err := connect(ctx, t.url)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Disconnect(0)
+
+	retained := make(chan []byte, 1)
+	handler := func(_ paho.Client, m paho.Message) {
+		if m.Retained() {
+			select {
+			case retained <- m.Payload():
+			default:
+			}
+		}
+	}
+	if err := wait(ctx, client.Subscribe(t.topic, 1, handler)); err != nil {
+		return nil, err
+	}
+	// Retained messages are sent as soon as the subscription's acknowledged.
+	select {
+	case payload := <-retained:
+		return payload, nil
+	case <-time.After(retainedWait):
+		activity.Record(ctx, "Topic %v has no retained message", t.topic)
+		return []byte{}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Stream subscribes to the topic. Each message is written on its own line.
+func (t *topic) Stream(ctx context.Context) (io.ReadCloser, error) {
+	client, err := connect(ctx, t.url)
+	if err != nil {
+		return nil, err
+	}
+
+	r, w := io.Pipe()
+	handler := func(_ paho.Client, m paho.Message) {
+		if _, err := w.Write(append(m.Payload(), '\n')); err != nil {
+			// The reader was closed.
+			activity.Record(ctx, "Stopped streaming %v: %v", t.topic, err)
+		}
+	}
+	if err := wait(ctx, client.Subscribe(t.topic, 0, handler)); err != nil {
+		client.Disconnect(0)
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		client.Disconnect(0)
+		activity.Record(ctx, "Closing write pipe: %v", w.Close())
+	}()
+	return r, nil
+}
+
+// Write publishes the data to the topic with QoS 1. The message isn't retained.
+func (t *topic) Write(ctx context.Context, b []byte) error {
+	client, err := connect(ctx, t.url)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(250)
+	activity.Record(ctx, "Publishing %v bytes to %v", len(b), t.topic)
+	return wait(ctx, client.Publish(t.topic, 1, false, b))
+}
+
+const topicDescription = `
+This is an MQTT topic. Reading it returns its retained message (if any),
+tailing it subscribes to it, and writing to it publishes a message with
+QoS 1. Its metadata describes the last message that was seen when the topic
+tree was discovered.
+`
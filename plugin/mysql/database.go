@@ -0,0 +1,130 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/sqlquery"
+)
+
+type databaseInfo struct {
+	Name         string `json:"name"`
+	CharacterSet string `json:"character_set"`
+	Collation    string `json:"collation"`
+	Size         int64  `json:"size"`
+}
+
+type database struct {
+	plugin.EntryBase
+	server *server
+}
+
+func newDatabase(srv *server, info databaseInfo) *database {
+	db := &database{
+		EntryBase: plugin.NewEntry(info.Name),
+	}
+	db.server = srv
+	db.
+		SetPartialMetadata(info).
+		Attributes().
+		SetSize(uint64(info.Size))
+	return db
+}
+
+func (d *database) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(d, "database").
+		SetDescription(databaseDescription).
+		SetPartialMetadataSchema(databaseInfo{})
+}
+
+func (d *database) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&table{}).Schema(),
+	}
+}
+
+// List lists the database's tables and views
+func (d *database) List(ctx context.Context) ([]plugin.Entry, error) {
+	db, err := d.server.db(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, `
+		SELECT TABLE_NAME, TABLE_TYPE, ENGINE, TABLE_ROWS, DATA_LENGTH, INDEX_LENGTH, CREATE_TIME, UPDATE_TIME
+		FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = ?
+		ORDER BY TABLE_NAME`, d.Name())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []plugin.Entry
+	for rows.Next() {
+		info := tableInfo{Database: d.Name()}
+		var dataLength, indexLength *int64
+		var createTime, updateTime *string
+		err := rows.Scan(
+			&info.Name,
+			&info.Type,
+			&info.Engine,
+			&info.EstimatedRows,
+			&dataLength,
+			&indexLength,
+			&createTime,
+			&updateTime,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if dataLength != nil {
+			info.DataSize = *dataLength
+		}
+		if indexLength != nil {
+			info.IndexSize = *indexLength
+		}
+		info.CreateTime = parseTime(createTime)
+		info.UpdateTime = parseTime(updateTime)
+		entries = append(entries, newTable(d, info))
+	}
+	return entries, rows.Err()
+}
+
+// Exec runs the SQL formed by joining cmd and args on the database.
+func (d *database) Exec(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	db, err := d.server.db(ctx, d.Name())
+	if err != nil {
+		return nil, err
+	}
+	query := strings.Join(append([]string{cmd}, args...), " ")
+	activity.Record(ctx, "Running %q on %v", query, d)
+	return sqlquery.Run(ctx, db, query), nil
+}
+
+// parseTime parses a MySQL DATETIME. We scan DATETIMEs as strings because
+// the driver only returns time.Time values when the DSN sets parseTime=true.
+func parseTime(s *string) time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	t, err := time.Parse("2006-01-02 15:04:05", *s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+const databaseDescription = `
+This is a MySQL database. Its children are the database's tables and views.
+You can run SQL on the database via the 'exec' action, e.g.
+
+  wash exec mysql/local/databases/mydb 'SELECT count(*) FROM users'
+
+The results are streamed to stdout as tab-separated rows preceded by a
+header row. If the query fails, the error is written to stderr and the
+command exits with status 1.
+`
@@ -0,0 +1,59 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type databasesDir struct {
+	plugin.EntryBase
+	server *server
+}
+
+func newDatabasesDir(srv *server) *databasesDir {
+	databasesDir := &databasesDir{
+		EntryBase: plugin.NewEntry("databases"),
+	}
+	databasesDir.server = srv
+	return databasesDir
+}
+
+func (ds *databasesDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(ds, "databases").IsSingleton()
+}
+
+func (ds *databasesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&database{}).Schema(),
+	}
+}
+
+// List lists the server's databases
+func (ds *databasesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	db, err := ds.server.db(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, `
+		SELECT s.SCHEMA_NAME, s.DEFAULT_CHARACTER_SET_NAME, s.DEFAULT_COLLATION_NAME,
+			COALESCE(SUM(t.DATA_LENGTH + t.INDEX_LENGTH), 0)
+		FROM information_schema.SCHEMATA s
+		LEFT JOIN information_schema.TABLES t ON t.TABLE_SCHEMA = s.SCHEMA_NAME
+		GROUP BY s.SCHEMA_NAME, s.DEFAULT_CHARACTER_SET_NAME, s.DEFAULT_COLLATION_NAME
+		ORDER BY s.SCHEMA_NAME`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []plugin.Entry
+	for rows.Next() {
+		var info databaseInfo
+		if err := rows.Scan(&info.Name, &info.CharacterSet, &info.Collation, &info.Size); err != nil {
+			return nil, err
+		}
+		entries = append(entries, newDatabase(ds.server, info))
+	}
+	return entries, rows.Err()
+}
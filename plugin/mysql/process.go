@@ -0,0 +1,75 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type processInfo struct {
+	ID       int64   `json:"id"`
+	User     string  `json:"user"`
+	Host     string  `json:"host"`
+	Database *string `json:"database"`
+	Command  string  `json:"command"`
+	Time     int64   `json:"time"`
+	State    *string `json:"state"`
+	Info     *string `json:"info"`
+}
+
+// process represents a thread in the server's processlist
+type process struct {
+	plugin.EntryBase
+	server *server
+	id     int64
+}
+
+func newProcess(srv *server, info processInfo) *process {
+	p := &process{
+		EntryBase: plugin.NewEntry(strconv.FormatInt(info.ID, 10)),
+	}
+	p.server = srv
+	p.id = info.ID
+	p.SetPartialMetadata(info)
+	return p
+}
+
+func (p *process) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(p, "process").
+		SetDescription(processDescription).
+		SetPartialMetadataSchema(processInfo{}).
+		AddSignal("cancel", "Kills the process' current statement. Equivalent to 'KILL QUERY <id>'").
+		AddSignal("kill", "Kills the process' connection. Equivalent to 'KILL <id>'")
+}
+
+func (p *process) Signal(ctx context.Context, signal string) error {
+	var stmt string
+	switch signal {
+	case "cancel":
+		stmt = "KILL QUERY"
+	case "kill":
+		stmt = "KILL"
+	default:
+		return fmt.Errorf("unsupported signal %v", signal)
+	}
+
+	db, err := p.server.db(ctx, "")
+	if err != nil {
+		return err
+	}
+	activity.Record(ctx, "Running %v on process %v", stmt, p.id)
+	_, err = db.ExecContext(ctx, fmt.Sprintf("%v %d", stmt, p.id))
+	return err
+}
+
+const processDescription = `
+This is a thread in a MySQL server's processlist. Its metadata includes the
+thread's user, host, database and current statement. Processes support the
+'cancel' and 'kill' signals, e.g.
+
+  signal kill mysql/local/processlist/1234
+`
@@ -0,0 +1,70 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type processlistDir struct {
+	plugin.EntryBase
+	server *server
+}
+
+func newProcesslistDir(srv *server) *processlistDir {
+	processlistDir := &processlistDir{
+		EntryBase: plugin.NewEntry("processlist"),
+	}
+	processlistDir.server = srv
+	// Connections come and go quickly, so keep the cached list short-lived.
+	processlistDir.SetTTLOf(plugin.ListOp, 5*time.Second)
+	return processlistDir
+}
+
+func (ps *processlistDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(ps, "processlist").IsSingleton()
+}
+
+func (ps *processlistDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&process{}).Schema(),
+	}
+}
+
+// List lists the server's threads, excluding our own
+func (ps *processlistDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	db, err := ps.server.db(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, `
+		SELECT ID, USER, HOST, DB, COMMAND, TIME, STATE, INFO
+		FROM information_schema.PROCESSLIST
+		WHERE ID <> CONNECTION_ID()
+		ORDER BY ID`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []plugin.Entry
+	for rows.Next() {
+		var info processInfo
+		err := rows.Scan(
+			&info.ID,
+			&info.User,
+			&info.Host,
+			&info.Database,
+			&info.Command,
+			&info.Time,
+			&info.State,
+			&info.Info,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, newProcess(ps.server, info))
+	}
+	return entries, rows.Err()
+}
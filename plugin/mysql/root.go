@@ -0,0 +1,83 @@
+// Package mysql presents a filesystem hierarchy for MySQL servers.
+//
+// Servers are configured in wash.yaml as a map of names to DSNs. Each
+// server exposes its databases, which can be exec'ed to run SQL, and its
+// processlist.
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Root of the mysql plugin
+type Root struct {
+	plugin.EntryBase
+	servers []plugin.Entry
+}
+
+// Init for root
+func (r *Root) Init(cfg map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("mysql")
+	r.DisableDefaultCaching()
+
+	r.servers = []plugin.Entry{}
+	return plugin.ParseNamedConfigs("mysql", cfg, "servers", func(name string, config interface{}) error {
+		dsn, ok := config.(string)
+		if !ok {
+			return fmt.Errorf("expected a DSN, not %v", config)
+		}
+		srv, err := newServer(name, dsn)
+		if err != nil {
+			return err
+		}
+		r.servers = append(r.servers, srv)
+		return nil
+	})
+}
+
+// Schema returns the root's schema
+func (r *Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "mysql").
+		SetDescription(rootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&server{}).Schema(),
+	}
+}
+
+// List lists the configured servers.
+func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	return r.servers, nil
+}
+
+const rootDescription = `
+This is the mysql plugin root. It lists the MySQL servers configured in
+wash.yaml, e.g.
+
+  mysql:
+    servers:
+      local: root:password@tcp(localhost:3306)/
+      prod: keyring:prod-mysql-dsn
+
+Like any plugin config value, a DSN can refer to a secret in the OS
+keychain or in Vault, e.g. 'keyring:<service>'. See the config docs'
+secrets section for the supported references.
+
+Each server includes its databases and its processlist. Databases support
+the 'exec' action so that you can run SQL against them
+
+  wash exec mysql/local/databases/mydb 'SELECT * FROM users LIMIT 10'
+
+The results are streamed back as tab-separated rows. Tables include their
+row-count estimates and sizes as metadata, and reading a table returns
+its 'SHOW CREATE TABLE' output. Processlist entries can be killed via the
+'signal' action.
+`
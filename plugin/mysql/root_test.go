@@ -0,0 +1,89 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInit(t *testing.T) {
+	r := &Root{}
+	assert.NoError(t, r.Init(map[string]interface{}{}))
+	assert.Empty(t, r.servers)
+
+	err := r.Init(map[string]interface{}{
+		"servers": map[string]interface{}{
+			"remote": "admin:secret@tcp(db.example.com:3307)/app",
+			"local":  "root@tcp(localhost:3306)/",
+		},
+	})
+	if assert.NoError(t, err) {
+		entries, err := r.List(context.Background())
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, "local", plugin.Name(entries[0]))
+			assert.Equal(t, "remote", plugin.Name(entries[1]))
+		}
+	}
+
+	err = r.Init(map[string]interface{}{
+		"servers": map[string]interface{}{"local": 3306},
+	})
+	assert.EqualError(t, err, "mysql.servers.local config is invalid: expected a DSN, not 3306")
+
+	err = r.Init(map[string]interface{}{
+		"servers": map[string]interface{}{"local": "root@localhost:3306"},
+	})
+	assert.Error(t, err)
+}
+
+func TestNewServer(t *testing.T) {
+	srv, err := newServer("remote", "admin:secret@tcp(db.example.com:3307)/app")
+	if !assert.NoError(t, err) {
+		return
+	}
+	meta := plugin.PartialMetadata(srv)
+	assert.Equal(t, "db.example.com:3307", meta["addr"])
+	assert.Equal(t, "admin", meta["user"])
+	// The password isn't included in the metadata
+	assert.NotContains(t, meta, "passwd")
+}
+
+func TestServerDB(t *testing.T) {
+	srv, err := newServer("remote", "admin:secret@tcp(db.example.com:3307)/app")
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+
+	// Opening a pool doesn't connect, so this doesn't need a server
+	app, err := srv.db(ctx, "")
+	assert.NoError(t, err)
+	other, err := srv.db(ctx, "other")
+	assert.NoError(t, err)
+	assert.NotSame(t, app, other)
+	assert.Contains(t, srv.dbs, "app")
+	assert.Contains(t, srv.dbs, "other")
+	// The configured DSN isn't changed
+	assert.Equal(t, "app", srv.cfg.DBName)
+
+	again, err := srv.db(ctx, "app")
+	assert.NoError(t, err)
+	assert.Same(t, app, again)
+}
+
+func TestParseTime(t *testing.T) {
+	assert.Equal(t, time.Time{}, parseTime(nil))
+	invalid := "yesterday"
+	assert.Equal(t, time.Time{}, parseTime(&invalid))
+	valid := "2020-04-22 22:49:57"
+	assert.Equal(t, time.Date(2020, 4, 22, 22, 49, 57, 0, time.UTC), parseTime(&valid))
+}
+
+func TestQuoteIdent(t *testing.T) {
+	assert.Equal(t, "`users`", quoteIdent("users"))
+	assert.Equal(t, "`my``table`", quoteIdent("my`table"))
+}
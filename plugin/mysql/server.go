@@ -0,0 +1,124 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type serverMetadata struct {
+	Addr string `json:"addr"`
+	User string `json:"user"`
+}
+
+// server represents a configured MySQL server
+type server struct {
+	plugin.EntryBase
+	mux sync.Mutex
+	cfg *mysql.Config
+	// dbs maps database names to their connection pools. We use a pool per
+	// database so that exec'ed SQL runs with that database selected.
+	dbs map[string]*sql.DB
+}
+
+func newServer(name string, dsn string) (*server, error) {
+	srv := &server{
+		EntryBase: plugin.NewEntry(name),
+		dbs:       make(map[string]*sql.DB),
+	}
+	srv.DisableDefaultCaching()
+	// Parse the DSN now to surface config errors early.
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	srv.cfg = cfg
+	srv.SetPartialMetadata(serverMetadata{Addr: cfg.Addr, User: cfg.User})
+	return srv, nil
+}
+
+func (s *server) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "server").
+		SetDescription(serverDescription).
+		SetPartialMetadataSchema(serverMetadata{})
+}
+
+func (s *server) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&databasesDir{}).Schema(),
+		(&processlistDir{}).Schema(),
+	}
+}
+
+// List lists the databases and processlist directories
+func (s *server) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{
+		newDatabasesDir(s),
+		newProcesslistDir(s),
+	}, nil
+}
+
+// Metadata includes the server's version and global variables.
+func (s *server) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	db, err := s.db(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	meta := plugin.ToJSONObject(serverMetadata{Addr: s.cfg.Addr, User: s.cfg.User})
+	var version string
+	if err := db.QueryRowContext(ctx, "SELECT VERSION()").Scan(&version); err != nil {
+		return nil, err
+	}
+	meta["version"] = version
+
+	rows, err := db.QueryContext(ctx, "SHOW GLOBAL VARIABLES")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	variables := make(map[string]string)
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, err
+		}
+		variables[name] = value
+	}
+	meta["variables"] = variables
+	return meta, rows.Err()
+}
+
+// db returns the connection pool for the named database. An empty name
+// returns the pool for the database in the configured DSN.
+func (s *server) db(ctx context.Context, name string) (*sql.DB, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	cfg := s.cfg
+	if name == "" {
+		name = cfg.DBName
+	}
+	if db, ok := s.dbs[name]; ok {
+		return db, nil
+	}
+
+	dbCfg := cfg.Clone()
+	dbCfg.DBName = name
+	activity.Record(ctx, "Connecting to database %q on %v", name, s)
+	db, err := sql.Open("mysql", dbCfg.FormatDSN())
+	if err != nil {
+		return nil, err
+	}
+	s.dbs[name] = db
+	return db, nil
+}
+
+const serverDescription = `
+This is a MySQL server. Its metadata includes the server version and its
+global variables.
+`
@@ -0,0 +1,95 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type tableInfo struct {
+	Name          string    `json:"name"`
+	Database      string    `json:"database"`
+	Type          string    `json:"type"`
+	Engine        *string   `json:"engine"`
+	EstimatedRows *int64    `json:"estimated_rows"`
+	DataSize      int64     `json:"data_size"`
+	IndexSize     int64     `json:"index_size"`
+	CreateTime    time.Time `json:"create_time"`
+	UpdateTime    time.Time `json:"update_time"`
+}
+
+type table struct {
+	plugin.EntryBase
+	database *database
+}
+
+func newTable(d *database, info tableInfo) *table {
+	t := &table{
+		EntryBase: plugin.NewEntry(info.Name),
+	}
+	t.database = d
+	attr := t.
+		SetPartialMetadata(info).
+		Attributes().
+		SetCrtime(info.CreateTime).
+		SetCtime(info.CreateTime).
+		SetMtime(info.CreateTime)
+	if !info.UpdateTime.IsZero() {
+		attr.SetMtime(info.UpdateTime)
+	}
+	return t
+}
+
+func (t *table) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(t, "table").
+		SetDescription(tableDescription).
+		SetPartialMetadataSchema(tableInfo{})
+}
+
+// Read returns the table's 'SHOW CREATE TABLE' output
+func (t *table) Read(ctx context.Context) ([]byte, error) {
+	db, err := t.database.server.db(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, "SHOW CREATE TABLE "+quoteIdent(t.database.Name())+"."+quoteIdent(t.Name()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Views return four columns instead of two, so scan generically.
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return []byte{}, nil
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return []byte(values[1] + ";\n"), nil
+}
+
+// quoteIdent quotes a MySQL identifier
+func quoteIdent(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+const tableDescription = `
+This is a MySQL table or view. Its metadata includes the table's engine,
+an estimate of the number of rows, and its data and index sizes. Reading
+the table returns its 'SHOW CREATE TABLE' output.
+`
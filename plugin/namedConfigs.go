@@ -0,0 +1,41 @@
+package plugin
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ParseNamedConfigs parses a plugin's map of names to configs, like the
+// servers in
+//
+//   postgres:
+//     servers:
+//       local: postgres://localhost:5432/postgres
+//
+// cfg is the plugin's config, which is passed to Init, and key is the map's
+// key in cfg. ParseNamedConfigs calls parse on each of the map's configs in
+// name order. Errors are prefixed with the config's location, e.g.
+// "postgres.servers.local config is invalid: ...". ParseNamedConfigs does
+// nothing if key isn't set.
+func ParseNamedConfigs(pluginName string, cfg map[string]interface{}, key string, parse func(name string, config interface{}) error) error {
+	configsI, ok := cfg[key]
+	if !ok {
+		return nil
+	}
+	configs, ok := configsI.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%v.%v config must be a map of names to configs, not %s", pluginName, key, configsI)
+	}
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := parse(name, configs[name]); err != nil {
+			return fmt.Errorf("%v.%v.%v config is invalid: %v", pluginName, key, name, err)
+		}
+	}
+	return nil
+}
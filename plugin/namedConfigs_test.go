@@ -0,0 +1,47 @@
+package plugin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNamedConfigs(t *testing.T) {
+	var names []string
+	var configs []interface{}
+	parse := func(name string, config interface{}) error {
+		names = append(names, name)
+		configs = append(configs, config)
+		return nil
+	}
+
+	assert.NoError(t, ParseNamedConfigs("foo", map[string]interface{}{}, "servers", parse))
+	assert.Empty(t, names)
+
+	cfg := map[string]interface{}{
+		"servers": map[string]interface{}{"b": "url-b", "a": "url-a", "c": 3},
+	}
+	assert.NoError(t, ParseNamedConfigs("foo", cfg, "servers", parse))
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+	assert.Equal(t, []interface{}{"url-a", "url-b", 3}, configs)
+}
+
+func TestParseNamedConfigs_Errors(t *testing.T) {
+	parse := func(name string, config interface{}) error {
+		if _, ok := config.(string); !ok {
+			return fmt.Errorf("expected a URL, not %v", config)
+		}
+		return nil
+	}
+
+	cfg := map[string]interface{}{"servers": []interface{}{"a"}}
+	err := ParseNamedConfigs("foo", cfg, "servers", parse)
+	assert.EqualError(t, err, "foo.servers config must be a map of names to configs, not [a]")
+
+	cfg = map[string]interface{}{
+		"servers": map[string]interface{}{"a": "url-a", "b": 3},
+	}
+	err = ParseNamedConfigs("foo", cfg, "servers", parse)
+	assert.EqualError(t, err, "foo.servers.b config is invalid: expected a URL, not 3")
+}
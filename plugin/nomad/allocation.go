@@ -0,0 +1,116 @@
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type allocation struct {
+	plugin.EntryBase
+	client *api.Client
+	stub   *api.AllocationListStub
+}
+
+func newAllocation(client *api.Client, stub *api.AllocationListStub) *allocation {
+	a := &allocation{
+		EntryBase: plugin.NewEntry(stub.ID),
+	}
+	a.client = client
+	a.stub = stub
+	a.
+		SetPartialMetadata(stub).
+		Attributes().
+		SetCrtime(time.Unix(0, stub.CreateTime)).
+		SetMtime(time.Unix(0, stub.ModifyTime)).
+		SetCtime(time.Unix(0, stub.ModifyTime)).
+		SetAtime(time.Unix(0, stub.ModifyTime))
+	return a
+}
+
+func (a *allocation) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(a, "allocation").
+		SetDescription(allocationDescription).
+		SetPartialMetadataSchema(api.AllocationListStub{}).
+		SetMetadataSchema(api.Allocation{}).
+		AddSignal("stop", "Stops the allocation so that it's rescheduled. Equivalent to 'nomad alloc stop <alloc>'").
+		AddSignal("restart", "Restarts the allocation's tasks in place. Equivalent to 'nomad alloc restart <alloc>'")
+}
+
+func (a *allocation) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&task{}).Schema(),
+	}
+}
+
+func (a *allocation) info(ctx context.Context) (*api.Allocation, error) {
+	alloc, _, err := a.client.Allocations().Info(a.stub.ID, (&api.QueryOptions{}).WithContext(ctx))
+	return alloc, err
+}
+
+// Metadata returns the allocation's full info
+func (a *allocation) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	alloc, err := a.info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return plugin.ToJSONObject(alloc), nil
+}
+
+// List lists the allocation's tasks
+func (a *allocation) List(ctx context.Context) ([]plugin.Entry, error) {
+	names := a.taskNames()
+	entries := make([]plugin.Entry, len(names))
+	for i, name := range names {
+		entries[i] = newTask(a.client, a.stub.ID, name, a.stub.TaskStates[name])
+	}
+	return entries, nil
+}
+
+func (a *allocation) taskNames() []string {
+	names := make([]string, 0, len(a.stub.TaskStates))
+	for name := range a.stub.TaskStates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Exec runs the command in the allocation's task. It's only supported for
+// allocations with a single task; otherwise, exec the task directly.
+func (a *allocation) Exec(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	names := a.taskNames()
+	if len(names) != 1 {
+		return nil, fmt.Errorf("allocation %v has %v tasks; exec one of its tasks instead", a.stub.ID, len(names))
+	}
+	return execTask(ctx, a.client, a.stub.ID, names[0], cmd, args, opts)
+}
+
+func (a *allocation) Signal(ctx context.Context, signal string) error {
+	alloc, err := a.info(ctx)
+	if err != nil {
+		return err
+	}
+	switch signal {
+	case "stop":
+		activity.Record(ctx, "Stopping allocation %v", a.stub.ID)
+		_, err = a.client.Allocations().Stop(alloc, (&api.QueryOptions{}).WithContext(ctx))
+	case "restart":
+		activity.Record(ctx, "Restarting allocation %v", a.stub.ID)
+		err = a.client.Allocations().Restart(alloc, "", (&api.QueryOptions{}).WithContext(ctx))
+	default:
+		err = fmt.Errorf("unsupported signal %v", signal)
+	}
+	return err
+}
+
+const allocationDescription = `
+This is a Nomad allocation. It lists the allocation's tasks. Exec'ing an
+allocation runs the command in its task if it only has one.
+`
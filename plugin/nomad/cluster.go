@@ -0,0 +1,65 @@
+package nomad
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type cluster struct {
+	plugin.EntryBase
+	client *api.Client
+}
+
+func newCluster(name string, client *api.Client) *cluster {
+	c := &cluster{
+		EntryBase: plugin.NewEntry(name),
+	}
+	c.client = client
+	c.SetTTLOf(plugin.ListOp, 30*time.Second)
+	return c
+}
+
+func (c *cluster) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(c, "cluster").
+		SetDescription(clusterDescription)
+}
+
+func (c *cluster) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&job{}).Schema(),
+	}
+}
+
+// List lists the cluster's jobs
+func (c *cluster) List(ctx context.Context) ([]plugin.Entry, error) {
+	jobs, _, err := c.client.Jobs().List((&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Listing %v jobs in %v", len(jobs), c)
+	entries := make([]plugin.Entry, len(jobs))
+	for i, stub := range jobs {
+		entries[i] = newJob(c.client, stub)
+	}
+	return entries, nil
+}
+
+// Metadata returns the cluster's agent info
+func (c *cluster) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	self, err := c.client.Agent().Self()
+	if err != nil {
+		return nil, err
+	}
+	return plugin.ToJSONObject(self), nil
+}
+
+const clusterDescription = `
+This is a Nomad cluster. It lists the jobs in the cluster's configured
+region and namespace. Its metadata is the info of the agent that Wash is
+connected to.
+`
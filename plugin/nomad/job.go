@@ -0,0 +1,92 @@
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type job struct {
+	plugin.EntryBase
+	client *api.Client
+	id     string
+}
+
+func newJob(client *api.Client, stub *api.JobListStub) *job {
+	j := &job{
+		EntryBase: plugin.NewEntry(stub.ID),
+	}
+	j.client = client
+	j.id = stub.ID
+	j.SetTTLOf(plugin.ListOp, 15*time.Second)
+	submitTime := time.Unix(0, stub.SubmitTime)
+	j.
+		SetPartialMetadata(stub).
+		Attributes().
+		SetCrtime(submitTime).
+		SetMtime(submitTime).
+		SetCtime(submitTime).
+		SetAtime(submitTime)
+	return j
+}
+
+func (j *job) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(j, "job").
+		SetDescription(jobDescription).
+		SetPartialMetadataSchema(api.JobListStub{}).
+		SetMetadataSchema(api.Job{}).
+		AddSignal("stop", "Stops the job. Equivalent to 'nomad job stop <job>'").
+		AddSignal("purge", "Stops and purges the job. Equivalent to 'nomad job stop -purge <job>'")
+}
+
+func (j *job) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&allocation{}).Schema(),
+	}
+}
+
+// Metadata returns the job's specification
+func (j *job) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	info, _, err := j.client.Jobs().Info(j.id, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return plugin.ToJSONObject(info), nil
+}
+
+// List lists the job's allocations
+func (j *job) List(ctx context.Context) ([]plugin.Entry, error) {
+	allocs, _, err := j.client.Jobs().Allocations(j.id, false, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(allocs))
+	for i, stub := range allocs {
+		entries[i] = newAllocation(j.client, stub)
+	}
+	return entries, nil
+}
+
+func (j *job) Signal(ctx context.Context, signal string) error {
+	var purge bool
+	switch signal {
+	case "stop":
+	case "purge":
+		purge = true
+	default:
+		return fmt.Errorf("unsupported signal %v", signal)
+	}
+	activity.Record(ctx, "Deregistering job %v (purge: %v)", j.id, purge)
+	_, _, err := j.client.Jobs().Deregister(j.id, purge, (&api.WriteOptions{}).WithContext(ctx))
+	return err
+}
+
+const jobDescription = `
+This is a Nomad job. Its metadata is the job's specification, and it lists
+the job's current allocations.
+`
@@ -0,0 +1,127 @@
+// Package nomad presents a filesystem hierarchy for HashiCorp Nomad.
+//
+// It uses Nomad's API client to access the clusters configured in
+// wash.yaml, or the cluster referenced by NOMAD_ADDR if none are.
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Root of the nomad plugin
+type Root struct {
+	plugin.EntryBase
+	clusters []plugin.Entry
+}
+
+// Init for root
+func (r *Root) Init(cfg map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("nomad")
+	r.DisableDefaultCaching()
+
+	r.clusters = []plugin.Entry{}
+	if _, ok := cfg["clusters"]; !ok {
+		if os.Getenv("NOMAD_ADDR") != "" {
+			client, err := api.NewClient(api.DefaultConfig())
+			if err != nil {
+				return fmt.Errorf("could not create a client for NOMAD_ADDR: %v", err)
+			}
+			r.clusters = append(r.clusters, newCluster("default", client))
+		}
+		return nil
+	}
+	return plugin.ParseNamedConfigs("nomad", cfg, "clusters", func(name string, config interface{}) error {
+		clusterConfig, err := parseClusterConfig(config)
+		if err != nil {
+			return err
+		}
+		client, err := api.NewClient(clusterConfig)
+		if err != nil {
+			return err
+		}
+		r.clusters = append(r.clusters, newCluster(name, client))
+		return nil
+	})
+}
+
+// parseClusterConfig parses a cluster's config. Unset fields fall back to
+// Nomad's environment variables (NOMAD_ADDR, NOMAD_TOKEN, etc.).
+func parseClusterConfig(cfgI interface{}) (*api.Config, error) {
+	cfg, ok := cfgI.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a map, not %v", cfgI)
+	}
+	config := api.DefaultConfig()
+	fields := map[string]*string{
+		"address":     &config.Address,
+		"region":      &config.Region,
+		"namespace":   &config.Namespace,
+		"token":       &config.SecretID,
+		"ca_cert":     &config.TLSConfig.CACert,
+		"client_cert": &config.TLSConfig.ClientCert,
+		"client_key":  &config.TLSConfig.ClientKey,
+	}
+	for key, val := range cfg {
+		field, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %v", key)
+		}
+		if *field, ok = val.(string); !ok {
+			return nil, fmt.Errorf("%v must be a string, not %v", key, val)
+		}
+	}
+	return config, nil
+}
+
+// Schema returns the root's schema
+func (r *Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "nomad").
+		SetDescription(rootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&cluster{}).Schema(),
+	}
+}
+
+// List lists the configured clusters.
+func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	return r.clusters, nil
+}
+
+const rootDescription = `
+This is the nomad plugin root. It lists the Nomad clusters configured in
+wash.yaml, e.g.
+
+  nomad:
+    clusters:
+      dev:
+        address: http://localhost:4646
+      prod:
+        address: https://nomad.example.com:4646
+        region: us-west
+        namespace: web
+        token: <ACL token>
+        ca_cert: ~/.nomad/ca.pem
+
+Unset keys fall back to Nomad's environment variables. If no clusters are
+configured, then the cluster referenced by NOMAD_ADDR is listed as 'default'.
+
+Each cluster lists its jobs, each job lists its allocations, and each
+allocation lists its tasks. Tasks include their stdout and stderr logs and
+support exec, so you can do things like
+
+  tail -f nomad/prod/web/<alloc ID>/server/stdout
+  wexec nomad/prod/web/<alloc ID>/server sh
+
+Jobs, allocations and tasks also support signals like 'stop' and 'restart'.
+`
@@ -0,0 +1,132 @@
+package nomad
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInit(t *testing.T) {
+	os.Unsetenv("NOMAD_ADDR")
+	r := &Root{}
+	assert.NoError(t, r.Init(map[string]interface{}{}))
+	assert.Empty(t, r.clusters)
+
+	err := r.Init(map[string]interface{}{
+		"clusters": map[string]interface{}{
+			"prod": map[string]interface{}{"address": "https://nomad.example.com:4646", "region": "east"},
+			"dev":  map[string]interface{}{"address": "http://localhost:4646"},
+		},
+	})
+	if assert.NoError(t, err) {
+		entries, err := r.List(context.Background())
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, "dev", plugin.Name(entries[0]))
+			assert.Equal(t, "prod", plugin.Name(entries[1]))
+		}
+	}
+
+	err = r.Init(map[string]interface{}{"clusters": "http://localhost:4646"})
+	assert.EqualError(t, err, "nomad.clusters config must be a map of names to configs, not http://localhost:4646")
+
+	err = r.Init(map[string]interface{}{
+		"clusters": map[string]interface{}{"dev": map[string]interface{}{"address": 4646}},
+	})
+	assert.EqualError(t, err, "nomad.clusters.dev config is invalid: address must be a string, not 4646")
+}
+
+func TestInit_NomadAddr(t *testing.T) {
+	os.Setenv("NOMAD_ADDR", "http://localhost:4646")
+	defer os.Unsetenv("NOMAD_ADDR")
+
+	r := &Root{}
+	if assert.NoError(t, r.Init(map[string]interface{}{})) && assert.Len(t, r.clusters, 1) {
+		assert.Equal(t, "default", plugin.Name(r.clusters[0]))
+	}
+
+	// Configured clusters take precedence over NOMAD_ADDR
+	assert.NoError(t, r.Init(map[string]interface{}{"clusters": map[string]interface{}{}}))
+	assert.Empty(t, r.clusters)
+}
+
+func TestParseClusterConfig(t *testing.T) {
+	config, err := parseClusterConfig(map[string]interface{}{
+		"address":     "https://nomad.example.com:4646",
+		"region":      "east",
+		"namespace":   "apps",
+		"token":       "secret",
+		"ca_cert":     "/etc/nomad/ca.pem",
+		"client_cert": "/etc/nomad/cli.pem",
+		"client_key":  "/etc/nomad/cli-key.pem",
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "https://nomad.example.com:4646", config.Address)
+		assert.Equal(t, "east", config.Region)
+		assert.Equal(t, "apps", config.Namespace)
+		assert.Equal(t, "secret", config.SecretID)
+		assert.Equal(t, "/etc/nomad/ca.pem", config.TLSConfig.CACert)
+		assert.Equal(t, "/etc/nomad/cli.pem", config.TLSConfig.ClientCert)
+		assert.Equal(t, "/etc/nomad/cli-key.pem", config.TLSConfig.ClientKey)
+	}
+
+	cases := []struct {
+		cfg interface{}
+		err string
+	}{
+		{"http://localhost:4646", "expected a map, not http://localhost:4646"},
+		{map[string]interface{}{"addr": "http://localhost:4646"}, "unknown key addr"},
+		{map[string]interface{}{"token": 5}, "token must be a string, not 5"},
+	}
+	for _, c := range cases {
+		_, err := parseClusterConfig(c.cfg)
+		assert.EqualError(t, err, c.err)
+	}
+}
+
+func TestClusterList(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/jobs", r.URL.Path)
+		_, _ = w.Write([]byte(`[{"ID": "api", "SubmitTime": 1577836800000000000}, {"ID": "web"}]`))
+	}))
+	defer srv.Close()
+
+	config := api.DefaultConfig()
+	config.Address = srv.URL
+	client, err := api.NewClient(config)
+	if !assert.NoError(t, err) {
+		return
+	}
+	entries, err := newCluster("dev", client).List(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, entries, 2) {
+		assert.Equal(t, "api", plugin.Name(entries[0]))
+		assert.Equal(t, int64(1577836800), entries[0].(*job).Attributes().Mtime().Unix())
+		assert.Equal(t, "web", plugin.Name(entries[1]))
+	}
+}
+
+func TestAllocationTasks(t *testing.T) {
+	alloc := newAllocation(nil, &api.AllocationListStub{
+		ID: "a1",
+		TaskStates: map[string]*api.TaskState{
+			"web":     {State: "running"},
+			"sidecar": {State: "running"},
+		},
+	})
+	assert.Equal(t, []string{"sidecar", "web"}, alloc.taskNames())
+
+	entries, err := alloc.List(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, entries, 2) {
+		assert.Equal(t, "sidecar", plugin.Name(entries[0]))
+		assert.Equal(t, "web", plugin.Name(entries[1]))
+	}
+
+	_, err = alloc.Exec(context.Background(), "ls", nil, plugin.ExecOptions{})
+	assert.EqualError(t, err, "allocation a1 has 2 tasks; exec one of its tasks instead")
+}
@@ -0,0 +1,118 @@
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type task struct {
+	plugin.EntryBase
+	client  *api.Client
+	allocID string
+}
+
+func newTask(client *api.Client, allocID string, name string, state *api.TaskState) *task {
+	t := &task{
+		EntryBase: plugin.NewEntry(name),
+	}
+	t.client = client
+	t.allocID = allocID
+	if state != nil {
+		t.
+			SetPartialMetadata(state).
+			Attributes().
+			SetCrtime(state.StartedAt).
+			SetAtime(state.StartedAt)
+		if !state.FinishedAt.IsZero() {
+			t.Attributes().SetMtime(state.FinishedAt)
+		} else {
+			t.Attributes().SetMtime(state.StartedAt)
+		}
+	}
+	return t
+}
+
+func (t *task) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(t, "task").
+		SetDescription(taskDescription).
+		SetPartialMetadataSchema(api.TaskState{}).
+		AddSignal("restart", "Restarts the task in place. Equivalent to 'nomad alloc restart <alloc> <task>'").
+		AddSignalGroup("linux", `\Asig.+`, "Consists of all the supported POSIX signals like SIGHUP, SIGKILL. Equivalent to\n'nomad alloc signal -s <signal> <alloc> <task>'")
+}
+
+func (t *task) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&taskLog{}).Schema(),
+	}
+}
+
+func (t *task) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{
+		newTaskLog(t.client, t.allocID, t.Name(), "stdout"),
+		newTaskLog(t.client, t.allocID, t.Name(), "stderr"),
+	}, nil
+}
+
+func (t *task) Exec(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	return execTask(ctx, t.client, t.allocID, t.Name(), cmd, args, opts)
+}
+
+func (t *task) Signal(ctx context.Context, signal string) error {
+	alloc, _, err := t.client.Allocations().Info(t.allocID, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	q := (&api.QueryOptions{}).WithContext(ctx)
+	if signal == "restart" {
+		activity.Record(ctx, "Restarting task %v in allocation %v", t.Name(), t.allocID)
+		return t.client.Allocations().Restart(alloc, t.Name(), q)
+	}
+	if !strings.HasPrefix(signal, "sig") {
+		return fmt.Errorf("unsupported signal %v", signal)
+	}
+	activity.Record(ctx, "Sending %v to task %v in allocation %v", signal, t.Name(), t.allocID)
+	return t.client.Allocations().Signal(alloc, q, t.Name(), strings.ToUpper(signal))
+}
+
+// execTask runs the command in the allocation's task
+func execTask(ctx context.Context, client *api.Client, allocID string, taskName string, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	alloc, _, err := client.Allocations().Info(allocID, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	execCmd := plugin.NewExecCommand(ctx)
+	stdin := opts.Stdin
+	if stdin == nil {
+		stdin = strings.NewReader("")
+	}
+	execCtx, cancel := context.WithCancel(ctx)
+	execCmd.SetStopFunc(cancel)
+	command := append([]string{cmd}, args...)
+	activity.Record(ctx, "Exec %v on task %v in allocation %v", command, taskName, allocID)
+	go func() {
+		defer cancel()
+		exitCode, err := client.Allocations().Exec(execCtx, alloc, taskName, opts.Tty, command, stdin, execCmd.Stdout(), execCmd.Stderr(), nil, nil)
+		activity.Record(ctx, "Exec on task %v in allocation %v complete: %v, %v", taskName, allocID, exitCode, err)
+		if err != nil {
+			// Set the exit code error so that callers don't block
+			// when trying to retrieve the command's exit code
+			execCmd.SetExitCodeErr(err)
+		} else {
+			execCmd.SetExitCode(exitCode)
+		}
+		execCmd.CloseStreamsWithError(err)
+	}()
+	return execCmd, nil
+}
+
+const taskDescription = `
+This is a task in a Nomad allocation. Its children are the task's stdout and
+stderr logs. Exec'ing a task is equivalent to 'nomad alloc exec -task <task>'.
+`
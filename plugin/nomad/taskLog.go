@@ -0,0 +1,70 @@
+package nomad
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type taskLog struct {
+	plugin.EntryBase
+	client   *api.Client
+	allocID  string
+	taskName string
+	logType  string
+}
+
+func newTaskLog(client *api.Client, allocID string, taskName string, logType string) *taskLog {
+	l := &taskLog{
+		EntryBase: plugin.NewEntry(logType),
+	}
+	l.client = client
+	l.allocID = allocID
+	l.taskName = taskName
+	l.logType = logType
+	l.DisableCachingFor(plugin.ReadOp)
+	return l
+}
+
+func (l *taskLog) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(l, "log").
+		SetDescription(taskLogDescription)
+}
+
+func (l *taskLog) logs(ctx context.Context, follow bool, origin string) (*api.FrameReader, error) {
+	alloc, _, err := l.client.Allocations().Info(l.allocID, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	cancel := make(chan struct{})
+	frames, errCh := l.client.AllocFS().Logs(alloc, follow, l.taskName, l.logType, origin, 0, cancel, (&api.QueryOptions{}).WithContext(ctx))
+	return api.NewFrameReader(frames, errCh, cancel), nil
+}
+
+func (l *taskLog) Read(ctx context.Context) ([]byte, error) {
+	rdr, err := l.logs(ctx, false, "start")
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+	content, err := ioutil.ReadAll(rdr)
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Read %v bytes of %v/%v %v", len(content), l.allocID, l.taskName, l.logType)
+	return content, nil
+}
+
+func (l *taskLog) Stream(ctx context.Context) (io.ReadCloser, error) {
+	return l.logs(ctx, true, "end")
+}
+
+const taskLogDescription = `
+This is a Nomad task's stdout or stderr log. Reading it returns the whole
+log, while tailing it follows new output.
+`
@@ -0,0 +1,79 @@
+package openstack
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type cloud struct {
+	plugin.EntryBase
+	region   string
+	mux      sync.Mutex
+	provider *gophercloud.ProviderClient
+}
+
+func newCloud(name string, region string) *cloud {
+	c := &cloud{
+		EntryBase: plugin.NewEntry(name),
+	}
+	c.region = region
+	c.DisableDefaultCaching()
+	return c
+}
+
+func (c *cloud) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(c, "cloud").
+		SetDescription(cloudDescription)
+}
+
+func (c *cloud) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&serversDir{}).Schema(),
+		(&volumesDir{}).Schema(),
+		(&snapshotsDir{}).Schema(),
+		(&containersDir{}).Schema(),
+		(&networksDir{}).Schema(),
+	}
+}
+
+// List lists the types of resources in the cloud
+func (c *cloud) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{
+		newServersDir(c),
+		newVolumesDir(c),
+		newSnapshotsDir(c),
+		newContainersDir(c),
+		newNetworksDir(c),
+	}, nil
+}
+
+// serviceClientFunc creates a client for a particular service, like
+// openstack.NewComputeV2
+type serviceClientFunc func(*gophercloud.ProviderClient, gophercloud.EndpointOpts) (*gophercloud.ServiceClient, error)
+
+// client returns a client for the service. Authentication happens once per
+// cloud and is shared by all of its services.
+func (c *cloud) client(ctx context.Context, newClient serviceClientFunc) (*gophercloud.ServiceClient, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.provider == nil {
+		activity.Record(ctx, "Authenticating to cloud %v", c.Name())
+		provider, err := clientconfig.AuthenticatedClient(&clientconfig.ClientOpts{Cloud: c.Name()})
+		if err != nil {
+			return nil, err
+		}
+		c.provider = provider
+	}
+	return newClient(c.provider, gophercloud.EndpointOpts{Region: c.region})
+}
+
+const cloudDescription = `
+This is an OpenStack cloud from clouds.yaml. Its children are the cloud's
+servers, volumes, snapshots, Swift containers and networks.
+`
@@ -0,0 +1,81 @@
+package openstack
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type networksDir struct {
+	plugin.EntryBase
+	cloud *cloud
+}
+
+func newNetworksDir(c *cloud) *networksDir {
+	n := &networksDir{
+		EntryBase: plugin.NewEntry("networks"),
+	}
+	n.cloud = c
+	return n
+}
+
+func (n *networksDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(n, "networks").IsSingleton()
+}
+
+func (n *networksDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&network{}).Schema(),
+	}
+}
+
+// List lists the cloud's Neutron networks
+func (n *networksDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	client, err := n.cloud.client(ctx, openstack.NewNetworkV2)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := networks.List(client, networks.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	nets, err := networks.ExtractNetworks(pages)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(nets))
+	for i, net := range nets {
+		entries[i] = newNetwork(net)
+	}
+	return entries, nil
+}
+
+type network struct {
+	plugin.EntryBase
+}
+
+func newNetwork(net networks.Network) *network {
+	name := net.Name
+	if name == "" {
+		name = net.ID
+	}
+	n := &network{
+		EntryBase: plugin.NewEntry(name),
+	}
+	n.SetPartialMetadata(net)
+	return n
+}
+
+func (n *network) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(n, "network").
+		SetDescription(networkDescription).
+		SetPartialMetadataSchema(networks.Network{})
+}
+
+const networkDescription = `
+This is a Neutron network. Its metadata includes the network's status,
+subnets and whether it's shared. Unnamed networks are listed by their ID.
+`
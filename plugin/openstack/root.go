@@ -0,0 +1,75 @@
+// Package openstack presents a filesystem hierarchy for OpenStack clouds.
+//
+// It authenticates using the clouds defined in clouds.yaml, and exposes
+// Nova servers, Cinder volumes and snapshots, Swift containers and Neutron
+// networks.
+package openstack
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Root of the openstack plugin
+type Root struct {
+	plugin.EntryBase
+}
+
+// Init for root
+func (r *Root) Init(map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("openstack")
+	r.DisableDefaultCaching()
+	return nil
+}
+
+// Schema returns the root's schema
+func (r *Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "openstack").
+		SetDescription(rootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&cloud{}).Schema(),
+	}
+}
+
+// List lists the clouds in clouds.yaml
+func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	clouds, err := clientconfig.LoadCloudsYAML()
+	if err != nil {
+		// clouds.yaml is optional, so treat a missing file as no clouds.
+		activity.Record(ctx, "Could not load clouds.yaml: %v", err)
+		return []plugin.Entry{}, nil
+	}
+	names := make([]string, 0, len(clouds))
+	for name := range clouds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entries := make([]plugin.Entry, len(names))
+	for i, name := range names {
+		entries[i] = newCloud(name, clouds[name].RegionName)
+	}
+	return entries, nil
+}
+
+const rootDescription = `
+This is the openstack plugin root. It lists the clouds defined in
+clouds.yaml, which is searched for in the current directory,
+~/.config/openstack and /etc/openstack (or the path in OS_CLIENT_CONFIG_FILE).
+Passwords can also be kept in secure.yaml, as with the openstack CLI.
+
+Each cloud includes its Nova servers, Cinder volumes and snapshots, Swift
+containers and Neutron networks. Servers include their console log and
+support power signals like 'start', 'stop' and 'restart'. Swift objects can
+be read, written and deleted.
+`
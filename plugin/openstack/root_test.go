@@ -0,0 +1,59 @@
+package openstack
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "openstack")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	cloudsYAML := filepath.Join(dir, "clouds.yaml")
+	err = ioutil.WriteFile(cloudsYAML, []byte(`
+clouds:
+  prod:
+    region_name: RegionOne
+    auth:
+      auth_url: https://keystone.example.com:5000/v3
+  dev:
+    auth:
+      auth_url: http://localhost:5000/v3
+`), 0600)
+	if !assert.NoError(t, err) {
+		return
+	}
+	os.Setenv("OS_CLIENT_CONFIG_FILE", cloudsYAML)
+	defer os.Unsetenv("OS_CLIENT_CONFIG_FILE")
+
+	r := &Root{}
+	if !assert.NoError(t, r.Init(nil)) {
+		return
+	}
+	entries, err := r.List(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, entries, 2) {
+		assert.Equal(t, "dev", plugin.Name(entries[0]))
+		assert.Equal(t, "", entries[0].(*cloud).region)
+		assert.Equal(t, "prod", plugin.Name(entries[1]))
+		assert.Equal(t, "RegionOne", entries[1].(*cloud).region)
+	}
+}
+
+func TestCloudList(t *testing.T) {
+	entries, err := newCloud("prod", "RegionOne").List(context.Background())
+	if assert.NoError(t, err) {
+		var names []string
+		for _, entry := range entries {
+			names = append(names, plugin.Name(entry))
+		}
+		assert.Equal(t, []string{"servers", "volumes", "snapshots", "containers", "networks"}, names)
+	}
+}
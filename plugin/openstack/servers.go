@@ -0,0 +1,209 @@
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/startstop"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type serversDir struct {
+	plugin.EntryBase
+	cloud *cloud
+}
+
+func newServersDir(c *cloud) *serversDir {
+	s := &serversDir{
+		EntryBase: plugin.NewEntry("servers"),
+	}
+	s.cloud = c
+	s.SetTTLOf(plugin.ListOp, 30*time.Second)
+	return s
+}
+
+func (s *serversDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(s, "servers").IsSingleton()
+}
+
+func (s *serversDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&server{}).Schema(),
+	}
+}
+
+// List lists the cloud's Nova servers
+func (s *serversDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	client, err := s.cloud.client(ctx, openstack.NewComputeV2)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := servers.List(client, servers.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	srvs, err := servers.ExtractServers(pages)
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Listing %v servers in %v", len(srvs), s.cloud.Name())
+	entries := make([]plugin.Entry, len(srvs))
+	for i, srv := range srvs {
+		entries[i] = newServer(client, srv)
+	}
+	return entries, nil
+}
+
+type server struct {
+	plugin.EntryBase
+	client *gophercloud.ServiceClient
+	id     string
+}
+
+func newServer(client *gophercloud.ServiceClient, srv servers.Server) *server {
+	s := &server{
+		EntryBase: plugin.NewEntry(srv.Name),
+	}
+	s.client = client
+	s.id = srv.ID
+	// Don't expose the admin password in the metadata.
+	srv.AdminPass = ""
+	s.
+		SetPartialMetadata(srv).
+		Attributes().
+		SetCrtime(srv.Created).
+		SetMtime(srv.Updated).
+		SetCtime(srv.Updated).
+		SetAtime(srv.Updated)
+	return s
+}
+
+func (s *server) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "server").
+		SetDescription(serverDescription).
+		SetPartialMetadataSchema(servers.Server{}).
+		AddSignal("start", "Starts the server").
+		AddSignal("stop", "Stops the server").
+		AddSignal("restart", "Gracefully reboots the server").
+		AddSignal("hard_restart", "Reboots the server by power cycling it")
+}
+
+func (s *server) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&consoleLog{}).Schema(),
+		(&plugin.MetadataJSONFile{}).Schema(),
+	}
+}
+
+func (s *server) List(ctx context.Context) ([]plugin.Entry, error) {
+	meta, err := plugin.NewMetadataJSONFile(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	return []plugin.Entry{newConsoleLog(s.client, s.id), meta}, nil
+}
+
+func (s *server) Signal(ctx context.Context, signal string) error {
+	var err error
+	switch signal {
+	case "start":
+		err = startstop.Start(s.client, s.id).ExtractErr()
+	case "stop":
+		err = startstop.Stop(s.client, s.id).ExtractErr()
+	case "restart":
+		err = servers.Reboot(s.client, s.id, servers.RebootOpts{Type: servers.SoftReboot}).ExtractErr()
+	case "hard_restart":
+		err = servers.Reboot(s.client, s.id, servers.RebootOpts{Type: servers.HardReboot}).ExtractErr()
+	default:
+		return fmt.Errorf("unsupported signal %v", signal)
+	}
+	if err == nil {
+		activity.Record(ctx, "Sent %v to server %v", signal, s.id)
+	}
+	return err
+}
+
+// consoleLog is the server's console output
+type consoleLog struct {
+	plugin.EntryBase
+	client *gophercloud.ServiceClient
+	id     string
+}
+
+func newConsoleLog(client *gophercloud.ServiceClient, id string) *consoleLog {
+	l := &consoleLog{
+		EntryBase: plugin.NewEntry("console.out"),
+	}
+	l.client = client
+	l.id = id
+	l.DisableCachingFor(plugin.ReadOp)
+	return l
+}
+
+func (l *consoleLog) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(l, "console.out").IsSingleton()
+}
+
+func (l *consoleLog) Read(ctx context.Context) ([]byte, error) {
+	output, err := servers.ShowConsoleOutput(l.client, l.id, servers.ShowConsoleOutputOpts{}).Extract()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(output), nil
+}
+
+// consoleLogPollInterval is how often Stream checks for new console output
+const consoleLogPollInterval = 2 * time.Second
+
+// Stream polls the console output and writes whatever's new since the last
+// poll. Nova doesn't support following the console log.
+func (l *consoleLog) Stream(ctx context.Context) (io.ReadCloser, error) {
+	output, err := l.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		seen := len(output)
+		ticker := time.NewTicker(consoleLogPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				activity.Record(ctx, "Closing write pipe: %v", w.Close())
+				return
+			case <-ticker.C:
+			}
+			output, err := l.Read(ctx)
+			if err != nil {
+				activity.Record(ctx, "Errored reading console output of server %v: %v", l.id, err)
+				activity.Record(ctx, "Closing write pipe: %v", w.CloseWithError(err))
+				return
+			}
+			if len(output) < seen {
+				// The log was truncated or rotated, so start over.
+				seen = 0
+			}
+			if _, err := w.Write(output[seen:]); err != nil {
+				// The reader was closed.
+				return
+			}
+			seen = len(output)
+		}
+	}()
+	return r, nil
+}
+
+const serverDescription = `
+This is a Nova server. Its children include the server's console output,
+which can be tailed. It supports the 'start', 'stop', 'restart' and
+'hard_restart' signals.
+`
@@ -0,0 +1,33 @@
+package openstack
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewServer(t *testing.T) {
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	s := newServer(nil, servers.Server{
+		ID:        "abc",
+		Name:      "web01",
+		AdminPass: "hunter2",
+		Created:   created,
+		Updated:   updated,
+	})
+	assert.Equal(t, "web01", plugin.Name(s))
+	assert.Equal(t, "abc", s.id)
+	assert.Equal(t, created, s.Attributes().Crtime())
+	assert.Equal(t, updated, s.Attributes().Mtime())
+	assert.Equal(t, "", plugin.PartialMetadata(s)["adminPass"])
+}
+
+func TestServerSignal_Unsupported(t *testing.T) {
+	s := newServer(nil, servers.Server{ID: "abc", Name: "web01"})
+	assert.EqualError(t, s.Signal(context.Background(), "hibernate"), "unsupported signal hibernate")
+}
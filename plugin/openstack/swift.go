@@ -0,0 +1,215 @@
+package openstack
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/objectstorage/v1/containers"
+	"github.com/gophercloud/gophercloud/openstack/objectstorage/v1/objects"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type containersDir struct {
+	plugin.EntryBase
+	cloud *cloud
+}
+
+func newContainersDir(c *cloud) *containersDir {
+	d := &containersDir{
+		EntryBase: plugin.NewEntry("containers"),
+	}
+	d.cloud = c
+	return d
+}
+
+func (d *containersDir) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(d, "containers").
+		SetDescription(containersDescription).
+		IsSingleton()
+}
+
+func (d *containersDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&swiftContainer{}).Schema(),
+	}
+}
+
+// List lists the cloud's Swift containers
+func (d *containersDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	client, err := d.cloud.client(ctx, openstack.NewObjectStorageV1)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := containers.List(client, &containers.ListOpts{Full: true}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	infos, err := containers.ExtractInfo(pages)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(infos))
+	for i, info := range infos {
+		entries[i] = newSwiftContainer(client, info)
+	}
+	return entries, nil
+}
+
+// swiftContainer is a Swift container. Objects are grouped into
+// directories by splitting their names on '/', like S3 prefixes.
+type swiftContainer struct {
+	swiftPrefix
+}
+
+func newSwiftContainer(client *gophercloud.ServiceClient, info containers.Container) *swiftContainer {
+	c := &swiftContainer{
+		swiftPrefix: swiftPrefix{
+			EntryBase: plugin.NewEntry(info.Name),
+		},
+	}
+	c.client = client
+	c.container = info.Name
+	c.
+		SetPartialMetadata(info).
+		Attributes().
+		SetSize(uint64(info.Bytes))
+	return c
+}
+
+func (c *swiftContainer) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(c, "container").
+		SetDescription(swiftContainerDescription).
+		SetPartialMetadataSchema(containers.Container{})
+}
+
+func (c *swiftContainer) ChildSchemas() []*plugin.EntrySchema {
+	return swiftPrefixChildSchemas()
+}
+
+type swiftPrefix struct {
+	plugin.EntryBase
+	client    *gophercloud.ServiceClient
+	container string
+	prefix    string
+}
+
+func newSwiftPrefix(client *gophercloud.ServiceClient, container string, prefix string) *swiftPrefix {
+	name := strings.TrimSuffix(prefix, "/")
+	name = name[strings.LastIndex(name, "/")+1:]
+	p := &swiftPrefix{
+		EntryBase: plugin.NewEntry(name),
+	}
+	p.client = client
+	p.container = container
+	p.prefix = prefix
+	return p
+}
+
+func (p *swiftPrefix) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(p, "prefix")
+}
+
+func (p *swiftPrefix) ChildSchemas() []*plugin.EntrySchema {
+	return swiftPrefixChildSchemas()
+}
+
+func swiftPrefixChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&swiftPrefix{}).Schema(),
+		(&swiftObject{}).Schema(),
+	}
+}
+
+// List lists the objects and sub-prefixes under the prefix
+func (p *swiftPrefix) List(ctx context.Context) ([]plugin.Entry, error) {
+	opts := &objects.ListOpts{Full: true, Prefix: p.prefix, Delimiter: "/"}
+	pages, err := objects.List(p.client, p.container, opts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	objs, err := objects.ExtractInfo(pages)
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Listing %v objects in %v/%v", len(objs), p.container, p.prefix)
+	entries := make([]plugin.Entry, 0, len(objs))
+	for _, obj := range objs {
+		if obj.Subdir != "" {
+			entries = append(entries, newSwiftPrefix(p.client, p.container, obj.Subdir))
+		} else {
+			entries = append(entries, newSwiftObject(p.client, p.container, obj))
+		}
+	}
+	return entries, nil
+}
+
+type swiftObject struct {
+	plugin.EntryBase
+	client    *gophercloud.ServiceClient
+	container string
+	name      string
+}
+
+func newSwiftObject(client *gophercloud.ServiceClient, container string, obj objects.Object) *swiftObject {
+	o := &swiftObject{
+		EntryBase: plugin.NewEntry(obj.Name[strings.LastIndex(obj.Name, "/")+1:]),
+	}
+	o.client = client
+	o.container = container
+	o.name = obj.Name
+	o.
+		SetPartialMetadata(obj).
+		Attributes().
+		SetCrtime(obj.LastModified).
+		SetMtime(obj.LastModified).
+		SetCtime(obj.LastModified).
+		SetAtime(obj.LastModified).
+		SetSize(uint64(obj.Bytes))
+	return o
+}
+
+func (o *swiftObject) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(o, "object").
+		SetPartialMetadataSchema(objects.Object{})
+}
+
+func (o *swiftObject) Read(ctx context.Context) ([]byte, error) {
+	result := objects.Download(o.client, o.container, o.name, nil)
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	defer result.Body.Close()
+	return ioutil.ReadAll(result.Body)
+}
+
+func (o *swiftObject) Write(ctx context.Context, b []byte) error {
+	activity.Record(ctx, "Uploading %v bytes to %v/%v", len(b), o.container, o.name)
+	return objects.Create(o.client, o.container, o.name, objects.CreateOpts{
+		Content: bytes.NewReader(b),
+	}).Err
+}
+
+func (o *swiftObject) Delete(ctx context.Context) (bool, error) {
+	activity.Record(ctx, "Deleting %v/%v", o.container, o.name)
+	_, err := objects.Delete(o.client, o.container, o.name, nil).Extract()
+	return err == nil, err
+}
+
+const containersDescription = `
+This lists the cloud's Swift containers. Objects are grouped into
+directories by splitting their names on '/', so a container behaves like an
+S3 bucket.
+`
+
+const swiftContainerDescription = `
+This is a Swift container. Its metadata includes the container's object
+count and size.
+`
@@ -0,0 +1,96 @@
+package openstack
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/objectstorage/v1/objects"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a service client for a fake object store
+func newTestClient(handler http.HandlerFunc) (*gophercloud.ServiceClient, func()) {
+	srv := httptest.NewServer(handler)
+	client := &gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{},
+		Endpoint:       srv.URL + "/",
+	}
+	return client, srv.Close
+}
+
+func TestNewSwiftPrefix(t *testing.T) {
+	assert.Equal(t, "2020", plugin.Name(newSwiftPrefix(nil, "photos", "2020/")))
+	assert.Equal(t, "01", plugin.Name(newSwiftPrefix(nil, "photos", "2020/01/")))
+}
+
+func TestSwiftPrefixList(t *testing.T) {
+	client, cleanup := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/photos", r.URL.Path)
+		query := r.URL.Query()
+		assert.Equal(t, "2020/", query.Get("prefix"))
+		assert.Equal(t, "/", query.Get("delimiter"))
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if query.Get("marker") != "" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"subdir": "2020/01/"},
+			{"name": "2020/cover.jpg", "bytes": 1024, "last_modified": "2020-01-01T00:00:00.000000"}
+		]`))
+	})
+	defer cleanup()
+
+	entries, err := newSwiftPrefix(client, "photos", "2020/").List(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, entries, 2) {
+		if prefix, ok := entries[0].(*swiftPrefix); assert.True(t, ok) {
+			assert.Equal(t, "01", plugin.Name(prefix))
+			assert.Equal(t, "2020/01/", prefix.prefix)
+		}
+		if obj, ok := entries[1].(*swiftObject); assert.True(t, ok) {
+			assert.Equal(t, "cover.jpg", plugin.Name(obj))
+			assert.Equal(t, "2020/cover.jpg", obj.name)
+			assert.Equal(t, uint64(1024), obj.Attributes().Size())
+		}
+	}
+}
+
+func TestSwiftObject(t *testing.T) {
+	var written []byte
+	deleted := false
+	client, cleanup := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/photos/2020/cover.jpg", r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte("jpeg data"))
+		case http.MethodPut:
+			written, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected %v request", r.Method)
+		}
+	})
+	defer cleanup()
+
+	obj := newSwiftObject(client, "photos", objects.Object{Name: "2020/cover.jpg"})
+	content, err := obj.Read(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "jpeg data", string(content))
+	}
+
+	assert.NoError(t, obj.Write(context.Background(), []byte("new data")))
+	assert.Equal(t, "new data", string(written))
+
+	ok, err := obj.Delete(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, deleted)
+}
@@ -0,0 +1,169 @@
+package openstack
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type volumesDir struct {
+	plugin.EntryBase
+	cloud *cloud
+}
+
+func newVolumesDir(c *cloud) *volumesDir {
+	v := &volumesDir{
+		EntryBase: plugin.NewEntry("volumes"),
+	}
+	v.cloud = c
+	return v
+}
+
+func (v *volumesDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(v, "volumes").IsSingleton()
+}
+
+func (v *volumesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&volume{}).Schema(),
+	}
+}
+
+// List lists the cloud's Cinder volumes
+func (v *volumesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	client, err := v.cloud.client(ctx, openstack.NewBlockStorageV3)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := volumes.List(client, volumes.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	vols, err := volumes.ExtractVolumes(pages)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(vols))
+	for i, vol := range vols {
+		entries[i] = newVolume(vol)
+	}
+	return entries, nil
+}
+
+type volume struct {
+	plugin.EntryBase
+}
+
+func newVolume(vol volumes.Volume) *volume {
+	// Volumes don't need a name, so fall back to the ID.
+	name := vol.Name
+	if name == "" {
+		name = vol.ID
+	}
+	v := &volume{
+		EntryBase: plugin.NewEntry(name),
+	}
+	v.
+		SetPartialMetadata(vol).
+		Attributes().
+		SetCrtime(vol.CreatedAt).
+		SetMtime(vol.UpdatedAt).
+		SetCtime(vol.UpdatedAt).
+		SetAtime(vol.UpdatedAt).
+		SetSize(uint64(vol.Size) * 1024 * 1024 * 1024)
+	return v
+}
+
+func (v *volume) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(v, "volume").
+		SetDescription(volumeDescription).
+		SetPartialMetadataSchema(volumes.Volume{})
+}
+
+type snapshotsDir struct {
+	plugin.EntryBase
+	cloud *cloud
+}
+
+func newSnapshotsDir(c *cloud) *snapshotsDir {
+	s := &snapshotsDir{
+		EntryBase: plugin.NewEntry("snapshots"),
+	}
+	s.cloud = c
+	return s
+}
+
+func (s *snapshotsDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(s, "snapshots").IsSingleton()
+}
+
+func (s *snapshotsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&snapshot{}).Schema(),
+	}
+}
+
+// List lists the cloud's Cinder snapshots
+func (s *snapshotsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	client, err := s.cloud.client(ctx, openstack.NewBlockStorageV3)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := snapshots.List(client, snapshots.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	snaps, err := snapshots.ExtractSnapshots(pages)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(snaps))
+	for i, snap := range snaps {
+		entries[i] = newSnapshot(snap)
+	}
+	return entries, nil
+}
+
+type snapshot struct {
+	plugin.EntryBase
+}
+
+func newSnapshot(snap snapshots.Snapshot) *snapshot {
+	name := snap.Name
+	if name == "" {
+		name = snap.ID
+	}
+	s := &snapshot{
+		EntryBase: plugin.NewEntry(name),
+	}
+	s.
+		SetPartialMetadata(snap).
+		Attributes().
+		SetCrtime(snap.CreatedAt).
+		SetMtime(snap.UpdatedAt).
+		SetCtime(snap.UpdatedAt).
+		SetAtime(snap.UpdatedAt).
+		SetSize(uint64(snap.Size) * 1024 * 1024 * 1024)
+	return s
+}
+
+func (s *snapshot) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "snapshot").
+		SetDescription(snapshotDescription).
+		SetPartialMetadataSchema(snapshots.Snapshot{})
+}
+
+const volumeDescription = `
+This is a Cinder volume. Its metadata includes the volume's status, type and
+attachments. Unnamed volumes are listed by their ID.
+`
+
+const snapshotDescription = `
+This is a Cinder volume snapshot. Its metadata includes the ID of the volume
+that it was taken from. Unnamed snapshots are listed by their ID.
+`
@@ -0,0 +1,168 @@
+package plugintest
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/puppetlabs/wash/datastore"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// FakeClock is a clock that only moves when it's advanced. Use it with a FakeCache to test
+// how an entry behaves once its cached results expire.
+type FakeClock struct {
+	mux sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a FakeClock that's set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the clock's time.
+func (c *FakeClock) Now() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.now = c.now.Add(d)
+}
+
+type fakeCacheItem struct {
+	value      interface{}
+	expiration time.Time
+}
+
+// FakeCache is an in-memory datastore.Cache whose items expire according to a FakeClock.
+// Like the server's cache, it caches errors and a TTL of 0 means one minute. Unlike the
+// server's cache, items with a negative TTL never expire.
+type FakeCache struct {
+	mux   sync.Mutex
+	clock *FakeClock
+	items map[string]fakeCacheItem
+}
+
+var _ = datastore.Cache(&FakeCache{})
+
+// NewFakeCache creates a FakeCache whose items expire according to clock.
+func NewFakeCache(clock *FakeClock) *FakeCache {
+	return &FakeCache{clock: clock, items: make(map[string]fakeCacheItem)}
+}
+
+func (c *FakeCache) get(key string) (interface{}, bool) {
+	item, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	if !item.expiration.IsZero() && !c.clock.Now().Before(item.expiration) {
+		delete(c.items, key)
+		return nil, false
+	}
+	return item.value, true
+}
+
+func (c *FakeCache) set(key string, value interface{}, ttl time.Duration) {
+	item := fakeCacheItem{value: value}
+	if ttl == 0 {
+		ttl = time.Minute
+	}
+	if ttl > 0 {
+		item.expiration = c.clock.Now().Add(ttl)
+	}
+	c.items[key] = item
+}
+
+// GetOrUpdate implements datastore.Cache#GetOrUpdate.
+func (c *FakeCache) GetOrUpdate(category, key string, ttl time.Duration, resetTTLOnHit bool, generateValue func() (interface{}, error)) (interface{}, error) {
+	key = category + "::" + key
+	c.mux.Lock()
+	value, ok := c.get(key)
+	if ok && resetTTLOnHit {
+		c.set(key, value, ttl)
+	}
+	c.mux.Unlock()
+
+	if !ok {
+		// Generate the value without holding the lock, because generating it can use the
+		// cache (e.g. when a List's children are cached).
+		var err error
+		value, err = generateValue()
+		if err != nil {
+			value = err
+		}
+		c.mux.Lock()
+		c.set(key, value, ttl)
+		c.mux.Unlock()
+	}
+
+	if err, ok := value.(error); ok {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Get implements datastore.Cache#Get.
+func (c *FakeCache) Get(category, key string) (interface{}, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	value, _ := c.get(category + "::" + key)
+	if err, ok := value.(error); ok {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Flush implements datastore.Cache#Flush.
+func (c *FakeCache) Flush() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.items = make(map[string]fakeCacheItem)
+}
+
+// Delete implements datastore.Cache#Delete.
+func (c *FakeCache) Delete(matcher *regexp.Regexp) []string {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	var deleted []string
+	for key := range c.items {
+		if matcher.MatchString(key) {
+			delete(c.items, key)
+			deleted = append(deleted, key)
+		}
+	}
+	return deleted
+}
+
+// Keys returns the keys of the cache's unexpired items, sorted. Keys have the form
+// <op>::<path>, e.g. List::/docker/containers.
+func (c *FakeCache) Keys() []string {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	var keys []string
+	for key := range c.items {
+		if _, ok := c.get(key); ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// UseFakeCache sets a new FakeCache as the plugin package's cache. It returns the cache, its
+// clock and a context that can be passed to the plugin package's functions. Call the returned
+// function to unset the cache once the test's done.
+func UseFakeCache() (context.Context, *FakeCache, *FakeClock, func()) {
+	clock := NewFakeClock(time.Now())
+	cache := NewFakeCache(clock)
+	ctx := plugin.SetTestCache(cache)
+	return ctx, cache, clock, plugin.UnsetTestCache
+}
@@ -90,6 +90,25 @@ func (m *MockReadWrite) Write(ctx context.Context, p []byte) error {
 var _ = plugin.Readable(&MockReadWrite{})
 var _ = plugin.Writable(&MockReadWrite{})
 
+// MockSetAttr only mocks SetAttr operations.
+type MockSetAttr struct {
+	MockBase
+}
+
+// NewMockSetAttr creates a new "mock" entry for setting attributes.
+func NewMockSetAttr() *MockSetAttr {
+	m := &MockSetAttr{MockBase{EntryBase: plugin.NewEntry("mocksa")}}
+	m.SetTestID("/mocksa")
+	return m
+}
+
+func (m *MockSetAttr) SetAttr(ctx context.Context, attr plugin.EntryAttributes) error {
+	args := m.Called(ctx, attr)
+	return args.Error(0)
+}
+
+var _ = plugin.SetAttrable(&MockSetAttr{})
+
 // MockBlockReadWrite mocks block read and write operations.
 type MockBlockReadWrite struct {
 	MockBase
@@ -114,3 +133,64 @@ func (m *MockBlockReadWrite) Write(ctx context.Context, p []byte) error {
 
 var _ = plugin.BlockReadable(&MockBlockReadWrite{})
 var _ = plugin.Writable(&MockBlockReadWrite{})
+
+// MockParent mocks List operations.
+type MockParent struct {
+	MockBase
+}
+
+// NewMockParent creates a new "mock" entry for lists.
+func NewMockParent() *MockParent {
+	m := &MockParent{MockBase{EntryBase: plugin.NewEntry("mockp")}}
+	m.SetTestID("/mockp")
+	return m
+}
+
+// ChildSchemas returns nil, which means that the children's schemas are unknown.
+func (m *MockParent) ChildSchemas() []*plugin.EntrySchema {
+	return nil
+}
+
+func (m *MockParent) List(ctx context.Context) ([]plugin.Entry, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]plugin.Entry), args.Error(1)
+}
+
+var _ = plugin.Parent(&MockParent{})
+
+// MockExec mocks Exec operations.
+type MockExec struct {
+	MockBase
+}
+
+// NewMockExec creates a new "mock" entry for execs.
+func NewMockExec() *MockExec {
+	m := &MockExec{MockBase{EntryBase: plugin.NewEntry("mockx")}}
+	m.SetTestID("/mockx")
+	return m
+}
+
+func (m *MockExec) Exec(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	margs := m.Called(ctx, cmd, args, opts)
+	return margs.Get(0).(plugin.ExecCommand), margs.Error(1)
+}
+
+var _ = plugin.Execable(&MockExec{})
+
+// NewExecCommand returns an ExecCommand that has already run. Its stdout and stderr contain
+// the given output, and it exited with the given exit code. Use it as the return value of a
+// mocked Exec.
+func NewExecCommand(stdout string, stderr string, exitCode int) plugin.ExecCommand {
+	cmd := plugin.NewExecCommand(context.Background())
+	go func() {
+		if stdout != "" {
+			_, _ = cmd.Stdout().Write([]byte(stdout))
+		}
+		if stderr != "" {
+			_, _ = cmd.Stderr().Write([]byte(stderr))
+		}
+		cmd.CloseStreamsWithError(nil)
+		cmd.SetExitCode(exitCode)
+	}()
+	return cmd
+}
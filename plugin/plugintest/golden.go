@@ -0,0 +1,78 @@
+package plugintest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// UpdateGoldenEnvVar is the environment variable that makes the golden-file helpers update
+// their golden files instead of comparing against them, e.g. `WASH_UPDATE_GOLDEN=1 go test ./...`.
+const UpdateGoldenEnvVar = "WASH_UPDATE_GOLDEN"
+
+// AssertGolden fails the test if actual doesn't match the content of the golden file at path.
+// The golden file is written instead if the WASH_UPDATE_GOLDEN environment variable is set.
+// Golden files are conventionally stored in the package's testdata directory.
+func AssertGolden(t testing.TB, path string, actual []byte) {
+	t.Helper()
+	if os.Getenv(UpdateGoldenEnvVar) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("could not create the directory of %v: %v", path, err)
+		}
+		if err := ioutil.WriteFile(path, actual, 0640); err != nil {
+			t.Fatalf("could not update %v: %v", path, err)
+		}
+		return
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read %v: %v. Set %v=1 to create it.", path, err, UpdateGoldenEnvVar)
+	}
+	if bytes.Equal(expected, actual) {
+		return
+	}
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(expected)),
+		B:        difflib.SplitLines(string(actual)),
+		FromFile: path,
+		ToFile:   "actual",
+		Context:  3,
+	})
+	t.Errorf("the content doesn't match %v. Set %v=1 to update it.\n%v", path, UpdateGoldenEnvVar, diff)
+}
+
+// AssertGoldenJSON is AssertGolden for v's indented JSON. Object keys are sorted, so the
+// golden file doesn't depend on map ordering.
+func AssertGoldenJSON(t testing.TB, path string, v interface{}) {
+	t.Helper()
+	actual, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("could not marshal the value for %v: %v", path, err)
+	}
+	AssertGolden(t, path, append(actual, '\n'))
+}
+
+// AssertSchemaGolden is AssertGoldenJSON for the entry's schema, as it's returned by the
+// schema API. Use it to catch unintended changes to the plugin's schema.
+func AssertSchemaGolden(t testing.TB, path string, e plugin.Entry) {
+	t.Helper()
+	schema, err := plugin.Schema(e)
+	if err != nil {
+		t.Fatalf("could not get the schema of %v: %v", plugin.ID(e), err)
+	}
+	AssertGoldenJSON(t, path, schema)
+}
+
+// AssertMetadataGolden is AssertGoldenJSON for the entry's metadata, as it's returned by the
+// metadata API.
+func AssertMetadataGolden(t testing.TB, path string, h *Harness, entryPath string) {
+	t.Helper()
+	AssertGoldenJSON(t, path, h.Metadata(entryPath))
+}
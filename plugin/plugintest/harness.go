@@ -0,0 +1,185 @@
+// Package plugintest helps core plugin authors test their plugins. It provides mock entries,
+// a fake cache and clock, golden-file helpers for schemas and metadata, and a Harness that
+// exercises a plugin's entries through the same code paths that the Wash server uses.
+package plugintest
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Harness registers a plugin the same way that the Wash server does, then exercises its
+// entries through the plugin package's functions. Those functions implement the behavior
+// that plugins can't see from their own methods, like caching, setting the children's IDs
+// and checking that the children's cnames are unique.
+//
+// The harness' methods take paths relative to Wash's mountpoint, e.g.
+// "myplugin/things/foo", and fail the test if the entry can't be found or its method
+// returns an error. FindEntry and List return the error instead so that tests can check it.
+type Harness struct {
+	t         testing.TB
+	ctx       context.Context
+	registry  *plugin.Registry
+	unsetTest func()
+
+	// Cache is the harness' cache. Use Cache.Keys to check what was cached.
+	Cache *FakeCache
+	// Clock is the Cache's clock. Advance it to expire the cached results.
+	Clock *FakeClock
+}
+
+// NewHarness initializes the plugin root with the given config and registers it. Call Close
+// once the test's done. Only one harness can exist at a time because it sets the plugin
+// package's cache.
+func NewHarness(t testing.TB, root plugin.Root, config map[string]interface{}) *Harness {
+	t.Helper()
+	ctx, cache, clock, unset := UseFakeCache()
+	h := &Harness{
+		t:         t,
+		ctx:       ctx,
+		registry:  plugin.NewRegistry(),
+		unsetTest: unset,
+		Cache:     cache,
+		Clock:     clock,
+	}
+	if err := h.registry.RegisterPlugin(root, config); err != nil {
+		h.Close()
+		t.Fatalf("could not initialize the plugin: %v", err)
+	}
+	return h
+}
+
+// Close unsets the harness' cache.
+func (h *Harness) Close() {
+	h.unsetTest()
+}
+
+// Context returns the context that the harness passes to the plugin's methods.
+func (h *Harness) Context() context.Context {
+	return h.ctx
+}
+
+// Registry returns the registry that the plugin was registered with.
+func (h *Harness) Registry() *plugin.Registry {
+	return h.registry
+}
+
+// FindEntry returns the entry at path, or an error if it can't be found.
+func (h *Harness) FindEntry(path string) (plugin.Entry, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) == 1 && segments[0] == "" {
+		return h.registry, nil
+	}
+	return plugin.FindEntry(h.ctx, h.registry, segments)
+}
+
+// Find returns the entry at path.
+func (h *Harness) Find(path string) plugin.Entry {
+	h.t.Helper()
+	e, err := h.FindEntry(path)
+	if err != nil {
+		h.t.Fatalf("could not find %v: %v", path, err)
+	}
+	return e
+}
+
+// List lists the entry at path. It returns the children keyed by their cname.
+func (h *Harness) List(path string) (map[string]plugin.Entry, error) {
+	h.t.Helper()
+	e := h.Find(path)
+	p, ok := e.(plugin.Parent)
+	if !ok || !plugin.ListAction().IsSupportedOn(e) {
+		h.t.Fatalf("%v doesn't support list", path)
+	}
+	entries, err := plugin.List(h.ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	return entries.Map(), nil
+}
+
+// ListNames returns the sorted cnames of the children of the entry at path.
+func (h *Harness) ListNames(path string) []string {
+	h.t.Helper()
+	children, err := h.List(path)
+	if err != nil {
+		h.t.Fatalf("could not list %v: %v", path, err)
+	}
+	var names []string
+	for cname := range children {
+		names = append(names, cname)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Read reads all of the content of the entry at path.
+func (h *Harness) Read(path string) []byte {
+	h.t.Helper()
+	e := h.Find(path)
+	if !plugin.ReadAction().IsSupportedOn(e) {
+		h.t.Fatalf("%v doesn't support read", path)
+	}
+	size, err := plugin.Size(h.ctx, e)
+	if err != nil {
+		h.t.Fatalf("could not get the size of %v: %v", path, err)
+	}
+	content, err := plugin.Read(h.ctx, e, int64(size), 0)
+	if err != nil && err != io.EOF {
+		h.t.Fatalf("could not read %v: %v", path, err)
+	}
+	return content
+}
+
+// Metadata returns the metadata of the entry at path.
+func (h *Harness) Metadata(path string) plugin.JSONObject {
+	h.t.Helper()
+	meta, err := plugin.Metadata(h.ctx, h.Find(path))
+	if err != nil {
+		h.t.Fatalf("could not get the metadata of %v: %v", path, err)
+	}
+	return meta
+}
+
+// ExecResult is the result of a Harness' Exec.
+type ExecResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// Exec runs the command on the entry at path and waits for it to finish.
+func (h *Harness) Exec(path string, cmd string, args []string, opts plugin.ExecOptions) ExecResult {
+	h.t.Helper()
+	e := h.Find(path)
+	execable, ok := e.(plugin.Execable)
+	if !ok || !plugin.ExecAction().IsSupportedOn(e) {
+		h.t.Fatalf("%v doesn't support exec", path)
+	}
+	execCmd, err := plugin.Exec(h.ctx, execable, cmd, args, opts)
+	if err != nil {
+		h.t.Fatalf("could not exec %v on %v: %v", cmd, path, err)
+	}
+
+	var stdout, stderr strings.Builder
+	for chunk := range execCmd.OutputCh() {
+		if chunk.Err != nil {
+			h.t.Fatalf("the %v output of %v on %v errored: %v", chunk.StreamID, cmd, path, chunk.Err)
+		}
+		if chunk.StreamID == plugin.Stdout {
+			stdout.WriteString(chunk.Data)
+		} else {
+			stderr.WriteString(chunk.Data)
+		}
+	}
+	exitCode, err := execCmd.ExitCode()
+	if err != nil {
+		h.t.Fatalf("could not get the exit code of %v on %v: %v", cmd, path, err)
+	}
+	return ExecResult{Stdout: stdout.String(), Stderr: stderr.String(), ExitCode: exitCode}
+}
@@ -0,0 +1,111 @@
+package plugintest
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type harnessTestsRoot struct {
+	plugin.EntryBase
+	children []plugin.Entry
+	listed   int
+}
+
+func (r *harnessTestsRoot) Init(map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("harnesstest")
+	return nil
+}
+
+func (r *harnessTestsRoot) Schema() *plugin.EntrySchema {
+	return nil
+}
+
+func (r *harnessTestsRoot) ChildSchemas() []*plugin.EntrySchema {
+	return nil
+}
+
+func (r *harnessTestsRoot) List(context.Context) ([]plugin.Entry, error) {
+	r.listed++
+	return r.children, nil
+}
+
+func TestHarness(t *testing.T) {
+	reader := NewMockRead()
+	reader.On("Read", mock.Anything).Return([]byte("some content"), nil)
+	execer := NewMockExec()
+	execer.On("Exec", mock.Anything, "echo", []string{"hello"}, plugin.ExecOptions{}).Return(NewExecCommand("hello\n", "", 0), nil)
+	root := &harnessTestsRoot{children: []plugin.Entry{reader, execer}}
+
+	h := NewHarness(t, root, nil)
+	defer h.Close()
+
+	assert.Equal(t, []string{"mockr", "mockx"}, h.ListNames("harnesstest"))
+	assert.Equal(t, "/harnesstest/mockr", plugin.ID(h.Find("harnesstest/mockr")))
+	assert.Equal(t, []byte("some content"), h.Read("harnesstest/mockr"))
+	assert.Equal(t, ExecResult{Stdout: "hello\n", ExitCode: 0}, h.Exec("harnesstest/mockx", "echo", []string{"hello"}, plugin.ExecOptions{}))
+
+	_, err := h.FindEntry("harnesstest/nonexistent")
+	assert.Error(t, err)
+
+	// The root's List was cached until its TTL expired.
+	listed := root.listed
+	h.ListNames("harnesstest")
+	assert.Equal(t, listed, root.listed)
+	h.Clock.Advance(time.Hour)
+	h.ListNames("harnesstest")
+	assert.Equal(t, listed+1, root.listed)
+}
+
+func TestFakeCache(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	cache := NewFakeCache(clock)
+	generated := 0
+	generate := func() (interface{}, error) {
+		generated++
+		return generated, nil
+	}
+
+	value, err := cache.GetOrUpdate("List", "/foo", time.Second, false, generate)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, value)
+	value, _ = cache.GetOrUpdate("List", "/foo", time.Second, false, generate)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, []string{"List::/foo"}, cache.Keys())
+
+	clock.Advance(time.Second)
+	value, _ = cache.GetOrUpdate("List", "/foo", time.Second, false, generate)
+	assert.Equal(t, 2, value)
+
+	assert.Equal(t, []string{"List::/foo"}, cache.Delete(regexp.MustCompile("^List::")))
+	value, err = cache.Get("List", "/foo")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestAssertGolden(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugintest")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "testdata", "metadata.golden")
+
+	os.Setenv(UpdateGoldenEnvVar, "1")
+	AssertGoldenJSON(t, path, map[string]interface{}{"b": 1, "a": "foo"})
+	os.Unsetenv(UpdateGoldenEnvVar)
+
+	content, err := ioutil.ReadFile(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "{\n  \"a\": \"foo\",\n  \"b\": 1\n}\n", string(content))
+	}
+	AssertGoldenJSON(t, path, map[string]interface{}{"a": "foo", "b": 1})
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/sqlquery"
+)
+
+type databaseInfo struct {
+	Name             string `json:"name"`
+	Owner            string `json:"owner"`
+	Encoding         string `json:"encoding"`
+	AllowConnections bool   `json:"allow_connections"`
+	Size             int64  `json:"size"`
+}
+
+type database struct {
+	plugin.EntryBase
+	server *server
+}
+
+func newDatabase(srv *server, info databaseInfo) *database {
+	db := &database{
+		EntryBase: plugin.NewEntry(info.Name),
+	}
+	db.server = srv
+	db.
+		SetPartialMetadata(info).
+		Attributes().
+		SetSize(uint64(info.Size))
+	return db
+}
+
+func (d *database) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(d, "database").
+		SetDescription(databaseDescription).
+		SetPartialMetadataSchema(databaseInfo{})
+}
+
+func (d *database) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&dbSchema{}).Schema(),
+	}
+}
+
+// List lists the database's schemas, excluding the system schemas
+func (d *database) List(ctx context.Context) ([]plugin.Entry, error) {
+	db, err := d.server.db(ctx, d.Name())
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, `
+		SELECT nspname, pg_catalog.pg_get_userbyid(nspowner)
+		FROM pg_namespace
+		WHERE nspname <> 'information_schema' AND nspname NOT LIKE 'pg\_%'
+		ORDER BY nspname`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []plugin.Entry
+	for rows.Next() {
+		var info schemaInfo
+		if err := rows.Scan(&info.Name, &info.Owner); err != nil {
+			return nil, err
+		}
+		entries = append(entries, newDBSchema(d, info))
+	}
+	return entries, rows.Err()
+}
+
+// Exec runs the SQL formed by joining cmd and args on the database.
+func (d *database) Exec(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	db, err := d.server.db(ctx, d.Name())
+	if err != nil {
+		return nil, err
+	}
+	query := strings.Join(append([]string{cmd}, args...), " ")
+	activity.Record(ctx, "Running %q on %v", query, d)
+	return sqlquery.Run(ctx, db, query), nil
+}
+
+const databaseDescription = `
+This is a PostgreSQL database. Its children are the database's schemas. You
+can run SQL on the database via the 'exec' action, e.g.
+
+  wash exec postgres/local/databases/mydb 'SELECT count(*) FROM users'
+
+The results are streamed to stdout as tab-separated rows preceded by a
+header row. If the query fails, the error is written to stderr and the
+command exits with status 1.
+`
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type databasesDir struct {
+	plugin.EntryBase
+	server *server
+}
+
+func newDatabasesDir(srv *server) *databasesDir {
+	databasesDir := &databasesDir{
+		EntryBase: plugin.NewEntry("databases"),
+	}
+	databasesDir.server = srv
+	return databasesDir
+}
+
+func (ds *databasesDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(ds, "databases").IsSingleton()
+}
+
+func (ds *databasesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&database{}).Schema(),
+	}
+}
+
+// List lists the server's databases, excluding templates
+func (ds *databasesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	db, err := ds.server.db(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, `
+		SELECT datname, pg_catalog.pg_get_userbyid(datdba), pg_encoding_to_char(encoding), datallowconn,
+			CASE WHEN has_database_privilege(datname, 'CONNECT') THEN pg_database_size(datname) ELSE 0 END
+		FROM pg_database
+		WHERE NOT datistemplate
+		ORDER BY datname`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []plugin.Entry
+	for rows.Next() {
+		var info databaseInfo
+		if err := rows.Scan(&info.Name, &info.Owner, &info.Encoding, &info.AllowConnections, &info.Size); err != nil {
+			return nil, err
+		}
+		entries = append(entries, newDatabase(ds.server, info))
+	}
+	return entries, rows.Err()
+}
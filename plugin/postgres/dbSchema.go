@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type schemaInfo struct {
+	Name  string `json:"name"`
+	Owner string `json:"owner"`
+}
+
+// dbSchema represents a PostgreSQL schema. It's named dbSchema to avoid
+// confusion with the entry's plugin.EntrySchema.
+type dbSchema struct {
+	plugin.EntryBase
+	database *database
+}
+
+func newDBSchema(db *database, info schemaInfo) *dbSchema {
+	s := &dbSchema{
+		EntryBase: plugin.NewEntry(info.Name),
+	}
+	s.database = db
+	s.SetPartialMetadata(info)
+	return s
+}
+
+func (s *dbSchema) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "schema").
+		SetPartialMetadataSchema(schemaInfo{})
+}
+
+func (s *dbSchema) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&table{}).Schema(),
+	}
+}
+
+// List lists the schema's tables, views and materialized views
+func (s *dbSchema) List(ctx context.Context) ([]plugin.Entry, error) {
+	db, err := s.database.server.db(ctx, s.database.Name())
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, `
+		SELECT c.relname, c.relkind, pg_catalog.pg_get_userbyid(c.relowner),
+			c.reltuples::bigint, pg_total_relation_size(c.oid)
+		FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relkind IN ('r', 'p', 'v', 'm', 'f')
+		ORDER BY c.relname`, s.Name())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []plugin.Entry
+	for rows.Next() {
+		info := tableInfo{Schema: s.Name()}
+		var kind string
+		if err := rows.Scan(&info.Name, &kind, &info.Owner, &info.EstimatedRows, &info.TotalSize); err != nil {
+			return nil, err
+		}
+		info.Kind = relkinds[kind]
+		entries = append(entries, newTable(s, info))
+	}
+	return entries, rows.Err()
+}
+
+var relkinds = map[string]string{
+	"r": "table",
+	"p": "partitioned table",
+	"v": "view",
+	"m": "materialized view",
+	"f": "foreign table",
+}
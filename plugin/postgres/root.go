@@ -0,0 +1,77 @@
+// Package postgres presents a filesystem hierarchy for PostgreSQL servers.
+//
+// Servers are configured in wash.yaml as a map of names to connection URLs.
+// Each server exposes its databases, which can be exec'ed to run SQL, and
+// the sessions that are currently connected to it.
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Root of the postgres plugin
+type Root struct {
+	plugin.EntryBase
+	servers []plugin.Entry
+}
+
+// Init for root
+func (r *Root) Init(cfg map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("postgres")
+	r.DisableDefaultCaching()
+
+	r.servers = []plugin.Entry{}
+	return plugin.ParseNamedConfigs("postgres", cfg, "servers", func(name string, config interface{}) error {
+		connURL, ok := config.(string)
+		if !ok {
+			return fmt.Errorf("expected a connection URL, not %v", config)
+		}
+		srv, err := newServer(name, connURL)
+		if err != nil {
+			return err
+		}
+		r.servers = append(r.servers, srv)
+		return nil
+	})
+}
+
+// Schema returns the root's schema
+func (r *Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "postgres").
+		SetDescription(rootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&server{}).Schema(),
+	}
+}
+
+// List lists the configured servers.
+func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	return r.servers, nil
+}
+
+const rootDescription = `
+This is the postgres plugin root. It lists the PostgreSQL servers configured
+in wash.yaml, e.g.
+
+  postgres:
+    servers:
+      local: postgres://postgres@localhost:5432/postgres?sslmode=disable
+
+Each server includes its databases and its active sessions. Databases
+support the 'exec' action so that you can run SQL against them
+
+  wash exec postgres/local/databases/mydb 'SELECT * FROM users LIMIT 10'
+
+The results are streamed back as tab-separated rows. Tables include their
+row-count estimates and sizes as metadata, and reading a table returns its
+DDL. Sessions can be cancelled or terminated via the 'signal' action.
+`
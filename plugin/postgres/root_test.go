@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInit(t *testing.T) {
+	r := &Root{}
+	assert.NoError(t, r.Init(map[string]interface{}{}))
+	assert.Empty(t, r.servers)
+
+	err := r.Init(map[string]interface{}{
+		"servers": map[string]interface{}{
+			"remote": "postgres://admin@db.example.com:5433/app",
+			"local":  "postgresql://postgres@localhost/?sslmode=disable",
+		},
+	})
+	if assert.NoError(t, err) {
+		entries, err := r.List(context.Background())
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, "local", plugin.Name(entries[0]))
+			assert.Equal(t, "remote", plugin.Name(entries[1]))
+		}
+	}
+
+	err = r.Init(map[string]interface{}{
+		"servers": map[string]interface{}{"local": 5432},
+	})
+	assert.EqualError(t, err, "postgres.servers.local config is invalid: expected a connection URL, not 5432")
+
+	err = r.Init(map[string]interface{}{
+		"servers": map[string]interface{}{"local": "mysql://localhost"},
+	})
+	assert.EqualError(t, err, "postgres.servers.local config is invalid: expected a postgres:// URL, not mysql://localhost")
+}
+
+func TestNewServer(t *testing.T) {
+	srv, err := newServer("remote", "postgres://admin@db.example.com:5433/app")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, serverMetadata{Host: "db.example.com", Port: "5433", User: "admin", Database: "app"}, srv.info)
+	assert.Equal(t, "db.example.com", plugin.PartialMetadata(srv)["host"])
+
+	srv, err = newServer("local", "postgres://localhost")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "postgres", srv.defaultDatabase())
+	}
+}
+
+func TestServerDB(t *testing.T) {
+	srv, err := newServer("remote", "postgres://admin@db.example.com:5433/app?sslmode=disable")
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+
+	// Opening a pool doesn't connect, so this doesn't need a server
+	app, err := srv.db(ctx, "")
+	assert.NoError(t, err)
+	other, err := srv.db(ctx, "other")
+	assert.NoError(t, err)
+	assert.NotSame(t, app, other)
+	assert.Contains(t, srv.dbs, "app")
+	assert.Contains(t, srv.dbs, "other")
+
+	again, err := srv.db(ctx, "app")
+	assert.NoError(t, err)
+	assert.Same(t, app, again)
+}
+
+func TestQuoteIdent(t *testing.T) {
+	assert.Equal(t, `"users"`, quoteIdent("users"))
+	assert.Equal(t, `"My ""Table"""`, quoteIdent(`My "Table"`))
+}
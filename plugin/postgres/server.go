@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+
+	// Registers the "postgres" database/sql driver
+	_ "github.com/lib/pq"
+)
+
+type serverMetadata struct {
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	User     string `json:"user"`
+	Database string `json:"database"`
+}
+
+// server represents a configured PostgreSQL server
+type server struct {
+	plugin.EntryBase
+	connURL *url.URL
+	info    serverMetadata
+	mux     sync.Mutex
+	// dbs maps database names to their connection pools. PostgreSQL
+	// connections are scoped to a single database, so each database that
+	// we talk to gets its own pool.
+	dbs map[string]*sql.DB
+}
+
+func newServer(name string, connURL string) (*server, error) {
+	u, err := url.Parse(connURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return nil, fmt.Errorf("expected a postgres:// URL, not %v", connURL)
+	}
+
+	srv := &server{
+		EntryBase: plugin.NewEntry(name),
+		connURL:   u,
+		dbs:       make(map[string]*sql.DB),
+	}
+	srv.DisableDefaultCaching()
+	srv.info = serverMetadata{
+		Host:     u.Hostname(),
+		Port:     u.Port(),
+		User:     u.User.Username(),
+		Database: srv.defaultDatabase(),
+	}
+	srv.SetPartialMetadata(srv.info)
+	return srv, nil
+}
+
+func (s *server) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "server").
+		SetDescription(serverDescription).
+		SetPartialMetadataSchema(serverMetadata{})
+}
+
+func (s *server) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&databasesDir{}).Schema(),
+		(&sessionsDir{}).Schema(),
+	}
+}
+
+// List lists the databases and sessions directories
+func (s *server) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{
+		newDatabasesDir(s),
+		newSessionsDir(s),
+	}, nil
+}
+
+// Metadata includes the server's version and settings in addition to
+// its connection info.
+func (s *server) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	db, err := s.db(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	meta := plugin.ToJSONObject(s.info)
+	var version string
+	if err := db.QueryRowContext(ctx, "SELECT version()").Scan(&version); err != nil {
+		return nil, err
+	}
+	meta["version"] = version
+
+	rows, err := db.QueryContext(ctx, "SELECT name, setting FROM pg_settings")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	settings := make(map[string]string)
+	for rows.Next() {
+		var name, setting string
+		if err := rows.Scan(&name, &setting); err != nil {
+			return nil, err
+		}
+		settings[name] = setting
+	}
+	meta["settings"] = settings
+	return meta, rows.Err()
+}
+
+func (s *server) defaultDatabase() string {
+	if len(s.connURL.Path) > 1 {
+		return s.connURL.Path[1:]
+	}
+	return "postgres"
+}
+
+// db returns the connection pool for the named database. An empty name
+// returns the pool for the database in the configured connection URL.
+func (s *server) db(ctx context.Context, name string) (*sql.DB, error) {
+	if name == "" {
+		name = s.defaultDatabase()
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if db, ok := s.dbs[name]; ok {
+		return db, nil
+	}
+
+	u := *s.connURL
+	u.Path = "/" + name
+	activity.Record(ctx, "Connecting to database %v on %v", name, s)
+	db, err := sql.Open("postgres", u.String())
+	if err != nil {
+		return nil, err
+	}
+	s.dbs[name] = db
+	return db, nil
+}
+
+const serverDescription = `
+This is a PostgreSQL server. Its metadata includes the server version and
+its current settings.
+`
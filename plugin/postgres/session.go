@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type sessionInfo struct {
+	PID             int        `json:"pid"`
+	User            *string    `json:"user"`
+	Database        *string    `json:"database"`
+	ApplicationName *string    `json:"application_name"`
+	ClientAddr      *string    `json:"client_addr"`
+	State           *string    `json:"state"`
+	Query           *string    `json:"query"`
+	BackendStart    time.Time  `json:"backend_start"`
+	QueryStart      *time.Time `json:"query_start"`
+}
+
+// session represents a client session (i.e. backend) on the server
+type session struct {
+	plugin.EntryBase
+	server *server
+	pid    int
+}
+
+func newSession(srv *server, info sessionInfo) *session {
+	s := &session{
+		EntryBase: plugin.NewEntry(strconv.Itoa(info.PID)),
+	}
+	s.server = srv
+	s.pid = info.PID
+	attr := s.
+		SetPartialMetadata(info).
+		Attributes().
+		SetCrtime(info.BackendStart).
+		SetCtime(info.BackendStart).
+		SetMtime(info.BackendStart)
+	if info.QueryStart != nil {
+		attr.SetMtime(*info.QueryStart)
+	}
+	return s
+}
+
+func (s *session) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "session").
+		SetDescription(sessionDescription).
+		SetPartialMetadataSchema(sessionInfo{}).
+		AddSignal("cancel", "Cancels the session's current query. Equivalent to 'SELECT pg_cancel_backend(<pid>)'").
+		AddSignal("terminate", "Terminates the session. Equivalent to 'SELECT pg_terminate_backend(<pid>)'")
+}
+
+func (s *session) Signal(ctx context.Context, signal string) error {
+	var fn string
+	switch signal {
+	case "cancel":
+		fn = "pg_cancel_backend"
+	case "terminate":
+		fn = "pg_terminate_backend"
+	default:
+		return fmt.Errorf("unsupported signal %v", signal)
+	}
+
+	db, err := s.server.db(ctx, "")
+	if err != nil {
+		return err
+	}
+	activity.Record(ctx, "Calling %v on session %v", fn, s.pid)
+	var ok bool
+	if err := db.QueryRowContext(ctx, "SELECT "+fn+"($1)", s.pid).Scan(&ok); err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%v(%v) failed; the session may have already exited", fn, s.pid)
+	}
+	return nil
+}
+
+const sessionDescription = `
+This is a client session on a PostgreSQL server. Its metadata includes the
+session's user, database, state and current query. Sessions support the
+'cancel' and 'terminate' signals, e.g.
+
+  signal cancel postgres/local/sessions/1234
+`
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type sessionsDir struct {
+	plugin.EntryBase
+	server *server
+}
+
+func newSessionsDir(srv *server) *sessionsDir {
+	sessionsDir := &sessionsDir{
+		EntryBase: plugin.NewEntry("sessions"),
+	}
+	sessionsDir.server = srv
+	// Sessions come and go quickly, so keep the cached list short-lived.
+	sessionsDir.SetTTLOf(plugin.ListOp, 5*time.Second)
+	return sessionsDir
+}
+
+func (ss *sessionsDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(ss, "sessions").IsSingleton()
+}
+
+func (ss *sessionsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&session{}).Schema(),
+	}
+}
+
+// List lists the server's client sessions, excluding our own
+func (ss *sessionsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	db, err := ss.server.db(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, `
+		SELECT pid, usename, datname, application_name, client_addr::text, state, query, backend_start, query_start
+		FROM pg_stat_activity
+		WHERE pid <> pg_backend_pid() AND backend_type = 'client backend'
+		ORDER BY pid`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []plugin.Entry
+	for rows.Next() {
+		var info sessionInfo
+		err := rows.Scan(
+			&info.PID,
+			&info.User,
+			&info.Database,
+			&info.ApplicationName,
+			&info.ClientAddr,
+			&info.State,
+			&info.Query,
+			&info.BackendStart,
+			&info.QueryStart,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, newSession(ss.server, info))
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,214 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type tableInfo struct {
+	Name          string `json:"name"`
+	Schema        string `json:"schema"`
+	Kind          string `json:"kind"`
+	Owner         string `json:"owner"`
+	EstimatedRows int64  `json:"estimated_rows"`
+	TotalSize     int64  `json:"total_size"`
+}
+
+type column struct {
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Nullable bool    `json:"nullable"`
+	Default  *string `json:"default"`
+}
+
+type tableMetadata struct {
+	tableInfo
+	Columns []column `json:"columns"`
+	Indexes []string `json:"indexes"`
+}
+
+type table struct {
+	plugin.EntryBase
+	schema *dbSchema
+	info   tableInfo
+}
+
+func newTable(s *dbSchema, info tableInfo) *table {
+	t := &table{
+		EntryBase: plugin.NewEntry(info.Name),
+	}
+	t.schema = s
+	t.info = info
+	t.SetPartialMetadata(info)
+	return t
+}
+
+func (t *table) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(t, "table").
+		SetDescription(tableDescription).
+		SetPartialMetadataSchema(tableInfo{}).
+		SetMetadataSchema(tableMetadata{})
+}
+
+func (t *table) db(ctx context.Context) (*sql.DB, error) {
+	return t.schema.database.server.db(ctx, t.schema.database.Name())
+}
+
+func (t *table) qualifiedName() string {
+	return quoteIdent(t.schema.Name()) + "." + quoteIdent(t.Name())
+}
+
+// Metadata includes the table's columns and indexes in addition to its
+// partial metadata.
+func (t *table) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	db, err := t.db(ctx)
+	if err != nil {
+		return nil, err
+	}
+	meta := tableMetadata{tableInfo: t.info}
+	if meta.Columns, err = t.columns(ctx, db); err != nil {
+		return nil, err
+	}
+	if meta.Indexes, err = t.indexes(ctx, db); err != nil {
+		return nil, err
+	}
+	return plugin.ToJSONObject(meta), nil
+}
+
+// Read returns the table's DDL
+func (t *table) Read(ctx context.Context) ([]byte, error) {
+	db, err := t.db(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ddl strings.Builder
+	switch t.info.Kind {
+	case "view", "materialized view":
+		var def string
+		err := db.QueryRowContext(ctx, "SELECT pg_get_viewdef($1::regclass, true)", t.qualifiedName()).Scan(&def)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&ddl, "CREATE %v %v AS\n%v\n", strings.ToUpper(t.info.Kind), t.qualifiedName(), def)
+		return []byte(ddl.String()), nil
+	}
+
+	columns, err := t.columns(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	constraints, err := t.constraints(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	defs := make([]string, 0, len(columns)+len(constraints))
+	for _, col := range columns {
+		def := "    " + quoteIdent(col.Name) + " " + col.Type
+		if col.Default != nil {
+			def += " DEFAULT " + *col.Default
+		}
+		if !col.Nullable {
+			def += " NOT NULL"
+		}
+		defs = append(defs, def)
+	}
+	for _, constraint := range constraints {
+		defs = append(defs, "    "+constraint)
+	}
+	fmt.Fprintf(&ddl, "CREATE TABLE %v (\n%v\n);\n", t.qualifiedName(), strings.Join(defs, ",\n"))
+
+	indexes, err := t.indexes(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indexes {
+		fmt.Fprintf(&ddl, "%v;\n", index)
+	}
+	return []byte(ddl.String()), nil
+}
+
+func (t *table) columns(ctx context.Context, db *sql.DB) ([]column, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT a.attname, format_type(a.atttypid, a.atttypmod), NOT a.attnotnull, pg_get_expr(d.adbin, d.adrelid)
+		FROM pg_attribute a LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`, t.qualifiedName())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []column
+	for rows.Next() {
+		var col column
+		if err := rows.Scan(&col.Name, &col.Type, &col.Nullable, &col.Default); err != nil {
+			return nil, err
+		}
+		columns = append(columns, col)
+	}
+	return columns, rows.Err()
+}
+
+func (t *table) constraints(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT conname, pg_get_constraintdef(oid, true)
+		FROM pg_constraint
+		WHERE conrelid = $1::regclass
+		ORDER BY contype DESC, conname`, t.qualifiedName())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var constraints []string
+	for rows.Next() {
+		var name, def string
+		if err := rows.Scan(&name, &def); err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, "CONSTRAINT "+quoteIdent(name)+" "+def)
+	}
+	return constraints, rows.Err()
+}
+
+func (t *table) indexes(ctx context.Context, db *sql.DB) ([]string, error) {
+	// Skip indexes that back constraints since those are already part of
+	// the table definition.
+	rows, err := db.QueryContext(ctx, `
+		SELECT pg_get_indexdef(i.indexrelid)
+		FROM pg_index i
+		WHERE i.indrelid = $1::regclass
+			AND NOT EXISTS (SELECT 1 FROM pg_constraint c WHERE c.conindid = i.indexrelid)
+		ORDER BY i.indexrelid`, t.qualifiedName())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var indexes []string
+	for rows.Next() {
+		var def string
+		if err := rows.Scan(&def); err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, def)
+	}
+	return indexes, rows.Err()
+}
+
+// quoteIdent quotes a PostgreSQL identifier
+func quoteIdent(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+const tableDescription = `
+This is a PostgreSQL table or view. Its metadata includes an estimate of
+the number of rows and the total size (in bytes) of the table, including its
+indexes. Reading the table returns its DDL.
+`
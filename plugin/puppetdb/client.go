@@ -0,0 +1,139 @@
+package puppetdb
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// client is a minimal PuppetDB query API client
+type client struct {
+	baseURL *url.URL
+	token   string
+	http    *http.Client
+}
+
+// newClient creates a client from a server's config, which is either a
+// URL or a map containing the URL and the credentials.
+func newClient(cfg interface{}) (*client, error) {
+	var opts map[string]string
+	switch cfg := cfg.(type) {
+	case string:
+		opts = map[string]string{"url": cfg}
+	case map[string]interface{}:
+		opts = make(map[string]string)
+		for key, val := range cfg {
+			str, ok := val.(string)
+			if !ok {
+				return nil, fmt.Errorf("%v must be a string, not %v", key, val)
+			}
+			opts[key] = str
+		}
+	default:
+		return nil, fmt.Errorf("expected a URL or a map, not %v", cfg)
+	}
+
+	u, err := url.Parse(opts["url"])
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("expected an http:// or https:// URL, not %v", opts["url"])
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	c := &client{baseURL: u, http: http.DefaultClient}
+
+	if u.Scheme == "https" {
+		tlsConfig := &tls.Config{}
+		if cacert := opts["cacert"]; cacert != "" {
+			pem, err := ioutil.ReadFile(expandHome(cacert))
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in %v", cacert)
+			}
+		}
+		if opts["cert"] != "" || opts["key"] != "" {
+			cert, err := tls.LoadX509KeyPair(expandHome(opts["cert"]), expandHome(opts["key"]))
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		c.http = &http.Client{Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		}}
+	}
+
+	c.token = opts["token"]
+	if tokenFile := opts["token_file"]; tokenFile != "" {
+		token, err := ioutil.ReadFile(expandHome(tokenFile))
+		if err != nil {
+			return nil, err
+		}
+		c.token = strings.TrimSpace(string(token))
+	}
+	return c, nil
+}
+
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if homedir, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(homedir, path[2:])
+		}
+	}
+	return path
+}
+
+// query sends a query to the v4 API's endpoint (e.g. "nodes") and unmarshals
+// the result into v. The AST query and the paging options are optional.
+func (c *client) query(ctx context.Context, endpoint string, ast []interface{}, opts url.Values, v interface{}) error {
+	params := url.Values{}
+	for key, vals := range opts {
+		params[key] = vals
+	}
+	if ast != nil {
+		q, err := json.Marshal(ast)
+		if err != nil {
+			return err
+		}
+		params.Set("query", string(q))
+	}
+
+	u := *c.baseURL
+	u.Path += "/pdb/query/v4/" + endpoint
+	u.RawQuery = params.Encode()
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	if c.token != "" {
+		req.Header.Set("X-Authentication", c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("querying %v: %v: %v", endpoint, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, v)
+}
@@ -0,0 +1,115 @@
+package puppetdb
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClient(t *testing.T) {
+	c, err := newClient("http://localhost:8080/")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "http://localhost:8080", c.baseURL.String())
+		assert.Equal(t, http.DefaultClient, c.http)
+		assert.Empty(t, c.token)
+	}
+
+	c, err = newClient(map[string]interface{}{
+		"url":   "https://puppetdb.example.com:8081",
+		"token": "secret",
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "secret", c.token)
+		assert.NotEqual(t, http.DefaultClient, c.http)
+	}
+}
+
+func TestNewClient_TokenFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "puppetdb")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	tokenFile := filepath.Join(dir, "token")
+	if !assert.NoError(t, ioutil.WriteFile(tokenFile, []byte("from-file\n"), 0600)) {
+		return
+	}
+
+	c, err := newClient(map[string]interface{}{
+		"url":        "https://puppetdb.example.com:8081",
+		"token":      "ignored",
+		"token_file": tokenFile,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "from-file", c.token)
+	}
+}
+
+func TestNewClient_Errors(t *testing.T) {
+	cases := []struct {
+		cfg interface{}
+		err string
+	}{
+		{5, "expected a URL or a map, not 5"},
+		{map[string]interface{}{"url": 5}, "url must be a string, not 5"},
+		{"puppetdb.example.com", "expected an http:// or https:// URL, not puppetdb.example.com"},
+		{map[string]interface{}{"token": "secret"}, "expected an http:// or https:// URL, not "},
+	}
+	for _, c := range cases {
+		_, err := newClient(c.cfg)
+		assert.EqualError(t, err, c.err)
+	}
+}
+
+func TestExpandHome(t *testing.T) {
+	homedir, err := os.UserHomeDir()
+	if assert.NoError(t, err) {
+		assert.Equal(t, filepath.Join(homedir, "certs/ca.pem"), expandHome("~/certs/ca.pem"))
+	}
+	assert.Equal(t, "/etc/certs/ca.pem", expandHome("/etc/certs/ca.pem"))
+}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*client, func()) {
+	srv := httptest.NewServer(handler)
+	c, err := newClient(map[string]interface{}{"url": srv.URL + "/prefix", "token": "secret"})
+	if err != nil {
+		srv.Close()
+		t.Fatal(err)
+	}
+	return c, srv.Close
+}
+
+func TestClientQuery(t *testing.T) {
+	c, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/prefix/pdb/query/v4/nodes", r.URL.Path)
+		assert.Equal(t, `["=","certname","web01"]`, r.URL.Query().Get("query"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		assert.Equal(t, "secret", r.Header.Get("X-Authentication"))
+		_, _ = w.Write([]byte(`[{"certname": "web01"}]`))
+	})
+	defer cleanup()
+
+	var nodes []nodeInfo
+	err := c.query(context.Background(), "nodes", []interface{}{"=", "certname", "web01"}, url.Values{"limit": {"10"}}, &nodes)
+	if assert.NoError(t, err) && assert.Len(t, nodes, 1) {
+		assert.Equal(t, "web01", nodes[0].Certname)
+	}
+}
+
+func TestClientQuery_Error(t *testing.T) {
+	c, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no such endpoint", http.StatusNotFound)
+	})
+	defer cleanup()
+
+	var nodes []nodeInfo
+	err := c.query(context.Background(), "bogus", nil, nil, &nodes)
+	assert.EqualError(t, err, "querying bogus: 404 Not Found: no such endpoint")
+}
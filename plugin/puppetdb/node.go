@@ -0,0 +1,113 @@
+package puppetdb
+
+import (
+	"context"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// nodeInfo is the node's partial metadata
+type nodeInfo struct {
+	Certname                     string    `json:"certname"`
+	Deactivated                  *string   `json:"deactivated"`
+	Expired                      *string   `json:"expired"`
+	CatalogTimestamp             time.Time `json:"catalog_timestamp"`
+	FactsTimestamp               time.Time `json:"facts_timestamp"`
+	ReportTimestamp              time.Time `json:"report_timestamp"`
+	CatalogEnvironment           string    `json:"catalog_environment"`
+	FactsEnvironment             string    `json:"facts_environment"`
+	ReportEnvironment            string    `json:"report_environment"`
+	LatestReportStatus           string    `json:"latest_report_status"`
+	LatestReportNoop             bool      `json:"latest_report_noop"`
+	LatestReportCorrectiveChange bool      `json:"latest_report_corrective_change"`
+	LatestReportHash             string    `json:"latest_report_hash"`
+	CachedCatalogStatus          string    `json:"cached_catalog_status"`
+}
+
+// nodeMetadata is the node's full metadata
+type nodeMetadata struct {
+	nodeInfo
+	Facts map[string]interface{} `json:"facts"`
+}
+
+type node struct {
+	plugin.EntryBase
+	client *client
+	info   nodeInfo
+}
+
+func newNode(c *client, info nodeInfo) *node {
+	n := &node{
+		EntryBase: plugin.NewEntry(info.Certname),
+	}
+	n.client = c
+	n.info = info
+	n.
+		SetPartialMetadata(info).
+		Attributes().
+		SetMtime(latest(info.CatalogTimestamp, info.FactsTimestamp, info.ReportTimestamp))
+	return n
+}
+
+func latest(times ...time.Time) time.Time {
+	var t time.Time
+	for _, candidate := range times {
+		if candidate.After(t) {
+			t = candidate
+		}
+	}
+	return t
+}
+
+func (n *node) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(n, "node").
+		SetDescription(nodeDescription).
+		SetPartialMetadataSchema(nodeInfo{}).
+		SetMetadataSchema(nodeMetadata{})
+}
+
+func (n *node) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&reportsDir{}).Schema(),
+		(&resourcesDir{}).Schema(),
+	}
+}
+
+// Metadata includes the node's facts
+func (n *node) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	var facts []struct {
+		Name  string      `json:"name"`
+		Value interface{} `json:"value"`
+	}
+	if err := n.client.query(ctx, "nodes/"+n.info.Certname+"/facts", nil, nil, &facts); err != nil {
+		return nil, err
+	}
+	meta := nodeMetadata{
+		nodeInfo: n.info,
+		Facts:    make(map[string]interface{}, len(facts)),
+	}
+	for _, fact := range facts {
+		meta.Facts[fact.Name] = fact.Value
+	}
+	return plugin.ToJSONObject(meta), nil
+}
+
+func (n *node) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{
+		newReportsDir(n.client, n.info.Certname),
+		newResourcesDir(n.client, n.info.Certname),
+	}, nil
+}
+
+const nodeDescription = `
+This is a node in PuppetDB. Its partial metadata includes the node's latest
+report status and its catalog/facts/report timestamps, while its full
+metadata also includes its facts, e.g.
+
+  meta puppetdb/prod/web01.example.com | jq .facts.os
+
+Its children are the node's recent reports and the resources in its latest
+catalog.
+`
@@ -0,0 +1,135 @@
+package puppetdb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// reportLimit is the number of recent reports that are listed for a node
+const reportLimit = 20
+
+type reportsDir struct {
+	plugin.EntryBase
+	client   *client
+	certname string
+}
+
+func newReportsDir(c *client, certname string) *reportsDir {
+	r := &reportsDir{
+		EntryBase: plugin.NewEntry("reports"),
+	}
+	r.client = c
+	r.certname = certname
+	r.SetTTLOf(plugin.ListOp, 1*time.Minute)
+	return r
+}
+
+func (r *reportsDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(r, "reports").IsSingleton()
+}
+
+func (r *reportsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&report{}).Schema(),
+	}
+}
+
+// List lists the node's most recent reports, newest first
+func (r *reportsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var reports []reportInfo
+	ast := []interface{}{"=", "certname", r.certname}
+	opts := url.Values{
+		"order_by": {`[{"field":"receive_time","order":"desc"}]`},
+		"limit":    {fmt.Sprint(reportLimit)},
+	}
+	if err := r.client.query(ctx, "reports", ast, opts, &reports); err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(reports))
+	for i, info := range reports {
+		entries[i] = newReport(r.client, info)
+	}
+	return entries, nil
+}
+
+// reportInfo is the report's metadata
+type reportInfo struct {
+	Hash                 string      `json:"hash"`
+	Certname             string      `json:"certname"`
+	PuppetVersion        string      `json:"puppet_version"`
+	ConfigurationVersion string      `json:"configuration_version"`
+	Environment          string      `json:"environment"`
+	Status               string      `json:"status"`
+	Noop                 bool        `json:"noop"`
+	CorrectiveChange     bool        `json:"corrective_change"`
+	CachedCatalogStatus  string      `json:"cached_catalog_status"`
+	StartTime            time.Time   `json:"start_time"`
+	EndTime              time.Time   `json:"end_time"`
+	ReceiveTime          time.Time   `json:"receive_time"`
+	TransactionUUID      string      `json:"transaction_uuid"`
+	Metrics              interface{} `json:"metrics"`
+}
+
+type report struct {
+	plugin.EntryBase
+	client *client
+	hash   string
+}
+
+func newReport(c *client, info reportInfo) *report {
+	// Name reports by their start time so that they sort chronologically.
+	// The hash prefix disambiguates reports that started at the same time.
+	hashPrefix := info.Hash
+	if len(hashPrefix) > 8 {
+		hashPrefix = hashPrefix[:8]
+	}
+	r := &report{
+		EntryBase: plugin.NewEntry(info.StartTime.UTC().Format("20060102T150405Z") + "-" + hashPrefix),
+	}
+	r.client = c
+	r.hash = info.Hash
+	r.
+		SetPartialMetadata(info).
+		Attributes().
+		SetCrtime(info.StartTime).
+		SetMtime(info.EndTime).
+		SetCtime(info.ReceiveTime).
+		SetAtime(info.ReceiveTime)
+	return r
+}
+
+func (r *report) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "report").
+		SetDescription(reportDescription).
+		SetPartialMetadataSchema(reportInfo{})
+}
+
+// Read returns the report's logs
+func (r *report) Read(ctx context.Context) ([]byte, error) {
+	var logs []struct {
+		Time    time.Time `json:"time"`
+		Level   string    `json:"level"`
+		Source  string    `json:"source"`
+		Message string    `json:"message"`
+	}
+	if err := r.client.query(ctx, "reports/"+r.hash+"/logs", nil, nil, &logs); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	for _, log := range logs {
+		fmt.Fprintf(&buf, "%v %v %v: %v\n", log.Time.Format(time.RFC3339), log.Level, log.Source, log.Message)
+	}
+	return buf.Bytes(), nil
+}
+
+const reportDescription = `
+This is a Puppet report. Reading it returns the report's logs. Its metadata
+includes the report's status and metrics. Reports are named by their start
+time (in UTC) followed by the first 8 characters of their hash.
+`
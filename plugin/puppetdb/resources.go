@@ -0,0 +1,85 @@
+package puppetdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type resourcesDir struct {
+	plugin.EntryBase
+	client   *client
+	certname string
+}
+
+func newResourcesDir(c *client, certname string) *resourcesDir {
+	r := &resourcesDir{
+		EntryBase: plugin.NewEntry("resources"),
+	}
+	r.client = c
+	r.certname = certname
+	return r
+}
+
+func (r *resourcesDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(r, "resources").IsSingleton()
+}
+
+func (r *resourcesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&resource{}).Schema(),
+	}
+}
+
+// List lists the resources in the node's latest catalog
+func (r *resourcesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var resources []resourceInfo
+	if err := r.client.query(ctx, "nodes/"+r.certname+"/resources", nil, nil, &resources); err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(resources))
+	for i, info := range resources {
+		entries[i] = newResource(info)
+	}
+	return entries, nil
+}
+
+// resourceInfo is the resource's metadata
+type resourceInfo struct {
+	Type        string                 `json:"type"`
+	Title       string                 `json:"title"`
+	Exported    bool                   `json:"exported"`
+	Tags        []string               `json:"tags"`
+	File        *string                `json:"file"`
+	Line        *int                   `json:"line"`
+	Environment string                 `json:"environment"`
+	Parameters  map[string]interface{} `json:"parameters"`
+}
+
+type resource struct {
+	plugin.EntryBase
+}
+
+func newResource(info resourceInfo) *resource {
+	r := &resource{
+		EntryBase: plugin.NewEntry(fmt.Sprintf("%v[%v]", info.Type, info.Title)),
+	}
+	r.SetPartialMetadata(info)
+	return r
+}
+
+func (r *resource) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "resource").
+		SetDescription(resourceDescription).
+		SetPartialMetadataSchema(resourceInfo{})
+}
+
+const resourceDescription = `
+This is a resource in a node's latest catalog, named by its reference (e.g.
+Package[nginx]). Its metadata includes the resource's parameters, tags and
+source location, so you can do things like
+
+  find puppetdb/prod -path '*/resources/Package*' -meta .parameters.ensure latest
+`
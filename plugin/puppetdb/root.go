@@ -0,0 +1,81 @@
+// Package puppetdb presents a filesystem hierarchy for PuppetDB.
+//
+// It queries each configured PuppetDB's v4 query API to expose nodes, their
+// facts, their recent reports and the resources in their catalogs.
+package puppetdb
+
+import (
+	"context"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Root of the puppetdb plugin
+type Root struct {
+	plugin.EntryBase
+	servers []plugin.Entry
+}
+
+// Init for root
+func (r *Root) Init(cfg map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("puppetdb")
+	r.DisableDefaultCaching()
+
+	r.servers = []plugin.Entry{}
+	return plugin.ParseNamedConfigs("puppetdb", cfg, "servers", func(name string, config interface{}) error {
+		client, err := newClient(config)
+		if err != nil {
+			return err
+		}
+		r.servers = append(r.servers, newServer(name, client))
+		return nil
+	})
+}
+
+// Schema returns the root's schema
+func (r *Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "puppetdb").
+		SetDescription(rootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&server{}).Schema(),
+	}
+}
+
+// List lists the configured servers.
+func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	return r.servers, nil
+}
+
+const rootDescription = `
+This is the puppetdb plugin root. It lists the PuppetDB servers configured
+in wash.yaml. A server is either a URL or a map that includes the URL and
+the certificates (or, for Puppet Enterprise, the RBAC token) used to
+authenticate, e.g.
+
+  puppetdb:
+    servers:
+      dev: http://localhost:8080
+      prod:
+        url: https://puppetdb.example.com:8081
+        cacert: ~/.puppetlabs/etc/puppet/ssl/certs/ca.pem
+        cert: ~/.puppetlabs/etc/puppet/ssl/certs/me.pem
+        key: ~/.puppetlabs/etc/puppet/ssl/private_keys/me.pem
+      pe:
+        url: https://pe.example.com:8081
+        cacert: ~/.puppetlabs/etc/puppet/ssl/certs/ca.pem
+        token_file: ~/.puppetlabs/token
+
+Each server lists its nodes. A node's metadata includes its facts, so you
+can do things like
+
+  find puppetdb/prod -maxdepth 1 -meta .facts.os.family RedHat
+
+Each node also includes its most recent reports and the resources in its
+latest catalog.
+`
@@ -0,0 +1,80 @@
+package puppetdb
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInit(t *testing.T) {
+	r := &Root{}
+	assert.NoError(t, r.Init(map[string]interface{}{}))
+	assert.Empty(t, r.servers)
+
+	err := r.Init(map[string]interface{}{
+		"servers": map[string]interface{}{
+			"prod": map[string]interface{}{"url": "https://puppetdb.example.com:8081", "token": "secret"},
+			"dev":  "http://localhost:8080",
+		},
+	})
+	if assert.NoError(t, err) {
+		entries, err := r.List(context.Background())
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, "dev", plugin.Name(entries[0]))
+			assert.Equal(t, "prod", plugin.Name(entries[1]))
+			assert.Equal(t, "secret", entries[1].(*server).client.token)
+		}
+	}
+
+	err = r.Init(map[string]interface{}{"servers": []interface{}{"http://localhost:8080"}})
+	assert.EqualError(t, err, "puppetdb.servers config must be a map of names to configs, not [http://localhost:8080]")
+
+	err = r.Init(map[string]interface{}{
+		"servers": map[string]interface{}{"dev": "localhost:8080"},
+	})
+	assert.EqualError(t, err, "puppetdb.servers.dev config is invalid: expected an http:// or https:// URL, not localhost:8080")
+}
+
+func TestServerList(t *testing.T) {
+	c, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `[{"field":"certname"}]`, r.URL.Query().Get("order_by"))
+		_, _ = w.Write([]byte(`[
+			{"certname": "db01", "facts_timestamp": "2020-01-01T10:00:00Z", "report_timestamp": "2020-01-01T12:00:00Z"},
+			{"certname": "web01", "catalog_timestamp": "2020-01-02T10:00:00Z"}
+		]`))
+	})
+	defer cleanup()
+
+	entries, err := newServer("prod", c).List(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, entries, 2) {
+		assert.Equal(t, "db01", plugin.Name(entries[0]))
+		assert.Equal(t, time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), entries[0].(*node).Attributes().Mtime())
+		assert.Equal(t, "web01", plugin.Name(entries[1]))
+	}
+}
+
+func TestNodeMetadata(t *testing.T) {
+	c, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/prefix/pdb/query/v4/nodes/web01/facts", r.URL.Path)
+		_, _ = w.Write([]byte(`[{"name": "kernel", "value": "Linux"}, {"name": "processorcount", "value": 4}]`))
+	})
+	defer cleanup()
+
+	meta, err := newNode(c, nodeInfo{Certname: "web01"}).Metadata(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "web01", meta["certname"])
+		assert.Equal(t, map[string]interface{}{"kernel": "Linux", "processorcount": float64(4)}, meta["facts"])
+	}
+}
+
+func TestLatest(t *testing.T) {
+	t1 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	assert.Equal(t, t2, latest(t1, t2, time.Time{}))
+	assert.True(t, latest().IsZero())
+}
@@ -0,0 +1,53 @@
+package puppetdb
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type server struct {
+	plugin.EntryBase
+	client *client
+}
+
+func newServer(name string, c *client) *server {
+	srv := &server{
+		EntryBase: plugin.NewEntry(name),
+	}
+	srv.client = c
+	return srv
+}
+
+func (s *server) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "server").
+		SetDescription(serverDescription)
+}
+
+func (s *server) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&node{}).Schema(),
+	}
+}
+
+// List lists the server's active nodes
+func (s *server) List(ctx context.Context) ([]plugin.Entry, error) {
+	var nodes []nodeInfo
+	opts := url.Values{"order_by": {`[{"field":"certname"}]`}}
+	if err := s.client.query(ctx, "nodes", nil, opts, &nodes); err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Listing %v nodes in %v", len(nodes), s)
+	entries := make([]plugin.Entry, len(nodes))
+	for i, info := range nodes {
+		entries[i] = newNode(s.client, info)
+	}
+	return entries, nil
+}
+
+const serverDescription = `
+This is a PuppetDB server. It lists the server's active nodes.
+`
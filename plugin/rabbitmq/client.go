@@ -0,0 +1,98 @@
+package rabbitmq
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// client is a minimal RabbitMQ management API client
+type client struct {
+	baseURL  *url.URL
+	username string
+	password string
+}
+
+func newClient(apiURL string) (*client, error) {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("expected an http:// or https:// URL, not %v", apiURL)
+	}
+	c := &client{baseURL: u}
+	if u.User != nil {
+		c.username = u.User.Username()
+		c.password, _ = u.User.Password()
+		u.User = nil
+	} else {
+		c.username, c.password = "guest", "guest"
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return c, nil
+}
+
+// path joins the escaped segments into an API path. Segments are escaped
+// so that vhosts like "/" work.
+func path(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	return "/api/" + strings.Join(escaped, "/")
+}
+
+func (c *client) get(ctx context.Context, path string, v interface{}) error {
+	return c.do(ctx, http.MethodGet, path, nil, v)
+}
+
+// do sends a request with body (marshalled as JSON) and unmarshals the
+// response into v. Either can be nil.
+func (c *client) do(ctx context.Context, method string, path string, body interface{}, v interface{}) error {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return err
+		}
+	}
+	// Use Opaque so that escaped segments like %2F aren't unescaped.
+	u := *c.baseURL
+	u.Opaque = "//" + u.Host + u.Path + path
+	req, err := http.NewRequest(method, u.String(), &reqBody)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		var apiErr struct {
+			Error  string `json:"error"`
+			Reason string `json:"reason"`
+		}
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Reason != "" {
+			return fmt.Errorf("%v %v: %v: %v", method, path, apiErr.Error, apiErr.Reason)
+		}
+		return fmt.Errorf("%v %v: %v", method, path, resp.Status)
+	}
+	if v == nil || len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, v)
+}
@@ -0,0 +1,137 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type exchangesDir struct {
+	plugin.EntryBase
+	client *client
+	vhost  string
+}
+
+func newExchangesDir(c *client, vhost string) *exchangesDir {
+	exchangesDir := &exchangesDir{
+		EntryBase: plugin.NewEntry("exchanges"),
+	}
+	exchangesDir.client = c
+	exchangesDir.vhost = vhost
+	return exchangesDir
+}
+
+func (es *exchangesDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(es, "exchanges").IsSingleton()
+}
+
+func (es *exchangesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&exchange{}).Schema(),
+	}
+}
+
+// List lists the vhost's exchanges
+func (es *exchangesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var exchanges []exchangeInfo
+	if err := es.client.get(ctx, path("exchanges", es.vhost), &exchanges); err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, 0, len(exchanges))
+	for _, info := range exchanges {
+		entries = append(entries, newExchange(es.client, es.vhost, info))
+	}
+	return entries, nil
+}
+
+type exchangeInfo struct {
+	Name       string                 `json:"name"`
+	Type       string                 `json:"type"`
+	Durable    bool                   `json:"durable"`
+	AutoDelete bool                   `json:"auto_delete"`
+	Internal   bool                   `json:"internal"`
+	Arguments  map[string]interface{} `json:"arguments"`
+}
+
+type exchange struct {
+	plugin.EntryBase
+	client *client
+	vhost  string
+	name   string
+}
+
+// defaultExchangeName is the entry name for the default exchange, whose
+// actual name is the empty string.
+const defaultExchangeName = "amq.default"
+
+func newExchange(c *client, vhost string, info exchangeInfo) *exchange {
+	name := info.Name
+	if name == "" {
+		name = defaultExchangeName
+	}
+	e := &exchange{
+		EntryBase: plugin.NewEntry(name),
+	}
+	e.client = c
+	e.vhost = vhost
+	e.name = info.Name
+	e.SetPartialMetadata(info)
+	return e
+}
+
+func (e *exchange) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(e, "exchange").
+		SetDescription(exchangeDescription).
+		SetPartialMetadataSchema(exchangeInfo{})
+}
+
+// Write publishes a message to the exchange with an empty routing key.
+func (e *exchange) Write(ctx context.Context, data []byte) error {
+	return publish(ctx, e.client, e.vhost, e.name, "", data)
+}
+
+// Delete deletes the exchange. The built-in exchanges can't be deleted.
+func (e *exchange) Delete(ctx context.Context) (bool, error) {
+	if e.name == "" || strings.HasPrefix(e.name, "amq.") {
+		return false, fmt.Errorf("cannot delete the built-in %v exchange", e.Name())
+	}
+	activity.Record(ctx, "Deleting exchange %v in vhost %v", e.name, e.vhost)
+	if err := e.client.do(ctx, "DELETE", path("exchanges", e.vhost, e.name), nil, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// publish publishes data to the exchange with the given routing key.
+func publish(ctx context.Context, c *client, vhost string, exchange string, routingKey string, data []byte) error {
+	name := exchange
+	if name == "" {
+		name = defaultExchangeName
+	}
+	body := map[string]interface{}{
+		"properties":       map[string]interface{}{},
+		"routing_key":      routingKey,
+		"payload":          string(data),
+		"payload_encoding": "string",
+	}
+	var resp struct {
+		Routed bool `json:"routed"`
+	}
+	if err := c.do(ctx, "POST", path("exchanges", vhost, name, "publish"), body, &resp); err != nil {
+		return err
+	}
+	if !resp.Routed {
+		return fmt.Errorf("the message was not routed to any queue")
+	}
+	activity.Record(ctx, "Published %v bytes to exchange %v with routing key %q", len(data), name, routingKey)
+	return nil
+}
+
+const exchangeDescription = `
+This is a RabbitMQ exchange. Writing to it publishes a message with an
+empty routing key. The default exchange is named 'amq.default'.
+`
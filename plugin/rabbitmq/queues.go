@@ -0,0 +1,201 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type queuesDir struct {
+	plugin.EntryBase
+	client *client
+	vhost  string
+}
+
+func newQueuesDir(c *client, vhost string) *queuesDir {
+	queuesDir := &queuesDir{
+		EntryBase: plugin.NewEntry("queues"),
+	}
+	queuesDir.client = c
+	queuesDir.vhost = vhost
+	queuesDir.SetTTLOf(plugin.ListOp, 5*time.Second)
+	return queuesDir
+}
+
+func (qs *queuesDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(qs, "queues").IsSingleton()
+}
+
+func (qs *queuesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&queue{}).Schema(),
+	}
+}
+
+// List lists the vhost's queues
+func (qs *queuesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var queues []queueInfo
+	if err := qs.client.get(ctx, path("queues", qs.vhost), &queues); err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, 0, len(queues))
+	for _, info := range queues {
+		entries = append(entries, newQueue(qs.client, qs.vhost, info))
+	}
+	return entries, nil
+}
+
+type queueInfo struct {
+	Name                   string                 `json:"name"`
+	State                  string                 `json:"state"`
+	Durable                bool                   `json:"durable"`
+	AutoDelete             bool                   `json:"auto_delete"`
+	Exclusive              bool                   `json:"exclusive"`
+	Node                   string                 `json:"node"`
+	Messages               int64                  `json:"messages"`
+	MessagesReady          int64                  `json:"messages_ready"`
+	MessagesUnacknowledged int64                  `json:"messages_unacknowledged"`
+	Consumers              int64                  `json:"consumers"`
+	Memory                 int64                  `json:"memory"`
+	IdleSince              string                 `json:"idle_since"`
+	Arguments              map[string]interface{} `json:"arguments"`
+}
+
+type queue struct {
+	plugin.EntryBase
+	client *client
+	vhost  string
+}
+
+func newQueue(c *client, vhost string, info queueInfo) *queue {
+	q := &queue{
+		EntryBase: plugin.NewEntry(info.Name),
+	}
+	q.client = c
+	q.vhost = vhost
+	q.SetPartialMetadata(info)
+	return q
+}
+
+func (q *queue) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(q, "queue").
+		SetDescription(queueDescription).
+		SetPartialMetadataSchema(queueInfo{}).
+		AddSignal("purge", "Removes all of the queue's ready messages")
+}
+
+// Metadata returns the queue's details, including its message rates
+func (q *queue) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	var meta plugin.JSONObject
+	if err := q.client.get(ctx, path("queues", q.vhost, q.Name()), &meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Write publishes a message to the queue via the default exchange.
+func (q *queue) Write(ctx context.Context, data []byte) error {
+	return publish(ctx, q.client, q.vhost, "", q.Name(), data)
+}
+
+// pollInterval is how often Stream checks for new messages when the queue
+// is empty.
+const pollInterval = 1 * time.Second
+
+type message struct {
+	Payload         string `json:"payload"`
+	PayloadEncoding string `json:"payload_encoding"`
+	MessageCount    int64  `json:"message_count"`
+}
+
+// Stream consumes the queue's messages, writing each payload on its own
+// line. The management API doesn't support subscriptions, so messages are
+// fetched in batches. Note that consumed messages are removed from the queue.
+func (q *queue) Stream(ctx context.Context) (io.ReadCloser, error) {
+	// Fetch a batch up front so that errors are reported to the caller.
+	msgs, err := q.getMessages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Consuming messages from queue %v", q.Name())
+
+	r, w := io.Pipe()
+	go func() {
+		for {
+			for _, msg := range msgs {
+				payload := []byte(msg.Payload)
+				if msg.PayloadEncoding == "base64" {
+					if decoded, err := base64.StdEncoding.DecodeString(msg.Payload); err == nil {
+						payload = decoded
+					}
+				}
+				if _, err := w.Write(append(payload, '\n')); err != nil {
+					// The reader was closed.
+					return
+				}
+			}
+			if len(msgs) == 0 {
+				select {
+				case <-ctx.Done():
+					activity.Record(ctx, "Closing write pipe: %v", w.CloseWithError(ctx.Err()))
+					return
+				case <-time.After(pollInterval):
+				}
+			}
+			if msgs, err = q.getMessages(ctx); err != nil {
+				activity.Record(ctx, "Closing write pipe: %v", w.CloseWithError(err))
+				return
+			}
+		}
+	}()
+	return r, nil
+}
+
+func (q *queue) getMessages(ctx context.Context) ([]message, error) {
+	body := map[string]interface{}{
+		"count":    100,
+		"ackmode":  "ack_requeue_false",
+		"encoding": "auto",
+	}
+	var msgs []message
+	err := q.client.do(ctx, "POST", path("queues", q.vhost, q.Name(), "get"), body, &msgs)
+	return msgs, err
+}
+
+// Signal purges the queue
+func (q *queue) Signal(ctx context.Context, signal string) error {
+	if signal != "purge" {
+		return fmt.Errorf("unsupported signal %v", signal)
+	}
+	activity.Record(ctx, "Purging queue %v in vhost %v", q.Name(), q.vhost)
+	return q.client.do(ctx, "DELETE", path("queues", q.vhost, q.Name(), "contents"), nil, nil)
+}
+
+// Delete deletes the queue
+func (q *queue) Delete(ctx context.Context) (bool, error) {
+	activity.Record(ctx, "Deleting queue %v in vhost %v", q.Name(), q.vhost)
+	if err := q.client.do(ctx, "DELETE", path("queues", q.vhost, q.Name()), nil, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+const queueDescription = `
+This is a RabbitMQ queue. Its metadata includes the queue's depth (the
+'messages', 'messages_ready' and 'messages_unacknowledged' keys) and its
+number of consumers. Tailing the queue consumes its messages, printing each
+payload on its own line. Note that consumed messages are removed from the
+queue. Writing to the queue publishes a message via the default exchange.
+
+Queues can be purged with
+
+  signal purge rabbitmq/local/_/queues/my-queue
+
+or deleted with 'delete'.
+`
@@ -0,0 +1,60 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type server struct {
+	plugin.EntryBase
+	client *client
+}
+
+func newServer(name string, c *client) *server {
+	srv := &server{
+		EntryBase: plugin.NewEntry(name),
+	}
+	srv.client = c
+	srv.DisableDefaultCaching()
+	return srv
+}
+
+func (s *server) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "server").
+		SetDescription(serverDescription)
+}
+
+func (s *server) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&vhost{}).Schema(),
+	}
+}
+
+// List lists the server's vhosts
+func (s *server) List(ctx context.Context) ([]plugin.Entry, error) {
+	var vhosts []vhostInfo
+	if err := s.client.get(ctx, path("vhosts"), &vhosts); err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(vhosts))
+	for i, info := range vhosts {
+		entries[i] = newVhost(s.client, info)
+	}
+	return entries, nil
+}
+
+// Metadata returns the server's overview
+func (s *server) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	var overview plugin.JSONObject
+	if err := s.client.get(ctx, path("overview"), &overview); err != nil {
+		return nil, err
+	}
+	return overview, nil
+}
+
+const serverDescription = `
+This is a RabbitMQ server. Its metadata is the management API's overview,
+which includes the server's version and message rates.
+`
@@ -0,0 +1,51 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type vhostInfo struct {
+	Name     string `json:"name"`
+	Tracing  bool   `json:"tracing"`
+	Messages int64  `json:"messages"`
+}
+
+type vhost struct {
+	plugin.EntryBase
+	client *client
+}
+
+func newVhost(c *client, info vhostInfo) *vhost {
+	v := &vhost{
+		EntryBase: plugin.NewEntry(info.Name),
+	}
+	v.client = c
+	// The default vhost is named "/", so use a slash replacer that's
+	// easier to type than '#'.
+	v.SetSlashReplacer('_')
+	v.SetPartialMetadata(info)
+	return v
+}
+
+func (v *vhost) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(v, "vhost").
+		SetPartialMetadataSchema(vhostInfo{})
+}
+
+func (v *vhost) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&exchangesDir{}).Schema(),
+		(&queuesDir{}).Schema(),
+	}
+}
+
+// List lists the exchanges and queues directories
+func (v *vhost) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{
+		newExchangesDir(v.client, v.Name()),
+		newQueuesDir(v.client, v.Name()),
+	}, nil
+}
@@ -0,0 +1,129 @@
+package redis
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type channelsDir struct {
+	plugin.EntryBase
+	server *server
+}
+
+func newChannelsDir(srv *server) *channelsDir {
+	channelsDir := &channelsDir{
+		EntryBase: plugin.NewEntry("channels"),
+	}
+	channelsDir.server = srv
+	channelsDir.SetTTLOf(plugin.ListOp, 5*time.Second)
+	return channelsDir
+}
+
+func (cs *channelsDir) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(cs, "channels").
+		SetDescription(channelsDirDescription).
+		IsSingleton()
+}
+
+func (cs *channelsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&channel{}).Schema(),
+	}
+}
+
+// List lists the server's active pub/sub channels
+func (cs *channelsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	client := cs.server.client(0).WithContext(ctx)
+	names, err := client.PubSubChannels("*").Result()
+	if err != nil {
+		return nil, err
+	}
+	subs, err := client.PubSubNumSub(names...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]plugin.Entry, len(names))
+	for i, name := range names {
+		entries[i] = newChannel(cs.server, name, subs[name])
+	}
+	return entries, nil
+}
+
+type channelInfo struct {
+	Subscribers int64 `json:"subscribers"`
+}
+
+// channel represents a pub/sub channel
+type channel struct {
+	plugin.EntryBase
+	server *server
+}
+
+func newChannel(srv *server, name string, subscribers int64) *channel {
+	c := &channel{
+		EntryBase: plugin.NewEntry(name),
+	}
+	c.server = srv
+	c.SetPartialMetadata(channelInfo{Subscribers: subscribers})
+	return c
+}
+
+func (c *channel) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(c, "channel").
+		SetDescription(channelDescription).
+		SetPartialMetadataSchema(channelInfo{})
+}
+
+// Stream subscribes to the channel, writing each message on its own line.
+func (c *channel) Stream(ctx context.Context) (io.ReadCloser, error) {
+	pubsub := c.server.client(0).Subscribe(c.Name())
+	// Wait for the subscription to be confirmed so that errors are
+	// reported to the caller.
+	if _, err := pubsub.Receive(); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+	activity.Record(ctx, "Subscribed to channel %v", c.Name())
+
+	r, w := io.Pipe()
+	go func() {
+		defer pubsub.Close()
+		msgs := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				activity.Record(ctx, "Closing write pipe: %v", w.CloseWithError(ctx.Err()))
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					activity.Record(ctx, "Closing write pipe: %v", w.Close())
+					return
+				}
+				if _, err := io.WriteString(w, msg.Payload+"\n"); err != nil {
+					// The reader was closed.
+					return
+				}
+			}
+		}
+	}()
+	return r, nil
+}
+
+const channelsDirDescription = `
+This directory contains the server's active pub/sub channels, i.e. the
+channels that have at least one subscriber.
+`
+
+const channelDescription = `
+This is a Redis pub/sub channel. Tailing it subscribes to the channel and
+prints each published message on its own line, e.g.
+
+  tail -f redis/local/channels/notifications
+`
@@ -0,0 +1,175 @@
+package redis
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-redis/redis"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// keySeparator is the separator that's used to group keys into prefixes
+const keySeparator = ":"
+
+type database struct {
+	plugin.EntryBase
+	server *server
+	db     int
+}
+
+func newDatabase(srv *server, db int, stats map[string]int64) *database {
+	d := &database{
+		EntryBase: plugin.NewEntry(dbName(db)),
+	}
+	d.server = srv
+	d.db = db
+	d.SetPartialMetadata(stats)
+	return d
+}
+
+func (d *database) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(d, "database").
+		SetDescription(databaseDescription).
+		SetPartialMetadataSchema(map[string]int64{})
+}
+
+func (d *database) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&keyPrefix{}).Schema(),
+		(&key{}).Schema(),
+	}
+}
+
+// List lists the database's top-level key prefixes and keys
+func (d *database) List(ctx context.Context) ([]plugin.Entry, error) {
+	return listKeys(ctx, d.server.client(d.db), "")
+}
+
+// keyPrefix represents a group of keys that share a ':'-separated prefix
+type keyPrefix struct {
+	plugin.EntryBase
+	client *redis.Client
+	prefix string
+}
+
+func newKeyPrefix(client *redis.Client, name string, prefix string) *keyPrefix {
+	p := &keyPrefix{
+		EntryBase: plugin.NewEntry(name),
+	}
+	p.client = client
+	p.prefix = prefix
+	return p
+}
+
+func (p *keyPrefix) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(p, "prefix")
+}
+
+func (p *keyPrefix) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&keyPrefix{}).Schema(),
+		(&key{}).Schema(),
+	}
+}
+
+// List lists the prefix's nested prefixes and keys
+func (p *keyPrefix) List(ctx context.Context) ([]plugin.Entry, error) {
+	return listKeys(ctx, p.client, p.prefix)
+}
+
+// listKeys SCANs the keys that start with prefix, grouping them by the
+// next segment of their name.
+func listKeys(ctx context.Context, client *redis.Client, prefix string) ([]plugin.Entry, error) {
+	client = client.WithContext(ctx)
+	match := escapeGlob(prefix) + "*"
+	var keys []string
+	var cursor uint64
+	for {
+		var batch []string
+		var err error
+		batch, cursor, err = client.Scan(cursor, match, 1000).Result()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, batch...)
+		if cursor == 0 {
+			break
+		}
+	}
+	activity.Record(ctx, "Found %v keys with prefix %q", len(keys), prefix)
+
+	prefixes, keyEntries := groupKeys(prefix, keys)
+
+	// Fetch the key types in a single round-trip.
+	pipe := client.Pipeline()
+	typeCmds := make([]*redis.StatusCmd, len(keyEntries))
+	for i, k := range keyEntries {
+		typeCmds[i] = pipe.Type(k)
+	}
+	if len(keyEntries) > 0 {
+		if _, err := pipe.Exec(); err != nil {
+			return nil, err
+		}
+	}
+
+	entries := make([]plugin.Entry, 0, len(prefixes)+len(keyEntries))
+	for dirName, p := range prefixes {
+		entries = append(entries, newKeyPrefix(client, dirName, p))
+	}
+	for i, k := range keyEntries {
+		entries = append(entries, newKey(client, k[len(prefix):], k, typeCmds[i].Val()))
+	}
+	return entries, nil
+}
+
+// groupKeys groups the keys that start with prefix by the next segment of
+// their name. It returns the nested prefixes, keyed by their entry's name,
+// and the keys that are directly under prefix.
+func groupKeys(prefix string, keys []string) (map[string]string, []string) {
+	keyNames := make(map[string]struct{})
+	prefixNames := make(map[string]struct{})
+	var keyEntries []string
+	for _, k := range keys {
+		rest := k[len(prefix):]
+		if ix := strings.Index(rest, keySeparator); ix >= 0 {
+			prefixNames[rest[:ix]] = struct{}{}
+		} else {
+			keyNames[rest] = struct{}{}
+			keyEntries = append(keyEntries, k)
+		}
+	}
+
+	prefixes := make(map[string]string, len(prefixNames))
+	for name := range prefixNames {
+		dirName := name
+		if _, ok := keyNames[name]; ok {
+			// A key and a prefix share the same name, so keep the separator
+			// on the prefix to distinguish them.
+			dirName += keySeparator
+		}
+		prefixes[dirName] = prefix + name + keySeparator
+	}
+	return prefixes, keyEntries
+}
+
+// escapeGlob escapes the characters that SCAN's MATCH treats specially
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch c {
+		case '*', '?', '[', ']', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+const databaseDescription = `
+This is a Redis keyspace database. Keys are grouped into directories by
+their ':'-separated prefixes, so the key 'user:1:name' appears as
+'user/1/name'. If a key and a prefix share the same name, the prefix's
+directory keeps its trailing ':'.
+`
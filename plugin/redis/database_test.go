@@ -0,0 +1,31 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupKeys(t *testing.T) {
+	keys := []string{"counter", "user:1:name", "user:1:email", "user:2:name", "user", "session:abc"}
+	prefixes, keyEntries := groupKeys("", keys)
+	assert.Equal(t, map[string]string{
+		// The user key and prefix share a name, so the prefix keeps its separator
+		"user:":   "user:",
+		"session": "session:",
+	}, prefixes)
+	assert.Equal(t, []string{"counter", "user"}, keyEntries)
+
+	prefixes, keyEntries = groupKeys("user:", []string{"user:1:name", "user:1:email", "user:2:name"})
+	assert.Equal(t, map[string]string{"1": "user:1:", "2": "user:2:"}, prefixes)
+	assert.Empty(t, keyEntries)
+
+	prefixes, keyEntries = groupKeys("user:1:", []string{"user:1:name", "user:1:email"})
+	assert.Empty(t, prefixes)
+	assert.Equal(t, []string{"user:1:name", "user:1:email"}, keyEntries)
+}
+
+func TestEscapeGlob(t *testing.T) {
+	assert.Equal(t, "user:", escapeGlob("user:"))
+	assert.Equal(t, `a\*b\?c\[d\]e\\f`, escapeGlob(`a*b?c[d]e\f`))
+}
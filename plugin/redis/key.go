@@ -0,0 +1,190 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type keyInfo struct {
+	Key  string `json:"key"`
+	Type string `json:"type"`
+}
+
+type keyMetadata struct {
+	keyInfo
+	TTL         time.Duration `json:"ttl"`
+	Encoding    string        `json:"encoding"`
+	MemoryUsage int64         `json:"memory_usage"`
+	Length      int64         `json:"length"`
+}
+
+// key represents a Redis key
+type key struct {
+	plugin.EntryBase
+	client *redis.Client
+	key    string
+	typ    string
+}
+
+func newKey(client *redis.Client, name string, k string, typ string) *key {
+	ky := &key{
+		EntryBase: plugin.NewEntry(name),
+	}
+	ky.client = client
+	ky.key = k
+	ky.typ = typ
+	ky.SetPartialMetadata(keyInfo{Key: k, Type: typ})
+	// Values can change at any time, so don't cache them.
+	ky.DisableCachingFor(plugin.ReadOp)
+	return ky
+}
+
+func (k *key) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(k, "key").
+		SetDescription(keyDescription).
+		SetPartialMetadataSchema(keyInfo{}).
+		SetMetadataSchema(keyMetadata{})
+}
+
+func (k *key) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	client := k.client.WithContext(ctx)
+	meta := keyMetadata{keyInfo: keyInfo{Key: k.key, Type: k.typ}}
+	var err error
+	if meta.Type, err = client.Type(k.key).Result(); err != nil {
+		return nil, err
+	}
+	if meta.TTL, err = client.PTTL(k.key).Result(); err != nil {
+		return nil, err
+	}
+	meta.Encoding, _ = client.ObjectEncoding(k.key).Result()
+	meta.MemoryUsage, _ = client.MemoryUsage(k.key).Result()
+
+	switch meta.Type {
+	case "string":
+		meta.Length, err = client.StrLen(k.key).Result()
+	case "list":
+		meta.Length, err = client.LLen(k.key).Result()
+	case "set":
+		meta.Length, err = client.SCard(k.key).Result()
+	case "zset":
+		meta.Length, err = client.ZCard(k.key).Result()
+	case "hash":
+		meta.Length, err = client.HLen(k.key).Result()
+	case "stream":
+		meta.Length, err = client.XLen(k.key).Result()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return plugin.ToJSONObject(meta), nil
+}
+
+// Read renders the key's value based on its type. Strings are returned
+// as-is, lists and sets have one element per line, and hashes, sorted sets
+// and streams have one tab-separated entry per line.
+func (k *key) Read(ctx context.Context) ([]byte, error) {
+	client := k.client.WithContext(ctx)
+	typ, err := client.Type(k.key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	switch typ {
+	case "none":
+		return nil, fmt.Errorf("the %v key no longer exists", k.key)
+	case "string":
+		return client.Get(k.key).Bytes()
+	case "list":
+		lines, err = client.LRange(k.key, 0, -1).Result()
+	case "set":
+		lines, err = client.SMembers(k.key).Result()
+		sort.Strings(lines)
+	case "zset":
+		var members []redis.Z
+		members, err = client.ZRangeWithScores(k.key, 0, -1).Result()
+		for _, member := range members {
+			lines = append(lines, fmt.Sprintf("%v\t%v", strconv.FormatFloat(member.Score, 'g', -1, 64), member.Member))
+		}
+	case "hash":
+		var fields map[string]string
+		fields, err = client.HGetAll(k.key).Result()
+		for field, value := range fields {
+			lines = append(lines, field+"\t"+value)
+		}
+		sort.Strings(lines)
+	case "stream":
+		var messages []redis.XMessage
+		messages, err = client.XRange(k.key, "-", "+").Result()
+		for _, msg := range messages {
+			fields := make([]string, 0, len(msg.Values))
+			for field, value := range msg.Values {
+				fields = append(fields, fmt.Sprintf("%v=%v", field, value))
+			}
+			sort.Strings(fields)
+			lines = append(lines, msg.ID+"\t"+strings.Join(fields, " "))
+		}
+	default:
+		return nil, fmt.Errorf("reading keys of type %v is not supported", typ)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return []byte{}, nil
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+// Write sets the value of a string key, preserving its TTL.
+func (k *key) Write(ctx context.Context, data []byte) error {
+	client := k.client.WithContext(ctx)
+	typ, err := client.Type(k.key).Result()
+	if err != nil {
+		return err
+	}
+	if typ != "string" && typ != "none" {
+		return fmt.Errorf("writing keys of type %v is not supported", typ)
+	}
+	ttl, err := client.PTTL(k.key).Result()
+	if err != nil {
+		return err
+	}
+	if ttl < 0 {
+		// The key doesn't expire (-1) or doesn't exist (-2)
+		ttl = 0
+	}
+	activity.Record(ctx, "Setting %v to %v bytes", k.key, len(data))
+	return client.Set(k.key, data, ttl).Err()
+}
+
+// Delete deletes the key
+func (k *key) Delete(ctx context.Context) (bool, error) {
+	activity.Record(ctx, "Deleting key %v", k.key)
+	if err := k.client.WithContext(ctx).Del(k.key).Err(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+const keyDescription = `
+This is a Redis key. Reading it renders its value based on its type:
+
+* strings are returned as-is
+* lists and sets contain one element per line
+* hashes contain one tab-separated field and value per line
+* sorted sets contain one tab-separated score and member per line
+* streams contain one tab-separated message ID and fields per line
+
+Writing to a string key sets its value (its TTL is preserved). Keys can be
+deleted with 'delete'.
+`
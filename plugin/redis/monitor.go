@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// monitor represents the server's MONITOR stream
+type monitor struct {
+	plugin.EntryBase
+	server *server
+}
+
+func newMonitor(srv *server) *monitor {
+	m := &monitor{
+		EntryBase: plugin.NewEntry("monitor"),
+	}
+	m.server = srv
+	return m
+}
+
+func (m *monitor) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(m, "monitor").
+		SetDescription(monitorDescription).
+		IsSingleton()
+}
+
+// Stream runs MONITOR on a dedicated connection. go-redis doesn't support
+// MONITOR, so we speak the protocol ourselves.
+func (m *monitor) Stream(ctx context.Context) (io.ReadCloser, error) {
+	opts := m.server.opts
+	network := opts.Network
+	if network == "" {
+		network = "tcp"
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, opts.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if opts.TLSConfig != nil {
+		conn = tls.Client(conn, opts.TLSConfig)
+	}
+
+	rdr := bufio.NewReader(conn)
+	if opts.Password != "" {
+		if err := sendCommand(conn, rdr, "AUTH", opts.Password); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if err := sendCommand(conn, rdr, "MONITOR"); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	activity.Record(ctx, "Monitoring %v", m.server)
+
+	r, w := io.Pipe()
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+	go func() {
+		defer conn.Close()
+		for {
+			line, err := rdr.ReadString('\n')
+			if err != nil {
+				activity.Record(ctx, "Closing write pipe: %v", w.CloseWithError(err))
+				return
+			}
+			// Each monitored command is a simple string, i.e. "+<command>\r\n"
+			if _, err := io.WriteString(w, strings.TrimPrefix(strings.TrimRight(line, "\r\n"), "+")+"\n"); err != nil {
+				return
+			}
+		}
+	}()
+	return r, nil
+}
+
+// sendCommand sends a command and checks that the reply is a simple string.
+func sendCommand(conn net.Conn, rdr *bufio.Reader, args ...string) error {
+	var cmd strings.Builder
+	fmt.Fprintf(&cmd, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&cmd, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(conn, cmd.String()); err != nil {
+		return err
+	}
+	reply, err := rdr.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(reply, "-") {
+		return fmt.Errorf("%v failed: %v", args[0], strings.TrimSpace(reply[1:]))
+	}
+	return nil
+}
+
+const monitorDescription = `
+This is the server's MONITOR stream. Tailing it prints every command that
+the server processes, e.g.
+
+  tail -f redis/local/monitor
+
+Note that MONITOR can significantly reduce the server's throughput.
+`
@@ -0,0 +1,75 @@
+// Package redis presents a filesystem hierarchy for Redis servers.
+//
+// Servers are configured in wash.yaml as a map of names to redis:// URLs.
+// Each server exposes its keyspace databases, pub/sub channels and a
+// MONITOR stream.
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Root of the redis plugin
+type Root struct {
+	plugin.EntryBase
+	servers []plugin.Entry
+}
+
+// Init for root
+func (r *Root) Init(cfg map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("redis")
+	r.DisableDefaultCaching()
+
+	r.servers = []plugin.Entry{}
+	return plugin.ParseNamedConfigs("redis", cfg, "servers", func(name string, config interface{}) error {
+		redisURL, ok := config.(string)
+		if !ok {
+			return fmt.Errorf("expected a redis:// URL, not %v", config)
+		}
+		srv, err := newServer(name, redisURL)
+		if err != nil {
+			return err
+		}
+		r.servers = append(r.servers, srv)
+		return nil
+	})
+}
+
+// Schema returns the root's schema
+func (r *Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "redis").
+		SetDescription(rootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&server{}).Schema(),
+	}
+}
+
+// List lists the configured servers.
+func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	return r.servers, nil
+}
+
+const rootDescription = `
+This is the redis plugin root. It lists the Redis servers configured in
+wash.yaml, e.g.
+
+  redis:
+    servers:
+      local: redis://localhost:6379
+      cache: redis://:password@cache.example.com:6379
+
+Each server includes its non-empty databases (db0, db1, ...), its active
+pub/sub channels, and a 'monitor' file that streams the server's MONITOR
+output when tailed. Keys are grouped into directories by their ':'-separated
+prefixes. Reading a key renders its value based on its type, writing to a
+string key sets its value, and keys can be deleted.
+`
@@ -0,0 +1,149 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// server represents a configured Redis server
+type server struct {
+	plugin.EntryBase
+	opts    *redis.Options
+	mux     sync.Mutex
+	clients map[int]*redis.Client
+}
+
+func newServer(name string, redisURL string) (*server, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, err
+	}
+	srv := &server{
+		EntryBase: plugin.NewEntry(name),
+		opts:      opts,
+		clients:   make(map[int]*redis.Client),
+	}
+	srv.DisableDefaultCaching()
+	return srv, nil
+}
+
+func (s *server) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "server").
+		SetDescription(serverDescription)
+}
+
+func (s *server) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&database{}).Schema(),
+		(&channelsDir{}).Schema(),
+		(&monitor{}).Schema(),
+	}
+}
+
+// List lists the server's non-empty databases, its channels, and its
+// monitor stream.
+func (s *server) List(ctx context.Context) ([]plugin.Entry, error) {
+	keyspace, err := s.client(0).WithContext(ctx).Info("keyspace").Result()
+	if err != nil {
+		return nil, err
+	}
+
+	dbs := make(map[int]map[string]int64)
+	for field, value := range parseInfo(keyspace)["keyspace"] {
+		// Each field looks like "db0:keys=1,expires=0,avg_ttl=0"
+		db, err := strconv.Atoi(strings.TrimPrefix(field, "db"))
+		if err != nil {
+			continue
+		}
+		stats := make(map[string]int64)
+		for _, stat := range strings.Split(value, ",") {
+			segments := strings.SplitN(stat, "=", 2)
+			if len(segments) == 2 {
+				stats[segments[0]], _ = strconv.ParseInt(segments[1], 10, 64)
+			}
+		}
+		dbs[db] = stats
+	}
+
+	indexes := make([]int, 0, len(dbs))
+	for db := range dbs {
+		indexes = append(indexes, db)
+	}
+	sort.Ints(indexes)
+	entries := make([]plugin.Entry, 0, len(dbs)+2)
+	for _, db := range indexes {
+		entries = append(entries, newDatabase(s, db, dbs[db]))
+	}
+	return append(entries, newChannelsDir(s), newMonitor(s)), nil
+}
+
+// Metadata returns the server's INFO output, organized by section
+func (s *server) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	info, err := s.client(0).WithContext(ctx).Info("everything").Result()
+	if err != nil {
+		return nil, err
+	}
+	meta := plugin.JSONObject{}
+	for section, fields := range parseInfo(info) {
+		meta[section] = fields
+	}
+	return meta, nil
+}
+
+// client returns a client for the given database
+func (s *server) client(db int) *redis.Client {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if client, ok := s.clients[db]; ok {
+		return client
+	}
+	opts := *s.opts
+	opts.DB = db
+	client := redis.NewClient(&opts)
+	s.clients[db] = client
+	return client
+}
+
+// parseInfo parses INFO output into a map of section names to fields
+func parseInfo(info string) map[string]map[string]string {
+	sections := make(map[string]map[string]string)
+	var section map[string]string
+	scanner := bufio.NewScanner(strings.NewReader(info))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			section = make(map[string]string)
+			sections[strings.ToLower(strings.TrimSpace(line[1:]))] = section
+			continue
+		}
+		if section == nil {
+			continue
+		}
+		segments := strings.SplitN(line, ":", 2)
+		if len(segments) == 2 {
+			section[segments[0]] = segments[1]
+		}
+	}
+	return sections
+}
+
+func dbName(db int) string {
+	return fmt.Sprintf("db%d", db)
+}
+
+const serverDescription = `
+This is a Redis server. Its metadata is the server's INFO output, organized
+by section (e.g. 'server', 'memory', 'keyspace').
+`
@@ -0,0 +1,99 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInit(t *testing.T) {
+	r := &Root{}
+	assert.NoError(t, r.Init(map[string]interface{}{}))
+	assert.Empty(t, r.servers)
+
+	err := r.Init(map[string]interface{}{
+		"servers": map[string]interface{}{
+			"local": "redis://localhost:6379",
+			"cache": "redis://:password@cache.example.com:6379/2",
+		},
+	})
+	if assert.NoError(t, err) {
+		entries, err := r.List(context.Background())
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, "cache", plugin.Name(entries[0]))
+			assert.Equal(t, "local", plugin.Name(entries[1]))
+			srv := entries[0].(*server)
+			assert.Equal(t, "cache.example.com:6379", srv.opts.Addr)
+			assert.Equal(t, "password", srv.opts.Password)
+			assert.Equal(t, 2, srv.opts.DB)
+		}
+	}
+
+	err = r.Init(map[string]interface{}{
+		"servers": map[string]interface{}{"local": "http://localhost:6379"},
+	})
+	assert.Error(t, err)
+}
+
+func TestServerClient(t *testing.T) {
+	srv, err := newServer("local", "redis://localhost:6379")
+	if !assert.NoError(t, err) {
+		return
+	}
+	db0, db1 := srv.client(0), srv.client(1)
+	assert.Equal(t, 0, db0.Options().DB)
+	assert.Equal(t, 1, db1.Options().DB)
+	assert.Same(t, db0, srv.client(0))
+	assert.Equal(t, 0, srv.opts.DB)
+}
+
+func TestParseInfo(t *testing.T) {
+	info := "# Server\r\nredis_version:5.0.7\r\nos:Linux\r\n\r\n# Keyspace\r\ndb0:keys=1,expires=0,avg_ttl=0\r\n"
+	assert.Equal(t, map[string]map[string]string{
+		"server":   {"redis_version": "5.0.7", "os": "Linux"},
+		"keyspace": {"db0": "keys=1,expires=0,avg_ttl=0"},
+	}, parseInfo(info))
+
+	// Fields outside of a section are ignored
+	assert.Empty(t, parseInfo("redis_version:5.0.7\r\n"))
+}
+
+func TestDBName(t *testing.T) {
+	assert.Equal(t, "db0", dbName(0))
+	assert.Equal(t, "db15", dbName(15))
+}
+
+func TestSendCommand(t *testing.T) {
+	client, srv := net.Pipe()
+	defer client.Close()
+	defer srv.Close()
+
+	// reply reads the command that's sent to srv, then sends it the reply
+	reply := func(cmdLen int, reply string) <-chan string {
+		received := make(chan string, 1)
+		go func() {
+			buf := make([]byte, cmdLen)
+			_, _ = io.ReadFull(srv, buf)
+			received <- string(buf)
+			_, _ = io.WriteString(srv, reply)
+		}()
+		return received
+	}
+
+	auth := "*2\r\n$4\r\nAUTH\r\n$6\r\nsecret\r\n"
+	received := reply(len(auth), "+OK\r\n")
+	assert.NoError(t, sendCommand(client, bufio.NewReader(client), "AUTH", "secret"))
+	assert.Equal(t, auth, <-received)
+
+	monitor := "*1\r\n$7\r\nMONITOR\r\n"
+	received = reply(len(monitor), "-ERR unknown command\r\n")
+	err := sendCommand(client, bufio.NewReader(client), "MONITOR")
+	assert.EqualError(t, err, "MONITOR failed: ERR unknown command")
+	assert.Equal(t, monitor, <-received)
+}
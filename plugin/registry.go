@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
 	"sync"
 )
 
@@ -27,10 +28,80 @@ func NewRegistry() *Registry {
 	return r
 }
 
-// Plugins returns a map of the currently registered plugins. It should not be called
-// while registering plugins.
+// Plugins returns a map of the currently registered plugins that are enabled.
 func (r *Registry) Plugins() map[string]Root {
-	return r.plugins
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	plugins := make(map[string]Root)
+	for name, root := range r.plugins {
+		if !isPluginDisabled(name) {
+			plugins[name] = root
+		}
+	}
+	return plugins
+}
+
+// PluginStatus describes a registered plugin.
+type PluginStatus struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// PluginStatuses returns the status of each registered plugin, sorted by name.
+func (r *Registry) PluginStatuses() []PluginStatus {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	statuses := make([]PluginStatus, 0, len(r.plugins))
+	for name := range r.plugins {
+		statuses = append(statuses, PluginStatus{Name: name, Enabled: !isPluginDisabled(name)})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// DisablePlugin removes the plugin's subtree from Wash until it's re-enabled.
+// The plugin's entries reject every action while it's disabled, so they're
+// inaccessible even to clients that are still holding on to them (like FUSE).
+func (r *Registry) DisablePlugin(name string) error {
+	return r.setPluginDisabled(name, true)
+}
+
+// EnablePlugin adds a disabled plugin's subtree back to Wash.
+func (r *Registry) EnablePlugin(name string) error {
+	return r.setPluginDisabled(name, false)
+}
+
+func (r *Registry) setPluginDisabled(name string, disabled bool) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if _, ok := r.plugins[name]; !ok {
+		return fmt.Errorf("the %v plugin isn't registered", name)
+	}
+	disabledPluginsMux.Lock()
+	if disabled {
+		disabledPlugins[name] = true
+	} else {
+		delete(disabledPlugins, name)
+	}
+	disabledPluginsMux.Unlock()
+	// Clear the plugin's cached data so that it's reloaded once it's enabled.
+	if cache != nil {
+		ClearCacheFor("/"+name, false)
+	}
+	return nil
+}
+
+// The disabled plugins are global rather than per-registry so that
+// Action#checkPermitted can reject actions on their entries.
+var disabledPlugins = make(map[string]bool)
+var disabledPluginsMux sync.RWMutex
+
+func isPluginDisabled(name string) bool {
+	disabledPluginsMux.RLock()
+	defer disabledPluginsMux.RUnlock()
+	return disabledPlugins[name]
 }
 
 var pluginNameRegex = regexp.MustCompile("^[0-9a-zA-Z_-]+$")
@@ -90,9 +161,21 @@ func (r *Registry) Schema() *EntrySchema {
 	return nil
 }
 
-// List all of Wash's loaded plugins
+// List all of Wash's loaded plugins that are enabled
 func (r *Registry) List(ctx context.Context) ([]Entry, error) {
-	return r.pluginRoots, nil
+	return r.enabledPluginRoots(), nil
+}
+
+func (r *Registry) enabledPluginRoots() []Entry {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	roots := make([]Entry, 0, len(r.pluginRoots))
+	for _, root := range r.pluginRoots {
+		if !isPluginDisabled(root.eb().name) {
+			roots = append(roots, root)
+		}
+	}
+	return roots
 }
 
 type stubRoot struct {
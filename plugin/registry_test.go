@@ -139,6 +139,38 @@ func (suite *RegistryTestSuite) TestRegisterPluginPluginRootImplementsDelete() {
 	suite.Panics(panicFunc, "r.RegisterPlugin: the mine plugin's root implements delete")
 }
 
+func (suite *RegistryTestSuite) TestDisableAndEnablePlugin() {
+	reg := NewRegistry()
+	m := &mockRoot{EntryBase: NewEntry("mine")}
+	cfg := map[string]interface{}{}
+	m.On("Init", cfg).Return(nil)
+	suite.NoError(reg.RegisterPlugin(m, cfg))
+
+	suite.EqualError(reg.DisablePlugin("unknown"), "the unknown plugin isn't registered")
+
+	suite.NoError(reg.DisablePlugin("mine"))
+	defer func() { suite.NoError(reg.EnablePlugin("mine")) }()
+	suite.NotContains(reg.Plugins(), "mine")
+	suite.Equal([]PluginStatus{{Name: "mine", Enabled: false}}, reg.PluginStatuses())
+	roots, err := reg.List(context.Background())
+	if suite.NoError(err) {
+		suite.Empty(roots)
+	}
+	err = ListAction().checkPermitted(m)
+	suite.EqualError(err, "the list action is not permitted: the mine plugin is disabled")
+	_, err = Metadata(context.Background(), m)
+	suite.EqualError(err, "the metadata action is not permitted: the mine plugin is disabled")
+
+	suite.NoError(reg.EnablePlugin("mine"))
+	suite.Contains(reg.Plugins(), "mine")
+	suite.Equal([]PluginStatus{{Name: "mine", Enabled: true}}, reg.PluginStatuses())
+	roots, err = reg.List(context.Background())
+	if suite.NoError(err) {
+		suite.Equal([]Entry{m}, roots)
+	}
+	suite.NoError(ListAction().checkPermitted(m))
+}
+
 func TestRegistry(t *testing.T) {
 	suite.Run(t, new(RegistryTestSuite))
 }
@@ -0,0 +1,157 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/puppetlabs/wash/tracing"
+	log "github.com/sirupsen/logrus"
+)
+
+// RetryPolicy configures how a plugin's List, Read, Metadata, Stream and
+// Search methods are retried when they fail with a transient error, e.g. when the
+// provider throttles its API. The wait between attempts starts at
+// InitialBackoff and doubles after each attempt, up to MaxBackoff. A
+// MaxAttempts of 0 or 1 disables retries.
+//
+// Exec, Write, Signal and Delete aren't retried because they aren't
+// idempotent. StreamList isn't retried because some of the children may
+// already have been emitted when it fails.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy is the RetryPolicy of plugins that don't configure one.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+}
+
+// RetryPolicies are the plugins' retry policies.
+type RetryPolicies struct {
+	// Default is the policy of each plugin that isn't in Plugins.
+	Default RetryPolicy
+	// Plugins are the policies of specific plugins.
+	Plugins map[string]RetryPolicy
+}
+
+var retryPolicies = RetryPolicies{Default: DefaultRetryPolicy}
+
+// SetRetryPolicies sets the plugins' retry policies.
+func SetRetryPolicies(policies RetryPolicies) {
+	retryPolicies = policies
+}
+
+func retryPolicyOf(plugin string) RetryPolicy {
+	if policy, ok := retryPolicies.Plugins[plugin]; ok {
+		return policy
+	}
+	return retryPolicies.Default
+}
+
+// backoff returns how long to wait before the given retry, starting at 1. The
+// wait's jittered so that invocations that were throttled together don't
+// retry together.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < retry && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// TransientError is implemented by errors that know whether they're
+// transient. Plugins can return one to control whether Wash retries the
+// method that returned it.
+type TransientError interface {
+	error
+	Transient() bool
+}
+
+// throttlingCodes are the error codes that cloud SDKs, e.g. the AWS SDK,
+// return when a request's throttled or times out.
+var throttlingCodes = map[string]bool{
+	"Throttling":                             true,
+	"ThrottlingException":                    true,
+	"ThrottledException":                     true,
+	"RequestThrottled":                       true,
+	"RequestThrottledException":              true,
+	"RequestLimitExceeded":                   true,
+	"TooManyRequestsException":               true,
+	"ProvisionedThroughputExceededException": true,
+	"SlowDown":                               true,
+	"RequestTimeout":                         true,
+	"RequestTimeoutException":                true,
+	"rateLimitExceeded":                      true,
+	"userRateLimitExceeded":                  true,
+}
+
+// IsTransientErr returns true if err is likely to go away if the method that
+// returned it is retried, e.g. because it's a throttling error or a network
+// timeout.
+func IsTransientErr(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var transientErr TransientError
+	if errors.As(err, &transientErr) {
+		return transientErr.Transient()
+	}
+	var codedErr interface{ Code() string }
+	if errors.As(err, &codedErr) && throttlingCodes[codedErr.Code()] {
+		return true
+	}
+	var statusErr interface{ StatusCode() int }
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode() {
+		case 429, 502, 503, 504:
+			return true
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// retry invokes op until it succeeds, it returns an error that isn't
+// transient, or the plugin's retry policy gives up. It returns op's last
+// error. The span records how many attempts were made.
+func retry(ctx context.Context, span *methodSpan, op func() error) error {
+	policy := retryPolicyOf(span.fields["plugin"].(string))
+	attempts := 1
+	defer func() {
+		if attempts > 1 {
+			span.SetAttributes(tracing.Int("wash.attempts", int64(attempts)))
+		}
+	}()
+	for {
+		err := op()
+		if attempts >= policy.MaxAttempts || !IsTransientErr(err) {
+			return err
+		}
+		wait := policy.backoff(attempts)
+		log.WithFields(span.fields).Debugf("plugin: retrying %v %v in %v: %v", span.fields["method"], span.fields["path"], wait, err)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return err
+		}
+		attempts++
+	}
+}
@@ -0,0 +1,102 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type retryTestsTransientErr bool
+
+func (e retryTestsTransientErr) Error() string {
+	return "an error"
+}
+
+func (e retryTestsTransientErr) Transient() bool {
+	return bool(e)
+}
+
+type retryTestsCodedErr string
+
+func (e retryTestsCodedErr) Error() string {
+	return string(e)
+}
+
+func (e retryTestsCodedErr) Code() string {
+	return string(e)
+}
+
+func TestIsTransientErr(t *testing.T) {
+	assert.False(t, IsTransientErr(nil))
+	assert.False(t, IsTransientErr(fmt.Errorf("an error")))
+	assert.False(t, IsTransientErr(context.Canceled))
+	assert.False(t, IsTransientErr(context.DeadlineExceeded))
+
+	assert.True(t, IsTransientErr(retryTestsTransientErr(true)))
+	assert.False(t, IsTransientErr(retryTestsTransientErr(false)))
+	assert.True(t, IsTransientErr(retryTestsCodedErr("ThrottlingException")))
+	assert.False(t, IsTransientErr(retryTestsCodedErr("AccessDenied")))
+	assert.True(t, IsTransientErr(&net.OpError{Op: "read", Err: syscall.ECONNRESET}))
+	assert.True(t, IsTransientErr(fmt.Errorf("list failed: %w", syscall.ECONNREFUSED)))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	for retry, max := range []time.Duration{100, 200, 300, 300} {
+		max *= time.Millisecond
+		wait := policy.backoff(retry + 1)
+		assert.True(t, wait >= max/2 && wait <= max, "retry %v waited %v", retry+1, wait)
+	}
+	assert.Equal(t, time.Duration(0), RetryPolicy{}.backoff(1))
+}
+
+func TestRetry(t *testing.T) {
+	SetRetryPolicies(RetryPolicies{
+		Default: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+		Plugins: map[string]RetryPolicy{"noretry": {MaxAttempts: 1}},
+	})
+	defer SetRetryPolicies(RetryPolicies{Default: DefaultRetryPolicy})
+
+	entry := newMethodWrappersTestsMockEntry("foo")
+	entry.SetTestID("/foo/bar")
+	ctx, span := startMethodSpan(context.Background(), entry, "List")
+	defer span.End()
+
+	retryOn := func(errs ...error) (int, error) {
+		attempts := 0
+		err := retry(ctx, span, func() error {
+			err := errs[attempts]
+			attempts++
+			return err
+		})
+		return attempts, err
+	}
+
+	transientErr := retryTestsTransientErr(true)
+	attempts, err := retryOn(transientErr, nil)
+	assert.Equal(t, 2, attempts)
+	assert.NoError(t, err)
+
+	// Gives up after MaxAttempts
+	attempts, err = retryOn(transientErr, transientErr, transientErr)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, transientErr, err)
+
+	// Errors that aren't transient aren't retried
+	attempts, err = retryOn(fmt.Errorf("an error"))
+	assert.Equal(t, 1, attempts)
+	assert.EqualError(t, err, "an error")
+
+	// Per-plugin policies override the default
+	entry.SetTestID("/noretry/bar")
+	_, span = startMethodSpan(context.Background(), entry, "List")
+	defer span.End()
+	attempts, err = retryOn(transientErr)
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, transientErr, err)
+}
@@ -0,0 +1,142 @@
+package sftp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path"
+
+	"github.com/jlaffaye/ftp"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/volume"
+)
+
+type ftpFileSystem struct {
+	addr     string
+	user     string
+	password string
+	tls      bool
+}
+
+func newFTPFileSystem(u *url.URL, useTLS bool) *ftpFileSystem {
+	fs := &ftpFileSystem{
+		addr: u.Host,
+		user: "anonymous",
+		tls:  useTLS,
+	}
+	if u.Port() == "" {
+		fs.addr = net.JoinHostPort(u.Hostname(), "21")
+	}
+	if u.User != nil {
+		fs.user = u.User.Username()
+		fs.password, _ = u.User.Password()
+	}
+	return fs
+}
+
+// conn opens a new logged-in connection. FTP connections are stateful and
+// can't be shared between concurrent operations, so each operation uses its
+// own connection. Callers must quit the returned connection.
+func (fs *ftpFileSystem) conn(ctx context.Context) (*ftp.ServerConn, error) {
+	opts := []ftp.DialOption{ftp.DialWithContext(ctx)}
+	if fs.tls {
+		host, _, _ := net.SplitHostPort(fs.addr)
+		opts = append(opts, ftp.DialWithExplicitTLS(&tls.Config{ServerName: host}))
+	}
+	conn, err := ftp.Dial(fs.addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Login(fs.user, fs.password); err != nil {
+		conn.Quit()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (fs *ftpFileSystem) quit(ctx context.Context, conn *ftp.ServerConn) {
+	if err := conn.Quit(); err != nil {
+		activity.Record(ctx, "Error closing FTP connection to %v: %v", fs.addr, err)
+	}
+}
+
+func (fs *ftpFileSystem) readDir(ctx context.Context, dir string) (volume.Children, error) {
+	conn, err := fs.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.quit(ctx, conn)
+
+	entries, err := conn.List(dir)
+	if err != nil {
+		return nil, err
+	}
+	children := make(volume.Children, len(entries))
+	for _, entry := range entries {
+		if entry.Name == "." || entry.Name == ".." {
+			continue
+		}
+		attr := plugin.EntryAttributes{}
+		attr.
+			SetSize(entry.Size).
+			SetMtime(entry.Time).
+			SetCtime(entry.Time).
+			SetAtime(entry.Time)
+		switch entry.Type {
+		case ftp.EntryTypeFolder:
+			attr.SetMode(os.ModeDir | 0755)
+		case ftp.EntryTypeLink:
+			attr.SetMode(os.ModeSymlink | 0777)
+		default:
+			attr.SetMode(0644)
+		}
+		children[entry.Name] = attr
+	}
+	return children, nil
+}
+
+func (fs *ftpFileSystem) readFile(ctx context.Context, file string) ([]byte, error) {
+	conn, err := fs.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.quit(ctx, conn)
+
+	resp, err := conn.Retr(file)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	return ioutil.ReadAll(resp)
+}
+
+// writeFile uploads data to file. FTP doesn't have a portable way to set
+// permissions, so mode is ignored.
+func (fs *ftpFileSystem) writeFile(ctx context.Context, file string, data []byte, _ os.FileMode) error {
+	conn, err := fs.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer fs.quit(ctx, conn)
+
+	return conn.Stor(file, bytes.NewReader(data))
+}
+
+func (fs *ftpFileSystem) removeAll(ctx context.Context, p string) error {
+	conn, err := fs.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer fs.quit(ctx, conn)
+
+	// Try deleting p as a file first since that's the common case.
+	if err := conn.Delete(p); err == nil {
+		return nil
+	}
+	return conn.RemoveDirRecur(path.Clean(p))
+}
@@ -0,0 +1,143 @@
+package sftp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/volume"
+)
+
+// fileSystem is implemented by each of the supported protocols. Paths are
+// absolute paths on the server.
+type fileSystem interface {
+	readDir(ctx context.Context, dir string) (volume.Children, error)
+	readFile(ctx context.Context, file string) ([]byte, error)
+	writeFile(ctx context.Context, file string, data []byte, mode os.FileMode) error
+	removeAll(ctx context.Context, p string) error
+}
+
+type serverMetadata struct {
+	Protocol string `json:"protocol"`
+	Host     string `json:"host"`
+	User     string `json:"user"`
+	Path     string `json:"path"`
+}
+
+// server represents a configured file server. It implements volume.Interface,
+// so its directories and files are volume entries.
+type server struct {
+	plugin.EntryBase
+	fs       fileSystem
+	basePath string
+}
+
+func newServer(name string, serverURL string) (*server, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := &server{
+		EntryBase: plugin.NewEntry(name),
+	}
+	srv.basePath = path.Clean("/" + u.Path)
+	switch u.Scheme {
+	case "sftp":
+		srv.fs = newSFTPFileSystem(u)
+		if u.Path == "" {
+			// SFTP paths are relative to the user's home directory
+			srv.basePath = "."
+		}
+	case "ftp", "ftps":
+		srv.fs = newFTPFileSystem(u, u.Scheme == "ftps")
+	default:
+		return nil, fmt.Errorf("unsupported protocol %v; expected one of sftp, ftps or ftp", u.Scheme)
+	}
+
+	srv.SetTTLOf(plugin.ListOp, volume.ListTTL)
+	srv.SetPartialMetadata(serverMetadata{
+		Protocol: u.Scheme,
+		Host:     u.Host,
+		User:     u.User.Username(),
+		Path:     srv.basePath,
+	})
+	return srv, nil
+}
+
+func (s *server) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "server").
+		SetDescription(serverDescription).
+		SetPartialMetadataSchema(serverMetadata{})
+}
+
+func (s *server) ChildSchemas() []*plugin.EntrySchema {
+	return volume.ChildSchemas()
+}
+
+func (s *server) List(ctx context.Context) ([]plugin.Entry, error) {
+	return volume.List(ctx, s)
+}
+
+// remotePath converts a volume path to a path on the server
+func (s *server) remotePath(p string) string {
+	if p == volume.RootPath {
+		return s.basePath
+	}
+	return path.Join(s.basePath, p)
+}
+
+// VolumeList lists the directory at p. Subdirectories are left unexplored.
+func (s *server) VolumeList(ctx context.Context, p string) (volume.DirMap, error) {
+	children, err := s.fs.readDir(ctx, s.remotePath(p))
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Listed %v entries in %v", len(children), s.remotePath(p))
+
+	dirmap := volume.DirMap{p: children}
+	for name, attr := range children {
+		if attr.Mode().IsDir() {
+			dirmap[p+"/"+name] = nil
+		}
+	}
+	return dirmap, nil
+}
+
+// VolumeRead reads the file at p
+func (s *server) VolumeRead(ctx context.Context, p string) ([]byte, error) {
+	return s.fs.readFile(ctx, s.remotePath(p))
+}
+
+// VolumeStream is not supported by file servers
+func (s *server) VolumeStream(ctx context.Context, p string) (io.ReadCloser, error) {
+	return nil, fmt.Errorf("streaming files is not supported by the sftp plugin")
+}
+
+// VolumeWrite replaces the file at p with b
+func (s *server) VolumeWrite(ctx context.Context, p string, b []byte, mode os.FileMode) error {
+	activity.Record(ctx, "Writing %v bytes to %v", len(b), s.remotePath(p))
+	return s.fs.writeFile(ctx, s.remotePath(p), b, mode)
+}
+
+// VolumeDelete recursively deletes the file or directory at p
+func (s *server) VolumeDelete(ctx context.Context, p string) (bool, error) {
+	activity.Record(ctx, "Deleting %v", s.remotePath(p))
+	if err := s.fs.removeAll(ctx, s.remotePath(p)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+const serverDescription = `
+This is a remote file server. Its directories and files mirror the server's
+contents, starting from the configured path. Files can be read, written and
+deleted. Directories can be deleted, which recursively deletes their
+contents.
+`
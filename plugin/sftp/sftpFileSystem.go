@@ -0,0 +1,146 @@
+package sftp
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/pkg/sftp"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/transport"
+	"github.com/puppetlabs/wash/volume"
+)
+
+type sftpFileSystem struct {
+	identity transport.Identity
+}
+
+func newSFTPFileSystem(u *url.URL) *sftpFileSystem {
+	id := transport.Identity{
+		Host: u.Hostname(),
+		User: u.User.Username(),
+	}
+	id.Password, _ = u.User.Password()
+	if port, err := strconv.ParseUint(u.Port(), 10, 32); err == nil {
+		id.Port = uint(port)
+	}
+	return &sftpFileSystem{identity: id}
+}
+
+// client opens a new SFTP session. The underlying SSH connection is cached
+// by the transport package, so this is relatively cheap. Callers must close
+// the returned client.
+func (fs *sftpFileSystem) client(ctx context.Context) (*sftp.Client, error) {
+	conn, err := transport.ConnectSSH(ctx, fs.identity)
+	if err != nil {
+		return nil, err
+	}
+	return sftp.NewClient(conn)
+}
+
+func (fs *sftpFileSystem) readDir(ctx context.Context, dir string) (volume.Children, error) {
+	client, err := fs.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	infos, err := client.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	children := make(volume.Children, len(infos))
+	for _, info := range infos {
+		children[info.Name()] = sftpAttributes(info)
+	}
+	return children, nil
+}
+
+func sftpAttributes(info os.FileInfo) plugin.EntryAttributes {
+	attr := plugin.EntryAttributes{}
+	attr.
+		SetMode(info.Mode()).
+		SetSize(uint64(info.Size())).
+		SetMtime(info.ModTime()).
+		SetCtime(info.ModTime()).
+		SetAtime(info.ModTime())
+	if stat, ok := info.Sys().(*sftp.FileStat); ok {
+		attr.SetAtime(time.Unix(int64(stat.Atime), 0))
+	}
+	return attr
+}
+
+func (fs *sftpFileSystem) readFile(ctx context.Context, file string) ([]byte, error) {
+	client, err := fs.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	f, err := client.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ioutil.ReadAll(f)
+}
+
+func (fs *sftpFileSystem) writeFile(ctx context.Context, file string, data []byte, mode os.FileMode) error {
+	client, err := fs.client(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.MkdirAll(path.Dir(file)); err != nil {
+		return err
+	}
+	f, err := client.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if mode != 0 {
+		return client.Chmod(file, mode)
+	}
+	return nil
+}
+
+func (fs *sftpFileSystem) removeAll(ctx context.Context, p string) error {
+	client, err := fs.client(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return sftpRemoveAll(client, p)
+}
+
+func sftpRemoveAll(client *sftp.Client, p string) error {
+	info, err := client.Lstat(p)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return client.Remove(p)
+	}
+	infos, err := client.ReadDir(p)
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		if err := sftpRemoveAll(client, path.Join(p, info.Name())); err != nil {
+			return err
+		}
+	}
+	return client.RemoveDirectory(p)
+}
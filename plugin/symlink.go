@@ -0,0 +1,77 @@
+package plugin
+
+import (
+	"path"
+	"strings"
+)
+
+// SymlinkEntry represents a symlink to another Wash entry. It's useful for
+// relating entries that are in different parts of a plugin, e.g. a container
+// and the volumes it mounts.
+type SymlinkEntry struct {
+	EntryBase
+	target string
+}
+
+// NewSymlinkEntry creates a new SymlinkEntry that links to target. See the
+// Symlink interface for target's format.
+func NewSymlinkEntry(name string, target string) *SymlinkEntry {
+	return &SymlinkEntry{
+		EntryBase: NewEntry(name),
+		target:    target,
+	}
+}
+
+// Schema defines the schema of a symlink.
+func (s *SymlinkEntry) Schema() *EntrySchema {
+	return NewEntrySchema(s, "symlink").SetDescription(symlinkDescription)
+}
+
+// SymlinkTarget returns the symlink's target.
+func (s *SymlinkEntry) SymlinkTarget() string {
+	return s.target
+}
+
+// SymlinkTarget returns the entry's target if it's a symlink. Absolute targets
+// are made relative to the entry's parent so that the target's the same
+// regardless of where Wash is mounted. SymlinkTarget returns "" if the entry
+// isn't a symlink.
+func SymlinkTarget(e Entry) string {
+	s, ok := e.(Symlink)
+	if !ok {
+		return ""
+	}
+	target := s.SymlinkTarget()
+	if !strings.HasPrefix(target, "/") {
+		return target
+	}
+
+	from, to := splitPath(path.Dir(ID(e))), splitPath(target)
+	i := 0
+	for i < len(from) && i < len(to) && from[i] == to[i] {
+		i++
+	}
+	var segments []string
+	for range from[i:] {
+		segments = append(segments, "..")
+	}
+	segments = append(segments, to[i:]...)
+	if len(segments) == 0 {
+		return "."
+	}
+	return strings.Join(segments, "/")
+}
+
+// splitPath returns the segments of an absolute path.
+func splitPath(p string) []string {
+	trimmed := strings.Trim(path.Clean(p), "/")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "/")
+}
+
+const symlinkDescription = `
+A link to another entry. It's a symlink in the mounted filesystem, so you can
+cd into it to navigate to the entry that it's related to.
+`
@@ -0,0 +1,29 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSymlinkTarget(t *testing.T) {
+	basic := &basicEntry{NewEntry("foo")}
+	assert.Equal(t, "", SymlinkTarget(basic))
+
+	link := NewSymlinkEntry("foo", "../bar")
+	link.SetTestID("/a/b/foo")
+	assert.Implements(t, (*Symlink)(nil), link)
+	assert.Equal(t, "../bar", SymlinkTarget(link))
+
+	for target, expected := range map[string]string{
+		"/a/b/bar":   "bar",
+		"/a/c/bar":   "../c/bar",
+		"/d":         "../../d",
+		"/a/b":       ".",
+		"/a/b/c/../": ".",
+	} {
+		link := NewSymlinkEntry("foo", target)
+		link.SetTestID("/a/b/foo")
+		assert.Equal(t, expected, SymlinkTarget(link), "target %v", target)
+	}
+}
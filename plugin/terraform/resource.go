@@ -0,0 +1,89 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+type resourceMetadata struct {
+	Address    string                 `json:"address"`
+	Module     string                 `json:"module"`
+	Mode       string                 `json:"mode"`
+	Type       string                 `json:"type"`
+	Name       string                 `json:"name"`
+	Provider   string                 `json:"provider"`
+	IndexKey   interface{}            `json:"index_key"`
+	Attributes map[string]interface{} `json:"attributes"`
+	WashPath   string                 `json:"wash_path"`
+}
+
+// resource represents a resource instance in a state
+type resource struct {
+	plugin.EntryBase
+}
+
+func newResource(meta resourceMetadata) *resource {
+	r := &resource{
+		EntryBase: plugin.NewEntry(meta.Address),
+	}
+	r.SetPartialMetadata(meta)
+	return r
+}
+
+func (r *resource) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "resource").
+		SetDescription(resourceDescription).
+		SetPartialMetadataSchema(resourceMetadata{})
+}
+
+// washPath returns the path of the live entry that corresponds to the resource,
+// or the empty string if it can't be resolved. Path segments that can't be
+// determined from the state (like the AWS profile) are represented by '*'
+// so that the path can be used as a glob.
+func washPath(typ string, attrs map[string]interface{}) string {
+	str := func(key string) string {
+		if s, ok := attrs[key].(string); ok {
+			return s
+		}
+		return ""
+	}
+
+	switch typ {
+	case "aws_instance":
+		if id := str("id"); id != "" {
+			return "aws/*/resources/ec2/instances/" + id
+		}
+	case "aws_s3_bucket":
+		if bucket := str("bucket"); bucket != "" {
+			return "aws/*/resources/s3/" + bucket
+		}
+	case "google_compute_instance":
+		if project, name := str("project"), str("name"); project != "" && name != "" {
+			return fmt.Sprintf("gcp/%v/compute/%v", project, name)
+		}
+	case "google_storage_bucket":
+		if project, name := str("project"), str("name"); project != "" && name != "" {
+			return fmt.Sprintf("gcp/%v/storage/%v", project, name)
+		}
+	case "docker_container":
+		if name := str("name"); name != "" {
+			return "docker/containers/" + name
+		}
+	case "docker_volume":
+		if name := str("name"); name != "" {
+			return "docker/volumes/" + name
+		}
+	}
+	return ""
+}
+
+const resourceDescription = `
+This is a resource instance managed by a Terraform state. Its metadata
+includes the resource's type, provider and attributes. If the resource
+corresponds to an entry in another plugin (e.g. an EC2 instance or a Docker
+container), then its 'wash_path' metadata key contains that entry's path.
+Segments that can't be determined from the state, like the AWS profile, are
+replaced with '*'.
+`
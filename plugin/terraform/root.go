@@ -0,0 +1,92 @@
+// Package terraform presents a filesystem hierarchy for Terraform state.
+//
+// States are configured in wash.yaml as a map of names to locations. The
+// resources in each state are exposed as entries whose metadata contains
+// the resource's attributes.
+package terraform
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Root of the terraform plugin
+type Root struct {
+	plugin.EntryBase
+	states []plugin.Entry
+}
+
+// Init for root
+func (r *Root) Init(cfg map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("terraform")
+	r.DisableDefaultCaching()
+
+	token := os.Getenv("TFE_TOKEN")
+	if tokenI, ok := cfg["token"]; ok {
+		if token, ok = tokenI.(string); !ok {
+			return fmt.Errorf("terraform.token config must be a string, not %v", tokenI)
+		}
+	}
+
+	r.states = []plugin.Entry{}
+	return plugin.ParseNamedConfigs("terraform", cfg, "states", func(name string, config interface{}) error {
+		location, ok := config.(string)
+		if !ok {
+			return fmt.Errorf("expected a state location, not %v", config)
+		}
+		src, err := newStateSource(location, token)
+		if err != nil {
+			return err
+		}
+		r.states = append(r.states, newState(name, src))
+		return nil
+	})
+}
+
+// Schema returns the root's schema
+func (r *Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "terraform").
+		SetDescription(rootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&state{}).Schema(),
+	}
+}
+
+// List lists the configured states.
+func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	return r.states, nil
+}
+
+const rootDescription = `
+This is the terraform plugin root. It lists the Terraform states configured
+in wash.yaml, e.g.
+
+  terraform:
+    states:
+      network: ~/infra/network/terraform.tfstate
+      app: s3://my-tf-state/app/terraform.tfstate
+      data: gs://my-tf-state/data/default.tfstate
+      prod: tfc://my-org/prod-workspace
+
+S3 states use the default AWS credentials (see the AWS plugin's docs), and
+GCS states use Google's application default credentials. Terraform Cloud
+states (tfc://<organization>/<workspace>) use the 'terraform.token' config
+or the TFE_TOKEN environment variable.
+
+Each state lists its resources by their address, e.g. 'aws_instance.web' or
+'module.vpc.aws_subnet.private[0]'. A resource's metadata includes its
+attributes and, where it can be resolved, the Wash path of the live entry
+that it manages. This lets you answer questions like "what does this state
+manage" with
+
+  find terraform/app -meta .type aws_instance
+`
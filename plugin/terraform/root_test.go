@@ -0,0 +1,59 @@
+package terraform
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInit(t *testing.T) {
+	r := &Root{}
+	assert.NoError(t, r.Init(map[string]interface{}{}))
+	assert.Empty(t, r.states)
+
+	err := r.Init(map[string]interface{}{
+		"token": "secret",
+		"states": map[string]interface{}{
+			"prod":    "tfc://my-org/prod-workspace",
+			"network": "/infra/network/terraform.tfstate",
+		},
+	})
+	if assert.NoError(t, err) {
+		entries, err := r.List(context.Background())
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, "network", plugin.Name(entries[0]))
+			assert.Equal(t, "prod", plugin.Name(entries[1]))
+			assert.Equal(t, "secret", entries[1].(*state).src.(*tfcSource).token)
+		}
+	}
+
+	err = r.Init(map[string]interface{}{"token": 5})
+	assert.EqualError(t, err, "terraform.token config must be a string, not 5")
+
+	err = r.Init(map[string]interface{}{
+		"states": map[string]interface{}{"app": 5},
+	})
+	assert.EqualError(t, err, "terraform.states.app config is invalid: expected a state location, not 5")
+
+	err = r.Init(map[string]interface{}{
+		"states": map[string]interface{}{"app": "http://example.com/terraform.tfstate"},
+	})
+	assert.EqualError(t, err, "terraform.states.app config is invalid: unsupported state location http://example.com/terraform.tfstate; expected a path or an s3://, gs:// or tfc:// URL")
+}
+
+func TestInit_TokenFromEnv(t *testing.T) {
+	os.Setenv("TFE_TOKEN", "from-env")
+	defer os.Unsetenv("TFE_TOKEN")
+
+	r := &Root{}
+	err := r.Init(map[string]interface{}{
+		"states": map[string]interface{}{"prod": "tfc://my-org/prod-workspace"},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "from-env", r.states[0].(*state).src.(*tfcSource).token)
+	}
+}
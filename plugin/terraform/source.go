@@ -0,0 +1,201 @@
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	s3Client "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/puppetlabs/wash/activity"
+)
+
+// stateSource fetches the raw content of a state file
+type stateSource interface {
+	fetch(ctx context.Context) ([]byte, error)
+	String() string
+}
+
+func newStateSource(location string, token string) (stateSource, error) {
+	u, err := url.Parse(location)
+	if err != nil || u.Scheme == "" || len(u.Scheme) == 1 {
+		// A local path. Single-letter schemes are Windows drive letters.
+		return newLocalSource(location)
+	}
+	key := strings.TrimPrefix(u.Path, "/")
+	switch u.Scheme {
+	case "file":
+		return newLocalSource(u.Path)
+	case "s3":
+		return &s3Source{bucket: u.Host, key: key}, nil
+	case "gs":
+		return &gcsSource{bucket: u.Host, object: key}, nil
+	case "tfc":
+		if u.Host == "" || key == "" || strings.Contains(key, "/") {
+			return nil, fmt.Errorf("expected tfc://<organization>/<workspace>, not %v", location)
+		}
+		return &tfcSource{organization: u.Host, workspace: key, token: token}, nil
+	default:
+		return nil, fmt.Errorf("unsupported state location %v; expected a path or an s3://, gs:// or tfc:// URL", location)
+	}
+}
+
+type localSource struct {
+	path string
+}
+
+func newLocalSource(path string) (*localSource, error) {
+	if strings.HasPrefix(path, "~/") {
+		homedir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(homedir, path[2:])
+	}
+	return &localSource{path: path}, nil
+}
+
+func (s *localSource) fetch(ctx context.Context) ([]byte, error) {
+	return ioutil.ReadFile(s.path)
+}
+
+func (s *localSource) String() string {
+	return s.path
+}
+
+type s3Source struct {
+	bucket string
+	key    string
+}
+
+func (s *s3Source) fetch(ctx context.Context) ([]byte, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	region, err := s3manager.GetBucketRegion(ctx, sess, s.bucket, "us-east-1")
+	if err != nil {
+		return nil, fmt.Errorf("could not get the region of bucket %v: %v", s.bucket, err)
+	}
+	client := s3Client.New(sess, awsSDK.NewConfig().WithRegion(region))
+	resp, err := client.GetObjectWithContext(ctx, &s3Client.GetObjectInput{
+		Bucket: awsSDK.String(s.bucket),
+		Key:    awsSDK.String(s.key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (s *s3Source) String() string {
+	return "s3://" + s.bucket + "/" + s.key
+}
+
+type gcsSource struct {
+	bucket string
+	object string
+}
+
+func (s *gcsSource) fetch(ctx context.Context) ([]byte, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	rdr, err := client.Bucket(s.bucket).Object(s.object).NewReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+	return ioutil.ReadAll(rdr)
+}
+
+func (s *gcsSource) String() string {
+	return "gs://" + s.bucket + "/" + s.object
+}
+
+const tfcAPI = "https://app.terraform.io/api/v2"
+
+type tfcSource struct {
+	organization string
+	workspace    string
+	token        string
+}
+
+func (s *tfcSource) fetch(ctx context.Context) ([]byte, error) {
+	if s.token == "" {
+		return nil, fmt.Errorf("a token is required to read %v; set terraform.token or TFE_TOKEN", s)
+	}
+
+	var workspace struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	wsURL := fmt.Sprintf("%v/organizations/%v/workspaces/%v", tfcAPI, url.PathEscape(s.organization), url.PathEscape(s.workspace))
+	if err := s.getJSON(ctx, wsURL, &workspace); err != nil {
+		return nil, err
+	}
+
+	var stateVersion struct {
+		Data struct {
+			Attributes struct {
+				DownloadURL string `json:"hosted-state-download-url"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	svURL := fmt.Sprintf("%v/workspaces/%v/current-state-version", tfcAPI, workspace.Data.ID)
+	if err := s.getJSON(ctx, svURL, &stateVersion); err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Downloading the current state of %v", s)
+	return s.get(ctx, stateVersion.Data.Attributes.DownloadURL)
+}
+
+func (s *tfcSource) get(ctx context.Context, u string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("GET %v: %v", u, resp.Status)
+	}
+	return body, nil
+}
+
+func (s *tfcSource) getJSON(ctx context.Context, u string, v interface{}) error {
+	body, err := s.get(ctx, u)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+func (s *tfcSource) String() string {
+	return "tfc://" + s.organization + "/" + s.workspace
+}
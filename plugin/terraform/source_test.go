@@ -0,0 +1,66 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStateSource(t *testing.T) {
+	homedir, err := os.UserHomeDir()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	cases := []struct {
+		location string
+		expected stateSource
+	}{
+		{"/infra/terraform.tfstate", &localSource{path: "/infra/terraform.tfstate"}},
+		{"infra/terraform.tfstate", &localSource{path: "infra/terraform.tfstate"}},
+		{"~/infra/terraform.tfstate", &localSource{path: filepath.Join(homedir, "infra/terraform.tfstate")}},
+		{`C:\infra\terraform.tfstate`, &localSource{path: `C:\infra\terraform.tfstate`}},
+		{"file:///infra/terraform.tfstate", &localSource{path: "/infra/terraform.tfstate"}},
+		{"s3://my-tf-state/app/terraform.tfstate", &s3Source{bucket: "my-tf-state", key: "app/terraform.tfstate"}},
+		{"gs://my-tf-state/data/default.tfstate", &gcsSource{bucket: "my-tf-state", object: "data/default.tfstate"}},
+		{"tfc://my-org/prod-workspace", &tfcSource{organization: "my-org", workspace: "prod-workspace", token: "secret"}},
+	}
+	for _, c := range cases {
+		src, err := newStateSource(c.location, "secret")
+		if assert.NoError(t, err, c.location) {
+			assert.Equal(t, c.expected, src, c.location)
+		}
+	}
+}
+
+func TestNewStateSource_Errors(t *testing.T) {
+	cases := []struct {
+		location string
+		err      string
+	}{
+		{"tfc://my-org", "expected tfc://<organization>/<workspace>, not tfc://my-org"},
+		{"tfc:///prod-workspace", "expected tfc://<organization>/<workspace>, not tfc:///prod-workspace"},
+		{"tfc://my-org/prod/workspace", "expected tfc://<organization>/<workspace>, not tfc://my-org/prod/workspace"},
+		{"http://example.com/terraform.tfstate", "unsupported state location http://example.com/terraform.tfstate; expected a path or an s3://, gs:// or tfc:// URL"},
+	}
+	for _, c := range cases {
+		_, err := newStateSource(c.location, "")
+		assert.EqualError(t, err, c.err, c.location)
+	}
+}
+
+func TestStateSourceString(t *testing.T) {
+	for _, location := range []string{
+		"/infra/terraform.tfstate",
+		"s3://my-tf-state/app/terraform.tfstate",
+		"gs://my-tf-state/data/default.tfstate",
+		"tfc://my-org/prod-workspace",
+	} {
+		src, err := newStateSource(location, "")
+		if assert.NoError(t, err, location) {
+			assert.Equal(t, location, src.String())
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// stateFile is the subset of the (version 4) state format that we use
+type stateFile struct {
+	Version          int                    `json:"version"`
+	TerraformVersion string                 `json:"terraform_version"`
+	Serial           int64                  `json:"serial"`
+	Lineage          string                 `json:"lineage"`
+	Outputs          map[string]interface{} `json:"outputs"`
+	Resources        []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Provider  string `json:"provider"`
+		Instances []struct {
+			IndexKey   interface{}            `json:"index_key"`
+			Attributes map[string]interface{} `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+type stateMetadata struct {
+	Location         string `json:"location"`
+	Version          int    `json:"version"`
+	TerraformVersion string `json:"terraform_version"`
+	Serial           int64  `json:"serial"`
+	Lineage          string `json:"lineage"`
+	Resources        int    `json:"resources"`
+}
+
+type state struct {
+	plugin.EntryBase
+	src stateSource
+}
+
+func newState(name string, src stateSource) *state {
+	s := &state{
+		EntryBase: plugin.NewEntry(name),
+	}
+	s.src = src
+	s.SetTTLOf(plugin.ListOp, 1*time.Minute)
+	s.SetPartialMetadata(stateMetadata{Location: src.String()})
+	return s
+}
+
+func (s *state) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "state").
+		SetDescription(stateDescription).
+		SetPartialMetadataSchema(stateMetadata{}).
+		SetMetadataSchema(stateMetadata{})
+}
+
+func (s *state) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&resource{}).Schema(),
+		(&outputsFile{}).Schema(),
+	}
+}
+
+func (s *state) load(ctx context.Context) (*stateFile, error) {
+	activity.Record(ctx, "Loading Terraform state from %v", s.src)
+	content, err := s.src.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var sf stateFile
+	if err := json.Unmarshal(content, &sf); err != nil {
+		return nil, fmt.Errorf("could not parse the state in %v: %v", s.src, err)
+	}
+	if sf.Version < 4 {
+		return nil, fmt.Errorf("the state in %v uses format version %v; only version 4 (Terraform 0.12+) is supported", s.src, sf.Version)
+	}
+	return &sf, nil
+}
+
+// Metadata includes the state's version info
+func (s *state) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	sf, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	meta := stateMetadata{
+		Location:         s.src.String(),
+		Version:          sf.Version,
+		TerraformVersion: sf.TerraformVersion,
+		Serial:           sf.Serial,
+		Lineage:          sf.Lineage,
+	}
+	for _, r := range sf.Resources {
+		meta.Resources += len(r.Instances)
+	}
+	return plugin.ToJSONObject(meta), nil
+}
+
+// List lists the state's resource instances and its outputs
+func (s *state) List(ctx context.Context) ([]plugin.Entry, error) {
+	sf, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []plugin.Entry
+	for _, r := range sf.Resources {
+		for _, inst := range r.Instances {
+			entries = append(entries, newResource(resourceMetadata{
+				Address:    address(r.Module, r.Mode, r.Type, r.Name, inst.IndexKey),
+				Module:     r.Module,
+				Mode:       r.Mode,
+				Type:       r.Type,
+				Name:       r.Name,
+				Provider:   r.Provider,
+				IndexKey:   inst.IndexKey,
+				Attributes: inst.Attributes,
+				WashPath:   washPath(r.Type, inst.Attributes),
+			}))
+		}
+	}
+	outputs, err := newOutputsFile(sf.Outputs)
+	if err != nil {
+		return nil, err
+	}
+	return append(entries, outputs), nil
+}
+
+// address returns the resource instance's address, e.g. module.vpc.aws_subnet.private[0]
+func address(module string, mode string, typ string, name string, indexKey interface{}) string {
+	addr := typ + "." + name
+	if mode == "data" {
+		addr = "data." + addr
+	}
+	if module != "" {
+		addr = module + "." + addr
+	}
+	switch key := indexKey.(type) {
+	case nil:
+	case string:
+		addr += fmt.Sprintf("[%q]", key)
+	default:
+		addr += fmt.Sprintf("[%v]", key)
+	}
+	return addr
+}
+
+// outputsFile contains the state's outputs
+type outputsFile struct {
+	plugin.EntryBase
+	content []byte
+}
+
+func newOutputsFile(outputs map[string]interface{}) (*outputsFile, error) {
+	content, err := json.MarshalIndent(outputs, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	f := &outputsFile{
+		EntryBase: plugin.NewEntry("outputs.json"),
+	}
+	f.content = content
+	f.Attributes().SetSize(uint64(len(content)))
+	return f, nil
+}
+
+func (f *outputsFile) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(f, "outputs.json").
+		IsSingleton()
+}
+
+func (f *outputsFile) Read(ctx context.Context) ([]byte, error) {
+	return f.content, nil
+}
+
+const stateDescription = `
+This is a Terraform state. Its children are the state's resource instances
+(named by their address) and an 'outputs.json' file containing the state's
+outputs. The state is re-fetched at most once a minute.
+`
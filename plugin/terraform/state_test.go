@@ -0,0 +1,155 @@
+package terraform
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+const testState = `{
+  "version": 4,
+  "terraform_version": "0.12.24",
+  "serial": 7,
+  "lineage": "3f2a9c1e",
+  "outputs": {
+    "ip": {"value": "10.0.0.5", "type": "string"}
+  },
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "web",
+      "provider": "provider.aws",
+      "instances": [
+        {"attributes": {"id": "i-0123456789abcdef0"}}
+      ]
+    },
+    {
+      "module": "module.vpc",
+      "mode": "managed",
+      "type": "aws_subnet",
+      "name": "private",
+      "provider": "provider.aws",
+      "instances": [
+        {"index_key": 0, "attributes": {"id": "subnet-1"}},
+        {"index_key": 1, "attributes": {"id": "subnet-2"}}
+      ]
+    }
+  ]
+}`
+
+func writeTestState(t *testing.T, content string) (*state, func()) {
+	dir, err := ioutil.TempDir("", "terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "terraform.tfstate")
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return newState("app", &localSource{path: path}), func() { os.RemoveAll(dir) }
+}
+
+func TestState_List(t *testing.T) {
+	s, cleanup := writeTestState(t, testState)
+	defer cleanup()
+
+	entries, err := s.List(context.Background())
+	if !assert.NoError(t, err) || !assert.Len(t, entries, 4) {
+		return
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, plugin.Name(entry))
+	}
+	assert.Equal(t, []string{
+		"aws_instance.web",
+		"module.vpc.aws_subnet.private[0]",
+		"module.vpc.aws_subnet.private[1]",
+		"outputs.json",
+	}, names)
+
+	meta := plugin.PartialMetadata(entries[0])
+	assert.Equal(t, "aws/*/resources/ec2/instances/i-0123456789abcdef0", meta["wash_path"])
+	assert.Equal(t, "provider.aws", meta["provider"])
+
+	content, err := entries[3].(*outputsFile).Read(context.Background())
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"ip": {"value": "10.0.0.5", "type": "string"}}`, string(content))
+	}
+}
+
+func TestState_Metadata(t *testing.T) {
+	s, cleanup := writeTestState(t, testState)
+	defer cleanup()
+
+	meta, err := s.Metadata(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, plugin.ToJSONObject(stateMetadata{
+			Location:         s.src.String(),
+			Version:          4,
+			TerraformVersion: "0.12.24",
+			Serial:           7,
+			Lineage:          "3f2a9c1e",
+			Resources:        3,
+		}), meta)
+	}
+}
+
+func TestState_LoadErrors(t *testing.T) {
+	s, cleanup := writeTestState(t, `{"version": 3}`)
+	defer cleanup()
+	_, err := s.List(context.Background())
+	assert.EqualError(t, err, "the state in "+s.src.String()+" uses format version 3; only version 4 (Terraform 0.12+) is supported")
+
+	s, cleanup = writeTestState(t, `not json`)
+	defer cleanup()
+	_, err = s.List(context.Background())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "could not parse the state in "+s.src.String())
+	}
+}
+
+func TestAddress(t *testing.T) {
+	cases := []struct {
+		module   string
+		mode     string
+		indexKey interface{}
+		expected string
+	}{
+		{"", "managed", nil, "aws_instance.web"},
+		{"", "data", nil, "data.aws_instance.web"},
+		{"module.app", "managed", nil, "module.app.aws_instance.web"},
+		{"module.app", "data", float64(2), "module.app.data.aws_instance.web[2]"},
+		{"", "managed", "blue", `aws_instance.web["blue"]`},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, address(c.module, c.mode, "aws_instance", "web", c.indexKey))
+	}
+}
+
+func TestWashPath(t *testing.T) {
+	cases := []struct {
+		typ      string
+		attrs    map[string]interface{}
+		expected string
+	}{
+		{"aws_instance", map[string]interface{}{"id": "i-1"}, "aws/*/resources/ec2/instances/i-1"},
+		{"aws_s3_bucket", map[string]interface{}{"bucket": "logs"}, "aws/*/resources/s3/logs"},
+		{"google_compute_instance", map[string]interface{}{"project": "p", "name": "vm"}, "gcp/p/compute/vm"},
+		{"google_compute_instance", map[string]interface{}{"name": "vm"}, ""},
+		{"google_storage_bucket", map[string]interface{}{"project": "p", "name": "b"}, "gcp/p/storage/b"},
+		{"docker_container", map[string]interface{}{"name": "web"}, "docker/containers/web"},
+		{"docker_volume", map[string]interface{}{"name": "data"}, "docker/volumes/data"},
+		{"aws_instance", map[string]interface{}{"id": 5}, ""},
+		{"null_resource", map[string]interface{}{"id": "1"}, ""},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, washPath(c.typ, c.attrs), c.typ)
+	}
+}
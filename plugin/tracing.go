@@ -0,0 +1,106 @@
+package plugin
+
+import (
+	"context"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/slowlog"
+	"github.com/puppetlabs/wash/tracing"
+	log "github.com/sirupsen/logrus"
+)
+
+// methodSpan records an invocation of an entry's method as a tracing span, in
+// the method's metrics, the entry's activity and the slow log, and as a debug
+// log entry with the plugin, path, method, duration and error fields, which
+// makes them queryable when the server logs JSON.
+type methodSpan struct {
+	*tracing.Span
+	fields  log.Fields
+	id      string
+	journal activity.Journal
+	start   time.Time
+	err     error
+	release func()
+}
+
+// startMethodSpan starts a methodSpan for invoking the entry's method. Spans
+// are started where Wash calls into the plugin, so cached results aren't
+// recorded. It waits until the invocation's within the concurrency limits,
+// which doesn't count towards the recorded duration. Callers must End the
+// span.
+func startMethodSpan(ctx context.Context, e Entry, method string) (context.Context, *methodSpan) {
+	ctx, span := tracing.Start(
+		ctx,
+		"plugin: "+method,
+		tracing.String("wash.plugin", pluginName(e)),
+		tracing.String("wash.path", e.eb().id),
+		tracing.String("wash.method", method),
+	)
+	journal, _ := ctx.Value(activity.JournalKey).(activity.Journal)
+	slowlog.CountInvocation(ctx)
+	queued := time.Now()
+	ctx, release := acquireInvocationSlot(ctx, pluginName(e))
+	if wait := time.Since(queued); wait >= time.Millisecond {
+		span.SetAttributes(tracing.Int("wash.queued_ms", wait.Milliseconds()))
+	}
+	return ctx, &methodSpan{
+		Span: span,
+		fields: log.Fields{
+			"plugin": pluginName(e),
+			"path":   e.eb().id,
+			"method": method,
+		},
+		id:      e.eb().id,
+		journal: journal,
+		start:   time.Now(),
+		release: release,
+	}
+}
+
+// RecordError marks the span as failed with err. Nil errors are ignored.
+func (s *methodSpan) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.err = err
+	s.Span.RecordError(err)
+}
+
+// End ends the span, then records and logs the invocation.
+func (s *methodSpan) End() {
+	s.release()
+	s.Span.End()
+	duration := time.Since(s.start)
+	method := s.fields["method"].(string)
+	recordMethodInvocation(s.fields["plugin"].(string), method, duration, s.err)
+	invocation := MethodInvocation{
+		Method:    method,
+		Time:      s.start,
+		Duration:  duration,
+		JournalID: s.journal.ID,
+		Command:   s.journal.Description,
+	}
+	entry := log.WithFields(s.fields).WithField("duration", duration.Seconds())
+	if s.err != nil {
+		invocation.Error = s.err.Error()
+		entry = entry.WithField("error", invocation.Error)
+	}
+	recordEntryInvocation(s.id, invocation)
+	cache := slowlog.Uncached
+	switch method {
+	case "List", "Read", "Metadata":
+		cache = slowlog.Miss
+	}
+	slowlog.Record(slowlog.Operation{
+		Time:     s.start,
+		Kind:     slowlog.Method,
+		Plugin:   s.fields["plugin"].(string),
+		Path:     s.id,
+		Method:   method,
+		Duration: duration,
+		Cache:    cache,
+		Error:    invocation.Error,
+	})
+	entry.Debugf("plugin: %v %v", s.fields["method"], s.fields["path"])
+}
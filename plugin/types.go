@@ -90,6 +90,35 @@ type Parent interface {
 	List(context.Context) ([]Entry, error)
 }
 
+// StreamLister is an interface that parents with very large numbers of children, like an S3
+// bucket, can implement to list them incrementally. StreamList should call emit with each child
+// as soon as it's available, e.g. after each page of a paginated API call, and stop listing and
+// return emit's error if it returns one. emit must not be called concurrently.
+//
+// When a parent implements StreamLister, Wash uses StreamList instead of List so that consumers
+// like FUSE's READDIR and the API's list endpoint can start returning children before the
+// whole listing's finished. List is still required since it's a part of the Parent interface.
+// Unlike List, StreamList isn't retried because some of the children may already have been
+// consumed.
+type StreamLister interface {
+	Parent
+	StreamList(ctx context.Context, emit func(Entry) error) error
+}
+
+// PaginatedLister is an interface that parents with huge collections of children, like an S3
+// prefix, can implement to list them one page at a time. ListPage returns up to limit of the
+// parent's children, starting from the page identified by continuationToken, along with the
+// token of the next page. The first page's token is empty, and so is the returned token once
+// there aren't any more children. A limit of 0 lets the parent pick the page's size. Tokens
+// are opaque to Wash.
+//
+// Pages aren't cached. The parent's children are still found via List, which is why it's
+// required.
+type PaginatedLister interface {
+	Parent
+	ListPage(ctx context.Context, limit int, continuationToken string) (entries []Entry, nextToken string, err error)
+}
+
 // SchemaMap represents a map of <type> => <JSON schema>.
 type SchemaMap = map[interface{}]*JSONSchema
 
@@ -117,6 +146,22 @@ type HasWrappedTypes interface {
 	WrappedTypes() SchemaMap
 }
 
+// ShellHelper is a command that a plugin contributes to the Wash shell. Command is a Wash command
+// as it would be typed in the Wash shell, e.g. "wps docker/containers". Arguments passed to the
+// helper are appended to Command.
+type ShellHelper struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+}
+
+// HasShellHelpers is an interface that plugin roots can implement to contribute helpers to the
+// Wash shell. Helpers are installed for all supported shells when the shell starts. Helpers
+// that conflict with Wash commands or user-defined aliases are skipped.
+type HasShellHelpers interface {
+	Root
+	ShellHelpers() []ShellHelper
+}
+
 // ExecOptions is a struct we can add new features to that must be serializable to JSON.
 // Examples of potential features: user, privileged, map of environment variables, timeout.
 type ExecOptions struct {
@@ -228,6 +273,78 @@ type Deletable interface {
 	Delete(context.Context) (bool, error)
 }
 
+// Renameable is an entry that can be renamed within its parent. Rename should
+// rename the entry to newName, which is the entry's new name (not its cname).
+// It's never empty and never contains a "/". If an entry with that name
+// already exists, then Rename should return an error instead of replacing it.
+type Renameable interface {
+	Entry
+	Rename(ctx context.Context, newName string) error
+}
+
+// Copyable is an entry that can be copied to another parent within the same
+// plugin without sending its content through Wash, e.g. by using the plugin's
+// API to do a server-side copy. Copy should copy the entry to a child of
+// dstParent named dstName, replacing the existing child if there's one.
+// dstName is never empty and never contains a "/". Copy should return an
+// error if it can't copy the entry to dstParent, e.g. because dstParent's a
+// different kind of entry.
+type Copyable interface {
+	Entry
+	Copy(ctx context.Context, dstParent Parent, dstName string) error
+}
+
+// Creatable is a parent that can create new children. Create should create a
+// child named name and return it. If attr's mode is a directory, then the child
+// should be a parent (e.g. a directory or an object prefix). Otherwise, it
+// should be empty. name is never empty and never contains a "/". If a child
+// with that name already exists, then Create should return an error instead of
+// replacing it.
+type Creatable interface {
+	Parent
+	Create(ctx context.Context, name string, attr EntryAttributes) (Entry, error)
+}
+
+// SetAttrable is an entry whose attributes can be changed, like chmod and
+// touch do for files. SetAttr should only change the attributes that are set
+// in attr, which are some of its mode's permission bits, atime and mtime.
+type SetAttrable interface {
+	Entry
+	SetAttr(ctx context.Context, attr EntryAttributes) error
+}
+
+// SearchMatch is a line of content that matched a search.
+type SearchMatch struct {
+	// Path is the path of the entry whose content has the line, relative to
+	// the searched entry. It's empty if the line's in the searched entry's
+	// own content.
+	Path string `json:"path,omitempty"`
+	// Line is the line's number, starting from 1. It's 0 if the line's number
+	// isn't known, e.g. for a log event.
+	Line int    `json:"line,omitempty"`
+	Text string `json:"text"`
+}
+
+// Searchable is an entry whose content, or whose descendants' content, can be
+// searched by the plugin's API instead of being read by Wash, e.g. a log group
+// whose events can be filtered by the service. Search should return the lines
+// that match pattern, a Go regular expression. Parents should search all of
+// their descendants.
+type Searchable interface {
+	Entry
+	Search(ctx context.Context, pattern string) ([]SearchMatch, error)
+}
+
+// Symlink is an entry that links to another Wash entry, e.g. a container's link
+// to a volume that it mounts. SymlinkTarget returns the target's path, which is
+// relative to the symlink's parent. If it starts with a "/", then it's absolute
+// instead, i.e. it's the target's ID. An empty target means that the entry
+// isn't a symlink.
+type Symlink interface {
+	Entry
+	SymlinkTarget() string
+}
+
 // Signalable is an entry that can be signaled. Signal should return nil if the
 // signal was successfully sent. Otherwise, it should return an error explaining
 // why the signal was not sent.
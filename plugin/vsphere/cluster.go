@@ -0,0 +1,174 @@
+package vsphere
+
+import (
+	"context"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+)
+
+type clustersDir struct {
+	plugin.EntryBase
+	dc *datacenter
+}
+
+func newClustersDir(dc *datacenter) *clustersDir {
+	c := &clustersDir{
+		EntryBase: plugin.NewEntry("clusters"),
+	}
+	c.dc = dc
+	return c
+}
+
+func (c *clustersDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(c, "clusters").IsSingleton()
+}
+
+func (c *clustersDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&cluster{}).Schema(),
+	}
+}
+
+// List lists the datacenter's clusters
+func (c *clustersDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	f, err := c.dc.finder(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := f.ClusterComputeResourceList(ctx, "*")
+	if err != nil {
+		if _, ok := err.(*find.NotFoundError); ok {
+			return []plugin.Entry{}, nil
+		}
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(clusters))
+	for i, cl := range clusters {
+		entries[i] = newCluster(c.dc.server, cl)
+	}
+	return entries, nil
+}
+
+type cluster struct {
+	plugin.EntryBase
+	server  *server
+	cluster *object.ClusterComputeResource
+}
+
+func newCluster(s *server, cl *object.ClusterComputeResource) *cluster {
+	c := &cluster{
+		EntryBase: plugin.NewEntry(cl.Name()),
+	}
+	c.server = s
+	c.cluster = cl
+	return c
+}
+
+func (c *cluster) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(c, "cluster").
+		SetDescription(clusterDescription)
+}
+
+func (c *cluster) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&host{}).Schema(),
+	}
+}
+
+// Metadata returns the cluster's summary
+func (c *cluster) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	var cl mo.ClusterComputeResource
+	if err := c.cluster.Properties(ctx, c.cluster.Reference(), []string{"summary"}, &cl); err != nil {
+		return nil, err
+	}
+	return plugin.ToJSONObject(cl.Summary), nil
+}
+
+// List lists the cluster's hosts
+func (c *cluster) List(ctx context.Context) ([]plugin.Entry, error) {
+	hosts, err := c.cluster.Hosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(hosts))
+	for i, h := range hosts {
+		entries[i] = newHost(c.server, h)
+	}
+	return entries, nil
+}
+
+type host struct {
+	plugin.EntryBase
+	server *server
+	host   *object.HostSystem
+}
+
+func newHost(s *server, h *object.HostSystem) *host {
+	hst := &host{
+		EntryBase: plugin.NewEntry(h.Name()),
+	}
+	hst.server = s
+	hst.host = h
+	hst.SetTTLOf(plugin.ListOp, 30*time.Second)
+	return hst
+}
+
+func (h *host) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(h, "host").
+		SetDescription(hostDescription)
+}
+
+func (h *host) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&vm{}).Schema(),
+	}
+}
+
+// Metadata returns the host's summary
+func (h *host) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	var hs mo.HostSystem
+	if err := h.host.Properties(ctx, h.host.Reference(), []string{"summary"}, &hs); err != nil {
+		return nil, err
+	}
+	return plugin.ToJSONObject(hs.Summary), nil
+}
+
+// List lists the VMs running on the host
+func (h *host) List(ctx context.Context) ([]plugin.Entry, error) {
+	var hs mo.HostSystem
+	if err := h.host.Properties(ctx, h.host.Reference(), []string{"vm"}, &hs); err != nil {
+		return nil, err
+	}
+	if len(hs.Vm) == 0 {
+		return []plugin.Entry{}, nil
+	}
+	var vms []mo.VirtualMachine
+	pc := property.DefaultCollector(h.host.Client())
+	if err := pc.Retrieve(ctx, hs.Vm, []string{"name", "summary", "runtime", "guest"}, &vms); err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Listing %v VMs on %v", len(vms), h.Name())
+	entries := make([]plugin.Entry, len(vms))
+	for i, v := range vms {
+		entries[i] = newVM(h.server, object.NewVirtualMachine(h.host.Client(), v.Reference()), v)
+	}
+	return entries, nil
+}
+
+const clusterDescription = `
+This is a vSphere cluster. Its metadata is the cluster's summary, and it
+lists the cluster's hosts.
+`
+
+const hostDescription = `
+This is an ESXi host. Its metadata is the host's summary, and it lists the
+VMs that the host is running.
+`
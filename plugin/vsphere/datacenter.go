@@ -0,0 +1,60 @@
+package vsphere
+
+import (
+	"context"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+)
+
+type datacenter struct {
+	plugin.EntryBase
+	server *server
+	dc     *object.Datacenter
+}
+
+func newDatacenter(s *server, dc *object.Datacenter) *datacenter {
+	d := &datacenter{
+		EntryBase: plugin.NewEntry(dc.Name()),
+	}
+	d.server = s
+	d.dc = dc
+	return d
+}
+
+func (d *datacenter) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(d, "datacenter").
+		SetDescription(datacenterDescription)
+}
+
+func (d *datacenter) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&clustersDir{}).Schema(),
+		(&datastoresDir{}).Schema(),
+	}
+}
+
+func (d *datacenter) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{
+		newClustersDir(d),
+		newDatastoresDir(d),
+	}, nil
+}
+
+// finder returns a finder that's scoped to the datacenter
+func (d *datacenter) finder(ctx context.Context) (*find.Finder, error) {
+	client, err := d.server.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	f := find.NewFinder(client.Client, true)
+	f.SetDatacenter(d.dc)
+	return f, nil
+}
+
+const datacenterDescription = `
+This is a vSphere datacenter. Its children are the datacenter's clusters and
+datastores.
+`
@@ -0,0 +1,207 @@
+package vsphere
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/volume"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+type datastoresDir struct {
+	plugin.EntryBase
+	dc *datacenter
+}
+
+func newDatastoresDir(dc *datacenter) *datastoresDir {
+	d := &datastoresDir{
+		EntryBase: plugin.NewEntry("datastores"),
+	}
+	d.dc = dc
+	return d
+}
+
+func (d *datastoresDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(d, "datastores").IsSingleton()
+}
+
+func (d *datastoresDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&datastore{}).Schema(),
+	}
+}
+
+// List lists the datacenter's datastores
+func (d *datastoresDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	f, err := d.dc.finder(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stores, err := f.DatastoreList(ctx, "*")
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(stores))
+	for i, ds := range stores {
+		entries[i] = newDatastore(d.dc, ds)
+	}
+	return entries, nil
+}
+
+// datastore implements volume.Interface, so its directories and files are
+// volume entries.
+type datastore struct {
+	plugin.EntryBase
+	dc *datacenter
+	ds *object.Datastore
+}
+
+func newDatastore(dc *datacenter, ds *object.Datastore) *datastore {
+	d := &datastore{
+		EntryBase: plugin.NewEntry(ds.Name()),
+	}
+	d.dc = dc
+	d.ds = ds
+	d.SetTTLOf(plugin.ListOp, volume.ListTTL)
+	return d
+}
+
+func (d *datastore) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(d, "datastore").
+		SetDescription(datastoreDescription)
+}
+
+func (d *datastore) ChildSchemas() []*plugin.EntrySchema {
+	return volume.ChildSchemas()
+}
+
+func (d *datastore) List(ctx context.Context) ([]plugin.Entry, error) {
+	return volume.List(ctx, d)
+}
+
+// Metadata returns the datastore's summary
+func (d *datastore) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	var m mo.Datastore
+	if err := d.ds.Properties(ctx, d.ds.Reference(), []string{"summary"}, &m); err != nil {
+		return nil, err
+	}
+	return plugin.ToJSONObject(m.Summary), nil
+}
+
+// dsPath converts a volume path to a datastore path like "[ds1] dir/file"
+func (d *datastore) dsPath(p string) string {
+	return d.ds.Path(strings.TrimPrefix(p, "/"))
+}
+
+// VolumeList lists the directory at p. Subdirectories are left unexplored.
+func (d *datastore) VolumeList(ctx context.Context, p string) (volume.DirMap, error) {
+	browser, err := d.ds.Browser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	spec := types.HostDatastoreBrowserSearchSpec{
+		Details: &types.FileQueryFlags{
+			FileType:     true,
+			FileSize:     true,
+			Modification: true,
+		},
+	}
+	task, err := browser.SearchDatastore(ctx, d.dsPath(p), &spec)
+	if err != nil {
+		return nil, err
+	}
+	info, err := task.WaitForResult(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	results, ok := info.Result.(types.HostDatastoreBrowserSearchResults)
+	if !ok {
+		return nil, fmt.Errorf("unexpected search result %T for %v", info.Result, d.dsPath(p))
+	}
+
+	children := make(volume.Children, len(results.File))
+	dirmap := volume.DirMap{p: children}
+	for _, f := range results.File {
+		fileInfo := f.GetFileInfo()
+		name := path.Base(fileInfo.Path)
+		attr := plugin.EntryAttributes{}
+		attr.SetSize(uint64(fileInfo.FileSize))
+		if fileInfo.Modification != nil {
+			attr.SetMtime(*fileInfo.Modification)
+		}
+		if _, isDir := f.(*types.FolderFileInfo); isDir {
+			attr.SetMode(os.ModeDir | 0755)
+			dirmap[p+"/"+name] = nil
+		} else {
+			attr.SetMode(0644)
+		}
+		children[name] = attr
+	}
+	activity.Record(ctx, "Listed %v entries in %v", len(children), d.dsPath(p))
+	return dirmap, nil
+}
+
+// VolumeRead reads the file at p
+func (d *datastore) VolumeRead(ctx context.Context, p string) ([]byte, error) {
+	rdr, _, err := d.ds.Download(ctx, strings.TrimPrefix(p, "/"), &soap.DefaultDownload)
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+	return ioutil.ReadAll(rdr)
+}
+
+// VolumeStream is not supported by datastores
+func (d *datastore) VolumeStream(ctx context.Context, p string) (io.ReadCloser, error) {
+	return nil, fmt.Errorf("streaming files is not supported by vSphere datastores")
+}
+
+// VolumeWrite replaces the file at p with b
+func (d *datastore) VolumeWrite(ctx context.Context, p string, b []byte, mode os.FileMode) error {
+	activity.Record(ctx, "Writing %v bytes to %v", len(b), d.dsPath(p))
+	param := soap.DefaultUpload
+	param.ContentLength = int64(len(b))
+	return d.ds.Upload(ctx, bytes.NewReader(b), strings.TrimPrefix(p, "/"), &param)
+}
+
+// VolumeDelete deletes the file or directory at p
+func (d *datastore) VolumeDelete(ctx context.Context, p string) (bool, error) {
+	activity.Record(ctx, "Deleting %v", d.dsPath(p))
+	task, err := object.NewFileManager(d.ds.Client()).DeleteDatastoreFile(ctx, d.dsPath(p), d.dc.dc)
+	if err != nil {
+		return false, err
+	}
+	if err := task.Wait(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// VolumeCopy copies the file at src to dst
+func (d *datastore) VolumeCopy(ctx context.Context, src string, dst string) error {
+	activity.Record(ctx, "Copying %v to %v", d.dsPath(src), d.dsPath(dst))
+	task, err := object.NewFileManager(d.ds.Client()).CopyDatastoreFile(ctx, d.dsPath(src), d.dc.dc, d.dsPath(dst), d.dc.dc, true)
+	if err != nil {
+		return err
+	}
+	return task.Wait(ctx)
+}
+
+const datastoreDescription = `
+This is a vSphere datastore. Its metadata is the datastore's summary, which
+includes its capacity and free space. Its directories and files mirror the
+datastore's contents. Files can be read, written and deleted. Deleting a
+directory recursively deletes its contents.
+`
@@ -0,0 +1,74 @@
+// Package vsphere presents a filesystem hierarchy for VMware vSphere.
+//
+// It browses the inventory of each vCenter (or ESXi host) configured in
+// wash.yaml using govmomi.
+package vsphere
+
+import (
+	"context"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Root of the vsphere plugin
+type Root struct {
+	plugin.EntryBase
+	servers []plugin.Entry
+}
+
+// Init for root
+func (r *Root) Init(cfg map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("vsphere")
+	r.DisableDefaultCaching()
+
+	r.servers = []plugin.Entry{}
+	return plugin.ParseNamedConfigs("vsphere", cfg, "servers", func(name string, config interface{}) error {
+		serverCfg, err := parseServerConfig(config)
+		if err != nil {
+			return err
+		}
+		r.servers = append(r.servers, newServer(name, serverCfg))
+		return nil
+	})
+}
+
+// Schema returns the root's schema
+func (r *Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "vsphere").
+		SetDescription(rootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&server{}).Schema(),
+	}
+}
+
+// List lists the configured servers.
+func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	return r.servers, nil
+}
+
+const rootDescription = `
+This is the vsphere plugin root. It lists the vCenter servers (or ESXi hosts)
+configured in wash.yaml, e.g.
+
+  vsphere:
+    servers:
+      lab:
+        url: https://administrator@vsphere.local:password@vcenter.example.com/sdk
+        insecure: true
+        guest_user: root
+        guest_password: <password>
+
+Set 'insecure' to skip verifying the server's certificate. The guest
+credentials are used to exec commands in VMs via VMware Tools.
+
+Each server lists its datacenters. A datacenter includes its clusters, whose
+hosts list the VMs that they're running, and its datastores, whose files can
+be browsed, read, written and deleted. VMs support power signals like
+'start', 'stop' and 'restart', and include a screenshot of their console.
+`
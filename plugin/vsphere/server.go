@@ -0,0 +1,149 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+type serverConfig struct {
+	url           *url.URL
+	insecure      bool
+	guestUser     string
+	guestPassword string
+}
+
+func parseServerConfig(cfgI interface{}) (serverConfig, error) {
+	var cfg serverConfig
+	m, ok := cfgI.(map[string]interface{})
+	if !ok {
+		return cfg, fmt.Errorf("expected a map, not %v", cfgI)
+	}
+	var rawURL string
+	strOpts := map[string]*string{
+		"url":            &rawURL,
+		"guest_user":     &cfg.guestUser,
+		"guest_password": &cfg.guestPassword,
+	}
+	for key, ptr := range strOpts {
+		valueI, ok := m[key]
+		if !ok {
+			continue
+		}
+		if *ptr, ok = valueI.(string); !ok {
+			return cfg, fmt.Errorf("%v must be a string, not %v", key, valueI)
+		}
+	}
+	if insecureI, ok := m["insecure"]; ok {
+		if cfg.insecure, ok = insecureI.(bool); !ok {
+			return cfg, fmt.Errorf("insecure must be a boolean, not %v", insecureI)
+		}
+	}
+	if rawURL == "" {
+		return cfg, fmt.Errorf("url must be set")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return cfg, err
+	}
+	if u.Path == "" {
+		u.Path = "/sdk"
+	}
+	cfg.url = u
+	return cfg, nil
+}
+
+type server struct {
+	plugin.EntryBase
+	cfg    serverConfig
+	mux    sync.Mutex
+	client *govmomi.Client
+}
+
+func newServer(name string, cfg serverConfig) *server {
+	s := &server{
+		EntryBase: plugin.NewEntry(name),
+	}
+	s.cfg = cfg
+	return s
+}
+
+func (s *server) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "server").
+		SetDescription(serverDescription)
+}
+
+func (s *server) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&datacenter{}).Schema(),
+	}
+}
+
+// connect returns the server's client, logging in if there's no active
+// session.
+func (s *server) connect(ctx context.Context) (*govmomi.Client, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.client != nil {
+		if active, err := s.client.SessionManager.SessionIsActive(ctx); err == nil && active {
+			return s.client, nil
+		}
+	}
+	activity.Record(ctx, "Logging in to %v", s.cfg.url.Host)
+	client, err := govmomi.NewClient(ctx, s.cfg.url, s.cfg.insecure)
+	if err != nil {
+		return nil, err
+	}
+	s.client = client
+	return client, nil
+}
+
+// List lists the server's datacenters
+func (s *server) List(ctx context.Context) ([]plugin.Entry, error) {
+	client, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dcs, err := find.NewFinder(client.Client, true).DatacenterList(ctx, "*")
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(dcs))
+	for i, dc := range dcs {
+		entries[i] = newDatacenter(s, dc)
+	}
+	return entries, nil
+}
+
+// Metadata returns information about the server's API
+func (s *server) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	client, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return plugin.ToJSONObject(client.ServiceContent.About), nil
+}
+
+// guestAuth returns the credentials used for guest operations
+func (s *server) guestAuth() (types.BaseGuestAuthentication, error) {
+	if s.cfg.guestUser == "" {
+		return nil, fmt.Errorf("guest operations require the server's guest_user and guest_password config")
+	}
+	return &types.NamePasswordAuthentication{
+		Username: s.cfg.guestUser,
+		Password: s.cfg.guestPassword,
+	}, nil
+}
+
+const serverDescription = `
+This is a vCenter server or ESXi host. Its metadata includes the server's
+product and API version. It lists the server's datacenters.
+`
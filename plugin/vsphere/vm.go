@@ -0,0 +1,317 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kballard/go-shellquote"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/vmware/govmomi/guest"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// vmInfo is the VM's partial metadata
+type vmInfo struct {
+	Name          string     `json:"name"`
+	UUID          string     `json:"uuid"`
+	PowerState    string     `json:"power_state"`
+	GuestFullName string     `json:"guest_full_name"`
+	GuestFamily   string     `json:"guest_family"`
+	Hostname      string     `json:"hostname"`
+	IPAddress     string     `json:"ip_address"`
+	ToolsStatus   string     `json:"tools_status"`
+	NumCPU        int32      `json:"num_cpu"`
+	MemoryMB      int32      `json:"memory_mb"`
+	BootTime      *time.Time `json:"boot_time"`
+}
+
+type vm struct {
+	plugin.EntryBase
+	server *server
+	vm     *object.VirtualMachine
+	info   vmInfo
+}
+
+func newVM(s *server, v *object.VirtualMachine, m mo.VirtualMachine) *vm {
+	info := vmInfo{
+		Name:       m.Name,
+		PowerState: string(m.Runtime.PowerState),
+		BootTime:   m.Runtime.BootTime,
+	}
+	if config := m.Summary.Config; config.Name != "" {
+		info.UUID = config.Uuid
+		info.GuestFullName = config.GuestFullName
+		info.NumCPU = config.NumCpu
+		info.MemoryMB = config.MemorySizeMB
+	}
+	if g := m.Guest; g != nil {
+		info.GuestFamily = g.GuestFamily
+		info.Hostname = g.HostName
+		info.IPAddress = g.IpAddress
+		info.ToolsStatus = string(g.ToolsRunningStatus)
+	}
+
+	vmEntry := &vm{
+		EntryBase: plugin.NewEntry(m.Name),
+	}
+	vmEntry.server = s
+	vmEntry.vm = v
+	vmEntry.info = info
+	vmEntry.SetPartialMetadata(info)
+	if info.BootTime != nil {
+		vmEntry.
+			Attributes().
+			SetCrtime(*info.BootTime).
+			SetMtime(*info.BootTime).
+			SetCtime(*info.BootTime).
+			SetAtime(*info.BootTime)
+	}
+	return vmEntry
+}
+
+func (v *vm) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(v, "vm").
+		SetDescription(vmDescription).
+		SetPartialMetadataSchema(vmInfo{}).
+		AddSignal("start", "Powers on the VM").
+		AddSignal("stop", "Shuts down the VM's guest OS. Requires VMware Tools").
+		AddSignal("restart", "Reboots the VM's guest OS. Requires VMware Tools").
+		AddSignal("power_off", "Powers off the VM").
+		AddSignal("reset", "Resets the VM. This is like pressing the reset button").
+		AddSignal("suspend", "Suspends the VM")
+}
+
+func (v *vm) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&screenshot{}).Schema(),
+		(&plugin.MetadataJSONFile{}).Schema(),
+	}
+}
+
+// Metadata returns the VM's summary
+func (v *vm) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	var m mo.VirtualMachine
+	if err := v.vm.Properties(ctx, v.vm.Reference(), []string{"summary"}, &m); err != nil {
+		return nil, err
+	}
+	return plugin.ToJSONObject(m.Summary), nil
+}
+
+func (v *vm) List(ctx context.Context) ([]plugin.Entry, error) {
+	meta, err := plugin.NewMetadataJSONFile(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	return []plugin.Entry{newScreenshot(v), meta}, nil
+}
+
+func (v *vm) Signal(ctx context.Context, signal string) error {
+	var task *object.Task
+	var err error
+	switch signal {
+	case "start":
+		task, err = v.vm.PowerOn(ctx)
+	case "stop":
+		err = v.vm.ShutdownGuest(ctx)
+	case "restart":
+		err = v.vm.RebootGuest(ctx)
+	case "power_off":
+		task, err = v.vm.PowerOff(ctx)
+	case "reset":
+		task, err = v.vm.Reset(ctx)
+	case "suspend":
+		task, err = v.vm.Suspend(ctx)
+	default:
+		return fmt.Errorf("unsupported signal %v", signal)
+	}
+	if err == nil && task != nil {
+		err = task.Wait(ctx)
+	}
+	if err == nil {
+		activity.Record(ctx, "Sent %v to VM %v", signal, v.Name())
+	}
+	return err
+}
+
+// execPollInterval is how often Exec checks whether the guest process exited
+const execPollInterval = 500 * time.Millisecond
+
+// Exec runs the command in the guest via VMware Tools. Guest operations
+// can't stream output, so the command's stdout and stderr are redirected to
+// temporary files in the guest that are downloaded once it exits.
+func (v *vm) Exec(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	if opts.Stdin != nil || opts.Tty {
+		return nil, fmt.Errorf("exec'ing vSphere VMs does not support stdin or a TTY")
+	}
+	auth, err := v.server.guestAuth()
+	if err != nil {
+		return nil, err
+	}
+	client, err := v.server.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opsmgr := guest.NewOperationsManager(client.Client, v.vm.Reference())
+	pm, err := opsmgr.ProcessManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fm, err := opsmgr.FileManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	windows := v.info.GuestFamily == string(types.VirtualMachineGuestOsFamilyWindowsGuest)
+	outputs := make([]string, 2)
+	for i, suffix := range []string{".out", ".err"} {
+		if outputs[i], err = fm.CreateTemporaryFile(ctx, auth, "wash-", suffix, ""); err != nil {
+			return nil, err
+		}
+	}
+	spec := &types.GuestProgramSpec{}
+	if windows {
+		spec.ProgramPath = `C:\Windows\System32\cmd.exe`
+		spec.Arguments = fmt.Sprintf(`/c "%v %v > %v 2> %v"`, cmd, strings.Join(args, " "), outputs[0], outputs[1])
+	} else {
+		spec.ProgramPath = "/bin/sh"
+		script := fmt.Sprintf("%v > %v 2> %v", shellquote.Join(append([]string{cmd}, args...)...), outputs[0], outputs[1])
+		spec.Arguments = "-c " + shellquote.Join(script)
+	}
+	pid, err := pm.StartProgram(ctx, auth, spec)
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Started %v %v as process %v on %v", cmd, args, pid, v.Name())
+
+	execCmd := plugin.NewExecCommand(ctx)
+	execCmd.SetStopFunc(func() {
+		if err := pm.TerminateProcess(context.Background(), auth, pid); err != nil {
+			activity.Record(ctx, "Failed to terminate process %v on %v: %v", pid, v.Name(), err)
+		}
+	})
+	go func() {
+		exitCode, err := waitForProcess(ctx, pm, auth, pid)
+		if err == nil {
+			for i, w := range []io.Writer{execCmd.Stdout(), execCmd.Stderr()} {
+				if err = downloadGuestFile(ctx, client.Client, fm, auth, outputs[i], w); err != nil {
+					break
+				}
+			}
+		}
+		for _, output := range outputs {
+			if delErr := fm.DeleteFile(context.Background(), auth, output); delErr != nil {
+				activity.Record(ctx, "Failed to delete %v on %v: %v", output, v.Name(), delErr)
+			}
+		}
+		if err != nil {
+			execCmd.SetExitCodeErr(err)
+		} else {
+			execCmd.SetExitCode(exitCode)
+		}
+		execCmd.CloseStreamsWithError(err)
+	}()
+	return execCmd, nil
+}
+
+func waitForProcess(ctx context.Context, pm *guest.ProcessManager, auth types.BaseGuestAuthentication, pid int64) (int, error) {
+	ticker := time.NewTicker(execPollInterval)
+	defer ticker.Stop()
+	for {
+		procs, err := pm.ListProcesses(ctx, auth, []int64{pid})
+		if err != nil {
+			return 0, err
+		}
+		if len(procs) == 0 {
+			return 0, fmt.Errorf("process %v not found", pid)
+		}
+		if procs[0].EndTime != nil {
+			return int(procs[0].ExitCode), nil
+		}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func downloadGuestFile(ctx context.Context, client downloader, fm *guest.FileManager, auth types.BaseGuestAuthentication, path string, w io.Writer) error {
+	info, err := fm.InitiateFileTransferFromGuest(ctx, auth, path)
+	if err != nil {
+		return err
+	}
+	u, err := fm.TransferURL(ctx, info.Url)
+	if err != nil {
+		return err
+	}
+	rdr, _, err := client.Download(ctx, u, &soap.DefaultDownload)
+	if err != nil {
+		return err
+	}
+	defer rdr.Close()
+	_, err = io.Copy(w, rdr)
+	return err
+}
+
+// downloader is implemented by *vim25.Client
+type downloader interface {
+	Download(ctx context.Context, u *url.URL, param *soap.Download) (io.ReadCloser, int64, error)
+}
+
+// screenshot is a PNG screenshot of the VM's console
+type screenshot struct {
+	plugin.EntryBase
+	vm *vm
+}
+
+func newScreenshot(v *vm) *screenshot {
+	s := &screenshot{
+		EntryBase: plugin.NewEntry("screenshot.png"),
+	}
+	s.vm = v
+	s.DisableCachingFor(plugin.ReadOp)
+	return s
+}
+
+func (s *screenshot) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "screenshot.png").
+		SetDescription(screenshotDescription).
+		IsSingleton()
+}
+
+func (s *screenshot) Read(ctx context.Context) ([]byte, error) {
+	client, err := s.vm.server.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u := client.Client.URL()
+	u.Path = "/screen"
+	u.RawQuery = "id=" + s.vm.vm.Reference().Value
+	rdr, _, err := client.Client.Download(ctx, u, &soap.DefaultDownload)
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+	return ioutil.ReadAll(rdr)
+}
+
+const vmDescription = `
+This is a vSphere VM. Exec'ing it runs the command in the guest via VMware
+Tools using the server's guest credentials. Output is returned once the
+command exits, and stdin isn't supported.
+`
+
+const screenshotDescription = `
+This is a PNG screenshot of the VM's console, taken when it's read.
+`
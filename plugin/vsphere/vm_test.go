@@ -0,0 +1,74 @@
+package vsphere
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestNewVM(t *testing.T) {
+	bootTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var m mo.VirtualMachine
+	m.Name = "web01"
+	m.Runtime.PowerState = types.VirtualMachinePowerStatePoweredOn
+	m.Runtime.BootTime = &bootTime
+	m.Summary.Config = types.VirtualMachineConfigSummary{
+		Name:          "web01",
+		Uuid:          "4203",
+		GuestFullName: "Ubuntu Linux (64-bit)",
+		NumCpu:        2,
+		MemorySizeMB:  4096,
+	}
+	m.Guest = &types.GuestInfo{
+		GuestFamily:        "linuxGuest",
+		HostName:           "web01.example.com",
+		IpAddress:          "10.0.0.5",
+		ToolsRunningStatus: "guestToolsRunning",
+	}
+
+	v := newVM(nil, nil, m)
+	assert.Equal(t, "web01", plugin.Name(v))
+	assert.Equal(t, vmInfo{
+		Name:          "web01",
+		UUID:          "4203",
+		PowerState:    "poweredOn",
+		GuestFullName: "Ubuntu Linux (64-bit)",
+		GuestFamily:   "linuxGuest",
+		Hostname:      "web01.example.com",
+		IPAddress:     "10.0.0.5",
+		ToolsStatus:   "guestToolsRunning",
+		NumCPU:        2,
+		MemoryMB:      4096,
+		BootTime:      &bootTime,
+	}, v.info)
+	assert.Equal(t, bootTime, v.Attributes().Mtime())
+
+	var off mo.VirtualMachine
+	off.Name = "db01"
+	off.Runtime.PowerState = types.VirtualMachinePowerStatePoweredOff
+	v = newVM(nil, nil, off)
+	assert.Equal(t, vmInfo{Name: "db01", PowerState: "poweredOff"}, v.info)
+	assert.False(t, v.Attributes().HasMtime())
+}
+
+func TestVMSignal_Unsupported(t *testing.T) {
+	var m mo.VirtualMachine
+	m.Name = "web01"
+	v := newVM(nil, nil, m)
+	assert.EqualError(t, v.Signal(context.Background(), "hibernate"), "unsupported signal hibernate")
+}
+
+func TestDatastorePath(t *testing.T) {
+	ds := object.NewDatastore(nil, types.ManagedObjectReference{Type: "Datastore", Value: "datastore-1"})
+	ds.InventoryPath = "/dc1/datastore/ds1"
+	d := newDatastore(nil, ds)
+	assert.Equal(t, "ds1", plugin.Name(d))
+	assert.Equal(t, "[ds1] vms/web01/web01.vmx", d.dsPath("/vms/web01/web01.vmx"))
+	assert.Equal(t, "[ds1]", d.dsPath("/"))
+}
@@ -0,0 +1,101 @@
+package zookeeper
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// sessionTimeout is the timeout of the ensemble's ZooKeeper session
+const sessionTimeout = 10 * time.Second
+
+type ensemble struct {
+	plugin.EntryBase
+	servers []string
+	chroot  string
+	mux     sync.Mutex
+	conn    *zk.Conn
+}
+
+func newEnsemble(name string, connStr string) (*ensemble, error) {
+	e := &ensemble{
+		EntryBase: plugin.NewEntry(name),
+	}
+	if ix := strings.Index(connStr, "/"); ix >= 0 {
+		e.chroot = path.Clean(connStr[ix:])
+		if e.chroot == "/" {
+			e.chroot = ""
+		}
+		connStr = connStr[:ix]
+	}
+	for _, server := range strings.Split(connStr, ",") {
+		if server = strings.TrimSpace(server); server != "" {
+			e.servers = append(e.servers, server)
+		}
+	}
+	if len(e.servers) == 0 {
+		return nil, fmt.Errorf("no servers found in %v", connStr)
+	}
+	return e, nil
+}
+
+func (e *ensemble) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(e, "ensemble").
+		SetDescription(ensembleDescription)
+}
+
+func (e *ensemble) ChildSchemas() []*plugin.EntrySchema {
+	return znodeChildSchemas()
+}
+
+// connect returns the ensemble's connection, creating it if needed. The
+// connection reconnects on its own, so it's shared by all of the ensemble's
+// znodes.
+func (e *ensemble) connect(ctx context.Context) (*zk.Conn, error) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	if e.conn != nil {
+		return e.conn, nil
+	}
+	activity.Record(ctx, "Connecting to ZooKeeper ensemble %v", e.servers)
+	conn, _, err := zk.Connect(e.servers, sessionTimeout, zk.WithLogger(discardLogger{}))
+	if err != nil {
+		return nil, err
+	}
+	e.conn = conn
+	return conn, nil
+}
+
+// zkPath converts a path relative to the chroot to an absolute path
+func (e *ensemble) zkPath(p string) string {
+	if e.chroot == "" {
+		return p
+	}
+	if p == "/" {
+		return e.chroot
+	}
+	return e.chroot + p
+}
+
+func (e *ensemble) List(ctx context.Context) ([]plugin.Entry, error) {
+	return listZnodes(ctx, e, "/")
+}
+
+// discardLogger discards the zk package's logs, which would otherwise be
+// written to the daemon's stderr.
+type discardLogger struct{}
+
+func (discardLogger) Printf(string, ...interface{}) {}
+
+const ensembleDescription = `
+This is a ZooKeeper ensemble. It lists the znodes under the ensemble's root
+(or chroot).
+`
@@ -0,0 +1,74 @@
+package zookeeper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInit(t *testing.T) {
+	r := &Root{}
+	assert.NoError(t, r.Init(map[string]interface{}{}))
+	assert.Empty(t, r.ensembles)
+
+	err := r.Init(map[string]interface{}{
+		"ensembles": map[string]interface{}{
+			"local": "localhost:2181",
+			"kafka": "zk1:2181,zk2:2181,zk3:2181/kafka",
+		},
+	})
+	if assert.NoError(t, err) {
+		entries, err := r.List(context.Background())
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, "kafka", plugin.Name(entries[0]))
+			assert.Equal(t, "local", plugin.Name(entries[1]))
+		}
+	}
+
+	err = r.Init(map[string]interface{}{
+		"ensembles": map[string]interface{}{"local": 2181},
+	})
+	assert.EqualError(t, err, "zookeeper.ensembles.local config is invalid: expected a connection string, not 2181")
+
+	err = r.Init(map[string]interface{}{
+		"ensembles": map[string]interface{}{"local": " , "},
+	})
+	assert.EqualError(t, err, "zookeeper.ensembles.local config is invalid: no servers found in  , ")
+}
+
+func TestNewEnsemble(t *testing.T) {
+	cases := []struct {
+		connStr string
+		servers []string
+		chroot  string
+	}{
+		{"localhost:2181", []string{"localhost:2181"}, ""},
+		{"zk1:2181, zk2:2181,zk3:2181", []string{"zk1:2181", "zk2:2181", "zk3:2181"}, ""},
+		{"zk1:2181,zk2:2181/kafka", []string{"zk1:2181", "zk2:2181"}, "/kafka"},
+		{"zk1:2181/kafka/", []string{"zk1:2181"}, "/kafka"},
+		{"zk1:2181/", []string{"zk1:2181"}, ""},
+	}
+	for _, c := range cases {
+		e, err := newEnsemble("e", c.connStr)
+		if assert.NoError(t, err, c.connStr) {
+			assert.Equal(t, c.servers, e.servers, c.connStr)
+			assert.Equal(t, c.chroot, e.chroot, c.connStr)
+		}
+	}
+
+	_, err := newEnsemble("e", "/kafka")
+	assert.EqualError(t, err, "no servers found in ")
+}
+
+func TestZkPath(t *testing.T) {
+	e := &ensemble{}
+	assert.Equal(t, "/", e.zkPath("/"))
+	assert.Equal(t, "/brokers/ids", e.zkPath("/brokers/ids"))
+
+	e.chroot = "/kafka"
+	assert.Equal(t, "/kafka", e.zkPath("/"))
+	assert.Equal(t, "/kafka/brokers/ids", e.zkPath("/brokers/ids"))
+}
@@ -0,0 +1,78 @@
+// Package zookeeper presents a filesystem hierarchy for ZooKeeper ensembles.
+//
+// It maps each configured ensemble's znode tree to entries whose metadata
+// is the znode's stat structure.
+package zookeeper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Root of the zookeeper plugin
+type Root struct {
+	plugin.EntryBase
+	ensembles []plugin.Entry
+}
+
+// Init for root
+func (r *Root) Init(cfg map[string]interface{}) error {
+	r.EntryBase = plugin.NewEntry("zookeeper")
+	r.DisableDefaultCaching()
+
+	r.ensembles = []plugin.Entry{}
+	return plugin.ParseNamedConfigs("zookeeper", cfg, "ensembles", func(name string, config interface{}) error {
+		connStr, ok := config.(string)
+		if !ok {
+			return fmt.Errorf("expected a connection string, not %v", config)
+		}
+		e, err := newEnsemble(name, connStr)
+		if err != nil {
+			return err
+		}
+		r.ensembles = append(r.ensembles, e)
+		return nil
+	})
+}
+
+// Schema returns the root's schema
+func (r *Root) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(r, "zookeeper").
+		SetDescription(rootDescription).
+		IsSingleton()
+}
+
+// ChildSchemas returns the root's child schema
+func (r *Root) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&ensemble{}).Schema(),
+	}
+}
+
+// List lists the configured ensembles.
+func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
+	return r.ensembles, nil
+}
+
+const rootDescription = `
+This is the zookeeper plugin root. It lists the ZooKeeper ensembles
+configured in wash.yaml. Ensembles are configured with the same connection
+strings that ZooKeeper's clients use, including an optional chroot, e.g.
+
+  zookeeper:
+    ensembles:
+      local: localhost:2181
+      kafka: zk1:2181,zk2:2181,zk3:2181/kafka
+
+Each ensemble presents its znode tree. Znodes with children are
+directories, while the rest are files. A znode's metadata is its stat
+structure, so you can do things like
+
+  find zookeeper/kafka -meta .ephemeral_owner +0
+
+to find ephemeral znodes. Znodes can be read, written and deleted, and
+tailing a znode streams its data whenever it changes.
+`
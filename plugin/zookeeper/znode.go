@@ -0,0 +1,264 @@
+package zookeeper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// znodeStat is a znode's stat structure
+type znodeStat struct {
+	Czxid          int64     `json:"czxid"`
+	Mzxid          int64     `json:"mzxid"`
+	Pzxid          int64     `json:"pzxid"`
+	Ctime          time.Time `json:"ctime"`
+	Mtime          time.Time `json:"mtime"`
+	Version        int32     `json:"version"`
+	Cversion       int32     `json:"cversion"`
+	Aversion       int32     `json:"aversion"`
+	EphemeralOwner int64     `json:"ephemeral_owner"`
+	DataLength     int32     `json:"data_length"`
+	NumChildren    int32     `json:"num_children"`
+}
+
+func newZnodeStat(stat *zk.Stat) znodeStat {
+	return znodeStat{
+		Czxid:          stat.Czxid,
+		Mzxid:          stat.Mzxid,
+		Pzxid:          stat.Pzxid,
+		Ctime:          time.Unix(0, stat.Ctime*int64(time.Millisecond)),
+		Mtime:          time.Unix(0, stat.Mtime*int64(time.Millisecond)),
+		Version:        stat.Version,
+		Cversion:       stat.Cversion,
+		Aversion:       stat.Aversion,
+		EphemeralOwner: stat.EphemeralOwner,
+		DataLength:     stat.DataLength,
+		NumChildren:    stat.NumChildren,
+	}
+}
+
+// listZnodes lists the children of the znode at p. Children with children of
+// their own are directories.
+func listZnodes(ctx context.Context, e *ensemble, p string) ([]plugin.Entry, error) {
+	conn, err := e.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names, _, err := conn.Children(e.zkPath(p))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	entries := make([]plugin.Entry, 0, len(names))
+	for _, name := range names {
+		childPath := path.Join(p, name)
+		exists, stat, err := conn.Exists(e.zkPath(childPath))
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			// The znode was deleted while we were listing the others.
+			continue
+		}
+		base := znodeBase{ensemble: e, path: childPath}
+		if stat.NumChildren > 0 {
+			entries = append(entries, newZnodeDir(name, base, stat))
+		} else {
+			entries = append(entries, newZnode(name, base, stat))
+		}
+	}
+	activity.Record(ctx, "Listed %v znodes in %v", len(entries), e.zkPath(p))
+	return entries, nil
+}
+
+// znodeBase implements the actions that all znodes support
+type znodeBase struct {
+	ensemble *ensemble
+	path     string
+}
+
+func setZnodeAttributes(entry *plugin.EntryBase, stat *zk.Stat) {
+	s := newZnodeStat(stat)
+	entry.
+		SetPartialMetadata(s).
+		Attributes().
+		SetCrtime(s.Ctime).
+		SetMtime(s.Mtime).
+		SetCtime(s.Mtime).
+		SetAtime(s.Mtime).
+		SetSize(uint64(s.DataLength))
+	entry.DisableCachingFor(plugin.ReadOp)
+}
+
+func (z *znodeBase) Read(ctx context.Context) ([]byte, error) {
+	conn, err := z.ensemble.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, _, err := conn.Get(z.ensemble.zkPath(z.path))
+	return data, err
+}
+
+// Write replaces the znode's data, regardless of its version
+func (z *znodeBase) Write(ctx context.Context, b []byte) error {
+	conn, err := z.ensemble.connect(ctx)
+	if err != nil {
+		return err
+	}
+	activity.Record(ctx, "Writing %v bytes to %v", len(b), z.ensemble.zkPath(z.path))
+	_, err = conn.Set(z.ensemble.zkPath(z.path), b, -1)
+	return err
+}
+
+// Delete recursively deletes the znode
+func (z *znodeBase) Delete(ctx context.Context) (bool, error) {
+	conn, err := z.ensemble.connect(ctx)
+	if err != nil {
+		return false, err
+	}
+	if err := deleteAll(ctx, conn, z.ensemble.zkPath(z.path)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func deleteAll(ctx context.Context, conn *zk.Conn, p string) error {
+	children, _, err := conn.Children(p)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if err := deleteAll(ctx, conn, path.Join(p, child)); err != nil {
+			return err
+		}
+	}
+	activity.Record(ctx, "Deleting %v", p)
+	if err := conn.Delete(p, -1); err != nil && err != zk.ErrNoNode {
+		return err
+	}
+	return nil
+}
+
+// Stream writes the znode's data whenever it changes, each time followed by
+// a newline. It uses ZooKeeper watches, so changes aren't polled for.
+func (z *znodeBase) Stream(ctx context.Context) (io.ReadCloser, error) {
+	conn, err := z.ensemble.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p := z.ensemble.zkPath(z.path)
+	_, _, events, err := conn.GetW(p)
+	if err != nil {
+		return nil, err
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		defer func() {
+			activity.Record(ctx, "Closing write pipe: %v", w.Close())
+		}()
+		for {
+			var event zk.Event
+			select {
+			case <-ctx.Done():
+				return
+			case event = <-events:
+			}
+			if event.Type == zk.EventNodeDeleted {
+				_ = w.CloseWithError(fmt.Errorf("%v was deleted", p))
+				return
+			}
+			// Watches only fire once, so re-register the watch while getting
+			// the new data.
+			var data []byte
+			data, _, events, err = conn.GetW(p)
+			if err != nil {
+				_ = w.CloseWithError(err)
+				return
+			}
+			if event.Type != zk.EventNodeDataChanged {
+				continue
+			}
+			if _, err := w.Write(append(data, '\n')); err != nil {
+				// The reader was closed.
+				return
+			}
+		}
+	}()
+	return r, nil
+}
+
+// znode is a znode without children
+type znode struct {
+	plugin.EntryBase
+	znodeBase
+}
+
+func newZnode(name string, base znodeBase, stat *zk.Stat) *znode {
+	z := &znode{
+		EntryBase: plugin.NewEntry(name),
+	}
+	z.znodeBase = base
+	setZnodeAttributes(&z.EntryBase, stat)
+	return z
+}
+
+func (z *znode) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(z, "znode").
+		SetDescription(znodeDescription).
+		SetPartialMetadataSchema(znodeStat{})
+}
+
+// znodeDir is a znode with children. Its data can still be read and
+// written via the Wash API.
+type znodeDir struct {
+	plugin.EntryBase
+	znodeBase
+}
+
+func newZnodeDir(name string, base znodeBase, stat *zk.Stat) *znodeDir {
+	z := &znodeDir{
+		EntryBase: plugin.NewEntry(name),
+	}
+	z.znodeBase = base
+	setZnodeAttributes(&z.EntryBase, stat)
+	return z
+}
+
+func (z *znodeDir) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(z, "dir").
+		SetDescription(znodeDescription).
+		SetPartialMetadataSchema(znodeStat{})
+}
+
+func (z *znodeDir) ChildSchemas() []*plugin.EntrySchema {
+	return znodeChildSchemas()
+}
+
+func (z *znodeDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	return listZnodes(ctx, z.ensemble, z.path)
+}
+
+func znodeChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&znodeDir{}).Schema(),
+		(&znode{}).Schema(),
+	}
+}
+
+const znodeDescription = `
+This is a znode. Its metadata is the znode's stat structure. Reading it
+returns its data, writing to it replaces its data (regardless of version),
+and tailing it streams its data whenever it changes. Deleting a znode
+recursively deletes its children.
+`
@@ -0,0 +1,46 @@
+package zookeeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewZnodeStat(t *testing.T) {
+	stat := &zk.Stat{
+		Czxid:          1,
+		Mzxid:          2,
+		Pzxid:          3,
+		Ctime:          1577836800000,
+		Mtime:          1577836801500,
+		Version:        4,
+		EphemeralOwner: 72057594037927936,
+		DataLength:     12,
+		NumChildren:    0,
+	}
+	s := newZnodeStat(stat)
+	assert.Equal(t, time.Unix(1577836800, 0), s.Ctime)
+	assert.Equal(t, time.Unix(1577836801, int64(500*time.Millisecond)), s.Mtime)
+	assert.Equal(t, int64(2), s.Mzxid)
+	assert.Equal(t, int32(4), s.Version)
+	assert.Equal(t, int64(72057594037927936), s.EphemeralOwner)
+	assert.Equal(t, int32(12), s.DataLength)
+}
+
+func TestNewZnode(t *testing.T) {
+	e := &ensemble{chroot: "/kafka"}
+	stat := &zk.Stat{Ctime: 1577836800000, Mtime: 1577836860000, DataLength: 12}
+	z := newZnode("1", znodeBase{ensemble: e, path: "/brokers/ids/1"}, stat)
+	assert.Equal(t, "1", plugin.Name(z))
+	assert.Equal(t, "/brokers/ids/1", z.path)
+	assert.Equal(t, time.Unix(1577836800, 0), z.Attributes().Crtime())
+	assert.Equal(t, time.Unix(1577836860, 0), z.Attributes().Mtime())
+	assert.Equal(t, uint64(12), z.Attributes().Size())
+
+	dir := newZnodeDir("ids", znodeBase{ensemble: e, path: "/brokers/ids"}, &zk.Stat{NumChildren: 3})
+	assert.Equal(t, "ids", plugin.Name(dir))
+	assert.Equal(t, float64(3), plugin.PartialMetadata(dir)["num_children"])
+}
@@ -0,0 +1,170 @@
+// Package secrets resolves references to secrets in the plugins' configs, so
+// that credentials can be kept in the OS keychain or Vault instead of in
+// wash.yaml. A reference is a string value of the form
+//
+//     keyring:<service>         The password of the OS keychain's item for service. It's looked
+//                               up with `security` on macOS and `secret-tool` (the Secret
+//                               Service) on Linux.
+//     vault:<path>              The Vault secret at path, e.g. secret/data/wash/aws, as a map.
+//     vault:<path>#<field>      The secret's field.
+//
+// Vault is accessed with the VAULT_ADDR and VAULT_TOKEN environment variables,
+// like the vault CLI. The token defaults to the contents of ~/.vault-token.
+package secrets
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Prefixes of a secret reference
+const (
+	KeyringPrefix = "keyring:"
+	VaultPrefix   = "vault:"
+)
+
+// Resolve returns a copy of config with its secret references, including the
+// references in nested maps and lists, replaced by their secrets.
+func Resolve(config map[string]interface{}) (map[string]interface{}, error) {
+	if config == nil {
+		return nil, nil
+	}
+	r := &resolver{vaultSecrets: make(map[string]map[string]interface{})}
+	resolved, err := r.resolve(config)
+	if err != nil {
+		return nil, err
+	}
+	return resolved.(map[string]interface{}), nil
+}
+
+type resolver struct {
+	vault *vaultClient
+	// vaultSecrets caches the secrets so that each is only read once.
+	vaultSecrets map[string]map[string]interface{}
+}
+
+func (r *resolver) resolve(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return r.resolveString(v)
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			resolvedElem, err := r.resolve(elem)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %v", key, err)
+			}
+			resolved[key] = resolvedElem
+		}
+		return resolved, nil
+	case map[interface{}]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			resolvedElem, err := r.resolve(elem)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %v", key, err)
+			}
+			resolved[fmt.Sprint(key)] = resolvedElem
+		}
+		return resolved, nil
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, elem := range v {
+			resolvedElem, err := r.resolve(elem)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = resolvedElem
+		}
+		return resolved, nil
+	default:
+		return v, nil
+	}
+}
+
+func (r *resolver) resolveString(str string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(str, KeyringPrefix):
+		service := strings.TrimPrefix(str, KeyringPrefix)
+		if service == "" {
+			return nil, fmt.Errorf("%v is missing the service's name", str)
+		}
+		secret, err := keyringLookup(service)
+		if err != nil {
+			return nil, fmt.Errorf("could not read %v from the keyring: %v", service, err)
+		}
+		return secret, nil
+	case strings.HasPrefix(str, VaultPrefix):
+		path := strings.TrimPrefix(str, VaultPrefix)
+		var field string
+		if i := strings.LastIndex(path, "#"); i >= 0 {
+			path, field = path[:i], path[i+1:]
+		}
+		path = strings.Trim(path, "/")
+		if path == "" {
+			return nil, fmt.Errorf("%v is missing the secret's path", str)
+		}
+		secret, err := r.vaultSecret(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read %v from Vault: %v", path, err)
+		}
+		if field == "" {
+			return secret, nil
+		}
+		value, ok := secret[field]
+		if !ok {
+			return nil, fmt.Errorf("the Vault secret %v does not have a %v field", path, field)
+		}
+		return value, nil
+	default:
+		return str, nil
+	}
+}
+
+func (r *resolver) vaultSecret(path string) (map[string]interface{}, error) {
+	if secret, ok := r.vaultSecrets[path]; ok {
+		return secret, nil
+	}
+	if r.vault == nil {
+		vault, err := newVaultClient()
+		if err != nil {
+			return nil, err
+		}
+		r.vault = vault
+	}
+	secret, err := r.vault.read(path)
+	if err != nil {
+		return nil, err
+	}
+	r.vaultSecrets[path] = secret
+	return secret, nil
+}
+
+// keyringLookup returns the password of the OS keychain's item for service.
+// It's a variable so that the tests can mock it.
+var keyringLookup = func(service string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", service, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", service)
+	default:
+		return "", fmt.Errorf("the keyring is not supported on %v", runtime.GOOS)
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%v: %v", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	secret := strings.TrimRight(string(output), "\n")
+	if secret == "" {
+		// secret-tool exits successfully when there's no matching item.
+		return "", fmt.Errorf("no secret was found for %v", service)
+	}
+	return secret, nil
+}
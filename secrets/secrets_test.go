@@ -0,0 +1,105 @@
+package secrets
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mockKeyring(t *testing.T, secrets map[string]string) func() {
+	oldKeyringLookup := keyringLookup
+	keyringLookup = func(service string) (string, error) {
+		if secret, ok := secrets[service]; ok {
+			return secret, nil
+		}
+		return "", fmt.Errorf("no secret was found for %v", service)
+	}
+	return func() { keyringLookup = oldKeyringLookup }
+}
+
+func mockVault(t *testing.T) func() {
+	reads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("X-Vault-Token"))
+		reads++
+		switch r.URL.Path {
+		case "/v1/secret/data/wash/aws":
+			// KV version 2
+			fmt.Fprint(w, `{"data": {"data": {"access_key": "AKIA", "secret_key": "shh"}, "metadata": {"version": 1}}}`)
+		case "/v1/kv/wash/github":
+			// KV version 1
+			fmt.Fprint(w, `{"data": {"token": "ghp"}}`)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"errors": ["permission denied"]}`)
+		}
+	}))
+	os.Setenv("VAULT_ADDR", server.URL)
+	os.Setenv("VAULT_TOKEN", "token")
+	return func() {
+		server.Close()
+		os.Unsetenv("VAULT_ADDR")
+		os.Unsetenv("VAULT_TOKEN")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	defer mockKeyring(t, map[string]string{"github-token": "ghp_keyring"})()
+	defer mockVault(t)()
+
+	config := map[string]interface{}{
+		"token":   "keyring:github-token",
+		"aws":     "vault:secret/data/wash/aws",
+		"key":     "vault:secret/data/wash/aws#access_key",
+		"secret":  "vault:secret/data/wash/aws#secret_key",
+		"github":  "vault:kv/wash/github#token",
+		"plain":   "us-west-1",
+		"timeout": 30,
+		"nested": map[interface{}]interface{}{
+			"tokens": []interface{}{"keyring:github-token", "literal"},
+		},
+	}
+	resolved, err := Resolve(config)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{
+			"token":   "ghp_keyring",
+			"aws":     map[string]interface{}{"access_key": "AKIA", "secret_key": "shh"},
+			"key":     "AKIA",
+			"secret":  "shh",
+			"github":  "ghp",
+			"plain":   "us-west-1",
+			"timeout": 30,
+			"nested": map[string]interface{}{
+				"tokens": []interface{}{"ghp_keyring", "literal"},
+			},
+		}, resolved)
+	}
+	// The original config is left alone
+	assert.Equal(t, "keyring:github-token", config["token"])
+
+	resolved, err = Resolve(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, resolved)
+}
+
+func TestResolve_Errors(t *testing.T) {
+	defer mockKeyring(t, nil)()
+	defer mockVault(t)()
+
+	_, err := Resolve(map[string]interface{}{"token": "keyring:missing"})
+	assert.EqualError(t, err, "token: could not read missing from the keyring: no secret was found for missing")
+
+	_, err = Resolve(map[string]interface{}{"token": "vault:secret/data/forbidden"})
+	assert.EqualError(t, err, "token: could not read secret/data/forbidden from Vault: 403 Forbidden: permission denied")
+
+	_, err = Resolve(map[string]interface{}{"token": "vault:kv/wash/github#missing"})
+	assert.EqualError(t, err, "token: the Vault secret kv/wash/github does not have a missing field")
+
+	os.Unsetenv("VAULT_ADDR")
+	_, err = Resolve(map[string]interface{}{"token": "vault:kv/wash/github"})
+	assert.EqualError(t, err, "token: could not read kv/wash/github from Vault: VAULT_ADDR is not set")
+}
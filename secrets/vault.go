@@ -0,0 +1,88 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type vaultClient struct {
+	addr      string
+	token     string
+	namespace string
+	client    *http.Client
+}
+
+func newVaultClient() (*vaultClient, error) {
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		return nil, fmt.Errorf("VAULT_ADDR is not set")
+	}
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadFile(filepath.Join(homeDir, ".vault-token"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, fmt.Errorf("VAULT_TOKEN is not set, and there's no ~/.vault-token")
+			}
+			return nil, err
+		}
+		token = strings.TrimSpace(string(content))
+	}
+	return &vaultClient{
+		addr:      strings.TrimSuffix(addr, "/"),
+		token:     token,
+		namespace: os.Getenv("VAULT_NAMESPACE"),
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// read returns the data of the secret at path. The data of KV version 2
+// secrets is nested in the response's data, so it's unwrapped.
+func (c *vaultClient) read(path string) (map[string]interface{}, error) {
+	req, err := http.NewRequest(http.MethodGet, c.addr+"/v1/"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", c.token)
+	if c.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Data   map[string]interface{} `json:"data"`
+		Errors []string               `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("could not decode the response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if len(body.Errors) > 0 {
+			return nil, fmt.Errorf("%v: %v", resp.Status, strings.Join(body.Errors, "; "))
+		}
+		return nil, fmt.Errorf("%v", resp.Status)
+	}
+	if data, ok := body.Data["data"].(map[string]interface{}); ok {
+		if _, ok := body.Data["metadata"]; ok {
+			return data, nil
+		}
+	}
+	if body.Data == nil {
+		return nil, fmt.Errorf("the secret has no data")
+	}
+	return body.Data, nil
+}
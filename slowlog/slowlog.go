@@ -0,0 +1,218 @@
+// Package slowlog records plugin method invocations and API requests that
+// take longer than a threshold. Each slow operation is appended as a JSON
+// line to a dedicated log, and the worst offenders are kept in memory so
+// that `wash server stats --slow` can report them.
+package slowlog
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Kinds of an Operation.
+const (
+	Method  = "method"
+	Request = "request"
+)
+
+// Cache statuses of an Operation. A method invocation is always a cache miss
+// for methods that Wash caches (List, Read and Metadata), and Uncached for the
+// other methods. A request is a cache Hit if it didn't invoke any methods.
+const (
+	Hit      = "hit"
+	Miss     = "miss"
+	Uncached = "uncached"
+)
+
+// Operation describes a slow operation.
+type Operation struct {
+	Time time.Time `json:"time"`
+	Kind string    `json:"kind"`
+	// Plugin is only set for method invocations.
+	Plugin string `json:"plugin,omitempty"`
+	Path   string `json:"path"`
+	// Method is the plugin method's name, or the request's HTTP method and
+	// URL path, e.g. "GET /fs/list".
+	Method   string        `json:"method"`
+	Duration time.Duration `json:"duration"`
+	Cache    string        `json:"cache"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Offender summarizes the slow operations of a path and method.
+type Offender struct {
+	Kind          string        `json:"kind"`
+	Plugin        string        `json:"plugin,omitempty"`
+	Path          string        `json:"path"`
+	Method        string        `json:"method"`
+	Count         uint64        `json:"count"`
+	TotalDuration time.Duration `json:"total_duration"`
+	MaxDuration   time.Duration `json:"max_duration"`
+	// Last is the most recent slow operation.
+	Last Operation `json:"last"`
+}
+
+// maxOffenders bounds the memory that's used to track the offenders. The
+// offender with the smallest max duration is forgotten once it's exceeded.
+const maxOffenders = 1000
+
+type offenderKey struct {
+	kind, path, method string
+}
+
+type slowLog struct {
+	mux       sync.Mutex
+	file      *os.File
+	threshold time.Duration
+	offenders map[offenderKey]*Offender
+}
+
+var slow *slowLog
+
+// Init records operations that take at least threshold to the log at path,
+// creating it if necessary. Operations are only tracked in memory if path is
+// empty, and aren't tracked at all until Init's called with a positive
+// threshold.
+func Init(path string, threshold time.Duration) error {
+	if threshold <= 0 {
+		return nil
+	}
+	s := &slowLog{threshold: threshold, offenders: make(map[offenderKey]*Offender)}
+	if path != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+		if err != nil {
+			return err
+		}
+		s.file = file
+	}
+	slow = s
+	return nil
+}
+
+// Close closes the slow log.
+func Close() {
+	if s := slow; s != nil {
+		s.mux.Lock()
+		defer s.mux.Unlock()
+		if s.file != nil {
+			if err := s.file.Close(); err != nil {
+				log.Warnf("Failed to close the slow log: %v", err)
+			}
+		}
+		slow = nil
+	}
+}
+
+// Record records op if it took at least the threshold.
+func Record(op Operation) {
+	s := slow
+	if s == nil || op.Duration < s.threshold {
+		return
+	}
+	if op.Time.IsZero() {
+		op.Time = time.Now()
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.file != nil {
+		data, err := json.Marshal(op)
+		if err == nil {
+			data = append(data, '\n')
+			_, err = s.file.Write(data)
+		}
+		if err != nil {
+			log.Warnf("Failed to record a slow operation: %v", err)
+		}
+	}
+
+	key := offenderKey{kind: op.Kind, path: op.Path, method: op.Method}
+	offender, ok := s.offenders[key]
+	if !ok {
+		if len(s.offenders) >= maxOffenders {
+			s.forgetFastestOffender()
+		}
+		offender = &Offender{Kind: op.Kind, Plugin: op.Plugin, Path: op.Path, Method: op.Method}
+		s.offenders[key] = offender
+	}
+	offender.Count++
+	offender.TotalDuration += op.Duration
+	if op.Duration > offender.MaxDuration {
+		offender.MaxDuration = op.Duration
+	}
+	offender.Last = op
+}
+
+func (s *slowLog) forgetFastestOffender() {
+	var fastest offenderKey
+	var fastestDuration time.Duration = -1
+	for key, offender := range s.offenders {
+		if fastestDuration < 0 || offender.MaxDuration < fastestDuration {
+			fastest, fastestDuration = key, offender.MaxDuration
+		}
+	}
+	delete(s.offenders, fastest)
+}
+
+// TopOffenders returns up to n of the offenders with the longest max
+// duration, slowest first. n <= 0 returns all of them.
+func TopOffenders(n int) []Offender {
+	s := slow
+	if s == nil {
+		return []Offender{}
+	}
+	s.mux.Lock()
+	offenders := make([]Offender, 0, len(s.offenders))
+	for _, offender := range s.offenders {
+		offenders = append(offenders, *offender)
+	}
+	s.mux.Unlock()
+
+	sort.Slice(offenders, func(i, j int) bool {
+		if offenders[i].MaxDuration != offenders[j].MaxDuration {
+			return offenders[i].MaxDuration > offenders[j].MaxDuration
+		}
+		return offenders[i].Path < offenders[j].Path
+	})
+	if n > 0 && len(offenders) > n {
+		offenders = offenders[:n]
+	}
+	return offenders
+}
+
+type key int
+
+const invocationsKey key = iota
+
+// WithInvocationCounter returns a context that counts the methods invoked
+// with it, which determines a request's cache status.
+func WithInvocationCounter(ctx context.Context) context.Context {
+	return context.WithValue(ctx, invocationsKey, new(int32))
+}
+
+// CountInvocation counts a method invocation in ctx's counter, if it has one.
+func CountInvocation(ctx context.Context) {
+	if counter, ok := ctx.Value(invocationsKey).(*int32); ok {
+		atomic.AddInt32(counter, 1)
+	}
+}
+
+// CacheStatus returns Hit if no methods were invoked with ctx, and Miss
+// otherwise.
+func CacheStatus(ctx context.Context) string {
+	if counter, ok := ctx.Value(invocationsKey).(*int32); ok && atomic.LoadInt32(counter) > 0 {
+		return Miss
+	}
+	return Hit
+}
@@ -0,0 +1,97 @@
+package slowlog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecord(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wash-slowlog")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "slow.log")
+	if !assert.NoError(t, Init(path, time.Second)) {
+		return
+	}
+	defer Close()
+
+	Record(Operation{Kind: Method, Plugin: "docker", Path: "/docker/containers", Method: "List", Duration: 2 * time.Second, Cache: Miss})
+	Record(Operation{Kind: Method, Plugin: "docker", Path: "/docker/containers", Method: "List", Duration: 4 * time.Second, Cache: Miss})
+	Record(Operation{Kind: Request, Path: "/aws", Method: "GET /fs/list", Duration: 3 * time.Second, Cache: Hit})
+	// Fast operations are ignored
+	Record(Operation{Kind: Method, Plugin: "aws", Path: "/aws", Method: "List", Duration: time.Millisecond, Cache: Miss})
+
+	offenders := TopOffenders(0)
+	if assert.Len(t, offenders, 2) {
+		assert.Equal(t, "/docker/containers", offenders[0].Path)
+		assert.Equal(t, uint64(2), offenders[0].Count)
+		assert.Equal(t, 4*time.Second, offenders[0].MaxDuration)
+		assert.Equal(t, 6*time.Second, offenders[0].TotalDuration)
+		assert.Equal(t, "GET /fs/list", offenders[1].Method)
+		assert.Equal(t, Hit, offenders[1].Last.Cache)
+	}
+	assert.Len(t, TopOffenders(1), 1)
+
+	f, err := os.Open(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer f.Close()
+	var ops []Operation
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var op Operation
+		if assert.NoError(t, json.Unmarshal(scanner.Bytes(), &op)) {
+			ops = append(ops, op)
+		}
+	}
+	if assert.Len(t, ops, 3) {
+		assert.Equal(t, "docker", ops[0].Plugin)
+		assert.False(t, ops[0].Time.IsZero())
+	}
+}
+
+func TestRecord_Disabled(t *testing.T) {
+	assert.NoError(t, Init("", 0))
+	Record(Operation{Kind: Method, Path: "/docker", Method: "List", Duration: time.Hour})
+	assert.Empty(t, TopOffenders(0))
+}
+
+func TestCacheStatus(t *testing.T) {
+	ctx := WithInvocationCounter(context.Background())
+	assert.Equal(t, Hit, CacheStatus(ctx))
+	CountInvocation(ctx)
+	assert.Equal(t, Miss, CacheStatus(ctx))
+
+	// Contexts without a counter are ignored
+	CountInvocation(context.Background())
+	assert.Equal(t, Hit, CacheStatus(context.Background()))
+}
+
+func TestForgetFastestOffender(t *testing.T) {
+	if !assert.NoError(t, Init("", time.Second)) {
+		return
+	}
+	defer Close()
+	for i := 0; i < maxOffenders; i++ {
+		Record(Operation{Kind: Method, Path: fmt.Sprintf("/p/%v", i), Method: "List", Duration: time.Second + time.Duration(i)})
+	}
+	Record(Operation{Kind: Method, Path: "/slowest", Method: "List", Duration: time.Hour})
+	offenders := TopOffenders(0)
+	assert.Len(t, offenders, maxOffenders)
+	assert.Equal(t, "/slowest", offenders[0].Path)
+	// The fastest offender was forgotten
+	assert.Equal(t, time.Second+1, offenders[len(offenders)-1].MaxDuration)
+}
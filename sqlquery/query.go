@@ -0,0 +1,88 @@
+// Package sqlquery runs queries against database/sql databases for the
+// plugins that let you exec SQL on a database, like the mysql and postgres
+// plugins.
+package sqlquery
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// Run runs the query on db, streaming the results to the returned command's
+// stdout as tab-separated rows. The first row has the column names, and NULL
+// values are printed as NULL.
+func Run(ctx context.Context, db *sql.DB, query string) plugin.ExecCommand {
+	execCmd := plugin.NewExecCommand(ctx)
+	go func() {
+		err := runQuery(ctx, db, query, execCmd.Stdout())
+		if err != nil {
+			fmt.Fprintln(execCmd.Stderr(), err)
+		}
+		execCmd.CloseStreamsWithError(nil)
+		if err != nil {
+			execCmd.SetExitCode(1)
+		} else {
+			execCmd.SetExitCode(0)
+		}
+	}()
+	return execCmd
+}
+
+func runQuery(ctx context.Context, db *sql.DB, query string, w io.Writer) error {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	return writeRows(rows, w)
+}
+
+// resultRows is the part of *sql.Rows that writeRows uses.
+type resultRows interface {
+	Columns() ([]string, error)
+	Next() bool
+	Scan(dest ...interface{}) error
+	Err() error
+}
+
+func writeRows(rows resultRows, w io.Writer) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		// The statement didn't return any rows (e.g. an INSERT).
+		return rows.Err()
+	}
+	if _, err := fmt.Fprintln(w, strings.Join(columns, "\t")); err != nil {
+		return err
+	}
+
+	values := make([]sql.RawBytes, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	fields := make([]string, len(columns))
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		for i, value := range values {
+			if value == nil {
+				fields[i] = "NULL"
+			} else {
+				fields[i] = string(value)
+			}
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(fields, "\t")); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,70 @@
+package sqlquery
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockRows returns rows of raw values. A nil value is NULL.
+type mockRows struct {
+	columns []string
+	rows    [][]sql.RawBytes
+	next    int
+	err     error
+}
+
+func (r *mockRows) Columns() ([]string, error) {
+	return r.columns, nil
+}
+
+func (r *mockRows) Next() bool {
+	r.next++
+	return r.next <= len(r.rows)
+}
+
+func (r *mockRows) Scan(dest ...interface{}) error {
+	for i, value := range r.rows[r.next-1] {
+		*dest[i].(*sql.RawBytes) = value
+	}
+	return nil
+}
+
+func (r *mockRows) Err() error {
+	return r.err
+}
+
+func TestWriteRows(t *testing.T) {
+	rows := &mockRows{
+		columns: []string{"id", "name"},
+		rows: [][]sql.RawBytes{
+			{sql.RawBytes("1"), sql.RawBytes("foo")},
+			{sql.RawBytes("2"), nil},
+		},
+	}
+	var buf bytes.Buffer
+	if assert.NoError(t, writeRows(rows, &buf)) {
+		assert.Equal(t, "id\tname\n1\tfoo\n2\tNULL\n", buf.String())
+	}
+}
+
+func TestWriteRows_NoColumns(t *testing.T) {
+	var buf bytes.Buffer
+	if assert.NoError(t, writeRows(&mockRows{}, &buf)) {
+		assert.Empty(t, buf.String())
+	}
+}
+
+func TestWriteRows_ReturnsRowsErr(t *testing.T) {
+	rows := &mockRows{
+		columns: []string{"id"},
+		rows:    [][]sql.RawBytes{{sql.RawBytes("1")}},
+		err:     fmt.Errorf("connection reset"),
+	}
+	var buf bytes.Buffer
+	assert.EqualError(t, writeRows(rows, &buf), "connection reset")
+	assert.Equal(t, "id\n1\n", buf.String())
+}
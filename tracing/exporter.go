@@ -0,0 +1,239 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// Spans are exported in batches of this size, or every flushInterval.
+	batchSize     = 256
+	flushInterval = 5 * time.Second
+	// Spans are dropped if this many are waiting to be exported, which can
+	// happen if the collector is unavailable.
+	maxQueuedSpans = 4 * batchSize
+)
+
+// exporter exports spans in batches using OTLP's JSON encoding over HTTP. See
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/docs/specification.md.
+type exporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+
+	mux     sync.Mutex
+	spans   []*Span
+	flushCh chan struct{}
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+}
+
+func newExporter(config Config) *exporter {
+	url := strings.TrimSuffix(config.Endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	e := &exporter{
+		url:         url,
+		serviceName: config.ServiceName,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		flushCh:     make(chan struct{}, 1),
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+	go e.loop()
+	return e
+}
+
+func (e *exporter) add(span *Span) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	if len(e.spans) >= maxQueuedSpans {
+		log.Debugf("tracing: dropping span %v because the export queue is full", span.name)
+		return
+	}
+	e.spans = append(e.spans, span)
+	if len(e.spans) >= batchSize {
+		select {
+		case e.flushCh <- struct{}{}:
+		default:
+			// A flush is already pending
+		}
+	}
+}
+
+func (e *exporter) loop() {
+	defer close(e.doneCh)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.flush()
+		case <-e.flushCh:
+			e.flush()
+		case <-e.stopCh:
+			e.flush()
+			return
+		}
+	}
+}
+
+func (e *exporter) shutdown() {
+	close(e.stopCh)
+	<-e.doneCh
+}
+
+func (e *exporter) flush() {
+	e.mux.Lock()
+	spans := e.spans
+	e.spans = nil
+	e.mux.Unlock()
+
+	for len(spans) > 0 {
+		n := batchSize
+		if len(spans) < n {
+			n = len(spans)
+		}
+		e.export(spans[:n])
+		spans = spans[n:]
+	}
+}
+
+func (e *exporter) export(spans []*Span) {
+	body, err := json.Marshal(e.request(spans))
+	if err != nil {
+		log.Warnf("tracing: could not marshal spans: %v", err)
+		return
+	}
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Debugf("tracing: could not export %v spans to %v: %v", len(spans), e.url, err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		log.Debugf("tracing: exporting %v spans to %v failed: %v", len(spans), e.url, resp.Status)
+	}
+}
+
+// The following types are OTLP's JSON encoding of an ExportTraceServiceRequest.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	spanKindInternal = 1
+	statusCodeUnset  = 0
+	statusCodeError  = 2
+)
+
+func (e *exporter) request(spans []*Span) otlpRequest {
+	otlpSpans := make([]otlpSpan, len(spans))
+	for i, span := range spans {
+		otlpSpans[i] = toOTLPSpan(span)
+	}
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: toOTLPAttributes([]Attribute{String("service.name", e.serviceName)}),
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/puppetlabs/wash"},
+				Spans: otlpSpans,
+			}},
+		}},
+	}
+}
+
+func toOTLPSpan(span *Span) otlpSpan {
+	span.mux.Lock()
+	defer span.mux.Unlock()
+
+	s := otlpSpan{
+		TraceID:           hex.EncodeToString(span.traceID[:]),
+		SpanID:            hex.EncodeToString(span.spanID[:]),
+		Name:              span.name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+		Attributes:        toOTLPAttributes(span.attributes),
+		Status:            otlpStatus{Code: statusCodeUnset},
+	}
+	if span.parentID != [8]byte{} {
+		s.ParentSpanID = hex.EncodeToString(span.parentID[:])
+	}
+	if span.err != nil {
+		s.Status = otlpStatus{Code: statusCodeError, Message: span.err.Error()}
+	}
+	return s
+}
+
+func toOTLPAttributes(attributes []Attribute) []otlpAttribute {
+	var otlpAttributes []otlpAttribute
+	for _, attribute := range attributes {
+		var value map[string]interface{}
+		switch v := attribute.Value.(type) {
+		case string:
+			value = map[string]interface{}{"stringValue": v}
+		case int64:
+			// OTLP's JSON encoding represents 64-bit integers as strings
+			value = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case bool:
+			value = map[string]interface{}{"boolValue": v}
+		default:
+			continue
+		}
+		otlpAttributes = append(otlpAttributes, otlpAttribute{Key: attribute.Key, Value: value})
+	}
+	return otlpAttributes
+}
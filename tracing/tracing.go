@@ -0,0 +1,180 @@
+// Package tracing records spans for the Wash server's API, FUSE and plugin
+// layers, and exports them to an OpenTelemetry collector over OTLP/HTTP. It
+// implements just enough of OpenTelemetry's data model and OTLP's JSON
+// encoding for Wash, similar to how the analytics package speaks Google
+// Analytics' measurement protocol.
+//
+// Tracing is disabled until Init is called with an endpoint. All of the
+// functions and Span methods are safe to use when it's disabled, so callers
+// don't need to check.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Config configures tracing.
+type Config struct {
+	// Endpoint is the OTLP/HTTP endpoint that spans are exported to, e.g.
+	// http://localhost:4318. Spans are posted to its /v1/traces path unless it
+	// already ends with it. Tracing is disabled if it's empty.
+	Endpoint string
+	// ServiceName identifies the exporting process. It defaults to "wash".
+	ServiceName string
+}
+
+// expMux guards exp. Spans are added to exp while holding a read lock, so
+// Shutdown can't stop exp while a span is being added to it.
+var (
+	expMux sync.RWMutex
+	exp    *exporter
+)
+
+// Init starts exporting spans. It should be called once, before any spans are
+// started.
+func Init(config Config) {
+	if config.Endpoint == "" {
+		return
+	}
+	if config.ServiceName == "" {
+		config.ServiceName = "wash"
+	}
+	expMux.Lock()
+	defer expMux.Unlock()
+	exp = newExporter(config)
+}
+
+// Shutdown exports any remaining spans, then stops exporting them.
+func Shutdown() {
+	expMux.Lock()
+	e := exp
+	exp = nil
+	expMux.Unlock()
+	if e != nil {
+		e.shutdown()
+	}
+}
+
+func enabled() bool {
+	expMux.RLock()
+	defer expMux.RUnlock()
+	return exp != nil
+}
+
+// Attribute is a key-value pair that describes a span. Use String, Int or
+// Bool to create them.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string attribute.
+func String(key string, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an integer attribute.
+func Int(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span represents a single operation, such as an API request or a plugin
+// method invocation. A nil *Span is a no-op, which is what Start returns when
+// tracing is disabled.
+type Span struct {
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte
+	name     string
+	start    time.Time
+	end      time.Time
+
+	mux        sync.Mutex
+	attributes []Attribute
+	err        error
+}
+
+type spanKey struct{}
+
+// Start starts a span that's a child of the span in ctx, if any. It returns a
+// context containing the new span; pass it on so that nested operations are
+// recorded as the span's children. Callers must End the span.
+func Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, *Span) {
+	if !enabled() {
+		return ctx, nil
+	}
+
+	span := &Span{name: name, start: time.Now(), attributes: attributes}
+	if parent, ok := ctx.Value(spanKey{}).(*Span); ok && parent != nil {
+		span.traceID = parent.traceID
+		span.parentID = parent.spanID
+	} else {
+		randomID(span.traceID[:])
+	}
+	randomID(span.spanID[:])
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func randomID(id []byte) {
+	if _, err := rand.Read(id); err != nil {
+		// crypto/rand only fails if the OS's entropy source is unavailable
+		panic(fmt.Sprintf("tracing: could not generate an ID: %v", err))
+	}
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.attributes = append(s.attributes, attributes...)
+}
+
+// RecordError marks the span as failed with err. Nil errors are ignored.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.err = err
+}
+
+// End ends the span and queues it for export.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mux.Lock()
+	s.end = time.Now()
+	s.mux.Unlock()
+	expMux.RLock()
+	defer expMux.RUnlock()
+	if exp != nil {
+		exp.add(s)
+	}
+}
+
+// TraceParent returns the W3C traceparent (https://www.w3.org/TR/trace-context)
+// of the span in ctx, or an empty string if there isn't one. It's passed to
+// external plugins so that they can report their own spans as part of the
+// trace.
+func TraceParent(ctx context.Context) string {
+	span, ok := ctx.Value(spanKey{}).(*Span)
+	if !ok || span == nil {
+		return ""
+	}
+	return "00-" + hex.EncodeToString(span.traceID[:]) + "-" + hex.EncodeToString(span.spanID[:]) + "-01"
+}
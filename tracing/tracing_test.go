@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStart_Disabled(t *testing.T) {
+	ctx, span := Start(context.Background(), "test")
+	assert.Nil(t, span)
+	assert.Empty(t, TraceParent(ctx))
+
+	// Nil spans are no-ops
+	span.SetAttributes(String("key", "value"))
+	span.RecordError(errors.New("failed"))
+	span.End()
+}
+
+func TestExport(t *testing.T) {
+	requests := make(chan otlpRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var req otlpRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests <- req
+	}))
+	defer server.Close()
+
+	Init(Config{Endpoint: server.URL})
+	ctx, parent := Start(context.Background(), "API: GET /fs/list", String("path", "/docker"))
+	childCtx, child := Start(ctx, "plugin: List", Int("entries", 2))
+	child.RecordError(errors.New("failed"))
+	child.End()
+	parent.SetAttributes(Bool("cached", false))
+	parent.End()
+
+	traceParent := TraceParent(childCtx)
+	assert.Regexp(t, "^00-[0-9a-f]{32}-[0-9a-f]{16}-01$", traceParent)
+	Shutdown()
+
+	req := <-requests
+	if !assert.Len(t, req.ResourceSpans, 1) || !assert.Len(t, req.ResourceSpans[0].ScopeSpans, 1) {
+		return
+	}
+	assert.Equal(t, "service.name", req.ResourceSpans[0].Resource.Attributes[0].Key)
+	assert.Equal(t, "wash", req.ResourceSpans[0].Resource.Attributes[0].Value["stringValue"])
+
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	if !assert.Len(t, spans, 2) {
+		return
+	}
+	childSpan, parentSpan := spans[0], spans[1]
+	assert.Equal(t, "plugin: List", childSpan.Name)
+	assert.Equal(t, parentSpan.TraceID, childSpan.TraceID)
+	assert.Equal(t, parentSpan.SpanID, childSpan.ParentSpanID)
+	assert.Equal(t, "00-"+childSpan.TraceID+"-"+childSpan.SpanID+"-01", traceParent)
+	assert.Equal(t, otlpStatus{Code: statusCodeError, Message: "failed"}, childSpan.Status)
+	assert.Equal(t, []otlpAttribute{{Key: "entries", Value: map[string]interface{}{"intValue": "2"}}}, childSpan.Attributes)
+
+	assert.Equal(t, "API: GET /fs/list", parentSpan.Name)
+	assert.Empty(t, parentSpan.ParentSpanID)
+	assert.Equal(t, otlpStatus{Code: statusCodeUnset}, parentSpan.Status)
+	assert.Equal(t, []otlpAttribute{
+		{Key: "path", Value: map[string]interface{}{"stringValue": "/docker"}},
+		{Key: "cached", Value: map[string]interface{}{"boolValue": false}},
+	}, parentSpan.Attributes)
+}
+
+func TestShutdown_ConcurrentEnd(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	Init(Config{Endpoint: server.URL})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		_, span := Start(context.Background(), "test")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			span.End()
+		}()
+	}
+	Shutdown()
+	wg.Wait()
+
+	_, span := Start(context.Background(), "test")
+	assert.Nil(t, span)
+}
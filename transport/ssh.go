@@ -214,6 +214,23 @@ type Identity struct {
 	Port    uint `json:"port"`
 }
 
+// ConnectSSH returns an SSH connection to the target. It looks up configuration the same way
+// as ExecSSH. Connections are cached and shared, so callers should not close them.
+func ConnectSSH(ctx context.Context, id Identity) (*ssh.Client, error) {
+	// find port, username, etc from .ssh/config
+	conf, err := getConnInfo(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get connection info: %s", err)
+	}
+	activity.Record(ctx, "Found connection info %+v", conf)
+
+	connection, err := sshConnect(ctx, conf, id.Retries)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to connect: %s", err)
+	}
+	return connection, nil
+}
+
 // ExecSSH executes against a target via SSH. It will look up port, user, and other configuration
 // by exact hostname match from default SSH config files. Identity can be used to override the
 // user configured in SSH config. If opts.Elevate is true, will attempt to `sudo` as root.
@@ -228,16 +245,9 @@ type Identity struct {
 //   Host *.compute.amazonaws.com
 //     StrictHostKeyChecking no
 func ExecSSH(ctx context.Context, id Identity, cmd []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
-	// find port, username, etc from .ssh/config
-	conf, err := getConnInfo(ctx, id)
+	connection, err := ConnectSSH(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to get connection info: %s", err)
-	}
-	activity.Record(ctx, "Found connection info %+v", conf)
-
-	connection, err := sshConnect(ctx, conf, id.Retries)
-	if err != nil {
-		return nil, fmt.Errorf("Failed to connect: %s", err)
+		return nil, err
 	}
 
 	// Run command via session
@@ -8,6 +8,7 @@ package volume
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -39,6 +40,24 @@ type Interface interface {
 	VolumeDelete(ctx context.Context, path string) (bool, error)
 }
 
+// Copier is implemented by volumes that can copy a file to another path in the
+// volume without transferring its content, e.g. by running cp. Files in volumes
+// that don't implement it are copied by reading them then writing their content
+// to the destination.
+type Copier interface {
+	// Copies the file at src to dst, replacing dst if it exists.
+	VolumeCopy(ctx context.Context, src string, dst string) error
+}
+
+// AttrSetter is implemented by volumes that can change a node's mode, atime and
+// mtime. Files and directories in volumes that don't implement it can't have
+// their attributes set.
+type AttrSetter interface {
+	// Sets the mode, atime and mtime in attr on the node at path. Attributes
+	// that aren't set in attr are left alone.
+	VolumeSetAttr(ctx context.Context, path string, attr plugin.EntryAttributes) error
+}
+
 // Children represents a directory's children. It is a map of <child_basename> => <child_attributes>.
 type Children = map[string]plugin.EntryAttributes
 
@@ -101,3 +120,97 @@ func deleteNode(ctx context.Context, impl Interface, path string, dirmap *dirMap
 	}
 	return
 }
+
+// copyNode copies the file at src to dst, then adds dst to the parent's children
+// in dirmap (if the parent's been explored) so that the copy's listed. dirmap can
+// be nil if the parent's children aren't stored in a dirmap.
+func copyNode(ctx context.Context, impl Interface, src string, attr plugin.EntryAttributes, dst string, dirmap *dirMap) error {
+	if copier, ok := impl.(Copier); ok {
+		if err := copier.VolumeCopy(ctx, src, dst); err != nil {
+			return err
+		}
+	} else {
+		content, err := impl.VolumeRead(ctx, src)
+		if err != nil {
+			return err
+		}
+		if err := impl.VolumeWrite(ctx, dst, content, writeMode(attr)); err != nil {
+			return err
+		}
+	}
+	if dirmap == nil {
+		return nil
+	}
+
+	dirmap.mux.Lock()
+	defer dirmap.mux.Unlock()
+	segments := strings.Split(dst, "/")
+	parentPath := strings.Join(segments[:len(segments)-1], "/")
+	if parentChildren := dirmap.mp[parentPath]; parentChildren != nil {
+		parentChildren[segments[len(segments)-1]] = attr
+	}
+	return nil
+}
+
+// writeMode returns the mode that's passed to VolumeWrite when a file's replaced.
+func writeMode(attr plugin.EntryAttributes) os.FileMode {
+	if attr.HasMode() {
+		return attr.Mode()
+	}
+	return os.FileMode(0640)
+}
+
+// setAttrNode sets the node's mode, atime and mtime, then updates them in the
+// parent's children in dirmap (if the parent's been explored) so that they're
+// listed. dirmap can be nil if the parent's children aren't stored in a dirmap.
+func setAttrNode(ctx context.Context, impl Interface, path string, attr plugin.EntryAttributes, dirmap *dirMap) error {
+	setter, ok := impl.(AttrSetter)
+	if !ok {
+		return fmt.Errorf("setting attributes is not supported on %v", plugin.ID(impl))
+	}
+	if err := setter.VolumeSetAttr(ctx, path, attr); err != nil {
+		return err
+	}
+	if dirmap == nil {
+		return nil
+	}
+
+	dirmap.mux.Lock()
+	defer dirmap.mux.Unlock()
+	segments := strings.Split(path, "/")
+	parentPath := strings.Join(segments[:len(segments)-1], "/")
+	basename := segments[len(segments)-1]
+	if current, ok := dirmap.mp[parentPath][basename]; ok {
+		if attr.HasMode() {
+			current.SetMode(current.Mode()&^os.ModePerm | attr.Mode())
+		}
+		if attr.HasAtime() {
+			current.SetAtime(attr.Atime())
+		}
+		if attr.HasMtime() {
+			current.SetMtime(attr.Mtime())
+		}
+		dirmap.mp[parentPath][basename] = current
+	}
+	return nil
+}
+
+// SetAttrCommand returns a POSIX command that sets the mode, atime and mtime in
+// attr on the node at path, e.g. for a VolumeSetAttr implementation that runs
+// it on a container.
+func SetAttrCommand(path string, attr plugin.EntryAttributes) []string {
+	var script []string
+	if attr.HasMode() {
+		script = append(script, fmt.Sprintf(`chmod %o "$1"`, attr.Mode().Perm()))
+	}
+	// touch -t interprets the time in the local timezone, so use UTC.
+	const touchLayout = "200601021504.05"
+	if attr.HasAtime() {
+		script = append(script, `TZ=UTC touch -a -c -t `+attr.Atime().UTC().Format(touchLayout)+` "$1"`)
+	}
+	if attr.HasMtime() {
+		script = append(script, `TZ=UTC touch -m -c -t `+attr.Mtime().UTC().Format(touchLayout)+` "$1"`)
+	}
+	// Pass path as an argument so that it doesn't need to be quoted.
+	return []string{"sh", "-c", strings.Join(script, " && "), "sh", path}
+}
@@ -3,7 +3,9 @@ package volume
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/puppetlabs/wash/plugin"
 	"github.com/stretchr/testify/suite"
@@ -69,6 +71,113 @@ func (s *coreTestSuite) TestDeleteNode_DeletedNode_UpdatesDirMap() {
 	}
 }
 
+func (s *coreTestSuite) TestCopyNode_ReadsAndWritesTheFile() {
+	ctx := context.Background()
+	mockImpl := &mockFileEntry{EntryBase: plugin.NewEntry("foo"), content: "hello"}
+	attr := plugin.EntryAttributes{}
+	attr.SetSize(5)
+	dirMap := &dirMap{
+		mp: map[string]Children{
+			"/bar": map[string]plugin.EntryAttributes{
+				"baz": attr,
+			},
+		},
+	}
+
+	err := copyNode(ctx, mockImpl, "/bar/baz", attr, "/bar/qux", dirMap)
+	if s.NoError(err) {
+		s.Equal("hello", mockImpl.content)
+		s.Equal(attr, dirMap.mp["/bar"]["qux"])
+	}
+}
+
+func (s *coreTestSuite) TestCopyNode_UsesCopier() {
+	ctx := context.Background()
+	mockImpl := &mockCopierEntry{mockDirEntry{EntryBase: plugin.NewEntry("foo")}}
+	mockImpl.On("VolumeCopy", ctx, "/bar/baz", "/qux").Return(nil)
+
+	err := copyNode(ctx, mockImpl, "/bar/baz", plugin.EntryAttributes{}, "/qux", nil)
+	if s.NoError(err) {
+		mockImpl.AssertExpectations(s.T())
+	}
+}
+
+func (s *coreTestSuite) TestCopyNode_ReturnsVolumeCopyError() {
+	ctx := context.Background()
+	mockImpl := &mockCopierEntry{mockDirEntry{EntryBase: plugin.NewEntry("foo")}}
+	dirMap := &dirMap{mp: map[string]Children{RootPath: map[string]plugin.EntryAttributes{}}}
+
+	expectedErr := fmt.Errorf("failed to copy")
+	mockImpl.On("VolumeCopy", ctx, "/bar", "/qux").Return(expectedErr)
+
+	err := copyNode(ctx, mockImpl, "/bar", plugin.EntryAttributes{}, "/qux", dirMap)
+	s.EqualError(err, expectedErr.Error())
+	s.NotContains(dirMap.mp[RootPath], "qux")
+}
+
+type mockCopierEntry struct {
+	mockDirEntry
+}
+
+func (m *mockCopierEntry) VolumeCopy(ctx context.Context, src string, dst string) error {
+	args := m.Called(ctx, src, dst)
+	return args.Error(0)
+}
+
+func (s *coreTestSuite) TestSetAttrNode_UnsupportedVolume_ReturnsError() {
+	ctx := context.Background()
+	mockImpl := &mockDirEntry{EntryBase: plugin.NewEntry("foo")}
+	mockImpl.SetTestID("/foo")
+
+	err := setAttrNode(ctx, mockImpl, "/bar", plugin.EntryAttributes{}, nil)
+	s.EqualError(err, "setting attributes is not supported on /foo")
+}
+
+func (s *coreTestSuite) TestSetAttrNode_UpdatesDirMap() {
+	ctx := context.Background()
+	mockImpl := &mockAttrSetterEntry{mockDirEntry{EntryBase: plugin.NewEntry("foo")}}
+	current := plugin.EntryAttributes{}
+	current.SetMode(os.ModeDir | 0755).SetSize(4096)
+	dirMap := &dirMap{
+		mp: map[string]Children{
+			"/bar": map[string]plugin.EntryAttributes{
+				"baz": current,
+			},
+		},
+	}
+
+	attr := plugin.EntryAttributes{}
+	attr.SetMode(0700).SetMtime(time.Now())
+	mockImpl.On("VolumeSetAttr", ctx, "/bar/baz", attr).Return(nil)
+
+	err := setAttrNode(ctx, mockImpl, "/bar/baz", attr, dirMap)
+	if s.NoError(err) {
+		mockImpl.AssertExpectations(s.T())
+		updated := dirMap.mp["/bar"]["baz"]
+		s.Equal(os.ModeDir|0700, updated.Mode())
+		s.Equal(attr.Mtime(), updated.Mtime())
+		s.Equal(uint64(4096), updated.Size())
+	}
+}
+
+func (s *coreTestSuite) TestSetAttrCommand() {
+	attr := plugin.EntryAttributes{}
+	attr.SetMode(0640).SetMtime(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	s.Equal(
+		[]string{"sh", "-c", `chmod 640 "$1" && TZ=UTC touch -m -c -t 202001020304.05 "$1"`, "sh", "/mnt/foo bar"},
+		SetAttrCommand("/mnt/foo bar", attr),
+	)
+}
+
+type mockAttrSetterEntry struct {
+	mockDirEntry
+}
+
+func (m *mockAttrSetterEntry) VolumeSetAttr(ctx context.Context, path string, attr plugin.EntryAttributes) error {
+	args := m.Called(ctx, path, attr)
+	return args.Error(0)
+}
+
 func TestCore(t *testing.T) {
 	suite.Run(t, new(coreTestSuite))
 }
@@ -77,6 +77,12 @@ func (v *dir) List(ctx context.Context) ([]plugin.Entry, error) {
 	return v.generateChildren(&dirMap{mp: dirmap}), nil
 }
 
+// SetAttr sets the directory's mode, atime and mtime. The volume must implement
+// AttrSetter.
+func (v *dir) SetAttr(ctx context.Context, attr plugin.EntryAttributes) error {
+	return setAttrNode(ctx, v.impl, v.path, attr, v.dirmap)
+}
+
 func (v *dir) Delete(ctx context.Context) (bool, error) {
 	return deleteNode(ctx, v.impl, v.path, v.dirmap)
 }
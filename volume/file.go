@@ -2,8 +2,8 @@ package volume
 
 import (
 	"context"
+	"fmt"
 	"io"
-	"os"
 	"time"
 
 	"github.com/puppetlabs/wash/plugin"
@@ -45,11 +45,24 @@ func (v *file) Stream(ctx context.Context) (io.ReadCloser, error) {
 
 func (v *file) Write(ctx context.Context, b []byte) error {
 	// Pass mode for Write operations that replace the file.
-	mode := os.FileMode(0640)
-	if v.Attributes().HasMode() {
-		mode = v.Attributes().Mode()
+	return v.impl.VolumeWrite(ctx, v.path, b, writeMode(v.Attributes()))
+}
+
+// Copy copies the file to a directory in the same volume.
+func (v *file) Copy(ctx context.Context, dstParent plugin.Parent, dstName string) error {
+	parentPath, dirmap := RootPath, (*dirMap)(nil)
+	if d, ok := dstParent.(*dir); ok && plugin.ID(d.impl) == plugin.ID(v.impl) {
+		parentPath, dirmap = d.path, d.dirmap
+	} else if plugin.ID(dstParent) != plugin.ID(v.impl) {
+		return fmt.Errorf("files can only be copied to a directory in the same volume, not %v", plugin.ID(dstParent))
 	}
-	return v.impl.VolumeWrite(ctx, v.path, b, mode)
+	return copyNode(ctx, v.impl, v.path, v.Attributes(), parentPath+"/"+dstName, dirmap)
+}
+
+// SetAttr sets the file's mode, atime and mtime. The volume must implement
+// AttrSetter.
+func (v *file) SetAttr(ctx context.Context, attr plugin.EntryAttributes) error {
+	return setAttrNode(ctx, v.impl, v.path, attr, v.dirmap)
 }
 
 func (v *file) Delete(ctx context.Context) (bool, error) {
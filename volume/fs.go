@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
@@ -255,6 +256,45 @@ func (d *FS) VolumeDelete(ctx context.Context, path string) (bool, error) {
 	return true, nil
 }
 
+// VolumeCopy satisfies the Copier interface required by Copy to copy files.
+func (d *FS) VolumeCopy(ctx context.Context, src string, dst string) error {
+	command := d.selectShellCommand(
+		[]string{"cp", src, dst},
+		[]string{"Copy-Item -Force '" + src + "' '" + dst + "'"},
+	)
+
+	// Skip tty because we don't need it, we ignore the output.
+	_, err := exec(ctx, d.executor, command, false)
+	if err != nil {
+		activity.Record(ctx, "Exec error running 'cp %v %v' in VolumeCopy: %v", src, dst, err)
+	}
+	return err
+}
+
+// VolumeSetAttr satisfies the AttrSetter interface required by SetAttr to set attributes.
+// Windows doesn't have POSIX modes, so only the atime and mtime can be set with PowerShell.
+func (d *FS) VolumeSetAttr(ctx context.Context, path string, attr plugin.EntryAttributes) error {
+	if d.loginShell() == plugin.PowerShell && attr.HasMode() {
+		return fmt.Errorf("cannot set the mode of %v: modes are not supported by PowerShell", path)
+	}
+
+	power := "$f = Get-Item -Force '" + path + "'"
+	if attr.HasAtime() {
+		power += "; $f.LastAccessTimeUtc = '" + attr.Atime().UTC().Format(time.RFC3339) + "'"
+	}
+	if attr.HasMtime() {
+		power += "; $f.LastWriteTimeUtc = '" + attr.Mtime().UTC().Format(time.RFC3339) + "'"
+	}
+	command := d.selectShellCommand(SetAttrCommand(path, attr), []string{power})
+
+	// Skip tty because we don't need it, we ignore the output.
+	_, err := exec(ctx, d.executor, command, false)
+	if err != nil {
+		activity.Record(ctx, "Exec error running %v in VolumeSetAttr: %v", command, err)
+	}
+	return err
+}
+
 // Selects between a posix and powershell command based on the entry's login shell.
 // Note that powershell commands are often a single string because they represent a PowerShell
 // expression, and it's easier to pass that as a string than try to correctly escape it as